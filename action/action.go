@@ -0,0 +1,174 @@
+// Package action parse, validate and apply the simple changes to a bug given
+// as words, like "label add bug" or "close", as used by git bug batch and by
+// the automation rules.
+package action
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+)
+
+// Action is a change to apply to a bug
+type Action struct {
+	Name string
+	Args []string
+}
+
+// Parse read an action from its words, the first one being its name
+func Parse(words []string) (Action, error) {
+	if len(words) == 0 {
+		return Action{}, fmt.Errorf("missing action")
+	}
+
+	a := Action{Name: words[0], Args: words[1:]}
+
+	return a, a.Validate()
+}
+
+// String format the action as words, double quotes grouping words
+func (a Action) String() string {
+	words := []string{a.Name}
+	for _, arg := range a.Args {
+		if strings.IndexFunc(arg, unicode.IsSpace) >= 0 {
+			arg = `"` + arg + `"`
+		}
+		words = append(words, arg)
+	}
+	return strings.Join(words, " ")
+}
+
+// Validate check that the action is known, with the right arguments
+func (a Action) Validate() error {
+	switch a.Name {
+	case "open", "close":
+		if len(a.Args) > 0 {
+			return fmt.Errorf("%s doesn't take arguments", a.Name)
+		}
+	case "comment", "title":
+		if len(a.Args) == 0 {
+			return fmt.Errorf("%s needs a text", a.Name)
+		}
+	case "label":
+		if len(a.Args) < 2 || a.Args[0] != "add" && a.Args[0] != "rm" {
+			return fmt.Errorf("expected \"label add <label>...\" or \"label rm <label>...\"")
+		}
+	case "assign":
+		if len(a.Args) != 1 {
+			return fmt.Errorf("assign needs a single user")
+		}
+	case "unassign":
+		if len(a.Args) > 0 {
+			return fmt.Errorf("unassign doesn't take arguments")
+		}
+	case "milestone":
+		if len(a.Args) > 1 {
+			return fmt.Errorf("milestone takes a single milestone, or none to clear it")
+		}
+	case "":
+		return fmt.Errorf("missing action")
+	default:
+		return fmt.Errorf("unknown action \"%s\"", a.Name)
+	}
+
+	return nil
+}
+
+// Apply add the operation of the action to a bug, without committing it. It
+// returns false if the action doesn't change the bug, like closing a closed
+// bug. The action has to be valid.
+func (a Action) Apply(backend *cache.RepoCache, b *cache.BugCache) (bool, error) {
+	snap := b.Snapshot()
+	text := strings.Join(a.Args, " ")
+
+	var err error
+
+	switch a.Name {
+	case "open":
+		if snap.Status == bug.OpenStatus {
+			return false, nil
+		}
+		_, err = b.Open()
+
+	case "close":
+		if snap.Status == bug.ClosedStatus {
+			return false, nil
+		}
+		_, err = b.Close()
+
+	case "comment":
+		_, err = b.AddComment(text)
+
+	case "title":
+		if snap.Title == text {
+			return false, nil
+		}
+		_, err = b.SetTitle(text)
+
+	case "label":
+		var added, removed []string
+		if a.Args[0] == "add" {
+			added = a.Args[1:]
+		} else {
+			removed = a.Args[1:]
+		}
+		var results []bug.LabelChangeResult
+		results, _, err = b.ChangeLabels(added, removed)
+		// only already set or missing labels
+		if err != nil && results != nil {
+			return false, nil
+		}
+
+	case "assign":
+		var assignee *cache.IdentityCache
+		assignee, err = backend.ResolveIdentityUser(a.Args[0])
+		if err != nil {
+			return false, err
+		}
+		if snap.Assignee == assignee.Id() {
+			return false, nil
+		}
+		_, err = b.SetAssignee(assignee)
+
+	case "unassign":
+		if snap.Assignee == "" {
+			return false, nil
+		}
+		_, err = b.SetAssignee(nil)
+
+	case "milestone":
+		if snap.Milestone == text {
+			return false, nil
+		}
+		_, err = b.SetMilestone(text)
+
+	default:
+		return false, fmt.Errorf("unknown action \"%s\"", a.Name)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// SplitWords split a line in words, double quotes grouping words
+func SplitWords(line string) []string {
+	inQuote := false
+	words := strings.FieldsFunc(line, func(c rune) bool {
+		if c == '"' {
+			inQuote = !inQuote
+		}
+		return !inQuote && unicode.IsSpace(c)
+	})
+
+	for i, word := range words {
+		words[i] = strings.Replace(word, `"`, "", -1)
+	}
+
+	return words
+}
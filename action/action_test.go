@@ -0,0 +1,84 @@
+package action
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestParse(t *testing.T) {
+	a, err := Parse(SplitWords(`comment "hello world"`))
+	require.NoError(t, err)
+	require.Equal(t, Action{Name: "comment", Args: []string{"hello world"}}, a)
+	require.Equal(t, `comment "hello world"`, a.String())
+
+	invalid := []string{
+		"",
+		"delete",
+		"close now",
+		"comment",
+		"label stale",
+		"label remove stale",
+		"assign rene isaac",
+		"unassign rene",
+		"milestone v1 v2",
+	}
+	for _, s := range invalid {
+		_, err := Parse(SplitWords(s))
+		require.Error(t, err, s)
+	}
+}
+
+func TestApply(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	backend, err := cache.NewRepoCache(repo)
+	require.NoError(t, err)
+	defer backend.Close()
+
+	rene, err := backend.NewIdentityRaw("René Descartes", "rene@descartes.fr", "rene", "", nil)
+	require.NoError(t, err)
+	require.NoError(t, backend.SetUserIdentity(rene))
+
+	b, _, err := backend.NewBug("title", "message")
+	require.NoError(t, err)
+
+	apply := func(line string) bool {
+		a, err := Parse(SplitWords(line))
+		require.NoError(t, err)
+		changed, err := a.Apply(backend, b)
+		require.NoError(t, err)
+		return changed
+	}
+
+	require.False(t, apply("open"))
+	require.True(t, apply("close"))
+	require.False(t, apply("close"))
+	require.True(t, apply("label add bug"))
+	require.False(t, apply("label add bug"))
+	require.False(t, apply("label rm wontfix"))
+	require.True(t, apply("assign rene"))
+	require.False(t, apply("assign me"))
+	require.True(t, apply("unassign"))
+	require.False(t, apply("title title"))
+	require.True(t, apply(`title "new title"`))
+	require.True(t, apply("milestone v1"))
+	require.True(t, apply("milestone"))
+	require.True(t, apply("comment hello"))
+	require.NoError(t, b.Commit())
+
+	snap := b.Snapshot()
+	require.Equal(t, bug.ClosedStatus, snap.Status)
+	require.Equal(t, []bug.Label{"bug"}, snap.Labels)
+	require.Equal(t, "new title", snap.Title)
+	require.Empty(t, snap.Milestone)
+	require.Len(t, snap.Comments, 2)
+
+	_, err = Action{Name: "assign", Args: []string{"nobody"}}.Apply(backend, b)
+	require.Error(t, err)
+}
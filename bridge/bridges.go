@@ -6,6 +6,7 @@ import (
 	_ "github.com/MichaelMure/git-bug/bridge/github"
 	_ "github.com/MichaelMure/git-bug/bridge/gitlab"
 	_ "github.com/MichaelMure/git-bug/bridge/launchpad"
+	_ "github.com/MichaelMure/git-bug/bridge/mock"
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/repository"
 )
@@ -15,6 +16,18 @@ func Targets() []string {
 	return core.Targets()
 }
 
+// AttachableTargets return the bridge targets whose accounts can be attached
+// to an identity
+func AttachableTargets() []string {
+	return core.AttachableTargets()
+}
+
+// LoginMetadataKey return the immutable metadata key recording the login of
+// the accounts of a bridge target
+func LoginMetadataKey(target string) (string, error) {
+	return core.LoginMetadataKey(target)
+}
+
 // Instantiate a new Bridge for a repo, from the given target and name
 func NewBridge(repo *cache.RepoCache, target string, name string) (*core.Bridge, error) {
 	return core.NewBridge(repo, target, name)
@@ -0,0 +1,67 @@
+// Package bridgetest hold the helpers of the bridge tests. It's only imported
+// by the tests, so that the testing package doesn't end up in the binary.
+package bridgetest
+
+import (
+	"net/http"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+)
+
+// NewRecorder install as http.DefaultTransport a core.Recorder using the
+// fixture testdata/<name>.json of the tested package. The fixture is replayed,
+// unless the GIT_BUG_RECORD environment variable is set, in which case the
+// requests reach the live remote API. When replaying, the test fails if the
+// fixture is not recorded, so that a missing fixture doesn't go unnoticed.
+//
+// The returned function must be called at the end of the test, typically with
+// defer. It restore the previous transport and, when recording, write the
+// fixture if the test succeeded.
+func NewRecorder(t *testing.T, name string) (*core.Recorder, func()) {
+	fixture := path.Join("testdata", name+".json")
+	recording := os.Getenv(core.RecordEnv) != ""
+
+	if !recording {
+		if _, err := os.Stat(fixture); os.IsNotExist(err) {
+			t.Fatalf("HTTP fixture %s missing, record it with %s=1", fixture, core.RecordEnv)
+		}
+	}
+
+	previous := http.DefaultTransport
+
+	r, err := core.NewRecorder(fixture, recording, previous)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	http.DefaultTransport = r
+
+	return r, func() {
+		http.DefaultTransport = previous
+
+		if recording && !t.Failed() {
+			if err := r.Save(); err != nil {
+				t.Error(err)
+			}
+		}
+	}
+}
+
+// Secret return the value of an environment variable holding a credential when
+// recording, or a placeholder when replaying as the remote is not reached. The
+// secret is never written in the fixture. The test is skipped if the variable
+// is missing when recording.
+func Secret(t *testing.T, r *core.Recorder, env string) string {
+	if !r.Recording() {
+		return "replayed-" + env
+	}
+
+	value := os.Getenv(env)
+	if value == "" {
+		t.Skipf("Env var %s missing", env)
+	}
+	return value
+}
@@ -0,0 +1,49 @@
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// AccountAttacher is an optional interface for a BridgeImpl whose accounts
+// can be attached to a local identity, for the importer to use it instead of
+// creating a new identity.
+type AccountAttacher interface {
+	// LoginMetadataKey return the immutable metadata key recording the
+	// login of the account on the identities
+	LoginMetadataKey() string
+}
+
+// AttachableTargets return the bridge targets whose accounts can be attached
+// to an identity
+func AttachableTargets() []string {
+	var result []string
+
+	for target, implType := range bridgeImpl {
+		impl := reflect.New(implType).Elem().Interface()
+		if _, ok := impl.(AccountAttacher); ok {
+			result = append(result, target)
+		}
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// LoginMetadataKey return the immutable metadata key recording the login of
+// the accounts of a bridge target
+func LoginMetadataKey(target string) (string, error) {
+	implType, ok := bridgeImpl[target]
+	if !ok {
+		return "", fmt.Errorf("unknown bridge target %v", target)
+	}
+
+	attacher, ok := reflect.New(implType).Elem().Interface().(AccountAttacher)
+	if !ok {
+		return "", fmt.Errorf("the accounts of %v can't be attached to an identity", target)
+	}
+
+	return attacher.LoginMetadataKey(), nil
+}
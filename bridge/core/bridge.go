@@ -12,7 +12,9 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
 	"github.com/MichaelMure/git-bug/repository"
 )
 
@@ -36,6 +38,9 @@ type BridgeParams struct {
 	URL        string
 	Token      string
 	TokenStdin bool
+
+	// LocalProject bind the bridge to a git-bug project, see KeyLocalProject
+	LocalProject string
 }
 
 // Bridge is a wrapper around a BridgeImpl that will bind low-level
@@ -198,6 +203,13 @@ func (b *Bridge) Configure(params BridgeParams) error {
 		return err
 	}
 
+	if params.LocalProject != "" {
+		if err := bug.ValidateProject(params.LocalProject); err != nil {
+			return err
+		}
+		conf[KeyLocalProject] = params.LocalProject
+	}
+
 	err = b.impl.ValidateConfig(conf)
 	if err != nil {
 		return fmt.Errorf("invalid configuration: %v", err)
@@ -249,6 +261,18 @@ func loadConfig(repo repository.RepoCommon, name string) (Configuration, error)
 	return result, nil
 }
 
+// SetErrorPolicy override the configured ErrorPolicy for this run only. It
+// need to be called before any import.
+func (b *Bridge) SetErrorPolicy(policy ErrorPolicy) error {
+	err := b.ensureConfig()
+	if err != nil {
+		return err
+	}
+
+	b.conf[KeyErrorPolicy] = policy.String()
+	return nil
+}
+
 func (b *Bridge) getImporter() Importer {
 	if b.importer == nil {
 		b.importer = b.impl.NewImporter()
@@ -307,7 +331,45 @@ func (b *Bridge) ImportAll(ctx context.Context, since time.Time) (<-chan ImportR
 		return nil, err
 	}
 
-	return importer.ImportAll(ctx, b.repo, since)
+	start := time.Now()
+
+	events, err := importer.ImportAll(ctx, b.repo, since)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan ImportResult)
+
+	// forward the events and record the time of a complete and successful import
+	go func() {
+		defer close(out)
+
+		failed := false
+		var created []entity.Id
+		for result := range events {
+			switch result.Event {
+			case ImportEventError:
+				failed = true
+			case ImportEventBug:
+				created = append(created, result.ID)
+			}
+			out <- result
+		}
+
+		// once the importer is done, to not race with it
+		if err := moveToLocalProject(b.repo, b.conf, created); err != nil {
+			failed = true
+			out <- NewImportError(err, "")
+		}
+
+		if !failed && ctx.Err() == nil {
+			if err := b.recordSync(keyLastImport, start); err != nil {
+				out <- NewImportError(err, "")
+			}
+		}
+	}()
+
+	return out, nil
 }
 
 func (b *Bridge) ExportAll(ctx context.Context, since time.Time) (<-chan ExportResult, error) {
@@ -326,5 +388,33 @@ func (b *Bridge) ExportAll(ctx context.Context, since time.Time) (<-chan ExportR
 		return nil, err
 	}
 
-	return exporter.ExportAll(ctx, b.repo, since)
+	start := time.Now()
+
+	events, err := exporter.ExportAll(ctx, b.repo, since)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan ExportResult)
+
+	// forward the events and record the time of a complete and successful export
+	go func() {
+		defer close(out)
+
+		failed := false
+		for result := range events {
+			if result.Event == ExportEventError {
+				failed = true
+			}
+			out <- result
+		}
+
+		if !failed && ctx.Err() == nil {
+			if err := b.recordSync(keyLastExport, start); err != nil {
+				out <- NewExportError(err, "")
+			}
+		}
+	}()
+
+	return out, nil
 }
@@ -0,0 +1,101 @@
+package core
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+// metadata keys holding an url are expected to use this suffix (e.g. "github-url")
+const urlMetadataSuffix = "-url"
+
+// CanonicalURL return a normalized form of an issue url, so that the same
+// upstream issue reached through different bridges or configurations can be
+// identified: scheme, "www.", query, fragment, trailing slash and gitlab's
+// "/-/" path separator are dropped, and the host is lower-cased.
+func CanonicalURL(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return strings.TrimSuffix(strings.TrimSpace(raw), "/")
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	p := strings.Replace(u.Path, "/-/", "/", -1)
+	p = strings.TrimSuffix(p, "/")
+
+	return host + p
+}
+
+// URLIndex index the local bugs by the canonical form of the urls found in
+// their creation metadata, whatever the bridge that set them. It's used by
+// importers to avoid creating two local bugs for the same upstream issue.
+type URLIndex struct {
+	repo *cache.RepoCache
+	ids  map[string]entity.Id
+}
+
+// NewURLIndex build an URLIndex for the bugs of the repo
+func NewURLIndex(repo *cache.RepoCache) (*URLIndex, error) {
+	index := &URLIndex{
+		repo: repo,
+		ids:  make(map[string]entity.Id),
+	}
+
+	for _, id := range repo.AllBugsIds() {
+		excerpt, err := repo.ResolveBugExcerpt(id)
+		if err != nil {
+			return nil, err
+		}
+
+		for key, value := range excerpt.CreateMetadata {
+			if strings.HasSuffix(key, urlMetadataSuffix) && value != "" {
+				index.ids[CanonicalURL(value)] = id
+			}
+		}
+	}
+
+	return index, nil
+}
+
+// Add register a bug under the given url
+func (ui *URLIndex) Add(url string, id entity.Id) {
+	ui.ids[CanonicalURL(url)] = id
+}
+
+// Reconcile look for a bug already imported from the same upstream issue. If
+// one is found, the given metadata are merged onto its creation operation so
+// that the calling bridge resolve it directly afterward. If there is no such
+// bug, bug.ErrBugNotExist is returned.
+func (ui *URLIndex) Reconcile(url string, author *cache.IdentityCache, unixTime int64, metadata map[string]string) (*cache.BugCache, error) {
+	id, ok := ui.ids[CanonicalURL(url)]
+	if !ok {
+		return nil, bug.ErrBugNotExist
+	}
+
+	b, err := ui.repo.ResolveBug(id)
+	if err != nil {
+		return nil, err
+	}
+
+	createOp := b.Snapshot().Operations[0]
+
+	// only add what's missing, the original metadata take precedence anyway
+	missing := make(map[string]string)
+	for key, value := range metadata {
+		if _, ok := createOp.GetMetadata(key); !ok {
+			missing[key] = value
+		}
+	}
+
+	if len(missing) > 0 {
+		_, err = b.SetMetadataRaw(author, unixTime, createOp.Id(), missing)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return b, nil
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestCanonicalURL(t *testing.T) {
+	tests := map[string]string{
+		"https://gitlab.com/group/project/issues/12":       "gitlab.com/group/project/issues/12",
+		"http://www.GitLab.com/group/project/-/issues/12/": "gitlab.com/group/project/issues/12",
+		"https://github.com/owner/project/issues/3#top":    "github.com/owner/project/issues/3",
+		"not an url": "not an url",
+	}
+
+	for raw, expected := range tests {
+		require.Equal(t, expected, CanonicalURL(raw), raw)
+	}
+}
+
+func TestURLIndexReconcile(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	backend, err := cache.NewRepoCache(repo)
+	require.NoError(t, err)
+	defer backend.Close()
+
+	author, err := backend.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+
+	imported, _, err := backend.NewBugRaw(author, time.Now().Unix(), "title", "message", nil,
+		map[string]string{
+			KeyOrigin:    "gitlab",
+			"gitlab-url": "https://gitlab.com/group/project/-/issues/12",
+		})
+	require.NoError(t, err)
+
+	index, err := NewURLIndex(backend)
+	require.NoError(t, err)
+
+	_, err = index.Reconcile("https://gitlab.com/group/project/issues/13", author, time.Now().Unix(), nil)
+	require.Equal(t, bug.ErrBugNotExist, err)
+
+	b, err := index.Reconcile("http://gitlab.com/group/project/issues/12", author, time.Now().Unix(),
+		map[string]string{
+			KeyOrigin:  "other",
+			"other-id": "42",
+		})
+	require.NoError(t, err)
+	require.Equal(t, imported.Id(), b.Id())
+
+	// the new metadata are merged, the original ones are kept
+	excerpt, err := backend.ResolveBugExcerpt(b.Id())
+	require.NoError(t, err)
+	require.Equal(t, "gitlab", excerpt.CreateMetadata[KeyOrigin])
+	require.Equal(t, "42", excerpt.CreateMetadata["other-id"])
+
+	resolved, err := backend.ResolveBugCreateMetadata("other-id", "42")
+	require.NoError(t, err)
+	require.Equal(t, imported.Id(), resolved.Id())
+}
@@ -0,0 +1,113 @@
+package core
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// ImportAllDryRun run the importer against a throwaway copy of the bugs and
+// identities of the repository. The events report what would be imported,
+// but nothing is ever committed in the real repository.
+func (b *Bridge) ImportAllDryRun(ctx context.Context, since time.Time) (<-chan ImportResult, error) {
+	err := b.ensureConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := ioutil.TempDir("", "git-bug-dry-run")
+	if err != nil {
+		return nil, err
+	}
+
+	throwaway, err := b.throwawayCache(dir)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+
+	cleanup := func() {
+		_ = throwaway.Close()
+		_ = os.RemoveAll(dir)
+	}
+
+	dryRun, err := NewBridge(throwaway, b.conf[KeyTarget], b.Name)
+	if err != nil {
+		cleanup()
+		return nil, err
+	}
+	dryRun.conf = b.conf
+
+	events, err := dryRun.ImportAll(ctx, since)
+	if err != nil {
+		cleanup()
+		return nil, err
+	}
+
+	out := make(chan ImportResult)
+
+	go func() {
+		defer close(out)
+		defer cleanup()
+
+		for result := range events {
+			out <- result
+		}
+	}()
+
+	return out, nil
+}
+
+// throwawayCache create a new repository in the given directory holding a
+// copy of the bugs, identities and identity mapping of the bridge repository.
+func (b *Bridge) throwawayCache(dir string) (*cache.RepoCache, error) {
+	source, err := filepath.Abs(b.repo.GetPath())
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := repository.InitGitRepo(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't create the throwaway repository")
+	}
+
+	// git need a committer identity, even for a throwaway repository
+	name, _ := b.repo.GetUserName()
+	email, _ := b.repo.GetUserEmail()
+	if name == "" || email == "" {
+		name, email = "git-bug", "git-bug@localhost"
+	}
+	for key, value := range map[string]string{"user.name": name, "user.email": email} {
+		err = repo.StoreConfig(key, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, refSpec := range []string{"refs/bugs/*:refs/bugs/*", "refs/identities/*:refs/identities/*"} {
+		_, err = repo.FetchRefs(source, refSpec)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't copy data in the throwaway repository")
+		}
+	}
+
+	identityMapping, err := LoadIdentityMapping(b.repo)
+	if err != nil {
+		return nil, err
+	}
+	if len(identityMapping) > 0 {
+		err = identityMapping.Store(repo)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return cache.NewRepoCache(repo)
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+type fakeImportBridge struct{}
+
+func (*fakeImportBridge) Target() string { return "fake-import" }
+func (*fakeImportBridge) Configure(repo repository.RepoCommon, params BridgeParams) (Configuration, error) {
+	return Configuration{KeyTarget: "fake-import"}, nil
+}
+func (*fakeImportBridge) ValidateConfig(conf Configuration) error { return nil }
+func (*fakeImportBridge) NewImporter() Importer                   { return &fakeImporter{} }
+func (*fakeImportBridge) NewExporter() Exporter                   { return nil }
+
+type fakeImporter struct{}
+
+func (*fakeImporter) Init(conf Configuration) error { return nil }
+
+func (*fakeImporter) ImportAll(ctx context.Context, repo *cache.RepoCache, since time.Time) (<-chan ImportResult, error) {
+	out := make(chan ImportResult)
+
+	go func() {
+		defer close(out)
+
+		author, err := repo.NewIdentityRaw("René Descartes", "rene@descartes.fr", "", "", nil)
+		if err != nil {
+			out <- NewImportError(err, "")
+			return
+		}
+		out <- NewImportIdentity(author.Id())
+
+		b, _, err := repo.NewBugRaw(author, time.Now().Unix(), "title", "message", nil, nil)
+		if err != nil {
+			out <- NewImportError(err, "")
+			return
+		}
+		if err := b.CommitAsNeeded(); err != nil {
+			out <- NewImportError(err, "")
+			return
+		}
+		out <- NewImportBug(b.Id())
+	}()
+
+	return out, nil
+}
+
+func TestImportAllDryRun(t *testing.T) {
+	Register(&fakeImportBridge{})
+
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	backend, err := cache.NewRepoCache(repo)
+	require.NoError(t, err)
+	defer backend.Close()
+
+	b, err := NewBridge(backend, "fake-import", "test")
+	require.NoError(t, err)
+	require.NoError(t, b.Configure(BridgeParams{}))
+
+	events, err := b.ImportAllDryRun(context.Background(), time.Time{})
+	require.NoError(t, err)
+
+	counts := make(map[ImportEvent]int)
+	for result := range events {
+		require.NoError(t, result.Err)
+		counts[result.Event]++
+	}
+
+	require.Equal(t, 1, counts[ImportEventBug])
+	require.Equal(t, 1, counts[ImportEventIdentity])
+
+	// nothing reached the real repository
+	require.Len(t, backend.AllBugsIds(), 0)
+	require.Len(t, backend.AllIdentityIds(), 0)
+
+	refs, err := repo.ListRefs("refs/bugs/")
+	require.NoError(t, err)
+	require.Len(t, refs, 0)
+}
@@ -0,0 +1,49 @@
+package core
+
+import "fmt"
+
+// KeyErrorPolicy is the configuration key holding the ErrorPolicy of a bridge
+const KeyErrorPolicy = "error-policy"
+
+// ErrorPolicy define how an importer react when an issue fails to import
+type ErrorPolicy int
+
+const (
+	// ErrorPolicyFailFast abort the whole import at the first error
+	ErrorPolicyFailFast ErrorPolicy = iota
+	// ErrorPolicySkip report the error and continue with the next issue
+	ErrorPolicySkip
+)
+
+func (ep ErrorPolicy) String() string {
+	switch ep {
+	case ErrorPolicyFailFast:
+		return "fail-fast"
+	case ErrorPolicySkip:
+		return "skip"
+	default:
+		return "unknown error policy"
+	}
+}
+
+// ErrorPolicyFromString parse an ErrorPolicy
+func ErrorPolicyFromString(str string) (ErrorPolicy, error) {
+	switch str {
+	case "fail-fast":
+		return ErrorPolicyFailFast, nil
+	case "skip":
+		return ErrorPolicySkip, nil
+	default:
+		return 0, fmt.Errorf("unknown error policy %s", str)
+	}
+}
+
+// NewErrorPolicy return the ErrorPolicy of the given configuration, defaulting
+// to ErrorPolicyFailFast
+func NewErrorPolicy(conf Configuration) (ErrorPolicy, error) {
+	raw, ok := conf[KeyErrorPolicy]
+	if !ok || raw == "" {
+		return ErrorPolicyFailFast, nil
+	}
+	return ErrorPolicyFromString(raw)
+}
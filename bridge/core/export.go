@@ -17,6 +17,8 @@ const (
 	ExportEventTitleEdition
 	ExportEventLabelChange
 	ExportEventNothing
+	ExportEventProgress
+	ExportEventRateLimiting
 	ExportEventError
 )
 
@@ -28,6 +30,10 @@ type ExportResult struct {
 	Event  ExportEvent
 	ID     entity.Id
 	Reason string
+
+	// progress, for ExportEventProgress
+	Current int
+	Total   int
 }
 
 func (er ExportResult) String() string {
@@ -49,6 +55,13 @@ func (er ExportResult) String() string {
 			return fmt.Sprintf("no actions taken for event %s: %s", er.ID, er.Reason)
 		}
 		return fmt.Sprintf("no actions taken: %s", er.Reason)
+	case ExportEventProgress:
+		if er.Total > 0 {
+			return fmt.Sprintf("progress: %d/%d issues", er.Current, er.Total)
+		}
+		return fmt.Sprintf("progress: %d issues", er.Current)
+	case ExportEventRateLimiting:
+		return fmt.Sprintf("rate limiting: %s", er.Reason)
 	case ExportEventError:
 		if er.ID != "" {
 			return fmt.Sprintf("export error at %s: %s", er.ID, er.Err.Error())
@@ -117,3 +130,22 @@ func NewExportTitleEdition(id entity.Id) ExportResult {
 		Event: ExportEventTitleEdition,
 	}
 }
+
+// NewExportProgress create an event reporting that current issues out of total
+// have been processed. If the total is unknown, it should be set to 0.
+func NewExportProgress(current int, total int) ExportResult {
+	return ExportResult{
+		Current: current,
+		Total:   total,
+		Event:   ExportEventProgress,
+	}
+}
+
+// NewExportRateLimiting create an event reporting that the exporter is waiting
+// for the remote API quota to be replenished.
+func NewExportRateLimiting(msg string) ExportResult {
+	return ExportResult{
+		Reason: msg,
+		Event:  ExportEventRateLimiting,
+	}
+}
@@ -0,0 +1,174 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path"
+	"time"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+const httpCacheDir = "bridge-cache"
+
+// HTTPCache is a http.RoundTripper storing on disk the responses to GET
+// requests, and revalidating them with the ETag and Last-Modified headers.
+// When the remote confirm that a resource didn't change (304), the stored
+// response is served instead, which usually doesn't consume API quota and
+// avoid transferring the data again.
+//
+// The cache is keyed by url and credentials, so that different accounts never
+// share responses.
+type HTTPCache struct {
+	// Transport is the underlying RoundTripper. If nil, http.DefaultTransport
+	// is used.
+	Transport http.RoundTripper
+
+	dir string
+}
+
+// NewHTTPCache create an HTTPCache storing its data in .git/git-bug/bridge-cache,
+// wrapping the given RoundTripper (or http.DefaultTransport if nil).
+func NewHTTPCache(repo repository.RepoCommon, transport http.RoundTripper) *HTTPCache {
+	return &HTTPCache{
+		Transport: transport,
+		dir:       path.Join(repo.GetPath(), "git-bug", httpCacheDir),
+	}
+}
+
+// NewCachedHTTPClient create a http.Client using an HTTPCache on top of a
+// RetryTransport
+func NewCachedHTTPClient(repo repository.RepoCommon, timeout time.Duration) *http.Client {
+	return &http.Client{
+		Transport: NewHTTPCache(repo, NewRetryTransport(nil)),
+		Timeout:   timeout,
+	}
+}
+
+func (hc *HTTPCache) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := hc.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		return transport.RoundTrip(req)
+	}
+
+	key := hc.key(req)
+	cached := hc.load(key, req)
+
+	current := req
+	if cached != nil {
+		current = new(http.Request)
+		*current = *req
+		current.Header = copyHeader(req.Header)
+
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			current.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := cached.Header.Get("Last-Modified"); lastModified != "" {
+			current.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := transport.RoundTrip(current)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		_ = resp.Body.Close()
+
+		// the 304 carry the up to date headers (rate limit ...)
+		for k, v := range resp.Header {
+			cached.Header[k] = v
+		}
+		return cached, nil
+	}
+
+	if resp.StatusCode != http.StatusOK ||
+		(resp.Header.Get("ETag") == "" && resp.Header.Get("Last-Modified") == "") {
+		return resp, nil
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	// failing to write the cache is not a reason to fail the request
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	_ = hc.store(key, resp)
+
+	// the dump consumed the body
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// key compute the cache key of a request, from its url and credentials
+func (hc *HTTPCache) key(req *http.Request) string {
+	h := sha256.New()
+	_, _ = h.Write([]byte(req.URL.String()))
+	for _, header := range []string{"Authorization", "Private-Token"} {
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(req.Header.Get(header)))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (hc *HTTPCache) load(key string, req *http.Request) *http.Response {
+	data, err := ioutil.ReadFile(path.Join(hc.dir, key))
+	if err != nil {
+		return nil
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), req)
+	if err != nil {
+		return nil
+	}
+
+	return resp
+}
+
+func (hc *HTTPCache) store(key string, resp *http.Response) error {
+	data, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(hc.dir, 0777)
+	if err != nil {
+		return err
+	}
+
+	// write then rename, to never leave a truncated entry behind
+	tmp := path.Join(hc.dir, key+".tmp")
+	err = ioutil.WriteFile(tmp, data, 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path.Join(hc.dir, key))
+}
+
+// copyHeader return a deep copy of a http.Header, as http.Header.Clone needs
+// go 1.13
+func copyHeader(h http.Header) http.Header {
+	if h == nil {
+		return nil
+	}
+
+	result := make(http.Header, len(h))
+	for k, v := range h {
+		result[k] = append([]string(nil), v...)
+	}
+	return result
+}
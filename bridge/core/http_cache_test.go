@@ -0,0 +1,56 @@
+package core
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestHTTPCache(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	version := 1
+	fullResponses := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag := fmt.Sprintf(`"v%d"`, version)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fullResponses++
+		w.Header().Set("ETag", etag)
+		_, _ = fmt.Fprintf(w, "content %d", version)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewHTTPCache(repo, nil)}
+
+	get := func() string {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		body, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return string(body)
+	}
+
+	require.Equal(t, "content 1", get())
+	require.Equal(t, 1, fullResponses)
+
+	// unchanged, served from the cache
+	require.Equal(t, "content 1", get())
+	require.Equal(t, 1, fullResponses)
+
+	// changed, fetched again
+	version = 2
+	require.Equal(t, "content 2", get())
+	require.Equal(t, 2, fullResponses)
+}
@@ -0,0 +1,237 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"sync"
+)
+
+// RecordEnv is the environment variable enabling the recording of the HTTP
+// fixtures of the bridge tests against the live remote APIs
+const RecordEnv = "GIT_BUG_RECORD"
+
+// the response headers never written in a fixture
+var recorderSkippedHeaders = []string{"Set-Cookie", "Date"}
+
+// Recorder is a http.RoundTripper recording the exchanges with a remote API in
+// a fixture file, or replaying them from this file without reaching the
+// network, so that the bridge tests can run offline and deterministically.
+//
+// A request is replayed with the first unused recorded response having the
+// same method, url and body. If none exist, as the body of a request can
+// change from a run to another, the first unused response with the same method
+// and url is used. The request headers are never recorded, so the credentials
+// don't end up in the fixtures.
+type Recorder struct {
+	// Transport is the underlying RoundTripper used when recording. If nil,
+	// http.DefaultTransport is used.
+	Transport http.RoundTripper
+
+	path      string
+	recording bool
+
+	mu      sync.Mutex
+	fixture httpFixture
+	used    []bool
+}
+
+type httpFixture struct {
+	Values       map[string]string `json:"values,omitempty"`
+	Interactions []httpInteraction `json:"interactions"`
+}
+
+type httpInteraction struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+
+	Status         int         `json:"status"`
+	ResponseHeader http.Header `json:"response_header,omitempty"`
+	ResponseBody   string      `json:"response_body"`
+}
+
+// NewRecorder create a Recorder for the given fixture file, recording the
+// exchanges made through the given RoundTripper (or http.DefaultTransport if
+// nil) or replaying the existing fixture.
+func NewRecorder(fixture string, recording bool, transport http.RoundTripper) (*Recorder, error) {
+	r := &Recorder{
+		Transport: transport,
+		path:      fixture,
+		recording: recording,
+	}
+
+	if recording {
+		return r, nil
+	}
+
+	data, err := ioutil.ReadFile(fixture)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &r.fixture)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fixture %s: %v", fixture, err)
+	}
+	r.used = make([]bool, len(r.fixture.Interactions))
+
+	return r, nil
+}
+
+// Recording tell if the exchanges reach the remote API and are recorded
+func (r *Recorder) Recording() bool {
+	return r.recording
+}
+
+// Value return a value that has to be the same when replaying as when
+// recording, like the name of a test repository created on the remote. The
+// value is generated and stored in the fixture when recording, and read from
+// the fixture otherwise.
+func (r *Recorder) Value(key string, generate func() string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.recording {
+		return r.fixture.Values[key]
+	}
+
+	if r.fixture.Values == nil {
+		r.fixture.Values = make(map[string]string)
+	}
+
+	value, ok := r.fixture.Values[key]
+	if !ok {
+		value = generate()
+		r.fixture.Values[key] = value
+	}
+	return value
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.recording {
+		return r.record(req, body)
+	}
+
+	return r.replay(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	current := new(http.Request)
+	*current = *req
+	if body != nil {
+		current.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := transport.RoundTrip(current)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	header := copyHeader(resp.Header)
+	for _, skipped := range recorderSkippedHeaders {
+		header.Del(skipped)
+	}
+
+	r.mu.Lock()
+	r.fixture.Interactions = append(r.fixture.Interactions, httpInteraction{
+		Method:         req.Method,
+		URL:            req.URL.String(),
+		Body:           string(body),
+		Status:         resp.StatusCode,
+		ResponseHeader: header,
+		ResponseBody:   string(respBody),
+	})
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	url := req.URL.String()
+
+	match := -1
+	for i, interaction := range r.fixture.Interactions {
+		if r.used[i] || interaction.Method != req.Method || interaction.URL != url {
+			continue
+		}
+		if interaction.Body == string(body) {
+			match = i
+			break
+		}
+		if match < 0 {
+			match = i
+		}
+	}
+
+	if match < 0 {
+		return nil, fmt.Errorf("no recorded response in %s for %s %s", r.path, req.Method, url)
+	}
+
+	r.used[match] = true
+	interaction := r.fixture.Interactions[match]
+
+	header := copyHeader(interaction.ResponseHeader)
+	if header == nil {
+		header = make(http.Header)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Status, http.StatusText(interaction.Status)),
+		StatusCode:    interaction.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader([]byte(interaction.ResponseBody))),
+		ContentLength: int64(len(interaction.ResponseBody)),
+		Request:       req,
+	}, nil
+}
+
+// Save write the recorded exchanges in the fixture file
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.fixture, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(path.Dir(r.path), 0777)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(r.path, data, 0644)
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "git-bug-recorder")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fixture := path.Join(dir, "testdata", "fixture.json")
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		require.Equal(t, "token secret", r.Header.Get("Authorization"))
+
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		w.Header().Set("X-Call", fmt.Sprintf("%d", calls))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, "%s %s %s", r.Method, r.URL.Path, body)
+	}))
+
+	do := func(client *http.Client, method string, url string, body string) (*http.Response, string) {
+		req, err := http.NewRequest(method, url, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "token secret")
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp, string(data)
+	}
+
+	// record
+	recorder, err := NewRecorder(fixture, true, nil)
+	require.NoError(t, err)
+	require.Equal(t, "value", recorder.Value("key", func() string { return "value" }))
+
+	client := &http.Client{Transport: recorder}
+	_, body := do(client, "GET", server.URL+"/a", "")
+	require.Equal(t, "GET /a ", body)
+	_, body = do(client, "POST", server.URL+"/b", "first")
+	require.Equal(t, "POST /b first", body)
+	_, body = do(client, "POST", server.URL+"/b", "second")
+	require.Equal(t, "POST /b second", body)
+
+	require.NoError(t, recorder.Save())
+	server.Close()
+
+	data, err := ioutil.ReadFile(fixture)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "secret")
+
+	// replay, without the server
+	recorder, err = NewRecorder(fixture, false, nil)
+	require.NoError(t, err)
+	require.Equal(t, "value", recorder.Value("key", func() string { return "other" }))
+
+	client = &http.Client{Transport: recorder}
+
+	// matched by body first, regardless of the order
+	resp, body := do(client, "POST", server.URL+"/b", "second")
+	require.Equal(t, "POST /b second", body)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	require.Equal(t, "3", resp.Header.Get("X-Call"))
+
+	// then by url
+	_, body = do(client, "POST", server.URL+"/b", "changed")
+	require.Equal(t, "POST /b first", body)
+
+	_, body = do(client, "GET", server.URL+"/a", "")
+	require.Equal(t, "GET /a ", body)
+
+	// every response is replayed once
+	_, err = client.Get(server.URL + "/a")
+	require.Error(t, err)
+}
@@ -0,0 +1,118 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+const identityMappingFile = "identity-mapping"
+
+// IdentityMapping map remote identities, expressed as a metadata key/value
+// pair (e.g. "github-login:alice"), to existing local identities. Bridges
+// consult it before creating a new identity, to avoid creating duplicated
+// identities for the same person across bridges.
+//
+// It's stored in .git/git-bug/identity-mapping, one mapping per line:
+//   github-login:alice 8d4b4bd...
+//   gitlab-login:alice 8d4b4bd...
+// Empty lines and lines starting with # are ignored. The local identity can
+// be given as a prefix of its id.
+type IdentityMapping map[string]string
+
+func identityMappingFilePath(repo repository.RepoCommon) string {
+	return path.Join(repo.GetPath(), "git-bug", identityMappingFile)
+}
+
+// LoadIdentityMapping read the identity mapping of the repo. A missing file
+// result in an empty mapping.
+func LoadIdentityMapping(repo repository.RepoCommon) (IdentityMapping, error) {
+	result := make(IdentityMapping)
+
+	data, err := ioutil.ReadFile(identityMappingFilePath(repo))
+	if os.IsNotExist(err) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "can't read identity mapping")
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 || !strings.Contains(fields[0], ":") {
+			return nil, fmt.Errorf("invalid identity mapping at line %d: %s", lineNum, line)
+		}
+
+		result[fields[0]] = fields[1]
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "can't read identity mapping")
+	}
+
+	return result, nil
+}
+
+// Store write the identity mapping in the repo
+func (im IdentityMapping) Store(repo repository.RepoCommon) error {
+	keys := make([]string, 0, len(im))
+	for key := range im {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	for _, key := range keys {
+		_, _ = fmt.Fprintf(&buf, "%s %s\n", key, im[key])
+	}
+
+	p := identityMappingFilePath(repo)
+
+	err := os.MkdirAll(path.Dir(p), 0777)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(p, buf.Bytes(), 0644)
+}
+
+// Add register a new mapping between a remote identity and a local one
+func (im IdentityMapping) Add(key string, value string, localId string) {
+	im[key+":"+value] = localId
+}
+
+// Resolve return the local identity mapped to the given remote identity, if
+// any. If there is no mapping, identity.ErrIdentityNotExist is returned.
+func (im IdentityMapping) Resolve(repo *cache.RepoCache, key string, value string) (*cache.IdentityCache, error) {
+	localId, ok := im[key+":"+value]
+	if !ok {
+		return nil, identity.ErrIdentityNotExist
+	}
+
+	i, err := repo.ResolveIdentityPrefix(localId)
+	if err != nil {
+		return nil, errors.Wrapf(err, "identity mapping for %s:%s", key, value)
+	}
+
+	return i, nil
+}
@@ -0,0 +1,47 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestIdentityMapping(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	backend, err := cache.NewRepoCache(repo)
+	require.NoError(t, err)
+	defer backend.Close()
+
+	iden, err := backend.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+
+	// no mapping file yet
+	im, err := LoadIdentityMapping(repo)
+	require.NoError(t, err)
+	require.Len(t, im, 0)
+
+	im.Add("github-login", "rene", iden.Id().String())
+	im.Add("gitlab-login", "descartes", iden.Id().Human())
+	require.NoError(t, im.Store(repo))
+
+	im, err = LoadIdentityMapping(repo)
+	require.NoError(t, err)
+	require.Len(t, im, 2)
+
+	resolved, err := im.Resolve(backend, "github-login", "rene")
+	require.NoError(t, err)
+	require.Equal(t, iden.Id(), resolved.Id())
+
+	resolved, err = im.Resolve(backend, "gitlab-login", "descartes")
+	require.NoError(t, err)
+	require.Equal(t, iden.Id(), resolved.Id())
+
+	_, err = im.Resolve(backend, "github-login", "unknown")
+	require.Equal(t, identity.ErrIdentityNotExist, err)
+}
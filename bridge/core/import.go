@@ -18,6 +18,8 @@ const (
 	ImportEventLabelChange
 	ImportEventIdentity
 	ImportEventNothing
+	ImportEventProgress
+	ImportEventRateLimiting
 	ImportEventError
 )
 
@@ -29,6 +31,10 @@ type ImportResult struct {
 	Event  ImportEvent
 	ID     entity.Id
 	Reason string
+
+	// progress, for ImportEventProgress
+	Current int
+	Total   int
 }
 
 func (er ImportResult) String() string {
@@ -52,6 +58,13 @@ func (er ImportResult) String() string {
 			return fmt.Sprintf("no action taken for event %s: %s", er.ID, er.Reason)
 		}
 		return fmt.Sprintf("no action taken: %s", er.Reason)
+	case ImportEventProgress:
+		if er.Total > 0 {
+			return fmt.Sprintf("progress: %d/%d issues", er.Current, er.Total)
+		}
+		return fmt.Sprintf("progress: %d issues", er.Current)
+	case ImportEventRateLimiting:
+		return fmt.Sprintf("rate limiting: %s", er.Reason)
 	case ImportEventError:
 		if er.ID != "" {
 			return fmt.Sprintf("import error at id %s: %s", er.ID, er.Err.Error())
@@ -126,3 +139,22 @@ func NewImportIdentity(id entity.Id) ImportResult {
 		Event: ImportEventIdentity,
 	}
 }
+
+// NewImportProgress create an event reporting that current issues out of total
+// have been processed. If the total is unknown, it should be set to 0.
+func NewImportProgress(current int, total int) ImportResult {
+	return ImportResult{
+		Current: current,
+		Total:   total,
+		Event:   ImportEventProgress,
+	}
+}
+
+// NewImportRateLimiting create an event reporting that the importer is waiting
+// for the remote API quota to be replenished.
+func NewImportRateLimiting(msg string) ImportResult {
+	return ImportResult{
+		Reason: msg,
+		Event:  ImportEventRateLimiting,
+	}
+}
@@ -0,0 +1,208 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+const (
+	// KeyLabelMapping is the configuration key holding the label mapping of a
+	// bridge, as a comma separated list of `remote=local` pairs.
+	// Example: "kind/bug=bug,kind/feature=enhancement"
+	KeyLabelMapping = "label-mapping"
+
+	// KeyStatusMapping is the configuration key holding the status mapping of a
+	// bridge, as a comma separated list of `remote=status[+label]` pairs.
+	// Example: "In Review=open+review,Done=closed"
+	KeyStatusMapping = "status-mapping"
+
+	// StatusLabelSuffix is appended to the remote id of a status change to
+	// tag the label change of its mapping, keeping both ids distinct.
+	StatusLabelSuffix = "-label"
+)
+
+// LabelMapping translate label names between a remote and git-bug. Labels
+// without a mapping are kept as is.
+type LabelMapping struct {
+	toLocal  map[string]string
+	toRemote map[string]string
+}
+
+// NewLabelMapping parse the label mapping of the given configuration
+func NewLabelMapping(conf Configuration) (*LabelMapping, error) {
+	lm := &LabelMapping{
+		toLocal:  make(map[string]string),
+		toRemote: make(map[string]string),
+	}
+
+	pairs, err := parseMappingPairs(conf[KeyLabelMapping])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", KeyLabelMapping, err)
+	}
+
+	for _, pair := range pairs {
+		if _, ok := lm.toLocal[pair[0]]; ok {
+			return nil, fmt.Errorf("invalid %s: duplicate remote label %s", KeyLabelMapping, pair[0])
+		}
+		lm.toLocal[pair[0]] = pair[1]
+
+		// the first mapping win when exporting
+		if _, ok := lm.toRemote[pair[1]]; !ok {
+			lm.toRemote[pair[1]] = pair[0]
+		}
+	}
+
+	return lm, nil
+}
+
+// ToLocal return the git-bug label for the given remote label
+func (lm *LabelMapping) ToLocal(remote string) string {
+	if local, ok := lm.toLocal[remote]; ok {
+		return local
+	}
+	return remote
+}
+
+// ToRemote return the remote label for the given git-bug label
+func (lm *LabelMapping) ToRemote(local string) string {
+	if remote, ok := lm.toRemote[local]; ok {
+		return remote
+	}
+	return local
+}
+
+type statusTarget struct {
+	status bug.Status
+	label  string
+}
+
+// StatusMapping translate remote statuses into a git-bug status, with an
+// optional label to retain the finer grained remote status. The remote
+// statuses are matched case insensitively.
+type StatusMapping struct {
+	toLocal  map[string]statusTarget
+	toRemote map[bug.Status]string
+}
+
+// NewStatusMapping parse the status mapping of the given configuration
+func NewStatusMapping(conf Configuration) (*StatusMapping, error) {
+	sm := &StatusMapping{
+		toLocal:  make(map[string]statusTarget),
+		toRemote: make(map[bug.Status]string),
+	}
+
+	pairs, err := parseMappingPairs(conf[KeyStatusMapping])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", KeyStatusMapping, err)
+	}
+
+	for _, pair := range pairs {
+		split := strings.SplitN(pair[1], "+", 2)
+
+		status, err := bug.StatusFromString(strings.TrimSpace(split[0]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", KeyStatusMapping, err)
+		}
+
+		target := statusTarget{status: status}
+		if len(split) == 2 {
+			target.label = strings.TrimSpace(split[1])
+		}
+
+		sm.toLocal[strings.ToLower(pair[0])] = target
+
+		// only a plain mapping can be used to go back to the remote status
+		if _, ok := sm.toRemote[status]; !ok && target.label == "" {
+			sm.toRemote[status] = pair[0]
+		}
+	}
+
+	return sm, nil
+}
+
+// ToLocal return the git-bug status and the optional label matching the given
+// remote status. If no mapping exist, ok is false.
+func (sm *StatusMapping) ToLocal(remote string) (status bug.Status, label string, ok bool) {
+	target, ok := sm.toLocal[strings.ToLower(remote)]
+	return target.status, target.label, ok
+}
+
+// ToRemote return the remote status for the given git-bug status. If no
+// mapping exist, ok is false.
+func (sm *StatusMapping) ToRemote(status bug.Status) (remote string, ok bool) {
+	remote, ok = sm.toRemote[status]
+	return remote, ok
+}
+
+// Resolve return the git-bug status and the optional label of the first of the
+// given remote statuses having a mapping, from the most to the least precise.
+// If none has, fallback is returned without label.
+func (sm *StatusMapping) Resolve(remotes []string, fallback bug.Status) (status bug.Status, label string) {
+	for _, remote := range remotes {
+		if status, label, ok := sm.ToLocal(remote); ok {
+			return status, label
+		}
+	}
+	return fallback, ""
+}
+
+// LabelChanges return the labels to add and to remove so that the bug carry
+// the given label of a mapped status, and none of the labels of the other
+// statuses.
+func (sm *StatusMapping) LabelChanges(snap *bug.Snapshot, label string) (added []string, removed []string) {
+	if label != "" && !hasLabel(snap, label) {
+		added = append(added, label)
+	}
+
+	seen := make(map[string]bool)
+	for _, target := range sm.toLocal {
+		if target.label == "" || target.label == label || seen[target.label] {
+			continue
+		}
+		seen[target.label] = true
+		if hasLabel(snap, target.label) {
+			removed = append(removed, target.label)
+		}
+	}
+	sort.Strings(removed)
+
+	return added, removed
+}
+
+func hasLabel(snap *bug.Snapshot, label string) bool {
+	for _, l := range snap.Labels {
+		if string(l) == label {
+			return true
+		}
+	}
+	return false
+}
+
+func parseMappingPairs(raw string) ([][2]string, error) {
+	var result [][2]string
+
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		split := strings.SplitN(item, "=", 2)
+		if len(split) != 2 {
+			return nil, fmt.Errorf("malformed pair \"%s\"", item)
+		}
+
+		remote := strings.TrimSpace(split[0])
+		local := strings.TrimSpace(split[1])
+		if remote == "" || local == "" {
+			return nil, fmt.Errorf("malformed pair \"%s\"", item)
+		}
+
+		result = append(result, [2]string{remote, local})
+	}
+
+	return result, nil
+}
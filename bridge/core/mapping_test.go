@@ -0,0 +1,101 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+func TestLabelMapping(t *testing.T) {
+	lm, err := NewLabelMapping(Configuration{
+		KeyLabelMapping: "kind/bug=bug, type/bug=bug ,kind/feature=enhancement",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "bug", lm.ToLocal("kind/bug"))
+	assert.Equal(t, "bug", lm.ToLocal("type/bug"))
+	assert.Equal(t, "enhancement", lm.ToLocal("kind/feature"))
+	assert.Equal(t, "other", lm.ToLocal("other"))
+
+	assert.Equal(t, "kind/bug", lm.ToRemote("bug"))
+	assert.Equal(t, "kind/feature", lm.ToRemote("enhancement"))
+	assert.Equal(t, "other", lm.ToRemote("other"))
+
+	_, err = NewLabelMapping(Configuration{KeyLabelMapping: "kind/bug"})
+	assert.Error(t, err)
+
+	_, err = NewLabelMapping(Configuration{KeyLabelMapping: "a=b,a=c"})
+	assert.Error(t, err)
+
+	lm, err = NewLabelMapping(Configuration{})
+	require.NoError(t, err)
+	assert.Equal(t, "bug", lm.ToLocal("bug"))
+}
+
+func TestStatusMapping(t *testing.T) {
+	sm, err := NewStatusMapping(Configuration{
+		KeyStatusMapping: "In Review=open+review,Done=closed,Closed=closed",
+	})
+	require.NoError(t, err)
+
+	status, label, ok := sm.ToLocal("in review")
+	assert.True(t, ok)
+	assert.Equal(t, bug.OpenStatus, status)
+	assert.Equal(t, "review", label)
+
+	status, label, ok = sm.ToLocal("Done")
+	assert.True(t, ok)
+	assert.Equal(t, bug.ClosedStatus, status)
+	assert.Equal(t, "", label)
+
+	_, _, ok = sm.ToLocal("unknown")
+	assert.False(t, ok)
+
+	remote, ok := sm.ToRemote(bug.ClosedStatus)
+	assert.True(t, ok)
+	assert.Equal(t, "Done", remote)
+
+	_, ok = sm.ToRemote(bug.OpenStatus)
+	assert.False(t, ok)
+
+	_, err = NewStatusMapping(Configuration{KeyStatusMapping: "Done=finished"})
+	assert.Error(t, err)
+}
+
+func TestStatusMappingResolve(t *testing.T) {
+	sm, err := NewStatusMapping(Configuration{
+		KeyStatusMapping: "not_planned=closed+wontfix,In Progress=open+wip,closed=closed",
+	})
+	require.NoError(t, err)
+
+	// the most precise remote status having a mapping win
+	status, label := sm.Resolve([]string{"NOT_PLANNED", "closed"}, bug.ClosedStatus)
+	assert.Equal(t, bug.ClosedStatus, status)
+	assert.Equal(t, "wontfix", label)
+
+	status, label = sm.Resolve([]string{"COMPLETED", "closed"}, bug.ClosedStatus)
+	assert.Equal(t, bug.ClosedStatus, status)
+	assert.Equal(t, "", label)
+
+	status, label = sm.Resolve([]string{"open"}, bug.OpenStatus)
+	assert.Equal(t, bug.OpenStatus, status)
+	assert.Equal(t, "", label)
+
+	// the label of the status replace the ones of the other statuses
+	snap := &bug.Snapshot{Labels: []bug.Label{"bug", "wip"}}
+
+	added, removed := sm.LabelChanges(snap, "wontfix")
+	assert.Equal(t, []string{"wontfix"}, added)
+	assert.Equal(t, []string{"wip"}, removed)
+
+	added, removed = sm.LabelChanges(snap, "wip")
+	assert.Len(t, added, 0)
+	assert.Len(t, removed, 0)
+
+	added, removed = sm.LabelChanges(snap, "")
+	assert.Len(t, added, 0)
+	assert.Equal(t, []string{"wip"}, removed)
+}
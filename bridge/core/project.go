@@ -0,0 +1,77 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+// KeyLocalProject is the configuration key holding the git-bug project a
+// bridge is bound to, if any. The imported bugs are moved into this project,
+// and only the bugs of this project are exported.
+//
+// Not to be confused with the project of the remote, like a GitHub
+// repository, configured by each target.
+const KeyLocalProject = "local-project"
+
+// ExportedBugsIds return the ids of the bugs a bridge with the given
+// configuration should export, that is the bugs of its local project if any,
+// or else all the bugs.
+func ExportedBugsIds(repo *cache.RepoCache, conf Configuration) []entity.Id {
+	project := conf[KeyLocalProject]
+	if project == "" {
+		return repo.AllBugsIds()
+	}
+
+	query := cache.NewQuery()
+	query.Project = append(query.Project, cache.ProjectFilter(project))
+
+	return repo.QueryBugs(query)
+}
+
+// moveToLocalProject move the given imported bugs into the local project of
+// the bridge, if any. The move is authored by the author of the bug at its
+// creation time, as if the bug had always been in this project. The bugs
+// already in a project are left untouched, so that they can be moved
+// elsewhere by the user.
+func moveToLocalProject(repo *cache.RepoCache, conf Configuration, ids []entity.Id) error {
+	project := conf[KeyLocalProject]
+	if project == "" {
+		return nil
+	}
+
+	for _, id := range ids {
+		b, err := repo.ResolveBug(id)
+		if err == bug.ErrBugNotExist {
+			// rolled back
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		snap := b.Snapshot()
+		if snap.Project != "" {
+			continue
+		}
+
+		author, err := repo.ResolveIdentity(snap.Author.Id())
+		if err != nil {
+			return err
+		}
+
+		_, err = b.SetProjectRaw(author, snap.CreatedAt.Unix(), project, nil)
+		if err != nil {
+			return err
+		}
+
+		err = b.Commit()
+		if err != nil {
+			return fmt.Errorf("can't move bug %s to the project %s: %v", id.Human(), project, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,211 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryMaxAttempts = 5
+	defaultRetryBaseDelay   = 1 * time.Second
+	defaultRetryMaxDelay    = 1 * time.Minute
+)
+
+// RetryTransport is a http.RoundTripper retrying failed requests with an
+// exponential backoff and jitter.
+//
+// Requests rejected for quota reasons (429, or Github's 403 with an exhausted
+// rate limit) are always retried, honoring the Retry-After or X-RateLimit-Reset
+// headers. Transient failures (network errors, 502, 503, 504) are only retried
+// for idempotent methods, as the remote might have processed the request
+// already.
+type RetryTransport struct {
+	// Transport is the underlying RoundTripper. If nil, http.DefaultTransport
+	// is used.
+	Transport http.RoundTripper
+
+	// MaxAttempts is the maximum number of attempts, including the first one
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry, doubled for every new attempt
+	BaseDelay time.Duration
+
+	// MaxDelay cap the computed backoff delay. Delays requested by the
+	// remote are not capped.
+	MaxDelay time.Duration
+
+	// OnRetry, if not nil, is called before waiting for the next attempt
+	OnRetry func(attempt int, wait time.Duration, reason string)
+}
+
+type rateLimitHandlerKey struct{}
+
+// WithRateLimitHandler return a context carrying a handler called by the
+// RetryTransport each time a request made with this context has to wait for
+// the remote API quota to be replenished. The handler is called synchronously
+// from the goroutine making the request.
+func WithRateLimitHandler(ctx context.Context, handler func(wait time.Duration)) context.Context {
+	return context.WithValue(ctx, rateLimitHandlerKey{}, handler)
+}
+
+// NewRetryTransport create a RetryTransport with sensible defaults, wrapping
+// the given RoundTripper (or http.DefaultTransport if nil).
+func NewRetryTransport(transport http.RoundTripper) *RetryTransport {
+	return &RetryTransport{
+		Transport:   transport,
+		MaxAttempts: defaultRetryMaxAttempts,
+		BaseDelay:   defaultRetryBaseDelay,
+		MaxDelay:    defaultRetryMaxDelay,
+	}
+}
+
+// NewRetryHTTPClient create a http.Client using a RetryTransport
+func NewRetryHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Transport: NewRetryTransport(nil),
+		Timeout:   timeout,
+	}
+}
+
+func (rt *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := rt.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	for attempt := 1; ; attempt++ {
+		current := req
+
+		// a new body is required for every new attempt
+		if attempt > 1 && req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			current = new(http.Request)
+			*current = *req
+			current.Body = body
+		}
+
+		resp, err := transport.RoundTrip(current)
+
+		wait, reason, quota, retry := rt.shouldRetry(req, resp, err, attempt)
+		if !retry || attempt >= rt.MaxAttempts {
+			return resp, err
+		}
+
+		// can't replay the body
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		if rt.OnRetry != nil {
+			rt.OnRetry(attempt, wait, reason)
+		}
+
+		if handler, ok := req.Context().Value(rateLimitHandlerKey{}).(func(time.Duration)); ok && quota {
+			handler(wait)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// shouldRetry decide if a new attempt should be made, and after how long. The
+// quota return value tell if the wait is due to the remote API quota.
+func (rt *RetryTransport) shouldRetry(req *http.Request, resp *http.Response, err error, attempt int) (wait time.Duration, reason string, quota bool, retry bool) {
+	idempotent := isIdempotent(req.Method)
+
+	if err != nil {
+		if req.Context().Err() != nil || !idempotent {
+			return 0, "", false, false
+		}
+		return rt.backoff(attempt), err.Error(), false, true
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return rt.remoteDelay(resp, attempt), "rate limited", true, true
+
+	case resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0":
+		return rt.remoteDelay(resp, attempt), "rate limit exhausted", true, true
+
+	case idempotent && (resp.StatusCode == http.StatusBadGateway ||
+		resp.StatusCode == http.StatusServiceUnavailable ||
+		resp.StatusCode == http.StatusGatewayTimeout):
+		return rt.remoteDelay(resp, attempt), fmt.Sprintf("HTTP error %d", resp.StatusCode), false, true
+	}
+
+	return 0, "", false, false
+}
+
+// remoteDelay return the delay requested by the remote if any, or the
+// backoff delay otherwise
+func (rt *RetryTransport) remoteDelay(resp *http.Response, attempt int) time.Duration {
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			return nonNegative(time.Until(date))
+		}
+	}
+
+	if reset := resp.Header.Get("X-RateLimit-Reset"); reset != "" {
+		if unix, err := strconv.ParseInt(reset, 10, 64); err == nil {
+			return nonNegative(time.Until(time.Unix(unix, 0)))
+		}
+	}
+
+	return rt.backoff(attempt)
+}
+
+// backoff compute an exponential delay with jitter for the given attempt
+func (rt *RetryTransport) backoff(attempt int) time.Duration {
+	delay := rt.BaseDelay
+	for i := 1; i < attempt && delay < rt.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > rt.MaxDelay {
+		delay = rt.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	// half fixed, half random
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
@@ -0,0 +1,127 @@
+package core
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testRetryClient(attempts int) (*http.Client, *int) {
+	retries := 0
+	rt := NewRetryTransport(nil)
+	rt.MaxAttempts = attempts
+	rt.BaseDelay = time.Millisecond
+	rt.MaxDelay = 5 * time.Millisecond
+	rt.OnRetry = func(attempt int, wait time.Duration, reason string) {
+		retries++
+	}
+	return &http.Client{Transport: rt}, &retries
+}
+
+func TestRetryTransport(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch {
+		case calls == 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case calls == 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	client, retries := testRetryClient(5)
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, 3, calls)
+	require.Equal(t, 2, *retries)
+}
+
+func TestRetryTransportMaxAttempts(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client, _ := testRetryClient(3)
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	require.Equal(t, 3, calls)
+}
+
+func TestRetryTransportNonIdempotent(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		if len(bodies) == 1 {
+			// rate limited, the request wasn't processed and can be retried
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "0")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		// might have been processed, must not be retried
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client, _ := testRetryClient(5)
+
+	resp, err := client.Post(server.URL, "text/plain", strings.NewReader("payload"))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	require.Equal(t, []string{"payload", "payload"}, bodies)
+}
+
+func TestRetryTransportRateLimitHandler(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch calls {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := testRetryClient(5)
+
+	var waits []time.Duration
+	ctx := WithRateLimitHandler(context.Background(), func(wait time.Duration) {
+		waits = append(waits, wait)
+	})
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req.WithContext(ctx))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// only the wait for the quota is reported
+	require.Equal(t, []time.Duration{0}, waits)
+}
@@ -0,0 +1,121 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/MichaelMure/git-bug/cache"
+)
+
+const (
+	keyLastImport = "last-import"
+	keyLastExport = "last-export"
+)
+
+// RemoteStatus describe the state of a bridge as seen by the remote
+type RemoteStatus struct {
+	// Login is the remote user associated with the configured credentials
+	Login string
+
+	// QuotaLimit, QuotaRemaining and QuotaReset describe the API quota, if the
+	// remote has one. A QuotaLimit of 0 means unknown.
+	QuotaLimit     int
+	QuotaRemaining int
+	QuotaReset     time.Time
+}
+
+// RemoteStatusReporter is an optional interface for a BridgeImpl able to
+// validate its credentials and report on the remote API quota.
+type RemoteStatusReporter interface {
+	RemoteStatus(ctx context.Context, conf Configuration) (*RemoteStatus, error)
+}
+
+// BugMatcher is an optional interface for a BridgeImpl able to tell if a bug
+// is bound to the configured remote. If not implemented, bugs are matched with
+// their origin metadata.
+type BugMatcher interface {
+	MatchBug(conf Configuration, excerpt *cache.BugExcerpt) bool
+}
+
+// Status is a health report of a configured bridge
+type Status struct {
+	Name   string
+	Target string
+
+	// Remote is nil if the bridge can't report on the remote status
+	Remote *RemoteStatus
+	// RemoteErr is set if the credentials or the remote failed to validate
+	RemoteErr error
+
+	// LastImport and LastExport are zero if it never happened
+	LastImport time.Time
+	LastExport time.Time
+
+	// BugCount is the number of local bugs bound to the remote
+	BugCount int
+}
+
+// Status produce a health report of the bridge
+func (b *Bridge) Status(ctx context.Context) (*Status, error) {
+	err := b.ensureConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{
+		Name:       b.Name,
+		Target:     b.conf[KeyTarget],
+		LastImport: parseSyncTime(b.conf[keyLastImport]),
+		LastExport: parseSyncTime(b.conf[keyLastExport]),
+	}
+
+	if reporter, ok := b.impl.(RemoteStatusReporter); ok {
+		status.Remote, status.RemoteErr = reporter.RemoteStatus(ctx, b.conf)
+	}
+
+	matcher, hasMatcher := b.impl.(BugMatcher)
+
+	for _, id := range b.repo.AllBugsIds() {
+		excerpt, err := b.repo.ResolveBugExcerpt(id)
+		if err != nil {
+			return nil, err
+		}
+
+		if hasMatcher {
+			if matcher.MatchBug(b.conf, excerpt) {
+				status.BugCount++
+			}
+		} else if excerpt.CreateMetadata[KeyOrigin] == status.Target {
+			status.BugCount++
+		}
+	}
+
+	return status, nil
+}
+
+// recordSync store the time of a successful import or export
+func (b *Bridge) recordSync(key string, t time.Time) error {
+	value := strconv.FormatInt(t.Unix(), 10)
+	storeKey := fmt.Sprintf("git-bug.bridge.%s.%s", b.Name, key)
+
+	err := b.repo.StoreConfig(storeKey, value)
+	if err != nil {
+		return err
+	}
+
+	b.conf[key] = value
+	return nil
+}
+
+func parseSyncTime(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	unix, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(unix, 0)
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+type fakeBridge struct{}
+
+func (*fakeBridge) Target() string { return "fake" }
+func (*fakeBridge) Configure(repo repository.RepoCommon, params BridgeParams) (Configuration, error) {
+	return Configuration{KeyTarget: "fake"}, nil
+}
+func (*fakeBridge) ValidateConfig(conf Configuration) error { return nil }
+func (*fakeBridge) NewImporter() Importer                   { return nil }
+func (*fakeBridge) NewExporter() Exporter                   { return nil }
+
+func TestBridgeStatus(t *testing.T) {
+	Register(&fakeBridge{})
+
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	backend, err := cache.NewRepoCache(repo)
+	require.NoError(t, err)
+	defer backend.Close()
+
+	author, err := backend.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+
+	_, _, err = backend.NewBugRaw(author, time.Now().Unix(), "imported", "message", nil,
+		map[string]string{KeyOrigin: "fake"})
+	require.NoError(t, err)
+	_, _, err = backend.NewBugRaw(author, time.Now().Unix(), "local", "message", nil, nil)
+	require.NoError(t, err)
+
+	b, err := NewBridge(backend, "fake", "test")
+	require.NoError(t, err)
+	require.NoError(t, b.Configure(BridgeParams{}))
+
+	b, err = LoadBridge(backend, "test")
+	require.NoError(t, err)
+
+	status, err := b.Status(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "fake", status.Target)
+	require.Nil(t, status.Remote)
+	require.True(t, status.LastImport.IsZero())
+	require.Equal(t, 1, status.BugCount)
+
+	now := time.Unix(time.Now().Unix(), 0)
+	require.NoError(t, b.recordSync(keyLastImport, now))
+
+	b, err = LoadBridge(backend, "test")
+	require.NoError(t, err)
+
+	status, err = b.Status(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, now, status.LastImport)
+	require.True(t, status.LastExport.IsZero())
+}
@@ -0,0 +1,112 @@
+package core
+
+import (
+	"context"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/git"
+)
+
+// ImportTransaction make the import of a single remote issue atomic: either
+// all the new operations of the bug are committed, or none are and a bug
+// created during the transaction is removed entirely. This way, an error in
+// the middle of an issue never leave a half imported bug behind, and the next
+// run start from the same state.
+//
+// The operations are buffered until the commit, which write them in a single
+// pack and only then update the ref of the bug, so that an import interrupted
+// at any point, even killed, never leave a bug pointing to a partial import.
+// Once the context is done, the import of the issue was likely cut short, so
+// the commit is refused and the transaction must be rolled back.
+//
+// The import events of the issue are buffered as well, and only sent once
+// committed, so that an issue rolled back is never reported as imported.
+//
+// Identities created along the way are not rolled back, as they stand on
+// their own and can be reused by other bugs.
+type ImportTransaction struct {
+	ctx     context.Context
+	repo    *cache.RepoCache
+	out     chan<- ImportResult
+	bug     *cache.BugCache
+	created bool
+	events  []ImportResult
+}
+
+// NewImportTransaction start a transaction for importing a remote issue,
+// sending the import events on out once committed
+func NewImportTransaction(ctx context.Context, repo *cache.RepoCache, out chan<- ImportResult) *ImportTransaction {
+	return &ImportTransaction{ctx: ctx, repo: repo, out: out}
+}
+
+// NewBugRaw create a new bug that will be removed if the transaction is
+// rolled back, and only appear in the repository once committed. See
+// cache.RepoCache.NewBugRawDetached.
+func (tx *ImportTransaction) NewBugRaw(author *cache.IdentityCache, unixTime int64, title string, message string, files []git.Hash, metadata map[string]string) (*cache.BugCache, *bug.CreateOperation, error) {
+	b, op, err := tx.repo.NewBugRawDetached(author, unixTime, title, message, files, metadata)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tx.bug = b
+	tx.created = true
+
+	return b, op, nil
+}
+
+// Track register an existing bug in the transaction, so that its pending
+// operations are discarded on rollback.
+func (tx *ImportTransaction) Track(b *cache.BugCache) {
+	if tx.bug == nil {
+		tx.bug = b
+	}
+}
+
+// Report buffer an import event of the issue, sent once the transaction is
+// committed
+func (tx *ImportTransaction) Report(result ImportResult) {
+	tx.events = append(tx.events, result)
+}
+
+// Bug return the bug of the transaction, if any
+func (tx *ImportTransaction) Bug() *cache.BugCache {
+	return tx.bug
+}
+
+// Commit write the pending operations of the bug, if any, unless the context
+// is done, then send the import events
+func (tx *ImportTransaction) Commit() error {
+	if err := tx.ctx.Err(); err != nil {
+		return err
+	}
+
+	if tx.bug != nil {
+		if err := tx.bug.CommitAsNeeded(); err != nil {
+			return err
+		}
+	}
+
+	for _, event := range tx.events {
+		tx.out <- event
+	}
+	tx.events = nil
+
+	return nil
+}
+
+// Rollback discard the pending operations of the bug and the import events,
+// and remove the bug if it was created during the transaction.
+func (tx *ImportTransaction) Rollback() error {
+	tx.events = nil
+
+	if tx.bug == nil {
+		return nil
+	}
+
+	if tx.created {
+		return tx.repo.RemoveBug(tx.bug.Id())
+	}
+
+	return tx.bug.DiscardPendingOps()
+}
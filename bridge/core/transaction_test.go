@@ -0,0 +1,159 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestImportTransaction(t *testing.T) {
+	out := make(chan ImportResult, 10)
+
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	backend, err := cache.NewRepoCache(repo)
+	require.NoError(t, err)
+	defer backend.Close()
+
+	author, err := backend.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+
+	// a bug created in a rolled back transaction is removed, and not reported
+	tx := NewImportTransaction(context.Background(), backend, out)
+	created, _, err := tx.NewBugRaw(author, time.Now().Unix(), "title", "message", nil, nil)
+	require.NoError(t, err)
+	tx.Report(NewImportBug(created.Id()))
+	_, err = created.AddCommentRaw(author, time.Now().Unix(), "comment", nil, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, tx.Rollback())
+	require.Len(t, backend.AllBugsIds(), 0)
+	require.Len(t, out, 0)
+	_, err = backend.ResolveBug(created.Id())
+	require.Error(t, err)
+
+	// pending operations of an existing bug are discarded on rollback
+	existing, _, err := backend.NewBugRaw(author, time.Now().Unix(), "title", "message", nil, nil)
+	require.NoError(t, err)
+
+	tx = NewImportTransaction(context.Background(), backend, out)
+	tx.Track(existing)
+	_, err = existing.AddCommentRaw(author, time.Now().Unix(), "comment", nil, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, tx.Rollback())
+	require.Len(t, existing.Snapshot().Comments, 1)
+	excerpt, err := backend.ResolveBugExcerpt(existing.Id())
+	require.NoError(t, err)
+	require.Equal(t, 1, excerpt.LenComments)
+
+	// and committed otherwise, the events being sent only then
+	tx = NewImportTransaction(context.Background(), backend, out)
+	tx.Track(existing)
+	op, err := existing.AddCommentRaw(author, time.Now().Unix(), "comment", nil, nil)
+	require.NoError(t, err)
+	tx.Report(NewImportComment(op.Id()))
+	require.Len(t, out, 0)
+
+	require.NoError(t, tx.Commit())
+	require.Len(t, out, 1)
+	event := <-out
+	require.Equal(t, ImportEventComment, event.Event)
+	require.Equal(t, op.Id(), event.ID)
+
+	b, err := bug.ReadLocalBug(repo, existing.Id())
+	require.NoError(t, err)
+	require.Len(t, b.Compile().Comments, 2)
+}
+
+func TestImportTransactionInterrupted(t *testing.T) {
+	out := make(chan ImportResult, 10)
+
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	backend, err := cache.NewRepoCache(repo)
+	require.NoError(t, err)
+
+	author, err := backend.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+
+	existing, _, err := backend.NewBugRaw(author, time.Now().Unix(), "title", "message", nil, nil)
+	require.NoError(t, err)
+
+	// cancelled midway, the commit is refused and the transaction rolled back
+	ctx, cancel := context.WithCancel(context.Background())
+
+	tx := NewImportTransaction(ctx, backend, out)
+	tx.Track(existing)
+	op, err := existing.AddCommentRaw(author, time.Now().Unix(), "comment", nil, nil)
+	require.NoError(t, err)
+	tx.Report(NewImportComment(op.Id()))
+
+	cancel()
+
+	require.Equal(t, context.Canceled, tx.Commit())
+	require.NoError(t, tx.Rollback())
+	require.Len(t, out, 0)
+	b, err := bug.ReadLocalBug(repo, existing.Id())
+	require.NoError(t, err)
+	require.Len(t, b.Compile().Comments, 1)
+
+	// killed midway, nothing of the new bug is in the repository
+	tx = NewImportTransaction(context.Background(), backend, out)
+	created, _, err := tx.NewBugRaw(author, time.Now().Unix(), "title", "message", nil, nil)
+	require.NoError(t, err)
+	_, err = created.AddCommentRaw(author, time.Now().Unix(), "comment", nil, nil)
+	require.NoError(t, err)
+
+	ids, err := bug.ListLocalIds(repo)
+	require.NoError(t, err)
+	require.Equal(t, []entity.Id{existing.Id()}, ids)
+
+	// the process stop without committing nor rolling back
+	require.NoError(t, backend.Close())
+
+	backend, err = cache.NewRepoCache(repo)
+	require.NoError(t, err)
+	defer backend.Close()
+
+	require.Equal(t, []entity.Id{existing.Id()}, backend.AllBugsIds())
+	_, err = backend.ResolveBug(created.Id())
+	require.Error(t, err)
+}
+
+func TestImportTransactionDetached(t *testing.T) {
+	out := make(chan ImportResult, 10)
+
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	backend, err := cache.NewRepoCache(repo)
+	require.NoError(t, err)
+	defer backend.Close()
+
+	author, err := backend.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+
+	// a new bug without more operations is still published by the commit
+	tx := NewImportTransaction(context.Background(), backend, out)
+	created, _, err := tx.NewBugRaw(author, time.Now().Unix(), "title", "message", nil, nil)
+	require.NoError(t, err)
+
+	_, err = bug.ReadLocalBug(repo, created.Id())
+	require.Error(t, err)
+
+	require.NoError(t, tx.Commit())
+
+	b, err := bug.ReadLocalBug(repo, created.Id())
+	require.NoError(t, err)
+	require.Equal(t, "title", b.Compile().Title)
+}
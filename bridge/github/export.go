@@ -43,6 +43,10 @@ type githubExporter struct {
 
 	// cache labels used to speed up exporting labels events
 	cachedLabels map[string]string
+
+	// mapping between git-bug and github labels and statuses
+	labelMapping  *core.LabelMapping
+	statusMapping *core.StatusMapping
 }
 
 // Init .
@@ -53,6 +57,18 @@ func (ge *githubExporter) Init(conf core.Configuration) error {
 	ge.identityClient = make(map[entity.Id]*githubv4.Client)
 	ge.cachedOperationIDs = make(map[entity.Id]string)
 	ge.cachedLabels = make(map[string]string)
+
+	var err error
+	ge.labelMapping, err = core.NewLabelMapping(conf)
+	if err != nil {
+		return err
+	}
+
+	ge.statusMapping, err = core.NewStatusMapping(conf)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -115,14 +131,19 @@ func (ge *githubExporter) ExportAll(ctx context.Context, repo *cache.RepoCache,
 	go func() {
 		defer close(out)
 
+		// report the waits for the API quota instead of appearing frozen
+		ctx := core.WithRateLimitHandler(ctx, func(wait time.Duration) {
+			out <- core.NewExportRateLimiting(fmt.Sprintf("waiting %v for GitHub rate limit", wait.Round(time.Second)))
+		})
+
 		var allIdentitiesIds []entity.Id
 		for id := range ge.identityToken {
 			allIdentitiesIds = append(allIdentitiesIds, id)
 		}
 
-		allBugsIds := repo.AllBugsIds()
+		allBugsIds := core.ExportedBugsIds(repo, ge.conf)
 
-		for _, id := range allBugsIds {
+		for i, id := range allBugsIds {
 			b, err := repo.ResolveBug(id)
 			if err != nil {
 				out <- core.NewExportError(errors.Wrap(err, "can't load bug"), id)
@@ -142,15 +163,14 @@ func (ge *githubExporter) ExportAll(ctx context.Context, repo *cache.RepoCache,
 				// TODO: compare the Lamport time instead of using the unix time
 				if snapshot.CreatedAt.Before(since) {
 					out <- core.NewExportNothing(b.Id(), "bug created before the since date")
-					continue
-				}
-
-				if snapshot.HasAnyActor(allIdentitiesIds...) {
+				} else if snapshot.HasAnyActor(allIdentitiesIds...) {
 					// try to export the bug and it associated events
 					ge.exportBug(ctx, b, since, out)
 				} else {
 					out <- core.NewExportNothing(id, "not an actor")
 				}
+
+				out <- core.NewExportProgress(i+1, len(allBugsIds))
 			}
 		}
 	}()
@@ -332,7 +352,7 @@ func (ge *githubExporter) exportBug(ctx context.Context, b *cache.BugCache, sinc
 
 		case *bug.SetStatusOperation:
 			opr := op.(*bug.SetStatusOperation)
-			if err := updateGithubIssueStatus(ctx, client, bugGithubID, opr.Status); err != nil {
+			if err := ge.updateGithubIssueStatus(ctx, client, bugGithubID, opr.Status); err != nil {
 				err := errors.Wrap(err, "editing status")
 				out <- core.NewExportError(err, b.Id())
 				return
@@ -358,7 +378,7 @@ func (ge *githubExporter) exportBug(ctx context.Context, b *cache.BugCache, sinc
 
 		case *bug.LabelChangeOperation:
 			opr := op.(*bug.LabelChangeOperation)
-			if err := ge.updateGithubIssueLabels(ctx, client, bugGithubID, opr.Added, opr.Removed); err != nil {
+			if err := ge.updateGithubIssueLabels(ctx, client, bugGithubID, ge.remoteLabels(opr.Added), ge.remoteLabels(opr.Removed)); err != nil {
 				err := errors.Wrap(err, "updating labels")
 				out <- core.NewExportError(err, b.Id())
 				return
@@ -392,7 +412,7 @@ func (ge *githubExporter) exportBug(ctx context.Context, b *cache.BugCache, sinc
 // getRepositoryNodeID request github api v3 to get repository node id
 func getRepositoryNodeID(ctx context.Context, owner, project, token string) (string, error) {
 	url := fmt.Sprintf("%s/repos/%s/%s", githubV3Url, owner, project)
-	client := &http.Client{}
+	client := core.NewRetryHTTPClient(0)
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -492,7 +512,7 @@ func (ge *githubExporter) getLabelID(gc *githubv4.Client, label string) (string,
 // see https://developer.github.com/v4/mutation/createlabel/ and https://developer.github.com/v4/previews/#labels-preview
 func (ge *githubExporter) createGithubLabel(ctx context.Context, label, color string) (string, error) {
 	url := fmt.Sprintf("%s/repos/%s/%s/labels", githubV3Url, ge.conf[keyOwner], ge.conf[keyProject])
-	client := &http.Client{}
+	client := core.NewRetryHTTPClient(0)
 
 	params := struct {
 		Name        string `json:"name"`
@@ -670,19 +690,33 @@ func editCommentGithubIssue(ctx context.Context, gc *githubv4.Client, commentID,
 	return commentID, m.UpdateIssueComment.IssueComment.URL, nil
 }
 
-func updateGithubIssueStatus(ctx context.Context, gc *githubv4.Client, id string, status bug.Status) error {
-	m := &updateIssueMutation{}
+// update github issue status, translated through the configured status
+// mapping: open, closed or a close reason (completed or not_planned)
+func (ge *githubExporter) updateGithubIssueStatus(ctx context.Context, gc *githubv4.Client, id string, status bug.Status) error {
+	remote, ok := ge.statusMapping.ToRemote(status)
+	if !ok {
+		remote = status.String()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
 
-	// set state
 	var state githubv4.IssueState
 
-	switch status {
-	case bug.OpenStatus:
+	switch remote = strings.ToLower(remote); remote {
+	case "open":
 		state = githubv4.IssueStateOpen
-	case bug.ClosedStatus:
+	case "closed":
 		state = githubv4.IssueStateClosed
+	case "completed", "not_planned":
+		reason := githubv4.String(strings.ToUpper(remote))
+		input := CloseIssueInput{
+			IssueID:     id,
+			StateReason: &reason,
+		}
+		return gc.Mutate(ctx, &closeIssueMutation{}, input, nil)
 	default:
-		panic("unknown bug state")
+		return fmt.Errorf("status %s can't be exported to github", remote)
 	}
 
 	input := githubv4.UpdateIssueInput{
@@ -690,14 +724,7 @@ func updateGithubIssueStatus(ctx context.Context, gc *githubv4.Client, id string
 		State: &state,
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
-	defer cancel()
-
-	if err := gc.Mutate(ctx, m, input, nil); err != nil {
-		return err
-	}
-
-	return nil
+	return gc.Mutate(ctx, &updateIssueMutation{}, input, nil)
 }
 
 func updateGithubIssueBody(ctx context.Context, gc *githubv4.Client, id string, body string) error {
@@ -734,6 +761,15 @@ func updateGithubIssueTitle(ctx context.Context, gc *githubv4.Client, id, title
 	return nil
 }
 
+// remoteLabels translate git-bug labels into github labels
+func (ge *githubExporter) remoteLabels(labels []bug.Label) []bug.Label {
+	result := make([]bug.Label, len(labels))
+	for i, label := range labels {
+		result[i] = bug.Label(ge.labelMapping.ToRemote(string(label)))
+	}
+	return result
+}
+
 // update github issue labels
 func (ge *githubExporter) updateGithubIssueLabels(ctx context.Context, gc *githubv4.Client, labelableID string, added, removed []bug.Label) error {
 	reqCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
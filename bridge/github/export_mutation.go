@@ -1,5 +1,7 @@
 package github
 
+import "github.com/shurcooL/githubv4"
+
 type createIssueMutation struct {
 	CreateIssue struct {
 		Issue struct {
@@ -18,6 +20,23 @@ type updateIssueMutation struct {
 	} `graphql:"updateIssue(input:$input)"`
 }
 
+type closeIssueMutation struct {
+	CloseIssue struct {
+		Issue struct {
+			ID  string `graphql:"id"`
+			URL string `graphql:"url"`
+		}
+	} `graphql:"closeIssue(input:$input)"`
+}
+
+// CloseIssueInput is githubv4.CloseIssueInput with the close reason, that the
+// vendored schema predate. The name is the one of the GraphQL input type.
+type CloseIssueInput struct {
+	IssueID githubv4.ID `json:"issueId"`
+	// COMPLETED or NOT_PLANNED
+	StateReason *githubv4.String `json:"stateReason,omitempty"`
+}
+
 type addCommentToIssueMutation struct {
 	AddComment struct {
 		CommentEdge struct {
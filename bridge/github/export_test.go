@@ -13,6 +13,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/MichaelMure/git-bug/bridge/bridgetest"
 	"github.com/MichaelMure/git-bug/bridge/core"
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
@@ -127,13 +128,13 @@ func testCases(t *testing.T, repo *cache.RepoCache, identity *cache.IdentityCach
 
 func TestPushPull(t *testing.T) {
 	// repo owner
-	user := os.Getenv("GITHUB_TEST_USER")
+	// replayed from testdata/export.json, see bridgetest.NewRecorder
+	recorder, done := bridgetest.NewRecorder(t, "export")
+	defer done()
 
 	// token must have 'repo' and 'delete_repo' scopes
-	token := os.Getenv("GITHUB_TOKEN_ADMIN")
-	if token == "" {
-		t.Skip("Env var GITHUB_TOKEN_ADMIN missing")
-	}
+	token := bridgetest.Secret(t, recorder, "GITHUB_TOKEN_ADMIN")
+	user := recorder.Value("user", func() string { return os.Getenv("GITHUB_TEST_USER") })
 
 	// create repo backend
 	repo := repository.CreateTestRepo(false)
@@ -155,7 +156,7 @@ func TestPushPull(t *testing.T) {
 	tests := testCases(t, backend, author)
 
 	// generate project name
-	projectName := generateRepoName()
+	projectName := recorder.Value("project", generateRepoName)
 
 	// create target Github repository
 	err = createRepository(projectName, token)
@@ -191,11 +192,18 @@ func TestPushPull(t *testing.T) {
 	exportEvents, err := exporter.ExportAll(ctx, backend, time.Time{})
 	require.NoError(t, err)
 
+	exportedBugs := 0
 	for result := range exportEvents {
 		require.NoError(t, result.Err)
+		if result.Event == core.ExportEventBug {
+			exportedBugs++
+		}
 	}
 	require.NoError(t, err)
 
+	// every test case is a new bug
+	require.Equal(t, len(tests), exportedBugs)
+
 	fmt.Printf("test repository exported in %f seconds\n", time.Since(start).Seconds())
 
 	repoTwo := repository.CreateTestRepo(false)
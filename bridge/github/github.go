@@ -20,6 +20,12 @@ func (*Github) Target() string {
 	return target
 }
 
+// LoginMetadataKey return the metadata key recording the Github login of the
+// identities
+func (*Github) LoginMetadataKey() string {
+	return keyGithubLogin
+}
+
 func (*Github) NewImporter() core.Importer {
 	return &githubImporter{}
 }
@@ -32,7 +38,9 @@ func buildClient(token string) *githubv4.Client {
 	src := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: token},
 	)
-	httpClient := oauth2.NewClient(context.TODO(), src)
+	// retry transient errors and rate limiting underneath the authentication
+	ctx := context.WithValue(context.TODO(), oauth2.HTTPClient, core.NewRetryHTTPClient(0))
+	httpClient := oauth2.NewClient(ctx, src)
 
 	return githubv4.NewClient(httpClient)
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
 	"github.com/MichaelMure/git-bug/util/text"
 )
 
@@ -25,6 +26,19 @@ const (
 type githubImporter struct {
 	conf core.Configuration
 
+	// mapping between github and git-bug labels and statuses
+	labelMapping  *core.LabelMapping
+	statusMapping *core.StatusMapping
+
+	// mapping of remote identities to local ones
+	identityMapping core.IdentityMapping
+
+	// index of the bugs by url, to reconcile with other bridges
+	urlIndex *core.URLIndex
+
+	// how to react to an issue failing to import
+	errorPolicy core.ErrorPolicy
+
 	// iterator
 	iterator *iterator
 
@@ -34,46 +48,73 @@ type githubImporter struct {
 
 func (gi *githubImporter) Init(conf core.Configuration) error {
 	gi.conf = conf
+
+	var err error
+	gi.labelMapping, err = core.NewLabelMapping(conf)
+	if err != nil {
+		return err
+	}
+
+	gi.statusMapping, err = core.NewStatusMapping(conf)
+	if err != nil {
+		return err
+	}
+
+	gi.errorPolicy, err = core.NewErrorPolicy(conf)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // ImportAll iterate over all the configured repository issues and ensure the creation of the
 // missing issues / timeline items / edits / label events ...
 func (gi *githubImporter) ImportAll(ctx context.Context, repo *cache.RepoCache, since time.Time) (<-chan core.ImportResult, error) {
-	gi.iterator = NewIterator(ctx, 10, gi.conf[keyOwner], gi.conf[keyProject], gi.conf[keyToken], since)
+	identityMapping, err := core.LoadIdentityMapping(repo)
+	if err != nil {
+		return nil, err
+	}
+	gi.identityMapping = identityMapping
+
+	gi.urlIndex, err = core.NewURLIndex(repo)
+	if err != nil {
+		return nil, err
+	}
+
 	out := make(chan core.ImportResult)
 	gi.out = out
 
+	// report the waits for the API quota instead of appearing frozen
+	ctx = core.WithRateLimitHandler(ctx, func(wait time.Duration) {
+		out <- core.NewImportRateLimiting(fmt.Sprintf("waiting %v for GitHub rate limit", wait.Round(time.Second)))
+	})
+
+	gi.iterator = NewIterator(ctx, 10, gi.conf[keyOwner], gi.conf[keyProject], gi.conf[keyToken], since)
+
 	go func() {
 		defer close(gi.out)
 
+		processed := 0
+
 		// Loop over all matching issues
 		for gi.iterator.NextIssue() {
 			issue := gi.iterator.IssueValue()
-			// create issue
-			b, err := gi.ensureIssue(repo, issue)
-			if err != nil {
-				err := fmt.Errorf("issue creation: %v", err)
-				out <- core.NewImportError(err, "")
+
+			err := gi.importIssue(ctx, repo, issue)
+			if err != nil && ctx.Err() != nil {
+				// interrupted, the issue was rolled back
 				return
 			}
-
-			// loop over timeline items
-			for gi.iterator.NextTimelineItem() {
-				item := gi.iterator.TimelineItemValue()
-				if err := gi.ensureTimelineItem(repo, b, item); err != nil {
-					err := fmt.Errorf("timeline item creation: %v", err)
-					out <- core.NewImportError(err, "")
+			if err != nil {
+				out <- core.NewImportError(err, "")
+				if gi.errorPolicy == core.ErrorPolicyFailFast {
 					return
 				}
 			}
 
-			// commit bug state
-			if err := b.CommitAsNeeded(); err != nil {
-				err = fmt.Errorf("bug commit: %v", err)
-				out <- core.NewImportError(err, "")
-				return
-			}
+			processed++
+			out <- core.NewImportProgress(processed, gi.iterator.IssueCount())
 		}
 
 		if err := gi.iterator.Error(); err != nil && err != context.Canceled {
@@ -84,7 +125,49 @@ func (gi *githubImporter) ImportAll(ctx context.Context, repo *cache.RepoCache,
 	return out, nil
 }
 
-func (gi *githubImporter) ensureIssue(repo *cache.RepoCache, issue issueTimeline) (*cache.BugCache, error) {
+// importIssue import an issue and all its timeline items. The import is
+// transactional: on error, nothing of the issue is committed.
+func (gi *githubImporter) importIssue(ctx context.Context, repo *cache.RepoCache, issue issueTimeline) error {
+	tx := core.NewImportTransaction(ctx, repo, gi.out)
+
+	err := gi.importIssueTx(repo, tx, issue)
+	if err == nil {
+		// commit bug state
+		if errCommit := tx.Commit(); errCommit != nil {
+			err = fmt.Errorf("bug commit: %v", errCommit)
+		}
+	}
+	if err != nil {
+		if errRollback := tx.Rollback(); errRollback != nil {
+			return fmt.Errorf("%v (rollback failed: %v)", err, errRollback)
+		}
+		return err
+	}
+
+	gi.urlIndex.Add(issue.Url.String(), tx.Bug().Id())
+
+	return nil
+}
+
+func (gi *githubImporter) importIssueTx(repo *cache.RepoCache, tx *core.ImportTransaction, issue issueTimeline) error {
+	// create issue
+	b, err := gi.ensureIssue(repo, tx, issue)
+	if err != nil {
+		return fmt.Errorf("issue creation: %v", err)
+	}
+
+	// loop over timeline items
+	for gi.iterator.NextTimelineItem() {
+		item := gi.iterator.TimelineItemValue()
+		if err := gi.ensureTimelineItem(repo, tx, b, item); err != nil {
+			return fmt.Errorf("timeline item creation: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (gi *githubImporter) ensureIssue(repo *cache.RepoCache, tx *core.ImportTransaction, issue issueTimeline) (*cache.BugCache, error) {
 	// ensure issue author
 	author, err := gi.ensurePerson(repo, issue.Author)
 	if err != nil {
@@ -93,9 +176,20 @@ func (gi *githubImporter) ensureIssue(repo *cache.RepoCache, issue issueTimeline
 
 	// resolve bug
 	b, err := repo.ResolveBugCreateMetadata(keyGithubUrl, issue.Url.String())
+	if err == bug.ErrBugNotExist {
+		// the same issue might have been imported through another bridge
+		b, err = gi.urlIndex.Reconcile(issue.Url.String(), author, issue.CreatedAt.Unix(),
+			map[string]string{
+				keyGithubId:  parseId(issue.Id),
+				keyGithubUrl: issue.Url.String(),
+			})
+	}
 	if err != nil && err != bug.ErrBugNotExist {
 		return nil, err
 	}
+	if err == nil {
+		tx.Track(b)
+	}
 
 	// get issue edits
 	var issueEdits []userContentEdit
@@ -112,7 +206,7 @@ func (gi *githubImporter) ensureIssue(repo *cache.RepoCache, issue issueTimeline
 			}
 
 			// create bug
-			b, _, err = repo.NewBugRaw(
+			b, _, err = tx.NewBugRaw(
 				author,
 				issue.CreatedAt.Unix(),
 				issue.Title,
@@ -128,9 +222,9 @@ func (gi *githubImporter) ensureIssue(repo *cache.RepoCache, issue issueTimeline
 			}
 
 			// importing a new bug
-			gi.out <- core.NewImportBug(b.Id())
+			tx.Report(core.NewImportBug(b.Id()))
 		} else {
-			gi.out <- core.NewImportNothing("", "bug already imported")
+			tx.Report(core.NewImportNothing("", "bug already imported"))
 		}
 
 	} else {
@@ -138,7 +232,7 @@ func (gi *githubImporter) ensureIssue(repo *cache.RepoCache, issue issueTimeline
 		for i, edit := range issueEdits {
 			if i == 0 && b != nil {
 				// The first edit in the github result is the issue creation itself, we already have that
-				gi.out <- core.NewImportNothing("", "bug already imported")
+				tx.Report(core.NewImportNothing("", "bug already imported"))
 				continue
 			}
 
@@ -150,7 +244,7 @@ func (gi *githubImporter) ensureIssue(repo *cache.RepoCache, issue issueTimeline
 			// if the bug doesn't exist
 			if b == nil {
 				// we create the bug as soon as we have a legit first edition
-				b, _, err = repo.NewBugRaw(
+				b, _, err = tx.NewBugRaw(
 					author,
 					issue.CreatedAt.Unix(),
 					issue.Title,
@@ -168,7 +262,7 @@ func (gi *githubImporter) ensureIssue(repo *cache.RepoCache, issue issueTimeline
 				}
 
 				// importing a new bug
-				gi.out <- core.NewImportBug(b.Id())
+				tx.Report(core.NewImportBug(b.Id()))
 				continue
 			}
 
@@ -178,7 +272,7 @@ func (gi *githubImporter) ensureIssue(repo *cache.RepoCache, issue issueTimeline
 				return nil, err
 			}
 
-			err = gi.ensureCommentEdit(repo, b, target, edit)
+			err = gi.ensureCommentEdit(repo, tx, b, target, edit)
 			if err != nil {
 				return nil, err
 			}
@@ -188,7 +282,7 @@ func (gi *githubImporter) ensureIssue(repo *cache.RepoCache, issue issueTimeline
 	return b, nil
 }
 
-func (gi *githubImporter) ensureTimelineItem(repo *cache.RepoCache, b *cache.BugCache, item timelineItem) error {
+func (gi *githubImporter) ensureTimelineItem(repo *cache.RepoCache, tx *core.ImportTransaction, b *cache.BugCache, item timelineItem) error {
 
 	switch item.Typename {
 	case "IssueComment":
@@ -198,8 +292,8 @@ func (gi *githubImporter) ensureTimelineItem(repo *cache.RepoCache, b *cache.Bug
 			commentEdits = append(commentEdits, gi.iterator.CommentEditValue())
 		}
 
-		// ensureTimelineComment send import events over out chanel
-		err := gi.ensureTimelineComment(repo, b, item.IssueComment, commentEdits)
+		// ensureTimelineComment report the import events to the transaction
+		err := gi.ensureTimelineComment(repo, tx, b, item.IssueComment, commentEdits)
 		if err != nil {
 			return fmt.Errorf("timeline comment creation: %v", err)
 		}
@@ -209,7 +303,7 @@ func (gi *githubImporter) ensureTimelineItem(repo *cache.RepoCache, b *cache.Bug
 		_, err := b.ResolveOperationWithMetadata(keyGithubId, id)
 		if err == nil {
 			reason := fmt.Sprintf("operation already imported: %v", item.Typename)
-			gi.out <- core.NewImportNothing("", reason)
+			tx.Report(core.NewImportNothing("", reason))
 			return nil
 		}
 
@@ -224,7 +318,7 @@ func (gi *githubImporter) ensureTimelineItem(repo *cache.RepoCache, b *cache.Bug
 			author,
 			item.LabeledEvent.CreatedAt.Unix(),
 			[]string{
-				string(item.LabeledEvent.Label.Name),
+				gi.labelMapping.ToLocal(string(item.LabeledEvent.Label.Name)),
 			},
 			nil,
 			map[string]string{keyGithubId: id},
@@ -233,7 +327,7 @@ func (gi *githubImporter) ensureTimelineItem(repo *cache.RepoCache, b *cache.Bug
 			return err
 		}
 
-		gi.out <- core.NewImportLabelChange(op.Id())
+		tx.Report(core.NewImportLabelChange(op.Id()))
 		return nil
 
 	case "UnlabeledEvent":
@@ -241,7 +335,7 @@ func (gi *githubImporter) ensureTimelineItem(repo *cache.RepoCache, b *cache.Bug
 		_, err := b.ResolveOperationWithMetadata(keyGithubId, id)
 		if err == nil {
 			reason := fmt.Sprintf("operation already imported: %v", item.Typename)
-			gi.out <- core.NewImportNothing("", reason)
+			tx.Report(core.NewImportNothing("", reason))
 			return nil
 		}
 		if err != cache.ErrNoMatchingOp {
@@ -257,7 +351,7 @@ func (gi *githubImporter) ensureTimelineItem(repo *cache.RepoCache, b *cache.Bug
 			item.UnlabeledEvent.CreatedAt.Unix(),
 			nil,
 			[]string{
-				string(item.UnlabeledEvent.Label.Name),
+				gi.labelMapping.ToLocal(string(item.UnlabeledEvent.Label.Name)),
 			},
 			map[string]string{keyGithubId: id},
 		)
@@ -265,7 +359,7 @@ func (gi *githubImporter) ensureTimelineItem(repo *cache.RepoCache, b *cache.Bug
 			return err
 		}
 
-		gi.out <- core.NewImportLabelChange(op.Id())
+		tx.Report(core.NewImportLabelChange(op.Id()))
 		return nil
 
 	case "ClosedEvent":
@@ -276,24 +370,31 @@ func (gi *githubImporter) ensureTimelineItem(repo *cache.RepoCache, b *cache.Bug
 		}
 		if err == nil {
 			reason := fmt.Sprintf("operation already imported: %v", item.Typename)
-			gi.out <- core.NewImportNothing("", reason)
+			tx.Report(core.NewImportNothing("", reason))
 			return nil
 		}
 		author, err := gi.ensurePerson(repo, item.ClosedEvent.Actor)
 		if err != nil {
 			return err
 		}
-		op, err := b.CloseRaw(
+		remotes := []string{bug.ClosedStatus.String()}
+		if item.ClosedEvent.StateReason != nil {
+			remotes = append([]string{string(*item.ClosedEvent.StateReason)}, remotes...)
+		}
+		op, err := gi.ensureStatus(
+			b,
 			author,
 			item.ClosedEvent.CreatedAt.Unix(),
-			map[string]string{keyGithubId: id},
+			remotes,
+			bug.ClosedStatus,
+			id,
 		)
 
 		if err != nil {
 			return err
 		}
 
-		gi.out <- core.NewImportStatusChange(op.Id())
+		tx.Report(core.NewImportStatusChange(op.Id()))
 		return nil
 
 	case "ReopenedEvent":
@@ -304,24 +405,27 @@ func (gi *githubImporter) ensureTimelineItem(repo *cache.RepoCache, b *cache.Bug
 		}
 		if err == nil {
 			reason := fmt.Sprintf("operation already imported: %v", item.Typename)
-			gi.out <- core.NewImportNothing("", reason)
+			tx.Report(core.NewImportNothing("", reason))
 			return nil
 		}
 		author, err := gi.ensurePerson(repo, item.ReopenedEvent.Actor)
 		if err != nil {
 			return err
 		}
-		op, err := b.OpenRaw(
+		op, err := gi.ensureStatus(
+			b,
 			author,
 			item.ReopenedEvent.CreatedAt.Unix(),
-			map[string]string{keyGithubId: id},
+			[]string{bug.OpenStatus.String()},
+			bug.OpenStatus,
+			id,
 		)
 
 		if err != nil {
 			return err
 		}
 
-		gi.out <- core.NewImportStatusChange(op.Id())
+		tx.Report(core.NewImportStatusChange(op.Id()))
 		return nil
 
 	case "RenamedTitleEvent":
@@ -332,7 +436,7 @@ func (gi *githubImporter) ensureTimelineItem(repo *cache.RepoCache, b *cache.Bug
 		}
 		if err == nil {
 			reason := fmt.Sprintf("operation already imported: %v", item.Typename)
-			gi.out <- core.NewImportNothing("", reason)
+			tx.Report(core.NewImportNothing("", reason))
 			return nil
 		}
 		author, err := gi.ensurePerson(repo, item.RenamedTitleEvent.Actor)
@@ -349,18 +453,53 @@ func (gi *githubImporter) ensureTimelineItem(repo *cache.RepoCache, b *cache.Bug
 			return err
 		}
 
-		gi.out <- core.NewImportTitleEdition(op.Id())
+		tx.Report(core.NewImportTitleEdition(op.Id()))
 		return nil
 
 	default:
 		reason := fmt.Sprintf("ignoring timeline type: %v", item.Typename)
-		gi.out <- core.NewImportNothing("", reason)
+		tx.Report(core.NewImportNothing("", reason))
 	}
 
 	return nil
 }
 
-func (gi *githubImporter) ensureTimelineComment(repo *cache.RepoCache, b *cache.BugCache, item issueComment, edits []userContentEdit) error {
+// ensureStatus apply a github status change to the bug, translated through the
+// configured status mapping from the most precise remote status: the close
+// reason (completed or not_planned), then open or closed. The label of the
+// mapping, if any, replace the ones of the other statuses, in an operation
+// tagged with the github id of the event as well so that it's not exported.
+func (gi *githubImporter) ensureStatus(b *cache.BugCache, author *cache.IdentityCache, unixTime int64, remotes []string, fallback bug.Status, id string) (*bug.SetStatusOperation, error) {
+	status, label := gi.statusMapping.Resolve(remotes, fallback)
+
+	var op *bug.SetStatusOperation
+	var err error
+
+	switch status {
+	case bug.OpenStatus:
+		op, err = b.OpenRaw(author, unixTime, map[string]string{keyGithubId: id})
+	case bug.ClosedStatus:
+		op, err = b.CloseRaw(author, unixTime, map[string]string{keyGithubId: id})
+	default:
+		return nil, fmt.Errorf("unexpected status %v", status)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	added, removed := gi.statusMapping.LabelChanges(b.Snapshot(), label)
+	if len(added) > 0 || len(removed) > 0 {
+		metadata := map[string]string{keyGithubId: id + core.StatusLabelSuffix}
+		_, err = b.ForceChangeLabelsRaw(author, unixTime, added, removed, metadata)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return op, nil
+}
+
+func (gi *githubImporter) ensureTimelineComment(repo *cache.RepoCache, tx *core.ImportTransaction, b *cache.BugCache, item issueComment, edits []userContentEdit) error {
 	// ensure person
 	author, err := gi.ensurePerson(repo, item.Author)
 	if err != nil {
@@ -369,7 +508,7 @@ func (gi *githubImporter) ensureTimelineComment(repo *cache.RepoCache, b *cache.
 
 	targetOpID, err := b.ResolveOperationWithMetadata(keyGithubId, parseId(item.Id))
 	if err == nil {
-		gi.out <- core.NewImportNothing("", "comment already imported")
+		tx.Report(core.NewImportNothing("", "comment already imported"))
 	} else if err != cache.ErrNoMatchingOp {
 		// real error
 		return err
@@ -398,14 +537,14 @@ func (gi *githubImporter) ensureTimelineComment(repo *cache.RepoCache, b *cache.
 				return err
 			}
 
-			gi.out <- core.NewImportComment(op.Id())
+			tx.Report(core.NewImportComment(op.Id()))
 		}
 
 	} else {
 		for i, edit := range edits {
 			if i == 0 && targetOpID != "" {
 				// The first edit in the github result is the comment creation itself, we already have that
-				gi.out <- core.NewImportNothing("", "comment already imported")
+				tx.Report(core.NewImportNothing("", "comment already imported"))
 				continue
 			}
 
@@ -441,7 +580,7 @@ func (gi *githubImporter) ensureTimelineComment(repo *cache.RepoCache, b *cache.
 				continue
 			}
 
-			err = gi.ensureCommentEdit(repo, b, targetOpID, edit)
+			err = gi.ensureCommentEdit(repo, tx, b, targetOpID, edit)
 			if err != nil {
 				return err
 			}
@@ -450,10 +589,10 @@ func (gi *githubImporter) ensureTimelineComment(repo *cache.RepoCache, b *cache.
 	return nil
 }
 
-func (gi *githubImporter) ensureCommentEdit(repo *cache.RepoCache, b *cache.BugCache, target entity.Id, edit userContentEdit) error {
+func (gi *githubImporter) ensureCommentEdit(repo *cache.RepoCache, tx *core.ImportTransaction, b *cache.BugCache, target entity.Id, edit userContentEdit) error {
 	_, err := b.ResolveOperationWithMetadata(keyGithubId, parseId(edit.Id))
 	if err == nil {
-		gi.out <- core.NewImportNothing(b.Id(), "edition already imported")
+		tx.Report(core.NewImportNothing(b.Id(), "edition already imported"))
 		return nil
 	}
 	if err != cache.ErrNoMatchingOp {
@@ -469,7 +608,7 @@ func (gi *githubImporter) ensureCommentEdit(repo *cache.RepoCache, b *cache.BugC
 	switch {
 	case edit.DeletedAt != nil:
 		// comment deletion, not supported yet
-		gi.out <- core.NewImportNothing(b.Id(), "comment deletion is not supported yet")
+		tx.Report(core.NewImportNothing(b.Id(), "comment deletion is not supported yet"))
 
 	case edit.DeletedAt == nil:
 
@@ -493,7 +632,7 @@ func (gi *githubImporter) ensureCommentEdit(repo *cache.RepoCache, b *cache.BugC
 			return err
 		}
 
-		gi.out <- core.NewImportCommentEdition(op.Id())
+		tx.Report(core.NewImportCommentEdition(op.Id()))
 	}
 
 	return nil
@@ -507,7 +646,8 @@ func (gi *githubImporter) ensurePerson(repo *cache.RepoCache, actor *actor) (*ca
 		return gi.getGhost(repo)
 	}
 
-	// Look first in the cache
+	// Look first in the cache, including the identities the account has been
+	// attached to
 	i, err := repo.ResolveIdentityImmutableMetadata(keyGithubLogin, string(actor.Login))
 	if err == nil {
 		return i, nil
@@ -516,6 +656,15 @@ func (gi *githubImporter) ensurePerson(repo *cache.RepoCache, actor *actor) (*ca
 		return nil, err
 	}
 
+	// Then look in the identity mapping
+	i, err = gi.identityMapping.Resolve(repo, keyGithubLogin, string(actor.Login))
+	if err == nil {
+		return i, nil
+	}
+	if err != identity.ErrIdentityNotExist {
+		return nil, err
+	}
+
 	// importing a new identity
 
 	var name string
@@ -83,6 +83,8 @@ type timelineItem struct {
 	ClosedEvent struct {
 		actorEvent
 		// Url githubv4.URI
+		// COMPLETED or NOT_PLANNED, if given
+		StateReason *githubv4.String
 	} `graphql:"... on  ClosedEvent"`
 	ReopenedEvent struct {
 		actorEvent
@@ -126,8 +128,9 @@ type issueEdit struct {
 type issueTimelineQuery struct {
 	Repository struct {
 		Issues struct {
-			Nodes    []issueTimeline
-			PageInfo pageInfo
+			TotalCount githubv4.Int
+			Nodes      []issueTimeline
+			PageInfo   pageInfo
 		} `graphql:"issues(first: $issueFirst, after: $issueAfter, orderBy: {field: CREATED_AT, direction: ASC}, filterBy: {since: $issueSince})"`
 	} `graphql:"repository(owner: $owner, name: $name)"`
 }
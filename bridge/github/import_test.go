@@ -3,13 +3,13 @@ package github
 import (
 	"context"
 	"fmt"
-	"os"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/MichaelMure/git-bug/bridge/bridgetest"
 	"github.com/MichaelMure/git-bug/bridge/core"
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
@@ -134,10 +134,10 @@ func Test_Importer(t *testing.T) {
 	defer backend.Close()
 	interrupt.RegisterCleaner(backend.Close)
 
-	token := os.Getenv("GITHUB_TOKEN_PRIVATE")
-	if token == "" {
-		t.Skip("Env var GITHUB_TOKEN_PRIVATE missing")
-	}
+	// replayed from testdata/import.json, see bridgetest.NewRecorder
+	recorder, done := bridgetest.NewRecorder(t, "import")
+	defer done()
+	token := bridgetest.Secret(t, recorder, "GITHUB_TOKEN_PRIVATE")
 
 	importer := &githubImporter{}
 	err = importer.Init(core.Configuration{
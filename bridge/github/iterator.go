@@ -203,6 +203,11 @@ func (i *iterator) IssueValue() issueTimeline {
 	return i.timeline.query.Repository.Issues.Nodes[0]
 }
 
+// IssueCount return the total number of issues matched by the query
+func (i *iterator) IssueCount() int {
+	return int(i.timeline.query.Repository.Issues.TotalCount)
+}
+
 // NextTimelineItem return true if there is a next timeline item and increments the index by one.
 // It is used iterates over all the timeline items. Extra queries are made if it is necessary.
 func (i *iterator) NextTimelineItem() bool {
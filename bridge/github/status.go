@@ -0,0 +1,54 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/shurcooL/githubv4"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/cache"
+)
+
+type rateLimitQuery struct {
+	Viewer struct {
+		Login githubv4.String
+	}
+	RateLimit struct {
+		Limit     githubv4.Int
+		Remaining githubv4.Int
+		ResetAt   githubv4.DateTime
+	}
+}
+
+// RemoteStatus validate the token and report on the Github API quota
+func (*Github) RemoteStatus(ctx context.Context, conf core.Configuration) (*core.RemoteStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	q := rateLimitQuery{}
+	err := buildClient(conf[keyToken]).Query(ctx, &q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.RemoteStatus{
+		Login:          string(q.Viewer.Login),
+		QuotaLimit:     int(q.RateLimit.Limit),
+		QuotaRemaining: int(q.RateLimit.Remaining),
+		QuotaReset:     q.RateLimit.ResetAt.Time,
+	}, nil
+}
+
+// MatchBug tell if a bug has been imported from or exported to the configured
+// repository
+func (*Github) MatchBug(conf core.Configuration, excerpt *cache.BugExcerpt) bool {
+	url, ok := excerpt.CreateMetadata[keyGithubUrl]
+	if !ok {
+		return false
+	}
+
+	prefix := fmt.Sprintf("https://github.com/%s/%s/", conf[keyOwner], conf[keyProject])
+	return strings.HasPrefix(strings.ToLower(url), strings.ToLower(prefix))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -35,6 +36,10 @@ type gitlabExporter struct {
 	// cache identifiers used to speed up exporting operations
 	// cleared for each bug
 	cachedOperationIDs map[string]string
+
+	// mapping between git-bug and gitlab labels and statuses
+	labelMapping  *core.LabelMapping
+	statusMapping *core.StatusMapping
 }
 
 // Init .
@@ -45,6 +50,17 @@ func (ge *gitlabExporter) Init(conf core.Configuration) error {
 	ge.identityClient = make(map[string]*gitlab.Client)
 	ge.cachedOperationIDs = make(map[string]string)
 
+	var err error
+	ge.labelMapping, err = core.NewLabelMapping(conf)
+	if err != nil {
+		return err
+	}
+
+	ge.statusMapping, err = core.NewStatusMapping(conf)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -87,14 +103,19 @@ func (ge *gitlabExporter) ExportAll(ctx context.Context, repo *cache.RepoCache,
 	go func() {
 		defer close(out)
 
+		// report the waits for the API quota instead of appearing frozen
+		ctx := core.WithRateLimitHandler(ctx, func(wait time.Duration) {
+			out <- core.NewExportRateLimiting(fmt.Sprintf("waiting %v for GitLab rate limit", wait.Round(time.Second)))
+		})
+
 		allIdentitiesIds := make([]entity.Id, 0, len(ge.identityToken))
 		for id := range ge.identityToken {
 			allIdentitiesIds = append(allIdentitiesIds, entity.Id(id))
 		}
 
-		allBugsIds := repo.AllBugsIds()
+		allBugsIds := core.ExportedBugsIds(repo, ge.conf)
 
-		for _, id := range allBugsIds {
+		for i, id := range allBugsIds {
 			select {
 			case <-ctx.Done():
 				return
@@ -111,15 +132,14 @@ func (ge *gitlabExporter) ExportAll(ctx context.Context, repo *cache.RepoCache,
 				// TODO: compare the Lamport time instead of using the unix time
 				if snapshot.CreatedAt.Before(since) {
 					out <- core.NewExportNothing(b.Id(), "bug created before the since date")
-					continue
-				}
-
-				if snapshot.HasAnyActor(allIdentitiesIds...) {
+				} else if snapshot.HasAnyActor(allIdentitiesIds...) {
 					// try to export the bug and it associated events
 					ge.exportBug(ctx, b, since, out)
 				} else {
 					out <- core.NewExportNothing(id, "not an actor")
 				}
+
+				out <- core.NewExportProgress(i+1, len(allBugsIds))
 			}
 		}
 	}()
@@ -309,7 +329,7 @@ func (ge *gitlabExporter) exportBug(ctx context.Context, b *cache.BugCache, sinc
 			}
 
 		case *bug.SetStatusOperation:
-			if err := updateGitlabIssueStatus(ctx, client, ge.repositoryID, bugGitlabID, op.Status); err != nil {
+			if err := ge.updateGitlabIssueStatus(ctx, client, bugGitlabID, op.Status); err != nil {
 				err := errors.Wrap(err, "editing status")
 				out <- core.NewExportError(err, b.Id())
 				return
@@ -333,11 +353,11 @@ func (ge *gitlabExporter) exportBug(ctx context.Context, b *cache.BugCache, sinc
 			// because gitlab update issue requests need directly the latest list of the verison
 
 			for _, label := range op.Added {
-				labelSet[label.String()] = struct{}{}
+				labelSet[ge.labelMapping.ToRemote(label.String())] = struct{}{}
 			}
 
 			for _, label := range op.Removed {
-				delete(labelSet, label.String())
+				delete(labelSet, ge.labelMapping.ToRemote(label.String()))
 			}
 
 			labels := make([]string, 0, len(labelSet))
@@ -437,22 +457,31 @@ func editCommentGitlabIssue(ctx context.Context, gc *gitlab.Client, repositoryID
 	return err
 }
 
-func updateGitlabIssueStatus(ctx context.Context, gc *gitlab.Client, repositoryID string, issueID int, status bug.Status) error {
+// update gitlab issue status, translated through the configured status
+// mapping. As gitlab can only be told to close or reopen an issue, the ways of
+// closing it (closed via merge request or closed via commit) are exported as
+// closed.
+func (ge *gitlabExporter) updateGitlabIssueStatus(ctx context.Context, gc *gitlab.Client, issueID int, status bug.Status) error {
+	remote, ok := ge.statusMapping.ToRemote(status)
+	if !ok {
+		remote = status.String()
+	}
+
 	var state string
 
-	switch status {
-	case bug.OpenStatus:
+	switch remote = strings.ToLower(remote); {
+	case remote == "open":
 		state = "reopen"
-	case bug.ClosedStatus:
+	case remote == "closed" || strings.HasPrefix(remote, "closed via "):
 		state = "close"
 	default:
-		panic("unknown bug state")
+		return fmt.Errorf("status %s can't be exported to gitlab", remote)
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
 	defer cancel()
 	_, _, err := gc.Issues.UpdateIssue(
-		repositoryID, issueID,
+		ge.repositoryID, issueID,
 		&gitlab.UpdateIssueOptions{
 			StateEvent: &state,
 		},
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
-	"os"
 	"strconv"
 	"testing"
 	"time"
@@ -13,6 +12,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/MichaelMure/git-bug/bridge/bridgetest"
 	"github.com/MichaelMure/git-bug/bridge/core"
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
@@ -134,11 +134,12 @@ func testCases(t *testing.T, repo *cache.RepoCache, identity *cache.IdentityCach
 }
 
 func TestPushPull(t *testing.T) {
+	// replayed from testdata/export.json, see bridgetest.NewRecorder
+	recorder, done := bridgetest.NewRecorder(t, "export")
+	defer done()
+
 	// token must have 'repo' and 'delete_repo' scopes
-	token := os.Getenv("GITLAB_API_TOKEN")
-	if token == "" {
-		t.Skip("Env var GITLAB_API_TOKEN missing")
-	}
+	token := bridgetest.Secret(t, recorder, "GITLAB_API_TOKEN")
 
 	// create repo backend
 	repo := repository.CreateTestRepo(false)
@@ -160,7 +161,7 @@ func TestPushPull(t *testing.T) {
 	tests := testCases(t, backend, author)
 
 	// generate project name
-	projectName := generateRepoName()
+	projectName := recorder.Value("project", generateRepoName)
 
 	// create target Gitlab repository
 	projectID, err := createRepository(context.TODO(), projectName, token)
@@ -195,11 +196,18 @@ func TestPushPull(t *testing.T) {
 	exportEvents, err := exporter.ExportAll(ctx, backend, time.Time{})
 	require.NoError(t, err)
 
+	exportedBugs := 0
 	for result := range exportEvents {
 		require.NoError(t, result.Err)
+		if result.Event == core.ExportEventBug {
+			exportedBugs++
+		}
 	}
 	require.NoError(t, err)
 
+	// every test case is a new bug
+	require.Equal(t, len(tests), exportedBugs)
+
 	fmt.Printf("test repository exported in %f seconds\n", time.Since(start).Seconds())
 
 	repoTwo := repository.CreateTestRepo(false)
@@ -1,12 +1,12 @@
 package gitlab
 
 import (
-	"net/http"
 	"time"
 
 	"github.com/xanzy/go-gitlab"
 
 	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/repository"
 )
 
 const (
@@ -33,6 +33,12 @@ func (*Gitlab) Target() string {
 	return target
 }
 
+// LoginMetadataKey return the metadata key recording the Gitlab login of the
+// identities
+func (*Gitlab) LoginMetadataKey() string {
+	return keyGitlabLogin
+}
+
 func (*Gitlab) NewImporter() core.Importer {
 	return &gitlabImporter{}
 }
@@ -42,9 +48,15 @@ func (*Gitlab) NewExporter() core.Exporter {
 }
 
 func buildClient(token string) *gitlab.Client {
-	client := &http.Client{
-		Timeout: defaultTimeout,
-	}
+	client := core.NewRetryHTTPClient(defaultTimeout)
+
+	return gitlab.NewClient(client, token)
+}
+
+// buildCachedClient build a client caching the responses on disk, to save
+// API quota and time when pulling unchanged resources again
+func buildCachedClient(repo repository.RepoCommon, token string) *gitlab.Client {
+	client := core.NewCachedHTTPClient(repo, defaultTimeout)
 
 	return gitlab.NewClient(client, token)
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
 	"github.com/MichaelMure/git-bug/util/text"
 )
 
@@ -19,6 +20,22 @@ import (
 type gitlabImporter struct {
 	conf core.Configuration
 
+	// mapping between gitlab and git-bug labels and statuses
+	labelMapping  *core.LabelMapping
+	statusMapping *core.StatusMapping
+
+	// mapping of remote identities to local ones
+	identityMapping core.IdentityMapping
+
+	// index of the bugs by url, to reconcile with other bridges
+	urlIndex *core.URLIndex
+
+	// how to react to an issue failing to import
+	errorPolicy core.ErrorPolicy
+
+	// client, caching the responses on disk
+	client *gitlab.Client
+
 	// iterator
 	iterator *iterator
 
@@ -28,57 +45,74 @@ type gitlabImporter struct {
 
 func (gi *gitlabImporter) Init(conf core.Configuration) error {
 	gi.conf = conf
+
+	var err error
+	gi.labelMapping, err = core.NewLabelMapping(conf)
+	if err != nil {
+		return err
+	}
+
+	gi.statusMapping, err = core.NewStatusMapping(conf)
+	if err != nil {
+		return err
+	}
+
+	gi.errorPolicy, err = core.NewErrorPolicy(conf)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // ImportAll iterate over all the configured repository issues (notes) and ensure the creation
 // of the missing issues / comments / label events / title changes ...
 func (gi *gitlabImporter) ImportAll(ctx context.Context, repo *cache.RepoCache, since time.Time) (<-chan core.ImportResult, error) {
-	gi.iterator = NewIterator(ctx, 10, gi.conf[keyProjectID], gi.conf[keyToken], since)
+	identityMapping, err := core.LoadIdentityMapping(repo)
+	if err != nil {
+		return nil, err
+	}
+	gi.identityMapping = identityMapping
+
+	gi.urlIndex, err = core.NewURLIndex(repo)
+	if err != nil {
+		return nil, err
+	}
+
 	out := make(chan core.ImportResult)
 	gi.out = out
 
+	// report the waits for the API quota instead of appearing frozen
+	ctx = core.WithRateLimitHandler(ctx, func(wait time.Duration) {
+		out <- core.NewImportRateLimiting(fmt.Sprintf("waiting %v for GitLab rate limit", wait.Round(time.Second)))
+	})
+
+	gi.client = buildCachedClient(repo, gi.conf[keyToken])
+	gi.iterator = NewIterator(ctx, gi.client, 10, gi.conf[keyProjectID], since)
+
 	go func() {
 		defer close(gi.out)
 
+		processed := 0
+
 		// Loop over all matching issues
 		for gi.iterator.NextIssue() {
 			issue := gi.iterator.IssueValue()
 
-			// create issue
-			b, err := gi.ensureIssue(repo, issue)
-			if err != nil {
-				err := fmt.Errorf("issue creation: %v", err)
-				out <- core.NewImportError(err, "")
+			failedId, err := gi.importIssue(ctx, repo, issue)
+			if err != nil && ctx.Err() != nil {
+				// interrupted, the issue was rolled back
 				return
 			}
-
-			// Loop over all notes
-			for gi.iterator.NextNote() {
-				note := gi.iterator.NoteValue()
-				if err := gi.ensureNote(repo, b, note); err != nil {
-					err := fmt.Errorf("note creation: %v", err)
-					out <- core.NewImportError(err, entity.Id(strconv.Itoa(note.ID)))
-					return
-				}
-			}
-
-			// Loop over all label events
-			for gi.iterator.NextLabelEvent() {
-				labelEvent := gi.iterator.LabelEventValue()
-				if err := gi.ensureLabelEvent(repo, b, labelEvent); err != nil {
-					err := fmt.Errorf("label event creation: %v", err)
-					out <- core.NewImportError(err, entity.Id(strconv.Itoa(labelEvent.ID)))
+			if err != nil {
+				out <- core.NewImportError(err, failedId)
+				if gi.errorPolicy == core.ErrorPolicyFailFast {
 					return
 				}
 			}
 
-			// commit bug state
-			if err := b.CommitAsNeeded(); err != nil {
-				err := fmt.Errorf("bug commit: %v", err)
-				out <- core.NewImportError(err, "")
-				return
-			}
+			processed++
+			out <- core.NewImportProgress(processed, gi.iterator.IssueCount())
 		}
 
 		if err := gi.iterator.Error(); err != nil {
@@ -89,7 +123,58 @@ func (gi *gitlabImporter) ImportAll(ctx context.Context, repo *cache.RepoCache,
 	return out, nil
 }
 
-func (gi *gitlabImporter) ensureIssue(repo *cache.RepoCache, issue *gitlab.Issue) (*cache.BugCache, error) {
+// importIssue import an issue with its notes and label events. The import is
+// transactional: on error, nothing of the issue is committed and the id of the
+// failing gitlab entity is returned if known.
+func (gi *gitlabImporter) importIssue(ctx context.Context, repo *cache.RepoCache, issue *gitlab.Issue) (entity.Id, error) {
+	tx := core.NewImportTransaction(ctx, repo, gi.out)
+
+	failedId, err := gi.importIssueTx(repo, tx, issue)
+	if err == nil {
+		// commit bug state
+		if errCommit := tx.Commit(); errCommit != nil {
+			err = fmt.Errorf("bug commit: %v", errCommit)
+		}
+	}
+	if err != nil {
+		if errRollback := tx.Rollback(); errRollback != nil {
+			return failedId, fmt.Errorf("%v (rollback failed: %v)", err, errRollback)
+		}
+		return failedId, err
+	}
+
+	gi.urlIndex.Add(issue.WebURL, tx.Bug().Id())
+
+	return "", nil
+}
+
+func (gi *gitlabImporter) importIssueTx(repo *cache.RepoCache, tx *core.ImportTransaction, issue *gitlab.Issue) (entity.Id, error) {
+	// create issue
+	b, err := gi.ensureIssue(repo, tx, issue)
+	if err != nil {
+		return "", fmt.Errorf("issue creation: %v", err)
+	}
+
+	// Loop over all notes
+	for gi.iterator.NextNote() {
+		note := gi.iterator.NoteValue()
+		if err := gi.ensureNote(repo, tx, b, note); err != nil {
+			return entity.Id(strconv.Itoa(note.ID)), fmt.Errorf("note creation: %v", err)
+		}
+	}
+
+	// Loop over all label events
+	for gi.iterator.NextLabelEvent() {
+		labelEvent := gi.iterator.LabelEventValue()
+		if err := gi.ensureLabelEvent(repo, tx, b, labelEvent); err != nil {
+			return entity.Id(strconv.Itoa(labelEvent.ID)), fmt.Errorf("label event creation: %v", err)
+		}
+	}
+
+	return "", nil
+}
+
+func (gi *gitlabImporter) ensureIssue(repo *cache.RepoCache, tx *core.ImportTransaction, issue *gitlab.Issue) (*cache.BugCache, error) {
 	// ensure issue author
 	author, err := gi.ensurePerson(repo, issue.Author.ID)
 	if err != nil {
@@ -99,7 +184,26 @@ func (gi *gitlabImporter) ensureIssue(repo *cache.RepoCache, issue *gitlab.Issue
 	// resolve bug
 	b, err := repo.ResolveBugCreateMetadata(keyGitlabUrl, issue.WebURL)
 	if err == nil {
-		gi.out <- core.NewImportNothing("", "bug already imported")
+		tx.Track(b)
+		tx.Report(core.NewImportNothing("", "bug already imported"))
+		return b, nil
+	}
+	if err != bug.ErrBugNotExist {
+		return nil, err
+	}
+
+	metadata := map[string]string{
+		core.KeyOrigin:   target,
+		keyGitlabId:      parseID(issue.IID),
+		keyGitlabUrl:     issue.WebURL,
+		keyGitlabProject: gi.conf[keyProjectID],
+	}
+
+	// the same issue might have been imported through another bridge
+	b, err = gi.urlIndex.Reconcile(issue.WebURL, author, issue.CreatedAt.Unix(), metadata)
+	if err == nil {
+		tx.Track(b)
+		tx.Report(core.NewImportNothing(b.Id(), "bug already imported by another bridge"))
 		return b, nil
 	}
 	if err != bug.ErrBugNotExist {
@@ -113,18 +217,13 @@ func (gi *gitlabImporter) ensureIssue(repo *cache.RepoCache, issue *gitlab.Issue
 	}
 
 	// create bug
-	b, _, err = repo.NewBugRaw(
+	b, _, err = tx.NewBugRaw(
 		author,
 		issue.CreatedAt.Unix(),
 		issue.Title,
 		cleanText,
 		nil,
-		map[string]string{
-			core.KeyOrigin:   target,
-			keyGitlabId:      parseID(issue.IID),
-			keyGitlabUrl:     issue.WebURL,
-			keyGitlabProject: gi.conf[keyProjectID],
-		},
+		metadata,
 	)
 
 	if err != nil {
@@ -132,12 +231,12 @@ func (gi *gitlabImporter) ensureIssue(repo *cache.RepoCache, issue *gitlab.Issue
 	}
 
 	// importing a new bug
-	gi.out <- core.NewImportBug(b.Id())
+	tx.Report(core.NewImportBug(b.Id()))
 
 	return b, nil
 }
 
-func (gi *gitlabImporter) ensureNote(repo *cache.RepoCache, b *cache.BugCache, note *gitlab.Note) error {
+func (gi *gitlabImporter) ensureNote(repo *cache.RepoCache, tx *core.ImportTransaction, b *cache.BugCache, note *gitlab.Note) error {
 	gitlabID := parseID(note.ID)
 
 	id, errResolve := b.ResolveOperationWithMetadata(keyGitlabId, gitlabID)
@@ -158,36 +257,42 @@ func (gi *gitlabImporter) ensureNote(repo *cache.RepoCache, b *cache.BugCache, n
 			return nil
 		}
 
-		op, err := b.CloseRaw(
+		remotes := []string{bug.ClosedStatus.String()}
+		if body != "" {
+			remotes = append([]string{"closed via " + body}, remotes...)
+		}
+		op, err := gi.ensureStatus(
+			b,
 			author,
 			note.CreatedAt.Unix(),
-			map[string]string{
-				keyGitlabId: gitlabID,
-			},
+			remotes,
+			bug.ClosedStatus,
+			gitlabID,
 		)
 		if err != nil {
 			return err
 		}
 
-		gi.out <- core.NewImportStatusChange(op.Id())
+		tx.Report(core.NewImportStatusChange(op.Id()))
 
 	case NOTE_REOPENED:
 		if errResolve == nil {
 			return nil
 		}
 
-		op, err := b.OpenRaw(
+		op, err := gi.ensureStatus(
+			b,
 			author,
 			note.CreatedAt.Unix(),
-			map[string]string{
-				keyGitlabId: gitlabID,
-			},
+			[]string{bug.OpenStatus.String()},
+			bug.OpenStatus,
+			gitlabID,
 		)
 		if err != nil {
 			return err
 		}
 
-		gi.out <- core.NewImportStatusChange(op.Id())
+		tx.Report(core.NewImportStatusChange(op.Id()))
 
 	case NOTE_DESCRIPTION_CHANGED:
 		issue := gi.iterator.IssueValue()
@@ -211,7 +316,7 @@ func (gi *gitlabImporter) ensureNote(repo *cache.RepoCache, b *cache.BugCache, n
 				return err
 			}
 
-			gi.out <- core.NewImportTitleEdition(op.Id())
+			tx.Report(core.NewImportTitleEdition(op.Id()))
 		}
 
 	case NOTE_COMMENT:
@@ -236,7 +341,7 @@ func (gi *gitlabImporter) ensureNote(repo *cache.RepoCache, b *cache.BugCache, n
 			if err != nil {
 				return err
 			}
-			gi.out <- core.NewImportComment(op.Id())
+			tx.Report(core.NewImportComment(op.Id()))
 			return nil
 		}
 
@@ -262,7 +367,7 @@ func (gi *gitlabImporter) ensureNote(repo *cache.RepoCache, b *cache.BugCache, n
 			if err != nil {
 				return err
 			}
-			gi.out <- core.NewImportCommentEdition(op.Id())
+			tx.Report(core.NewImportCommentEdition(op.Id()))
 		}
 
 		return nil
@@ -285,7 +390,7 @@ func (gi *gitlabImporter) ensureNote(repo *cache.RepoCache, b *cache.BugCache, n
 			return err
 		}
 
-		gi.out <- core.NewImportTitleEdition(op.Id())
+		tx.Report(core.NewImportTitleEdition(op.Id()))
 
 	case NOTE_UNKNOWN,
 		NOTE_ASSIGNED,
@@ -300,7 +405,7 @@ func (gi *gitlabImporter) ensureNote(repo *cache.RepoCache, b *cache.BugCache, n
 		NOTE_MENTIONED_IN_MERGE_REQUEST:
 
 		reason := fmt.Sprintf("unsupported note type: %s", noteType.String())
-		gi.out <- core.NewImportNothing("", reason)
+		tx.Report(core.NewImportNothing("", reason))
 		return nil
 
 	default:
@@ -310,7 +415,43 @@ func (gi *gitlabImporter) ensureNote(repo *cache.RepoCache, b *cache.BugCache, n
 	return nil
 }
 
-func (gi *gitlabImporter) ensureLabelEvent(repo *cache.RepoCache, b *cache.BugCache, labelEvent *gitlab.LabelEvent) error {
+// ensureStatus apply a gitlab status change to the bug, translated through the
+// configured status mapping from the most precise remote status: how the issue
+// got closed (closed via merge request or closed via commit), then open or
+// closed. The label of the mapping, if any, replace the ones of the other
+// statuses, in an operation tagged with the gitlab id of the note as well so
+// that it's not exported.
+func (gi *gitlabImporter) ensureStatus(b *cache.BugCache, author *cache.IdentityCache, unixTime int64, remotes []string, fallback bug.Status, id string) (*bug.SetStatusOperation, error) {
+	status, label := gi.statusMapping.Resolve(remotes, fallback)
+
+	var op *bug.SetStatusOperation
+	var err error
+
+	switch status {
+	case bug.OpenStatus:
+		op, err = b.OpenRaw(author, unixTime, map[string]string{keyGitlabId: id})
+	case bug.ClosedStatus:
+		op, err = b.CloseRaw(author, unixTime, map[string]string{keyGitlabId: id})
+	default:
+		return nil, fmt.Errorf("unexpected status %v", status)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	added, removed := gi.statusMapping.LabelChanges(b.Snapshot(), label)
+	if len(added) > 0 || len(removed) > 0 {
+		metadata := map[string]string{keyGitlabId: id + core.StatusLabelSuffix}
+		_, err = b.ForceChangeLabelsRaw(author, unixTime, added, removed, metadata)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return op, nil
+}
+
+func (gi *gitlabImporter) ensureLabelEvent(repo *cache.RepoCache, tx *core.ImportTransaction, b *cache.BugCache, labelEvent *gitlab.LabelEvent) error {
 	_, err := b.ResolveOperationWithMetadata(keyGitlabId, parseID(labelEvent.ID))
 	if err != cache.ErrNoMatchingOp {
 		return err
@@ -327,7 +468,7 @@ func (gi *gitlabImporter) ensureLabelEvent(repo *cache.RepoCache, b *cache.BugCa
 		_, err = b.ForceChangeLabelsRaw(
 			author,
 			labelEvent.CreatedAt.Unix(),
-			[]string{labelEvent.Label.Name},
+			[]string{gi.labelMapping.ToLocal(labelEvent.Label.Name)},
 			nil,
 			map[string]string{
 				keyGitlabId: parseID(labelEvent.ID),
@@ -339,7 +480,7 @@ func (gi *gitlabImporter) ensureLabelEvent(repo *cache.RepoCache, b *cache.BugCa
 			author,
 			labelEvent.CreatedAt.Unix(),
 			nil,
-			[]string{labelEvent.Label.Name},
+			[]string{gi.labelMapping.ToLocal(labelEvent.Label.Name)},
 			map[string]string{
 				keyGitlabId: parseID(labelEvent.ID),
 			},
@@ -362,13 +503,39 @@ func (gi *gitlabImporter) ensurePerson(repo *cache.RepoCache, id int) (*cache.Id
 		return nil, err
 	}
 
-	client := buildClient(gi.conf["token"])
+	// Then look in the identity mapping
+	i, err = gi.identityMapping.Resolve(repo, keyGitlabId, strconv.Itoa(id))
+	if err == nil {
+		return i, nil
+	}
+	if err != identity.ErrIdentityNotExist {
+		return nil, err
+	}
 
-	user, _, err := client.Users.GetUser(id)
+	user, _, err := gi.client.Users.GetUser(id)
 	if err != nil {
 		return nil, err
 	}
 
+	// the account can have been attached to an identity by its login, only
+	// known at that point
+	i, err = repo.ResolveIdentityImmutableMetadata(keyGitlabLogin, user.Username)
+	if err == nil {
+		return i, nil
+	}
+	if err != identity.ErrIdentityNotExist {
+		return nil, err
+	}
+
+	// the mapping can also use the login
+	i, err = gi.identityMapping.Resolve(repo, keyGitlabLogin, user.Username)
+	if err == nil {
+		return i, nil
+	}
+	if err != identity.ErrIdentityNotExist {
+		return nil, err
+	}
+
 	i, err = repo.NewIdentityRaw(
 		user.Name,
 		user.PublicEmail,
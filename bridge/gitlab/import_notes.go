@@ -80,6 +80,15 @@ func GetNoteType(n *gitlab.Note) (NoteType, string) {
 		return NOTE_CLOSED, ""
 	}
 
+	// the content is how the issue got closed
+	if strings.HasPrefix(n.Body, "closed via merge request") {
+		return NOTE_CLOSED, "merge request"
+	}
+
+	if strings.HasPrefix(n.Body, "closed via commit") {
+		return NOTE_CLOSED, "commit"
+	}
+
 	if n.Body == "reopened" {
 		return NOTE_REOPENED, ""
 	}
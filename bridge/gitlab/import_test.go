@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/MichaelMure/git-bug/bridge/bridgetest"
 	"github.com/MichaelMure/git-bug/bridge/core"
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
@@ -83,12 +84,12 @@ func TestImport(t *testing.T) {
 	defer backend.Close()
 	interrupt.RegisterCleaner(backend.Close)
 
-	token := os.Getenv("GITLAB_API_TOKEN")
-	if token == "" {
-		t.Skip("Env var GITLAB_API_TOKEN missing")
-	}
+	// replayed from testdata/import.json, see bridgetest.NewRecorder
+	recorder, done := bridgetest.NewRecorder(t, "import")
+	defer done()
+	token := bridgetest.Secret(t, recorder, "GITLAB_API_TOKEN")
 
-	projectID := os.Getenv("GITLAB_PROJECT_ID")
+	projectID := recorder.Value("project", func() string { return os.Getenv("GITLAB_PROJECT_ID") })
 	if projectID == "" {
 		t.Skip("Env var GITLAB_PROJECT_ID missing")
 	}
@@ -10,6 +10,7 @@ import (
 type issueIterator struct {
 	page  int
 	index int
+	total int
 	cache []*gitlab.Issue
 }
 
@@ -56,9 +57,9 @@ type iterator struct {
 }
 
 // NewIterator create a new iterator
-func NewIterator(ctx context.Context, capacity int, projectID, token string, since time.Time) *iterator {
+func NewIterator(ctx context.Context, gc *gitlab.Client, capacity int, projectID string, since time.Time) *iterator {
 	return &iterator{
-		gc:       buildClient(token),
+		gc:       gc,
 		project:  projectID,
 		since:    since,
 		capacity: capacity,
@@ -87,7 +88,7 @@ func (i *iterator) getNextIssues() bool {
 	ctx, cancel := context.WithTimeout(i.ctx, defaultTimeout)
 	defer cancel()
 
-	issues, _, err := i.gc.Issues.ListProjectIssues(
+	issues, resp, err := i.gc.Issues.ListProjectIssues(
 		i.project,
 		&gitlab.ListProjectIssuesOptions{
 			ListOptions: gitlab.ListOptions{
@@ -112,6 +113,7 @@ func (i *iterator) getNextIssues() bool {
 	}
 
 	i.issue.cache = issues
+	i.issue.total = resp.TotalItems
 	i.issue.index = 0
 	i.issue.page++
 	i.note.index = -1
@@ -147,6 +149,11 @@ func (i *iterator) IssueValue() *gitlab.Issue {
 	return i.issue.cache[i.issue.index]
 }
 
+// IssueCount return the total number of issues matched by the query
+func (i *iterator) IssueCount() int {
+	return i.issue.total
+}
+
 func (i *iterator) getNextNotes() bool {
 	ctx, cancel := context.WithTimeout(i.ctx, defaultTimeout)
 	defer cancel()
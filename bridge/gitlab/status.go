@@ -0,0 +1,42 @@
+package gitlab
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/cache"
+)
+
+// RemoteStatus validate the token and report on the Gitlab API quota, if
+// the instance expose it
+func (*Gitlab) RemoteStatus(ctx context.Context, conf core.Configuration) (*core.RemoteStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	user, resp, err := buildClient(conf[keyToken]).Users.CurrentUser(gitlab.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	status := &core.RemoteStatus{
+		Login: user.Username,
+	}
+
+	status.QuotaLimit, _ = strconv.Atoi(resp.Header.Get("RateLimit-Limit"))
+	status.QuotaRemaining, _ = strconv.Atoi(resp.Header.Get("RateLimit-Remaining"))
+	if reset, err := strconv.ParseInt(resp.Header.Get("RateLimit-Reset"), 10, 64); err == nil {
+		status.QuotaReset = time.Unix(reset, 0)
+	}
+
+	return status, nil
+}
+
+// MatchBug tell if a bug has been imported from or exported to the configured
+// project
+func (*Gitlab) MatchBug(conf core.Configuration, excerpt *cache.BugExcerpt) bool {
+	return excerpt.CreateMetadata[keyGitlabProject] == conf[keyProjectID]
+}
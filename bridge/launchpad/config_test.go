@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/MichaelMure/git-bug/bridge/bridgetest"
 )
 
 func TestSplitURL(t *testing.T) {
@@ -60,6 +62,10 @@ func TestSplitURL(t *testing.T) {
 }
 
 func TestValidateProject(t *testing.T) {
+	// replayed from testdata/validate_project.json, see bridgetest.NewRecorder
+	_, done := bridgetest.NewRecorder(t, "validate_project")
+	defer done()
+
 	type args struct {
 		project string
 	}
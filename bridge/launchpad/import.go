@@ -9,22 +9,41 @@ import (
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
 )
 
 type launchpadImporter struct {
 	conf core.Configuration
+
+	// mapping of remote identities to local ones
+	identityMapping core.IdentityMapping
+
+	// how to react to a bug failing to import
+	errorPolicy core.ErrorPolicy
+
+	// mapping between launchpad and git-bug statuses
+	statusMapping *core.StatusMapping
 }
 
 func (li *launchpadImporter) Init(conf core.Configuration) error {
 	li.conf = conf
-	return nil
+
+	var err error
+	li.errorPolicy, err = core.NewErrorPolicy(conf)
+	if err != nil {
+		return err
+	}
+
+	li.statusMapping, err = core.NewStatusMapping(conf)
+	return err
 }
 
 const keyLaunchpadID = "launchpad-id"
 const keyLaunchpadLogin = "launchpad-login"
 
 func (li *launchpadImporter) ensurePerson(repo *cache.RepoCache, owner LPPerson) (*cache.IdentityCache, error) {
-	// Look first in the cache
+	// Look first in the cache, including the identities the account has been
+	// attached to
 	i, err := repo.ResolveIdentityImmutableMetadata(keyLaunchpadLogin, owner.Login)
 	if err == nil {
 		return i, nil
@@ -33,6 +52,15 @@ func (li *launchpadImporter) ensurePerson(repo *cache.RepoCache, owner LPPerson)
 		return nil, err
 	}
 
+	// Then look in the identity mapping
+	i, err = li.identityMapping.Resolve(repo, keyLaunchpadLogin, owner.Login)
+	if err == nil {
+		return i, nil
+	}
+	if err != identity.ErrIdentityNotExist {
+		return nil, err
+	}
+
 	return repo.NewIdentityRaw(
 		owner.Name,
 		"",
@@ -45,10 +73,16 @@ func (li *launchpadImporter) ensurePerson(repo *cache.RepoCache, owner LPPerson)
 }
 
 func (li *launchpadImporter) ImportAll(ctx context.Context, repo *cache.RepoCache, since time.Time) (<-chan core.ImportResult, error) {
+	identityMapping, err := core.LoadIdentityMapping(repo)
+	if err != nil {
+		return nil, err
+	}
+	li.identityMapping = identityMapping
+
 	out := make(chan core.ImportResult)
 	lpAPI := new(launchpadAPI)
 
-	err := lpAPI.Init()
+	err = lpAPI.Init(repo)
 	if err != nil {
 		return nil, err
 	}
@@ -59,101 +93,196 @@ func (li *launchpadImporter) ImportAll(ctx context.Context, repo *cache.RepoCach
 	}
 
 	go func() {
-		for _, lpBug := range lpBugs {
+		defer close(out)
+
+		for i, lpBug := range lpBugs {
 			select {
 			case <-ctx.Done():
 				return
 			default:
-				lpBugID := fmt.Sprintf("%d", lpBug.ID)
-				b, err := repo.ResolveBugCreateMetadata(keyLaunchpadID, lpBugID)
-				if err != nil && err != bug.ErrBugNotExist {
-					out <- core.NewImportError(err, entity.Id(lpBugID))
+				failedId, err := li.importBug(ctx, repo, lpBug, out)
+				if err != nil && ctx.Err() != nil {
+					// interrupted, the bug was rolled back
 					return
 				}
-
-				owner, err := li.ensurePerson(repo, lpBug.Owner)
 				if err != nil {
-					out <- core.NewImportError(err, entity.Id(lpBugID))
-					return
-				}
-
-				if err == bug.ErrBugNotExist {
-					createdAt, _ := time.Parse(time.RFC3339, lpBug.CreatedAt)
-					b, _, err = repo.NewBugRaw(
-						owner,
-						createdAt.Unix(),
-						lpBug.Title,
-						lpBug.Description,
-						nil,
-						map[string]string{
-							keyLaunchpadID: lpBugID,
-						},
-					)
-					if err != nil {
-						out <- core.NewImportError(err, entity.Id(lpBugID))
+					out <- core.NewImportError(err, failedId)
+					if li.errorPolicy == core.ErrorPolicyFailFast {
 						return
 					}
+				}
 
-					out <- core.NewImportBug(b.Id())
+				out <- core.NewImportProgress(i+1, len(lpBugs))
+			}
+		}
+	}()
 
-				}
+	return out, nil
+}
 
-				/* Handle messages */
-				if len(lpBug.Messages) == 0 {
-					err := fmt.Sprintf("bug doesn't have any comments")
-					out <- core.NewImportNothing(entity.Id(lpBugID), err)
-					return
-				}
+// importBug import a launchpad bug with its messages. The import is
+// transactional: on error, nothing of the bug is committed and the id of the
+// failing launchpad entity is returned if known.
+func (li *launchpadImporter) importBug(ctx context.Context, repo *cache.RepoCache, lpBug LPBug, out chan<- core.ImportResult) (entity.Id, error) {
+	tx := core.NewImportTransaction(ctx, repo, out)
 
-				// The Launchpad API returns the bug description as the first
-				// comment, so skip it.
-				for _, lpMessage := range lpBug.Messages[1:] {
-					_, err := b.ResolveOperationWithMetadata(keyLaunchpadID, lpMessage.ID)
-					if err != nil && err != cache.ErrNoMatchingOp {
-						out <- core.NewImportError(err, entity.Id(lpMessage.ID))
-						return
-					}
+	failedId, err := li.importBugTx(repo, tx, lpBug)
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		if errRollback := tx.Rollback(); errRollback != nil {
+			return failedId, fmt.Errorf("%v (rollback failed: %v)", err, errRollback)
+		}
+		return failedId, err
+	}
 
-					// If this comment already exists, we are probably
-					// updating an existing bug. We do not want to duplicate
-					// the comments, so let us just skip this one.
-					// TODO: Can Launchpad comments be edited?
-					if err == nil {
-						continue
-					}
+	return "", nil
+}
 
-					owner, err := li.ensurePerson(repo, lpMessage.Owner)
-					if err != nil {
-						out <- core.NewImportError(err, "")
-						return
-					}
+func (li *launchpadImporter) importBugTx(repo *cache.RepoCache, tx *core.ImportTransaction, lpBug LPBug) (entity.Id, error) {
+	lpBugID := fmt.Sprintf("%d", lpBug.ID)
+	b, err := repo.ResolveBugCreateMetadata(keyLaunchpadID, lpBugID)
+	if err != nil && err != bug.ErrBugNotExist {
+		return entity.Id(lpBugID), err
+	}
+	if err == nil {
+		tx.Track(b)
+	}
 
-					// This is a new comment, we can add it.
-					createdAt, _ := time.Parse(time.RFC3339, lpMessage.CreatedAt)
-					op, err := b.AddCommentRaw(
-						owner,
-						createdAt.Unix(),
-						lpMessage.Content,
-						nil,
-						map[string]string{
-							keyLaunchpadID: lpMessage.ID,
-						})
-					if err != nil {
-						out <- core.NewImportError(err, op.Id())
-						return
-					}
+	if err == bug.ErrBugNotExist {
+		owner, err := li.ensurePerson(repo, lpBug.Owner)
+		if err != nil {
+			return entity.Id(lpBugID), err
+		}
 
-					out <- core.NewImportComment(op.Id())
-				}
+		createdAt, _ := time.Parse(time.RFC3339, lpBug.CreatedAt)
+		b, _, err = tx.NewBugRaw(
+			owner,
+			createdAt.Unix(),
+			lpBug.Title,
+			lpBug.Description,
+			nil,
+			map[string]string{
+				keyLaunchpadID: lpBugID,
+			},
+		)
+		if err != nil {
+			return entity.Id(lpBugID), err
+		}
 
-				err = b.CommitAsNeeded()
-				if err != nil {
-					out <- core.NewImportError(err, "")
-					return
-				}
-			}
+		tx.Report(core.NewImportBug(b.Id()))
+	}
+
+	if err := li.ensureStatus(repo, tx, b, lpBug); err != nil {
+		return entity.Id(lpBugID), err
+	}
+
+	/* Handle messages */
+	if len(lpBug.Messages) == 0 {
+		tx.Report(core.NewImportNothing(entity.Id(lpBugID), "bug doesn't have any comments"))
+		return "", nil
+	}
+
+	// The Launchpad API returns the bug description as the first
+	// comment, so skip it.
+	for _, lpMessage := range lpBug.Messages[1:] {
+		_, err := b.ResolveOperationWithMetadata(keyLaunchpadID, lpMessage.ID)
+		if err != nil && err != cache.ErrNoMatchingOp {
+			return entity.Id(lpMessage.ID), err
 		}
-	}()
 
-	return out, nil
+		// If this comment already exists, we are probably
+		// updating an existing bug. We do not want to duplicate
+		// the comments, so let us just skip this one.
+		// TODO: Can Launchpad comments be edited?
+		if err == nil {
+			continue
+		}
+
+		owner, err := li.ensurePerson(repo, lpMessage.Owner)
+		if err != nil {
+			return "", err
+		}
+
+		// This is a new comment, we can add it.
+		createdAt, _ := time.Parse(time.RFC3339, lpMessage.CreatedAt)
+		op, err := b.AddCommentRaw(
+			owner,
+			createdAt.Unix(),
+			lpMessage.Content,
+			nil,
+			map[string]string{
+				keyLaunchpadID: lpMessage.ID,
+			})
+		if err != nil {
+			return entity.Id(lpMessage.ID), err
+		}
+
+		tx.Report(core.NewImportComment(op.Id()))
+	}
+
+	return "", nil
+}
+
+// closedStatuses are the launchpad statuses of a bug no longer needing work,
+// imported as closed without a status mapping
+var closedStatuses = map[string]bool{
+	"Opinion":      true,
+	"Invalid":      true,
+	"Won't Fix":    true,
+	"Expired":      true,
+	"Fix Released": true,
+}
+
+// ensureStatus bring the bug to the current launchpad status, translated
+// through the configured status mapping. As launchpad doesn't provide the
+// history of the statuses, only a change of the current one is imported.
+func (li *launchpadImporter) ensureStatus(repo *cache.RepoCache, tx *core.ImportTransaction, b *cache.BugCache, lpBug LPBug) error {
+	fallback := bug.OpenStatus
+	if closedStatuses[lpBug.Status] {
+		fallback = bug.ClosedStatus
+	}
+	status, label := li.statusMapping.Resolve([]string{lpBug.Status}, fallback)
+
+	snap := b.Snapshot()
+	added, removed := li.statusMapping.LabelChanges(snap, label)
+	if snap.Status == status && len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	// launchpad doesn't tell who changed the status, so it's attributed to
+	// the reporter of the bug
+	author, err := li.ensurePerson(repo, lpBug.Owner)
+	if err != nil {
+		return err
+	}
+
+	updatedAt, _ := time.Parse(time.RFC3339, lpBug.UpdatedAt)
+	metadata := map[string]string{
+		keyLaunchpadID: fmt.Sprintf("%d", lpBug.ID),
+	}
+
+	if snap.Status != status {
+		var op *bug.SetStatusOperation
+		if status == bug.ClosedStatus {
+			op, err = b.CloseRaw(author, updatedAt.Unix(), metadata)
+		} else {
+			op, err = b.OpenRaw(author, updatedAt.Unix(), metadata)
+		}
+		if err != nil {
+			return err
+		}
+		tx.Report(core.NewImportStatusChange(op.Id()))
+	}
+
+	if len(added) > 0 || len(removed) > 0 {
+		op, err := b.ForceChangeLabelsRaw(author, updatedAt.Unix(), added, removed, metadata)
+		if err != nil {
+			return err
+		}
+		tx.Report(core.NewImportLabelChange(op.Id()))
+	}
+
+	return nil
 }
@@ -0,0 +1,104 @@
+package launchpad
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/bridge/bridgetest"
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+)
+
+func TestImport(t *testing.T) {
+	author := identity.NewIdentity("Michael Muré", "")
+	tests := []struct {
+		name string
+		id   string
+		bug  *bug.Snapshot
+	}{
+		{
+			name: "simple bug",
+			id:   "1839719",
+			bug: &bug.Snapshot{
+				Operations: []bug.Operation{
+					bug.NewCreateOp(author, 0, "simple bug", "initial comment", nil),
+					bug.NewAddCommentOp(author, 0, "first comment", nil),
+				},
+			},
+		},
+		{
+			name: "released bug",
+			id:   "1839722",
+			bug: &bug.Snapshot{
+				Operations: []bug.Operation{
+					bug.NewCreateOp(author, 0, "released bug", "initial comment", nil),
+					bug.NewSetStatusOp(author, 0, bug.ClosedStatus),
+					bug.NewAddCommentOp(author, 0, "fixed in the next release", nil),
+				},
+			},
+		},
+	}
+
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	backend, err := cache.NewRepoCache(repo)
+	require.NoError(t, err)
+
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	// replayed from testdata/import.json, see bridgetest.NewRecorder
+	_, done := bridgetest.NewRecorder(t, "import")
+	defer done()
+
+	importer := &launchpadImporter{}
+	err = importer.Init(core.Configuration{
+		"project": "git-bug-test-launchpad-bridge",
+	})
+	require.NoError(t, err)
+
+	events, err := importer.ImportAll(context.Background(), backend, time.Time{})
+	require.NoError(t, err)
+
+	for result := range events {
+		require.NoError(t, result.Err)
+	}
+
+	require.Len(t, backend.AllBugsIds(), len(tests))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := backend.ResolveBugCreateMetadata(keyLaunchpadID, tt.id)
+			require.NoError(t, err)
+
+			ops := b.Snapshot().Operations
+			require.Len(t, ops, len(tt.bug.Operations))
+
+			for i, op := range tt.bug.Operations {
+				require.IsType(t, op, ops[i])
+				assert.Equal(t, op.GetAuthor().Name(), ops[i].GetAuthor().Name())
+
+				switch op := op.(type) {
+				case *bug.CreateOperation:
+					assert.Equal(t, op.Title, ops[i].(*bug.CreateOperation).Title)
+					assert.Equal(t, op.Message, ops[i].(*bug.CreateOperation).Message)
+				case *bug.SetStatusOperation:
+					assert.Equal(t, op.Status, ops[i].(*bug.SetStatusOperation).Status)
+				case *bug.AddCommentOperation:
+					assert.Equal(t, op.Message, ops[i].(*bug.AddCommentOperation).Message)
+				default:
+					panic("unknown operation type")
+				}
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package launchpad
 
 import (
 	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/cache"
 )
 
 func init() {
@@ -15,6 +16,12 @@ func (*Launchpad) Target() string {
 	return "launchpad-preview"
 }
 
+// LoginMetadataKey return the metadata key recording the Launchpad login of
+// the identities
+func (*Launchpad) LoginMetadataKey() string {
+	return keyLaunchpadLogin
+}
+
 func (*Launchpad) NewImporter() core.Importer {
 	return &launchpadImporter{}
 }
@@ -22,3 +29,9 @@ func (*Launchpad) NewImporter() core.Importer {
 func (*Launchpad) NewExporter() core.Exporter {
 	return nil
 }
+
+// MatchBug tell if a bug has been imported from Launchpad
+func (*Launchpad) MatchBug(conf core.Configuration, excerpt *cache.BugExcerpt) bool {
+	_, ok := excerpt.CreateMetadata[keyLaunchpadID]
+	return ok
+}
@@ -5,7 +5,6 @@ package launchpad
  * https://launchpad.net/+apidoc/devel.html
  *
  * TODO:
- * - Retrieve bug status
  * - Retrieve activity log
  * - SearchTasks should yield bugs one by one
  *
@@ -19,6 +18,9 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/repository"
 )
 
 const apiRoot = "https://api.launchpad.net/devel"
@@ -38,23 +40,31 @@ var personCache = make(map[string]LPPerson)
 type LPBug struct {
 	Title       string   `json:"title"`
 	ID          int      `json:"id"`
-	Owner       LPPerson `json:"owner_link"`
+	OwnerLink   string   `json:"owner_link"`
+	Owner       LPPerson `json:"-"`
 	Description string   `json:"description"`
 	CreatedAt   string   `json:"date_created"`
+	UpdatedAt   string   `json:"date_last_updated"`
 	Messages    []LPMessage
+
+	// Status is the status of the task of the bug in the project, like
+	// "Fix Released"
+	Status string `json:"-"`
 }
 
 // LPMessage describes a comment on a bug report
 type LPMessage struct {
 	Content   string   `json:"content"`
 	CreatedAt string   `json:"date_created"`
-	Owner     LPPerson `json:"owner_link"`
+	OwnerLink string   `json:"owner_link"`
+	Owner     LPPerson `json:"-"`
 	ID        string   `json:"self_link"`
 }
 
 type launchpadBugEntry struct {
 	BugLink  string `json:"bug_link"`
 	SelfLink string `json:"self_link"`
+	Status   string `json:"status"`
 }
 
 type launchpadAnswer struct {
@@ -72,10 +82,8 @@ type launchpadAPI struct {
 	client *http.Client
 }
 
-func (lapi *launchpadAPI) Init() error {
-	lapi.client = &http.Client{
-		Timeout: defaultTimeout,
-	}
+func (lapi *launchpadAPI) Init(repo repository.RepoCommon) error {
+	lapi.client = core.NewCachedHTTPClient(repo, defaultTimeout)
 	return nil
 }
 
@@ -121,6 +129,7 @@ func (lapi *launchpadAPI) SearchTasks(ctx context.Context, project string) ([]LP
 		for _, bugEntry := range result.Entries {
 			bug, err := lapi.queryBug(ctx, bugEntry.BugLink)
 			if err == nil {
+				bug.Status = bugEntry.Status
 				bugs = append(bugs, bug)
 			}
 		}
@@ -156,6 +165,11 @@ func (lapi *launchpadAPI) queryBug(ctx context.Context, url string) (LPBug, erro
 		return bug, err
 	}
 
+	bug.Owner, err = lapi.queryPerson(ctx, bug.OwnerLink)
+	if err != nil {
+		return bug, err
+	}
+
 	/* Fetch messages */
 	messagesCollectionLink := fmt.Sprintf("%s/bugs/%d/messages", apiRoot, bug.ID)
 	messages, err := lapi.queryMessages(ctx, messagesCollectionLink)
@@ -191,7 +205,13 @@ func (lapi *launchpadAPI) queryMessages(ctx context.Context, messagesURL string)
 			return nil, err
 		}
 
-		messages = append(messages, result.Entries...)
+		for _, message := range result.Entries {
+			message.Owner, err = lapi.queryPerson(ctx, message.OwnerLink)
+			if err != nil {
+				return nil, err
+			}
+			messages = append(messages, message)
+		}
 
 		// Launchpad only returns 75 results at a time. We get the next
 		// page and run another query, unless there is no other page.
@@ -202,3 +222,34 @@ func (lapi *launchpadAPI) queryMessages(ctx context.Context, messagesURL string)
 	}
 	return messages, nil
 }
+
+// queryPerson return the person behind an owner link, as the API only give
+// the link to the person with the bugs and messages
+func (lapi *launchpadAPI) queryPerson(ctx context.Context, ownerLink string) (LPPerson, error) {
+	if person, ok := personCache[ownerLink]; ok {
+		return person, nil
+	}
+
+	var person LPPerson
+
+	req, err := http.NewRequest("GET", ownerLink, nil)
+	if err != nil {
+		return person, err
+	}
+	req = req.WithContext(ctx)
+
+	resp, err := lapi.client.Do(req)
+	if err != nil {
+		return person, err
+	}
+
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(&person); err != nil {
+		return person, err
+	}
+
+	personCache[ownerLink] = person
+
+	return person, nil
+}
@@ -0,0 +1,138 @@
+package mock
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+const (
+	target = "mock"
+
+	keyProject = "project"
+	// number of issues of the synthetic remote
+	keyIssues = "issues"
+	// number of comments of each issue
+	keyComments = "comments"
+	// number of title, label and status changes of each issue
+	keyEvents = "events"
+	// seed of the generated content
+	keySeed = "seed"
+	// comma separated numbers of the issues failing to import
+	keyFail = "fail"
+	// duration to wait before importing each issue
+	keyDelay = "delay"
+
+	defaultProject  = "mock"
+	defaultIssues   = 10
+	defaultComments = 3
+	defaultEvents   = 2
+)
+
+// Configure doesn't prompt anything: the project default to "mock", and the
+// size of the synthetic remote can be tuned afterward in the git config, for
+// example with `git config git-bug.bridge.<name>.issues 100`.
+func (m *Mock) Configure(repo repository.RepoCommon, params core.BridgeParams) (core.Configuration, error) {
+	if params.Token != "" || params.TokenStdin {
+		fmt.Println("warning: --token is ineffective for a mock bridge")
+	}
+	if params.Owner != "" {
+		fmt.Println("warning: --owner is ineffective for a mock bridge")
+	}
+	if params.URL != "" {
+		fmt.Println("warning: --url is ineffective for a mock bridge")
+	}
+
+	project := params.Project
+	if project == "" {
+		project = defaultProject
+	}
+
+	conf := make(core.Configuration)
+	conf[keyProject] = project
+	conf[keyIssues] = strconv.Itoa(defaultIssues)
+	conf[keyComments] = strconv.Itoa(defaultComments)
+	conf[keyEvents] = strconv.Itoa(defaultEvents)
+	conf[keySeed] = "0"
+	conf[core.KeyTarget] = target
+
+	err := m.ValidateConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return conf, nil
+}
+
+func (*Mock) ValidateConfig(conf core.Configuration) error {
+	if _, ok := conf[keyProject]; !ok {
+		return fmt.Errorf("missing %s key", keyProject)
+	}
+
+	if _, ok := conf[core.KeyTarget]; !ok {
+		return fmt.Errorf("missing %s key", core.KeyTarget)
+	}
+
+	_, err := newGenerator(conf)
+	return err
+}
+
+// newGenerator build the generator of the synthetic remote described by the
+// configuration
+func newGenerator(conf core.Configuration) (*generator, error) {
+	g := &generator{
+		project:  conf[keyProject],
+		issues:   defaultIssues,
+		comments: defaultComments,
+		events:   defaultEvents,
+		fail:     make(map[int]bool),
+	}
+
+	for key, dest := range map[string]*int{
+		keyIssues:   &g.issues,
+		keyComments: &g.comments,
+		keyEvents:   &g.events,
+	} {
+		raw, ok := conf[key]
+		if !ok {
+			continue
+		}
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 0 {
+			return nil, fmt.Errorf("invalid %s value %q", key, raw)
+		}
+		*dest = value
+	}
+
+	if raw, ok := conf[keySeed]; ok {
+		seed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value %q", keySeed, raw)
+		}
+		g.seed = seed
+	}
+
+	if raw := conf[keyFail]; raw != "" {
+		for _, field := range strings.Split(raw, ",") {
+			number, err := strconv.Atoi(strings.TrimSpace(field))
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s value %q", keyFail, raw)
+			}
+			g.fail[number] = true
+		}
+	}
+
+	if raw := conf[keyDelay]; raw != "" {
+		delay, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value %q", keyDelay, raw)
+		}
+		g.delay = delay
+	}
+
+	return g, nil
+}
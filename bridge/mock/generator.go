@@ -0,0 +1,205 @@
+package mock
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+// the synthetic remote starts at a fixed date, one issue a day, so that the
+// generated content is the same from a run to another
+var epoch = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+var users = []mockUser{
+	{Login: "rene", Name: "René Descartes"},
+	{Login: "ada", Name: "Ada Lovelace"},
+	{Login: "alan", Name: "Alan Turing"},
+	{Login: "grace", Name: "Grace Hopper"},
+	{Login: "edsger", Name: "Edsger Dijkstra"},
+}
+
+var labels = []string{"bug", "enhancement", "question", "documentation", "performance"}
+
+var words = strings.Fields(`the a crash when after before opening closing
+	saving loading bug file repository config cache identity label title comment
+	status bridge remote import export push pull fails hangs breaks slowly twice
+	missing wrong empty large unicode window terminal command flag`)
+
+type mockUser struct {
+	Login string
+	Name  string
+}
+
+type eventKind int
+
+const (
+	eventComment eventKind = iota
+	eventTitle
+	eventLabels
+	eventStatus
+)
+
+// mockEvent is a change of a synthetic issue after its creation
+type mockEvent struct {
+	// ID is unique within the issue
+	ID        string
+	Kind      eventKind
+	Author    mockUser
+	CreatedAt time.Time
+
+	Message string     // eventComment
+	Title   string     // eventTitle
+	Added   []string   // eventLabels
+	Removed []string   // eventLabels
+	Status  bug.Status // eventStatus
+}
+
+// mockIssue is an issue of the synthetic remote
+type mockIssue struct {
+	Number    int
+	Author    mockUser
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Title     string
+	Body      string
+	Events    []mockEvent
+}
+
+// generator produce the issues of a synthetic remote. Every issue, comment and
+// event only depends on the seed and on its position, so that growing the
+// number of issues, comments or events in the configuration keep the existing
+// content untouched and only add to it, like a real remote being active.
+type generator struct {
+	project  string
+	issues   int
+	comments int
+	events   int
+	seed     int64
+	fail     map[int]bool
+	delay    time.Duration
+}
+
+// remoteID return the identifier of an issue, unique across the mock projects
+func (g *generator) remoteID(number int) string {
+	return fmt.Sprintf("%s#%d", g.project, number)
+}
+
+// Issues return the issues updated since the given time
+func (g *generator) Issues(since time.Time) []mockIssue {
+	var result []mockIssue
+	for number := 1; number <= g.issues; number++ {
+		issue := g.issue(number)
+		if issue.UpdatedAt.Before(since) {
+			continue
+		}
+		result = append(result, issue)
+	}
+	return result
+}
+
+func (g *generator) issue(number int) mockIssue {
+	r := g.rand(number, 0)
+
+	issue := mockIssue{
+		Number:    number,
+		Author:    users[r.Intn(len(users))],
+		CreatedAt: epoch.Add(time.Duration(number-1) * 24 * time.Hour),
+		Title:     sentence(r, 3, 7),
+		Body:      paragraph(r),
+	}
+	issue.UpdatedAt = issue.CreatedAt
+
+	title := issue.Title
+	status := bug.OpenStatus
+	current := make(map[string]bool)
+
+	// comments and events alternate, a minute apart
+	for k := 0; k < g.comments || k < g.events; k++ {
+		if k < g.comments {
+			r := g.rand(number, 2*k+1)
+			issue.Events = append(issue.Events, mockEvent{
+				ID:        fmt.Sprintf("comment-%d", k),
+				Kind:      eventComment,
+				Author:    users[r.Intn(len(users))],
+				CreatedAt: issue.CreatedAt.Add(time.Duration(2*k+1) * time.Minute),
+				Message:   paragraph(r),
+			})
+		}
+
+		if k < g.events {
+			r := g.rand(number, 2*k+2)
+			event := mockEvent{
+				ID:        fmt.Sprintf("event-%d", k),
+				Author:    users[r.Intn(len(users))],
+				CreatedAt: issue.CreatedAt.Add(time.Duration(2*k+2) * time.Minute),
+			}
+
+			switch r.Intn(3) {
+			case 0:
+				event.Kind = eventTitle
+				event.Title = sentence(r, 3, 7)
+				for event.Title == title {
+					event.Title = sentence(r, 3, 7)
+				}
+				title = event.Title
+
+			case 1:
+				event.Kind = eventLabels
+				label := labels[r.Intn(len(labels))]
+				if current[label] {
+					event.Removed = []string{label}
+				} else {
+					event.Added = []string{label}
+				}
+				current[label] = !current[label]
+
+			case 2:
+				event.Kind = eventStatus
+				if status == bug.OpenStatus {
+					status = bug.ClosedStatus
+				} else {
+					status = bug.OpenStatus
+				}
+				event.Status = status
+			}
+
+			issue.Events = append(issue.Events, event)
+		}
+	}
+
+	if len(issue.Events) > 0 {
+		issue.UpdatedAt = issue.Events[len(issue.Events)-1].CreatedAt
+	}
+
+	return issue
+}
+
+// rand return the random source of a given piece of an issue
+func (g *generator) rand(number int, piece int) *rand.Rand {
+	h := fnv.New64a()
+	_, _ = fmt.Fprintf(h, "%d/%d/%d", g.seed, number, piece)
+	return rand.New(rand.NewSource(int64(h.Sum64())))
+}
+
+func sentence(r *rand.Rand, min int, max int) string {
+	n := min + r.Intn(max-min+1)
+	result := make([]string, n)
+	for i := range result {
+		result[i] = words[r.Intn(len(words))]
+	}
+	result[0] = strings.ToUpper(result[0][:1]) + result[0][1:]
+	return strings.Join(result, " ")
+}
+
+func paragraph(r *rand.Rand) string {
+	n := 1 + r.Intn(4)
+	result := make([]string, n)
+	for i := range result {
+		result[i] = sentence(r, 4, 12) + "."
+	}
+	return strings.Join(result, " ")
+}
@@ -0,0 +1,244 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+const keyMockID = "mock-id"
+const keyMockLogin = "mock-login"
+
+type mockImporter struct {
+	conf core.Configuration
+
+	// the synthetic remote
+	generator *generator
+
+	// mapping of remote identities to local ones
+	identityMapping core.IdentityMapping
+
+	// how to react to a bug failing to import
+	errorPolicy core.ErrorPolicy
+}
+
+func (mi *mockImporter) Init(conf core.Configuration) error {
+	mi.conf = conf
+
+	var err error
+	mi.generator, err = newGenerator(conf)
+	if err != nil {
+		return err
+	}
+
+	mi.errorPolicy, err = core.NewErrorPolicy(conf)
+	return err
+}
+
+func (mi *mockImporter) ensureUser(repo *cache.RepoCache, user mockUser, out chan<- core.ImportResult) (*cache.IdentityCache, error) {
+	// Look first in the cache, including the identities the account has been
+	// attached to
+	i, err := repo.ResolveIdentityImmutableMetadata(keyMockLogin, user.Login)
+	if err == nil {
+		return i, nil
+	}
+	if _, ok := err.(entity.ErrMultipleMatch); ok {
+		return nil, err
+	}
+
+	// Then look in the identity mapping
+	i, err = mi.identityMapping.Resolve(repo, keyMockLogin, user.Login)
+	if err == nil {
+		return i, nil
+	}
+	if err != identity.ErrIdentityNotExist {
+		return nil, err
+	}
+
+	i, err = repo.NewIdentityRaw(
+		user.Name,
+		"",
+		user.Login,
+		"",
+		map[string]string{
+			keyMockLogin: user.Login,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out <- core.NewImportIdentity(i.Id())
+	return i, nil
+}
+
+func (mi *mockImporter) ImportAll(ctx context.Context, repo *cache.RepoCache, since time.Time) (<-chan core.ImportResult, error) {
+	identityMapping, err := core.LoadIdentityMapping(repo)
+	if err != nil {
+		return nil, err
+	}
+	mi.identityMapping = identityMapping
+
+	out := make(chan core.ImportResult)
+	issues := mi.generator.Issues(since)
+
+	go func() {
+		defer close(out)
+
+		for i, issue := range issues {
+			// simulate the latency of a remote
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(mi.generator.delay):
+			}
+
+			failedId, err := mi.importIssue(ctx, repo, issue, out)
+			if err != nil && ctx.Err() != nil {
+				// interrupted, the issue was rolled back
+				return
+			}
+			if err != nil {
+				out <- core.NewImportError(err, failedId)
+				if mi.errorPolicy == core.ErrorPolicyFailFast {
+					return
+				}
+			}
+
+			out <- core.NewImportProgress(i+1, len(issues))
+		}
+	}()
+
+	return out, nil
+}
+
+// importIssue import a synthetic issue with its comments and events. The
+// import is transactional: on error, nothing of the issue is committed and
+// the id of the failing synthetic entity is returned.
+func (mi *mockImporter) importIssue(ctx context.Context, repo *cache.RepoCache, issue mockIssue, out chan<- core.ImportResult) (entity.Id, error) {
+	tx := core.NewImportTransaction(ctx, repo, out)
+
+	failedId, err := mi.importIssueTx(repo, tx, issue, out)
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		if errRollback := tx.Rollback(); errRollback != nil {
+			return failedId, fmt.Errorf("%v (rollback failed: %v)", err, errRollback)
+		}
+		return failedId, err
+	}
+
+	return "", nil
+}
+
+func (mi *mockImporter) importIssueTx(repo *cache.RepoCache, tx *core.ImportTransaction, issue mockIssue, out chan<- core.ImportResult) (entity.Id, error) {
+	issueID := mi.generator.remoteID(issue.Number)
+
+	b, err := repo.ResolveBugCreateMetadata(keyMockID, issueID)
+	if err != nil && err != bug.ErrBugNotExist {
+		return entity.Id(issueID), err
+	}
+	if err == nil {
+		tx.Track(b)
+	}
+
+	if err == bug.ErrBugNotExist {
+		author, err := mi.ensureUser(repo, issue.Author, out)
+		if err != nil {
+			return entity.Id(issueID), err
+		}
+
+		b, _, err = tx.NewBugRaw(
+			author,
+			issue.CreatedAt.Unix(),
+			issue.Title,
+			issue.Body,
+			nil,
+			map[string]string{
+				core.KeyOrigin: target,
+				keyMockID:      issueID,
+			},
+		)
+		if err != nil {
+			return entity.Id(issueID), err
+		}
+
+		tx.Report(core.NewImportBug(b.Id()))
+	}
+
+	for i, event := range issue.Events {
+		// fail halfway, to exercise the rollback
+		if mi.generator.fail[issue.Number] && i >= len(issue.Events)/2 {
+			return entity.Id(issueID), fmt.Errorf("synthetic failure of issue %s", issueID)
+		}
+
+		eventID := fmt.Sprintf("%s/%s", issueID, event.ID)
+
+		// skip the events already imported by a previous run
+		_, err := b.ResolveOperationWithMetadata(keyMockID, eventID)
+		if err == nil {
+			continue
+		}
+		if err != cache.ErrNoMatchingOp {
+			return entity.Id(eventID), err
+		}
+
+		author, err := mi.ensureUser(repo, event.Author, out)
+		if err != nil {
+			return entity.Id(eventID), err
+		}
+
+		metadata := map[string]string{
+			keyMockID: eventID,
+		}
+		unixTime := event.CreatedAt.Unix()
+
+		switch event.Kind {
+		case eventComment:
+			op, err := b.AddCommentRaw(author, unixTime, event.Message, nil, metadata)
+			if err != nil {
+				return entity.Id(eventID), err
+			}
+			tx.Report(core.NewImportComment(op.Id()))
+
+		case eventTitle:
+			op, err := b.SetTitleRaw(author, unixTime, event.Title, metadata)
+			if err != nil {
+				return entity.Id(eventID), err
+			}
+			tx.Report(core.NewImportTitleEdition(op.Id()))
+
+		case eventLabels:
+			op, err := b.ForceChangeLabelsRaw(author, unixTime, event.Added, event.Removed, metadata)
+			if err != nil {
+				return entity.Id(eventID), err
+			}
+			tx.Report(core.NewImportLabelChange(op.Id()))
+
+		case eventStatus:
+			var op *bug.SetStatusOperation
+			if event.Status == bug.ClosedStatus {
+				op, err = b.CloseRaw(author, unixTime, metadata)
+			} else {
+				op, err = b.OpenRaw(author, unixTime, metadata)
+			}
+			if err != nil {
+				return entity.Id(eventID), err
+			}
+			tx.Report(core.NewImportStatusChange(op.Id()))
+		}
+	}
+
+	if mi.generator.fail[issue.Number] {
+		return entity.Id(issueID), fmt.Errorf("synthetic failure of issue %s", issueID)
+	}
+
+	return "", nil
+}
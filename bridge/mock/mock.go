@@ -0,0 +1,39 @@
+// Package mock contains a bridge importing synthetic issues generated locally,
+// to exercise the bridge machinery end to end without network access
+package mock
+
+import (
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/cache"
+)
+
+func init() {
+	core.Register(&Mock{})
+}
+
+type Mock struct{}
+
+func (*Mock) Target() string {
+	return target
+}
+
+// LoginMetadataKey return the metadata key recording the login of the
+// synthetic users
+func (*Mock) LoginMetadataKey() string {
+	return keyMockLogin
+}
+
+func (*Mock) NewImporter() core.Importer {
+	return &mockImporter{}
+}
+
+func (*Mock) NewExporter() core.Exporter {
+	return nil
+}
+
+// MatchBug tell if a bug has been imported from the configured mock project
+func (*Mock) MatchBug(conf core.Configuration, excerpt *cache.BugExcerpt) bool {
+	return strings.HasPrefix(excerpt.CreateMetadata[keyMockID], conf[keyProject]+"#")
+}
@@ -0,0 +1,199 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestGeneratorStable(t *testing.T) {
+	small, err := newGenerator(core.Configuration{keyProject: "test", keyIssues: "3", keyComments: "1", keyEvents: "2", keySeed: "42"})
+	require.NoError(t, err)
+	large, err := newGenerator(core.Configuration{keyProject: "test", keyIssues: "5", keyComments: "4", keyEvents: "2", keySeed: "42"})
+	require.NoError(t, err)
+
+	// the same configuration generate the same content
+	require.Equal(t, small.Issues(time.Time{}), small.Issues(time.Time{}))
+
+	// growing the remote only add to it
+	smallIssues := small.Issues(time.Time{})
+	largeIssues := large.Issues(time.Time{})
+	require.Len(t, smallIssues, 3)
+	require.Len(t, largeIssues, 5)
+
+	for i, issue := range smallIssues {
+		require.Equal(t, issue.Title, largeIssues[i].Title)
+		require.Equal(t, issue.Body, largeIssues[i].Body)
+		require.Len(t, largeIssues[i].Events, 6)
+
+		large := make(map[string]mockEvent)
+		for _, event := range largeIssues[i].Events {
+			large[event.ID] = event
+		}
+		for _, event := range issue.Events {
+			require.Equal(t, event, large[event.ID])
+		}
+	}
+
+	// only the issues updated since are returned
+	since := largeIssues[3].CreatedAt
+	require.Len(t, large.Issues(since), 2)
+
+	_, err = newGenerator(core.Configuration{keyProject: "test", keyIssues: "-1"})
+	require.Error(t, err)
+	_, err = newGenerator(core.Configuration{keyProject: "test", keyFail: "1,a"})
+	require.Error(t, err)
+}
+
+func TestImport(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	backend, err := cache.NewRepoCache(repo)
+	require.NoError(t, err)
+	defer backend.Close()
+
+	b, err := core.NewBridge(backend, target, "test")
+	require.NoError(t, err)
+	require.NoError(t, b.Configure(core.BridgeParams{Project: "test"}))
+
+	configure(t, backend, map[string]string{keyIssues: "4", keyComments: "2", keyEvents: "3"})
+	counts := importAll(t, backend)
+
+	require.Equal(t, 4, counts[core.ImportEventBug])
+	require.Equal(t, 8, counts[core.ImportEventComment])
+	require.Equal(t, 12, counts[core.ImportEventTitleEdition]+counts[core.ImportEventLabelChange]+counts[core.ImportEventStatusChange])
+	require.Equal(t, 4, counts[core.ImportEventProgress])
+	require.NotZero(t, counts[core.ImportEventIdentity])
+	require.Len(t, backend.AllBugsIds(), 4)
+
+	for _, id := range backend.AllBugsIds() {
+		b, err := backend.ResolveBug(id)
+		require.NoError(t, err)
+		require.Len(t, b.Snapshot().Comments, 3)
+	}
+
+	// nothing new on the remote
+	counts = importAll(t, backend)
+	require.Zero(t, counts[core.ImportEventBug])
+	require.Zero(t, counts[core.ImportEventComment])
+	require.Zero(t, counts[core.ImportEventIdentity])
+	require.Len(t, backend.AllBugsIds(), 4)
+
+	// the remote grew, only the new content is imported
+	configure(t, backend, map[string]string{keyIssues: "6", keyComments: "3"})
+	counts = importAll(t, backend)
+	require.Equal(t, 2, counts[core.ImportEventBug])
+	require.Equal(t, 4+6, counts[core.ImportEventComment])
+	require.Len(t, backend.AllBugsIds(), 6)
+
+	for _, id := range backend.AllBugsIds() {
+		b, err := backend.ResolveBug(id)
+		require.NoError(t, err)
+		require.Len(t, b.Snapshot().Comments, 4)
+	}
+
+	bridge, err := core.LoadBridge(backend, "test")
+	require.NoError(t, err)
+	status, err := bridge.Status(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 6, status.BugCount)
+	require.False(t, status.LastImport.IsZero())
+}
+
+func TestImportFailure(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	backend, err := cache.NewRepoCache(repo)
+	require.NoError(t, err)
+	defer backend.Close()
+
+	b, err := core.NewBridge(backend, target, "test")
+	require.NoError(t, err)
+	require.NoError(t, b.Configure(core.BridgeParams{}))
+
+	configure(t, backend, map[string]string{keyIssues: "3", keyFail: "2", core.KeyErrorPolicy: "skip"})
+	counts := importAll(t, backend)
+
+	require.Equal(t, 1, counts[core.ImportEventError])
+	require.Equal(t, 3, counts[core.ImportEventProgress])
+
+	// the failing issue was rolled back entirely
+	require.Len(t, backend.AllBugsIds(), 2)
+	_, err = backend.ResolveBugCreateMetadata(keyMockID, "mock#2")
+	require.Error(t, err)
+
+	// it's imported once the remote recovered
+	configure(t, backend, map[string]string{keyFail: ""})
+	counts = importAll(t, backend)
+	require.Zero(t, counts[core.ImportEventError])
+	require.Equal(t, 1, counts[core.ImportEventBug])
+	require.Len(t, backend.AllBugsIds(), 3)
+}
+
+func TestImportLocalProject(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	backend, err := cache.NewRepoCache(repo)
+	require.NoError(t, err)
+	defer backend.Close()
+
+	rene, err := backend.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	require.NoError(t, backend.SetUserIdentity(rene))
+	native, _, err := backend.NewBug("native", "message")
+	require.NoError(t, err)
+
+	b, err := core.NewBridge(backend, target, "test")
+	require.NoError(t, err)
+	require.Error(t, b.Configure(core.BridgeParams{LocalProject: "not valid"}))
+	require.NoError(t, b.Configure(core.BridgeParams{LocalProject: "backend"}))
+
+	configure(t, backend, map[string]string{keyIssues: "3", keyFail: "2", core.KeyErrorPolicy: "skip"})
+	counts := importAll(t, backend)
+	require.Equal(t, 1, counts[core.ImportEventError])
+
+	imported := core.ExportedBugsIds(backend, core.Configuration{core.KeyLocalProject: "backend"})
+	require.Len(t, imported, 2)
+	require.NotContains(t, imported, native.Id())
+	require.Len(t, core.ExportedBugsIds(backend, core.Configuration{}), 3)
+
+	for _, id := range imported {
+		b, err := backend.ResolveBug(id)
+		require.NoError(t, err)
+		snap := b.Snapshot()
+		require.Equal(t, "backend", snap.Project)
+		// authored as if the bug had always been in the project
+		last := snap.Operations[len(snap.Operations)-1]
+		require.Equal(t, snap.Author.Id(), last.GetAuthor().Id())
+	}
+}
+
+func configure(t *testing.T, backend *cache.RepoCache, conf map[string]string) {
+	for key, value := range conf {
+		require.NoError(t, backend.StoreConfig(fmt.Sprintf("git-bug.bridge.test.%s", key), value))
+	}
+}
+
+func importAll(t *testing.T, backend *cache.RepoCache) map[core.ImportEvent]int {
+	b, err := core.LoadBridge(backend, "test")
+	require.NoError(t, err)
+
+	events, err := b.ImportAll(context.Background(), time.Time{})
+	require.NoError(t, err)
+
+	counts := make(map[core.ImportEvent]int)
+	for result := range events {
+		counts[result.Event]++
+	}
+	return counts
+}
@@ -2,7 +2,6 @@
 package bug
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -57,12 +56,19 @@ type Bug struct {
 	lastCommit git.Hash
 	rootPack   git.Hash
 
+	// the commit the ref of the bug point to, as last read or written, so
+	// that a concurrent change of the bug is not overwritten
+	refHash git.Hash
+
 	// all the committed operations
 	packs []OperationPack
 
 	// a temporary pack of operations used for convenience to pile up new operations
 	// before a commit
 	staging OperationPack
+
+	// the last commits are not referenced by the ref of the bug yet
+	detached bool
 }
 
 // NewBug create a new Bug
@@ -114,6 +120,24 @@ func ReadRemoteBug(repo repository.ClockedRepo, remote string, id string) (*Bug,
 
 // readBug will read and parse a Bug from git
 func readBug(repo repository.ClockedRepo, ref string) (*Bug, error) {
+	bug, err := readBugData(repo, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure that the identities are properly loaded
+	resolver := identity.NewSimpleResolver(repo)
+	err = bug.EnsureIdentities(resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	return bug, nil
+}
+
+// readBugData read the operation packs of a bug, leaving the authors as
+// IdentityStub
+func readBugData(repo repository.ClockedRepo, ref string) (*Bug, error) {
 	refSplit := strings.Split(ref, "/")
 	id := entity.Id(refSplit[len(refSplit)-1])
 
@@ -148,6 +172,7 @@ func readBug(repo repository.ClockedRepo, ref string) (*Bug, error) {
 		rootFound := false
 		var createTime uint64
 		var editTime uint64
+		signatures := make(identity.Signatures)
 
 		for _, entry := range entries {
 			if entry.Name == opsEntryName {
@@ -155,6 +180,10 @@ func readBug(repo repository.ClockedRepo, ref string) (*Bug, error) {
 				opsFound = true
 				continue
 			}
+			if fingerprint, ok := identity.IsSignatureEntry(entry); ok {
+				signatures[fingerprint] = entry.Hash
+				continue
+			}
 			if entry.Name == rootEntryName {
 				rootEntry = entry
 				rootFound = true
@@ -209,25 +238,21 @@ func readBug(repo repository.ClockedRepo, ref string) (*Bug, error) {
 			return nil, errors.Wrap(err, "failed to read git blob data")
 		}
 
-		opp := &OperationPack{}
-		err = json.Unmarshal(data, &opp)
-
+		opp, err := decodeOperationPack(data)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to decode OperationPack json")
+			return nil, errors.Wrap(err, "failed to decode OperationPack")
 		}
 
 		// tag the pack with the commit hash
 		opp.commitHash = hash
+		opp.blobHash = opsEntry.Hash
+		opp.editTime = lamport.Time(editTime)
+		opp.signatures = signatures
 
 		bug.packs = append(bug.packs, *opp)
 	}
 
-	// Make sure that the identities are properly loaded
-	resolver := identity.NewSimpleResolver(repo)
-	err = bug.EnsureIdentities(resolver)
-	if err != nil {
-		return nil, err
-	}
+	bug.refHash = bug.lastCommit
 
 	return &bug, nil
 }
@@ -276,6 +301,62 @@ func readAllBugs(repo repository.ClockedRepo, refPrefix string) <-chan StreamedB
 	return out
 }
 
+// RemoveLocalBug remove a local bug from git. Its operations stay in the
+// git object store until garbage collected.
+func RemoveLocalBug(repo repository.Repo, id entity.Id) error {
+	return repo.RemoveRef(bugsRefPattern + id.String())
+}
+
+// RemoveRemoteTrackingBug remove the remote-tracking refs of a bug for all the
+// remotes, so that a merge doesn't bring the bug back before the next fetch.
+func RemoveRemoteTrackingBug(repo repository.Repo, id entity.Id) error {
+	refs, err := repo.ListRefs("refs/remotes/")
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range refs {
+		if strings.HasSuffix(ref, "/bugs/"+id.String()) {
+			err := repo.RemoveRef(ref)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ListOrphanRemoteRefs list the remote-tracking refs of the bugs of the
+// remotes that are not configured anymore. These refs keep the operations of
+// the bugs alive in the git object store, while no pull can merge them
+// anymore. The refs of the configured remotes are never listed, as they hold
+// the bugs fetched but not merged yet.
+func ListOrphanRemoteRefs(repo repository.Repo) ([]string, error) {
+	remotes, err := repo.GetRemotes()
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := repo.ListRefs("refs/remotes/")
+	if err != nil {
+		return nil, err
+	}
+
+	var orphans []string
+	for _, ref := range refs {
+		i := strings.LastIndex(ref, "/bugs/")
+		if i < 0 {
+			continue
+		}
+		if _, ok := remotes[strings.TrimPrefix(ref[:i], "refs/remotes/")]; !ok {
+			orphans = append(orphans, ref)
+		}
+	}
+
+	return orphans, nil
+}
+
 // ListLocalIds list all the available local bug ids
 func ListLocalIds(repo repository.Repo) ([]entity.Id, error) {
 	refs, err := repo.ListRefs(bugsRefPattern)
@@ -286,6 +367,38 @@ func ListLocalIds(repo repository.Repo) ([]entity.Id, error) {
 	return refsToIds(refs), nil
 }
 
+// ListLocalHeads list all the available local bug ids, with the hash of the
+// last commit of each bug
+func ListLocalHeads(repo repository.Repo) (map[entity.Id]git.Hash, error) {
+	refs, err := repo.ListRefHashes(bugsRefPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	heads := make(map[entity.Id]git.Hash, len(refs))
+	for ref, hash := range refs {
+		heads[refsToIds([]string{ref})[0]] = hash
+	}
+
+	return heads, nil
+}
+
+// ListRemoteHeads list the bug ids fetched from a remote, with the hash of the
+// last commit of each bug
+func ListRemoteHeads(repo repository.Repo, remote string) (map[entity.Id]git.Hash, error) {
+	refs, err := repo.ListRefHashes(fmt.Sprintf(bugsRemoteRefPattern, remote))
+	if err != nil {
+		return nil, err
+	}
+
+	heads := make(map[entity.Id]git.Hash, len(refs))
+	for ref, hash := range refs {
+		heads[refsToIds([]string{ref})[0]] = hash
+	}
+
+	return heads, nil
+}
+
 func refsToIds(refs []string) []entity.Id {
 	ids := make([]entity.Id, len(refs))
 
@@ -345,33 +458,158 @@ func (bug *Bug) Validate() error {
 	return nil
 }
 
+// validateStaging check that the staging area can be committed on top of the
+// packs. Contrary to Validate(), the packs are not checked again: they were
+// when committed or merged, which keep each commit proportional to the
+// staging area only.
+func (bug *Bug) validateStaging() error {
+	if err := bug.staging.Validate(); err != nil {
+		return errors.Wrap(err, "staging")
+	}
+
+	for i, op := range bug.staging.Operations {
+		isCreate := op.base().OperationType == CreateOp
+		first := len(bug.packs) == 0 && i == 0
+
+		if first && !isCreate {
+			return fmt.Errorf("first operation should be a Create op")
+		}
+		if !first && isCreate {
+			return fmt.Errorf("only one Create op allowed")
+		}
+	}
+
+	return nil
+}
+
 // Append an operation into the staging area, to be committed later
 func (bug *Bug) Append(op Operation) {
 	bug.staging.Append(op)
 }
 
-// HasPendingOp tell if the bug need to be committed
+// DiscardPendingOps drop the operations of the staging area
+func (bug *Bug) DiscardPendingOps() {
+	bug.staging = OperationPack{}
+}
+
+// HasPendingOp tell if the bug need to be committed, that is if it has
+// operations in the staging area or commits not published yet
 func (bug *Bug) HasPendingOp() bool {
-	return !bug.staging.IsEmpty()
+	return bug.NeedCommit()
+}
+
+// Published tell if the bug has been written in its ref, that is if it exist
+// in the repository. A bug only committed with CommitDetached is not.
+func (bug *Bug) Published() bool {
+	return bug.refHash != ""
 }
 
 // Commit write the staging area in Git and move the operations to the packs
 func (bug *Bug) Commit(repo repository.ClockedRepo) error {
+	err := bug.commit(repo)
+	if err != nil {
+		return err
+	}
+
+	return bug.updateRef(repo)
+}
+
+// CommitDetached write the staging area in Git like Commit, but without
+// creating or updating the ref of the bug. Those commits only become visible
+// with the next Commit or CommitAsNeeded, and are otherwise garbage collected.
+// This allow to build a bug over several commits, yet publish it at once.
+func (bug *Bug) CommitDetached(repo repository.ClockedRepo) error {
+	err := bug.commit(repo)
+	if err != nil {
+		return err
+	}
+
+	bug.detached = true
+	return nil
+}
+
+// commit write the staging area in Git, without touching the ref of the bug
+func (bug *Bug) commit(repo repository.ClockedRepo) error {
+	return commitBatch(repo, []*Bug{bug})
+}
+
+// commitBatch write the staging area of several bugs in Git, without touching
+// their refs. The blobs of all the bugs are written with a single git process,
+// and so are their trees.
+func commitBatch(repo repository.ClockedRepo, bugs []*Bug) error {
+	// Serialize the Ops of each bug as a Git blob containing the serialized
+	// array, along with the empty blob used for the clocks
+	data := make([][]byte, 0, len(bugs)+1)
+
+	for _, bug := range bugs {
+		if bug.staging.IsEmpty() {
+			return fmt.Errorf("can't commit a bug with no pending operation")
+		}
+
+		if err := bug.validateStaging(); err != nil {
+			return errors.Wrap(err, "can't commit a bug with invalid data")
+		}
 
-	if !bug.NeedCommit() {
-		return fmt.Errorf("can't commit a bug with no pending operation")
+		serialized, err := bug.staging.serialize(repo)
+		if err != nil {
+			return err
+		}
+		data = append(data, serialized)
 	}
 
-	if err := bug.Validate(); err != nil {
-		return errors.Wrap(err, "can't commit a bug with invalid data")
+	blobs, err := repo.StoreDataBatch(append(data, []byte{}))
+	if err != nil {
+		return err
 	}
+	emptyBlobHash := blobs[len(bugs)]
 
-	// Write the Ops as a Git blob containing the serialized array
-	hash, err := bug.staging.Write(repo)
+	// Reference, if any, all the files required by the ops
+	// Git will check that they actually exist in the storage and will make sure
+	// to push/pull them as needed.
+	var mediaTrees [][]repository.TreeEntry
+	withMedia := make([]bool, len(bugs))
+	for i, bug := range bugs {
+		if mediaTree := makeMediaTree(bug.staging); len(mediaTree) > 0 {
+			mediaTrees = append(mediaTrees, mediaTree)
+			withMedia[i] = true
+		}
+	}
+
+	mediaTreeHashes, err := repo.StoreTreeBatch(mediaTrees)
 	if err != nil {
 		return err
 	}
 
+	trees := make([][]repository.TreeEntry, len(bugs))
+	for i, bug := range bugs {
+		var mediaTreeHash git.Hash
+		if withMedia[i] {
+			mediaTreeHash, mediaTreeHashes = mediaTreeHashes[0], mediaTreeHashes[1:]
+		}
+
+		trees[i], err = bug.makeTree(repo, data[i], blobs[i], emptyBlobHash, mediaTreeHash)
+		if err != nil {
+			return err
+		}
+	}
+
+	treeHashes, err := repo.StoreTreeBatch(trees)
+	if err != nil {
+		return err
+	}
+
+	for i, bug := range bugs {
+		if err := bug.storeCommit(repo, treeHashes[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// makeTree build the Git tree of the staging area, given its serialized ops
+// stored as blob, the empty blob and the tree of its media, if any
+func (bug *Bug) makeTree(repo repository.ClockedRepo, data []byte, hash git.Hash, emptyBlobHash git.Hash, mediaTreeHash git.Hash) ([]repository.TreeEntry, error) {
 	if bug.rootPack == "" {
 		bug.rootPack = hash
 	}
@@ -384,15 +622,17 @@ func (bug *Bug) Commit(repo repository.ClockedRepo) error {
 		{ObjectType: repository.Blob, Hash: bug.rootPack, Name: rootEntryName},
 	}
 
-	// Reference, if any, all the files required by the ops
-	// Git will check that they actually exist in the storage and will make sure
-	// to push/pull them as needed.
-	mediaTree := makeMediaTree(bug.staging)
-	if len(mediaTree) > 0 {
-		mediaTreeHash, err := repo.StoreTree(mediaTree)
-		if err != nil {
-			return err
-		}
+	// Sign the ops for the authors protected by keys
+	signatures, err := signPack(repo, bug.staging, packPayload(bug.rootPack, bug.lastCommit, data))
+	if err != nil {
+		return nil, err
+	}
+	tree = append(tree, signatures...)
+
+	bug.staging.blobHash = hash
+	bug.staging.signatures = identity.ReadSignatures(signatures)
+
+	if mediaTreeHash != "" {
 		tree = append(tree, repository.TreeEntry{
 			ObjectType: repository.Tree,
 			Hash:       mediaTreeHash,
@@ -406,14 +646,9 @@ func (bug *Bug) Commit(repo repository.ClockedRepo) error {
 	//
 	// To avoid having one blob for each clock value, clocks are serialized
 	// directly into the entry name
-	emptyBlobHash, err := repo.StoreData([]byte{})
-	if err != nil {
-		return err
-	}
-
 	bug.editTime, err = repo.EditTimeIncrement()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	tree = append(tree, repository.TreeEntry{
@@ -424,7 +659,7 @@ func (bug *Bug) Commit(repo repository.ClockedRepo) error {
 	if bug.lastCommit == "" {
 		bug.createTime, err = repo.CreateTimeIncrement()
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		tree = append(tree, repository.TreeEntry{
@@ -434,17 +669,19 @@ func (bug *Bug) Commit(repo repository.ClockedRepo) error {
 		})
 	}
 
-	// Store the tree
-	hash, err = repo.StoreTree(tree)
-	if err != nil {
-		return err
-	}
+	return tree, nil
+}
+
+// storeCommit write a Git commit referencing the tree of the staging area,
+// with the previous commit as parent, and move the operations to the packs
+func (bug *Bug) storeCommit(repo repository.ClockedRepo, treeHash git.Hash) error {
+	var hash git.Hash
+	var err error
 
-	// Write a Git commit referencing the tree, with the previous commit as parent
 	if bug.lastCommit != "" {
-		hash, err = repo.StoreCommitWithParent(hash, bug.lastCommit)
+		hash, err = repo.StoreCommitWithParent(treeHash, bug.lastCommit)
 	} else {
-		hash, err = repo.StoreCommit(hash)
+		hash, err = repo.StoreCommit(treeHash)
 	}
 
 	if err != nil {
@@ -458,32 +695,91 @@ func (bug *Bug) Commit(repo repository.ClockedRepo) error {
 		bug.id = entity.Id(hash)
 	}
 
-	// Create or update the Git reference for this bug
+	bug.staging.commitHash = hash
+	bug.staging.editTime = bug.editTime
+	bug.packs = append(bug.packs, bug.staging)
+	bug.staging = OperationPack{}
+
+	return nil
+}
+
+// updateRef create or update the Git reference for this bug, unless it
+// changed since the bug was read
+func (bug *Bug) updateRef(repo repository.ClockedRepo) error {
 	// When pushing later, the remote will ensure that this ref update
 	// is fast-forward, that is no data has been overwritten
 	ref := fmt.Sprintf("%s%s", bugsRefPattern, bug.id)
-	err = repo.UpdateRef(ref, hash)
-
+	err := repo.UpdateRefsIf(map[string]git.Hash{ref: bug.lastCommit}, map[string]git.Hash{ref: bug.refHash})
 	if err != nil {
+		// the commits are kept, but not published
+		bug.detached = true
+		return errors.Wrapf(err, "can't update the bug %s", bug.id.Human())
+	}
+
+	bug.refHash = bug.lastCommit
+	bug.detached = false
+	return nil
+}
+
+// CommitAll commit as needed several bugs at once, like CommitAsNeeded, but
+// writing the blobs and the trees of all the bugs with a single git process
+// each, and updating their refs in a single transaction.
+func CommitAll(repo repository.ClockedRepo, bugs []*Bug) error {
+	var staged []*Bug
+	for _, bug := range bugs {
+		if !bug.staging.IsEmpty() {
+			staged = append(staged, bug)
+		}
+	}
+
+	if len(staged) > 0 {
+		if err := commitBatch(repo, staged); err != nil {
+			return err
+		}
+		for _, bug := range staged {
+			bug.detached = true
+		}
+	}
+
+	refs := make(map[string]git.Hash)
+	old := make(map[string]git.Hash)
+	for _, bug := range bugs {
+		if bug.detached {
+			refs[bugsRefPattern+bug.id.String()] = bug.lastCommit
+			old[bugsRefPattern+bug.id.String()] = bug.refHash
+		}
+	}
+
+	if err := repo.UpdateRefsIf(refs, old); err != nil {
 		return err
 	}
 
-	bug.staging.commitHash = hash
-	bug.packs = append(bug.packs, bug.staging)
-	bug.staging = OperationPack{}
+	for _, bug := range bugs {
+		if bug.detached {
+			bug.refHash = bug.lastCommit
+		}
+		bug.detached = false
+	}
 
 	return nil
 }
 
+// CommitAsNeeded commit the staging area if needed, and publish the commits
+// left detached by CommitDetached
 func (bug *Bug) CommitAsNeeded(repo repository.ClockedRepo) error {
-	if !bug.NeedCommit() {
-		return nil
+	if !bug.staging.IsEmpty() {
+		return bug.Commit(repo)
+	}
+	if bug.detached {
+		return bug.updateRef(repo)
 	}
-	return bug.Commit(repo)
+	return nil
 }
 
+// NeedCommit tell if the bug has operations to commit, or commits left
+// detached to publish
 func (bug *Bug) NeedCommit() bool {
-	return !bug.staging.IsEmpty()
+	return !bug.staging.IsEmpty() || bug.detached
 }
 
 func makeMediaTree(pack OperationPack) []repository.TreeEntry {
@@ -514,28 +810,35 @@ func makeMediaTree(pack OperationPack) []repository.TreeEntry {
 // that are not present in the other on top of the chain of operations of the
 // other version.
 func (bug *Bug) Merge(repo repository.Repo, other Interface) (bool, error) {
-	var otherBug = bugFromInterface(other)
+	merged, err := bug.merge(repo, bugFromInterface(other))
+	return merged > 0, err
+}
 
+// merge do the actual Merge, and return the number of operations of the other
+// version that were merged. The git history is rebased so that it stays a
+// fast-forward of the other version, the operations being compiled in their
+// total order regardless.
+func (bug *Bug) merge(repo repository.Repo, otherBug *Bug) (int, error) {
 	// Note: a faster merge should be possible without actually reading and parsing
 	// all operations pack of our side.
 	// Reading the other side is still necessary to validate remote data, at least
 	// for new operations
 
 	if bug.id != otherBug.id {
-		return false, errors.New("merging unrelated bugs is not supported")
+		return 0, errors.New("merging unrelated bugs is not supported")
 	}
 
 	if len(otherBug.staging.Operations) > 0 {
-		return false, errors.New("merging a bug with a non-empty staging is not supported")
+		return 0, errors.New("merging a bug with a non-empty staging is not supported")
 	}
 
 	if bug.lastCommit == "" || otherBug.lastCommit == "" {
-		return false, errors.New("can't merge a bug that has never been stored")
+		return 0, errors.New("can't merge a bug that has never been stored")
 	}
 
 	ancestor, err := repo.FindCommonAncestor(bug.lastCommit, otherBug.lastCommit)
 	if err != nil {
-		return false, errors.Wrap(err, "can't find common ancestor")
+		return 0, errors.Wrap(err, "can't find common ancestor")
 	}
 
 	ancestorIndex := 0
@@ -553,9 +856,11 @@ func (bug *Bug) Merge(repo repository.Repo, other Interface) (bool, error) {
 
 	if len(otherBug.packs) == ancestorIndex+1 {
 		// Nothing to rebase, return early
-		return false, nil
+		return 0, nil
 	}
 
+	merged := 0
+
 	// get other bug's extra packs
 	for i := ancestorIndex + 1; i < len(otherBug.packs); i++ {
 		// clone is probably not necessary
@@ -563,42 +868,51 @@ func (bug *Bug) Merge(repo repository.Repo, other Interface) (bool, error) {
 
 		newPacks = append(newPacks, newPack)
 		bug.lastCommit = newPack.commitHash
+		merged += len(newPack.Operations)
 	}
 
 	// rebase our extra packs
 	for i := ancestorIndex + 1; i < len(bug.packs); i++ {
 		pack := bug.packs[i]
 
-		// get the referenced git tree
-		treeHash, err := repo.GetTreeHash(pack.commitHash)
+		// get the referenced git tree, signed again for its new parent
+		treeHash, signatures, err := bug.rebasePackTree(repo, pack, bug.lastCommit)
 
 		if err != nil {
-			return false, err
+			return 0, err
 		}
 
 		// create a new commit with the correct ancestor
 		hash, err := repo.StoreCommitWithParent(treeHash, bug.lastCommit)
 
 		if err != nil {
-			return false, err
+			return 0, err
 		}
 
 		// replace the pack
 		newPack := pack.Clone()
 		newPack.commitHash = hash
+		newPack.signatures = signatures
 		newPacks = append(newPacks, newPack)
 
 		// update the bug
 		bug.lastCommit = hash
 	}
 
+	bug.packs = newPacks
+	if otherBug.editTime > bug.editTime {
+		bug.editTime = otherBug.editTime
+	}
+
 	// Update the git ref
-	err = repo.UpdateRef(bugsRefPattern+bug.id.String(), bug.lastCommit)
+	ref := bugsRefPattern + bug.id.String()
+	err = repo.UpdateRefsIf(map[string]git.Hash{ref: bug.lastCommit}, map[string]git.Hash{ref: bug.refHash})
 	if err != nil {
-		return false, err
+		return 0, err
 	}
+	bug.refHash = bug.lastCommit
 
-	return true, nil
+	return merged, nil
 }
 
 // Id return the Bug identifier
@@ -611,6 +925,12 @@ func (bug *Bug) Id() entity.Id {
 	return bug.id
 }
 
+// LastCommit return the hash of the last commit of the bug in git, that is
+// the value of its ref. It is empty if the bug was never committed.
+func (bug *Bug) LastCommit() git.Hash {
+	return bug.lastCommit
+}
+
 // CreateLamportTime return the Lamport time of creation
 func (bug *Bug) CreateLamportTime() lamport.Time {
 	return bug.createTime
@@ -648,7 +968,8 @@ func (bug *Bug) LastOp() Operation {
 		return nil
 	}
 
-	lastPack := bug.packs[len(bug.packs)-1]
+	packs := bug.orderedPacks()
+	lastPack := packs[len(packs)-1]
 
 	if len(lastPack.Operations) == 0 {
 		return nil
@@ -657,20 +978,33 @@ func (bug *Bug) LastOp() Operation {
 	return lastPack.Operations[len(lastPack.Operations)-1]
 }
 
-// Compile a bug in a easily usable snapshot
+// opCount return the number of operations of the bug, staging included
+func (bug *Bug) opCount() int {
+	count := len(bug.staging.Operations)
+	for _, pack := range bug.packs {
+		count += len(pack.Operations)
+	}
+	return count
+}
+
+// Compile a bug in a easily usable snapshot, applying the operations in their
+// total order
 func (bug *Bug) Compile() Snapshot {
+	count := bug.opCount()
+
+	// most operations add a single item to the timeline, so both are sized
+	// once instead of growing along the way
 	snap := Snapshot{
-		id:     bug.id,
-		Status: OpenStatus,
+		id:         bug.id,
+		Status:     OpenStatus,
+		Operations: make([]Operation, 0, count),
+		Timeline:   make([]TimelineItem, 0, count),
 	}
 
-	it := NewOperationIterator(bug)
-
-	for it.Next() {
-		op := it.Value()
-		op.Apply(&snap)
-		snap.Operations = append(snap.Operations, op)
+	for _, pack := range bug.orderedPacks() {
+		snap.applyAll(pack.Operations)
 	}
+	snap.applyAll(bug.staging.Operations)
 
 	return snap
 }
@@ -18,11 +18,45 @@ func Fetch(repo repository.Repo, remote string) (string, error) {
 	return repo.FetchRefs(remote, fetchRefSpec)
 }
 
+// FetchBugs retrieve updates of the given bugs from a remote, leaving the
+// other bugs alone
+// This does not change the local bugs state
+func FetchBugs(repo repository.Repo, remote string, ids []entity.Id) (string, error) {
+	remoteRefSpec := fmt.Sprintf(bugsRemoteRefPattern, remote)
+
+	refSpecs := make([]string, len(ids))
+	for i, id := range ids {
+		refSpecs[i] = fmt.Sprintf("%s%s:%s%s", bugsRefPattern, id, remoteRefSpec, id)
+	}
+
+	return repo.FetchRefs(remote, refSpecs...)
+}
+
 // Push update a remote with the local changes
 func Push(repo repository.Repo, remote string) (string, error) {
 	return repo.PushRefs(remote, bugsRefPattern+"*")
 }
 
+// PushBugs update a remote with the local changes of the given bugs only
+func PushBugs(repo repository.Repo, remote string, ids []entity.Id) (string, error) {
+	refSpecs := make([]string, len(ids))
+	for i, id := range ids {
+		refSpecs[i] = bugsRefPattern + id.String()
+	}
+
+	return repo.PushRefs(remote, refSpecs...)
+}
+
+// ConfigureRemote install the fetch refspec of the bugs in the configuration of
+// a remote, so that a plain git fetch carry them as well. As with Fetch, the
+// fetched bugs are not merged.
+func ConfigureRemote(repo repository.Repo, remote string) error {
+	remoteRefSpec := fmt.Sprintf(bugsRemoteRefPattern, remote)
+	fetchRefSpec := fmt.Sprintf("%s*:%s*", bugsRefPattern, remoteRefSpec)
+
+	return repo.AddRemoteFetchRefSpecs(remote, fetchRefSpec)
+}
+
 // Pull will do a Fetch + MergeAll
 // This function will return an error if a merge fail
 func Pull(repo repository.ClockedRepo, remote string) error {
@@ -51,14 +85,39 @@ func Pull(repo repository.ClockedRepo, remote string) error {
 // - if both local and remote bug have new commits (that is, we have a concurrent edition),
 //   new local commits are rewritten at the head of the remote history (that is, a rebase)
 func MergeAll(repo repository.ClockedRepo, remote string) <-chan entity.MergeResult {
+	return merge(repo, func() ([]string, error) {
+		return repo.ListRefs(fmt.Sprintf(bugsRemoteRefPattern, remote))
+	})
+}
+
+// MergeBugs is like MergeAll, limited to the given bugs
+func MergeBugs(repo repository.ClockedRepo, remote string, ids []entity.Id) <-chan entity.MergeResult {
+	return merge(repo, func() ([]string, error) {
+		remoteRefSpec := fmt.Sprintf(bugsRemoteRefPattern, remote)
+
+		remoteRefs := make([]string, len(ids))
+		for i, id := range ids {
+			remoteRefs[i] = remoteRefSpec + id.String()
+		}
+		return remoteRefs, nil
+	})
+}
+
+// merge the remote bugs of the refs given by listRefs
+func merge(repo repository.ClockedRepo, listRefs func() ([]string, error)) <-chan entity.MergeResult {
 	out := make(chan entity.MergeResult)
 
 	go func() {
 		defer close(out)
 
-		remoteRefSpec := fmt.Sprintf(bugsRemoteRefPattern, remote)
-		remoteRefs, err := repo.ListRefs(remoteRefSpec)
+		remoteRefs, err := listRefs()
+
+		if err != nil {
+			out <- entity.MergeResult{Err: err}
+			return
+		}
 
+		policy, err := LoadPolicy(repo)
 		if err != nil {
 			out <- entity.MergeResult{Err: err}
 			return
@@ -96,6 +155,17 @@ func MergeAll(repo repository.ClockedRepo, remote string) <-chan entity.MergeRes
 
 			// the bug is not local yet, simply create the reference
 			if !localExist {
+				// Check that the protected identities are not impersonated
+				if err := remoteBug.VerifySignatures(repo, nil); err != nil {
+					out <- entity.NewMergeInvalidStatus(id, errors.Wrap(err, "remote bug is not properly signed").Error())
+					continue
+				}
+
+				if err := remoteBug.VerifyPolicy(repo, policy, nil); err != nil {
+					out <- entity.NewMergeInvalidStatus(id, errors.Wrap(err, "remote bug is not allowed").Error())
+					continue
+				}
+
 				err := repo.CopyRef(remoteRef, localRef)
 
 				if err != nil {
@@ -114,15 +184,27 @@ func MergeAll(repo repository.ClockedRepo, remote string) <-chan entity.MergeRes
 				return
 			}
 
-			updated, err := localBug.Merge(repo, remoteBug)
+			// Check that the protected identities are not impersonated
+			if err := remoteBug.VerifySignatures(repo, localBug); err != nil {
+				out <- entity.NewMergeInvalidStatus(id, errors.Wrap(err, "remote bug is not properly signed").Error())
+				continue
+			}
+
+			// only the new operations are checked
+			if err := remoteBug.VerifyPolicy(repo, policy, localBug); err != nil {
+				out <- entity.NewMergeInvalidStatus(id, errors.Wrap(err, "remote bug is not allowed").Error())
+				continue
+			}
+
+			merged, err := localBug.merge(repo, remoteBug)
 
 			if err != nil {
 				out <- entity.NewMergeInvalidStatus(id, errors.Wrap(err, "merge failed").Error())
 				return
 			}
 
-			if updated {
-				out <- entity.NewMergeStatus(entity.MergeStatusUpdated, id, localBug)
+			if merged > 0 {
+				out <- entity.NewMergeUpdatedStatus(id, localBug, merged)
 			} else {
 				out <- entity.NewMergeStatus(entity.MergeStatusNothing, id, localBug)
 			}
@@ -62,6 +62,62 @@ func TestPushPull(t *testing.T) {
 	}
 }
 
+func TestListOrphanRemoteRefs(t *testing.T) {
+	repoA, repoB, remote := repository.SetupReposAndRemote(t)
+	defer repository.CleanupTestRepos(t, repoA, repoB, remote)
+
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+
+	bug1, _, err := Create(rene, time.Now().Unix(), "bug1", "message")
+	require.NoError(t, err)
+	err = bug1.Commit(repoA)
+	require.NoError(t, err)
+
+	_, err = identity.Push(repoA, "origin")
+	require.NoError(t, err)
+	_, err = Push(repoA, "origin")
+	require.NoError(t, err)
+
+	// fetched but not merged, the refs are kept
+	_, err = identity.Fetch(repoB, "origin")
+	require.NoError(t, err)
+	_, err = Fetch(repoB, "origin")
+	require.NoError(t, err)
+
+	identityRefs, err := identity.ListOrphanRemoteRefs(repoB)
+	require.NoError(t, err)
+	require.Empty(t, identityRefs)
+
+	bugRefs, err := ListOrphanRemoteRefs(repoB)
+	require.NoError(t, err)
+	require.Empty(t, bugRefs)
+
+	err = identity.Pull(repoB, "origin")
+	require.NoError(t, err)
+	err = Pull(repoB, "origin")
+	require.NoError(t, err)
+
+	// removed, but a pull would merge it again from the remote-tracking ref
+	err = RemoveLocalBug(repoB, bug1.Id())
+	require.NoError(t, err)
+
+	bugRefs, err = ListOrphanRemoteRefs(repoB)
+	require.NoError(t, err)
+	require.Empty(t, bugRefs)
+
+	// once the remote is gone, nothing can merge them anymore
+	err = repoB.LocalConfig().RemoveAll("remote.origin")
+	require.NoError(t, err)
+
+	identityRefs, err = identity.ListOrphanRemoteRefs(repoB)
+	require.NoError(t, err)
+	require.Equal(t, []string{"refs/remotes/origin/identities/" + rene.Id().String()}, identityRefs)
+
+	bugRefs, err = ListOrphanRemoteRefs(repoB)
+	require.NoError(t, err)
+	require.Equal(t, []string{"refs/remotes/origin/bugs/" + bug1.Id().String()}, bugRefs)
+}
+
 func allBugs(t testing.TB, bugs <-chan StreamedBug) []*Bug {
 	var result []*Bug
 	for streamed := range bugs {
@@ -6,6 +6,7 @@ import (
 
 	"github.com/MichaelMure/git-bug/identity"
 	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/git"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -61,6 +62,41 @@ func TestBugValidity(t *testing.T) {
 	if err == nil {
 		t.Fatal("Invalid bug should not commit")
 	}
+
+	bug2 := NewBug()
+	bug2.Append(NewSetTitleOp(rene, time.Now().Unix(), "title2", "title1"))
+
+	err = bug2.Commit(mockRepo)
+	if err == nil {
+		t.Fatal("Bug not starting with a CreateOp should not commit")
+	}
+}
+
+func TestBugSnapshotIncremental(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+
+	b := &WithSnapshot{Bug: NewBug()}
+	b.Append(NewCreateOp(rene, time.Now().Unix(), "title", "message", nil))
+	assert.NoError(t, b.Commit(repo))
+
+	// the snapshot is compiled once, then maintained along the commits
+	assert.Equal(t, "title", b.Snapshot().Title)
+
+	for i := 0; i < 10; i++ {
+		b.Append(NewAddCommentOp(rene, time.Now().Unix(), "comment", nil))
+		b.Append(NewSetTitleOp(rene, time.Now().Unix(), "title2", "title"))
+		assert.NoError(t, b.CommitAsNeeded(repo))
+	}
+
+	compiled := b.Bug.Compile()
+	assert.Equal(t, compiled.Title, b.Snapshot().Title)
+	assert.Len(t, b.Snapshot().Operations, 21)
+	assert.Len(t, compiled.Operations, 21)
+	assert.Len(t, b.Snapshot().Comments, 11)
+	assert.Len(t, compiled.Comments, 11)
+	assert.Len(t, b.Snapshot().Timeline, len(compiled.Timeline))
 }
 
 func TestBugCommitLoad(t *testing.T) {
@@ -109,3 +145,75 @@ func equivalentBug(t *testing.T, expected, actual *Bug) {
 
 	assert.Equal(t, expected, actual)
 }
+
+func TestBugCommitDetached(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+
+	b := NewBug()
+	b.Append(NewCreateOp(rene, time.Now().Unix(), "title", "message", nil))
+	assert.NoError(t, b.CommitDetached(repo))
+
+	b.Append(NewAddCommentOp(rene, time.Now().Unix(), "message2", nil))
+	assert.NoError(t, b.CommitDetached(repo))
+
+	exist, err := repo.RefExist(bugsRefPattern + b.Id().String())
+	assert.NoError(t, err)
+	assert.False(t, exist)
+
+	// published without more operations
+	assert.NoError(t, b.CommitAsNeeded(repo))
+
+	b2, err := ReadLocalBug(repo, b.Id())
+	assert.NoError(t, err)
+	equivalentBug(t, b, b2)
+}
+
+func TestCommitAll(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	err := rene.Commit(repo)
+	assert.NoError(t, err)
+
+	file, err := repo.StoreData([]byte("content"))
+	assert.NoError(t, err)
+
+	// an existing bug with more operations, including a file
+	existing := NewBug()
+	existing.Append(NewCreateOp(rene, time.Now().Unix(), "existing", "message", nil))
+	assert.NoError(t, existing.Commit(repo))
+	existing.Append(NewAddCommentOp(rene, time.Now().Unix(), "with a file", []git.Hash{file}))
+
+	// a new bug
+	created := NewBug()
+	created.Append(NewCreateOp(rene, time.Now().Unix(), "created", "message", nil))
+
+	// a bug only waiting to be published
+	detached := NewBug()
+	detached.Append(NewCreateOp(rene, time.Now().Unix(), "detached", "message", nil))
+	assert.NoError(t, detached.CommitDetached(repo))
+
+	// and one without any change
+	unchanged := NewBug()
+	unchanged.Append(NewCreateOp(rene, time.Now().Unix(), "unchanged", "message", nil))
+	assert.NoError(t, unchanged.Commit(repo))
+
+	err = CommitAll(repo, []*Bug{existing, created, detached, unchanged})
+	assert.NoError(t, err)
+
+	for _, b := range []*Bug{existing, created, detached, unchanged} {
+		assert.False(t, b.NeedCommit())
+
+		read, err := ReadLocalBug(repo, b.Id())
+		assert.NoError(t, err)
+		assert.Equal(t, b.Compile().Title, read.Compile().Title)
+		assert.Equal(t, len(b.packs), len(read.packs))
+	}
+
+	read, err := ReadLocalBug(repo, existing.Id())
+	assert.NoError(t, err)
+	assert.Equal(t, []git.Hash{file}, read.Compile().Comments[1].Files)
+}
@@ -0,0 +1,150 @@
+package bug
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// CheckResult is the outcome of the integrity check of a bug
+type CheckResult struct {
+	Id entity.Id
+	// Broken tell that the bug can't be read or is invalid as a whole
+	Broken bool
+	// Problems describe what is wrong with the bug, if anything
+	Problems []string
+	// MissingIdentities are the identities referenced by the bug that don't
+	// exist locally
+	MissingIdentities []entity.Id
+}
+
+// Ok tell if no problem was found
+func (cr CheckResult) Ok() bool {
+	return len(cr.Problems) == 0
+}
+
+func (cr *CheckResult) problem(format string, a ...interface{}) {
+	cr.Problems = append(cr.Problems, fmt.Sprintf(format, a...))
+}
+
+// CheckLocalBugs check the integrity of all the local bugs: their operation
+// packs parse and are valid, their edit lamport times come after the root one,
+// the identities and the comments they reference exist, as well as their
+// files, and they are properly signed.
+func CheckLocalBugs(repo repository.ClockedRepo) ([]CheckResult, error) {
+	refs, err := repo.ListRefs(bugsRefPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]CheckResult, len(refs))
+	for i, ref := range refs {
+		results[i] = checkBug(repo, ref)
+	}
+
+	return results, nil
+}
+
+func checkBug(repo repository.ClockedRepo, ref string) CheckResult {
+	result := CheckResult{Id: refsToIds([]string{ref})[0]}
+
+	b, err := readBugData(repo, ref)
+	if err != nil {
+		result.Broken = true
+		result.problem("unreadable: %v", err)
+		return result
+	}
+
+	// the packs are rebased when merging, so their edit lamport times are not
+	// ordered along the history, but they all come after the root pack
+	for _, pack := range b.packs[1:] {
+		if pack.editTime <= b.packs[0].editTime {
+			result.problem("the edit lamport time %d of %s is not after the one of the first pack (%d)",
+				pack.editTime, pack.commitHash, b.packs[0].editTime)
+		}
+	}
+
+	resolver := identity.NewSimpleResolver(repo)
+	seenIdentities := make(map[entity.Id]bool)
+	checkIdentity := func(id entity.Id) {
+		if seenIdentities[id] {
+			return
+		}
+		seenIdentities[id] = true
+
+		_, err := resolver.ResolveIdentity(id)
+		if err == identity.ErrIdentityNotExist {
+			result.MissingIdentities = append(result.MissingIdentities, id)
+			result.problem("the identity %s doesn't exist", id.Human())
+		} else if err != nil {
+			result.problem("the identity %s can't be read: %v", id.Human(), err)
+		}
+	}
+
+	comments := make(map[entity.Id]bool)
+	seenFiles := make(map[string]bool)
+
+	it := NewOperationIterator(b)
+	for it.Next() {
+		op := it.Value()
+
+		checkIdentity(op.GetAuthor().Id())
+
+		switch op := op.(type) {
+		case *CreateOperation, *AddCommentOperation:
+			comments[op.Id()] = true
+		case *EditCommentOperation:
+			if !comments[op.Target] {
+				result.problem("the operation %s edit the unknown comment %s", op.Id().Human(), op.Target.Human())
+			}
+		case *DeleteCommentOperation:
+			if !comments[op.Target] {
+				result.problem("the operation %s delete the unknown comment %s", op.Id().Human(), op.Target.Human())
+			}
+		case *SetAssigneeOperation:
+			if op.Assignee != "" {
+				checkIdentity(op.Assignee)
+			}
+		}
+
+		for _, file := range op.GetFiles() {
+			if seenFiles[string(file)] {
+				continue
+			}
+			seenFiles[string(file)] = true
+
+			if _, err := repo.ReadData(file); err != nil {
+				result.problem("the file %s of the operation %s can't be read", file, op.Id().Human())
+			}
+		}
+	}
+
+	// the bug can only be validated with its authors, and the signatures
+	// checked with their keys
+	if len(result.MissingIdentities) > 0 {
+		return result
+	}
+
+	err = b.EnsureIdentities(resolver)
+	if err != nil {
+		result.problem("%v", err)
+		return result
+	}
+
+	err = b.Validate()
+	if err != nil {
+		result.Broken = true
+		result.problem("invalid: %v", err)
+		return result
+	}
+
+	// the local history is trusted for the time of its packs
+	err = b.VerifySignatures(repo, b)
+	if err != nil {
+		result.problem("%v", err)
+	}
+
+	return result
+}
@@ -0,0 +1,71 @@
+package bug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestCheckLocalBugs(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, rene.Commit(repo))
+
+	isaac := identity.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	require.NoError(t, isaac.Commit(repo))
+
+	sound, _, err := Create(rene, time.Now().Unix(), "sound", "message")
+	require.NoError(t, err)
+	require.NoError(t, sound.Commit(repo))
+
+	orphan, _, err := Create(isaac, time.Now().Unix(), "orphan", "message")
+	require.NoError(t, err)
+	require.NoError(t, orphan.Commit(repo))
+
+	dangling, _, err := Create(rene, time.Now().Unix(), "dangling", "message")
+	require.NoError(t, err)
+	// the comment of another bug
+	_, err = EditComment(dangling, rene, time.Now().Unix(), sound.FirstOp().Id(), "edited")
+	require.NoError(t, err)
+	require.NoError(t, dangling.Commit(repo))
+
+	// isaac is lost
+	require.NoError(t, repo.RemoveRef("refs/identities/"+isaac.Id().String()))
+
+	// a commit without operations
+	blob, err := repo.StoreData([]byte("garbage"))
+	require.NoError(t, err)
+	tree, err := repo.StoreTree([]repository.TreeEntry{{ObjectType: repository.Blob, Hash: blob, Name: "garbage"}})
+	require.NoError(t, err)
+	commit, err := repo.StoreCommit(tree)
+	require.NoError(t, err)
+	require.NoError(t, repo.UpdateRef("refs/bugs/"+string(commit), commit))
+
+	results, err := CheckLocalBugs(repo)
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	byId := make(map[entity.Id]CheckResult)
+	for _, result := range results {
+		byId[result.Id] = result
+	}
+
+	require.True(t, byId[sound.Id()].Ok())
+
+	require.False(t, byId[orphan.Id()].Ok())
+	require.False(t, byId[orphan.Id()].Broken)
+	require.Equal(t, []entity.Id{isaac.Id()}, byId[orphan.Id()].MissingIdentities)
+
+	require.False(t, byId[dangling.Id()].Ok())
+	require.False(t, byId[dangling.Id()].Broken)
+	require.Empty(t, byId[dangling.Id()].MissingIdentities)
+
+	require.True(t, byId[entity.Id(commit)].Broken)
+}
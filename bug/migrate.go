@@ -0,0 +1,114 @@
+package bug
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+var ErrBugShared = errors.New("the bug is shared with a remote, its history can't be rewritten")
+
+// MigratePacks rewrite the operation packs of a local bug in the given format
+// version, except the first one as the hash of its commit is the id of the bug.
+// As the hashes of the following commits change, this is refused for a bug
+// shared with a remote, whose copies would conflict with the rewritten one.
+// Return whether the bug was changed.
+func MigratePacks(repo repository.ClockedRepo, id entity.Id, version uint) (bool, error) {
+	shared, err := isShared(repo, id)
+	if err != nil {
+		return false, err
+	}
+	if shared {
+		return false, ErrBugShared
+	}
+
+	b, err := ReadLocalBug(repo, id)
+	if err != nil {
+		return false, err
+	}
+
+	parent := b.packs[0].commitHash
+	rewritten := false
+
+	for _, pack := range b.packs[1:] {
+		data, err := pack.encode(version)
+		if err != nil {
+			return false, err
+		}
+
+		hash, err := repo.StoreData(data)
+		if err != nil {
+			return false, err
+		}
+
+		if !rewritten && hash == pack.blobHash {
+			parent = pack.commitHash
+			continue
+		}
+
+		entries, err := repo.ListEntries(pack.commitHash)
+		if err != nil {
+			return false, errors.Wrap(err, "can't list git tree entries")
+		}
+
+		// the clocks, the root pack and the media are kept as is
+		var tree []repository.TreeEntry
+		for _, entry := range entries {
+			if _, ok := identity.IsSignatureEntry(entry); ok || entry.Name == opsEntryName {
+				continue
+			}
+			tree = append(tree, entry)
+		}
+
+		tree = append(tree, repository.TreeEntry{
+			ObjectType: repository.Blob,
+			Hash:       hash,
+			Name:       opsEntryName,
+		})
+
+		signatures, err := resignPack(repo, pack, packPayload(b.rootPack, parent, data))
+		if err != nil {
+			return false, err
+		}
+		tree = append(tree, signatures...)
+
+		treeHash, err := repo.StoreTree(tree)
+		if err != nil {
+			return false, err
+		}
+
+		parent, err = repo.StoreCommitWithParent(treeHash, parent)
+		if err != nil {
+			return false, err
+		}
+
+		rewritten = true
+	}
+
+	if !rewritten {
+		return false, nil
+	}
+
+	return true, repo.UpdateRef(bugsRefPattern+id.String(), parent)
+}
+
+// isShared tell if a bug has a remote-tracking ref, that is if it was pushed
+// or pulled
+func isShared(repo repository.Repo, id entity.Id) (bool, error) {
+	refs, err := repo.ListRefs("refs/remotes/")
+	if err != nil {
+		return false, err
+	}
+
+	for _, ref := range refs {
+		if strings.HasSuffix(ref, "/bugs/"+id.String()) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,70 @@
+package bug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestMigratePacks(t *testing.T) {
+	repo, other, remote := repository.SetupReposAndRemote(t)
+	defer repository.CleanupTestRepos(t, repo, other, remote)
+
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	key, err := identity.GenerateKey(repo)
+	require.NoError(t, err)
+	require.NoError(t, rene.AddKey(key))
+	require.NoError(t, rene.Commit(repo))
+
+	local, _, err := Create(rene, time.Now().Unix(), "local", "message")
+	require.NoError(t, err)
+	require.NoError(t, local.Commit(repo))
+	_, err = AddComment(local, rene, time.Now().Unix(), "comment")
+	require.NoError(t, err)
+	require.NoError(t, local.Commit(repo))
+	_, err = SetTitle(local, rene, time.Now().Unix(), "new title")
+	require.NoError(t, err)
+	require.NoError(t, local.Commit(repo))
+
+	changed, err := MigratePacks(repo, local.Id(), formatVersion2)
+	require.NoError(t, err)
+	require.True(t, changed)
+
+	migrated, err := ReadLocalBug(repo, local.Id())
+	require.NoError(t, err)
+	require.NoError(t, migrated.VerifySignatures(repo, nil))
+	require.Equal(t, local.packs[0].commitHash, migrated.packs[0].commitHash)
+	require.NotEqual(t, local.lastCommit, migrated.lastCommit)
+	require.Equal(t, "new title", migrated.Compile().Title)
+	require.Len(t, migrated.Compile().Comments, 2)
+
+	// already in this format
+	changed, err = MigratePacks(repo, local.Id(), formatVersion2)
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	// the next packs are written in this format as well
+	require.NoError(t, SetPackFormat(repo, formatVersion2))
+	_, err = AddComment(migrated, rene, time.Now().Unix(), "compressed")
+	require.NoError(t, err)
+	require.NoError(t, migrated.Commit(repo))
+
+	changed, err = MigratePacks(repo, local.Id(), formatVersion2)
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	shared, _, err := Create(rene, time.Now().Unix(), "shared", "message")
+	require.NoError(t, err)
+	require.NoError(t, shared.Commit(repo))
+	_, err = Push(repo, "origin")
+	require.NoError(t, err)
+	_, err = Fetch(repo, "origin")
+	require.NoError(t, err)
+
+	_, err = MigratePacks(repo, shared.Id(), formatVersion2)
+	require.Equal(t, ErrBugShared, err)
+}
@@ -0,0 +1,115 @@
+package bug
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+var _ Operation = &DeleteCommentOperation{}
+
+// DeleteCommentOperation will remove a comment from the bug. The first
+// comment, the description of the bug, can't be removed.
+type DeleteCommentOperation struct {
+	OpBase
+	Target entity.Id `json:"target"`
+}
+
+func (op *DeleteCommentOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *DeleteCommentOperation) Id() entity.Id {
+	return idOperation(op)
+}
+
+func (op *DeleteCommentOperation) Apply(snapshot *Snapshot) {
+	// Todo: currently any comment can be removed, even by a different author
+	// crypto signature are needed.
+
+	snapshot.addActor(op.Author)
+
+	for i, item := range snapshot.Timeline {
+		if item.Id() != op.Target {
+			continue
+		}
+
+		if _, ok := item.(*AddCommentTimelineItem); !ok {
+			// only a comment can be removed
+			return
+		}
+
+		snapshot.Timeline = append(snapshot.Timeline[:i], snapshot.Timeline[i+1:]...)
+		break
+	}
+
+	for i := range snapshot.Comments {
+		// the description can't be removed
+		if i > 0 && snapshot.Comments[i].Id() == op.Target {
+			snapshot.Comments = append(snapshot.Comments[:i], snapshot.Comments[i+1:]...)
+			break
+		}
+	}
+}
+
+func (op *DeleteCommentOperation) Validate() error {
+	if err := opBaseValidate(op, DeleteCommentOp); err != nil {
+		return err
+	}
+
+	if err := op.Target.Validate(); err != nil {
+		return errors.Wrap(err, "target hash is invalid")
+	}
+
+	return nil
+}
+
+// UnmarshalJSON is a two step JSON unmarshaling
+// This workaround is necessary to avoid the inner OpBase.MarshalJSON
+// overriding the outer op's MarshalJSON
+func (op *DeleteCommentOperation) UnmarshalJSON(data []byte) error {
+	// Unmarshal OpBase and the op separately
+
+	base := OpBase{}
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	aux := struct {
+		Target entity.Id `json:"target"`
+	}{}
+
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	op.OpBase = base
+	op.Target = aux.Target
+
+	return nil
+}
+
+// Sign post method for gqlgen
+func (op *DeleteCommentOperation) IsAuthored() {}
+
+func NewDeleteCommentOp(author identity.Interface, unixTime int64, target entity.Id) *DeleteCommentOperation {
+	return &DeleteCommentOperation{
+		OpBase: newOpBase(DeleteCommentOp, author, unixTime),
+		Target: target,
+	}
+}
+
+// Convenience function to apply the operation
+func DeleteComment(b Interface, author identity.Interface, unixTime int64, target entity.Id) (*DeleteCommentOperation, error) {
+	op := NewDeleteCommentOp(author, unixTime, target)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
@@ -0,0 +1,71 @@
+package bug
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+func TestDelete(t *testing.T) {
+	snapshot := Snapshot{}
+
+	rene := identity.NewBare("René Descartes", "rene@descartes.fr")
+	unix := time.Now().Unix()
+
+	create := NewCreateOp(rene, unix, "title", "create", nil)
+	create.Apply(&snapshot)
+
+	id1 := create.Id()
+	require.NoError(t, id1.Validate())
+
+	comment := NewAddCommentOp(rene, unix, "comment", nil)
+	comment.Apply(&snapshot)
+
+	id2 := comment.Id()
+	require.NoError(t, id2.Validate())
+
+	// the description can't be removed
+	del := NewDeleteCommentOp(rene, unix, id1)
+	del.Apply(&snapshot)
+
+	assert.Len(t, snapshot.Timeline, 2)
+	assert.Len(t, snapshot.Comments, 2)
+
+	del = NewDeleteCommentOp(rene, unix, id2)
+	del.Apply(&snapshot)
+
+	assert.Len(t, snapshot.Timeline, 1)
+	assert.Len(t, snapshot.Comments, 1)
+	assert.Equal(t, "create", snapshot.Comments[0].Message)
+
+	// editing a removed comment is a no-op
+	edit := NewEditCommentOp(rene, unix, id2, "edited", nil)
+	edit.Apply(&snapshot)
+
+	assert.Len(t, snapshot.Comments, 1)
+	assert.Equal(t, "create", snapshot.Comments[0].Message)
+}
+
+func TestDeleteCommentSerialize(t *testing.T) {
+	var rene = identity.NewBare("René Descartes", "rene@descartes.fr")
+	unix := time.Now().Unix()
+	before := NewDeleteCommentOp(rene, unix, "123")
+
+	data, err := json.Marshal(before)
+	assert.NoError(t, err)
+
+	var after DeleteCommentOperation
+	err = json.Unmarshal(data, &after)
+	assert.NoError(t, err)
+
+	// enforce creating the IDs
+	before.Id()
+	rene.Id()
+
+	assert.Equal(t, before, &after)
+}
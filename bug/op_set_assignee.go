@@ -0,0 +1,93 @@
+package bug
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+var _ Operation = &SetAssigneeOperation{}
+
+// SetAssigneeOperation will change the identity assigned to a bug. An empty
+// Assignee remove the assignment.
+type SetAssigneeOperation struct {
+	OpBase
+	Assignee entity.Id `json:"assignee"`
+}
+
+func (op *SetAssigneeOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *SetAssigneeOperation) Id() entity.Id {
+	return idOperation(op)
+}
+
+func (op *SetAssigneeOperation) Apply(snapshot *Snapshot) {
+	snapshot.Assignee = op.Assignee
+	snapshot.addActor(op.Author)
+}
+
+func (op *SetAssigneeOperation) Validate() error {
+	if err := opBaseValidate(op, SetAssigneeOp); err != nil {
+		return err
+	}
+
+	if op.Assignee != "" {
+		if err := op.Assignee.Validate(); err != nil {
+			return errors.Wrap(err, "assignee")
+		}
+	}
+
+	return nil
+}
+
+// UnmarshalJSON is a two step JSON unmarshaling
+// This workaround is necessary to avoid the inner OpBase.MarshalJSON
+// overriding the outer op's MarshalJSON
+func (op *SetAssigneeOperation) UnmarshalJSON(data []byte) error {
+	// Unmarshal OpBase and the op separately
+
+	base := OpBase{}
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	aux := struct {
+		Assignee entity.Id `json:"assignee"`
+	}{}
+
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	op.OpBase = base
+	op.Assignee = aux.Assignee
+
+	return nil
+}
+
+// Sign post method for gqlgen
+func (op *SetAssigneeOperation) IsAuthored() {}
+
+func NewSetAssigneeOp(author identity.Interface, unixTime int64, assignee entity.Id) *SetAssigneeOperation {
+	return &SetAssigneeOperation{
+		OpBase:   newOpBase(SetAssigneeOp, author, unixTime),
+		Assignee: assignee,
+	}
+}
+
+// Convenience function to apply the operation
+func SetAssignee(b Interface, author identity.Interface, unixTime int64, assignee entity.Id) (*SetAssigneeOperation, error) {
+	op := NewSetAssigneeOp(author, unixTime, assignee)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
@@ -0,0 +1,29 @@
+package bug
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetMilestoneSerialize(t *testing.T) {
+	var rene = identity.NewBare("René Descartes", "rene@descartes.fr")
+	unix := time.Now().Unix()
+	before := NewSetMilestoneOp(rene, unix, "v1.0")
+
+	data, err := json.Marshal(before)
+	assert.NoError(t, err)
+
+	var after SetMilestoneOperation
+	err = json.Unmarshal(data, &after)
+	assert.NoError(t, err)
+
+	// enforce creating the IDs
+	before.Id()
+	rene.Id()
+
+	assert.Equal(t, before, &after)
+}
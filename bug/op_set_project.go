@@ -0,0 +1,106 @@
+package bug
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+// a project name can be used in a git ref or a file name
+var projectRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+var _ Operation = &SetProjectOperation{}
+
+// SetProjectOperation will move a bug into a project, to partition the bugs of
+// a repository tracking several components. An empty Project remove the bug
+// from its project.
+type SetProjectOperation struct {
+	OpBase
+	Project string `json:"project"`
+}
+
+func (op *SetProjectOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *SetProjectOperation) Id() entity.Id {
+	return idOperation(op)
+}
+
+func (op *SetProjectOperation) Apply(snapshot *Snapshot) {
+	snapshot.Project = op.Project
+	snapshot.addActor(op.Author)
+}
+
+func (op *SetProjectOperation) Validate() error {
+	if err := opBaseValidate(op, SetProjectOp); err != nil {
+		return err
+	}
+
+	if op.Project == "" {
+		return nil
+	}
+
+	return ValidateProject(op.Project)
+}
+
+// UnmarshalJSON is a two step JSON unmarshaling
+// This workaround is necessary to avoid the inner OpBase.MarshalJSON
+// overriding the outer op's MarshalJSON
+func (op *SetProjectOperation) UnmarshalJSON(data []byte) error {
+	// Unmarshal OpBase and the op separately
+
+	base := OpBase{}
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	aux := struct {
+		Project string `json:"project"`
+	}{}
+
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	op.OpBase = base
+	op.Project = aux.Project
+
+	return nil
+}
+
+// Sign post method for gqlgen
+func (op *SetProjectOperation) IsAuthored() {}
+
+func NewSetProjectOp(author identity.Interface, unixTime int64, project string) *SetProjectOperation {
+	return &SetProjectOperation{
+		OpBase:  newOpBase(SetProjectOp, author, unixTime),
+		Project: project,
+	}
+}
+
+// Convenience function to apply the operation
+func SetProject(b Interface, author identity.Interface, unixTime int64, project string) (*SetProjectOperation, error) {
+	op := NewSetProjectOp(author, unixTime, strings.TrimSpace(project))
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
+
+// ValidateProject check that a project name is made of letters, digits, dots,
+// dashes and underscores, and doesn't start with a punctuation.
+func ValidateProject(project string) error {
+	if !projectRegexp.MatchString(project) {
+		return fmt.Errorf("invalid project name %q: only letters, digits, '.', '-' and '_' are allowed", project)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package bug
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetProjectSerialize(t *testing.T) {
+	var rene = identity.NewBare("René Descartes", "rene@descartes.fr")
+	unix := time.Now().Unix()
+	before := NewSetProjectOp(rene, unix, "frontend")
+
+	data, err := json.Marshal(before)
+	assert.NoError(t, err)
+
+	var after SetProjectOperation
+	err = json.Unmarshal(data, &after)
+	assert.NoError(t, err)
+
+	// enforce creating the IDs
+	before.Id()
+	rene.Id()
+
+	assert.Equal(t, before, &after)
+}
+
+func TestValidateProject(t *testing.T) {
+	assert.NoError(t, ValidateProject("frontend"))
+	assert.NoError(t, ValidateProject("api-v2.1_beta"))
+
+	assert.Error(t, ValidateProject(""))
+	assert.Error(t, ValidateProject("front end"))
+	assert.Error(t, ValidateProject("front/end"))
+	assert.Error(t, ValidateProject(".hidden"))
+	assert.Error(t, ValidateProject("-flag"))
+}
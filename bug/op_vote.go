@@ -0,0 +1,100 @@
+package bug
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+var _ Operation = &VoteOperation{}
+
+// VoteOperation will set the vote of its author on a bug: 1 for an up-vote,
+// -1 for a down-vote and 0 to retract a previous vote. Only the last vote of
+// each author is counted.
+type VoteOperation struct {
+	OpBase
+	Vote int `json:"vote"`
+}
+
+func (op *VoteOperation) base() *OpBase {
+	return &op.OpBase
+}
+
+func (op *VoteOperation) Id() entity.Id {
+	return idOperation(op)
+}
+
+func (op *VoteOperation) Apply(snapshot *Snapshot) {
+	if snapshot.Votes == nil {
+		snapshot.Votes = make(map[entity.Id]int)
+	}
+
+	if op.Vote == 0 {
+		delete(snapshot.Votes, op.Author.Id())
+	} else {
+		snapshot.Votes[op.Author.Id()] = op.Vote
+	}
+
+	snapshot.addActor(op.Author)
+}
+
+func (op *VoteOperation) Validate() error {
+	if err := opBaseValidate(op, VoteOp); err != nil {
+		return err
+	}
+
+	if op.Vote < -1 || op.Vote > 1 {
+		return fmt.Errorf("vote should be -1, 0 or 1")
+	}
+
+	return nil
+}
+
+// UnmarshalJSON is a two step JSON unmarshaling
+// This workaround is necessary to avoid the inner OpBase.MarshalJSON
+// overriding the outer op's MarshalJSON
+func (op *VoteOperation) UnmarshalJSON(data []byte) error {
+	// Unmarshal OpBase and the op separately
+
+	base := OpBase{}
+	err := json.Unmarshal(data, &base)
+	if err != nil {
+		return err
+	}
+
+	aux := struct {
+		Vote int `json:"vote"`
+	}{}
+
+	err = json.Unmarshal(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	op.OpBase = base
+	op.Vote = aux.Vote
+
+	return nil
+}
+
+// Sign post method for gqlgen
+func (op *VoteOperation) IsAuthored() {}
+
+func NewVoteOp(author identity.Interface, unixTime int64, vote int) *VoteOperation {
+	return &VoteOperation{
+		OpBase: newOpBase(VoteOp, author, unixTime),
+		Vote:   vote,
+	}
+}
+
+// Convenience function to apply the operation
+func Vote(b Interface, author identity.Interface, unixTime int64, vote int) (*VoteOperation, error) {
+	op := NewVoteOp(author, unixTime, vote)
+	if err := op.Validate(); err != nil {
+		return nil, err
+	}
+	b.Append(op)
+	return op, nil
+}
@@ -26,6 +26,11 @@ const (
 	EditCommentOp
 	NoOpOp
 	SetMetadataOp
+	SetAssigneeOp
+	SetMilestoneOp
+	VoteOp
+	DeleteCommentOp
+	SetProjectOp
 )
 
 // Operation define the interface to fulfill for an edit operation of a Bug
@@ -1,14 +1,19 @@
 package bug
 
+// OperationIterator iterate over the operations of a bug in their total order,
+// staging area included
 type OperationIterator struct {
-	bug       *Bug
+	packs     []OperationPack
+	staging   OperationPack
 	packIndex int
 	opIndex   int
 }
 
 func NewOperationIterator(bug Interface) *OperationIterator {
+	b := bugFromInterface(bug)
 	return &OperationIterator{
-		bug:       bugFromInterface(bug),
+		packs:     b.orderedPacks(),
+		staging:   b.staging,
 		packIndex: 0,
 		opIndex:   -1,
 	}
@@ -16,17 +21,17 @@ func NewOperationIterator(bug Interface) *OperationIterator {
 
 func (it *OperationIterator) Next() bool {
 	// Special case of the staging area
-	if it.packIndex == len(it.bug.packs) {
-		pack := it.bug.staging
+	if it.packIndex == len(it.packs) {
+		pack := it.staging
 		it.opIndex++
 		return it.opIndex < len(pack.Operations)
 	}
 
-	if it.packIndex >= len(it.bug.packs) {
+	if it.packIndex >= len(it.packs) {
 		return false
 	}
 
-	pack := it.bug.packs[it.packIndex]
+	pack := it.packs[it.packIndex]
 
 	it.opIndex++
 
@@ -39,17 +44,17 @@ func (it *OperationIterator) Next() bool {
 	it.packIndex++
 
 	// Special case of the non-empty staging area
-	if it.packIndex == len(it.bug.packs) && len(it.bug.staging.Operations) > 0 {
+	if it.packIndex == len(it.packs) && len(it.staging.Operations) > 0 {
 		return true
 	}
 
-	return it.packIndex < len(it.bug.packs)
+	return it.packIndex < len(it.packs)
 }
 
 func (it *OperationIterator) Value() Operation {
 	// Special case of the staging area
-	if it.packIndex == len(it.bug.packs) {
-		pack := it.bug.staging
+	if it.packIndex == len(it.packs) {
+		pack := it.staging
 
 		if it.opIndex >= len(pack.Operations) {
 			panic("Iterator is not valid anymore")
@@ -58,11 +63,11 @@ func (it *OperationIterator) Value() Operation {
 		return pack.Operations[it.opIndex]
 	}
 
-	if it.packIndex >= len(it.bug.packs) {
+	if it.packIndex >= len(it.packs) {
 		panic("Iterator is not valid anymore")
 	}
 
-	pack := it.bug.packs[it.packIndex]
+	pack := it.packs[it.packIndex]
 
 	if it.opIndex >= len(pack.Operations) {
 		panic("Iterator is not valid anymore")
@@ -1,15 +1,35 @@
 package bug
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 
+	"github.com/MichaelMure/git-bug/identity"
 	"github.com/MichaelMure/git-bug/repository"
 	"github.com/MichaelMure/git-bug/util/git"
+	"github.com/MichaelMure/git-bug/util/lamport"
 	"github.com/pkg/errors"
 )
 
-const formatVersion = 1
+// The format versions of the operation packs:
+// - 1 is plain JSON
+// - 2 is the same JSON, gzip compressed
+const (
+	formatVersion1 = 1
+	formatVersion2 = 2
+)
+
+// LatestPackFormat is the most recent format version of the operation packs
+const LatestPackFormat = formatVersion2
+
+// packFormatConfigKey is the git config key holding the format version of the
+// operation packs written in this repository. As older versions of git-bug
+// can only read the first one, it's the default.
+const packFormatConfigKey = "git-bug.pack-format"
 
 // OperationPack represent an ordered set of operation to apply
 // to a Bug. These operations are stored in a single Git commit.
@@ -22,14 +42,23 @@ type OperationPack struct {
 
 	// Private field so not serialized
 	commitHash git.Hash
+	blobHash   git.Hash
+	editTime   lamport.Time
+
+	// the signatures of the pack by the authors having declared keys
+	signatures identity.Signatures
 }
 
 func (opp *OperationPack) MarshalJSON() ([]byte, error) {
+	return opp.marshalJSON(formatVersion1)
+}
+
+func (opp *OperationPack) marshalJSON(version uint) ([]byte, error) {
 	return json.Marshal(struct {
 		Version    uint        `json:"version"`
 		Operations []Operation `json:"ops"`
 	}{
-		Version:    formatVersion,
+		Version:    version,
 		Operations: opp.Operations,
 	})
 }
@@ -44,21 +73,12 @@ func (opp *OperationPack) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	if aux.Version != formatVersion {
+	if aux.Version != formatVersion1 && aux.Version != formatVersion2 {
 		return fmt.Errorf("unknown format version %v", aux.Version)
 	}
 
 	for _, raw := range aux.Operations {
-		var t struct {
-			OperationType OperationType `json:"type"`
-		}
-
-		if err := json.Unmarshal(raw, &t); err != nil {
-			return err
-		}
-
-		// delegate to specialized unmarshal function
-		op, err := opp.unmarshalOp(raw, t.OperationType)
+		op, err := UnmarshalOperation(raw)
 		if err != nil {
 			return err
 		}
@@ -69,7 +89,23 @@ func (opp *OperationPack) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-func (opp *OperationPack) unmarshalOp(raw []byte, _type OperationType) (Operation, error) {
+// UnmarshalOperation decode a single serialized operation, whatever its type.
+// As the id of an operation is derived from its serialized form, the result has
+// the id of the given data.
+func UnmarshalOperation(raw []byte) (Operation, error) {
+	var t struct {
+		OperationType OperationType `json:"type"`
+	}
+
+	if err := json.Unmarshal(raw, &t); err != nil {
+		return nil, err
+	}
+
+	// delegate to specialized unmarshal function
+	return unmarshalOp(raw, t.OperationType)
+}
+
+func unmarshalOp(raw []byte, _type OperationType) (Operation, error) {
 	switch _type {
 	case AddCommentOp:
 		op := &AddCommentOperation{}
@@ -95,6 +131,26 @@ func (opp *OperationPack) unmarshalOp(raw []byte, _type OperationType) (Operatio
 		op := &SetMetadataOperation{}
 		err := json.Unmarshal(raw, &op)
 		return op, err
+	case SetAssigneeOp:
+		op := &SetAssigneeOperation{}
+		err := json.Unmarshal(raw, &op)
+		return op, err
+	case SetMilestoneOp:
+		op := &SetMilestoneOperation{}
+		err := json.Unmarshal(raw, &op)
+		return op, err
+	case VoteOp:
+		op := &VoteOperation{}
+		err := json.Unmarshal(raw, &op)
+		return op, err
+	case DeleteCommentOp:
+		op := &DeleteCommentOperation{}
+		err := json.Unmarshal(raw, &op)
+		return op, err
+	case SetProjectOp:
+		op := &SetProjectOperation{}
+		err := json.Unmarshal(raw, &op)
+		return op, err
 	case SetStatusOp:
 		op := &SetStatusOperation{}
 		err := json.Unmarshal(raw, &op)
@@ -136,33 +192,146 @@ func (opp *OperationPack) Validate() error {
 // Write will serialize and store the OperationPack as a git blob and return
 // its hash
 func (opp *OperationPack) Write(repo repository.ClockedRepo) (git.Hash, error) {
+	data, err := opp.serialize(repo)
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := repo.StoreData(data)
+
+	if err != nil {
+		return "", err
+	}
+
+	return hash, nil
+}
+
+// serialize validate and serialize the OperationPack, ready to be stored as a
+// git blob
+func (opp *OperationPack) serialize(repo repository.ClockedRepo) ([]byte, error) {
 	// make sure we don't write invalid data
 	err := opp.Validate()
 	if err != nil {
-		return "", errors.Wrap(err, "validation error")
+		return nil, errors.Wrap(err, "validation error")
 	}
 
 	// First, make sure that all the identities are properly Commit as well
 	for _, op := range opp.Operations {
 		err := op.base().Author.CommitAsNeeded(repo)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 	}
 
-	data, err := json.Marshal(opp)
+	version, err := PackFormat(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return opp.encode(version)
+}
 
+// PackFormat return the format version of the operation packs written in the
+// repository
+func PackFormat(repo repository.RepoCommon) (uint, error) {
+	version, err := repo.ReadConfigString(packFormatConfigKey)
+	if err == repository.ErrNoConfigEntry {
+		return formatVersion1, nil
+	}
 	if err != nil {
-		return "", err
+		return 0, err
 	}
 
-	hash, err := repo.StoreData(data)
+	switch version {
+	case "1":
+		return formatVersion1, nil
+	case "2":
+		return formatVersion2, nil
+	default:
+		return 0, fmt.Errorf("invalid %s: %s", packFormatConfigKey, version)
+	}
+}
+
+// SetPackFormat set the format version of the operation packs written in the
+// repository from now on
+func SetPackFormat(repo repository.RepoCommon, version uint) error {
+	if version != formatVersion1 && version != formatVersion2 {
+		return fmt.Errorf("unknown format version %v", version)
+	}
+	return repo.StoreConfig(packFormatConfigKey, fmt.Sprintf("%d", version))
+}
 
+// encode serialize the OperationPack in the given format version
+func (opp *OperationPack) encode(version uint) ([]byte, error) {
+	data, err := opp.marshalJSON(version)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return hash, nil
+	if version == formatVersion1 {
+		return data, nil
+	}
+
+	var buffer bytes.Buffer
+	w, err := gzip.NewWriterLevel(&buffer, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// maxPackSize is the maximum size of a decompressed operation pack, so that a
+// small but highly compressed pack from a remote can't exhaust the memory
+const maxPackSize = 64 * 1024 * 1024
+
+// decodeOperationPack read an OperationPack in any of the format versions
+func decodeOperationPack(data []byte) (*OperationPack, error) {
+	// the compressed packs start with the gzip magic number, while the JSON
+	// ones start with a brace
+	compressed := len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b
+
+	if compressed {
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		data, err = ioutil.ReadAll(io.LimitReader(r, maxPackSize+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > maxPackSize {
+			return nil, fmt.Errorf("operation pack larger than %d bytes once decompressed", maxPackSize)
+		}
+	}
+
+	// the version has to match the encoding
+	var header struct {
+		Version uint `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+	if compressed && header.Version != formatVersion2 {
+		return nil, fmt.Errorf("compressed operation pack with format version %v", header.Version)
+	}
+	if !compressed && header.Version == formatVersion2 {
+		return nil, fmt.Errorf("uncompressed operation pack with format version %v", header.Version)
+	}
+
+	opp := &OperationPack{}
+	err := json.Unmarshal(data, &opp)
+	if err != nil {
+		return nil, err
+	}
+
+	return opp, nil
 }
 
 // Make a deep copy
@@ -171,6 +340,9 @@ func (opp *OperationPack) Clone() OperationPack {
 	clone := OperationPack{
 		Operations: make([]Operation, len(opp.Operations)),
 		commitHash: opp.commitHash,
+		blobHash:   opp.blobHash,
+		editTime:   opp.editTime,
+		signatures: opp.signatures,
 	}
 
 	for i, op := range opp.Operations {
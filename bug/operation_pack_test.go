@@ -1,7 +1,10 @@
 package bug
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
+	"io"
 	"testing"
 	"time"
 
@@ -61,3 +64,72 @@ func ensureIDs(t *testing.T, opp *OperationPack) {
 		require.NoError(t, id.Validate())
 	}
 }
+
+func TestOperationPackEncode(t *testing.T) {
+	opp := &OperationPack{}
+
+	rene := identity.NewBare("René Descartes", "rene@descartes.fr")
+	opp.Append(NewCreateOp(rene, time.Now().Unix(), "title", "message", nil))
+	opp.Append(NewAddCommentOp(rene, time.Now().Unix(), "message2", nil))
+
+	plain, err := opp.encode(formatVersion1)
+	require.NoError(t, err)
+
+	compressed, err := opp.encode(formatVersion2)
+	require.NoError(t, err)
+	require.NotEqual(t, plain, compressed)
+
+	for _, data := range [][]byte{plain, compressed} {
+		decoded, err := decodeOperationPack(data)
+		require.NoError(t, err)
+		ensureIDs(t, opp)
+		require.Equal(t, opp, decoded)
+	}
+}
+
+func TestOperationPackDecodeInvalid(t *testing.T) {
+	opp := &OperationPack{}
+
+	rene := identity.NewBare("René Descartes", "rene@descartes.fr")
+	opp.Append(NewCreateOp(rene, time.Now().Unix(), "title", "message", nil))
+
+	compress := func(data []byte) []byte {
+		var buffer bytes.Buffer
+		w := gzip.NewWriter(&buffer)
+		_, err := w.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		return buffer.Bytes()
+	}
+
+	// the version doesn't match the encoding
+	plain, err := opp.marshalJSON(formatVersion2)
+	require.NoError(t, err)
+	_, err = decodeOperationPack(plain)
+	require.Error(t, err)
+
+	plain, err = opp.marshalJSON(formatVersion1)
+	require.NoError(t, err)
+	_, err = decodeOperationPack(compress(plain))
+	require.Error(t, err)
+
+	// a decompression bomb
+	var buffer bytes.Buffer
+	w, err := gzip.NewWriterLevel(&buffer, gzip.BestCompression)
+	require.NoError(t, err)
+	_, err = io.CopyN(w, zeroReader{}, maxPackSize+1)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	_, err = decodeOperationPack(buffer.Bytes())
+	require.Error(t, err)
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
@@ -0,0 +1,57 @@
+package bug
+
+import (
+	"sort"
+)
+
+// The operations of a bug are totally ordered, so that every copy of a bug
+// compiles to the same snapshot whatever the order in which the operation
+// packs were merged:
+//
+// - the root pack, holding the Create op, is always first
+// - the other packs are ordered by their edit lamport time
+// - the packs created concurrently with the same edit lamport time are ordered
+//   by the id of their first operation
+// - the operations of a pack are kept in their order
+// - the staging area, not committed yet, comes last
+//
+// As a repository witnesses the edit time of a bug before writing on it, a
+// pack always has a greater edit time than the packs it was written on top
+// of, so this ordering respects the causality.
+//
+// The git history itself is not reordered when merging, so that it stays a
+// fast-forward of the remote one and can be pushed back.
+
+// packLess tell if a pack is ordered before another one, the root pack aside
+func packLess(a, b *OperationPack) bool {
+	if a.editTime != b.editTime {
+		return a.editTime < b.editTime
+	}
+	return a.Operations[0].Id() < b.Operations[0].Id()
+}
+
+// orderedPacks return the packs of the bug in their total order. The packs
+// are returned as is when already ordered, which is the common case without
+// concurrent edition.
+func (bug *Bug) orderedPacks() []OperationPack {
+	if len(bug.packs) < 3 {
+		return bug.packs
+	}
+
+	rest := bug.packs[1:]
+	less := func(i, j int) bool {
+		return packLess(&rest[i], &rest[j])
+	}
+
+	if sort.SliceIsSorted(rest, less) {
+		return bug.packs
+	}
+
+	ordered := make([]OperationPack, len(bug.packs))
+	copy(ordered, bug.packs)
+
+	rest = ordered[1:]
+	sort.SliceStable(rest, less)
+
+	return ordered
+}
@@ -0,0 +1,205 @@
+package bug
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/lamport"
+)
+
+// randomPacks generate a root pack followed by packs with random edit times,
+// often equal to exercise the tiebreak
+func randomPacks(r *rand.Rand) []OperationPack {
+	rene := identity.NewBare("René Descartes", "rene@descartes.fr")
+
+	packs := make([]OperationPack, 1+r.Intn(10))
+	for i := range packs {
+		op := NewAddCommentOp(rene, time.Now().Unix(), fmt.Sprintf("message %d", i), nil)
+		op.id = entity.Id(fmt.Sprintf("%064x", r.Int63n(8)))
+		packs[i] = OperationPack{
+			Operations: []Operation{op},
+			editTime:   lamport.Time(2 + r.Intn(4)),
+		}
+	}
+	packs[0].editTime = 1
+
+	return packs
+}
+
+func TestOrderedPacksProperties(t *testing.T) {
+	property := func(seed int64) bool {
+		r := rand.New(rand.NewSource(seed))
+		packs := randomPacks(r)
+
+		ordered := (&Bug{packs: packs}).orderedPacks()
+
+		// the root pack stays first, and the others are sorted
+		if ordered[0].Operations[0] != packs[0].Operations[0] {
+			return false
+		}
+		for i := 2; i < len(ordered); i++ {
+			if packLess(&ordered[i], &ordered[i-1]) {
+				return false
+			}
+		}
+
+		// the order doesn't depend on the order of the history
+		shuffled := make([]OperationPack, len(packs))
+		copy(shuffled, packs)
+		r.Shuffle(len(shuffled)-1, func(i, j int) {
+			shuffled[i+1], shuffled[j+1] = shuffled[j+1], shuffled[i+1]
+		})
+
+		reordered := (&Bug{packs: shuffled}).orderedPacks()
+		for i := range ordered {
+			if ordered[i].editTime != reordered[i].editTime ||
+				ordered[i].Operations[0].Id() != reordered[i].Operations[0].Id() {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	require.NoError(t, quick.Check(property, nil))
+}
+
+func TestMergeConvergence(t *testing.T) {
+	repoA, repoB, remote := repository.SetupReposAndRemote(t)
+	defer repository.CleanupTestRepos(t, repoA, repoB, remote)
+
+	r := rand.New(rand.NewSource(42))
+
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, rene.Commit(repoA))
+
+	_, err := identity.Push(repoA, "origin")
+	require.NoError(t, err)
+	require.NoError(t, identity.Pull(repoB, "origin"))
+
+	created, _, err := Create(rene, time.Now().Unix(), "title", "message")
+	require.NoError(t, err)
+	require.NoError(t, created.Commit(repoA))
+
+	_, err = Push(repoA, "origin")
+	require.NoError(t, err)
+	require.NoError(t, Pull(repoB, "origin"))
+
+	id := created.Id()
+	localRef := bugsRefPattern + id.String()
+	remoteRef := fmt.Sprintf(bugsRemoteRefPattern, "origin") + id.String()
+
+	for round := 0; round < 5; round++ {
+		bugA, err := ReadLocalBug(repoA, id)
+		require.NoError(t, err)
+		bugB, err := ReadLocalBug(repoB, id)
+		require.NoError(t, err)
+
+		// concurrent edition on both sides
+		opsA := editRandomly(t, r, repoA, bugA, rene)
+		opsB := editRandomly(t, r, repoB, bugB, rene)
+
+		_, err = Push(repoA, "origin")
+		require.NoError(t, err)
+		_, err = Fetch(repoB, "origin")
+		require.NoError(t, err)
+
+		// B merge A
+		ours, err := readBug(repoB, localRef)
+		require.NoError(t, err)
+		theirs, err := readBug(repoB, remoteRef)
+		require.NoError(t, err)
+
+		head := ours.LastCommit()
+
+		merged, err := ours.merge(repoB, theirs)
+		require.NoError(t, err)
+		require.Equal(t, len(opsA), merged)
+
+		// A merge B, the other way around
+		require.NoError(t, repoB.UpdateRef(localRef, head))
+
+		ours2, err := readBug(repoB, localRef)
+		require.NoError(t, err)
+		theirs2, err := readBug(repoB, remoteRef)
+		require.NoError(t, err)
+
+		// as if the local version was A's, the ref being updated when merging
+		require.NoError(t, repoB.UpdateRef(localRef, theirs2.LastCommit()))
+
+		merged, err = theirs2.merge(repoB, ours2)
+		require.NoError(t, err)
+		require.Equal(t, len(opsB), merged)
+
+		// both compile to the same snapshot, respecting the order of each side
+		require.Equal(t, opIds(ours), opIds(theirs2))
+		require.Equal(t, ours.Compile().Title, theirs2.Compile().Title)
+		require.Equal(t, ours.Compile().Labels, theirs2.Compile().Labels)
+		requireSubsequence(t, opsA, opIds(ours))
+		requireSubsequence(t, opsB, opIds(ours))
+
+		// keep the history fast-forward of A's, and synchronize
+		require.NoError(t, repoB.UpdateRef(localRef, ours.LastCommit()))
+		_, err = Push(repoB, "origin")
+		require.NoError(t, err)
+		require.NoError(t, Pull(repoA, "origin"))
+
+		synced, err := ReadLocalBug(repoA, id)
+		require.NoError(t, err)
+		require.Equal(t, opIds(ours), opIds(synced))
+	}
+}
+
+// editRandomly commit a few packs of random operations, and return their ids
+func editRandomly(t *testing.T, r *rand.Rand, repo repository.ClockedRepo, b *Bug, author identity.Interface) []entity.Id {
+	var ids []entity.Id
+
+	for pack := 0; pack < 1+r.Intn(3); pack++ {
+		for i := 0; i < 1+r.Intn(3); i++ {
+			var op Operation
+			var err error
+			value := fmt.Sprintf("%d", r.Intn(1000))
+
+			switch r.Intn(3) {
+			case 0:
+				op, err = AddComment(b, author, time.Now().Unix(), value)
+			case 1:
+				op, err = SetTitle(b, author, time.Now().Unix(), value)
+			case 2:
+				_, op, err = ChangeLabels(b, author, time.Now().Unix(), []string{value}, nil)
+			}
+			require.NoError(t, err)
+			ids = append(ids, op.Id())
+		}
+		require.NoError(t, b.Commit(repo))
+	}
+
+	return ids
+}
+
+func opIds(b *Bug) []entity.Id {
+	var ids []entity.Id
+	it := NewOperationIterator(b)
+	for it.Next() {
+		ids = append(ids, it.Value().Id())
+	}
+	return ids
+}
+
+func requireSubsequence(t *testing.T, sub []entity.Id, seq []entity.Id) {
+	i := 0
+	for _, id := range seq {
+		if i < len(sub) && sub[i] == id {
+			i++
+		}
+	}
+	require.Equal(t, len(sub), i, "the operations are not kept in order")
+}
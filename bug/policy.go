@@ -0,0 +1,360 @@
+package bug
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// policyConfigPrefix is the prefix of the git config keys holding the
+// identities granted each permission, as a comma separated list of ids
+const policyConfigPrefix = "git-bug.acl."
+
+// ErrNotAllowed is returned when an operation is denied by the access control
+// policy of the repository
+var ErrNotAllowed = errors.New("not allowed by the access control policy")
+
+// Permission is a right restricted by a Policy
+type Permission string
+
+const (
+	// PermissionClose restrict who can close or reopen a bug
+	PermissionClose Permission = "close"
+	// PermissionLabel restrict who can add or remove labels
+	PermissionLabel Permission = "label"
+	// PermissionEditComments restrict who can edit or delete the comments
+	// of someone else. Everyone can still edit their own comments.
+	PermissionEditComments Permission = "edit-comments"
+)
+
+// Permissions list the permissions that a Policy can restrict
+var Permissions = []Permission{PermissionClose, PermissionLabel, PermissionEditComments}
+
+// ParsePermission parse a Permission
+func ParsePermission(s string) (Permission, error) {
+	for _, p := range Permissions {
+		if string(p) == s {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("unknown permission %s, valid values are [close,label,edit-comments]", s)
+}
+
+// Policy is an optional access control layer, for a repository shared on a
+// server with a wider audience. Each permission can be restricted to a list
+// of identities, possibly empty to deny it to everyone. The permissions not
+// configured are not restricted, so an empty Policy allows everything.
+//
+// The Policy is stored in the git config of the repository, as:
+//
+//	git-bug.acl.close = <identity id>,<identity id>
+//	git-bug.acl.label = <identity id>
+//	git-bug.acl.edit-comments = <identity id>
+//
+// It is enforced on the operations coming from the remotes when merging, the
+// restricted ones having to be signed by their author, and by the GraphQL
+// API. The operations made locally are not restricted, as whoever has access
+// to the repository can write in it anyway.
+type Policy struct {
+	allowed map[Permission]map[entity.Id]struct{}
+}
+
+// LoadPolicy read the Policy of a repository
+func LoadPolicy(repo repository.RepoCommon) (*Policy, error) {
+	configs, err := repo.ReadConfigs(policyConfigPrefix)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't read the access control policy")
+	}
+
+	p := &Policy{allowed: make(map[Permission]map[entity.Id]struct{})}
+
+	for key, value := range configs {
+		perm, err := ParsePermission(strings.TrimPrefix(key, policyConfigPrefix))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid access control policy %s", key)
+		}
+
+		p.allowed[perm] = make(map[entity.Id]struct{})
+
+		for _, raw := range strings.Split(value, ",") {
+			id := entity.Id(strings.TrimSpace(raw))
+			if id == "" {
+				continue
+			}
+			if err := id.Validate(); err != nil {
+				return nil, errors.Wrapf(err, "invalid access control policy %s", key)
+			}
+			p.allowed[perm][id] = struct{}{}
+		}
+	}
+
+	return p, nil
+}
+
+// Restricted tell if any permission is restricted
+func (p *Policy) Restricted() bool {
+	return len(p.allowed) > 0
+}
+
+// Allowed return the identities granted a permission, sorted, and whether
+// the permission is restricted at all
+func (p *Policy) Allowed(perm Permission) ([]entity.Id, bool) {
+	granted, restricted := p.allowed[perm]
+	if !restricted {
+		return nil, false
+	}
+
+	result := make([]entity.Id, 0, len(granted))
+	for id := range granted {
+		result = append(result, id)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i] < result[j]
+	})
+
+	return result, true
+}
+
+// Check return an error wrapping ErrNotAllowed if the given identity is not
+// granted the permission
+func (p *Policy) Check(perm Permission, author entity.Id) error {
+	granted, restricted := p.allowed[perm]
+	if !restricted {
+		return nil
+	}
+
+	if _, ok := granted[author]; !ok {
+		return errors.Wrapf(ErrNotAllowed, "%s can't %s", author.Human(), perm.action())
+	}
+
+	return nil
+}
+
+// CheckOperation return an error wrapping ErrNotAllowed if the author of an
+// operation is not allowed to apply it on top of the given snapshot
+func (p *Policy) CheckOperation(snap *Snapshot, op Operation) error {
+	author := op.GetAuthor().Id()
+
+	switch op := op.(type) {
+	case *SetStatusOperation:
+		return p.Check(PermissionClose, author)
+
+	case *LabelChangeOperation:
+		return p.Check(PermissionLabel, author)
+
+	case *EditCommentOperation:
+		return p.CheckCommentEdition(snap, op.Target, author)
+
+	case *DeleteCommentOperation:
+		return p.CheckCommentEdition(snap, op.Target, author)
+	}
+
+	return nil
+}
+
+// Gates tell if CheckOperation depends on the author of an operation applied
+// on top of the given snapshot. The edition of a comment is gated even for
+// its own author, as claiming to be the author would be enough otherwise.
+func (p *Policy) Gates(snap *Snapshot, op Operation) bool {
+	switch op := op.(type) {
+	case *SetStatusOperation:
+		return p.restricts(PermissionClose)
+
+	case *LabelChangeOperation:
+		return p.restricts(PermissionLabel)
+
+	case *EditCommentOperation:
+		return p.gatesCommentEdition(snap, op.Target)
+
+	case *DeleteCommentOperation:
+		return p.gatesCommentEdition(snap, op.Target)
+	}
+
+	return false
+}
+
+func (p *Policy) restricts(perm Permission) bool {
+	_, restricted := p.allowed[perm]
+	return restricted
+}
+
+func (p *Policy) gatesCommentEdition(snap *Snapshot, target entity.Id) bool {
+	if _, err := snap.SearchComment(target); err != nil {
+		// the operation is a no-op
+		return false
+	}
+
+	return p.restricts(PermissionEditComments)
+}
+
+// CheckCommentEdition check that an identity can edit or delete the given
+// comment, that is if it's the author of the comment or else is granted
+// PermissionEditComments
+func (p *Policy) CheckCommentEdition(snap *Snapshot, target entity.Id, author entity.Id) error {
+	comment, err := snap.SearchComment(target)
+	if err != nil {
+		// the operation is a no-op
+		return nil
+	}
+
+	if comment.Author.Id() == author {
+		return nil
+	}
+
+	return p.Check(PermissionEditComments, author)
+}
+
+// Grant add an identity to the ones granted a permission, restricting the
+// permission if it wasn't already
+func Grant(repo repository.RepoCommon, perm Permission, id entity.Id) error {
+	policy, err := LoadPolicy(repo)
+	if err != nil {
+		return err
+	}
+
+	granted, _ := policy.Allowed(perm)
+	for _, other := range granted {
+		if other == id {
+			return nil
+		}
+	}
+
+	return storePermission(repo, perm, append(granted, id))
+}
+
+// Revoke remove an identity from the ones granted a permission. The
+// permission stay restricted, even to no identity at all.
+func Revoke(repo repository.RepoCommon, perm Permission, id entity.Id) error {
+	policy, err := LoadPolicy(repo)
+	if err != nil {
+		return err
+	}
+
+	granted, restricted := policy.Allowed(perm)
+	if !restricted {
+		return fmt.Errorf("the permission %s is not restricted", perm)
+	}
+
+	kept := make([]entity.Id, 0, len(granted))
+	for _, other := range granted {
+		if other != id {
+			kept = append(kept, other)
+		}
+	}
+	if len(kept) == len(granted) {
+		return fmt.Errorf("%s is not granted the permission %s", id.Human(), perm)
+	}
+
+	return storePermission(repo, perm, kept)
+}
+
+// Unrestrict remove the restriction of a permission, allowing it to everyone
+func Unrestrict(repo repository.RepoCommon, perm Permission) error {
+	policy, err := LoadPolicy(repo)
+	if err != nil {
+		return err
+	}
+
+	if _, restricted := policy.Allowed(perm); !restricted {
+		return fmt.Errorf("the permission %s is not restricted", perm)
+	}
+
+	return repo.RmConfigs(policyConfigPrefix + string(perm))
+}
+
+func storePermission(repo repository.RepoCommon, perm Permission, ids []entity.Id) error {
+	raw := make([]string, len(ids))
+	for i, id := range ids {
+		raw[i] = id.String()
+	}
+
+	return repo.StoreConfig(policyConfigPrefix+string(perm), strings.Join(raw, ","))
+}
+
+func (perm Permission) action() string {
+	switch perm {
+	case PermissionClose:
+		return "close or reopen bugs"
+	case PermissionLabel:
+		return "change labels"
+	case PermissionEditComments:
+		return "edit the comments of someone else"
+	default:
+		return string(perm)
+	}
+}
+
+// VerifyPolicy check that the operations of the bug are allowed by the given
+// Policy. The operations already known in the local version of the bug, if
+// any, are accepted as is, so that a policy set up later or changed doesn't
+// reject the history.
+//
+// Anyone can write an operation claiming any author, so an operation
+// restricted by the policy is only accepted if its author declared keys and
+// signed it with one of them.
+func (bug *Bug) VerifyPolicy(repo repository.Repo, policy *Policy, local *Bug) error {
+	if !policy.Restricted() {
+		return nil
+	}
+
+	known := make(map[entity.Id]struct{})
+	if local != nil {
+		it := NewOperationIterator(local)
+		for it.Next() {
+			known[it.Value().Id()] = struct{}{}
+		}
+	}
+
+	// check each operation against the state of the bug before it
+	snap := Snapshot{
+		id:     bug.id,
+		Status: OpenStatus,
+	}
+
+	for i, pack := range bug.packs {
+		for _, op := range pack.Operations {
+			if _, ok := known[op.Id()]; !ok {
+				if err := policy.CheckOperation(&snap, op); err != nil {
+					return errors.Wrapf(err, "operation %s", op.Id().Human())
+				}
+				if policy.Gates(&snap, op) {
+					if err := bug.verifyAuthor(repo, i, op); err != nil {
+						return errors.Wrapf(err, "operation %s", op.Id().Human())
+					}
+				}
+			}
+
+			snap.apply(op)
+		}
+	}
+
+	return nil
+}
+
+// verifyAuthor check that an operation of the i-th pack is signed with one of
+// the current keys of its author
+func (bug *Bug) verifyAuthor(repo repository.Repo, i int, op Operation) error {
+	author := op.GetAuthor()
+
+	keys := author.Keys()
+	if len(keys) == 0 {
+		return errors.Wrapf(ErrNotAllowed, "%s has no keys to prove its authorship", author.DisplayName())
+	}
+
+	payload, err := bug.readPackPayload(repo, i)
+	if err != nil {
+		return err
+	}
+
+	if err := bug.packs[i].signatures.VerifyData(repo, keys, payload); err != nil {
+		return errors.Wrapf(ErrNotAllowed, "not signed by %s: %s", author.DisplayName(), err)
+	}
+
+	return nil
+}
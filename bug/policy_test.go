@@ -0,0 +1,223 @@
+package bug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestPolicy(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, rene.Commit(repo))
+	isaac := identity.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	require.NoError(t, isaac.Commit(repo))
+
+	// unrestricted by default
+	policy, err := LoadPolicy(repo)
+	require.NoError(t, err)
+	require.False(t, policy.Restricted())
+	require.NoError(t, policy.Check(PermissionClose, isaac.Id()))
+
+	require.NoError(t, repo.StoreConfig("git-bug.acl.close", rene.Id().String()))
+	require.NoError(t, repo.StoreConfig("git-bug.acl.edit-comments", rene.Id().String()+", "+isaac.Id().String()))
+
+	policy, err = LoadPolicy(repo)
+	require.NoError(t, err)
+	require.True(t, policy.Restricted())
+	granted, restricted := policy.Allowed(PermissionEditComments)
+	require.True(t, restricted)
+	require.Len(t, granted, 2)
+	_, restricted = policy.Allowed(PermissionLabel)
+	require.False(t, restricted)
+
+	require.NoError(t, policy.Check(PermissionClose, rene.Id()))
+	err = policy.Check(PermissionClose, isaac.Id())
+	require.Error(t, err)
+	require.Equal(t, ErrNotAllowed, errors.Cause(err))
+	require.NoError(t, policy.Check(PermissionLabel, isaac.Id()))
+
+	unix := time.Now().Unix()
+	b, create, err := Create(rene, unix, "title", "message")
+	require.NoError(t, err)
+	snap := b.Compile()
+
+	require.Error(t, policy.CheckOperation(&snap, NewSetStatusOp(isaac, unix, ClosedStatus)))
+	require.NoError(t, policy.CheckOperation(&snap, NewSetStatusOp(rene, unix, ClosedStatus)))
+	require.NoError(t, policy.CheckOperation(&snap, NewLabelChangeOperation(isaac, unix, []Label{"bug"}, nil)))
+	require.NoError(t, policy.CheckOperation(&snap, NewEditCommentOp(isaac, unix, create.Id(), "edited", nil)))
+
+	// only rene can edit the comments of someone else
+	require.NoError(t, repo.StoreConfig("git-bug.acl.edit-comments", rene.Id().String()))
+	policy, err = LoadPolicy(repo)
+	require.NoError(t, err)
+	require.Error(t, policy.CheckOperation(&snap, NewEditCommentOp(isaac, unix, create.Id(), "edited", nil)))
+	require.Error(t, policy.CheckOperation(&snap, NewDeleteCommentOp(isaac, unix, create.Id())))
+	require.NoError(t, policy.CheckOperation(&snap, NewEditCommentOp(rene, unix, create.Id(), "edited", nil)))
+
+	require.NoError(t, repo.StoreConfig("git-bug.acl.delete", rene.Id().String()))
+	_, err = LoadPolicy(repo)
+	require.Error(t, err)
+}
+
+func TestGrantRevoke(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, rene.Commit(repo))
+	isaac := identity.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	require.NoError(t, isaac.Commit(repo))
+
+	require.Error(t, Revoke(repo, PermissionLabel, rene.Id()))
+	require.Error(t, Unrestrict(repo, PermissionLabel))
+
+	require.NoError(t, Grant(repo, PermissionLabel, rene.Id()))
+	require.NoError(t, Grant(repo, PermissionLabel, isaac.Id()))
+	require.NoError(t, Grant(repo, PermissionLabel, isaac.Id()))
+
+	policy, err := LoadPolicy(repo)
+	require.NoError(t, err)
+	granted, _ := policy.Allowed(PermissionLabel)
+	require.Len(t, granted, 2)
+
+	// revoking everyone still deny the permission
+	require.NoError(t, Revoke(repo, PermissionLabel, rene.Id()))
+	require.NoError(t, Revoke(repo, PermissionLabel, isaac.Id()))
+	require.Error(t, Revoke(repo, PermissionLabel, isaac.Id()))
+
+	policy, err = LoadPolicy(repo)
+	require.NoError(t, err)
+	granted, restricted := policy.Allowed(PermissionLabel)
+	require.True(t, restricted)
+	require.Empty(t, granted)
+	require.Error(t, policy.Check(PermissionLabel, rene.Id()))
+
+	require.NoError(t, Unrestrict(repo, PermissionLabel))
+	policy, err = LoadPolicy(repo)
+	require.NoError(t, err)
+	require.False(t, policy.Restricted())
+}
+
+func TestMergePolicy(t *testing.T) {
+	repoA, repoB, remote := repository.SetupReposAndRemote(t)
+	defer repository.CleanupTestRepos(t, repoA, repoB, remote)
+
+	// A is the server, administrated by rene
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, rene.Commit(repoA))
+	isaac := identity.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	require.NoError(t, isaac.Commit(repoB))
+
+	_, err := identity.Push(repoA, "origin")
+	require.NoError(t, err)
+	_, err = identity.Push(repoB, "origin")
+	require.NoError(t, err)
+	require.NoError(t, identity.Pull(repoA, "origin"))
+	require.NoError(t, identity.Pull(repoB, "origin"))
+
+	// a bug made before the policy
+	unix := time.Now().Unix()
+	b, _, err := Create(isaac, unix, "title", "message")
+	require.NoError(t, err)
+	_, err = Close(b, isaac, unix)
+	require.NoError(t, err)
+	require.NoError(t, b.Commit(repoB))
+	_, err = Push(repoB, "origin")
+	require.NoError(t, err)
+	require.NoError(t, Pull(repoA, "origin"))
+
+	require.NoError(t, repoA.StoreConfig("git-bug.acl.close", rene.Id().String()))
+
+	// the history is accepted, but not the new operations
+	_, err = Open(b, isaac, unix)
+	require.NoError(t, err)
+	require.NoError(t, b.Commit(repoB))
+	_, err = Push(repoB, "origin")
+	require.NoError(t, err)
+	require.Error(t, Pull(repoA, "origin"))
+
+	// and neither a new bug breaking the policy
+	b2, _, err := Create(isaac, unix, "title", "message")
+	require.NoError(t, err)
+	_, err = Close(b2, isaac, unix)
+	require.NoError(t, err)
+	require.NoError(t, b2.Commit(repoB))
+	_, err = Push(repoB, "origin")
+	require.NoError(t, err)
+
+	_, err = Fetch(repoA, "origin")
+	require.NoError(t, err)
+	invalid := 0
+	for merge := range MergeAll(repoA, "origin") {
+		require.NoError(t, merge.Err)
+		if merge.Status == entity.MergeStatusInvalid {
+			invalid++
+		}
+	}
+	require.Equal(t, 2, invalid)
+
+	local, err := ReadLocalBug(repoA, b.Id())
+	require.NoError(t, err)
+	require.Equal(t, ClosedStatus, local.Compile().Status)
+	_, err = ReadLocalBug(repoA, b2.Id())
+	require.Equal(t, ErrBugNotExist, err)
+}
+
+func TestVerifyPolicyAuthorship(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	key, err := identity.GenerateKey(repo)
+	require.NoError(t, err)
+	require.NoError(t, rene.AddKey(key))
+	require.NoError(t, rene.Commit(repo))
+	ada := identity.NewIdentity("Ada Lovelace", "ada@lovelace.uk")
+	require.NoError(t, ada.Commit(repo))
+	isaac := identity.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	require.NoError(t, isaac.Commit(repo))
+
+	require.NoError(t, repo.StoreConfig("git-bug.acl.close", rene.Id().String()+","+ada.Id().String()))
+	require.NoError(t, repo.StoreConfig("git-bug.acl.edit-comments", rene.Id().String()))
+	policy, err := LoadPolicy(repo)
+	require.NoError(t, err)
+
+	unix := time.Now().Unix()
+
+	// anyone can claim to be ada, who has no keys
+	forged, _, err := Create(isaac, unix, "title", "message")
+	require.NoError(t, err)
+	_, err = Close(forged, ada, unix)
+	require.NoError(t, err)
+	require.NoError(t, forged.Commit(repo))
+	err = forged.VerifyPolicy(repo, policy, nil)
+	require.Error(t, err)
+	require.Equal(t, ErrNotAllowed, errors.Cause(err))
+
+	// or to be the author of a comment to edit it
+	edited, createOp, err := Create(isaac, unix, "title", "message")
+	require.NoError(t, err)
+	_, err = EditComment(edited, isaac, unix, createOp.Id(), "edited")
+	require.NoError(t, err)
+	require.NoError(t, edited.Commit(repo))
+	require.Error(t, edited.VerifyPolicy(repo, policy, nil))
+
+	// a signed operation of rene is accepted
+	signed, _, err := Create(isaac, unix, "title", "message")
+	require.NoError(t, err)
+	require.NoError(t, signed.Commit(repo))
+	_, err = Close(signed, rene, unix)
+	require.NoError(t, err)
+	require.NoError(t, signed.Commit(repo))
+	require.NoError(t, signed.VerifyPolicy(repo, policy, nil))
+
+	// but not once the signature is stripped
+	signed.packs[1].signatures = nil
+	require.Error(t, signed.VerifyPolicy(repo, policy, nil))
+}
@@ -0,0 +1,180 @@
+package bug
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/git"
+)
+
+// packPayload return what the authors of a pack sign: the serialized pack,
+// bound to the root pack of the bug and to the previous commit, so that the
+// signed operations can't be replayed in another bug or elsewhere in its
+// history. The root pack stands for the bug id, as the id is the hash of the
+// first commit, which doesn't exist yet when signing the first pack.
+func packPayload(rootPack git.Hash, parent git.Hash, data []byte) []byte {
+	header := fmt.Sprintf("root %s\nparent %s\n\n", rootPack, parent)
+	return append([]byte(header), data...)
+}
+
+// readPackPayload return the payload signed by the authors of the i-th pack
+func (bug *Bug) readPackPayload(repo repository.Repo, i int) ([]byte, error) {
+	data, err := repo.ReadData(bug.packs[i].blobHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read git blob data")
+	}
+
+	var parent git.Hash
+	if i > 0 {
+		parent = bug.packs[i-1].commitHash
+	}
+
+	return packPayload(bug.rootPack, parent, data), nil
+}
+
+// signPack sign the payload of a pack for each of its authors that declared
+// keys, with one of their keys available in the repository
+func signPack(repo repository.Repo, pack OperationPack, payload []byte) ([]repository.TreeEntry, error) {
+	return signPackWith(repo, pack, payload, func(author identity.Interface) []identity.Key {
+		return author.Keys()
+	})
+}
+
+// resignPack sign again an already committed pack, serialized differently or
+// moved on another parent, with the keys its authors had at the time
+func resignPack(repo repository.Repo, pack OperationPack, payload []byte) ([]repository.TreeEntry, error) {
+	return signPackWith(repo, pack, payload, func(author identity.Interface) []identity.Key {
+		return author.ValidKeysAtTime(pack.editTime)
+	})
+}
+
+// rebasePackTree return the git tree of an already committed pack moved on a
+// new parent commit, signed again as the signatures are bound to the parent
+func (bug *Bug) rebasePackTree(repo repository.Repo, pack OperationPack, parent git.Hash) (git.Hash, identity.Signatures, error) {
+	if len(pack.signatures) == 0 {
+		treeHash, err := repo.GetTreeHash(pack.commitHash)
+		return treeHash, pack.signatures, err
+	}
+
+	entries, err := repo.ListEntries(pack.commitHash)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "can't list git tree entries")
+	}
+
+	var tree []repository.TreeEntry
+	for _, entry := range entries {
+		if _, ok := identity.IsSignatureEntry(entry); !ok {
+			tree = append(tree, entry)
+		}
+	}
+
+	data, err := repo.ReadData(pack.blobHash)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to read git blob data")
+	}
+
+	signatures, err := resignPack(repo, pack, packPayload(bug.rootPack, parent, data))
+	if err != nil {
+		return "", nil, err
+	}
+	tree = append(tree, signatures...)
+
+	treeHash, err := repo.StoreTree(tree)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return treeHash, identity.ReadSignatures(signatures), nil
+}
+
+func signPackWith(repo repository.Repo, pack OperationPack, payload []byte, keysOf func(identity.Interface) []identity.Key) ([]repository.TreeEntry, error) {
+	var tree []repository.TreeEntry
+
+	for _, author := range packAuthors(pack) {
+		keys := keysOf(author)
+		if len(keys) == 0 {
+			continue
+		}
+
+		entry, err := identity.SignEntry(repo, keys, payload)
+		if err == identity.ErrNoPrivateKey {
+			return nil, fmt.Errorf("the identity %s is protected, but none of its keys is available in this repository to sign the operations", author.DisplayName())
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		tree = append(tree, entry)
+	}
+
+	return tree, nil
+}
+
+// VerifySignatures check that the operations authored by a protected
+// identity are signed with one of its keys, to prevent someone else to
+// impersonate it. The signatures also cover the bug and the previous commit,
+// see packPayload.
+//
+// The packs already known in the local version of the bug, if any, only need
+// to be signed by the authors having declared keys at the time of the pack.
+// That time is given by the pack itself though, so the new packs must be
+// signed by every author having ever declared keys, with one of them.
+func (bug *Bug) VerifySignatures(repo repository.Repo, local *Bug) error {
+	known := make(map[git.Hash]struct{})
+	if local != nil {
+		for _, pack := range local.packs {
+			known[pack.commitHash] = struct{}{}
+		}
+	}
+
+	for i, pack := range bug.packs {
+		_, isKnown := known[pack.commitHash]
+		var payload []byte
+
+		for _, author := range packAuthors(pack) {
+			keys := author.ValidKeysAtTime(pack.editTime)
+			if !isKnown && len(keys) == 0 {
+				keys = author.AllKeys()
+			}
+			if len(keys) == 0 {
+				continue
+			}
+
+			if payload == nil {
+				var err error
+				payload, err = bug.readPackPayload(repo, i)
+				if err != nil {
+					return err
+				}
+			}
+
+			err := pack.signatures.VerifyData(repo, keys, payload)
+			if err != nil {
+				return errors.Wrapf(err, "operations of %s in %s", author.DisplayName(), pack.commitHash)
+			}
+		}
+	}
+
+	return nil
+}
+
+// packAuthors return the distinct authors of the operations of a pack
+func packAuthors(pack OperationPack) []identity.Interface {
+	var result []identity.Interface
+	seen := make(map[entity.Id]struct{})
+
+	for _, op := range pack.Operations {
+		author := op.GetAuthor()
+		if _, ok := seen[author.Id()]; ok {
+			continue
+		}
+		seen[author.Id()] = struct{}{}
+		result = append(result, author)
+	}
+
+	return result
+}
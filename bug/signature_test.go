@@ -0,0 +1,167 @@
+package bug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestBugSignatures(t *testing.T) {
+	mockRepo := repository.NewMockRepoForTest()
+
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	key, err := identity.GenerateKey(mockRepo)
+	require.NoError(t, err)
+	require.NoError(t, rene.AddKey(key))
+	require.NoError(t, rene.Commit(mockRepo))
+
+	isaac := identity.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	require.NoError(t, isaac.Commit(mockRepo))
+
+	bug1 := NewBug()
+	bug1.Append(NewCreateOp(rene, time.Now().Unix(), "title", "message", nil))
+	require.NoError(t, bug1.Commit(mockRepo))
+
+	bug1.Append(NewAddCommentOp(isaac, time.Now().Unix(), "unprotected", nil))
+	require.NoError(t, bug1.Commit(mockRepo))
+
+	loaded, err := ReadLocalBug(mockRepo, bug1.Id())
+	require.NoError(t, err)
+	require.NoError(t, loaded.VerifySignatures(mockRepo, nil))
+
+	// an unsigned pack of a protected identity is rejected
+	loaded.packs[0].signatures = nil
+	require.Error(t, loaded.VerifySignatures(mockRepo, nil))
+
+	// without the private key, the operations can't be signed
+	require.NoError(t, identity.RemovePrivateKey(mockRepo, key))
+	bug1.Append(NewAddCommentOp(rene, time.Now().Unix(), "impersonated", nil))
+	require.Error(t, bug1.Commit(mockRepo))
+}
+
+func TestBugSignaturesForgedTime(t *testing.T) {
+	mockRepo := repository.NewMockRepoForTest()
+
+	isaac := identity.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	require.NoError(t, isaac.Commit(mockRepo))
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, rene.Commit(mockRepo))
+
+	before, _, err := Create(isaac, time.Now().Unix(), "title", "message")
+	require.NoError(t, err)
+	require.NoError(t, before.Commit(mockRepo))
+
+	// rene declare keys later
+	key, err := identity.GenerateKey(mockRepo)
+	require.NoError(t, err)
+	require.NoError(t, rene.AddKey(key))
+	require.NoError(t, rene.Commit(mockRepo))
+	require.Empty(t, rene.ValidKeysAtTime(0))
+
+	bug1, _, err := Create(isaac, time.Now().Unix(), "title", "message")
+	require.NoError(t, err)
+	_, err = AddComment(bug1, rene, time.Now().Unix(), "signed")
+	require.NoError(t, err)
+	require.NoError(t, bug1.Commit(mockRepo))
+
+	loaded, err := ReadLocalBug(mockRepo, bug1.Id())
+	require.NoError(t, err)
+	require.NoError(t, loaded.VerifySignatures(mockRepo, nil))
+
+	// a new pack claiming a time before the keys of rene must still be signed
+	forged, err := ReadLocalBug(mockRepo, bug1.Id())
+	require.NoError(t, err)
+	forged.packs[0].editTime = 0
+	forged.packs[0].signatures = nil
+	require.Error(t, forged.VerifySignatures(mockRepo, nil))
+
+	// unless it is already known locally
+	require.NoError(t, forged.VerifySignatures(mockRepo, loaded))
+}
+
+func TestBugSignaturesBinding(t *testing.T) {
+	mockRepo := repository.NewMockRepoForTest()
+
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	key, err := identity.GenerateKey(mockRepo)
+	require.NoError(t, err)
+	require.NoError(t, rene.AddKey(key))
+	require.NoError(t, rene.Commit(mockRepo))
+
+	bug1, _, err := Create(rene, time.Now().Unix(), "title", "message")
+	require.NoError(t, err)
+	require.NoError(t, bug1.Commit(mockRepo))
+	_, err = AddComment(bug1, rene, time.Now().Unix(), "signed")
+	require.NoError(t, err)
+	require.NoError(t, bug1.Commit(mockRepo))
+
+	loaded, err := ReadLocalBug(mockRepo, bug1.Id())
+	require.NoError(t, err)
+	require.NoError(t, loaded.VerifySignatures(mockRepo, nil))
+
+	// a signed pack can't be replayed in another bug
+	moved, err := ReadLocalBug(mockRepo, bug1.Id())
+	require.NoError(t, err)
+	moved.rootPack = moved.packs[1].blobHash
+	require.Error(t, moved.VerifySignatures(mockRepo, nil))
+
+	// nor on another parent
+	moved, err = ReadLocalBug(mockRepo, bug1.Id())
+	require.NoError(t, err)
+	moved.packs[0].commitHash = moved.packs[1].commitHash
+	require.Error(t, moved.VerifySignatures(mockRepo, nil))
+}
+
+func TestBugSignaturesRebase(t *testing.T) {
+	repoA, repoB, remote := repository.SetupReposAndRemote(t)
+	defer repository.CleanupTestRepos(t, repoA, repoB, remote)
+
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	key, err := identity.GenerateKey(repoA)
+	require.NoError(t, err)
+	require.NoError(t, rene.AddKey(key))
+	require.NoError(t, rene.Commit(repoA))
+
+	isaac := identity.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	require.NoError(t, isaac.Commit(repoA))
+
+	bug1, _, err := Create(rene, time.Now().Unix(), "title", "message")
+	require.NoError(t, err)
+	require.NoError(t, bug1.Commit(repoA))
+
+	_, err = identity.Push(repoA, "origin")
+	require.NoError(t, err)
+	_, err = Push(repoA, "origin")
+	require.NoError(t, err)
+	require.NoError(t, identity.Pull(repoB, "origin"))
+	require.NoError(t, Pull(repoB, "origin"))
+
+	// concurrent edits
+	bugB, err := ReadLocalBug(repoB, bug1.Id())
+	require.NoError(t, err)
+	_, err = AddComment(bugB, isaac, time.Now().Unix(), "from B")
+	require.NoError(t, err)
+	require.NoError(t, bugB.Commit(repoB))
+	_, err = Push(repoB, "origin")
+	require.NoError(t, err)
+
+	_, err = AddComment(bug1, rene, time.Now().Unix(), "from A")
+	require.NoError(t, err)
+	require.NoError(t, bug1.Commit(repoA))
+
+	// the pack of rene is moved on top of the one of isaac, and signed again
+	require.NoError(t, Pull(repoA, "origin"))
+
+	merged, err := ReadLocalBug(repoA, bug1.Id())
+	require.NoError(t, err)
+	require.Len(t, merged.packs, 3)
+	require.NoError(t, merged.VerifySignatures(repoA, nil))
+
+	_, err = Push(repoA, "origin")
+	require.NoError(t, err)
+	require.NoError(t, Pull(repoB, "origin"))
+}
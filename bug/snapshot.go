@@ -16,6 +16,10 @@ type Snapshot struct {
 	Title        string
 	Comments     []Comment
 	Labels       []Label
+	Assignee     entity.Id
+	Milestone    string
+	Project      string
+	Votes        map[entity.Id]int
 	Author       identity.Interface
 	Actors       []identity.Interface
 	Participants []identity.Interface
@@ -49,6 +53,15 @@ func (snap *Snapshot) LastEditUnix() int64 {
 	return snap.Operations[len(snap.Operations)-1].GetUnixTime()
 }
 
+// VoteCount return the sum of the votes on the bug
+func (snap *Snapshot) VoteCount() int {
+	count := 0
+	for _, vote := range snap.Votes {
+		count += vote
+	}
+	return count
+}
+
 // GetCreateMetadata return the creation metadata
 func (snap *Snapshot) GetCreateMetadata(key string) (string, bool) {
 	return snap.Operations[0].GetMetadata(key)
@@ -76,6 +89,59 @@ func (snap *Snapshot) SearchComment(id entity.Id) (*Comment, error) {
 	return nil, fmt.Errorf("comment item not found")
 }
 
+// apply an operation on top of the snapshot, and record it
+func (snap *Snapshot) apply(op Operation) {
+	op.Apply(snap)
+	snap.Operations = append(snap.Operations, op)
+}
+
+// clone return a copy of the snapshot that can be changed without affecting
+// this one
+func (snap *Snapshot) clone() *Snapshot {
+	clone := *snap
+
+	clone.Comments = append([]Comment(nil), snap.Comments...)
+	clone.Labels = append([]Label(nil), snap.Labels...)
+	clone.Actors = append([]identity.Interface(nil), snap.Actors...)
+	clone.Participants = append([]identity.Interface(nil), snap.Participants...)
+	clone.Operations = append([]Operation(nil), snap.Operations...)
+
+	if snap.Votes != nil {
+		clone.Votes = make(map[entity.Id]int, len(snap.Votes))
+		for id, vote := range snap.Votes {
+			clone.Votes[id] = vote
+		}
+	}
+
+	// the comments are edited in place
+	if snap.Timeline != nil {
+		clone.Timeline = make([]TimelineItem, len(snap.Timeline))
+	}
+	for i, item := range snap.Timeline {
+		switch item := item.(type) {
+		case *CreateTimelineItem:
+			c := *item
+			c.History = append([]CommentHistoryStep(nil), item.History...)
+			clone.Timeline[i] = &c
+		case *AddCommentTimelineItem:
+			c := *item
+			c.History = append([]CommentHistoryStep(nil), item.History...)
+			clone.Timeline[i] = &c
+		default:
+			clone.Timeline[i] = item
+		}
+	}
+
+	return &clone
+}
+
+// apply a series of operations, in order
+func (snap *Snapshot) applyAll(ops []Operation) {
+	for _, op := range ops {
+		snap.apply(op)
+	}
+}
+
 // append the operation author to the actors list
 func (snap *Snapshot) addActor(actor identity.Interface) {
 	for _, a := range snap.Actors {
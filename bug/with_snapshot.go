@@ -1,17 +1,33 @@
 package bug
 
-import "github.com/MichaelMure/git-bug/repository"
+import (
+	"sync"
+
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/git"
+)
 
 var _ Interface = &WithSnapshot{}
 
-// WithSnapshot encapsulate a Bug and maintain the corresponding Snapshot efficiently
+// WithSnapshot encapsulate a Bug and maintain the corresponding Snapshot efficiently.
+//
+// The Snapshot returned is never changed afterward, so it can be read while
+// the bug evolves. The changes of the bug go through WithSnapshot and are
+// serialized, but a change depending on the state of the bug, like removing
+// an existing label, still needs to be synchronized by the caller.
 type WithSnapshot struct {
 	*Bug
 	snap *Snapshot
+
+	// protect the bug and snap, as readers compile it lazily
+	mu sync.Mutex
 }
 
 // Snapshot return the current snapshot
 func (b *WithSnapshot) Snapshot() *Snapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
 	if b.snap == nil {
 		snap := b.Bug.Compile()
 		b.snap = &snap
@@ -19,22 +35,104 @@ func (b *WithSnapshot) Snapshot() *Snapshot {
 	return b.snap
 }
 
+// Compile intercept Bug.Compile() to read the bug safely
+func (b *WithSnapshot) Compile() Snapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.Bug.Compile()
+}
+
 // Append intercept Bug.Append() to update the snapshot efficiently
 func (b *WithSnapshot) Append(op Operation) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
 	b.Bug.Append(op)
 
 	if b.snap == nil {
 		return
 	}
 
-	op.Apply(b.snap)
-	b.snap.Operations = append(b.snap.Operations, op)
+	// the previous snapshot might still be read
+	snap := b.snap.clone()
+	snap.apply(op)
+	b.snap = snap
+}
+
+// HasPendingOp intercept Bug.HasPendingOp() to read the bug safely
+func (b *WithSnapshot) HasPendingOp() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.Bug.HasPendingOp()
+}
+
+// Published intercept Bug.Published() to read the bug safely
+func (b *WithSnapshot) Published() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.Bug.Published()
 }
 
 // Commit intercept Bug.Commit() to update the snapshot efficiently
 func (b *WithSnapshot) Commit(repo repository.ClockedRepo) error {
-	err := b.Bug.Commit(repo)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.committed(b.Bug.Commit(repo))
+}
+
+// CommitDetached intercept Bug.CommitDetached() to update the snapshot
+// efficiently
+func (b *WithSnapshot) CommitDetached(repo repository.ClockedRepo) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.committed(b.Bug.CommitDetached(repo))
+}
+
+// CommitAsNeeded intercept Bug.CommitAsNeeded() to update the snapshot
+// efficiently
+func (b *WithSnapshot) CommitAsNeeded(repo repository.ClockedRepo) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
 
+	return b.committed(b.Bug.CommitAsNeeded(repo))
+}
+
+// CommitAllWithSnapshot intercept CommitAll() to update the snapshots
+// efficiently. The bugs are locked in the given order.
+func CommitAllWithSnapshot(repo repository.ClockedRepo, bugs []*WithSnapshot) error {
+	raw := make([]*Bug, len(bugs))
+	for i, b := range bugs {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		raw[i] = b.Bug
+	}
+
+	err := CommitAll(repo, raw)
+
+	for _, b := range bugs {
+		// the first error is enough
+		_ = b.committed(err)
+	}
+
+	return err
+}
+
+// LastCommit intercept Bug.LastCommit() to read the bug safely
+func (b *WithSnapshot) LastCommit() git.Hash {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.Bug.LastCommit()
+}
+
+// committed update the snapshot after a commit, given its outcome. The lock
+// must be held.
+func (b *WithSnapshot) committed(err error) error {
 	if err != nil {
 		b.snap = nil
 		return err
@@ -43,16 +141,30 @@ func (b *WithSnapshot) Commit(repo repository.ClockedRepo) error {
 	// Commit() shouldn't change anything of the bug state apart from the
 	// initial ID set
 
-	if b.snap == nil {
+	if b.snap == nil || b.snap.id == b.Bug.id {
 		return nil
 	}
 
-	b.snap.id = b.Bug.id
+	snap := *b.snap
+	snap.id = b.Bug.id
+	b.snap = &snap
 	return nil
 }
 
+// DiscardPendingOps intercept Bug.DiscardPendingOps() and clear the snapshot
+func (b *WithSnapshot) DiscardPendingOps() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.snap = nil
+	b.Bug.DiscardPendingOps()
+}
+
 // Merge intercept Bug.Merge() and clear the snapshot
 func (b *WithSnapshot) Merge(repo repository.Repo, other Interface) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
 	b.snap = nil
 	return b.Bug.Merge(repo, other)
 }
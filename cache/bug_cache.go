@@ -2,6 +2,7 @@ package cache
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/MichaelMure/git-bug/bug"
@@ -15,9 +16,20 @@ var ErrNoMatchingOp = fmt.Errorf("no matching operation found")
 //
 // 1. Provide a higher level API to use than the raw API from Bug.
 // 2. Maintain an up to date Snapshot available.
+//
+// It is safe for concurrent use. The Snapshot returned is never changed, a
+// new one is returned once the bug changed.
 type BugCache struct {
 	repoCache *RepoCache
 	bug       *bug.WithSnapshot
+
+	// serialize the changes of the bug, as they depend on its state, and
+	// protect its readers
+	mu sync.RWMutex
+
+	// the instance has been unloaded from the cache, the calls are forwarded
+	// to the one loaded again
+	evicted bool
 }
 
 func NewBugCache(repoCache *RepoCache, b *bug.Bug) *BugCache {
@@ -28,7 +40,10 @@ func NewBugCache(repoCache *RepoCache, b *bug.Bug) *BugCache {
 }
 
 func (c *BugCache) Snapshot() *bug.Snapshot {
-	return c.bug.Snapshot()
+	b := c.rlock()
+	defer b.mu.RUnlock()
+
+	return b.bug.Snapshot()
 }
 
 func (c *BugCache) Id() entity.Id {
@@ -36,15 +51,76 @@ func (c *BugCache) Id() entity.Id {
 }
 
 func (c *BugCache) notifyUpdated() error {
-	return c.repoCache.bugUpdated(c.bug.Id())
+	return c.repoCache.bugUpdated(c)
+}
+
+// lock return the instance of the bug to change, locked: this one, or the
+// one loaded again if this one has been evicted from the cache
+func (c *BugCache) lock() (*BugCache, error) {
+	c.mu.Lock()
+	if !c.evicted {
+		return c, nil
+	}
+	c.mu.Unlock()
+
+	live, err := c.repoCache.ResolveBug(c.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	return live.lock()
+}
+
+// rlock is like lock, for reading the bug. If the bug doesn't exist anymore,
+// its last state is still readable.
+func (c *BugCache) rlock() *BugCache {
+	c.mu.RLock()
+	if !c.evicted {
+		return c
+	}
+	c.mu.RUnlock()
+
+	live, err := c.repoCache.ResolveBug(c.Id())
+	if err != nil {
+		c.mu.RLock()
+		return c
+	}
+
+	return live.rlock()
+}
+
+// published tell if the bug exist in the repository, see bug.Bug.Published
+func (c *BugCache) published() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.bug.Published()
+}
+
+// evict mark the instance as unloaded from the cache, unless it has pending
+// operations or unpublished commits that would be lost and force is false.
+// It return false if it can't be evicted.
+func (c *BugCache) evict(force bool) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !force && c.bug.HasPendingOp() {
+		return false
+	}
+
+	c.evicted = true
+	return true
 }
 
 // ResolveOperationWithMetadata will find an operation that has the matching metadata
 func (c *BugCache) ResolveOperationWithMetadata(key string, value string) (entity.Id, error) {
+	b := c.rlock()
+	defer b.mu.RUnlock()
+
 	// preallocate but empty
 	matching := make([]entity.Id, 0, 5)
 
-	it := bug.NewOperationIterator(c.bug)
+	it := bug.NewOperationIterator(b.bug)
 	for it.Next() {
 		op := it.Value()
 		opValue, ok := op.GetMetadata(key)
@@ -78,16 +154,23 @@ func (c *BugCache) AddCommentWithFiles(message string, files []git.Hash) (*bug.A
 }
 
 func (c *BugCache) AddCommentRaw(author *IdentityCache, unixTime int64, message string, files []git.Hash, metadata map[string]string) (*bug.AddCommentOperation, error) {
-	op, err := bug.AddCommentWithFiles(c.bug, author.Identity, unixTime, message, files)
+	b, err := c.lock()
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := bug.AddCommentWithFiles(b.bug, author.Identity, unixTime, message, files)
 	if err != nil {
+		b.mu.Unlock()
 		return nil, err
 	}
 
 	for key, value := range metadata {
 		op.SetMetadata(key, value)
 	}
+	b.mu.Unlock()
 
-	return op, c.notifyUpdated()
+	return op, b.notifyUpdated()
 }
 
 func (c *BugCache) ChangeLabels(added []string, removed []string) ([]bug.LabelChangeResult, *bug.LabelChangeOperation, error) {
@@ -100,16 +183,23 @@ func (c *BugCache) ChangeLabels(added []string, removed []string) ([]bug.LabelCh
 }
 
 func (c *BugCache) ChangeLabelsRaw(author *IdentityCache, unixTime int64, added []string, removed []string, metadata map[string]string) ([]bug.LabelChangeResult, *bug.LabelChangeOperation, error) {
-	changes, op, err := bug.ChangeLabels(c.bug, author.Identity, unixTime, added, removed)
+	b, err := c.lock()
 	if err != nil {
+		return nil, nil, err
+	}
+
+	changes, op, err := bug.ChangeLabels(b.bug, author.Identity, unixTime, added, removed)
+	if err != nil {
+		b.mu.Unlock()
 		return changes, nil, err
 	}
 
 	for key, value := range metadata {
 		op.SetMetadata(key, value)
 	}
+	b.mu.Unlock()
 
-	err = c.notifyUpdated()
+	err = b.notifyUpdated()
 	if err != nil {
 		return nil, nil, err
 	}
@@ -127,16 +217,23 @@ func (c *BugCache) ForceChangeLabels(added []string, removed []string) (*bug.Lab
 }
 
 func (c *BugCache) ForceChangeLabelsRaw(author *IdentityCache, unixTime int64, added []string, removed []string, metadata map[string]string) (*bug.LabelChangeOperation, error) {
-	op, err := bug.ForceChangeLabels(c.bug, author.Identity, unixTime, added, removed)
+	b, err := c.lock()
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := bug.ForceChangeLabels(b.bug, author.Identity, unixTime, added, removed)
 	if err != nil {
+		b.mu.Unlock()
 		return nil, err
 	}
 
 	for key, value := range metadata {
 		op.SetMetadata(key, value)
 	}
+	b.mu.Unlock()
 
-	err = c.notifyUpdated()
+	err = b.notifyUpdated()
 	if err != nil {
 		return nil, err
 	}
@@ -154,16 +251,23 @@ func (c *BugCache) Open() (*bug.SetStatusOperation, error) {
 }
 
 func (c *BugCache) OpenRaw(author *IdentityCache, unixTime int64, metadata map[string]string) (*bug.SetStatusOperation, error) {
-	op, err := bug.Open(c.bug, author.Identity, unixTime)
+	b, err := c.lock()
 	if err != nil {
 		return nil, err
 	}
 
+	op, err := bug.Open(b.bug, author.Identity, unixTime)
+	if err != nil {
+		b.mu.Unlock()
+		return nil, err
+	}
+
 	for key, value := range metadata {
 		op.SetMetadata(key, value)
 	}
+	b.mu.Unlock()
 
-	return op, c.notifyUpdated()
+	return op, b.notifyUpdated()
 }
 
 func (c *BugCache) Close() (*bug.SetStatusOperation, error) {
@@ -176,16 +280,23 @@ func (c *BugCache) Close() (*bug.SetStatusOperation, error) {
 }
 
 func (c *BugCache) CloseRaw(author *IdentityCache, unixTime int64, metadata map[string]string) (*bug.SetStatusOperation, error) {
-	op, err := bug.Close(c.bug, author.Identity, unixTime)
+	b, err := c.lock()
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := bug.Close(b.bug, author.Identity, unixTime)
 	if err != nil {
+		b.mu.Unlock()
 		return nil, err
 	}
 
 	for key, value := range metadata {
 		op.SetMetadata(key, value)
 	}
+	b.mu.Unlock()
 
-	return op, c.notifyUpdated()
+	return op, b.notifyUpdated()
 }
 
 func (c *BugCache) SetTitle(title string) (*bug.SetTitleOperation, error) {
@@ -198,16 +309,146 @@ func (c *BugCache) SetTitle(title string) (*bug.SetTitleOperation, error) {
 }
 
 func (c *BugCache) SetTitleRaw(author *IdentityCache, unixTime int64, title string, metadata map[string]string) (*bug.SetTitleOperation, error) {
-	op, err := bug.SetTitle(c.bug, author.Identity, unixTime, title)
+	b, err := c.lock()
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := bug.SetTitle(b.bug, author.Identity, unixTime, title)
+	if err != nil {
+		b.mu.Unlock()
+		return nil, err
+	}
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+	b.mu.Unlock()
+
+	return op, b.notifyUpdated()
+}
+
+// SetAssignee assign the bug to the given identity, or remove the assignment
+// if nil
+func (c *BugCache) SetAssignee(assignee *IdentityCache) (*bug.SetAssigneeOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.SetAssigneeRaw(author, time.Now().Unix(), assignee, nil)
+}
+
+func (c *BugCache) SetAssigneeRaw(author *IdentityCache, unixTime int64, assignee *IdentityCache, metadata map[string]string) (*bug.SetAssigneeOperation, error) {
+	var assigneeId entity.Id
+	if assignee != nil {
+		assigneeId = assignee.Id()
+	}
+
+	b, err := c.lock()
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := bug.SetAssignee(b.bug, author.Identity, unixTime, assigneeId)
 	if err != nil {
+		b.mu.Unlock()
 		return nil, err
 	}
 
 	for key, value := range metadata {
 		op.SetMetadata(key, value)
 	}
+	b.mu.Unlock()
 
-	return op, c.notifyUpdated()
+	return op, b.notifyUpdated()
+}
+
+func (c *BugCache) SetMilestone(milestone string) (*bug.SetMilestoneOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.SetMilestoneRaw(author, time.Now().Unix(), milestone, nil)
+}
+
+func (c *BugCache) SetMilestoneRaw(author *IdentityCache, unixTime int64, milestone string, metadata map[string]string) (*bug.SetMilestoneOperation, error) {
+	b, err := c.lock()
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := bug.SetMilestone(b.bug, author.Identity, unixTime, milestone)
+	if err != nil {
+		b.mu.Unlock()
+		return nil, err
+	}
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+	b.mu.Unlock()
+
+	return op, b.notifyUpdated()
+}
+
+func (c *BugCache) SetProject(project string) (*bug.SetProjectOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.SetProjectRaw(author, time.Now().Unix(), project, nil)
+}
+
+func (c *BugCache) SetProjectRaw(author *IdentityCache, unixTime int64, project string, metadata map[string]string) (*bug.SetProjectOperation, error) {
+	b, err := c.lock()
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := bug.SetProject(b.bug, author.Identity, unixTime, project)
+	if err != nil {
+		b.mu.Unlock()
+		return nil, err
+	}
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+	b.mu.Unlock()
+
+	return op, b.notifyUpdated()
+}
+
+func (c *BugCache) Vote(vote int) (*bug.VoteOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.VoteRaw(author, time.Now().Unix(), vote, nil)
+}
+
+func (c *BugCache) VoteRaw(author *IdentityCache, unixTime int64, vote int, metadata map[string]string) (*bug.VoteOperation, error) {
+	b, err := c.lock()
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := bug.Vote(b.bug, author.Identity, unixTime, vote)
+	if err != nil {
+		b.mu.Unlock()
+		return nil, err
+	}
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+	b.mu.Unlock()
+
+	return op, b.notifyUpdated()
 }
 
 func (c *BugCache) EditComment(target entity.Id, message string) (*bug.EditCommentOperation, error) {
@@ -220,16 +461,52 @@ func (c *BugCache) EditComment(target entity.Id, message string) (*bug.EditComme
 }
 
 func (c *BugCache) EditCommentRaw(author *IdentityCache, unixTime int64, target entity.Id, message string, metadata map[string]string) (*bug.EditCommentOperation, error) {
-	op, err := bug.EditComment(c.bug, author.Identity, unixTime, target, message)
+	b, err := c.lock()
 	if err != nil {
 		return nil, err
 	}
 
+	op, err := bug.EditComment(b.bug, author.Identity, unixTime, target, message)
+	if err != nil {
+		b.mu.Unlock()
+		return nil, err
+	}
+
 	for key, value := range metadata {
 		op.SetMetadata(key, value)
 	}
+	b.mu.Unlock()
+
+	return op, b.notifyUpdated()
+}
+
+func (c *BugCache) DeleteComment(target entity.Id) (*bug.DeleteCommentOperation, error) {
+	author, err := c.repoCache.GetUserIdentity()
+	if err != nil {
+		return nil, err
+	}
 
-	return op, c.notifyUpdated()
+	return c.DeleteCommentRaw(author, time.Now().Unix(), target, nil)
+}
+
+func (c *BugCache) DeleteCommentRaw(author *IdentityCache, unixTime int64, target entity.Id, metadata map[string]string) (*bug.DeleteCommentOperation, error) {
+	b, err := c.lock()
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := bug.DeleteComment(b.bug, author.Identity, unixTime, target)
+	if err != nil {
+		b.mu.Unlock()
+		return nil, err
+	}
+
+	for key, value := range metadata {
+		op.SetMetadata(key, value)
+	}
+	b.mu.Unlock()
+
+	return op, b.notifyUpdated()
 }
 
 func (c *BugCache) SetMetadata(target entity.Id, newMetadata map[string]string) (*bug.SetMetadataOperation, error) {
@@ -242,26 +519,62 @@ func (c *BugCache) SetMetadata(target entity.Id, newMetadata map[string]string)
 }
 
 func (c *BugCache) SetMetadataRaw(author *IdentityCache, unixTime int64, target entity.Id, newMetadata map[string]string) (*bug.SetMetadataOperation, error) {
-	op, err := bug.SetMetadata(c.bug, author.Identity, unixTime, target, newMetadata)
+	b, err := c.lock()
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := bug.SetMetadata(b.bug, author.Identity, unixTime, target, newMetadata)
 	if err != nil {
+		b.mu.Unlock()
 		return nil, err
 	}
+	b.mu.Unlock()
 
-	return op, c.notifyUpdated()
+	return op, b.notifyUpdated()
 }
 
 func (c *BugCache) Commit() error {
-	err := c.bug.Commit(c.repoCache.repo)
+	b, err := c.lock()
+	if err != nil {
+		return err
+	}
+
+	err = b.bug.Commit(b.repoCache.repo)
+	b.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return b.notifyUpdated()
+}
+
+// DiscardPendingOps drop the operations not committed yet
+func (c *BugCache) DiscardPendingOps() error {
+	b, err := c.lock()
 	if err != nil {
 		return err
 	}
-	return c.notifyUpdated()
+
+	if !b.bug.HasPendingOp() {
+		b.mu.Unlock()
+		return nil
+	}
+	b.bug.DiscardPendingOps()
+	b.mu.Unlock()
+
+	return b.notifyUpdated()
 }
 
 func (c *BugCache) CommitAsNeeded() error {
-	err := c.bug.CommitAsNeeded(c.repoCache.repo)
+	b, err := c.lock()
+	if err != nil {
+		return err
+	}
+
+	err = b.bug.CommitAsNeeded(b.repoCache.repo)
+	b.mu.Unlock()
 	if err != nil {
 		return err
 	}
-	return c.notifyUpdated()
+	return b.notifyUpdated()
 }
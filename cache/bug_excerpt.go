@@ -3,6 +3,7 @@ package cache
 import (
 	"encoding/gob"
 	"fmt"
+	"strings"
 
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/entity"
@@ -31,6 +32,10 @@ type BugExcerpt struct {
 	LenComments  int
 	Actors       []entity.Id
 	Participants []entity.Id
+	AssigneeId   entity.Id
+	Milestone    string
+	Project      string
+	Votes        int
 
 	// If author is identity.Bare, LegacyAuthor is set
 	// If author is identity.Identity, AuthorId is set and data is deported
@@ -83,6 +88,10 @@ func NewBugExcerpt(b bug.Interface, snap *bug.Snapshot) *BugExcerpt {
 		Participants:      participantsIds,
 		Title:             snap.Title,
 		LenComments:       len(snap.Comments),
+		AssigneeId:        snap.Assignee,
+		Milestone:         snap.Milestone,
+		Project:           snap.Project,
+		Votes:             snap.VoteCount(),
 		CreateMetadata:    b.FirstOp().AllMetadata(),
 	}
 
@@ -101,6 +110,28 @@ func NewBugExcerpt(b bug.Interface, snap *bug.Snapshot) *BugExcerpt {
 	return e
 }
 
+// filterCreateMetadata only keep the creation metadata whose key start with
+// one of the given prefixes. The origin of the bug is always kept.
+func (b *BugExcerpt) filterCreateMetadata(prefixes []string) {
+	for key := range b.CreateMetadata {
+		if key == "origin" {
+			continue
+		}
+
+		keep := false
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(key, prefix) {
+				keep = true
+				break
+			}
+		}
+
+		if !keep {
+			delete(b.CreateMetadata, key)
+		}
+	}
+}
+
 /*
  * Sorting
  */
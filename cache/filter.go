@@ -2,8 +2,10 @@ package cache
 
 import (
 	"strings"
+	"time"
 
 	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/entity"
 )
 
 // Filter is a predicate that match a subset of bugs
@@ -28,8 +30,12 @@ func AuthorFilter(query string) Filter {
 
 		// Normal identity
 		if excerpt.AuthorId != "" {
-			author, ok := repoCache.identitiesExcerpts[excerpt.AuthorId]
-			if !ok {
+			if query == userIdentityQuery {
+				return isUserIdentity(repoCache, excerpt.AuthorId)
+			}
+
+			author, err := repoCache.ResolveIdentityExcerpt(excerpt.AuthorId)
+			if err != nil {
 				panic("missing identity in the cache")
 			}
 
@@ -42,6 +48,19 @@ func AuthorFilter(query string) Filter {
 	}
 }
 
+// userIdentityQuery is the identity query matching the user identity
+const userIdentityQuery = "me"
+
+// isUserIdentity tell if the given id is the one of the user identity, or of
+// an identity merged into it
+func isUserIdentity(repoCache *RepoCache, id entity.Id) bool {
+	user, err := repoCache.GetUserIdentity()
+	if err != nil {
+		return false
+	}
+	return user.Id() == repoCache.MergedIdentityId(id)
+}
+
 // LabelFilter return a Filter that match a label
 func LabelFilter(label string) Filter {
 	return func(repoCache *RepoCache, excerpt *BugExcerpt) bool {
@@ -60,8 +79,15 @@ func ActorFilter(query string) Filter {
 		query = strings.ToLower(query)
 
 		for _, id := range excerpt.Actors {
-			identityExcerpt, ok := repoCache.identitiesExcerpts[id]
-			if !ok {
+			if query == userIdentityQuery {
+				if isUserIdentity(repoCache, id) {
+					return true
+				}
+				continue
+			}
+
+			identityExcerpt, err := repoCache.ResolveIdentityExcerpt(id)
+			if err != nil {
 				panic("missing identity in the cache")
 			}
 
@@ -79,8 +105,15 @@ func ParticipantFilter(query string) Filter {
 		query = strings.ToLower(query)
 
 		for _, id := range excerpt.Participants {
-			identityExcerpt, ok := repoCache.identitiesExcerpts[id]
-			if !ok {
+			if query == userIdentityQuery {
+				if isUserIdentity(repoCache, id) {
+					return true
+				}
+				continue
+			}
+
+			identityExcerpt, err := repoCache.ResolveIdentityExcerpt(id)
+			if err != nil {
 				panic("missing identity in the cache")
 			}
 
@@ -92,6 +125,41 @@ func ParticipantFilter(query string) Filter {
 	}
 }
 
+// AssigneeFilter return a Filter that match the identity assigned to a bug
+func AssigneeFilter(query string) Filter {
+	return func(repoCache *RepoCache, excerpt *BugExcerpt) bool {
+		if excerpt.AssigneeId == "" {
+			return false
+		}
+
+		if strings.ToLower(query) == userIdentityQuery {
+			return isUserIdentity(repoCache, excerpt.AssigneeId)
+		}
+
+		// the assignee might not be known locally
+		assignee, err := repoCache.ResolveIdentityExcerpt(excerpt.AssigneeId)
+		if err != nil {
+			return false
+		}
+
+		return assignee.Match(strings.ToLower(query))
+	}
+}
+
+// MilestoneFilter return a Filter that match the milestone of a bug
+func MilestoneFilter(milestone string) Filter {
+	return func(repoCache *RepoCache, excerpt *BugExcerpt) bool {
+		return strings.EqualFold(excerpt.Milestone, milestone)
+	}
+}
+
+// ProjectFilter return a Filter that match the project of a bug
+func ProjectFilter(project string) Filter {
+	return func(repoCache *RepoCache, excerpt *BugExcerpt) bool {
+		return strings.EqualFold(excerpt.Project, project)
+	}
+}
+
 // TitleFilter return a Filter that match if the title contains the given query
 func TitleFilter(query string) Filter {
 	return func(repo *RepoCache, excerpt *BugExcerpt) bool {
@@ -102,6 +170,31 @@ func TitleFilter(query string) Filter {
 	}
 }
 
+// FullTextFilter return a Filter that match if the title or any comment
+// contains the given query. Comments are not part of the excerpt, so the bug
+// is loaded if the title doesn't match.
+func FullTextFilter(query string) Filter {
+	return func(repoCache *RepoCache, excerpt *BugExcerpt) bool {
+		query := strings.ToLower(query)
+
+		if strings.Contains(strings.ToLower(excerpt.Title), query) {
+			return true
+		}
+
+		b, err := repoCache.ResolveBug(excerpt.Id)
+		if err != nil {
+			return false
+		}
+
+		for _, comment := range b.Snapshot().Comments {
+			if strings.Contains(strings.ToLower(comment.Message), query) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
 // NoLabelFilter return a Filter that match the absence of labels
 func NoLabelFilter() Filter {
 	return func(repoCache *RepoCache, excerpt *BugExcerpt) bool {
@@ -109,14 +202,72 @@ func NoLabelFilter() Filter {
 	}
 }
 
+// NoAssigneeFilter return a Filter that match the absence of assignee
+func NoAssigneeFilter() Filter {
+	return func(repoCache *RepoCache, excerpt *BugExcerpt) bool {
+		return excerpt.AssigneeId == ""
+	}
+}
+
+// NoMilestoneFilter return a Filter that match the absence of milestone
+func NoMilestoneFilter() Filter {
+	return func(repoCache *RepoCache, excerpt *BugExcerpt) bool {
+		return excerpt.Milestone == ""
+	}
+}
+
+// NoProjectFilter return a Filter that match the absence of project
+func NoProjectFilter() Filter {
+	return func(repoCache *RepoCache, excerpt *BugExcerpt) bool {
+		return excerpt.Project == ""
+	}
+}
+
+// CreatedBeforeFilter return a Filter that match the bugs created before the
+// given time
+func CreatedBeforeFilter(t time.Time) Filter {
+	return func(repoCache *RepoCache, excerpt *BugExcerpt) bool {
+		return excerpt.CreateUnixTime < t.Unix()
+	}
+}
+
+// CreatedAfterFilter return a Filter that match the bugs created after the
+// given time
+func CreatedAfterFilter(t time.Time) Filter {
+	return func(repoCache *RepoCache, excerpt *BugExcerpt) bool {
+		return excerpt.CreateUnixTime > t.Unix()
+	}
+}
+
+// EditedBeforeFilter return a Filter that match the bugs last edited before
+// the given time
+func EditedBeforeFilter(t time.Time) Filter {
+	return func(repoCache *RepoCache, excerpt *BugExcerpt) bool {
+		return excerpt.EditUnixTime < t.Unix()
+	}
+}
+
+// EditedAfterFilter return a Filter that match the bugs last edited after the
+// given time
+func EditedAfterFilter(t time.Time) Filter {
+	return func(repoCache *RepoCache, excerpt *BugExcerpt) bool {
+		return excerpt.EditUnixTime > t.Unix()
+	}
+}
+
 // Filters is a collection of Filter that implement a complex filter
 type Filters struct {
 	Status      []Filter
 	Author      []Filter
 	Actor       []Filter
 	Participant []Filter
+	Assignee    []Filter
+	Milestone   []Filter
+	Project     []Filter
+	Time        []Filter
 	Label       []Filter
 	Title       []Filter
+	Search      []Filter
 	NoFilters   []Filter
 }
 
@@ -138,6 +289,22 @@ func (f *Filters) Match(repoCache *RepoCache, excerpt *BugExcerpt) bool {
 		return false
 	}
 
+	if match := f.orMatch(f.Assignee, repoCache, excerpt); !match {
+		return false
+	}
+
+	if match := f.orMatch(f.Milestone, repoCache, excerpt); !match {
+		return false
+	}
+
+	if match := f.orMatch(f.Project, repoCache, excerpt); !match {
+		return false
+	}
+
+	if match := f.andMatch(f.Time, repoCache, excerpt); !match {
+		return false
+	}
+
 	if match := f.andMatch(f.Label, repoCache, excerpt); !match {
 		return false
 	}
@@ -150,6 +317,11 @@ func (f *Filters) Match(repoCache *RepoCache, excerpt *BugExcerpt) bool {
 		return false
 	}
 
+	// last, as it might need to load the bug
+	if match := f.andMatch(f.Search, repoCache, excerpt); !match {
+		return false
+	}
+
 	return true
 }
 
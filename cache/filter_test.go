@@ -2,6 +2,7 @@ package cache
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -32,3 +33,20 @@ func TestTitleFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestTimeFilters(t *testing.T) {
+	created := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	edited := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	excerpt := &BugExcerpt{CreateUnixTime: created.Unix(), EditUnixTime: edited.Unix()}
+
+	between := time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.True(t, CreatedBeforeFilter(between)(nil, excerpt))
+	assert.False(t, CreatedAfterFilter(between)(nil, excerpt))
+	assert.False(t, EditedBeforeFilter(between)(nil, excerpt))
+	assert.True(t, EditedAfterFilter(between)(nil, excerpt))
+
+	// the bounds are exclusive
+	assert.False(t, CreatedBeforeFilter(created)(nil, excerpt))
+	assert.False(t, EditedAfterFilter(edited)(nil, excerpt))
+}
@@ -22,7 +22,11 @@ type IdentityExcerpt struct {
 
 	Name              string
 	Login             string
+	Email             string
 	ImmutableMetadata map[string]string
+
+	// the identity this identity has been merged into, if any
+	MergedInto entity.Id
 }
 
 func NewIdentityExcerpt(i *identity.Identity) *IdentityExcerpt {
@@ -30,7 +34,9 @@ func NewIdentityExcerpt(i *identity.Identity) *IdentityExcerpt {
 		Id:                i.Id(),
 		Name:              i.Name(),
 		Login:             i.Login(),
+		Email:             i.Email(),
 		ImmutableMetadata: i.ImmutableMetadata(),
+		MergedInto:        i.MergedInto(),
 	}
 }
 
@@ -0,0 +1,153 @@
+package cache
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// relevance of an identity for a search term, higher is better
+const (
+	searchNoMatch = iota
+	searchFuzzy
+	searchContains
+	searchPrefix
+	searchExact
+)
+
+// ResolveIdentityUser retrieve an identity designated by a user: "me" for the
+// user identity, a login or an id prefix.
+func (c *RepoCache) ResolveIdentityUser(user string) (*IdentityCache, error) {
+	if strings.ToLower(user) == "me" {
+		return c.GetUserIdentity()
+	}
+
+	var matching []*IdentityExcerpt
+	for _, i := range c.SearchIdentity(user) {
+		if i.Login != "" && strings.EqualFold(i.Login, user) {
+			matching = append(matching, i)
+		}
+	}
+
+	if len(matching) == 1 {
+		return c.ResolveIdentity(matching[0].Id)
+	}
+	if len(matching) > 1 {
+		return nil, fmt.Errorf("multiple identities have the login \"%s\", use an id instead", user)
+	}
+
+	return c.ResolveIdentityPrefix(user)
+}
+
+// SearchIdentity return the identities matching a search term, the most
+// relevant first. The term is matched against the name, login, email and
+// id of the identities, in order of preference exactly, as a prefix of one
+// of the words, as a substring or as a fuzzy subsequence of characters.
+// An empty term match all the identities.
+func (c *RepoCache) SearchIdentity(term string) []*IdentityExcerpt {
+	term = strings.ToLower(strings.TrimSpace(term))
+
+	type scored struct {
+		excerpt *IdentityExcerpt
+		score   int
+	}
+
+	c.muIdentity.RLock()
+	matching := make([]scored, 0, len(c.identitiesExcerpts))
+	for _, excerpt := range c.identitiesExcerpts {
+		score := identitySearchScore(excerpt, term)
+		if score != searchNoMatch {
+			matching = append(matching, scored{excerpt: excerpt, score: score})
+		}
+	}
+	c.muIdentity.RUnlock()
+
+	sort.Slice(matching, func(i, j int) bool {
+		if matching[i].score != matching[j].score {
+			return matching[i].score > matching[j].score
+		}
+		nameI := strings.ToLower(matching[i].excerpt.DisplayName())
+		nameJ := strings.ToLower(matching[j].excerpt.DisplayName())
+		if nameI != nameJ {
+			return nameI < nameJ
+		}
+		return matching[i].excerpt.Id < matching[j].excerpt.Id
+	})
+
+	result := make([]*IdentityExcerpt, len(matching))
+	for i, m := range matching {
+		result[i] = m.excerpt
+	}
+
+	return result
+}
+
+func identitySearchScore(excerpt *IdentityExcerpt, term string) int {
+	if term == "" {
+		return searchExact
+	}
+
+	score := searchNoMatch
+
+	if excerpt.Id.HasPrefix(term) {
+		score = searchPrefix
+	}
+
+	for _, value := range []string{excerpt.Name, excerpt.Login, excerpt.Email} {
+		if s := textSearchScore(strings.ToLower(value), term); s > score {
+			score = s
+		}
+	}
+
+	return score
+}
+
+func textSearchScore(value string, term string) int {
+	switch {
+	case value == "":
+		return searchNoMatch
+	case value == term:
+		return searchExact
+	case hasWordPrefix(value, term):
+		return searchPrefix
+	case strings.Contains(value, term):
+		return searchContains
+	case isSubsequence(value, term):
+		return searchFuzzy
+	default:
+		return searchNoMatch
+	}
+}
+
+// hasWordPrefix tell if one of the words of value start with the term
+func hasWordPrefix(value string, term string) bool {
+	if strings.HasPrefix(value, term) {
+		return true
+	}
+
+	isSeparator := func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}
+
+	for _, word := range strings.FieldsFunc(value, isSeparator) {
+		if strings.HasPrefix(word, term) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isSubsequence tell if all the characters of the term appear in value, in
+// the same order
+func isSubsequence(value string, term string) bool {
+	runes := []rune(term)
+	i := 0
+	for _, r := range value {
+		if i < len(runes) && r == runes[i] {
+			i++
+		}
+	}
+	return i == len(runes)
+}
@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/util/git"
+	"github.com/pkg/errors"
+)
+
+const jsonExportVersion = 1
+
+// JSONExport is the complete content of a JSON export: the operations of the
+// bugs, the identities they refer to and the files they carry.
+type JSONExport struct {
+	Version    int                  `json:"version"`
+	Identities []JSONExportIdentity `json:"identities"`
+	Bugs       []JSONExportBug      `json:"bugs"`
+	Files      map[git.Hash][]byte  `json:"files,omitempty"`
+}
+
+// JSONExportIdentity hold the data of an exported identity
+type JSONExportIdentity struct {
+	Id        entity.Id         `json:"id"`
+	Name      string            `json:"name"`
+	Email     string            `json:"email,omitempty"`
+	Login     string            `json:"login,omitempty"`
+	AvatarUrl string            `json:"avatar_url,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+// JSONExportBug hold the ordered operations of an exported bug
+type JSONExportBug struct {
+	Id         entity.Id             `json:"id"`
+	Operations []JSONExportOperation `json:"operations"`
+}
+
+// JSONExportOperation is an operation in its serialized form. The id is kept
+// alongside, as it can't be recomputed once the operation is reformatted.
+type JSONExportOperation struct {
+	Id        entity.Id       `json:"id"`
+	Operation json.RawMessage `json:"operation"`
+}
+
+// ExportJSON write the complete history of the given bugs as JSON, in a form
+// that ImportJSON can recreate in another repository.
+func (c *RepoCache) ExportJSON(w io.Writer, ids []entity.Id) error {
+	export := JSONExport{
+		Version:    jsonExportVersion,
+		Identities: []JSONExportIdentity{},
+		Bugs:       make([]JSONExportBug, 0, len(ids)),
+		Files:      make(map[git.Hash][]byte),
+	}
+
+	identities := make(map[entity.Id]bool)
+
+	for _, id := range ids {
+		b, err := c.ResolveBug(id)
+		if err != nil {
+			return err
+		}
+
+		snap := b.Snapshot()
+		exported := JSONExportBug{
+			Id:         b.Id(),
+			Operations: make([]JSONExportOperation, 0, len(snap.Operations)),
+		}
+
+		for _, op := range snap.Operations {
+			data, err := json.Marshal(op)
+			if err != nil {
+				return err
+			}
+
+			exported.Operations = append(exported.Operations, JSONExportOperation{
+				Id:        op.Id(),
+				Operation: data,
+			})
+
+			// legacy authors are fully serialized with the operation
+			if _, ok := op.GetAuthor().(*identity.Bare); !ok {
+				identities[op.GetAuthor().Id()] = true
+			}
+
+			if op, ok := op.(*bug.SetAssigneeOperation); ok && op.Assignee != "" {
+				identities[op.Assignee] = true
+			}
+
+			for _, hash := range op.GetFiles() {
+				if _, ok := export.Files[hash]; ok {
+					continue
+				}
+				data, err := c.repo.ReadData(hash)
+				if err != nil {
+					return errors.Wrapf(err, "can't read file %s", hash)
+				}
+				export.Files[hash] = data
+			}
+		}
+
+		export.Bugs = append(export.Bugs, exported)
+	}
+
+	for _, id := range sortedIds(identities) {
+		i, err := c.ResolveIdentity(id)
+		if err != nil {
+			return err
+		}
+
+		export.Identities = append(export.Identities, JSONExportIdentity{
+			Id:        i.Id(),
+			Name:      i.Name(),
+			Email:     i.Email(),
+			Login:     i.Login(),
+			AvatarUrl: i.AvatarUrl(),
+			Metadata:  i.ImmutableMetadata(),
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(export)
+}
+
+func sortedIds(set map[entity.Id]bool) []entity.Id {
+	result := make([]entity.Id, 0, len(set))
+	for id := range set {
+		result = append(result, id)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i] < result[j]
+	})
+	return result
+}
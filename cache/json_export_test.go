@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/git"
+)
+
+func TestExportImportJSON(t *testing.T) {
+	repoA := repository.CreateTestRepo(false)
+	repoB := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repoA, repoB)
+
+	cacheA, err := NewRepoCache(repoA)
+	require.NoError(t, err)
+
+	rene, err := cacheA.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = cacheA.SetUserIdentity(rene)
+	require.NoError(t, err)
+
+	file, err := repoA.StoreData([]byte("attached"))
+	require.NoError(t, err)
+
+	bug1, create, err := cacheA.NewBugRaw(rene, 1000, "title", "message", nil, map[string]string{"key": "value"})
+	require.NoError(t, err)
+	comment, err := bug1.AddCommentRaw(rene, 1001, "comment", []git.Hash{file}, nil)
+	require.NoError(t, err)
+	_, err = bug1.EditCommentRaw(rene, 1002, create.Id(), "edited", nil)
+	require.NoError(t, err)
+	_, err = bug1.SetMetadataRaw(rene, 1003, comment.Id(), map[string]string{"extra": "data"})
+	require.NoError(t, err)
+	_, _, err = bug1.ChangeLabelsRaw(rene, 1004, []string{"bug"}, nil, nil)
+	require.NoError(t, err)
+	_, err = bug1.SetAssigneeRaw(rene, 1005, rene, nil)
+	require.NoError(t, err)
+	_, err = bug1.CloseRaw(rene, 1006, nil)
+	require.NoError(t, err)
+	require.NoError(t, bug1.Commit())
+
+	export := &bytes.Buffer{}
+	err = cacheA.ExportJSON(export, cacheA.AllBugsIds())
+	require.NoError(t, err)
+
+	cacheB, err := NewRepoCache(repoB)
+	require.NoError(t, err)
+
+	result, err := cacheB.ImportJSON(bytes.NewReader(export.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, &JSONImportResult{Identities: 1, Bugs: 1, Operations: 7}, result)
+
+	imported, err := cacheB.ResolveBugCreateMetadata("key", "value")
+	require.NoError(t, err)
+
+	snapA := bug1.Snapshot()
+	snapB := imported.Snapshot()
+	require.Equal(t, snapA.Title, snapB.Title)
+	require.Equal(t, snapA.Status, snapB.Status)
+	require.Equal(t, snapA.Labels, snapB.Labels)
+	require.Equal(t, bug.ClosedStatus, snapB.Status)
+	require.Len(t, snapB.Comments, 2)
+	require.Equal(t, "edited", snapB.Comments[0].Message)
+	require.Equal(t, snapA.Comments[1].Files, snapB.Comments[1].Files)
+	require.Equal(t, int64(1001), snapB.Comments[1].UnixTime.Time().Unix())
+
+	assignee, err := cacheB.ResolveIdentity(snapB.Assignee)
+	require.NoError(t, err)
+	require.Equal(t, "René Descartes", assignee.Name())
+	require.Equal(t, snapB.Comments[0].Author.Id(), assignee.Id())
+
+	data, err := repoB.ReadData(file)
+	require.NoError(t, err)
+	require.Equal(t, []byte("attached"), data)
+
+	value, ok := snapB.Operations[1].GetMetadata("extra")
+	require.True(t, ok)
+	require.Equal(t, "data", value)
+
+	// importing again is a no-op
+	result, err = cacheB.ImportJSON(bytes.NewReader(export.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, &JSONImportResult{}, result)
+
+	// new operations are added on top of the previous import
+	_, err = bug1.SetTitleRaw(rene, 1007, "new title", nil)
+	require.NoError(t, err)
+	require.NoError(t, bug1.Commit())
+
+	export.Reset()
+	err = cacheA.ExportJSON(export, cacheA.AllBugsIds())
+	require.NoError(t, err)
+
+	result, err = cacheB.ImportJSON(bytes.NewReader(export.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, &JSONImportResult{Operations: 1}, result)
+	require.Equal(t, "new title", imported.Snapshot().Title)
+	require.Len(t, cacheB.AllBugsIds(), 1)
+
+	// the bugs already there natively are skipped
+	result, err = cacheA.ImportJSON(bytes.NewReader(export.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, &JSONImportResult{Skipped: 1}, result)
+}
@@ -0,0 +1,364 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/pkg/errors"
+)
+
+// metadata key holding the original id of an imported identity or operation
+const jsonImportIdMetadataKey = "json-import-id"
+
+// JSONImportResult summarize the changes made by ImportJSON
+type JSONImportResult struct {
+	// number of identities created
+	Identities int
+	// number of bugs created
+	Bugs int
+	// number of operations added, including the creation of the bugs
+	Operations int
+	// number of bugs skipped as they already exist natively in the repository
+	Skipped int
+}
+
+// ImportJSON recreate the bugs of a JSON export made with ExportJSON.
+//
+// The import is idempotent: the original ids are recorded as metadata, so that
+// importing the same export again only adds the operations that were not
+// already imported.
+func (c *RepoCache) ImportJSON(r io.Reader) (*JSONImportResult, error) {
+	var export JSONExport
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return nil, errors.Wrap(err, "invalid export")
+	}
+
+	if export.Version != jsonExportVersion {
+		return nil, fmt.Errorf("unsupported export version %d", export.Version)
+	}
+
+	for hash, data := range export.Files {
+		stored, err := c.repo.StoreData(data)
+		if err != nil {
+			return nil, err
+		}
+		if stored != hash {
+			return nil, fmt.Errorf("file %s doesn't match its content", hash)
+		}
+	}
+
+	imp := &jsonImporter{
+		cache:      c,
+		exported:   make(map[entity.Id]JSONExportIdentity),
+		identities: make(map[entity.Id]*IdentityCache),
+		result:     &JSONImportResult{},
+	}
+
+	for _, i := range export.Identities {
+		imp.exported[i.Id] = i
+	}
+
+	for _, b := range export.Bugs {
+		if err := imp.importBug(b); err != nil {
+			return imp.result, errors.Wrapf(err, "bug %s", b.Id.Human())
+		}
+	}
+
+	return imp.result, nil
+}
+
+type jsonImporter struct {
+	cache *RepoCache
+	// the identities of the export, by original id
+	exported map[entity.Id]JSONExportIdentity
+	// the local identities, by original id
+	identities map[entity.Id]*IdentityCache
+	result     *JSONImportResult
+}
+
+func (imp *jsonImporter) importBug(exported JSONExportBug) error {
+	if len(exported.Operations) == 0 {
+		return fmt.Errorf("no operation")
+	}
+
+	// a bug already there natively can only be updated with a git merge
+	if _, err := imp.cache.ResolveBugExcerpt(exported.Id); err == nil {
+		imp.result.Skipped++
+		return nil
+	}
+
+	var b *BugCache
+
+	// the original operation ids, and their local counterparts
+	ids := make(map[entity.Id]entity.Id)
+
+	createId := exported.Operations[0].Id
+	b, err := imp.cache.ResolveBugCreateMetadata(jsonImportIdMetadataKey, createId.String())
+	if err != nil && err != bug.ErrBugNotExist {
+		return err
+	}
+
+	for _, exportedOp := range exported.Operations {
+		if b != nil {
+			localId, err := b.ResolveOperationWithMetadata(jsonImportIdMetadataKey, exportedOp.Id.String())
+			if err == nil {
+				ids[exportedOp.Id] = localId
+				continue
+			}
+			if err != ErrNoMatchingOp {
+				return err
+			}
+		}
+
+		op, err := bug.UnmarshalOperation(exportedOp.Operation)
+		if err != nil {
+			return err
+		}
+
+		b, err = imp.applyOp(b, op, exportedOp.Id, ids)
+		if err != nil {
+			return errors.Wrapf(err, "operation %s", exportedOp.Id.Human())
+		}
+
+		imp.result.Operations++
+	}
+
+	return b.CommitAsNeeded()
+}
+
+// applyOp recreate an operation on the bug, or create the bug if b is nil.
+// The local id of the operation is recorded in ids.
+func (imp *jsonImporter) applyOp(b *BugCache, op bug.Operation, originalId entity.Id, ids map[entity.Id]entity.Id) (*BugCache, error) {
+	author, err := imp.resolveAuthor(op.GetAuthor())
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := op.AllMetadata()
+	metadata[jsonImportIdMetadataKey] = originalId.String()
+
+	unixTime := op.GetUnixTime()
+
+	target := func(id entity.Id) (entity.Id, error) {
+		local, ok := ids[id]
+		if !ok {
+			return "", fmt.Errorf("unknown target operation %s", id.Human())
+		}
+		return local, nil
+	}
+
+	if b == nil {
+		op, ok := op.(*bug.CreateOperation)
+		if !ok {
+			return nil, fmt.Errorf("the first operation is not a creation")
+		}
+
+		b, created, err := imp.cache.NewBugRaw(author, unixTime, op.Title, op.Message, op.Files, metadata)
+		if err != nil {
+			return nil, err
+		}
+
+		imp.result.Bugs++
+		ids[originalId] = created.Id()
+		return b, nil
+	}
+
+	var created bug.Operation
+
+	switch op := op.(type) {
+	case *bug.CreateOperation:
+		return nil, fmt.Errorf("unexpected creation")
+
+	case *bug.AddCommentOperation:
+		created, err = b.AddCommentRaw(author, unixTime, op.Message, op.Files, metadata)
+
+	case *bug.EditCommentOperation:
+		var localTarget entity.Id
+		localTarget, err = target(op.Target)
+		if err != nil {
+			return nil, err
+		}
+		var live *BugCache
+		live, err = b.lock()
+		if err != nil {
+			return nil, err
+		}
+		var edit *bug.EditCommentOperation
+		edit, err = bug.EditCommentWithFiles(live.bug, author.Identity, unixTime, localTarget, op.Message, op.Files)
+		if err != nil {
+			live.mu.Unlock()
+			return nil, err
+		}
+		for key, value := range metadata {
+			edit.SetMetadata(key, value)
+		}
+		live.mu.Unlock()
+		created, err = edit, live.notifyUpdated()
+
+	case *bug.DeleteCommentOperation:
+		var localTarget entity.Id
+		localTarget, err = target(op.Target)
+		if err != nil {
+			return nil, err
+		}
+		created, err = b.DeleteCommentRaw(author, unixTime, localTarget, metadata)
+
+	case *bug.LabelChangeOperation:
+		created, err = b.ForceChangeLabelsRaw(author, unixTime, labelsToStrings(op.Added), labelsToStrings(op.Removed), metadata)
+
+	case *bug.SetStatusOperation:
+		if op.Status == bug.ClosedStatus {
+			created, err = b.CloseRaw(author, unixTime, metadata)
+		} else {
+			created, err = b.OpenRaw(author, unixTime, metadata)
+		}
+
+	case *bug.SetTitleOperation:
+		created, err = b.SetTitleRaw(author, unixTime, op.Title, metadata)
+
+	case *bug.SetAssigneeOperation:
+		var assignee *IdentityCache
+		if op.Assignee != "" {
+			assignee, err = imp.resolveIdentity(op.Assignee)
+			if err != nil {
+				return nil, err
+			}
+		}
+		created, err = b.SetAssigneeRaw(author, unixTime, assignee, metadata)
+
+	case *bug.SetMilestoneOperation:
+		created, err = b.SetMilestoneRaw(author, unixTime, op.Milestone, metadata)
+
+	case *bug.SetProjectOperation:
+		created, err = b.SetProjectRaw(author, unixTime, op.Project, metadata)
+
+	case *bug.VoteOperation:
+		created, err = b.VoteRaw(author, unixTime, op.Vote, metadata)
+
+	case *bug.SetMetadataOperation:
+		var localTarget entity.Id
+		localTarget, err = target(op.Target)
+		if err != nil {
+			return nil, err
+		}
+		var set *bug.SetMetadataOperation
+		set, err = b.SetMetadataRaw(author, unixTime, localTarget, op.NewMetadata)
+		if err != nil {
+			return nil, err
+		}
+		// SetMetadataRaw doesn't take metadata for the operation itself
+		var live *BugCache
+		live, err = b.lock()
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range metadata {
+			set.SetMetadata(key, value)
+		}
+		live.mu.Unlock()
+		created = set
+
+	case *bug.NoOpOperation:
+		var live *BugCache
+		live, err = b.lock()
+		if err != nil {
+			return nil, err
+		}
+		var noop *bug.NoOpOperation
+		noop, err = bug.NoOp(live.bug, author.Identity, unixTime, metadata)
+		live.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		created, err = noop, live.notifyUpdated()
+
+	default:
+		return nil, fmt.Errorf("unsupported operation type %T", op)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	ids[originalId] = created.Id()
+	return b, nil
+}
+
+// resolveAuthor find or create the local identity of the author of an
+// imported operation
+func (imp *jsonImporter) resolveAuthor(author identity.Interface) (*IdentityCache, error) {
+	bare, ok := author.(*identity.Bare)
+	if !ok {
+		return imp.resolveIdentity(author.Id())
+	}
+
+	// legacy authors are converted to a proper identity
+	return imp.resolveOrCreate(bare.Id(), JSONExportIdentity{
+		Name:      bare.Name(),
+		Email:     bare.Email(),
+		Login:     bare.Login(),
+		AvatarUrl: bare.AvatarUrl(),
+	})
+}
+
+// resolveIdentity find or create the local identity matching an exported one
+func (imp *jsonImporter) resolveIdentity(id entity.Id) (*IdentityCache, error) {
+	if i, ok := imp.identities[id]; ok {
+		return i, nil
+	}
+
+	// the identity might already be there natively
+	if _, err := imp.cache.ResolveIdentityExcerpt(id); err == nil {
+		i, err := imp.cache.ResolveIdentity(id)
+		if err != nil {
+			return nil, err
+		}
+		imp.identities[id] = i
+		return i, nil
+	}
+
+	exported, ok := imp.exported[id]
+	if !ok {
+		return nil, fmt.Errorf("identity %s is missing from the export", id.Human())
+	}
+
+	return imp.resolveOrCreate(id, exported)
+}
+
+func (imp *jsonImporter) resolveOrCreate(id entity.Id, exported JSONExportIdentity) (*IdentityCache, error) {
+	if i, ok := imp.identities[id]; ok {
+		return i, nil
+	}
+
+	i, err := imp.cache.ResolveIdentityImmutableMetadata(jsonImportIdMetadataKey, id.String())
+	if err == identity.ErrIdentityNotExist {
+		metadata := make(map[string]string, len(exported.Metadata)+1)
+		for key, value := range exported.Metadata {
+			metadata[key] = value
+		}
+		metadata[jsonImportIdMetadataKey] = id.String()
+
+		i, err = imp.cache.NewIdentityRaw(exported.Name, exported.Email, exported.Login, exported.AvatarUrl, metadata)
+		if err == nil {
+			imp.result.Identities++
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	imp.identities[id] = i
+	return i, nil
+}
+
+func labelsToStrings(labels []bug.Label) []string {
+	result := make([]string, len(labels))
+	for i, label := range labels {
+		result[i] = string(label)
+	}
+	return result
+}
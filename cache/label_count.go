@@ -0,0 +1,46 @@
+package cache
+
+import (
+	"github.com/MichaelMure/git-bug/bug"
+)
+
+// LabelCount hold the number of open and closed bugs having a label
+type LabelCount struct {
+	Label  bug.Label
+	Open   int
+	Closed int
+}
+
+// Total return the number of bugs having the label
+func (lc LabelCount) Total() int {
+	return lc.Open + lc.Closed
+}
+
+// labelCounts maintain the LabelCount of each label in use
+type labelCounts map[bug.Label]*LabelCount
+
+// add count the labels of a bug excerpt, or uncount them with a negative delta
+func (lc labelCounts) add(excerpt *BugExcerpt, delta int) {
+	if excerpt == nil {
+		return
+	}
+
+	for _, label := range excerpt.Labels {
+		count, ok := lc[label]
+		if !ok {
+			count = &LabelCount{Label: label}
+			lc[label] = count
+		}
+
+		switch excerpt.Status {
+		case bug.OpenStatus:
+			count.Open += delta
+		case bug.ClosedStatus:
+			count.Closed += delta
+		}
+
+		if count.Total() <= 0 {
+			delete(lc, label)
+		}
+	}
+}
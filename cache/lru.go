@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+// lruIdCache track a set of ids in the order they were last used. It is safe
+// for concurrent use, so that a bug can be marked as used under a read lock.
+type lruIdCache struct {
+	mu    sync.Mutex
+	ll    *list.List
+	items map[entity.Id]*list.Element
+}
+
+func newLRUIdCache() *lruIdCache {
+	return &lruIdCache{
+		ll:    list.New(),
+		items: make(map[entity.Id]*list.Element),
+	}
+}
+
+// Add insert an id, or mark it as the most recently used if already present
+func (c *lruIdCache) Add(id entity.Id) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[id]; ok {
+		c.ll.MoveToFront(elem)
+		return
+	}
+	c.items[id] = c.ll.PushFront(id)
+}
+
+// Remove drop an id
+func (c *lruIdCache) Remove(id entity.Id) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[id]; ok {
+		c.ll.Remove(elem)
+		delete(c.items, id)
+	}
+}
+
+// Len return the number of ids
+func (c *lruIdCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.ll.Len()
+}
+
+// OldestToNewest return the ids, starting with the least recently used
+func (c *lruIdCache) OldestToNewest() []entity.Id {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result := make([]entity.Id, 0, c.ll.Len())
+	for elem := c.ll.Back(); elem != nil; elem = elem.Prev() {
+		result = append(result, elem.Value.(entity.Id))
+	}
+	return result
+}
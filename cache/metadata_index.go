@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"sort"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+// opMetadata is a single metadata of an operation of a bug
+type opMetadata struct {
+	OpId  entity.Id
+	Key   string
+	Value string
+}
+
+// collectMetadata return the metadata of all the operations of a bug,
+// including the ones added later with a SetMetadataOperation
+func collectMetadata(snap *bug.Snapshot) []opMetadata {
+	var result []opMetadata
+
+	for _, op := range snap.Operations {
+		all := op.AllMetadata()
+		if len(all) == 0 {
+			continue
+		}
+
+		keys := make([]string, 0, len(all))
+		for key := range all {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		id := op.Id()
+		for _, key := range keys {
+			result = append(result, opMetadata{OpId: id, Key: key, Value: all[key]})
+		}
+	}
+
+	return result
+}
+
+// metadataHit locate an operation having a given metadata
+type metadataHit struct {
+	bugId entity.Id
+	opId  entity.Id
+}
+
+// metadataIndex index the metadata of the operations of all the bugs, by key
+// then by value
+type metadataIndex map[string]map[string][]metadataHit
+
+func (idx metadataIndex) add(bugId entity.Id, entries []opMetadata) {
+	for _, entry := range entries {
+		values, ok := idx[entry.Key]
+		if !ok {
+			values = make(map[string][]metadataHit)
+			idx[entry.Key] = values
+		}
+		values[entry.Value] = append(values[entry.Value], metadataHit{bugId: bugId, opId: entry.OpId})
+	}
+}
+
+func (idx metadataIndex) remove(bugId entity.Id, entries []opMetadata) {
+	for _, entry := range entries {
+		values := idx[entry.Key]
+		hits, ok := values[entry.Value]
+		if !ok {
+			// already removed, when the same metadata is on multiple operations
+			continue
+		}
+
+		kept := hits[:0]
+		for _, hit := range hits {
+			if hit.bugId != bugId {
+				kept = append(kept, hit)
+			}
+		}
+
+		if len(kept) > 0 {
+			values[entry.Value] = kept
+			continue
+		}
+
+		delete(values, entry.Value)
+		if len(values) == 0 {
+			delete(idx, entry.Key)
+		}
+	}
+}
+
+// lookup return a copy of the hits for the given metadata
+func (idx metadataIndex) lookup(key string, value string) []metadataHit {
+	hits := idx[key][value]
+	result := make([]metadataHit, len(hits))
+	copy(result, hits)
+	return result
+}
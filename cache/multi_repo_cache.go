@@ -2,13 +2,24 @@ package cache
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/entity"
 	"github.com/MichaelMure/git-bug/repository"
 )
 
 const lockfile = "lock"
 
+// separate the name of a repository from the id of a bug, as in "name/1a2b3c4"
+const repoRefSeparator = "/"
+
 // MultiRepoCache is the root cache, holding multiple RepoCache.
+//
+// The bugs of all the repositories are presented in a single namespace, where
+// their ids are prefixed with the name of their repository, as in
+// "name/1a2b3c4". The bugs of the default repository are not prefixed.
 type MultiRepoCache struct {
 	repos map[string]*RepoCache
 }
@@ -21,6 +32,14 @@ func NewMultiRepoCache() MultiRepoCache {
 
 // RegisterRepository register a named repository. Use this for multi-repo setup
 func (c *MultiRepoCache) RegisterRepository(ref string, repo repository.ClockedRepo) error {
+	if ref == "" || strings.Contains(ref, repoRefSeparator) {
+		return fmt.Errorf("invalid repository name \"%s\"", ref)
+	}
+
+	if _, ok := c.repos[ref]; ok {
+		return fmt.Errorf("a repository is already registered as \"%s\"", ref)
+	}
+
 	r, err := NewRepoCache(repo)
 	if err != nil {
 		return err
@@ -63,6 +82,159 @@ func (c *MultiRepoCache) ResolveRepo(ref string) (*RepoCache, error) {
 	return r, nil
 }
 
+// RepoRefs return the names of all the registered repositories, sorted. The
+// default repository is named "".
+func (c *MultiRepoCache) RepoRefs() []string {
+	result := make([]string, 0, len(c.repos))
+	for ref := range c.repos {
+		result = append(result, ref)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// MultiRepoBugExcerpt is the excerpt of a bug along with the name of its
+// repository
+type MultiRepoBugExcerpt struct {
+	RepoRef string
+	*BugExcerpt
+}
+
+// FullId return the complete id of the bug, prefixed with the repository name
+func (e MultiRepoBugExcerpt) FullId() string {
+	return prefixRepoRef(e.RepoRef, e.Id.String())
+}
+
+// HumanId return the human readable id of the bug, prefixed with the
+// repository name
+func (e MultiRepoBugExcerpt) HumanId() string {
+	return prefixRepoRef(e.RepoRef, e.Id.Human())
+}
+
+func prefixRepoRef(ref string, id string) string {
+	if ref == "" {
+		return id
+	}
+	return ref + repoRefSeparator + id
+}
+
+// QueryBugs return the excerpts of the bugs of all the repositories matching
+// the given query, in the order of the query. The query is parsed for each
+// repository, so that the saved queries of each repository apply.
+//
+// As the logical clocks of different repositories can't be compared, the bugs
+// are ordered by their timestamps across repositories.
+func (c *MultiRepoCache) QueryBugs(query string) ([]MultiRepoBugExcerpt, error) {
+	var result []MultiRepoBugExcerpt
+	var less func(a, b MultiRepoBugExcerpt) bool
+
+	for _, ref := range c.RepoRefs() {
+		r := c.repos[ref]
+
+		q, err := r.ParseQuery(query)
+		if err != nil {
+			return nil, err
+		}
+		if less == nil {
+			less = multiRepoLess(q)
+		}
+
+		page, err := r.QueryBugsPage(q, Pagination{})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, excerpt := range page.Excerpts {
+			result = append(result, MultiRepoBugExcerpt{RepoRef: ref, BugExcerpt: excerpt})
+		}
+	}
+
+	if less != nil {
+		// stable, to keep the order of each repository for equal timestamps
+		sort.SliceStable(result, func(i, j int) bool {
+			return less(result[i], result[j])
+		})
+	}
+
+	return result, nil
+}
+
+func multiRepoLess(q *Query) func(a, b MultiRepoBugExcerpt) bool {
+	var byOrder func(a, b MultiRepoBugExcerpt) bool
+
+	switch q.OrderBy {
+	case OrderById:
+		byOrder = func(a, b MultiRepoBugExcerpt) bool { return a.Id < b.Id }
+	case OrderByCreation:
+		byOrder = func(a, b MultiRepoBugExcerpt) bool { return a.CreateUnixTime < b.CreateUnixTime }
+	case OrderByEdit:
+		byOrder = func(a, b MultiRepoBugExcerpt) bool { return a.EditUnixTime < b.EditUnixTime }
+	case OrderByTitle:
+		byOrder = func(a, b MultiRepoBugExcerpt) bool {
+			return strings.ToLower(a.Title) < strings.ToLower(b.Title)
+		}
+	case OrderByComments:
+		byOrder = func(a, b MultiRepoBugExcerpt) bool { return a.LenComments < b.LenComments }
+	default:
+		panic("missing sort type")
+	}
+
+	if q.OrderDirection == OrderDescending {
+		ascending := byOrder
+		byOrder = func(a, b MultiRepoBugExcerpt) bool { return ascending(b, a) }
+	}
+
+	return byOrder
+}
+
+// ResolveBugPrefix retrieve a bug matching an id prefix, optionally prefixed
+// with the name of its repository as in "name/1a2b3c4". Without name, all the
+// repositories are searched. It fails if multiple bugs match.
+func (c *MultiRepoCache) ResolveBugPrefix(prefix string) (*RepoCache, *BugCache, error) {
+	refs := c.RepoRefs()
+
+	if split := strings.SplitN(prefix, repoRefSeparator, 2); len(split) == 2 {
+		refs = []string{split[0]}
+		prefix = split[1]
+	}
+
+	var matching []entity.Id
+	var matchingRepo *RepoCache
+	var matchingBug *BugCache
+
+	for _, ref := range refs {
+		r, err := c.ResolveRepo(ref)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		b, err := r.ResolveBugPrefix(prefix)
+		switch err := err.(type) {
+		case nil:
+			matching = append(matching, entity.Id(prefixRepoRef(ref, b.Id().String())))
+			matchingRepo, matchingBug = r, b
+		case *entity.ErrMultipleMatch:
+			for _, id := range err.Matching {
+				matching = append(matching, entity.Id(prefixRepoRef(ref, id.String())))
+			}
+		default:
+			if err != bug.ErrBugNotExist {
+				return nil, nil, err
+			}
+		}
+	}
+
+	if len(matching) > 1 {
+		return nil, nil, bug.NewErrMultipleMatchBug(matching)
+	}
+
+	if len(matching) == 0 {
+		return nil, nil, bug.ErrBugNotExist
+	}
+
+	return matchingRepo, matchingBug, nil
+}
+
 // Close will do anything that is needed to close the cache properly
 func (c *MultiRepoCache) Close() error {
 	for _, cachedRepo := range c.repos {
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestMultiRepoCache(t *testing.T) {
+	repoA := repository.CreateTestRepo(false)
+	repoB := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repoA, repoB)
+
+	multi := NewMultiRepoCache()
+	require.NoError(t, multi.RegisterRepository("a", repoA))
+	require.NoError(t, multi.RegisterRepository("b", repoB))
+	require.Error(t, multi.RegisterRepository("a", repoB))
+	require.Error(t, multi.RegisterRepository("a/b", repoB))
+	require.Equal(t, []string{"a", "b"}, multi.RepoRefs())
+
+	newBug := func(ref string, title string, unixTime int64) *BugCache {
+		r, err := multi.ResolveRepo(ref)
+		require.NoError(t, err)
+		iden, err := r.NewIdentity("René Descartes", "rene@descartes.fr")
+		require.NoError(t, err)
+		b, _, err := r.NewBugRaw(iden, unixTime, title, "message", nil, nil)
+		require.NoError(t, err)
+		return b
+	}
+
+	now := time.Now().Unix()
+	bugA1 := newBug("a", "first", now-30)
+	bugB := newBug("b", "second", now-20)
+	bugA2 := newBug("a", "third", now-10)
+
+	result, err := multi.QueryBugs("sort:creation-asc")
+	require.NoError(t, err)
+	require.Len(t, result, 3)
+	require.Equal(t, bugA1.Id(), result[0].Id)
+	require.Equal(t, "a", result[0].RepoRef)
+	require.Equal(t, bugB.Id(), result[1].Id)
+	require.Equal(t, "b", result[1].RepoRef)
+	require.Equal(t, bugA2.Id(), result[2].Id)
+	require.Equal(t, "b/"+bugB.Id().Human(), result[1].HumanId())
+
+	result, err = multi.QueryBugs("title:second")
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	require.Equal(t, "b/"+bugB.Id().String(), result[0].FullId())
+
+	// with or without the repository name
+	r, b, err := multi.ResolveBugPrefix("b/" + bugB.Id().Human())
+	require.NoError(t, err)
+	require.Equal(t, bugB.Id(), b.Id())
+	rB, err := multi.ResolveRepo("b")
+	require.NoError(t, err)
+	require.True(t, r == rB)
+
+	_, b, err = multi.ResolveBugPrefix(bugA2.Id().String())
+	require.NoError(t, err)
+	require.Equal(t, bugA2.Id(), b.Id())
+
+	_, _, err = multi.ResolveBugPrefix("a/" + bugB.Id().String())
+	require.Equal(t, bug.ErrBugNotExist, err)
+
+	_, _, err = multi.ResolveBugPrefix("")
+	require.IsType(t, &entity.ErrMultipleMatch{}, err)
+
+	_, _, err = multi.ResolveBugPrefix("c/" + bugB.Id().String())
+	require.Error(t, err)
+
+	require.NoError(t, multi.Close())
+}
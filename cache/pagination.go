@@ -0,0 +1,249 @@
+package cache
+
+import (
+	"container/heap"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+// Pagination select a window of a sorted query result
+type Pagination struct {
+	// Limit is the maximum number of results, 0 meaning no limit
+	Limit int
+
+	// Offset is the number of results to skip
+	Offset int
+
+	// After, if set, is the id of the last bug of the previous page. The page
+	// then start right after this bug, and Offset is counted from there.
+	After entity.Id
+
+	// Before, if set, is the id of the first bug of the next page. The page
+	// then end right before this bug.
+	Before entity.Id
+
+	// Last select the end of the window instead of its start: the Limit
+	// results just before Before, Offset being counted backward from there.
+	Last bool
+}
+
+// BugPage is a page of the result of a query
+type BugPage struct {
+	Excerpts []*BugExcerpt
+
+	// TotalCount is the number of bugs matching the query, regardless of the
+	// pagination
+	TotalCount int
+
+	// HasNextPage tell if more bugs follow this page
+	HasNextPage bool
+
+	// HasPreviousPage tell if more bugs precede this page
+	HasPreviousPage bool
+}
+
+// QueryBugsPage return a page of the excerpts of the bugs matching the given
+// query, in the order of the query.
+//
+// When a Limit is given, only the bugs up to the end of the page are sorted,
+// which avoid sorting the whole set of bugs to display the first results.
+func (c *RepoCache) QueryBugsPage(query *Query, page Pagination) (*BugPage, error) {
+	if query == nil {
+		query = NewQuery()
+	}
+
+	less := query.less()
+
+	// matching might need to resolve bugs, so the lock can't be held while
+	// filtering
+	c.muBug.RLock()
+	after, ok := c.cursorExcerpt(page.After)
+	if !ok {
+		c.muBug.RUnlock()
+		return nil, bug.ErrBugNotExist
+	}
+	before, ok := c.cursorExcerpt(page.Before)
+	if !ok {
+		c.muBug.RUnlock()
+		return nil, bug.ErrBugNotExist
+	}
+	excerpts := make([]*BugExcerpt, 0, len(c.bugExcerpts))
+	for _, excerpt := range c.bugExcerpts {
+		excerpts = append(excerpts, excerpt)
+	}
+	c.muBug.RUnlock()
+
+	// the page is selected from the end of the window by reversing the order
+	selectLess := less
+	if page.Last {
+		selectLess = func(a, b *BugExcerpt) bool { return less(b, a) }
+	}
+
+	result := &BugPage{}
+
+	// bugs in the window, in reverse order so that the root is the first to
+	// drop when exceeding the size of the page
+	kept := &excerptHeap{less: func(a, b *BugExcerpt) bool { return selectLess(b, a) }}
+	size := page.Offset + page.Limit
+	remaining := 0
+	outsideBefore := 0
+	outsideAfter := 0
+
+	for _, excerpt := range excerpts {
+		if !query.Match(c, excerpt) {
+			continue
+		}
+
+		result.TotalCount++
+
+		if after != nil && !less(after, excerpt) {
+			outsideBefore++
+			continue
+		}
+		if before != nil && !less(excerpt, before) {
+			outsideAfter++
+			continue
+		}
+
+		remaining++
+
+		if page.Limit <= 0 {
+			kept.items = append(kept.items, excerpt)
+			continue
+		}
+
+		if kept.Len() < size {
+			heap.Push(kept, excerpt)
+		} else if selectLess(excerpt, kept.items[0]) {
+			kept.items[0] = excerpt
+			heap.Fix(kept, 0)
+		}
+	}
+
+	sorted := kept.items
+	sort.Slice(sorted, func(i, j int) bool {
+		return selectLess(sorted[i], sorted[j])
+	})
+
+	if page.Offset < len(sorted) {
+		result.Excerpts = sorted[page.Offset:]
+	}
+
+	truncated := page.Limit > 0 && remaining > size
+	skipped := page.Offset > 0 && remaining > 0
+
+	if page.Last {
+		for i, j := 0, len(result.Excerpts)-1; i < j; i, j = i+1, j-1 {
+			result.Excerpts[i], result.Excerpts[j] = result.Excerpts[j], result.Excerpts[i]
+		}
+		result.HasPreviousPage = truncated || outsideBefore > 0
+		result.HasNextPage = skipped || outsideAfter > 0
+	} else {
+		result.HasNextPage = truncated || outsideAfter > 0
+		result.HasPreviousPage = skipped || outsideBefore > 0
+	}
+
+	return result, nil
+}
+
+// cursorExcerpt return the excerpt of a pagination cursor, nil if the cursor
+// is not set, or false if the bug doesn't exist. The bug lock must be held.
+func (c *RepoCache) cursorExcerpt(id entity.Id) (*BugExcerpt, bool) {
+	if id == "" {
+		return nil, true
+	}
+	excerpt, ok := c.bugExcerpts[id]
+	return excerpt, ok
+}
+
+const bugCursorPrefix = "bug:"
+
+// BugCursor return the opaque cursor of a bug in a paginated query result.
+// As it designate the bug itself rather than its position, a page read from
+// a cursor doesn't shift when bugs are created or removed in the meantime.
+func BugCursor(id entity.Id) string {
+	return base64.StdEncoding.EncodeToString([]byte(bugCursorPrefix + id.String()))
+}
+
+// ParseBugCursor return the id of the bug designated by a cursor returned by
+// BugCursor.
+func ParseBugCursor(cursor string) (entity.Id, error) {
+	decoded, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(decoded), bugCursorPrefix) {
+		return "", fmt.Errorf("invalid cursor %s", cursor)
+	}
+
+	id := entity.Id(strings.TrimPrefix(string(decoded), bugCursorPrefix))
+	if err := id.Validate(); err != nil {
+		return "", fmt.Errorf("invalid cursor %s", cursor)
+	}
+
+	return id, nil
+}
+
+// less return a strict ordering of excerpts for the query. Bugs equal for the
+// query's order are sorted by id, so that the pagination is stable.
+func (q *Query) less() func(a, b *BugExcerpt) bool {
+	var byOrder func(a, b *BugExcerpt) bool
+
+	switch q.OrderBy {
+	case OrderById:
+		byOrder = func(a, b *BugExcerpt) bool { return a.Id < b.Id }
+	case OrderByCreation:
+		byOrder = func(a, b *BugExcerpt) bool {
+			return BugsByCreationTime{a, b}.Less(0, 1)
+		}
+	case OrderByEdit:
+		byOrder = func(a, b *BugExcerpt) bool {
+			return BugsByEditTime{a, b}.Less(0, 1)
+		}
+	case OrderByTitle:
+		byOrder = func(a, b *BugExcerpt) bool {
+			return strings.ToLower(a.Title) < strings.ToLower(b.Title)
+		}
+	case OrderByComments:
+		byOrder = func(a, b *BugExcerpt) bool { return a.LenComments < b.LenComments }
+	default:
+		panic("missing sort type")
+	}
+
+	if q.OrderDirection == OrderDescending {
+		ascending := byOrder
+		byOrder = func(a, b *BugExcerpt) bool { return ascending(b, a) }
+	}
+
+	return func(a, b *BugExcerpt) bool {
+		if byOrder(a, b) {
+			return true
+		}
+		if byOrder(b, a) {
+			return false
+		}
+		return a.Id < b.Id
+	}
+}
+
+// excerptHeap implement heap.Interface over excerpts
+type excerptHeap struct {
+	items []*BugExcerpt
+	less  func(a, b *BugExcerpt) bool
+}
+
+func (h *excerptHeap) Len() int           { return len(h.items) }
+func (h *excerptHeap) Less(i, j int) bool { return h.less(h.items[i], h.items[j]) }
+func (h *excerptHeap) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+
+func (h *excerptHeap) Push(x interface{}) {
+	h.items = append(h.items, x.(*BugExcerpt))
+}
+
+func (h *excerptHeap) Pop() interface{} {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
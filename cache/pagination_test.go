@@ -0,0 +1,111 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestQueryBugsPage(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	require.NoError(t, cache.SetUserIdentity(iden))
+
+	for i := 0; i < 7; i++ {
+		_, _, err := cache.NewBug("title", "message")
+		require.NoError(t, err)
+	}
+
+	for _, sorting := range []string{"sort:id", "sort:creation-desc", "sort:edit", "sort:title", "sort:comments"} {
+		query, err := ParseQuery(sorting)
+		require.NoError(t, err)
+
+		expected := cache.QueryBugs(query)
+		require.Len(t, expected, 7)
+
+		// walk the pages with the cursor
+		var walked []entity.Id
+		var after entity.Id
+		for {
+			page, err := cache.QueryBugsPage(query, Pagination{Limit: 3, After: after})
+			require.NoError(t, err)
+			require.Equal(t, 7, page.TotalCount)
+
+			for _, excerpt := range page.Excerpts {
+				walked = append(walked, excerpt.Id)
+			}
+
+			if !page.HasNextPage {
+				break
+			}
+			after = page.Excerpts[len(page.Excerpts)-1].Id
+		}
+		require.Equal(t, expected, walked, sorting)
+
+		// walk back from the end
+		var walkedBack []entity.Id
+		var before entity.Id
+		for {
+			page, err := cache.QueryBugsPage(query, Pagination{Limit: 3, Before: before, Last: true})
+			require.NoError(t, err)
+			require.Equal(t, before != "", page.HasNextPage)
+
+			walkedBack = append(pageIds(page), walkedBack...)
+
+			if !page.HasPreviousPage {
+				break
+			}
+			before = page.Excerpts[0].Id
+		}
+		require.Equal(t, expected, walkedBack, sorting)
+
+		// a window between two cursors
+		page, err := cache.QueryBugsPage(query, Pagination{After: expected[1], Before: expected[5]})
+		require.NoError(t, err)
+		require.Equal(t, expected[2:5], pageIds(page))
+		require.True(t, page.HasPreviousPage)
+		require.True(t, page.HasNextPage)
+
+		// or with an offset
+		page, err = cache.QueryBugsPage(query, Pagination{Limit: 2, Offset: 4})
+		require.NoError(t, err)
+		require.True(t, page.HasNextPage)
+		require.Len(t, page.Excerpts, 2)
+		require.Equal(t, expected[4], page.Excerpts[0].Id)
+		require.Equal(t, expected[5], page.Excerpts[1].Id)
+	}
+
+	_, err = cache.QueryBugsPage(nil, Pagination{After: "unknown"})
+	require.Error(t, err)
+}
+
+func TestBugCursor(t *testing.T) {
+	id := entity.Id("0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")
+
+	parsed, err := ParseBugCursor(BugCursor(id))
+	require.NoError(t, err)
+	require.Equal(t, id, parsed)
+
+	_, err = ParseBugCursor("not a cursor")
+	require.Error(t, err)
+
+	_, err = ParseBugCursor(BugCursor("short"))
+	require.Error(t, err)
+}
+
+func pageIds(page *BugPage) []entity.Id {
+	ids := make([]entity.Id, len(page.Excerpts))
+	for i, excerpt := range page.Excerpts {
+		ids[i] = excerpt.Id
+	}
+	return ids
+}
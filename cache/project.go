@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"sort"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// configCurrentProject is the git config key holding the project the
+// repository is switched to, if any
+const configCurrentProject = "git-bug.project"
+
+// ProjectCount hold the number of open and closed bugs of a project
+type ProjectCount struct {
+	Project string
+	Open    int
+	Closed  int
+}
+
+// Total return the number of bugs of the project
+func (pc ProjectCount) Total() int {
+	return pc.Open + pc.Closed
+}
+
+// Projects return the projects in use with the number of open and closed bugs
+// they hold, sorted by name
+func (c *RepoCache) Projects() []ProjectCount {
+	counts := make(map[string]*ProjectCount)
+
+	c.muBug.RLock()
+	for _, excerpt := range c.bugExcerpts {
+		if excerpt.Project == "" {
+			continue
+		}
+
+		count, ok := counts[excerpt.Project]
+		if !ok {
+			count = &ProjectCount{Project: excerpt.Project}
+			counts[excerpt.Project] = count
+		}
+
+		switch excerpt.Status {
+		case bug.OpenStatus:
+			count.Open++
+		case bug.ClosedStatus:
+			count.Closed++
+		}
+	}
+	c.muBug.RUnlock()
+
+	result := make([]ProjectCount, 0, len(counts))
+	for _, count := range counts {
+		result = append(result, *count)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Project < result[j].Project
+	})
+
+	return result
+}
+
+// CurrentProject return the project the repository is switched to, or an
+// empty string if none
+func (c *RepoCache) CurrentProject() (string, error) {
+	project, err := c.repo.ReadConfigString(configCurrentProject)
+	if err == repository.ErrNoConfigEntry {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return project, nil
+}
+
+// SetCurrentProject switch the repository to a project. The queries are then
+// restricted to the bugs of this project, unless they select the projects
+// themselves. An empty project switch back to the whole repository.
+func (c *RepoCache) SetCurrentProject(project string) error {
+	if project == "" {
+		current, err := c.CurrentProject()
+		if err != nil || current == "" {
+			return err
+		}
+		return c.repo.RmConfigs(configCurrentProject)
+	}
+
+	if err := bug.ValidateProject(project); err != nil {
+		return err
+	}
+
+	return c.repo.StoreConfig(configCurrentProject, project)
+}
+
+// ScopeQuery restrict a query to the current project, if any, unless the
+// query already select the projects itself.
+func (c *RepoCache) ScopeQuery(q *Query) error {
+	if q.ExplicitProject || len(q.Project) > 0 {
+		return nil
+	}
+
+	project, err := c.CurrentProject()
+	if err != nil {
+		return err
+	}
+
+	if project != "" {
+		q.Project = append(q.Project, ProjectFilter(project))
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestProjects(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	require.NoError(t, cache.SetUserIdentity(iden))
+
+	newBug := func(project string, closed bool) {
+		b, _, err := cache.NewBug("title", "message")
+		require.NoError(t, err)
+		if project != "" {
+			_, err = b.SetProject(project)
+			require.NoError(t, err)
+		}
+		if closed {
+			_, err = b.Close()
+			require.NoError(t, err)
+		}
+		require.NoError(t, b.CommitAsNeeded())
+	}
+
+	newBug("frontend", false)
+	newBug("frontend", true)
+	newBug("backend", false)
+	newBug("", false)
+
+	require.Equal(t, []ProjectCount{
+		{Project: "backend", Open: 1},
+		{Project: "frontend", Open: 1, Closed: 1},
+	}, cache.Projects())
+
+	count := func(query string) int {
+		q, err := cache.ParseQuery(query)
+		require.NoError(t, err)
+		return len(cache.QueryBugs(q))
+	}
+
+	// the whole repository
+	current, err := cache.CurrentProject()
+	require.NoError(t, err)
+	require.Equal(t, "", current)
+	require.Equal(t, 4, count(""))
+	require.Equal(t, 2, count("project:Frontend"))
+	require.Equal(t, 1, count("no:project"))
+
+	require.Error(t, cache.SetCurrentProject("front end"))
+	require.NoError(t, cache.SetCurrentProject("frontend"))
+	current, err = cache.CurrentProject()
+	require.NoError(t, err)
+	require.Equal(t, "frontend", current)
+
+	// restricted to the current project, unless the query select the projects
+	require.Equal(t, 2, count(""))
+	require.Equal(t, 1, count("status:open"))
+	require.Equal(t, 1, count("project:backend"))
+	require.Equal(t, 1, count("no:project"))
+	require.Equal(t, 4, count("project:*"))
+
+	require.NoError(t, cache.SetCurrentProject(""))
+	require.NoError(t, cache.SetCurrentProject(""))
+	require.Equal(t, 4, count(""))
+}
@@ -2,7 +2,9 @@ package cache
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 	"unicode"
 )
 
@@ -10,6 +12,11 @@ type Query struct {
 	Filters
 	OrderBy
 	OrderDirection
+
+	// ExplicitProject tell that the query select the projects itself, with
+	// project:<name>, project:* or no:project, so that it's not restricted
+	// to the current project of the repository
+	ExplicitProject bool
 }
 
 // Return an identity query with default sorting (creation-desc)
@@ -22,7 +29,9 @@ func NewQuery() *Query {
 
 // ParseQuery parse a query DSL
 //
-// Ex: "status:open author:descartes sort:edit-asc"
+// Ex: "status:open author:descartes sort:edit-asc \"panic in parser\""
+//
+// Terms without qualifier are searched in the title and comments of the bugs.
 //
 // Supported filter qualifiers and syntax are described in docs/queries.md
 func ParseQuery(query string) (*Query, error) {
@@ -36,12 +45,18 @@ func ParseQuery(query string) (*Query, error) {
 	sortingDone := false
 
 	for _, field := range fields {
-		split := strings.Split(field, ":")
-		if len(split) != 2 {
+		// quoted or without qualifier, that's a full text search
+		if isQuoted(field) || !strings.Contains(field, ":") {
+			result.Search = append(result.Search, FullTextFilter(removeQuote(field)))
+			continue
+		}
+
+		split := strings.SplitN(field, ":", 2)
+		if split[1] == "" {
 			return nil, fmt.Errorf("can't parse \"%s\"", field)
 		}
 
-		qualifierName := split[0]
+		qualifierName := strings.ToLower(split[0])
 		qualifierQuery := removeQuote(split[1])
 
 		switch qualifierName {
@@ -64,6 +79,30 @@ func ParseQuery(query string) (*Query, error) {
 			f := ParticipantFilter(qualifierQuery)
 			result.Participant = append(result.Participant, f)
 
+		case "assignee":
+			f := AssigneeFilter(qualifierQuery)
+			result.Assignee = append(result.Assignee, f)
+
+		case "milestone":
+			f := MilestoneFilter(qualifierQuery)
+			result.Milestone = append(result.Milestone, f)
+
+		case "project":
+			result.ExplicitProject = true
+			// any project, or none
+			if qualifierQuery == "*" {
+				continue
+			}
+			f := ProjectFilter(qualifierQuery)
+			result.Project = append(result.Project, f)
+
+		case "created-before", "created-after", "edited-before", "edited-after":
+			t, err := parseTime(qualifierQuery, time.Now())
+			if err != nil {
+				return nil, err
+			}
+			result.Time = append(result.Time, timeFilter(qualifierName, t))
+
 		case "label":
 			f := LabelFilter(qualifierQuery)
 			result.Label = append(result.Label, f)
@@ -83,7 +122,7 @@ func ParseQuery(query string) (*Query, error) {
 				return nil, fmt.Errorf("multiple sorting")
 			}
 
-			err := result.parseSorting(qualifierQuery)
+			err := result.ParseSorting(qualifierQuery)
 			if err != nil {
 				return nil, err
 			}
@@ -118,19 +157,79 @@ func splitQuery(query string) []string {
 	return strings.FieldsFunc(query, f)
 }
 
+func isQuoted(field string) bool {
+	return len(field) >= 2 && field[0] == '"' && field[len(field)-1] == '"'
+}
+
 func removeQuote(field string) string {
-	if len(field) >= 2 {
-		if field[0] == '"' && field[len(field)-1] == '"' {
-			return field[1 : len(field)-1]
-		}
+	if isQuoted(field) {
+		return field[1 : len(field)-1]
 	}
 	return field
 }
 
+func timeFilter(qualifierName string, t time.Time) Filter {
+	switch qualifierName {
+	case "created-before":
+		return CreatedBeforeFilter(t)
+	case "created-after":
+		return CreatedAfterFilter(t)
+	case "edited-before":
+		return EditedBeforeFilter(t)
+	case "edited-after":
+		return EditedAfterFilter(t)
+	}
+	panic("unknown time qualifier " + qualifierName)
+}
+
+// ParseTime parse a time as in the time qualifiers of a query, either an
+// absolute date or a duration before now (ex: "2019-01-02", "90d")
+func ParseTime(query string) (time.Time, error) {
+	return parseTime(query, time.Now())
+}
+
+// parseTime parse either an absolute date (2006-01-02 or RFC3339) or a
+// duration before now, made of a number and a unit among h (hours),
+// d (days), w (weeks) and y (years), for example 90d.
+func parseTime(query string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", query, time.Local); err == nil {
+		return t, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, query); err == nil {
+		return t, nil
+	}
+
+	if len(query) >= 2 {
+		n, err := strconv.Atoi(query[:len(query)-1])
+		if err == nil && n >= 0 {
+			switch query[len(query)-1] {
+			case 'h':
+				return now.Add(-time.Duration(n) * time.Hour), nil
+			case 'd':
+				return now.AddDate(0, 0, -n), nil
+			case 'w':
+				return now.AddDate(0, 0, -7*n), nil
+			case 'y':
+				return now.AddDate(-n, 0, 0), nil
+			}
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("can't parse the date \"%s\": expected YYYY-MM-DD, RFC3339 or a duration like 90d", query)
+}
+
 func (q *Query) parseNoFilter(query string) error {
 	switch query {
 	case "label":
 		q.NoFilters = append(q.NoFilters, NoLabelFilter())
+	case "assignee":
+		q.NoFilters = append(q.NoFilters, NoAssigneeFilter())
+	case "milestone":
+		q.NoFilters = append(q.NoFilters, NoMilestoneFilter())
+	case "project":
+		q.ExplicitProject = true
+		q.NoFilters = append(q.NoFilters, NoProjectFilter())
 	default:
 		return fmt.Errorf("unknown \"no\" filter %s", query)
 	}
@@ -138,7 +237,9 @@ func (q *Query) parseNoFilter(query string) error {
 	return nil
 }
 
-func (q *Query) parseSorting(query string) error {
+// ParseSorting set the sorting of the query from a sorting expression, as in
+// the sort: qualifier (ex: "edit-desc")
+func (q *Query) ParseSorting(query string) error {
 	switch query {
 	// default ASC
 	case "id-desc":
@@ -164,9 +265,50 @@ func (q *Query) parseSorting(query string) error {
 		q.OrderBy = OrderByEdit
 		q.OrderDirection = OrderAscending
 
+	// default ASC
+	case "title-desc":
+		q.OrderBy = OrderByTitle
+		q.OrderDirection = OrderDescending
+	case "title", "title-asc":
+		q.OrderBy = OrderByTitle
+		q.OrderDirection = OrderAscending
+
+	// default DESC
+	case "comments", "comments-desc":
+		q.OrderBy = OrderByComments
+		q.OrderDirection = OrderDescending
+	case "comments-asc":
+		q.OrderBy = OrderByComments
+		q.OrderDirection = OrderAscending
+
 	default:
 		return fmt.Errorf("unknow sorting %s", query)
 	}
 
 	return nil
 }
+
+// Sorting return the sorting expression of the query, as accepted by
+// ParseSorting (ex: "edit-desc")
+func (q *Query) Sorting() string {
+	var by string
+	switch q.OrderBy {
+	case OrderById:
+		by = "id"
+	case OrderByCreation:
+		by = "creation"
+	case OrderByEdit:
+		by = "edit"
+	case OrderByTitle:
+		by = "title"
+	case OrderByComments:
+		by = "comments"
+	default:
+		panic("missing sort type")
+	}
+
+	if q.OrderDirection == OrderDescending {
+		return by + "-desc"
+	}
+	return by + "-asc"
+}
@@ -1,6 +1,9 @@
 package cache
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestQueryParse(t *testing.T) {
 
@@ -8,9 +11,13 @@ func TestQueryParse(t *testing.T) {
 		input string
 		ok    bool
 	}{
-		{"gibberish", false},
+		{"gibberish", true},
+		{`"panic in parser"`, true},
+		{`status:open "panic in parser" sort:edit`, true},
 
 		{"status:", false},
+		{"unknown:value", false},
+		{"STATUS:open", true},
 
 		{"status:open", true},
 		{"status:closed", true},
@@ -22,6 +29,25 @@ func TestQueryParse(t *testing.T) {
 		{"actor:bernhard", true},
 		{"participant:leonhard", true},
 
+		{"assignee:rene", true},
+		{"milestone:v1.0", true},
+		{"project:frontend", true},
+		{"project:*", true},
+		{"no:assignee", true},
+		{"no:milestone", true},
+		{"no:project", true},
+		{"no:unknown", false},
+
+		{"created-before:2019-01-02", true},
+		{"created-after:2019-01-02T15:04:05Z", true},
+		{"edited-before:90d", true},
+		{"edited-after:2w", true},
+		{"edited-after:12h", true},
+		{"created-before:1y", true},
+		{"created-before:yesterday", false},
+		{"edited-before:90", false},
+		{"edited-before:-3d", false},
+
 		{"label:hello", true},
 		{`label:"Good first issue"`, true},
 
@@ -29,6 +55,8 @@ func TestQueryParse(t *testing.T) {
 		{`title:"Bug titleTwo"`, true},
 
 		{"sort:edit", true},
+		{"sort:title-desc", true},
+		{"sort:comments", true},
 		{"sort:unknown", false},
 	}
 
@@ -39,3 +67,46 @@ func TestQueryParse(t *testing.T) {
 		}
 	}
 }
+
+func TestQuerySorting(t *testing.T) {
+	for _, sorting := range []string{"id-asc", "creation-desc", "edit-asc", "title-desc", "comments-desc"} {
+		query, err := ParseQuery("status:open sort:" + sorting)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %v", sorting, err)
+		}
+		if query.Sorting() != sorting {
+			t.Fatalf("Unexpected sorting, expected: %v, got: %v", sorting, query.Sorting())
+		}
+	}
+
+	// the default sorting
+	if NewQuery().Sorting() != "creation-desc" {
+		t.Fatalf("Unexpected default sorting: %v", NewQuery().Sorting())
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2019, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		input    string
+		expected time.Time
+	}{
+		{"2019-01-02", time.Date(2019, 1, 2, 0, 0, 0, 0, time.Local)},
+		{"2019-01-02T15:04:05Z", time.Date(2019, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"12h", time.Date(2019, 6, 15, 0, 0, 0, 0, time.UTC)},
+		{"90d", time.Date(2019, 3, 17, 12, 0, 0, 0, time.UTC)},
+		{"2w", time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)},
+		{"1y", time.Date(2018, 6, 15, 12, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		result, err := parseTime(test.input, now)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %v", test.input, err)
+		}
+		if !result.Equal(test.expected) {
+			t.Fatalf("Unexpected result for %s, expected: %v, got: %v", test.input, test.expected, result)
+		}
+	}
+}
@@ -8,8 +8,11 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"runtime"
 	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -25,9 +28,26 @@ import (
 const bugCacheFile = "bug-cache"
 const identityCacheFile = "identity-cache"
 
+// configMetadataKeys is the git config key holding a comma separated list of
+// key prefixes selecting the creation metadata kept in the bug excerpts. All
+// the creation metadata are kept if not set.
+const configMetadataKeys = "git-bug.cache.metadata-keys"
+
+// configMaxLoadedBugs is the git config key holding the maximum number of bugs
+// kept loaded in memory, 0 meaning no limit
+const configMaxLoadedBugs = "git-bug.cache.max-loaded-bugs"
+
+const defaultMaxLoadedBugs = 1000
+
 // 1: original format
 // 2: added cache for identities with a reference in the bug cache
-const formatVersion = 2
+// 3: added the heads of the bugs, for incremental updates
+// 4: added assignee, milestone and votes to the bug excerpt
+// 5: added the metadata of all the operations
+// 6: added the email to the identity excerpt
+// 7: added the merges of identities to the identity excerpt
+// 8: added the project to the bug excerpt
+const formatVersion = 8
 
 type ErrInvalidCacheFormat struct {
 	message string
@@ -42,7 +62,9 @@ var _ repository.RepoCommon = &RepoCache{}
 // RepoCache is a cache for a Repository. This cache has multiple functions:
 //
 // 1. After being loaded, a Bug is kept in memory in the cache, allowing for fast
-// 		access later.
+// 		access later. Only the most recently used bugs are kept, the others being
+// 		evicted once they have no pending operations. An evicted instance still
+// 		in use forward to the one loaded again.
 // 2. The cache maintain in memory and on disk a pre-digested excerpt for each bug,
 // 		allowing for fast querying the whole set of bugs without having to load
 //		them individually.
@@ -53,15 +75,33 @@ var _ repository.RepoCommon = &RepoCache{}
 // The cache also protect the on-disk data by locking the git repository for its
 // own usage, by writing a lock file. Of course, normal git operations are not
 // affected, only git-bug related one.
+//
+// The RepoCache is safe for concurrent use.
 type RepoCache struct {
 	// the underlying repo
 	repo repository.ClockedRepo
 
+	// guard the bug maps
+	muBug sync.RWMutex
 	// excerpt of bugs data for all bugs
 	bugExcerpts map[entity.Id]*BugExcerpt
 	// bug loaded in memory
 	bugs map[entity.Id]*BugCache
-
+	// loaded bugs, by order of use
+	loadedBugs *lruIdCache
+	// maximum number of loaded bugs, 0 meaning no limit
+	maxLoadedBugs int
+	// hash of the last commit of each bug, as known by the excerpts
+	bugHeads map[entity.Id]git.Hash
+	// metadata of the operations of each bug
+	bugMetadata map[entity.Id][]opMetadata
+	// index of bugMetadata, by key and value
+	metadataIndex metadataIndex
+	// number of open and closed bugs for each label, computed from the excerpts
+	labelCounts labelCounts
+
+	// guard the identity maps and the user identity
+	muIdentity sync.RWMutex
 	// excerpt of identities data for all identities
 	identitiesExcerpts map[entity.Id]*IdentityExcerpt
 	// identities loaded in memory
@@ -69,23 +109,38 @@ type RepoCache struct {
 
 	// the user identity's id, if known
 	userIdentityId entity.Id
+
+	// prefixes of the creation metadata keys kept in the bug excerpts, all of
+	// them if empty
+	metadataKeys []string
+
+	// subscribers to the bug changes
+	subscribers subscribers
+
+	// serialize the writes of the cache files
+	muBugFile      sync.Mutex
+	muIdentityFile sync.Mutex
 }
 
 func NewRepoCache(r repository.ClockedRepo) (*RepoCache, error) {
-	c := &RepoCache{
-		repo:       r,
-		bugs:       make(map[entity.Id]*BugCache),
-		identities: make(map[entity.Id]*IdentityCache),
+	c, err := newRepoCache(r)
+	if err != nil {
+		return nil, err
 	}
 
-	err := c.lock()
+	err = c.lock()
 	if err != nil {
 		return &RepoCache{}, err
 	}
 
 	err = c.load()
 	if err == nil {
-		return c, nil
+		// the refs might have been changed outside of git-bug (fetch, rebase,
+		// another tool ...), only the bugs that changed are read again
+		err = c.updateBugCache()
+		if err == nil {
+			return c, nil
+		}
 	}
 	if _, ok := err.(ErrInvalidCacheFormat); ok {
 		return nil, err
@@ -99,6 +154,73 @@ func NewRepoCache(r repository.ClockedRepo) (*RepoCache, error) {
 	return c, c.write()
 }
 
+// RebuildRepoCache create a RepoCache, discarding the on-disk cache and
+// building it again from scratch
+func RebuildRepoCache(r repository.ClockedRepo) (*RepoCache, error) {
+	c, err := newRepoCache(r)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.lock()
+	if err != nil {
+		return &RepoCache{}, err
+	}
+
+	err = c.buildCache()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, c.write()
+}
+
+func newRepoCache(r repository.ClockedRepo) (*RepoCache, error) {
+	c := &RepoCache{
+		repo:          r,
+		bugs:          make(map[entity.Id]*BugCache),
+		loadedBugs:    newLRUIdCache(),
+		maxLoadedBugs: defaultMaxLoadedBugs,
+		identities:    make(map[entity.Id]*IdentityCache),
+	}
+
+	max, err := r.ReadConfigString(configMaxLoadedBugs)
+	switch err {
+	case nil:
+		c.maxLoadedBugs, err = strconv.Atoi(max)
+		if err != nil || c.maxLoadedBugs < 0 {
+			return nil, fmt.Errorf("invalid %s: %s", configMaxLoadedBugs, max)
+		}
+	case repository.ErrNoConfigEntry:
+	default:
+		return nil, errors.Wrap(err, "can't read the cache configuration")
+	}
+
+	keys, err := r.ReadConfigString(configMetadataKeys)
+	switch err {
+	case nil:
+		for _, key := range strings.Split(keys, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				c.metadataKeys = append(c.metadataKeys, key)
+			}
+		}
+	case repository.ErrNoConfigEntry:
+	default:
+		return nil, errors.Wrap(err, "can't read the cache configuration")
+	}
+
+	return c, nil
+}
+
+// newBugExcerpt create the BugExcerpt of a bug, as configured for this cache
+func (c *RepoCache) newBugExcerpt(b bug.Interface, snap *bug.Snapshot) *BugExcerpt {
+	excerpt := NewBugExcerpt(b, snap)
+	if len(c.metadataKeys) > 0 {
+		excerpt.filterCreateMetadata(c.metadataKeys)
+	}
+	return excerpt
+}
+
 // GetPath returns the path to the repo.
 func (c *RepoCache) GetPath() string {
 	return c.repo.GetPath()
@@ -124,6 +246,23 @@ func (c *RepoCache) GetUserEmail() (string, error) {
 	return c.repo.GetUserEmail()
 }
 
+// LocalConfig give access to the configuration of the repository
+func (c *RepoCache) LocalConfig() repository.Config {
+	return c.repo.LocalConfig()
+}
+
+// GlobalConfig give access to the configuration of the user, shared by all
+// the repositories
+func (c *RepoCache) GlobalConfig() repository.Config {
+	return c.repo.GlobalConfig()
+}
+
+// AnyConfig give access to the configuration as git read it: a value from the
+// repository configuration, or else the user's, or else the system's
+func (c *RepoCache) AnyConfig() repository.ConfigRead {
+	return c.repo.AnyConfig()
+}
+
 // StoreConfig store a single key/value pair in the config of the repo
 func (c *RepoCache) StoreConfig(key string, value string) error {
 	return c.repo.StoreConfig(key, value)
@@ -149,10 +288,33 @@ func (c *RepoCache) RmConfigs(keyPrefix string) error {
 	return c.repo.RmConfigs(keyPrefix)
 }
 
+// Policy return the access control policy of the repository
+func (c *RepoCache) Policy() (*bug.Policy, error) {
+	return bug.LoadPolicy(c.repo)
+}
+
 func (c *RepoCache) lock() error {
-	lockPath := repoLockFilePath(c.repo)
+	return lockRepo(c.repo)
+}
 
-	err := repoIsAvailable(c.repo)
+// LockRepo take the lock of a repository without loading the cache, to work
+// on the raw bug data without racing with another git-bug process, even when
+// the cache can't be built. The returned function release the lock.
+func LockRepo(repo repository.Repo) (func() error, error) {
+	err := lockRepo(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		return os.Remove(repoLockFilePath(repo))
+	}, nil
+}
+
+func lockRepo(repo repository.Repo) error {
+	lockPath := repoLockFilePath(repo)
+
+	err := repoIsAvailable(repo)
 	if err != nil {
 		return err
 	}
@@ -172,10 +334,22 @@ func (c *RepoCache) lock() error {
 }
 
 func (c *RepoCache) Close() error {
+	c.muIdentity.Lock()
 	c.identities = make(map[entity.Id]*IdentityCache)
 	c.identitiesExcerpts = nil
+	c.muIdentity.Unlock()
+
+	c.muBug.Lock()
 	c.bugs = make(map[entity.Id]*BugCache)
+	c.loadedBugs = newLRUIdCache()
 	c.bugExcerpts = nil
+	c.labelCounts = nil
+	c.bugHeads = nil
+	c.bugMetadata = nil
+	c.metadataIndex = nil
+	c.muBug.Unlock()
+
+	c.closeSubscriptions()
 
 	lockPath := repoLockFilePath(c.repo)
 	return os.Remove(lockPath)
@@ -183,27 +357,86 @@ func (c *RepoCache) Close() error {
 
 // bugUpdated is a callback to trigger when the excerpt of a bug changed,
 // that is each time a bug is updated
-func (c *RepoCache) bugUpdated(id entity.Id) error {
-	b, ok := c.bugs[id]
-	if !ok {
-		panic("missing bug in the cache")
+func (c *RepoCache) bugUpdated(b *BugCache) error {
+	published, created, err := c.updateBugExcerpt(b)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case !published:
+		// not in the repository yet
+	case created:
+		c.notify(BugCreated, b.Id())
+	default:
+		c.notify(BugUpdated, b.Id())
 	}
+	return nil
+}
 
-	c.bugExcerpts[id] = NewBugExcerpt(b.bug, b.Snapshot())
+// updateBugExcerpt compute again the excerpt of a loaded bug, and write the
+// bug cache. A bug not published in the repository yet is left out, and
+// created tell if the bug just got one.
+func (c *RepoCache) updateBugExcerpt(b *BugCache) (published bool, created bool, err error) {
+	id := b.Id()
+
+	c.muBug.Lock()
+	if cached, ok := c.bugs[id]; ok {
+		// an evicted instance report its last changes, that the instance
+		// loaded again since hold as well
+		b = cached
+		c.loadedBugs.Add(id)
+	}
+	c.muBug.Unlock()
+
+	b.mu.RLock()
+	if !b.bug.Published() {
+		b.mu.RUnlock()
+		return false, false, nil
+	}
+	snap := b.bug.Snapshot()
+	excerpt := c.newBugExcerpt(b.bug, snap)
+	head := b.bug.LastCommit()
+	evicted := b.evicted
+	b.mu.RUnlock()
+	metadata := collectMetadata(snap)
+
+	c.muBug.Lock()
+	current, ok := c.bugExcerpts[id]
+	switch {
+	case evicted && !ok:
+		// the bug has been removed
+		c.muBug.Unlock()
+		return true, false, nil
+	case evicted && current.EditLamportTime > excerpt.EditLamportTime:
+		// a late report of an evicted instance
+		c.muBug.Unlock()
+		return true, false, nil
+	}
+	c.setBugExcerpt(id, excerpt)
+	c.bugHeads[id] = head
+	c.setBugMetadata(id, metadata)
+	c.muBug.Unlock()
 
 	// we only need to write the bug cache
-	return c.writeBugCache()
+	return true, !ok, c.writeBugCache()
 }
 
 // identityUpdated is a callback to trigger when the excerpt of an identity
 // changed, that is each time an identity is updated
 func (c *RepoCache) identityUpdated(id entity.Id) error {
+	c.muIdentity.RLock()
 	i, ok := c.identities[id]
+	c.muIdentity.RUnlock()
 	if !ok {
 		panic("missing identity in the cache")
 	}
 
-	c.identitiesExcerpts[id] = NewIdentityExcerpt(i.Identity)
+	excerpt := NewIdentityExcerpt(i.Identity)
+
+	c.muIdentity.Lock()
+	c.identitiesExcerpts[id] = excerpt
+	c.muIdentity.Unlock()
 
 	// we only need to write the identity cache
 	return c.writeIdentityCache()
@@ -230,6 +463,8 @@ func (c *RepoCache) loadBugCache() error {
 	aux := struct {
 		Version  uint
 		Excerpts map[entity.Id]*BugExcerpt
+		Heads    map[entity.Id]git.Hash
+		Metadata map[entity.Id][]opMetadata
 	}{}
 
 	err = decoder.Decode(&aux)
@@ -237,13 +472,23 @@ func (c *RepoCache) loadBugCache() error {
 		return err
 	}
 
-	if aux.Version != 2 {
-		return ErrInvalidCacheFormat{
-			message: fmt.Sprintf("unknown cache format version %v", aux.Version),
-		}
+	err = checkFormatVersion(aux.Version)
+	if err != nil {
+		return err
 	}
 
-	c.bugExcerpts = aux.Excerpts
+	if aux.Heads == nil {
+		aux.Heads = make(map[entity.Id]git.Hash)
+	}
+	if aux.Metadata == nil {
+		aux.Metadata = make(map[entity.Id][]opMetadata)
+	}
+
+	c.muBug.Lock()
+	c.resetBugExcerpts(aux.Excerpts)
+	c.bugHeads = aux.Heads
+	c.resetBugMetadata(aux.Metadata)
+	c.muBug.Unlock()
 	return nil
 }
 
@@ -266,13 +511,169 @@ func (c *RepoCache) loadIdentityCache() error {
 		return err
 	}
 
-	if aux.Version != 2 {
+	err = checkFormatVersion(aux.Version)
+	if err != nil {
+		return err
+	}
+
+	c.muIdentity.Lock()
+	c.identitiesExcerpts = aux.Excerpts
+	c.muIdentity.Unlock()
+	return nil
+}
+
+// checkFormatVersion tell if a cache file can be used. An outdated cache is
+// simply rebuilt, but a cache written by a more recent version of git-bug is
+// an error.
+func checkFormatVersion(version uint) error {
+	if version > formatVersion {
 		return ErrInvalidCacheFormat{
-			message: fmt.Sprintf("unknown cache format version %v", aux.Version),
+			message: fmt.Sprintf("unknown cache format version %v", version),
 		}
 	}
+	if version < formatVersion {
+		return fmt.Errorf("outdated cache format version %v", version)
+	}
+	return nil
+}
+
+// updateBugCache compare the heads of the bugs stored in the cache with the
+// refs of the repository, and only compile again the bugs that changed.
+func (c *RepoCache) updateBugCache() error {
+	changes, err := c.changedBugs()
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	removed := 0
+	for _, change := range changes {
+		if change.Type == BugRemoved {
+			removed++
+		}
+	}
+
+	_, _ = fmt.Fprintf(os.Stderr, "Updating bug cache (%d changed, %d removed)... ", len(changes)-removed, removed)
+
+	err = c.applyBugChanges(changes)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintln(os.Stderr, "Done.")
+
+	return c.writeBugCache()
+}
+
+// Refresh read again the bugs whose refs changed since the cache was loaded,
+// for example by a git fetch into the bug refs, and notify the subscribers.
+// The loaded copies of these bugs are dropped, unless they have pending
+// operations.
+func (c *RepoCache) Refresh() ([]BugChangeEvent, error) {
+	changes, err := c.changedBugs()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	err = c.applyBugChanges(changes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, change := range changes {
+		c.notify(change.Type, change.Id)
+	}
+
+	return changes, c.writeBugCache()
+}
+
+// changedBugs compare the heads of the bugs stored in the cache with the refs
+// of the repository, ordered by id
+func (c *RepoCache) changedBugs() ([]BugChangeEvent, error) {
+	heads, err := bug.ListLocalHeads(c.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []BugChangeEvent
+
+	c.muBug.RLock()
+	for id, hash := range heads {
+		previous, ok := c.bugHeads[id]
+		switch {
+		case !ok:
+			changes = append(changes, BugChangeEvent{Type: BugCreated, Id: id})
+		case previous != hash:
+			changes = append(changes, BugChangeEvent{Type: BugUpdated, Id: id})
+		}
+	}
+	for id := range c.bugExcerpts {
+		if _, ok := heads[id]; ok {
+			continue
+		}
+		// a bug created detached has no ref until published
+		if loaded, ok := c.bugs[id]; ok && !loaded.published() {
+			continue
+		}
+		changes = append(changes, BugChangeEvent{Type: BugRemoved, Id: id})
+	}
+	c.muBug.RUnlock()
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Id < changes[j].Id
+	})
+
+	return changes, nil
+}
+
+// applyBugChanges compile again the changed bugs and update the cache
+func (c *RepoCache) applyBugChanges(changes []BugChangeEvent) error {
+	var changed []entity.Id
+	for _, change := range changes {
+		if change.Type != BugRemoved {
+			changed = append(changed, change.Id)
+		}
+	}
+
+	compiled, err := c.compileBugs(changed)
+	if err != nil {
+		return err
+	}
+
+	c.muBug.Lock()
+	defer c.muBug.Unlock()
+
+	for id, excerpt := range compiled.excerpts {
+		c.setBugExcerpt(id, excerpt)
+		c.bugHeads[id] = compiled.heads[id]
+		c.setBugMetadata(id, compiled.metadata[id])
+		// a loaded copy is outdated, it's read again when needed
+		if loaded, ok := c.bugs[id]; ok && loaded.evict(false) {
+			delete(c.bugs, id)
+			c.loadedBugs.Remove(id)
+		}
+	}
+	for _, change := range changes {
+		if change.Type != BugRemoved {
+			continue
+		}
+		c.setBugExcerpt(change.Id, nil)
+		delete(c.bugHeads, change.Id)
+		c.setBugMetadata(change.Id, nil)
+		if loaded, ok := c.bugs[change.Id]; ok {
+			loaded.evict(true)
+		}
+		delete(c.bugs, change.Id)
+		c.loadedBugs.Remove(change.Id)
+	}
 
-	c.identitiesExcerpts = aux.Excerpts
 	return nil
 }
 
@@ -287,19 +688,28 @@ func (c *RepoCache) write() error {
 
 // write will serialize on disk the bug cache file
 func (c *RepoCache) writeBugCache() error {
+	c.muBugFile.Lock()
+	defer c.muBugFile.Unlock()
+
 	var data bytes.Buffer
 
+	c.muBug.RLock()
 	aux := struct {
 		Version  uint
 		Excerpts map[entity.Id]*BugExcerpt
+		Heads    map[entity.Id]git.Hash
+		Metadata map[entity.Id][]opMetadata
 	}{
 		Version:  formatVersion,
 		Excerpts: c.bugExcerpts,
+		Heads:    c.bugHeads,
+		Metadata: c.bugMetadata,
 	}
 
 	encoder := gob.NewEncoder(&data)
 
 	err := encoder.Encode(aux)
+	c.muBug.RUnlock()
 	if err != nil {
 		return err
 	}
@@ -319,8 +729,12 @@ func (c *RepoCache) writeBugCache() error {
 
 // write will serialize on disk the identity cache file
 func (c *RepoCache) writeIdentityCache() error {
+	c.muIdentityFile.Lock()
+	defer c.muIdentityFile.Unlock()
+
 	var data bytes.Buffer
 
+	c.muIdentity.RLock()
 	aux := struct {
 		Version  uint
 		Excerpts map[entity.Id]*IdentityExcerpt
@@ -332,6 +746,7 @@ func (c *RepoCache) writeIdentityCache() error {
 	encoder := gob.NewEncoder(&data)
 
 	err := encoder.Encode(aux)
+	c.muIdentity.RUnlock()
 	if err != nil {
 		return err
 	}
@@ -360,7 +775,7 @@ func identityCacheFilePath(repo repository.Repo) string {
 func (c *RepoCache) buildCache() error {
 	_, _ = fmt.Fprintf(os.Stderr, "Building identity cache... ")
 
-	c.identitiesExcerpts = make(map[entity.Id]*IdentityExcerpt)
+	identitiesExcerpts := make(map[entity.Id]*IdentityExcerpt)
 
 	allIdentities := identity.ReadAllLocalIdentities(c.repo)
 
@@ -369,33 +784,126 @@ func (c *RepoCache) buildCache() error {
 			return i.Err
 		}
 
-		c.identitiesExcerpts[i.Identity.Id()] = NewIdentityExcerpt(i.Identity)
+		identitiesExcerpts[i.Identity.Id()] = NewIdentityExcerpt(i.Identity)
 	}
 
+	c.muIdentity.Lock()
+	c.identitiesExcerpts = identitiesExcerpts
+	c.muIdentity.Unlock()
+
 	_, _ = fmt.Fprintln(os.Stderr, "Done.")
 
 	_, _ = fmt.Fprintf(os.Stderr, "Building bug cache... ")
 
-	c.bugExcerpts = make(map[entity.Id]*BugExcerpt)
+	ids, err := bug.ListLocalIds(c.repo)
+	if err != nil {
+		return err
+	}
+
+	compiled, err := c.compileBugs(ids)
+	if err != nil {
+		return err
+	}
+
+	c.muBug.Lock()
+	c.resetBugExcerpts(compiled.excerpts)
+	c.bugHeads = compiled.heads
+	c.resetBugMetadata(compiled.metadata)
+	c.muBug.Unlock()
+
+	_, _ = fmt.Fprintln(os.Stderr, "Done.")
+	return nil
+}
 
-	allBugs := bug.ReadAllLocalBugs(c.repo)
+// compiledBugs hold the cached data of a set of bugs
+type compiledBugs struct {
+	excerpts map[entity.Id]*BugExcerpt
+	heads    map[entity.Id]git.Hash
+	metadata map[entity.Id][]opMetadata
+}
 
-	for b := range allBugs {
-		if b.Err != nil {
-			return b.Err
+// compileBugs read and compile the given local bugs with a pool of workers,
+// and return their cached data
+func (c *RepoCache) compileBugs(ids []entity.Id) (*compiledBugs, error) {
+	type result struct {
+		excerpt  *BugExcerpt
+		head     git.Hash
+		metadata []opMetadata
+		err      error
+	}
+
+	idChan := make(chan entity.Id)
+	results := make(chan result)
+	done := make(chan struct{})
+	defer close(done)
+
+	var wg sync.WaitGroup
+	for w := 0; w < runtime.NumCPU(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range idChan {
+				var r result
+				b, err := bug.ReadLocalBug(c.repo, id)
+				if err != nil {
+					r.err = err
+				} else {
+					snap := b.Compile()
+					r.excerpt = c.newBugExcerpt(b, &snap)
+					r.head = b.LastCommit()
+					r.metadata = collectMetadata(&snap)
+				}
+
+				select {
+				case results <- r:
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(idChan)
+		for _, id := range ids {
+			select {
+			case idChan <- id:
+			case <-done:
+				return
+			}
 		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
 
-		snap := b.Bug.Compile()
-		c.bugExcerpts[b.Bug.Id()] = NewBugExcerpt(b.Bug, &snap)
+	compiled := &compiledBugs{
+		excerpts: make(map[entity.Id]*BugExcerpt, len(ids)),
+		heads:    make(map[entity.Id]git.Hash, len(ids)),
+		metadata: make(map[entity.Id][]opMetadata, len(ids)),
+	}
+	for r := range results {
+		if r.err != nil {
+			return nil, r.err
+		}
+		compiled.excerpts[r.excerpt.Id] = r.excerpt
+		compiled.heads[r.excerpt.Id] = r.head
+		compiled.metadata[r.excerpt.Id] = r.metadata
 	}
 
-	_, _ = fmt.Fprintln(os.Stderr, "Done.")
-	return nil
+	return compiled, nil
 }
 
 // ResolveBug retrieve a bug matching the exact given id
 func (c *RepoCache) ResolveBug(id entity.Id) (*BugCache, error) {
+	c.muBug.RLock()
 	cached, ok := c.bugs[id]
+	if ok {
+		c.loadedBugs.Add(id)
+	}
+	c.muBug.RUnlock()
 	if ok {
 		return cached, nil
 	}
@@ -405,14 +913,51 @@ func (c *RepoCache) ResolveBug(id entity.Id) (*BugCache, error) {
 		return nil, err
 	}
 
+	c.muBug.Lock()
+	defer c.muBug.Unlock()
+
+	// another reader might have loaded the bug in the meantime, there must
+	// be a single instance
+	if cached, ok := c.bugs[id]; ok {
+		c.loadedBugs.Add(id)
+		return cached, nil
+	}
+
 	cached = NewBugCache(c, b)
 	c.bugs[id] = cached
+	c.loadedBugs.Add(id)
+	c.evictIfNeeded()
 
 	return cached, nil
 }
 
+// evictIfNeeded unload the least recently used bugs when there is too many
+// of them in memory. Bugs with pending operations or not published yet are
+// never evicted, so they can't be lost. The muBug lock must be held.
+func (c *RepoCache) evictIfNeeded() {
+	if c.maxLoadedBugs <= 0 || c.loadedBugs.Len() <= c.maxLoadedBugs {
+		return
+	}
+
+	for _, id := range c.loadedBugs.OldestToNewest() {
+		if c.loadedBugs.Len() <= c.maxLoadedBugs {
+			return
+		}
+
+		if !c.bugs[id].evict(false) {
+			continue
+		}
+
+		delete(c.bugs, id)
+		c.loadedBugs.Remove(id)
+	}
+}
+
 // ResolveBugExcerpt retrieve a BugExcerpt matching the exact given id
 func (c *RepoCache) ResolveBugExcerpt(id entity.Id) (*BugExcerpt, error) {
+	c.muBug.RLock()
+	defer c.muBug.RUnlock()
+
 	e, ok := c.bugExcerpts[id]
 	if !ok {
 		return nil, bug.ErrBugNotExist
@@ -424,9 +969,23 @@ func (c *RepoCache) ResolveBugExcerpt(id entity.Id) (*BugExcerpt, error) {
 // ResolveBugPrefix retrieve a bug matching an id prefix. It fails if multiple
 // bugs match.
 func (c *RepoCache) ResolveBugPrefix(prefix string) (*BugCache, error) {
+	excerpt, err := c.ResolveBugExcerptPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.ResolveBug(excerpt.Id)
+}
+
+// ResolveBugExcerptPrefix retrieve a BugExcerpt matching an id prefix,
+// without loading the bug. It fails if multiple bugs match.
+func (c *RepoCache) ResolveBugExcerptPrefix(prefix string) (*BugExcerpt, error) {
 	// preallocate but empty
 	matching := make([]entity.Id, 0, 5)
 
+	c.muBug.RLock()
+	defer c.muBug.RUnlock()
+
 	for id := range c.bugExcerpts {
 		if id.HasPrefix(prefix) {
 			matching = append(matching, id)
@@ -441,7 +1000,7 @@ func (c *RepoCache) ResolveBugPrefix(prefix string) (*BugCache, error) {
 		return nil, bug.ErrBugNotExist
 	}
 
-	return c.ResolveBug(matching[0])
+	return c.bugExcerpts[matching[0]], nil
 }
 
 // ResolveBugCreateMetadata retrieve a bug that has the exact given metadata on
@@ -451,11 +1010,13 @@ func (c *RepoCache) ResolveBugCreateMetadata(key string, value string) (*BugCach
 	// preallocate but empty
 	matching := make([]entity.Id, 0, 5)
 
+	c.muBug.RLock()
 	for id, excerpt := range c.bugExcerpts {
 		if excerpt.CreateMetadata[key] == value {
 			matching = append(matching, id)
 		}
 	}
+	c.muBug.RUnlock()
 
 	if len(matching) > 1 {
 		return nil, bug.NewErrMultipleMatchBug(matching)
@@ -468,42 +1029,128 @@ func (c *RepoCache) ResolveBugCreateMetadata(key string, value string) (*BugCach
 	return c.ResolveBug(matching[0])
 }
 
-// QueryBugs return the id of all Bug matching the given Query
-func (c *RepoCache) QueryBugs(query *Query) []entity.Id {
-	if query == nil {
-		return c.AllBugsIds()
+// ResolveBugAnyMetadata retrieve a bug having an operation with the exact
+// given metadata. Unlike ResolveBugCreateMetadata, all the operations are
+// considered.
+func (c *RepoCache) ResolveBugAnyMetadata(key string, value string) (*BugCache, error) {
+	c.muBug.RLock()
+	hits := c.metadataIndex.lookup(key, value)
+	c.muBug.RUnlock()
+
+	// preallocate but empty
+	matching := make([]entity.Id, 0, 5)
+
+	for _, hit := range hits {
+		found := false
+		for _, id := range matching {
+			if id == hit.bugId {
+				found = true
+				break
+			}
+		}
+		if !found {
+			matching = append(matching, hit.bugId)
+		}
 	}
 
-	var filtered []*BugExcerpt
+	if len(matching) > 1 {
+		return nil, bug.NewErrMultipleMatchBug(matching)
+	}
 
-	for _, excerpt := range c.bugExcerpts {
-		if query.Match(c, excerpt) {
-			filtered = append(filtered, excerpt)
+	if len(matching) == 0 {
+		return nil, bug.ErrBugNotExist
+	}
+
+	return c.ResolveBug(matching[0])
+}
+
+// ResolveOperationAnyMetadata retrieve the operation having the exact given
+// metadata, in any bug, and the bug holding it
+func (c *RepoCache) ResolveOperationAnyMetadata(key string, value string) (*BugCache, entity.Id, error) {
+	c.muBug.RLock()
+	hits := c.metadataIndex.lookup(key, value)
+	c.muBug.RUnlock()
+
+	if len(hits) > 1 {
+		matching := make([]entity.Id, len(hits))
+		for i, hit := range hits {
+			matching[i] = hit.opId
 		}
+		return nil, "", bug.NewErrMultipleMatchOp(matching)
 	}
 
-	var sorter sort.Interface
+	if len(hits) == 0 {
+		return nil, "", ErrNoMatchingOp
+	}
 
-	switch query.OrderBy {
-	case OrderById:
-		sorter = BugsById(filtered)
-	case OrderByCreation:
-		sorter = BugsByCreationTime(filtered)
-	case OrderByEdit:
-		sorter = BugsByEditTime(filtered)
-	default:
-		panic("missing sort type")
+	b, err := c.ResolveBug(hits[0].bugId)
+	if err != nil {
+		return nil, "", err
 	}
 
-	if query.OrderDirection == OrderDescending {
-		sorter = sort.Reverse(sorter)
+	return b, hits[0].opId, nil
+}
+
+// setBugExcerpt replace the excerpt of a bug, or remove it if nil, and update
+// the label counts. The muBug lock must be held.
+func (c *RepoCache) setBugExcerpt(id entity.Id, excerpt *BugExcerpt) {
+	c.labelCounts.add(c.bugExcerpts[id], -1)
+
+	if excerpt == nil {
+		delete(c.bugExcerpts, id)
+		return
+	}
+
+	c.bugExcerpts[id] = excerpt
+	c.labelCounts.add(excerpt, 1)
+}
+
+// resetBugExcerpts replace the excerpts of all the bugs and compute again the
+// label counts. The muBug lock must be held.
+func (c *RepoCache) resetBugExcerpts(excerpts map[entity.Id]*BugExcerpt) {
+	c.bugExcerpts = excerpts
+	c.labelCounts = make(labelCounts)
+	for _, excerpt := range excerpts {
+		c.labelCounts.add(excerpt, 1)
 	}
+}
 
-	sort.Sort(sorter)
+// setBugMetadata replace the metadata of a bug and update the index. The
+// muBug lock must be held.
+func (c *RepoCache) setBugMetadata(id entity.Id, metadata []opMetadata) {
+	c.metadataIndex.remove(id, c.bugMetadata[id])
 
-	result := make([]entity.Id, len(filtered))
+	if len(metadata) == 0 {
+		delete(c.bugMetadata, id)
+		return
+	}
 
-	for i, val := range filtered {
+	c.bugMetadata[id] = metadata
+	c.metadataIndex.add(id, metadata)
+}
+
+// resetBugMetadata replace the metadata of all the bugs and rebuild the
+// index. The muBug lock must be held.
+func (c *RepoCache) resetBugMetadata(metadata map[entity.Id][]opMetadata) {
+	c.bugMetadata = metadata
+	c.metadataIndex = make(metadataIndex)
+	for id, entries := range metadata {
+		c.metadataIndex.add(id, entries)
+	}
+}
+
+// QueryBugs return the id of all Bug matching the given Query
+func (c *RepoCache) QueryBugs(query *Query) []entity.Id {
+	if query == nil {
+		return c.AllBugsIds()
+	}
+
+	// without cursor, this can't fail
+	page, _ := c.QueryBugsPage(query, Pagination{})
+
+	result := make([]entity.Id, len(page.Excerpts))
+
+	for i, val := range page.Excerpts {
 		result[i] = val.Id
 	}
 
@@ -512,6 +1159,9 @@ func (c *RepoCache) QueryBugs(query *Query) []entity.Id {
 
 // AllBugsIds return all known bug ids
 func (c *RepoCache) AllBugsIds() []entity.Id {
+	c.muBug.RLock()
+	defer c.muBug.RUnlock()
+
 	result := make([]entity.Id, len(c.bugExcerpts))
 
 	i := 0
@@ -529,30 +1179,87 @@ func (c *RepoCache) AllBugsIds() []entity.Id {
 // labels are defined in a configuration file. Until that, the default behavior
 // is to return the list of labels already used.
 func (c *RepoCache) ValidLabels() []bug.Label {
-	set := map[bug.Label]interface{}{}
-
-	for _, excerpt := range c.bugExcerpts {
-		for _, l := range excerpt.Labels {
-			set[l] = nil
-		}
+	c.muBug.RLock()
+	result := make([]bug.Label, 0, len(c.labelCounts))
+	for l := range c.labelCounts {
+		result = append(result, l)
 	}
+	c.muBug.RUnlock()
 
-	result := make([]bug.Label, len(set))
+	// Sort
+	sort.Slice(result, func(i, j int) bool {
+		return string(result[i]) < string(result[j])
+	})
 
-	i := 0
-	for l := range set {
-		result[i] = l
-		i++
+	return result
+}
+
+// Labels return the labels in use with the number of open and closed bugs
+// having them, sorted by label
+func (c *RepoCache) Labels() []LabelCount {
+	c.muBug.RLock()
+	result := make([]LabelCount, 0, len(c.labelCounts))
+	for _, count := range c.labelCounts {
+		result = append(result, *count)
 	}
+	c.muBug.RUnlock()
 
-	// Sort
 	sort.Slice(result, func(i, j int) bool {
-		return string(result[i]) < string(result[j])
+		return string(result[i].Label) < string(result[j].Label)
 	})
 
 	return result
 }
 
+// RenameLabel replace a label by another on all the bugs having it, with an
+// operation on each bug, and return the number of bugs changed. The bugs
+// already having the new label only lose the old one.
+func (c *RepoCache) RenameLabel(from string, to string) (int, error) {
+	if err := bug.Label(to).Validate(); err != nil {
+		return 0, errors.Wrap(err, "invalid label")
+	}
+
+	if from == to {
+		return 0, fmt.Errorf("the new label is the same")
+	}
+
+	var ids []entity.Id
+	c.muBug.RLock()
+	for id, excerpt := range c.bugExcerpts {
+		for _, l := range excerpt.Labels {
+			if string(l) == from {
+				ids = append(ids, id)
+				break
+			}
+		}
+	}
+	c.muBug.RUnlock()
+
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	for i, id := range ids {
+		b, err := c.ResolveBug(id)
+		if err != nil {
+			return i, err
+		}
+
+		// an already set label is only reported, not an error
+		_, _, err = b.ChangeLabels([]string{to}, []string{from})
+		if err != nil {
+			return i, err
+		}
+
+		err = b.Commit()
+		if err != nil {
+			return i, err
+		}
+	}
+
+	return len(ids), nil
+}
+
 // NewBug create a new bug
 // The new bug is written in the repository (commit)
 func (c *RepoCache) NewBug(title string, message string) (*BugCache, *bug.CreateOperation, error) {
@@ -574,6 +1281,18 @@ func (c *RepoCache) NewBugWithFiles(title string, message string, files []git.Ha
 // well as metadata for the Create operation.
 // The new bug is written in the repository (commit)
 func (c *RepoCache) NewBugRaw(author *IdentityCache, unixTime int64, title string, message string, files []git.Hash, metadata map[string]string) (*BugCache, *bug.CreateOperation, error) {
+	return c.newBugRaw(author, unixTime, title, message, files, metadata, false)
+}
+
+// NewBugRawDetached is like NewBugRaw, except that the ref of the bug is only
+// created with its next commit, see bug.Bug.CommitDetached. This way, a bug
+// built over several steps never appears half written in the repository.
+// Until then, the bug lives in the cache only and is lost if the process stop.
+func (c *RepoCache) NewBugRawDetached(author *IdentityCache, unixTime int64, title string, message string, files []git.Hash, metadata map[string]string) (*BugCache, *bug.CreateOperation, error) {
+	return c.newBugRaw(author, unixTime, title, message, files, metadata, true)
+}
+
+func (c *RepoCache) newBugRaw(author *IdentityCache, unixTime int64, title string, message string, files []git.Hash, metadata map[string]string, detached bool) (*BugCache, *bug.CreateOperation, error) {
 	b, op, err := bug.CreateWithFiles(author.Identity, unixTime, title, message, files)
 	if err != nil {
 		return nil, nil, err
@@ -583,27 +1302,130 @@ func (c *RepoCache) NewBugRaw(author *IdentityCache, unixTime int64, title strin
 		op.SetMetadata(key, value)
 	}
 
-	err = b.Commit(c.repo)
+	if detached {
+		err = b.CommitDetached(c.repo)
+	} else {
+		err = b.Commit(c.repo)
+	}
 	if err != nil {
 		return nil, nil, err
 	}
 
+	c.muBug.Lock()
 	if _, has := c.bugs[b.Id()]; has {
+		c.muBug.Unlock()
 		return nil, nil, fmt.Errorf("bug %s already exist in the cache", b.Id())
 	}
 
 	cached := NewBugCache(c, b)
 	c.bugs[b.Id()] = cached
+	c.loadedBugs.Add(b.Id())
+	c.muBug.Unlock()
 
-	// force the write of the excerpt
-	err = c.bugUpdated(b.Id())
-	if err != nil {
-		return nil, nil, err
+	// a detached bug get its excerpt and is announced once published
+	if !detached {
+		// force the write of the excerpt, before the bug can be evicted
+		_, _, err = c.updateBugExcerpt(cached)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		c.notify(BugCreated, cached.Id())
 	}
 
+	c.muBug.Lock()
+	c.evictIfNeeded()
+	c.muBug.Unlock()
+
 	return cached, op, nil
 }
 
+// CommitBugs commit as needed several bugs at once, batching the writes to
+// the repository
+func (c *RepoCache) CommitBugs(bugs []*BugCache) error {
+	// always locked in the same order, to not deadlock with another commit,
+	// and only once each
+	var sorted []*BugCache
+	seen := make(map[*BugCache]struct{})
+	for _, b := range bugs {
+		if _, ok := seen[b]; !ok {
+			seen[b] = struct{}{}
+			sorted = append(sorted, b)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Id() < sorted[j].Id()
+	})
+
+	raw := make([]*bug.WithSnapshot, len(sorted))
+	for i, b := range sorted {
+		b.mu.Lock()
+		raw[i] = b.bug
+	}
+
+	err := bug.CommitAllWithSnapshot(c.repo, raw)
+
+	for _, b := range sorted {
+		b.mu.Unlock()
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, b := range sorted {
+		if err := b.notifyUpdated(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveBug remove a local bug from the repository and the cache, along with
+// its remote-tracking refs. The bug comes back with the next pull if a remote
+// still has it.
+func (c *RepoCache) RemoveBug(id entity.Id) error {
+	c.muBug.Lock()
+
+	// a bug not published yet only exist in the cache, its commits are
+	// garbage collected
+	if loaded, ok := c.bugs[id]; ok && !loaded.published() {
+		loaded.evict(true)
+		delete(c.bugs, id)
+		c.loadedBugs.Remove(id)
+		c.muBug.Unlock()
+		return nil
+	}
+
+	if _, ok := c.bugExcerpts[id]; !ok {
+		c.muBug.Unlock()
+		return bug.ErrBugNotExist
+	}
+
+	err := bug.RemoveLocalBug(c.repo, id)
+	if err == nil {
+		err = bug.RemoveRemoteTrackingBug(c.repo, id)
+	}
+	if err != nil {
+		c.muBug.Unlock()
+		return err
+	}
+
+	if loaded, ok := c.bugs[id]; ok {
+		loaded.evict(true)
+	}
+	delete(c.bugs, id)
+	c.loadedBugs.Remove(id)
+	c.setBugExcerpt(id, nil)
+	delete(c.bugHeads, id)
+	c.setBugMetadata(id, nil)
+	c.muBug.Unlock()
+
+	c.notify(BugRemoved, id)
+
+	return c.writeBugCache()
+}
+
 // Fetch retrieve updates from a remote
 // This does not change the local bugs or identities state
 func (c *RepoCache) Fetch(remote string) (string, error) {
@@ -620,8 +1442,39 @@ func (c *RepoCache) Fetch(remote string) (string, error) {
 	return stdout1 + stdout2, nil
 }
 
+// FetchBugs retrieve updates of the given bugs from a remote, along with all
+// the identities
+// This does not change the local bugs or identities state
+func (c *RepoCache) FetchBugs(remote string, ids []entity.Id) (string, error) {
+	stdout1, err := identity.Fetch(c.repo, remote)
+	if err != nil {
+		return stdout1, err
+	}
+
+	stdout2, err := bug.FetchBugs(c.repo, remote, ids)
+	if err != nil {
+		return stdout2, err
+	}
+
+	return stdout1 + stdout2, nil
+}
+
 // MergeAll will merge all the available remote bug and identities
 func (c *RepoCache) MergeAll(remote string) <-chan entity.MergeResult {
+	return c.merge(remote, func() <-chan entity.MergeResult {
+		return bug.MergeAll(c.repo, remote)
+	})
+}
+
+// MergeBugs will merge the given remote bugs, along with all the identities
+func (c *RepoCache) MergeBugs(remote string, ids []entity.Id) <-chan entity.MergeResult {
+	return c.merge(remote, func() <-chan entity.MergeResult {
+		return bug.MergeBugs(c.repo, remote, ids)
+	})
+}
+
+// merge all the remote identities, then the bugs merged by mergeBugs
+func (c *RepoCache) merge(remote string, mergeBugs func() <-chan entity.MergeResult) <-chan entity.MergeResult {
 	out := make(chan entity.MergeResult)
 
 	// Intercept merge results to update the cache properly
@@ -639,11 +1492,13 @@ func (c *RepoCache) MergeAll(remote string) <-chan entity.MergeResult {
 			switch result.Status {
 			case entity.MergeStatusNew, entity.MergeStatusUpdated:
 				i := result.Entity.(*identity.Identity)
+				c.muIdentity.Lock()
 				c.identitiesExcerpts[result.Id] = NewIdentityExcerpt(i)
+				c.muIdentity.Unlock()
 			}
 		}
 
-		results = bug.MergeAll(c.repo, remote)
+		results = mergeBugs()
 		for result := range results {
 			out <- result
 
@@ -655,7 +1510,24 @@ func (c *RepoCache) MergeAll(remote string) <-chan entity.MergeResult {
 			case entity.MergeStatusNew, entity.MergeStatusUpdated:
 				b := result.Entity.(*bug.Bug)
 				snap := b.Compile()
-				c.bugExcerpts[result.Id] = NewBugExcerpt(b, &snap)
+				excerpt := c.newBugExcerpt(b, &snap)
+				metadata := collectMetadata(&snap)
+				c.muBug.Lock()
+				c.setBugExcerpt(result.Id, excerpt)
+				c.bugHeads[result.Id] = b.LastCommit()
+				c.setBugMetadata(result.Id, metadata)
+				// a loaded copy is outdated, it's read again when needed
+				if loaded, ok := c.bugs[result.Id]; ok && loaded.evict(false) {
+					delete(c.bugs, result.Id)
+					c.loadedBugs.Remove(result.Id)
+				}
+				c.muBug.Unlock()
+
+				if result.Status == entity.MergeStatusNew {
+					c.notify(BugCreated, result.Id)
+				} else {
+					c.notify(BugUpdated, result.Id)
+				}
 			}
 		}
 
@@ -685,6 +1557,50 @@ func (c *RepoCache) Push(remote string) (string, error) {
 	return stdout1 + stdout2, nil
 }
 
+// PushBugs update a remote with the local changes of the given bugs, along
+// with all the identities
+func (c *RepoCache) PushBugs(remote string, ids []entity.Id) (string, error) {
+	stdout1, err := identity.Push(c.repo, remote)
+	if err != nil {
+		return stdout1, err
+	}
+
+	stdout2, err := bug.PushBugs(c.repo, remote, ids)
+	if err != nil {
+		return stdout2, err
+	}
+
+	return stdout1 + stdout2, nil
+}
+
+// BugsAhead return the ids of the local bugs having changes that were not
+// fetched from the remote, either because they are new or because they were
+// edited locally since. After a merge, these are the bugs a push would update.
+func (c *RepoCache) BugsAhead(remote string) ([]entity.Id, error) {
+	remoteHeads, err := bug.ListRemoteHeads(c.repo, remote)
+	if err != nil {
+		return nil, err
+	}
+
+	localHeads, err := bug.ListLocalHeads(c.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []entity.Id
+	for id, hash := range localHeads {
+		if remoteHeads[id] != hash {
+			result = append(result, id)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i] < result[j]
+	})
+
+	return result, nil
+}
+
 // Pull will do a Fetch + MergeAll
 // This function will return an error if a merge fail
 func (c *RepoCache) Pull(remote string) error {
@@ -714,8 +1630,6 @@ func repoLockFilePath(repo repository.Repo) string {
 // corresponding process is not there anymore.
 // If no error is returned, the repo is free to edit.
 func repoIsAvailable(repo repository.Repo) error {
-	lockPath := repoLockFilePath(repo)
-
 	// Todo: this leave way for a racey access to the repo between the test
 	// if the file exist and the actual write. It's probably not a problem in
 	// practice because using a repository will be done from user interaction
@@ -727,51 +1641,104 @@ func repoIsAvailable(repo repository.Repo) error {
 	// computer. Should add a configuration that prevent the cleaning of the
 	// lock file
 
+	pid, stale, err := repoLockHolder(repo)
+	if err == ErrNotLocked {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !stale {
+		return fmt.Errorf("the repository you want to access is already locked by the process pid %d", pid)
+	}
+
+	// The lock file is just laying there after a crash, clean it
+	_, _ = fmt.Fprintln(os.Stderr, "A lock file is present but the corresponding process is not, removing it.")
+
+	return os.Remove(repoLockFilePath(repo))
+}
+
+var ErrNotLocked = errors.New("the repository is not locked")
+
+// lockStartTimeSlack is how much later than the lock file a process holding
+// the lock can seem to have started
+const lockStartTimeSlack = time.Hour
+
+// repoLockHolder return the pid of the process holding the lock of the
+// repository, and if this lock is stale. A lock is stale when the process is
+// gone, when its pid has been reused by a process started after the lock was
+// taken, or when the lock file is garbage.
+func repoLockHolder(repo repository.Repo) (pid int, stale bool, err error) {
+	lockPath := repoLockFilePath(repo)
+
 	f, err := os.Open(lockPath)
+	if os.IsNotExist(err) {
+		return 0, false, ErrNotLocked
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	defer f.Close()
 
-	if err != nil && !os.IsNotExist(err) {
-		return err
+	info, err := f.Stat()
+	if err != nil {
+		return 0, false, err
 	}
 
-	if err == nil {
-		// lock file already exist
-		buf, err := ioutil.ReadAll(io.LimitReader(f, 10))
-		if err != nil {
-			return err
-		}
-		if len(buf) == 10 {
-			return fmt.Errorf("the lock file should be < 10 bytes")
-		}
+	buf, err := ioutil.ReadAll(io.LimitReader(f, 10))
+	if err != nil {
+		return 0, false, err
+	}
+	if len(buf) == 10 {
+		return 0, true, nil
+	}
 
-		pid, err := strconv.Atoi(string(buf))
-		if err != nil {
-			return err
-		}
+	pid, err = strconv.Atoi(string(buf))
+	if err != nil {
+		return 0, true, nil
+	}
 
-		if process.IsRunning(pid) {
-			return fmt.Errorf("the repository you want to access is already locked by the process pid %d", pid)
-		}
+	if !process.IsRunning(pid) {
+		return pid, true, nil
+	}
 
-		// The lock file is just laying there after a crash, clean it
+	// a running git-bug is the holder, not a process reusing its pid
+	if process.IsGitBug(pid) {
+		return pid, false, nil
+	}
 
-		fmt.Println("A lock file is present but the corresponding process is not, removing it.")
-		err = f.Close()
-		if err != nil {
-			return err
-		}
+	// allow a large slack, as the start time is derived from the boot time,
+	// which shift when the wall clock is stepped after the boot
+	start, ok := process.StartTime(pid)
+	if ok && start.After(info.ModTime().Add(lockStartTimeSlack)) {
+		return pid, true, nil
+	}
 
-		err = os.Remove(lockPath)
-		if err != nil {
-			return err
-		}
+	return pid, false, nil
+}
+
+// UnlockRepo remove the lock of a repository left behind by a crashed
+// git-bug. Unless force is set, a lock held by a running process is not
+// removed.
+func UnlockRepo(repo repository.Repo, force bool) error {
+	pid, stale, err := repoLockHolder(repo)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	if !stale && !force {
+		return fmt.Errorf("the repository is locked by the running process pid %d", pid)
+	}
+
+	return os.Remove(repoLockFilePath(repo))
 }
 
 // ResolveIdentity retrieve an identity matching the exact given id
 func (c *RepoCache) ResolveIdentity(id entity.Id) (*IdentityCache, error) {
+	c.muIdentity.RLock()
 	cached, ok := c.identities[id]
+	c.muIdentity.RUnlock()
 	if ok {
 		return cached, nil
 	}
@@ -781,6 +1748,15 @@ func (c *RepoCache) ResolveIdentity(id entity.Id) (*IdentityCache, error) {
 		return nil, err
 	}
 
+	c.muIdentity.Lock()
+	defer c.muIdentity.Unlock()
+
+	// another reader might have loaded the identity in the meantime, there
+	// must be a single instance
+	if cached, ok := c.identities[id]; ok {
+		return cached, nil
+	}
+
 	cached = NewIdentityCache(c, i)
 	c.identities[id] = cached
 
@@ -789,6 +1765,9 @@ func (c *RepoCache) ResolveIdentity(id entity.Id) (*IdentityCache, error) {
 
 // ResolveIdentityExcerpt retrieve a IdentityExcerpt matching the exact given id
 func (c *RepoCache) ResolveIdentityExcerpt(id entity.Id) (*IdentityExcerpt, error) {
+	c.muIdentity.RLock()
+	defer c.muIdentity.RUnlock()
+
 	e, ok := c.identitiesExcerpts[id]
 	if !ok {
 		return nil, identity.ErrIdentityNotExist
@@ -803,11 +1782,13 @@ func (c *RepoCache) ResolveIdentityPrefix(prefix string) (*IdentityCache, error)
 	// preallocate but empty
 	matching := make([]entity.Id, 0, 5)
 
+	c.muIdentity.RLock()
 	for id := range c.identitiesExcerpts {
 		if id.HasPrefix(prefix) {
 			matching = append(matching, id)
 		}
 	}
+	c.muIdentity.RUnlock()
 
 	if len(matching) > 1 {
 		return nil, identity.NewErrMultipleMatch(matching)
@@ -826,11 +1807,20 @@ func (c *RepoCache) ResolveIdentityImmutableMetadata(key string, value string) (
 	// preallocate but empty
 	matching := make([]entity.Id, 0, 5)
 
+	// a match merged into another identity resolve to the latter
+	seen := make(map[entity.Id]bool)
+
+	c.muIdentity.RLock()
 	for id, i := range c.identitiesExcerpts {
 		if i.ImmutableMetadata[key] == value {
-			matching = append(matching, id)
+			target := c.mergedIdentityId(id)
+			if !seen[target] {
+				seen[target] = true
+				matching = append(matching, target)
+			}
 		}
 	}
+	c.muIdentity.RUnlock()
 
 	if len(matching) > 1 {
 		return nil, identity.NewErrMultipleMatch(matching)
@@ -843,8 +1833,79 @@ func (c *RepoCache) ResolveIdentityImmutableMetadata(key string, value string) (
 	return c.ResolveIdentity(matching[0])
 }
 
+// MergedIdentityId return the id of the identity the given identity has been
+// merged into, following the successive merges, or the id itself if the
+// identity has not been merged
+func (c *RepoCache) MergedIdentityId(id entity.Id) entity.Id {
+	c.muIdentity.RLock()
+	defer c.muIdentity.RUnlock()
+
+	return c.mergedIdentityId(id)
+}
+
+// mergedIdentityId is MergedIdentityId, with c.muIdentity already held
+func (c *RepoCache) mergedIdentityId(id entity.Id) entity.Id {
+	// bounded, as concurrent merges on different repositories can make a cycle
+	for n := 0; n < len(c.identitiesExcerpts); n++ {
+		excerpt, ok := c.identitiesExcerpts[id]
+		if !ok || excerpt.MergedInto == "" {
+			return id
+		}
+		id = excerpt.MergedInto
+	}
+	return id
+}
+
+// MergeIdentity record that two identities represent the same person, like an
+// identity created by a bridge import and the user's own: from is merged into
+// into. Afterward, the identities found by metadata, as done by the bridges,
+// resolve to into, and the queries on the user identity also match from.
+func (c *RepoCache) MergeIdentity(from *IdentityCache, into *IdentityCache) error {
+	if from.MergedInto() != "" {
+		return fmt.Errorf("identity %s is already merged into %s", from.Id().Human(), from.MergedInto().Human())
+	}
+
+	if c.MergedIdentityId(into.Id()) == from.Id() {
+		return fmt.Errorf("identity %s is already merged into %s", into.Id().Human(), from.Id().Human())
+	}
+
+	err := from.Identity.MergeInto(into.Id())
+	if err != nil {
+		return err
+	}
+
+	return from.Commit()
+}
+
+// AttachIdentity record that the identity is the one of some accounts of
+// other trackers, as immutable metadata matched by the bridges when importing
+func (c *RepoCache) AttachIdentity(i *IdentityCache, metadata map[string]string) error {
+	for key, value := range metadata {
+		other, err := c.ResolveIdentityImmutableMetadata(key, value)
+		if err == identity.ErrIdentityNotExist {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if other.Id() != i.Id() {
+			return fmt.Errorf("%s %s is already the identity %s, merge it instead", key, value, other.Id().Human())
+		}
+	}
+
+	err := i.Identity.Attach(metadata)
+	if err != nil {
+		return err
+	}
+
+	return i.CommitAsNeeded()
+}
+
 // AllIdentityIds return all known identity ids
 func (c *RepoCache) AllIdentityIds() []entity.Id {
+	c.muIdentity.RLock()
+	defer c.muIdentity.RUnlock()
+
 	result := make([]entity.Id, len(c.identitiesExcerpts))
 
 	i := 0
@@ -862,6 +1923,9 @@ func (c *RepoCache) SetUserIdentity(i *IdentityCache) error {
 		return err
 	}
 
+	c.muIdentity.Lock()
+	defer c.muIdentity.Unlock()
+
 	// Make sure that everything is fine
 	if _, ok := c.identities[i.Id()]; !ok {
 		panic("SetUserIdentity while the identity is not from the cache, something is wrong")
@@ -873,25 +1937,57 @@ func (c *RepoCache) SetUserIdentity(i *IdentityCache) error {
 }
 
 func (c *RepoCache) GetUserIdentity() (*IdentityCache, error) {
+	c.muIdentity.RLock()
 	if c.userIdentityId != "" {
 		i, ok := c.identities[c.userIdentityId]
-		if ok {
+		// a merge pulled in the meantime is reported by the identity package
+		if ok && i.MergedInto() == "" {
+			c.muIdentity.RUnlock()
 			return i, nil
 		}
 	}
+	c.muIdentity.RUnlock()
 
 	i, err := identity.GetUserIdentity(c.repo)
 	if err != nil {
 		return nil, err
 	}
 
-	cached := NewIdentityCache(c, i)
-	c.identities[i.Id()] = cached
+	c.muIdentity.Lock()
+	defer c.muIdentity.Unlock()
+
+	cached, ok := c.identities[i.Id()]
+	if !ok {
+		cached = NewIdentityCache(c, i)
+		c.identities[i.Id()] = cached
+	}
 	c.userIdentityId = i.Id()
 
 	return cached, nil
 }
 
+// OwnIdentities return the identities the user has created or adopted in
+// the repository, among which the user identity is chosen
+func (c *RepoCache) OwnIdentities() ([]*IdentityExcerpt, error) {
+	ids, err := identity.OwnIdentities(c.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	c.muIdentity.RLock()
+	defer c.muIdentity.RUnlock()
+
+	result := make([]*IdentityExcerpt, 0, len(ids))
+	for _, id := range ids {
+		// an identity removed from the repository is ignored
+		if excerpt, ok := c.identitiesExcerpts[id]; ok {
+			result = append(result, excerpt)
+		}
+	}
+
+	return result, nil
+}
+
 // NewIdentity create a new identity
 // The new identity is written in the repository (commit)
 func (c *RepoCache) NewIdentity(name string, email string) (*IdentityCache, error) {
@@ -916,12 +2012,15 @@ func (c *RepoCache) NewIdentityRaw(name string, email string, login string, avat
 		return nil, err
 	}
 
+	c.muIdentity.Lock()
 	if _, has := c.identities[i.Id()]; has {
+		c.muIdentity.Unlock()
 		return nil, fmt.Errorf("identity %s already exist in the cache", i.Id())
 	}
 
 	cached := NewIdentityCache(c, i)
 	c.identities[i.Id()] = cached
+	c.muIdentity.Unlock()
 
 	// force the write of the excerpt
 	err = c.identityUpdated(i.Id())
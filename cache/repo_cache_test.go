@@ -1,10 +1,21 @@
 package cache
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"runtime"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/entity"
 	"github.com/MichaelMure/git-bug/repository"
 )
 
@@ -72,6 +83,14 @@ func TestCache(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, cache.QueryBugs(query), 2)
 
+	// Full text search, in the title and comments
+	query, err = ParseQuery(`status:open "MESSAGE"`)
+	require.NoError(t, err)
+	require.Len(t, cache.QueryBugs(query), 2)
+	query, err = ParseQuery("unmatched")
+	require.NoError(t, err)
+	require.Len(t, cache.QueryBugs(query), 0)
+
 	// Close
 	require.NoError(t, cache.Close())
 	require.Empty(t, cache.bugs)
@@ -144,9 +163,17 @@ func TestPushPull(t *testing.T) {
 	err = cacheB.SetUserIdentity(reneB)
 	require.NoError(t, err)
 
+	ahead, err := cacheB.BugsAhead("origin")
+	require.NoError(t, err)
+	require.Empty(t, ahead)
+
 	// B --> remote --> A
-	_, _, err = cacheB.NewBug("bug2", "message")
+	bug2, _, err := cacheB.NewBug("bug2", "message")
+	require.NoError(t, err)
+
+	ahead, err = cacheB.BugsAhead("origin")
 	require.NoError(t, err)
+	require.Equal(t, []entity.Id{bug2.Id()}, ahead)
 
 	_, err = cacheB.Push("origin")
 	require.NoError(t, err)
@@ -155,4 +182,1093 @@ func TestPushPull(t *testing.T) {
 	require.NoError(t, err)
 
 	require.Len(t, cacheA.AllBugsIds(), 2)
+
+	// a removed bug doesn't come back with a merge alone
+	bug1, err := cacheA.ResolveBugPrefix(cacheB.AllBugsIds()[0].String())
+	require.NoError(t, err)
+	require.NoError(t, cacheA.RemoveBug(bug1.Id()))
+	for result := range cacheA.MergeAll("origin") {
+		require.NoError(t, result.Err)
+	}
+	require.Len(t, cacheA.AllBugsIds(), 1)
+
+	// but does with a pull, as the remote still has it
+	err = cacheA.Pull("origin")
+	require.NoError(t, err)
+	require.Len(t, cacheA.AllBugsIds(), 2)
+}
+
+func TestPushPullBugs(t *testing.T) {
+	repoA, repoB, remote := repository.SetupReposAndRemote(t)
+	defer repository.CleanupTestRepos(t, repoA, repoB, remote)
+
+	cacheA, err := NewRepoCache(repoA)
+	require.NoError(t, err)
+
+	cacheB, err := NewRepoCache(repoB)
+	require.NoError(t, err)
+
+	rene, err := cacheA.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	err = cacheA.SetUserIdentity(rene)
+	require.NoError(t, err)
+
+	bug1, _, err := cacheA.NewBug("bug1", "message")
+	require.NoError(t, err)
+	bug2, _, err := cacheA.NewBug("bug2", "message")
+	require.NoError(t, err)
+
+	// only bug1 is pushed, with the identity
+	_, err = cacheA.PushBugs("origin", []entity.Id{bug1.Id()})
+	require.NoError(t, err)
+
+	_, err = cacheB.FetchBugs("origin", []entity.Id{bug2.Id()})
+	require.Error(t, err)
+
+	_, err = cacheA.Push("origin")
+	require.NoError(t, err)
+
+	// only bug2 is pulled
+	_, err = cacheB.FetchBugs("origin", []entity.Id{bug2.Id()})
+	require.NoError(t, err)
+	for result := range cacheB.MergeBugs("origin", []entity.Id{bug2.Id()}) {
+		require.NoError(t, result.Err)
+	}
+
+	require.Equal(t, []entity.Id{bug2.Id()}, cacheB.AllBugsIds())
+	_, err = cacheB.ResolveIdentity(rene.Id())
+	require.NoError(t, err)
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	require.NoError(t, cache.SetUserIdentity(iden))
+
+	for i := 0; i < 10; i++ {
+		_, _, err := cache.NewBug(fmt.Sprintf("title %d", i), "message")
+		require.NoError(t, err)
+	}
+
+	// rebuilding the cache with the worker pool give the same excerpts
+	require.NoError(t, cache.buildCache())
+	require.Len(t, cache.AllBugsIds(), 10)
+
+	query, err := ParseQuery("status:open message")
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+
+		// readers
+		go func() {
+			defer wg.Done()
+			for _, id := range cache.QueryBugs(query) {
+				b, err := cache.ResolveBug(id)
+				if err != nil {
+					errs <- err
+					return
+				}
+				_ = b.Snapshot()
+			}
+		}()
+
+		// writers
+		go func(i int) {
+			defer wg.Done()
+			_, _, err := cache.NewBug(fmt.Sprintf("concurrent %d", i), "message")
+			errs <- err
+		}(i)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	require.Len(t, cache.AllBugsIds(), 20)
+
+	// every bug resolve to a single instance
+	ids := cache.AllBugsIds()
+	first, err := cache.ResolveBug(ids[0])
+	require.NoError(t, err)
+	second, err := cache.ResolveBug(ids[0])
+	require.NoError(t, err)
+	require.True(t, first == second)
+}
+
+func TestConcurrentWriters(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	require.NoError(t, cache.SetUserIdentity(iden))
+
+	b, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+
+	before := b.Snapshot()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 40)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(3)
+
+		// readers of the snapshot
+		go func() {
+			defer wg.Done()
+			snap := b.Snapshot()
+			for _, comment := range snap.Comments {
+				_ = comment.Message
+			}
+			_ = len(snap.Timeline)
+		}()
+
+		// writers on the same bug
+		go func(i int) {
+			defer wg.Done()
+			_, err := b.AddComment(fmt.Sprintf("comment %d", i))
+			errs <- err
+			_, _, err = b.ChangeLabels([]string{fmt.Sprintf("label%d", i)}, nil)
+			errs <- err
+		}(i)
+
+		go func() {
+			defer wg.Done()
+			errs <- b.CommitAsNeeded()
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.NoError(t, b.CommitAsNeeded())
+
+	// a snapshot given out is never changed
+	require.Len(t, before.Comments, 1)
+	require.Len(t, before.Labels, 0)
+
+	snap := b.Snapshot()
+	require.Len(t, snap.Comments, 11)
+	require.Len(t, snap.Labels, 10)
+
+	// and the bug is committed as a whole
+	loaded, err := bug.ReadLocalBug(repo, b.Id())
+	require.NoError(t, err)
+	require.Len(t, loaded.Compile().Comments, 11)
+}
+
+func TestIncrementalUpdate(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	require.NoError(t, cache.SetUserIdentity(iden))
+
+	bug1, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+	bug2, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+
+	require.NoError(t, cache.Close())
+
+	// change the bugs behind the back of the cache
+	b, err := bug.ReadLocalBug(repo, bug1.Id())
+	require.NoError(t, err)
+	_, err = bug.AddComment(b, iden.Identity, time.Now().Unix(), "comment")
+	require.NoError(t, err)
+	require.NoError(t, b.Commit(repo))
+
+	require.NoError(t, bug.RemoveLocalBug(repo, bug2.Id()))
+
+	cache, err = NewRepoCache(repo)
+	require.NoError(t, err)
+
+	require.Len(t, cache.AllBugsIds(), 1)
+	excerpt, err := cache.ResolveBugExcerpt(bug1.Id())
+	require.NoError(t, err)
+	require.Equal(t, 2, excerpt.LenComments)
+	require.Equal(t, b.LastCommit(), cache.bugHeads[bug1.Id()])
+
+	require.NoError(t, cache.Close())
+
+	// rebuilding from scratch give the same result
+	cache, err = RebuildRepoCache(repo)
+	require.NoError(t, err)
+
+	require.Len(t, cache.AllBugsIds(), 1)
+	excerpt, err = cache.ResolveBugExcerpt(bug1.Id())
+	require.NoError(t, err)
+	require.Equal(t, 2, excerpt.LenComments)
+}
+
+func TestRefresh(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+	defer cache.Close()
+
+	iden, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	require.NoError(t, cache.SetUserIdentity(iden))
+
+	bug1, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+	bug2, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+
+	// nothing changed
+	changes, err := cache.Refresh()
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	events := cache.Subscribe()
+
+	// change the bugs behind the back of the cache, while it's open
+	b, err := bug.ReadLocalBug(repo, bug1.Id())
+	require.NoError(t, err)
+	_, err = bug.AddComment(b, iden.Identity, time.Now().Unix(), "comment")
+	require.NoError(t, err)
+	require.NoError(t, b.Commit(repo))
+
+	require.NoError(t, bug.RemoveLocalBug(repo, bug2.Id()))
+
+	changes, err = cache.Refresh()
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	require.Contains(t, changes, BugChangeEvent{Type: BugUpdated, Id: bug1.Id()})
+	require.Contains(t, changes, BugChangeEvent{Type: BugRemoved, Id: bug2.Id()})
+
+	require.Len(t, events, 2)
+
+	require.Len(t, cache.AllBugsIds(), 1)
+	excerpt, err := cache.ResolveBugExcerpt(bug1.Id())
+	require.NoError(t, err)
+	require.Equal(t, 2, excerpt.LenComments)
+
+	// the loaded copy is read again
+	reloaded, err := cache.ResolveBug(bug1.Id())
+	require.NoError(t, err)
+	require.True(t, bug1 != reloaded)
+	require.Len(t, reloaded.Snapshot().Comments, 2)
+}
+
+func TestExcerptAttributes(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	require.NoError(t, repo.StoreConfig(configMetadataKeys, "keep-"))
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	require.NoError(t, cache.SetUserIdentity(iden))
+
+	b1, _, err := cache.NewBugRaw(iden, time.Now().Unix(), "title", "message", nil, map[string]string{
+		"origin":  "test",
+		"keep-me": "value",
+		"drop-me": "value",
+	})
+	require.NoError(t, err)
+	_, err = b1.SetAssignee(iden)
+	require.NoError(t, err)
+	_, err = b1.SetMilestone("v1.0")
+	require.NoError(t, err)
+	_, err = b1.Vote(1)
+	require.NoError(t, err)
+	require.NoError(t, b1.Commit())
+
+	_, _, err = cache.NewBug("title", "message")
+	require.NoError(t, err)
+
+	excerpt, err := cache.ResolveBugExcerpt(b1.Id())
+	require.NoError(t, err)
+	require.Equal(t, iden.Id(), excerpt.AssigneeId)
+	require.Equal(t, "v1.0", excerpt.Milestone)
+	require.Equal(t, 1, excerpt.Votes)
+	require.Equal(t, map[string]string{"origin": "test", "keep-me": "value"}, excerpt.CreateMetadata)
+
+	for query, expected := range map[string]int{
+		"assignee:descartes": 1,
+		"no:assignee":        1,
+		"milestone:V1.0":     1,
+		"no:milestone":       1,
+	} {
+		q, err := ParseQuery(query)
+		require.NoError(t, err)
+		require.Len(t, cache.QueryBugs(q), expected, query)
+	}
+
+	// the attributes survive a reload of the cache
+	require.NoError(t, cache.Close())
+	cache, err = NewRepoCache(repo)
+	require.NoError(t, err)
+
+	excerpt, err = cache.ResolveBugExcerpt(b1.Id())
+	require.NoError(t, err)
+	require.Equal(t, iden.Id(), excerpt.AssigneeId)
+	require.Equal(t, 1, excerpt.Votes)
+}
+
+func TestResolveAnyMetadata(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	require.NoError(t, cache.SetUserIdentity(iden))
+
+	b1, _, err := cache.NewBugRaw(iden, time.Now().Unix(), "title", "message", nil, map[string]string{
+		"key": "create",
+	})
+	require.NoError(t, err)
+
+	comment, err := b1.AddCommentRaw(iden, time.Now().Unix(), "comment", nil, map[string]string{
+		"key": "comment",
+	})
+	require.NoError(t, err)
+	require.NoError(t, b1.Commit())
+
+	b2, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+	_, err = b2.AddCommentRaw(iden, time.Now().Unix(), "comment", nil, map[string]string{
+		"key": "duplicated",
+	})
+	require.NoError(t, err)
+	_, err = b2.AddCommentRaw(iden, time.Now().Unix(), "comment", nil, map[string]string{
+		"key": "duplicated",
+	})
+	require.NoError(t, err)
+	require.NoError(t, b2.Commit())
+
+	check := func(cache *RepoCache) {
+		b, err := cache.ResolveBugAnyMetadata("key", "comment")
+		require.NoError(t, err)
+		require.Equal(t, b1.Id(), b.Id())
+
+		b, err = cache.ResolveBugAnyMetadata("key", "create")
+		require.NoError(t, err)
+		require.Equal(t, b1.Id(), b.Id())
+
+		b, opId, err := cache.ResolveOperationAnyMetadata("key", "comment")
+		require.NoError(t, err)
+		require.Equal(t, b1.Id(), b.Id())
+		require.Equal(t, comment.Id(), opId)
+
+		// two operations of the same bug
+		b, err = cache.ResolveBugAnyMetadata("key", "duplicated")
+		require.NoError(t, err)
+		require.Equal(t, b2.Id(), b.Id())
+		_, _, err = cache.ResolveOperationAnyMetadata("key", "duplicated")
+		require.Error(t, err)
+
+		_, err = cache.ResolveBugAnyMetadata("key", "unknown")
+		require.Equal(t, bug.ErrBugNotExist, err)
+		_, _, err = cache.ResolveOperationAnyMetadata("key", "unknown")
+		require.Equal(t, ErrNoMatchingOp, err)
+	}
+
+	check(cache)
+
+	// the index is rebuilt from the cache file
+	require.NoError(t, cache.Close())
+	cache, err = NewRepoCache(repo)
+	require.NoError(t, err)
+	check(cache)
+
+	// and cleaned when the bug is removed
+	require.NoError(t, cache.RemoveBug(b2.Id()))
+	_, err = cache.ResolveBugAnyMetadata("key", "duplicated")
+	require.Equal(t, bug.ErrBugNotExist, err)
+	require.Empty(t, cache.metadataIndex["key"]["duplicated"])
+}
+
+func TestLoadedBugsEviction(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	require.NoError(t, repo.StoreConfig(configMaxLoadedBugs, "2"))
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	require.NoError(t, cache.SetUserIdentity(iden))
+
+	// a bug with pending operations is never evicted
+	pending, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+	_, err = pending.AddComment("pending")
+	require.NoError(t, err)
+
+	var evicted *BugCache
+	for i := 0; i < 5; i++ {
+		b, _, err := cache.NewBug("title", "message")
+		require.NoError(t, err)
+		if i == 0 {
+			evicted = b
+		}
+	}
+
+	require.Len(t, cache.bugs, 2)
+	require.Equal(t, 2, cache.loadedBugs.Len())
+	require.Contains(t, cache.bugs, pending.Id())
+	require.NotContains(t, cache.bugs, evicted.Id())
+
+	// an evicted bug can be loaded again
+	b, err := cache.ResolveBug(evicted.Id())
+	require.NoError(t, err)
+	require.Len(t, b.Snapshot().Comments, 1)
+	require.Len(t, cache.bugs, 2)
+
+	// an evicted instance forward to the one loaded again
+	_, err = cache.ResolveBug(pending.Id())
+	require.NoError(t, err)
+	for _, id := range cache.AllBugsIds() {
+		if id == evicted.Id() {
+			continue
+		}
+		_, err := cache.ResolveBug(id)
+		require.NoError(t, err)
+	}
+	require.NotContains(t, cache.bugs, evicted.Id())
+	b, err = cache.ResolveBug(evicted.Id())
+	require.NoError(t, err)
+	require.True(t, b != evicted)
+	_, err = evicted.AddComment("stale")
+	require.NoError(t, err)
+	require.Len(t, b.Snapshot().Comments, 2)
+	require.Len(t, evicted.Snapshot().Comments, 2)
+
+	require.NoError(t, pending.Commit())
+}
+
+func TestStaleBugHandle(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	require.NoError(t, repo.StoreConfig(configMaxLoadedBugs, "1"))
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	require.NoError(t, cache.SetUserIdentity(iden))
+
+	stale, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+	other, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+	require.NotContains(t, cache.bugs, stale.Id())
+
+	// the changes made through an evicted instance are not lost
+	_, err = stale.AddComment("through the stale handle")
+	require.NoError(t, err)
+	require.NoError(t, stale.Commit())
+
+	live, err := cache.ResolveBug(stale.Id())
+	require.NoError(t, err)
+	require.True(t, live != stale)
+	require.Len(t, live.Snapshot().Comments, 2)
+
+	stored, err := bug.ReadLocalBug(repo, stale.Id())
+	require.NoError(t, err)
+	require.Len(t, stored.Compile().Comments, 2)
+
+	excerpt, err := cache.ResolveBugExcerpt(stale.Id())
+	require.NoError(t, err)
+	require.Equal(t, 2, excerpt.LenComments)
+
+	// a bug changed behind the cache is not overwritten
+	loaded, err := cache.ResolveBug(other.Id())
+	require.NoError(t, err)
+
+	raw, err := bug.ReadLocalBug(repo, other.Id())
+	require.NoError(t, err)
+	_, err = bug.AddComment(raw, iden.Identity, time.Now().Unix(), "behind the cache")
+	require.NoError(t, err)
+	require.NoError(t, raw.Commit(repo))
+
+	_, err = loaded.AddComment("concurrent")
+	require.NoError(t, err)
+	err = loaded.Commit()
+	require.Error(t, err)
+	require.Equal(t, repository.ErrRefChanged, errors.Cause(err))
+
+	stored, err = bug.ReadLocalBug(repo, other.Id())
+	require.NoError(t, err)
+	require.Equal(t, "behind the cache", stored.Compile().Comments[1].Message)
+}
+
+func TestDetachedBug(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	require.NoError(t, repo.StoreConfig(configMaxLoadedBugs, "1"))
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	require.NoError(t, cache.SetUserIdentity(iden))
+
+	events := cache.Subscribe()
+
+	detached, _, err := cache.NewBugRawDetached(iden, time.Now().Unix(), "detached", "message", nil, nil)
+	require.NoError(t, err)
+
+	// not visible until published
+	require.Empty(t, cache.AllBugsIds())
+	require.Empty(t, events)
+
+	// not evicted past the max of loaded bugs
+	for i := 0; i < 3; i++ {
+		_, _, err := cache.NewBug("title", "message")
+		require.NoError(t, err)
+	}
+	require.Contains(t, cache.bugs, detached.Id())
+	for len(events) > 0 {
+		<-events
+	}
+
+	// not reported as removed
+	changes, err := cache.Refresh()
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	_, err = detached.AddComment("comment")
+	require.NoError(t, err)
+	require.Empty(t, events)
+	require.NoError(t, detached.CommitAsNeeded())
+
+	require.Equal(t, BugChangeEvent{Type: BugCreated, Id: detached.Id()}, <-events)
+	require.Empty(t, events)
+
+	excerpt, err := cache.ResolveBugExcerpt(detached.Id())
+	require.NoError(t, err)
+	require.Equal(t, 2, excerpt.LenComments)
+
+	stored, err := bug.ReadLocalBug(repo, detached.Id())
+	require.NoError(t, err)
+	require.Len(t, stored.Compile().Comments, 2)
+
+	// once published, it can be evicted
+	last, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+	require.NotContains(t, cache.bugs, detached.Id())
+	require.Equal(t, BugChangeEvent{Type: BugCreated, Id: last.Id()}, <-events)
+
+	// a bug removed before being published just disappear
+	removed, _, err := cache.NewBugRawDetached(iden, time.Now().Unix(), "removed", "message", nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, cache.RemoveBug(removed.Id()))
+	require.NotContains(t, cache.bugs, removed.Id())
+	require.Empty(t, events)
+}
+
+func TestStaleLock(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	lockPath := repoLockFilePath(repo)
+	require.NoError(t, os.MkdirAll(path.Dir(lockPath), 0777))
+
+	writeLock := func(content string) {
+		require.NoError(t, ioutil.WriteFile(lockPath, []byte(content), 0644))
+	}
+
+	open := func() error {
+		cache, err := NewRepoCache(repo)
+		if err != nil {
+			return err
+		}
+		return cache.Close()
+	}
+
+	// dead process
+	writeLock("999999999")
+	require.NoError(t, open())
+
+	// garbage
+	writeLock("garbage")
+	require.NoError(t, open())
+
+	// pid reused by a process started after the lock
+	if runtime.GOOS == "linux" {
+		writeLock("1")
+		require.NoError(t, os.Chtimes(lockPath, time.Unix(0, 0), time.Unix(0, 0)))
+		require.NoError(t, open())
+	}
+
+	// live process
+	writeLock(strconv.Itoa(os.Getpid()))
+	require.Error(t, open())
+
+	// live process looking younger than its lock, as after a clock step
+	require.NoError(t, os.Chtimes(lockPath, time.Unix(0, 0), time.Unix(0, 0)))
+	require.Error(t, open())
+	require.Error(t, UnlockRepo(repo, false))
+	require.NoError(t, UnlockRepo(repo, true))
+	require.Equal(t, ErrNotLocked, UnlockRepo(repo, true))
+	require.NoError(t, open())
+}
+
+func TestSavedQueries(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+	defer cache.Close()
+
+	iden1, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	require.NoError(t, cache.SetUserIdentity(iden1))
+	iden2, err := cache.NewIdentity("Pascal", "pascal@example.com")
+	require.NoError(t, err)
+
+	mine, _, err := cache.NewBug("mine", "")
+	require.NoError(t, err)
+	other, _, err := cache.NewBugRaw(iden2, time.Now().Unix(), "other", "", nil, nil)
+	require.NoError(t, err)
+	_, err = other.Close()
+	require.NoError(t, err)
+
+	require.Error(t, cache.SaveQuery("0invalid", "status:open"))
+	require.Error(t, cache.SaveQuery("broken", "foo:bar"))
+	require.Error(t, cache.SaveQuery("unknown", "@nope"))
+
+	require.NoError(t, cache.SaveQuery("Mine", "author:me"))
+	require.NoError(t, cache.SaveQuery("open-mine", "status:open @mine"))
+	require.Error(t, cache.SaveQuery("mine", "@open-mine"))
+
+	saved, err := cache.SavedQueries()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"mine":      "author:me",
+		"open-mine": "status:open @mine",
+	}, saved)
+
+	query, err := cache.ParseQuery("@open-mine")
+	require.NoError(t, err)
+	require.Equal(t, []entity.Id{mine.Id()}, cache.QueryBugs(query))
+
+	query, err = cache.ParseQuery("author:pascal")
+	require.NoError(t, err)
+	require.Equal(t, []entity.Id{other.Id()}, cache.QueryBugs(query))
+
+	require.NoError(t, cache.RemoveSavedQuery("mine"))
+	require.Error(t, cache.RemoveSavedQuery("mine"))
+	_, err = cache.ParseQuery("@open-mine")
+	require.Error(t, err)
+}
+
+func TestSearchIdentity(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+	defer cache.Close()
+
+	rene, err := cache.NewIdentityFull("René Descartes", "rene@descartes.fr", "rdescartes", "")
+	require.NoError(t, err)
+	robert, err := cache.NewIdentity("Robert Descartes", "robert@example.com")
+	require.NoError(t, err)
+	blaise, err := cache.NewIdentityFull("Blaise Pascal", "blaise@pascal.fr", "pascal", "")
+	require.NoError(t, err)
+
+	ids := func(excerpts []*IdentityExcerpt) []entity.Id {
+		result := make([]entity.Id, len(excerpts))
+		for i, excerpt := range excerpts {
+			result[i] = excerpt.Id
+		}
+		return result
+	}
+
+	// exact login first, then prefix of a word in the name
+	require.Equal(t, []entity.Id{blaise.Id()}, ids(cache.SearchIdentity("Pascal")))
+	require.Equal(t, []entity.Id{rene.Id(), robert.Id()}, ids(cache.SearchIdentity("desc")))
+	require.Equal(t, []entity.Id{rene.Id(), robert.Id()}, ids(cache.SearchIdentity("DESCARTES")))
+
+	// email
+	require.Equal(t, []entity.Id{robert.Id()}, ids(cache.SearchIdentity("example.com")))
+
+	// substring before fuzzy
+	require.Equal(t, []entity.Id{rene.Id(), robert.Id(), blaise.Id()}, ids(cache.SearchIdentity("esc")))
+	require.Equal(t, []entity.Id{robert.Id()}, ids(cache.SearchIdentity("rbrt")))
+
+	// id prefix
+	require.Equal(t, []entity.Id{robert.Id()}, ids(cache.SearchIdentity(robert.Id().String()[:10])))
+
+	require.Len(t, cache.SearchIdentity(""), 3)
+	require.Empty(t, cache.SearchIdentity("nobody"))
+
+	// designated by a user
+	require.NoError(t, cache.SetUserIdentity(robert))
+	i, err := cache.ResolveIdentityUser("me")
+	require.NoError(t, err)
+	require.Equal(t, robert.Id(), i.Id())
+	i, err = cache.ResolveIdentityUser("Pascal")
+	require.NoError(t, err)
+	require.Equal(t, blaise.Id(), i.Id())
+	i, err = cache.ResolveIdentityUser(rene.Id().Human())
+	require.NoError(t, err)
+	require.Equal(t, rene.Id(), i.Id())
+	_, err = cache.ResolveIdentityUser("nobody")
+	require.Error(t, err)
+}
+
+func TestLabels(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	require.NoError(t, cache.SetUserIdentity(iden))
+
+	bug1, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+	_, _, err = bug1.ChangeLabels([]string{"bug", "core"}, nil)
+	require.NoError(t, err)
+	require.NoError(t, bug1.Commit())
+
+	bug2, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+	_, _, err = bug2.ChangeLabels([]string{"bug"}, nil)
+	require.NoError(t, err)
+	_, err = bug2.Close()
+	require.NoError(t, err)
+	require.NoError(t, bug2.Commit())
+
+	expected := []LabelCount{
+		{Label: "bug", Open: 1, Closed: 1},
+		{Label: "core", Open: 1, Closed: 0},
+	}
+	require.Equal(t, expected, cache.Labels())
+	require.Equal(t, []bug.Label{"bug", "core"}, cache.ValidLabels())
+
+	// the counts are computed again when loading the cache from disk
+	require.NoError(t, cache.Close())
+	cache, err = NewRepoCache(repo)
+	require.NoError(t, err)
+	require.Equal(t, expected, cache.Labels())
+
+	bug1, err = cache.ResolveBug(bug1.Id())
+	require.NoError(t, err)
+	_, _, err = bug1.ChangeLabels(nil, []string{"core"})
+	require.NoError(t, err)
+	require.NoError(t, cache.RemoveBug(bug2.Id()))
+
+	require.Equal(t, []LabelCount{{Label: "bug", Open: 1, Closed: 0}}, cache.Labels())
+	require.NoError(t, cache.Close())
+}
+
+func TestRenameLabel(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	iden, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	require.NoError(t, cache.SetUserIdentity(iden))
+
+	bug1, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+	_, _, err = bug1.ChangeLabels([]string{"wip"}, nil)
+	require.NoError(t, err)
+	require.NoError(t, bug1.Commit())
+
+	// already has the new label
+	bug2, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+	_, _, err = bug2.ChangeLabels([]string{"wip", "in-progress"}, nil)
+	require.NoError(t, err)
+	require.NoError(t, bug2.Commit())
+
+	_, _, err = cache.NewBug("title", "message")
+	require.NoError(t, err)
+
+	_, err = cache.RenameLabel("wip", "in progress\n")
+	require.Error(t, err)
+
+	count, err := cache.RenameLabel("wip", "in-progress")
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	require.Equal(t, []bug.Label{"in-progress"}, bug1.Snapshot().Labels)
+	require.Equal(t, []bug.Label{"in-progress"}, bug2.Snapshot().Labels)
+	require.Equal(t, []LabelCount{{Label: "in-progress", Open: 2, Closed: 0}}, cache.Labels())
+
+	count, err = cache.RenameLabel("wip", "in-progress")
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+}
+
+func TestStats(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	rene, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	isaac, err := cache.NewIdentity("Isaac Newton", "isaac@newton.uk")
+	require.NoError(t, err)
+
+	since := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := int64(24 * 60 * 60)
+	at := func(days int64) int64 { return since.Unix() + days*day }
+
+	// before the period
+	old, _, err := cache.NewBugRaw(rene, at(-10), "old", "message", nil, nil)
+	require.NoError(t, err)
+	_, _, err = old.ChangeLabelsRaw(rene, at(-10), []string{"bug"}, nil, nil)
+	require.NoError(t, err)
+	_, err = old.CloseRaw(isaac, at(2), nil)
+	require.NoError(t, err)
+	require.NoError(t, old.Commit())
+
+	bug1, _, err := cache.NewBugRaw(rene, at(1), "bug1", "message", nil, nil)
+	require.NoError(t, err)
+	_, _, err = bug1.ChangeLabelsRaw(isaac, at(1), []string{"bug", "ui"}, nil, nil)
+	require.NoError(t, err)
+	_, err = bug1.CloseRaw(isaac, at(9), nil)
+	require.NoError(t, err)
+	require.NoError(t, bug1.Commit())
+
+	_, _, err = cache.NewBugRaw(isaac, at(8), "bug2", "message", nil, nil)
+	require.NoError(t, err)
+
+	// after the period
+	_, _, err = cache.NewBugRaw(rene, at(30), "new", "message", nil, nil)
+	require.NoError(t, err)
+
+	stats, err := cache.Stats(cache.AllBugsIds(), since, since.AddDate(0, 0, 14))
+	require.NoError(t, err)
+
+	// bug2 and the bug created after the period
+	require.Equal(t, 2, stats.Open)
+	require.Equal(t, 2, stats.Closed)
+	require.Equal(t, []WeekStats{
+		{Start: since, Opened: 1, Closed: 1},
+		{Start: since.AddDate(0, 0, 7), Opened: 1, Closed: 1},
+	}, stats.Weeks)
+	// (12 days + 8 days) / 2
+	require.Equal(t, 10*24*time.Hour, stats.MeanTimeToClose)
+	require.Equal(t, []LabelCount{
+		{Label: "bug", Closed: 2},
+		{Label: "ui", Closed: 1},
+	}, stats.Labels)
+	require.Equal(t, []AuthorStats{
+		{Id: isaac.Id(), Name: "Isaac Newton", Operations: 4},
+		{Id: rene.Id(), Name: "René Descartes", Operations: 1},
+	}, stats.Authors)
+}
+
+func TestBurndown(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	rene, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+
+	since := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := int64(24 * 60 * 60)
+	at := func(days int64) int64 { return since.Unix() + days*day }
+
+	// open and untouched during the period
+	_, _, err = cache.NewBugRaw(rene, at(-10), "old", "message", nil, nil)
+	require.NoError(t, err)
+
+	bug1, _, err := cache.NewBugRaw(rene, at(1), "bug1", "message", nil, nil)
+	require.NoError(t, err)
+	_, err = bug1.CloseRaw(rene, at(3), nil)
+	require.NoError(t, err)
+	_, err = bug1.OpenRaw(rene, at(4), nil)
+	require.NoError(t, err)
+	require.NoError(t, bug1.Commit())
+
+	bug2, _, err := cache.NewBugRaw(rene, at(-5), "bug2", "message", nil, nil)
+	require.NoError(t, err)
+	_, err = bug2.CloseRaw(rene, at(2), nil)
+	require.NoError(t, err)
+	require.NoError(t, bug2.Commit())
+
+	dayStart := func(days int) time.Time { return since.AddDate(0, 0, days) }
+
+	points, err := cache.Burndown(cache.AllBugsIds(), since, dayStart(5), 24*time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, []BurndownPoint{
+		{Start: dayStart(0), Opened: 0, Closed: 0, Open: 2},
+		{Start: dayStart(1), Opened: 1, Closed: 0, Open: 3},
+		{Start: dayStart(2), Opened: 0, Closed: 1, Open: 2},
+		{Start: dayStart(3), Opened: 0, Closed: 1, Open: 1},
+		{Start: dayStart(4), Opened: 0, Closed: 0, Open: 2},
+	}, points)
+}
+
+func TestMergeIdentity(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	rene, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	require.NoError(t, cache.SetUserIdentity(rene))
+
+	// as created by a bridge import
+	imported, err := cache.NewIdentityRaw("René", "", "rene", "", map[string]string{"github-login": "rene"})
+	require.NoError(t, err)
+
+	_, _, err = cache.NewBugRaw(imported, time.Now().Unix(), "imported", "message", nil, nil)
+	require.NoError(t, err)
+
+	mine, err := cache.ParseQuery("author:me")
+	require.NoError(t, err)
+	require.Len(t, cache.QueryBugs(mine), 0)
+
+	require.NoError(t, cache.MergeIdentity(imported, rene))
+	require.Equal(t, rene.Id(), imported.MergedInto())
+	require.Equal(t, rene.Id(), cache.MergedIdentityId(imported.Id()))
+
+	// merging again or the other way around is refused
+	require.Error(t, cache.MergeIdentity(imported, rene))
+	require.Error(t, cache.MergeIdentity(rene, imported))
+
+	// the imported activity is now the user's
+	require.Len(t, cache.QueryBugs(mine), 1)
+
+	// the next imports use the user identity
+	resolved, err := cache.ResolveIdentityImmutableMetadata("github-login", "rene")
+	require.NoError(t, err)
+	require.Equal(t, rene.Id(), resolved.Id())
+
+	// the merge is persisted
+	require.NoError(t, cache.Close())
+	cache, err = NewRepoCache(repo)
+	require.NoError(t, err)
+	require.Equal(t, rene.Id(), cache.MergedIdentityId(imported.Id()))
+}
+
+func TestOwnIdentities(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+	defer cache.Close()
+
+	work, err := cache.NewIdentity("René Descartes", "rene@work.fr")
+	require.NoError(t, err)
+	personal, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	other, err := cache.NewIdentity("Blaise Pascal", "blaise@pascal.fr")
+	require.NoError(t, err)
+
+	require.NoError(t, cache.SetUserIdentity(work))
+	require.NoError(t, cache.SetUserIdentity(personal))
+
+	own, err := cache.OwnIdentities()
+	require.NoError(t, err)
+	require.Len(t, own, 2)
+
+	user, err := cache.GetUserIdentity()
+	require.NoError(t, err)
+	require.Equal(t, personal.Id(), user.Id())
+
+	// an identity merged into another, maybe by someone else, doesn't author
+	// anything until the user choose again
+	require.NoError(t, cache.MergeIdentity(personal, other))
+	_, err = cache.GetUserIdentity()
+	require.Error(t, err)
+	_, _, err = cache.NewBug("title", "message")
+	require.Error(t, err)
+
+	require.NoError(t, cache.SetUserIdentity(work))
+	user, err = cache.GetUserIdentity()
+	require.NoError(t, err)
+	require.Equal(t, work.Id(), user.Id())
+}
+
+func TestAttachIdentity(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+	defer cache.Close()
+
+	rene, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	imported, err := cache.NewIdentityRaw("Blaise Pascal", "", "blaise", "", map[string]string{
+		"github-login": "blaise",
+	})
+	require.NoError(t, err)
+
+	err = cache.AttachIdentity(rene, map[string]string{
+		"github-login": "rene",
+		"gitlab-login": "rene2",
+	})
+	require.NoError(t, err)
+
+	// the attached accounts resolve to the identity, as when importing
+	i, err := cache.ResolveIdentityImmutableMetadata("gitlab-login", "rene2")
+	require.NoError(t, err)
+	require.Equal(t, rene.Id(), i.Id())
+
+	// attaching the same account again is a no-op
+	require.NoError(t, cache.AttachIdentity(rene, map[string]string{"github-login": "rene"}))
+	require.Len(t, rene.Versions(), 2)
+
+	// the attachments can't be changed
+	require.Error(t, cache.AttachIdentity(rene, map[string]string{"github-login": "descartes"}))
+
+	// an account already imported has to be merged instead
+	require.Error(t, cache.AttachIdentity(rene, map[string]string{"github-login": "blaise"}))
+	require.NoError(t, cache.MergeIdentity(imported, rene))
 }
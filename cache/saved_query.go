@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const savedQueryConfigPrefix = "git-bug.query."
+
+// saved queries can refer to other saved queries, up to this depth
+const maxSavedQueryDepth = 10
+
+var savedQueryNameRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-]*$`)
+
+// SaveQuery store a named query in the git config of the repository. It can
+// then be used as @name in other queries.
+func (c *RepoCache) SaveQuery(name string, query string) error {
+	if !savedQueryNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid query name \"%s\": only letters, digits and dashes are allowed, starting with a letter", name)
+	}
+
+	// git config keys are case insensitive
+	name = strings.ToLower(name)
+
+	expanded, err := c.expandQuery(query, map[string]bool{name: true}, 0)
+	if err != nil {
+		return err
+	}
+
+	_, err = ParseQuery(expanded)
+	if err != nil {
+		return err
+	}
+
+	return c.repo.StoreConfig(savedQueryConfigPrefix+name, query)
+}
+
+// SavedQueries return all the saved queries, by name
+func (c *RepoCache) SavedQueries() (map[string]string, error) {
+	configs, err := c.repo.ReadConfigs(savedQueryConfigPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string, len(configs))
+	for key, query := range configs {
+		result[strings.TrimPrefix(key, savedQueryConfigPrefix)] = query
+	}
+
+	return result, nil
+}
+
+// RemoveSavedQuery delete a saved query
+func (c *RepoCache) RemoveSavedQuery(name string) error {
+	name = strings.ToLower(name)
+
+	queries, err := c.SavedQueries()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := queries[name]; !ok {
+		return fmt.Errorf("no saved query named \"%s\"", name)
+	}
+
+	return c.repo.RmConfigs(savedQueryConfigPrefix + name)
+}
+
+// ParseQuery parse a query like the package's ParseQuery, after replacing the
+// references to saved queries (@name) by their content. The query is then
+// restricted to the current project, if any, see ScopeQuery.
+func (c *RepoCache) ParseQuery(query string) (*Query, error) {
+	expanded, err := c.expandQuery(query, map[string]bool{}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := ParseQuery(expanded)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.ScopeQuery(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+func (c *RepoCache) expandQuery(query string, seen map[string]bool, depth int) (string, error) {
+	if !strings.Contains(query, "@") {
+		return query, nil
+	}
+
+	if depth >= maxSavedQueryDepth {
+		return "", fmt.Errorf("too many nested saved queries")
+	}
+
+	saved, err := c.SavedQueries()
+	if err != nil {
+		return "", err
+	}
+
+	fields := splitQuery(query)
+	for i, field := range fields {
+		if !strings.HasPrefix(field, "@") {
+			continue
+		}
+
+		name := strings.ToLower(field[1:])
+
+		if seen[name] {
+			return "", fmt.Errorf("saved query \"%s\" refer to itself", name)
+		}
+
+		sub, ok := saved[name]
+		if !ok {
+			return "", fmt.Errorf("no saved query named \"%s\"", name)
+		}
+
+		seen[name] = true
+		fields[i], err = c.expandQuery(sub, seen, depth+1)
+		delete(seen, name)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return strings.Join(fields, " "), nil
+}
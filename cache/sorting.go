@@ -7,6 +7,8 @@ const (
 	OrderById
 	OrderByCreation
 	OrderByEdit
+	OrderByTitle
+	OrderByComments
 )
 
 type OrderDirection int
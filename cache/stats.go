@@ -0,0 +1,253 @@
+package cache
+
+import (
+	"sort"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+const week = 7 * 24 * time.Hour
+
+// BugStats hold statistics about a set of bugs over a period of time
+type BugStats struct {
+	Since time.Time
+	Until time.Time
+
+	// number of bugs currently open and closed
+	Open   int
+	Closed int
+
+	// bugs opened and closed in each week of the period, the first week
+	// starting at Since
+	Weeks []WeekStats
+
+	// mean time between the creation and the closing of the bugs closed
+	// during the period, zero if there is none
+	MeanTimeToClose time.Duration
+
+	// labels of the bugs, most used first
+	Labels []LabelCount
+
+	// authors of operations during the period, most active first
+	Authors []AuthorStats
+}
+
+// WeekStats hold the number of bugs opened and closed during a week
+type WeekStats struct {
+	Start  time.Time
+	Opened int
+	Closed int
+}
+
+// AuthorStats hold the number of operations authored during a period
+type AuthorStats struct {
+	Id         entity.Id
+	Name       string
+	Operations int
+}
+
+// Stats compute the statistics of the given bugs over the period [since, until).
+// Only the bugs edited during the period are loaded to inspect their history.
+func (c *RepoCache) Stats(ids []entity.Id, since time.Time, until time.Time) (*BugStats, error) {
+	stats := &BugStats{
+		Since:  since,
+		Until:  until,
+		Labels: []LabelCount{},
+	}
+
+	for start := since; start.Before(until); start = start.Add(week) {
+		stats.Weeks = append(stats.Weeks, WeekStats{Start: start})
+	}
+
+	weekOf := func(unixTime int64) *WeekStats {
+		t := time.Unix(unixTime, 0)
+		if t.Before(since) || !t.Before(until) {
+			return nil
+		}
+		return &stats.Weeks[int(t.Sub(since)/week)]
+	}
+
+	labels := make(labelCounts)
+	authors := make(map[entity.Id]*AuthorStats)
+
+	var closedCount int
+	var closedDuration time.Duration
+
+	for _, id := range ids {
+		excerpt, err := c.ResolveBugExcerpt(id)
+		if err != nil {
+			return nil, err
+		}
+
+		switch excerpt.Status {
+		case bug.OpenStatus:
+			stats.Open++
+		case bug.ClosedStatus:
+			stats.Closed++
+		}
+
+		labels.add(excerpt, 1)
+
+		if w := weekOf(excerpt.CreateUnixTime); w != nil {
+			w.Opened++
+		}
+
+		// nothing happened to this bug during the period
+		if time.Unix(excerpt.EditUnixTime, 0).Before(since) {
+			continue
+		}
+
+		b, err := c.ResolveBug(id)
+		if err != nil {
+			return nil, err
+		}
+
+		var lastClose int64
+		for _, op := range b.Snapshot().Operations {
+			if weekOf(op.GetUnixTime()) == nil {
+				continue
+			}
+
+			author := op.GetAuthor()
+			count, ok := authors[author.Id()]
+			if !ok {
+				count = &AuthorStats{Id: author.Id(), Name: author.DisplayName()}
+				authors[author.Id()] = count
+			}
+			count.Operations++
+
+			if op, ok := op.(*bug.SetStatusOperation); ok && op.Status == bug.ClosedStatus {
+				weekOf(op.UnixTime).Closed++
+				lastClose = op.UnixTime
+			}
+		}
+
+		if excerpt.Status == bug.ClosedStatus && lastClose != 0 {
+			closedCount++
+			closedDuration += time.Duration(lastClose-excerpt.CreateUnixTime) * time.Second
+		}
+	}
+
+	if closedCount > 0 {
+		stats.MeanTimeToClose = closedDuration / time.Duration(closedCount)
+	}
+
+	for _, count := range labels {
+		stats.Labels = append(stats.Labels, *count)
+	}
+	sort.Slice(stats.Labels, func(i, j int) bool {
+		if stats.Labels[i].Total() != stats.Labels[j].Total() {
+			return stats.Labels[i].Total() > stats.Labels[j].Total()
+		}
+		return stats.Labels[i].Label < stats.Labels[j].Label
+	})
+
+	stats.Authors = make([]AuthorStats, 0, len(authors))
+	for _, count := range authors {
+		stats.Authors = append(stats.Authors, *count)
+	}
+	sort.Slice(stats.Authors, func(i, j int) bool {
+		if stats.Authors[i].Operations != stats.Authors[j].Operations {
+			return stats.Authors[i].Operations > stats.Authors[j].Operations
+		}
+		return stats.Authors[i].Id < stats.Authors[j].Id
+	})
+
+	return stats, nil
+}
+
+// BurndownPoint hold the activity on a set of bugs during an interval of time,
+// and the number of bugs remaining open at its end
+type BurndownPoint struct {
+	Start  time.Time
+	Opened int
+	Closed int
+	Open   int
+}
+
+// Burndown compute the bugs opened and closed during each interval of the
+// period [since, until), and the bugs open at the end of each interval. The
+// last interval is cut at until. Like for Stats, only the bugs edited during
+// the period are loaded to replay their history.
+func (c *RepoCache) Burndown(ids []entity.Id, since time.Time, until time.Time, step time.Duration) ([]BurndownPoint, error) {
+	var points []BurndownPoint
+	for start := since; start.Before(until); start = start.Add(step) {
+		points = append(points, BurndownPoint{Start: start})
+	}
+
+	ends := make([]int64, len(points))
+	for i, point := range points {
+		end := point.Start.Add(step)
+		if end.After(until) {
+			end = until
+		}
+		ends[i] = end.Unix()
+	}
+
+	pointOf := func(unixTime int64) *BurndownPoint {
+		t := time.Unix(unixTime, 0)
+		if t.Before(since) || !t.Before(until) {
+			return nil
+		}
+		return &points[int(t.Sub(since)/step)]
+	}
+
+	for _, id := range ids {
+		excerpt, err := c.ResolveBugExcerpt(id)
+		if err != nil {
+			return nil, err
+		}
+
+		// nothing happened to this bug during the period, its status didn't
+		// change
+		if time.Unix(excerpt.EditUnixTime, 0).Before(since) {
+			if excerpt.Status == bug.OpenStatus {
+				for i := range points {
+					points[i].Open++
+				}
+			}
+			continue
+		}
+
+		if p := pointOf(excerpt.CreateUnixTime); p != nil {
+			p.Opened++
+		}
+
+		b, err := c.ResolveBug(id)
+		if err != nil {
+			return nil, err
+		}
+
+		ops := b.Snapshot().Operations
+
+		for _, op := range ops {
+			if op, ok := op.(*bug.SetStatusOperation); ok && op.Status == bug.ClosedStatus {
+				if p := pointOf(op.UnixTime); p != nil {
+					p.Closed++
+				}
+			}
+		}
+
+		// replay the status changes up to the end of each interval
+		for i, end := range ends {
+			if excerpt.CreateUnixTime >= end {
+				continue
+			}
+
+			status := bug.OpenStatus
+			for _, op := range ops {
+				if op, ok := op.(*bug.SetStatusOperation); ok && op.UnixTime < end {
+					status = op.Status
+				}
+			}
+
+			if status == bug.OpenStatus {
+				points[i].Open++
+			}
+		}
+	}
+
+	return points, nil
+}
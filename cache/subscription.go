@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+// subscriptionBuffer is the number of events a subscriber can lag behind
+// before missing events
+const subscriptionBuffer = 100
+
+type BugChangeType int
+
+const (
+	_ BugChangeType = iota
+	BugCreated
+	BugUpdated
+	BugRemoved
+)
+
+func (t BugChangeType) String() string {
+	switch t {
+	case BugCreated:
+		return "created"
+	case BugUpdated:
+		return "updated"
+	case BugRemoved:
+		return "removed"
+	default:
+		return "unknown"
+	}
+}
+
+// BugChangeEvent notify a change of a bug in the cache
+type BugChangeEvent struct {
+	Type BugChangeType
+	Id   entity.Id
+}
+
+// subscribers hold the channels of the subscribers to the bug changes
+type subscribers struct {
+	mu       sync.Mutex
+	channels []chan BugChangeEvent
+}
+
+// Subscribe return a channel receiving an event each time a bug is created,
+// updated or removed, be it locally, through a merge or an import.
+//
+// Events are not buffered indefinitely: a subscriber lagging too much behind
+// miss events. The channel is closed when calling Unsubscribe or when the
+// cache is closed.
+func (c *RepoCache) Subscribe() <-chan BugChangeEvent {
+	ch := make(chan BugChangeEvent, subscriptionBuffer)
+
+	c.subscribers.mu.Lock()
+	c.subscribers.channels = append(c.subscribers.channels, ch)
+	c.subscribers.mu.Unlock()
+
+	return ch
+}
+
+// Unsubscribe stop the delivery of events to a channel returned by Subscribe
+// and close it
+func (c *RepoCache) Unsubscribe(events <-chan BugChangeEvent) {
+	c.subscribers.mu.Lock()
+	defer c.subscribers.mu.Unlock()
+
+	for i, ch := range c.subscribers.channels {
+		if ch == events {
+			close(ch)
+			c.subscribers.channels = append(c.subscribers.channels[:i], c.subscribers.channels[i+1:]...)
+			return
+		}
+	}
+}
+
+// notify send an event to all the subscribers, without blocking
+func (c *RepoCache) notify(t BugChangeType, id entity.Id) {
+	c.subscribers.mu.Lock()
+	defer c.subscribers.mu.Unlock()
+
+	event := BugChangeEvent{Type: t, Id: id}
+	for _, ch := range c.subscribers.channels {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// closeSubscriptions close the channels of all the subscribers
+func (c *RepoCache) closeSubscriptions() {
+	c.subscribers.mu.Lock()
+	defer c.subscribers.mu.Unlock()
+
+	for _, ch := range c.subscribers.channels {
+		close(ch)
+	}
+	c.subscribers.channels = nil
+}
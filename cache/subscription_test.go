@@ -0,0 +1,130 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestSubscribe(t *testing.T) {
+	repoA, repoB, remote := repository.SetupReposAndRemote(t)
+	defer repository.CleanupTestRepos(t, repoA, repoB, remote)
+
+	cacheA, err := NewRepoCache(repoA)
+	require.NoError(t, err)
+
+	cacheB, err := NewRepoCache(repoB)
+	require.NoError(t, err)
+
+	reneA, err := cacheA.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	require.NoError(t, cacheA.SetUserIdentity(reneA))
+
+	eventsA := cacheA.Subscribe()
+	eventsB := cacheB.Subscribe()
+
+	next := func(events <-chan BugChangeEvent) BugChangeEvent {
+		select {
+		case event := <-events:
+			return event
+		default:
+			t.Fatal("missing event")
+			return BugChangeEvent{}
+		}
+	}
+
+	// local changes
+	b, _, err := cacheA.NewBug("title", "message")
+	require.NoError(t, err)
+	require.Equal(t, BugChangeEvent{Type: BugCreated, Id: b.Id()}, next(eventsA))
+
+	_, err = b.AddComment("comment")
+	require.NoError(t, err)
+	require.Equal(t, BugChangeEvent{Type: BugUpdated, Id: b.Id()}, next(eventsA))
+	require.NoError(t, b.Commit())
+	require.Equal(t, BugChangeEvent{Type: BugUpdated, Id: b.Id()}, next(eventsA))
+
+	// merged changes
+	_, err = cacheA.Push("origin")
+	require.NoError(t, err)
+	require.NoError(t, cacheB.Pull("origin"))
+	require.Equal(t, BugChangeEvent{Type: BugCreated, Id: b.Id()}, next(eventsB))
+
+	_, err = b.AddComment("another comment")
+	require.NoError(t, err)
+	require.NoError(t, b.Commit())
+	_, err = cacheA.Push("origin")
+	require.NoError(t, err)
+	require.NoError(t, cacheB.Pull("origin"))
+	require.Equal(t, BugChangeEvent{Type: BugUpdated, Id: b.Id()}, next(eventsB))
+
+	bugB, err := cacheB.ResolveBug(b.Id())
+	require.NoError(t, err)
+	require.Len(t, bugB.Snapshot().Comments, 3)
+
+	require.NoError(t, cacheB.RemoveBug(b.Id()))
+	require.Equal(t, BugChangeEvent{Type: BugRemoved, Id: b.Id()}, next(eventsB))
+
+	// unsubscribing close the channel
+	cacheA.Unsubscribe(eventsA)
+	for range eventsA {
+	}
+
+	// so does closing the cache
+	require.NoError(t, cacheB.Close())
+	_, ok := <-eventsB
+	require.False(t, ok)
+}
+
+func TestBugWatcher(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	rene, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	require.NoError(t, cache.SetUserIdentity(rene))
+
+	existing, _, err := cache.NewBug("existing", "message")
+	require.NoError(t, err)
+
+	watcher, err := NewBugWatcher(repo)
+	require.NoError(t, err)
+
+	events, err := watcher.Poll()
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	b, _, err := cache.NewBug("title", "message")
+	require.NoError(t, err)
+
+	events, err = watcher.Poll()
+	require.NoError(t, err)
+	require.Equal(t, []BugChangeEvent{{Type: BugCreated, Id: b.Id()}}, events)
+
+	// pending operations are not visible until committed
+	_, err = b.AddComment("comment")
+	require.NoError(t, err)
+	events, err = watcher.Poll()
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	require.NoError(t, b.Commit())
+	require.NoError(t, cache.RemoveBug(existing.Id()))
+
+	expected := []BugChangeEvent{
+		{Type: BugUpdated, Id: b.Id()},
+		{Type: BugRemoved, Id: existing.Id()},
+	}
+	if existing.Id() < b.Id() {
+		expected[0], expected[1] = expected[1], expected[0]
+	}
+
+	events, err = watcher.Poll()
+	require.NoError(t, err)
+	require.Equal(t, expected, events)
+}
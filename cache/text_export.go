@@ -0,0 +1,147 @@
+package cache
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/pkg/errors"
+)
+
+// TextFormat is a plain text format, to triage the bugs in a text editor
+type TextFormat string
+
+const (
+	// TextFormatOrg is an org-mode file, with a TODO or DONE heading per bug
+	TextFormatOrg TextFormat = "org"
+	// TextFormatTodoTxt is a todo.txt file, with a task per bug
+	TextFormatTodoTxt TextFormat = "todotxt"
+)
+
+// ParseTextFormat parse a TextFormat
+func ParseTextFormat(s string) (TextFormat, error) {
+	switch TextFormat(s) {
+	case TextFormatOrg, TextFormatTodoTxt:
+		return TextFormat(s), nil
+	}
+	return "", fmt.Errorf("unknown text format %s", s)
+}
+
+// textEntry is a bug as written in a text export. Only the id and the status
+// are read back.
+type textEntry struct {
+	Id        entity.Id
+	Closed    bool
+	Title     string
+	Labels    []bug.Label
+	Author    string
+	CreatedAt time.Time
+	Comments  int
+	Message   string
+}
+
+// TextImportResult summarize the changes made by ImportText
+type TextImportResult struct {
+	// number of bugs closed
+	Closed int
+	// number of bugs reopened
+	Reopened int
+}
+
+// ExportText write the given bugs in a plain text format, which can be edited
+// and read back with ImportText to change the status of the bugs.
+func (c *RepoCache) ExportText(w io.Writer, format TextFormat, ids []entity.Id) error {
+	entries := make([]textEntry, 0, len(ids))
+
+	for _, id := range ids {
+		b, err := c.ResolveBug(id)
+		if err != nil {
+			return err
+		}
+
+		snap := b.Snapshot()
+		entry := textEntry{
+			Id:        b.Id(),
+			Closed:    snap.Status == bug.ClosedStatus,
+			Title:     snap.Title,
+			Labels:    snap.Labels,
+			Author:    snap.Author.DisplayName(),
+			CreatedAt: snap.CreatedAt,
+			Comments:  len(snap.Comments),
+		}
+		if len(snap.Comments) > 0 {
+			entry.Message = snap.Comments[0].Message
+		}
+
+		entries = append(entries, entry)
+	}
+
+	switch format {
+	case TextFormatOrg:
+		return writeOrg(w, entries)
+	case TextFormatTodoTxt:
+		return writeTodoTxt(w, entries)
+	}
+	return fmt.Errorf("unknown text format %s", format)
+}
+
+// ImportText read back a text export made with ExportText and edited by the
+// user, and open or close the bugs whose status changed. The other edits are
+// ignored. The changes are authored by the user identity.
+//
+// The import is idempotent: a bug already having the status of the file is
+// left untouched.
+func (c *RepoCache) ImportText(r io.Reader, format TextFormat) (*TextImportResult, error) {
+	var entries []textEntry
+	var err error
+
+	switch format {
+	case TextFormatOrg:
+		entries, err = readOrg(r)
+	case TextFormatTodoTxt:
+		entries, err = readTodoTxt(r)
+	default:
+		return nil, fmt.Errorf("unknown text format %s", format)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid export")
+	}
+
+	// resolve everything first, to not apply a partial import
+	bugs := make([]*BugCache, len(entries))
+	for i, entry := range entries {
+		bugs[i], err = c.ResolveBug(entry.Id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "bug %s", entry.Id.Human())
+		}
+	}
+
+	result := &TextImportResult{}
+
+	for i, entry := range entries {
+		b := bugs[i]
+		closed := b.Snapshot().Status == bug.ClosedStatus
+
+		switch {
+		case entry.Closed && !closed:
+			_, err = b.Close()
+			result.Closed++
+		case !entry.Closed && closed:
+			_, err = b.Open()
+			result.Reopened++
+		default:
+			continue
+		}
+		if err != nil {
+			return result, err
+		}
+
+		if err := b.Commit(); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestExportImportText(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	cache, err := NewRepoCache(repo)
+	require.NoError(t, err)
+
+	rene, err := cache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	require.NoError(t, cache.SetUserIdentity(rene))
+
+	bug1, _, err := cache.NewBugRaw(rene, 1600000000, "first", "message\n* not a heading", nil, nil)
+	require.NoError(t, err)
+	_, _, err = bug1.ChangeLabels([]string{"good first issue"}, nil)
+	require.NoError(t, err)
+	require.NoError(t, bug1.Commit())
+
+	bug2, _, err := cache.NewBug("second", "message")
+	require.NoError(t, err)
+	_, err = bug2.Close()
+	require.NoError(t, err)
+	require.NoError(t, bug2.Commit())
+
+	status := func(b *BugCache) bug.Status {
+		return b.Snapshot().Status
+	}
+
+	for _, tc := range []struct {
+		format TextFormat
+		// turn the first bug from open to closed, and the second from closed
+		// to open
+		edit func(string) string
+	}{
+		{
+			format: TextFormatOrg,
+			edit: func(s string) string {
+				s = strings.Replace(s, "* TODO first", "* DONE first", 1)
+				s = strings.Replace(s, "* DONE second", "* TODO second", 1)
+				// the notes of the user are ignored
+				return s + "\n* TODO a note\n  :PROPERTIES:\n  :ID: 123e4567-e89b\n  :END:\n** TODO sub-task\n"
+			},
+		},
+		{
+			format: TextFormatTodoTxt,
+			edit: func(s string) string {
+				lines := strings.Split(s, "\n")
+				require.True(t, strings.HasPrefix(lines[1], "x "))
+				lines[0] = "x " + lines[0]
+				lines[1] = strings.TrimPrefix(lines[1], "x ")
+				return strings.Join(lines, "\n") + "call mom +home\n"
+			},
+		},
+	} {
+		t.Run(string(tc.format), func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			require.NoError(t, cache.ExportText(buf, tc.format, []entity.Id{bug1.Id(), bug2.Id()}))
+			exported := buf.String()
+
+			// unchanged
+			result, err := cache.ImportText(strings.NewReader(exported), tc.format)
+			require.NoError(t, err)
+			require.Equal(t, &TextImportResult{}, result)
+
+			result, err = cache.ImportText(strings.NewReader(tc.edit(exported)), tc.format)
+			require.NoError(t, err)
+			require.Equal(t, &TextImportResult{Closed: 1, Reopened: 1}, result)
+			require.Equal(t, bug.ClosedStatus, status(bug1))
+			require.Equal(t, bug.OpenStatus, status(bug2))
+
+			// back to the original state
+			result, err = cache.ImportText(strings.NewReader(exported), tc.format)
+			require.NoError(t, err)
+			require.Equal(t, &TextImportResult{Closed: 1, Reopened: 1}, result)
+			require.Equal(t, bug.OpenStatus, status(bug1))
+			require.Equal(t, bug.ClosedStatus, status(bug2))
+		})
+	}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, cache.ExportText(buf, TextFormatOrg, []entity.Id{bug1.Id()}))
+	require.Contains(t, buf.String(), "* TODO first :good_first_issue:\n")
+	require.Contains(t, buf.String(), "  message\n  * not a heading\n")
+
+	buf.Reset()
+	require.NoError(t, cache.ExportText(buf, TextFormatTodoTxt, []entity.Id{bug1.Id()}))
+	require.Equal(t, "2020-09-13 first +good_first_issue git-bug:"+bug1.Id().String()+"\n", buf.String())
+
+	// an unknown bug fail the whole import
+	unknown := strings.Replace(buf.String(), bug1.Id().String(), strings.Repeat("0", 64), 1)
+	_, err = cache.ImportText(strings.NewReader("x "+buf.String()+unknown), TextFormatTodoTxt)
+	require.Error(t, err)
+	require.Equal(t, bug.OpenStatus, status(bug1))
+}
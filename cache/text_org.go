@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+const (
+	orgTodo = "TODO"
+	orgDone = "DONE"
+)
+
+// the characters allowed in an org-mode tag
+var orgTagInvalid = regexp.MustCompile(`[^[:alnum:]_@#%]`)
+
+// a heading of any level
+var orgHeading = regexp.MustCompile(`^\*+ `)
+
+// an org-mode property line, like "  :ID: 1234"
+var orgProperty = regexp.MustCompile(`^\s*:([A-Za-z_-]+):\s*(.*?)\s*$`)
+
+// writeOrg write the bugs as the top level headings of an org-mode file:
+//
+//	#+TODO: TODO | DONE
+//	* TODO The title of the bug                  :label:
+//	  :PROPERTIES:
+//	  :ID:       <id of the bug>
+//	  :AUTHOR:   René Descartes
+//	  :CREATED:  [2020-01-01 Wed 10:00]
+//	  :COMMENTS: 3
+//	  :END:
+//	  The message of the bug
+//
+// The headings can be switched between TODO and DONE to close or reopen the
+// bugs, as long as the ID property stays.
+func writeOrg(w io.Writer, entries []textEntry) error {
+	bw := bufio.NewWriter(w)
+
+	_, _ = fmt.Fprintf(bw, "#+TITLE: git-bug\n")
+	_, _ = fmt.Fprintf(bw, "#+TODO: %s | %s\n", orgTodo, orgDone)
+
+	for _, entry := range entries {
+		keyword := orgTodo
+		if entry.Closed {
+			keyword = orgDone
+		}
+
+		heading := fmt.Sprintf("* %s %s", keyword, entry.Title)
+		if len(entry.Labels) > 0 {
+			tags := make([]string, len(entry.Labels))
+			for i, label := range entry.Labels {
+				tags[i] = orgTagInvalid.ReplaceAllString(label.String(), "_")
+			}
+			heading += " :" + strings.Join(tags, ":") + ":"
+		}
+
+		_, _ = fmt.Fprintf(bw, "\n%s\n", heading)
+		_, _ = fmt.Fprintf(bw, "  :PROPERTIES:\n")
+		_, _ = fmt.Fprintf(bw, "  :ID:       %s\n", entry.Id)
+		_, _ = fmt.Fprintf(bw, "  :AUTHOR:   %s\n", entry.Author)
+		_, _ = fmt.Fprintf(bw, "  :CREATED:  [%s]\n", entry.CreatedAt.Format("2006-01-02 Mon 15:04"))
+		_, _ = fmt.Fprintf(bw, "  :COMMENTS: %d\n", entry.Comments)
+		_, _ = fmt.Fprintf(bw, "  :END:\n")
+
+		// indented, so that the message can't be mistaken for a heading
+		message := strings.TrimSpace(entry.Message)
+		if message != "" {
+			_, _ = fmt.Fprintf(bw, "  %s\n", strings.Replace(message, "\n", "\n  ", -1))
+		}
+	}
+
+	return bw.Flush()
+}
+
+// readOrg read the top level headings holding the id of a bug in their ID
+// property. The other headings, like the notes of the user, are ignored.
+func readOrg(r io.Reader) ([]textEntry, error) {
+	var entries []textEntry
+	var current *textEntry
+	// whether the properties of the current heading are over
+	done := false
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if orgHeading.MatchString(line) {
+			done = true
+
+			if !strings.HasPrefix(line, "* ") {
+				// a sub-heading
+				continue
+			}
+
+			current = nil
+			done = false
+
+			fields := strings.Fields(line)
+			if len(fields) >= 2 && (fields[1] == orgTodo || fields[1] == orgDone) {
+				current = &textEntry{Closed: fields[1] == orgDone}
+			}
+			continue
+		}
+
+		if current == nil || done {
+			continue
+		}
+
+		match := orgProperty.FindStringSubmatch(line)
+		if match == nil || strings.ToUpper(match[1]) != "ID" {
+			continue
+		}
+
+		done = true
+
+		// not a bug, like the id of an org-mode entry
+		id := entity.Id(match[2])
+		if id.Validate() != nil {
+			continue
+		}
+
+		current.Id = id
+		entries = append(entries, *current)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,75 @@
+package cache
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+// the todo.txt key:value extension holding the id of a bug
+const todoTxtIdKey = "git-bug:"
+
+// writeTodoTxt write a task per bug, following the todo.txt conventions:
+//
+//	2020-01-01 The title of the bug +label git-bug:<id of the bug>
+//	x 2020-01-01 The title of a closed bug git-bug:<id of the bug>
+//
+// The tasks can be marked as completed with a leading "x" to close the bugs,
+// or the other way around to reopen them, as long as the id stays.
+func writeTodoTxt(w io.Writer, entries []textEntry) error {
+	bw := bufio.NewWriter(w)
+
+	for _, entry := range entries {
+		if entry.Closed {
+			_, _ = fmt.Fprint(bw, "x ")
+		}
+
+		_, _ = fmt.Fprintf(bw, "%s %s", entry.CreatedAt.Format("2006-01-02"), strings.Join(strings.Fields(entry.Title), " "))
+
+		// a project can't contain spaces
+		for _, label := range entry.Labels {
+			_, _ = fmt.Fprintf(bw, " +%s", strings.Join(strings.Fields(label.String()), "_"))
+		}
+
+		_, _ = fmt.Fprintf(bw, " %s%s\n", todoTxtIdKey, entry.Id)
+	}
+
+	return bw.Flush()
+}
+
+// readTodoTxt read the tasks holding the id of a bug. The other tasks are
+// ignored.
+func readTodoTxt(r io.Reader) ([]textEntry, error) {
+	var entries []textEntry
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1024*1024)
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+
+		var id entity.Id
+		for _, field := range fields {
+			if strings.HasPrefix(field, todoTxtIdKey) {
+				id = entity.Id(strings.TrimPrefix(field, todoTxtIdKey))
+			}
+		}
+		if id == "" || id.Validate() != nil {
+			continue
+		}
+
+		entries = append(entries, textEntry{
+			Id:     id,
+			Closed: len(fields) > 0 && fields[0] == "x",
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"sort"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/git"
+)
+
+// BugWatcher detect the changes of the bugs of a repository by comparing the
+// refs of the bugs between two polls.
+//
+// Contrary to Subscribe, it doesn't need a RepoCache and doesn't lock the
+// repository: it catches the changes made by any process, be it another
+// git-bug command or a git operation like a fetch.
+type BugWatcher struct {
+	repo  repository.Repo
+	heads map[entity.Id]git.Hash
+}
+
+// NewBugWatcher create a BugWatcher. The bugs already existing don't trigger
+// any event.
+func NewBugWatcher(repo repository.Repo) (*BugWatcher, error) {
+	heads, err := bug.ListLocalHeads(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BugWatcher{
+		repo:  repo,
+		heads: heads,
+	}, nil
+}
+
+// Poll return an event for each bug created, updated or removed since the
+// previous poll, ordered by id
+func (w *BugWatcher) Poll() ([]BugChangeEvent, error) {
+	heads, err := bug.ListLocalHeads(w.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []BugChangeEvent
+
+	for id, hash := range heads {
+		previous, ok := w.heads[id]
+		switch {
+		case !ok:
+			events = append(events, BugChangeEvent{Type: BugCreated, Id: id})
+		case previous != hash:
+			events = append(events, BugChangeEvent{Type: BugUpdated, Id: id})
+		}
+	}
+
+	for id := range w.heads {
+		if _, ok := heads[id]; !ok {
+			events = append(events, BugChangeEvent{Type: BugRemoved, Id: id})
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Id < events[j].Id
+	})
+
+	w.heads = heads
+
+	return events, nil
+}
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/MichaelMure/git-bug/util/text"
+)
+
+func runAcl(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	policy, err := backend.Policy()
+	if err != nil {
+		return err
+	}
+
+	for _, perm := range bug.Permissions {
+		granted, restricted := policy.Allowed(perm)
+
+		var who string
+		switch {
+		case !restricted:
+			who = colors.Green("anyone")
+		case len(granted) == 0:
+			who = colors.Red("no one")
+		default:
+			names := make([]string, len(granted))
+			for i, id := range granted {
+				names[i] = id.Human()
+				if excerpt, err := backend.ResolveIdentityExcerpt(id); err == nil {
+					names[i] = fmt.Sprintf("%s (%s)", excerpt.DisplayName(), id.Human())
+				}
+			}
+			who = strings.Join(names, ", ")
+		}
+
+		fmt.Printf("%s\t%s\n", text.LeftPadMaxLine(string(perm), 15, 0), who)
+	}
+
+	return nil
+}
+
+var aclCmd = &cobra.Command{
+	Use:   "acl",
+	Short: "Show and configure the access control policy of the repository.",
+	Long: `Show and configure the access control policy of the repository.
+
+For a repository shared on a server with a wider audience, closing or reopening bugs (close), changing labels (label) and editing or deleting the comments of someone else (edit-comments) can each be restricted to a list of identities. Everyone can still comment and edit their own comments.
+
+The policy is enforced by the GraphQL API of "git bug webui", and on the bugs pulled from the remotes: a remote bug holding an operation not allowed is rejected as a whole. As the author of an operation can be claimed by anyone, the restricted operations pulled from the remotes must be signed with a key of their author, and the GraphQL API only accepts mutations authored by the identity of an API token or the one given with "git bug webui --identity". The operations made locally are not restricted, as whoever has access to the repository can write in it anyway.
+
+Without sub-command, the permissions are listed with the identities granted them. The policy is stored in the git config of the repository, as git-bug.acl.<permission>.`,
+	Example: `git bug acl
+git bug acl grant close rene isaac
+git bug acl revoke close isaac
+git bug acl unrestrict close`,
+	PreRunE: loadRepo,
+	RunE:    runAcl,
+	Args:    cobra.NoArgs,
+}
+
+func init() {
+	RootCmd.AddCommand(aclCmd)
+}
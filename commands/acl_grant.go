@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+)
+
+func runAclGrant(cmd *cobra.Command, args []string) error {
+	perm, err := bug.ParsePermission(args[0])
+	if err != nil {
+		return err
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	for _, user := range args[1:] {
+		i, err := backend.ResolveIdentityUser(user)
+		if err != nil {
+			return err
+		}
+
+		err = bug.Grant(backend, perm, i.Id())
+		if err != nil {
+			return err
+		}
+
+		_, _ = fmt.Fprintf(os.Stderr, "Granted %s to %s\n", perm, i.DisplayName())
+	}
+
+	return nil
+}
+
+var aclGrantCmd = &cobra.Command{
+	Use:   "grant <permission> <user>...",
+	Short: "Grant a permission to some identities.",
+	Long: `Grant a permission to some identities, given by login, name, email or id prefix, or "me".
+
+The permission is one of close, label or edit-comments. Once granted to someone, the permission is restricted: everyone else is denied it.`,
+	Example: `git bug acl grant label rene isaac`,
+	PreRunE: loadRepo,
+	RunE:    runAclGrant,
+	Args:    cobra.MinimumNArgs(2),
+}
+
+func init() {
+	aclCmd.AddCommand(aclGrantCmd)
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+)
+
+func runAclRevoke(cmd *cobra.Command, args []string) error {
+	perm, err := bug.ParsePermission(args[0])
+	if err != nil {
+		return err
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	for _, user := range args[1:] {
+		i, err := backend.ResolveIdentityUser(user)
+		if err != nil {
+			return err
+		}
+
+		err = bug.Revoke(backend, perm, i.Id())
+		if err != nil {
+			return err
+		}
+
+		_, _ = fmt.Fprintf(os.Stderr, "Revoked %s from %s\n", perm, i.DisplayName())
+	}
+
+	return nil
+}
+
+var aclRevokeCmd = &cobra.Command{
+	Use:   "revoke <permission> <user>...",
+	Short: "Revoke a permission from some identities.",
+	Long: `Revoke a permission from some identities, given by login, name, email or id prefix, or "me".
+
+The permission stays restricted, even once revoked from everyone. Use "git bug acl unrestrict" to allow it to everyone again.`,
+	Example: `git bug acl revoke label isaac`,
+	PreRunE: loadRepo,
+	RunE:    runAclRevoke,
+	Args:    cobra.MinimumNArgs(2),
+}
+
+func init() {
+	aclCmd.AddCommand(aclRevokeCmd)
+}
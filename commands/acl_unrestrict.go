@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+)
+
+func runAclUnrestrict(cmd *cobra.Command, args []string) error {
+	perm, err := bug.ParsePermission(args[0])
+	if err != nil {
+		return err
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	err = bug.Unrestrict(backend, perm)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(os.Stderr, "Allowed %s to anyone\n", perm)
+
+	return nil
+}
+
+var aclUnrestrictCmd = &cobra.Command{
+	Use:     "unrestrict <permission>",
+	Short:   "Allow a permission to anyone.",
+	Example: `git bug acl unrestrict label`,
+	PreRunE: loadRepo,
+	RunE:    runAclUnrestrict,
+	Args:    cobra.ExactArgs(1),
+}
+
+func init() {
+	aclCmd.AddCommand(aclUnrestrictCmd)
+}
@@ -3,6 +3,7 @@ package commands
 import (
 	"fmt"
 
+	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/input"
 	"github.com/MichaelMure/git-bug/util/interrupt"
@@ -13,6 +14,8 @@ var (
 	addTitle       string
 	addMessage     string
 	addMessageFile string
+	addLabels      []string
+	addProject     string
 )
 
 func runAddBug(cmd *cobra.Command, args []string) error {
@@ -25,14 +28,38 @@ func runAddBug(cmd *cobra.Command, args []string) error {
 	defer backend.Close()
 	interrupt.RegisterCleaner(backend.Close)
 
-	if addMessageFile != "" && addMessage == "" {
+	// by default, the bug is created in the current project
+	if !cmd.Flags().Changed("project") {
+		addProject, err = backend.CurrentProject()
+		if err != nil {
+			return err
+		}
+	}
+	if addProject != "" {
+		if err := bug.ValidateProject(addProject); err != nil {
+			return err
+		}
+	}
+
+	// with a title given, the file only hold the message
+	if addMessageFile != "" && addMessage == "" && addTitle != "" {
+		addMessage, err = input.BugCommentFileInput(addMessageFile)
+		if err != nil && err != input.ErrEmptyMessage {
+			return err
+		}
+	}
+
+	if addMessageFile != "" && addMessage == "" && addTitle == "" {
 		addTitle, addMessage, err = input.BugCreateFileInput(addMessageFile)
 		if err != nil {
 			return err
 		}
 	}
 
-	if addMessageFile == "" && (addMessage == "" || addTitle == "") {
+	// an explicitly empty message doesn't need an editor
+	messageGiven := addMessage != "" || cmd.Flags().Changed("message")
+
+	if addMessageFile == "" && (!messageGiven || addTitle == "") {
 		addTitle, addMessage, err = input.BugCreateEditorInput(backend, addTitle, addMessage)
 
 		if err == input.ErrEmptyTitle {
@@ -51,12 +78,40 @@ func runAddBug(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("%s created\n", b.Id().Human())
 
-	return nil
+	if len(addLabels) > 0 {
+		changes, _, err := b.ChangeLabels(addLabels, nil)
+		for _, change := range changes {
+			fmt.Println(change)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if addProject != "" {
+		_, err = b.SetProject(addProject)
+		if err != nil {
+			return err
+		}
+	}
+
+	return b.CommitAsNeeded()
 }
 
 var addCmd = &cobra.Command{
-	Use:     "add",
-	Short:   "Create a new bug.",
+	Use:   "add",
+	Short: "Create a new bug.",
+	Long: `Create a new bug.
+
+Without a title and a message, an editor is opened to write them. When a title is given, the file given with --file only hold the message.
+
+The bug is created in the current project, if any (see "git bug project"), unless another one is given with --project.`,
+	Example: `Create a bug with an editor:
+git bug add
+
+Create a bug from a script, reading the message from the standard input:
+echo "It crashes" | git bug add --title "Crash on startup" --file - --label bug
+`,
 	PreRunE: loadRepo,
 	RunE:    runAddBug,
 }
@@ -75,4 +130,11 @@ func init() {
 	addCmd.Flags().StringVarP(&addMessageFile, "file", "F", "",
 		"Take the message from the given file. Use - to read the message from the standard input",
 	)
+	addCmd.Flags().StringSliceVarP(&addLabels, "label", "l", nil,
+		"Add a label to the new bug",
+	)
+	_ = addCmd.MarkFlagCustom("label", "__git-bug_complete_label")
+	addCmd.Flags().StringVarP(&addProject, "project", "p", "",
+		"Create the bug in this project instead of the current one. An empty value create it outside of any project",
+	)
 }
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/graphql/apitoken"
+	"github.com/MichaelMure/git-bug/util/colors"
+)
+
+func runAPIToken(cmd *cobra.Command, args []string) error {
+	tokens, err := apitoken.List(repo)
+	if err != nil {
+		return err
+	}
+
+	for _, token := range tokens {
+		identity := ""
+		if token.Identity != "" {
+			identity = " as " + token.Identity.Human()
+		}
+
+		fmt.Printf("%s %s\t%s%s\t%s\n",
+			colors.Cyan(token.Id),
+			colors.Yellow(token.Scope),
+			token.Name,
+			identity,
+			token.CreatedAt.Format("Jan 2 2006"),
+		)
+	}
+
+	return nil
+}
+
+var apiTokenCmd = &cobra.Command{
+	Use:   "api-token",
+	Short: "List, create and revoke the tokens of the GraphQL API.",
+	Long: `List, create and revoke the tokens of the GraphQL API.
+
+An API token give access to the GraphQL API served by "git bug webui" to an automation, without giving it access to the repository. A token is limited to a scope: read only allow the queries, write also allow the changes. The changes can be authored by an identity of the token instead of the identity of the server.
+
+The token is given as a bearer token, in an "Authorization: Bearer <token>" header. Only a hash of the tokens is stored, in the git config of the repository.`,
+	PreRunE: loadRepo,
+	RunE:    runAPIToken,
+	Args:    cobra.NoArgs,
+}
+
+func init() {
+	RootCmd.AddCommand(apiTokenCmd)
+}
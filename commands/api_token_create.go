@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/graphql/apitoken"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+)
+
+var (
+	apiTokenCreateScope    string
+	apiTokenCreateIdentity string
+)
+
+func runAPITokenCreate(cmd *cobra.Command, args []string) error {
+	scope, err := apitoken.ParseScope(apiTokenCreateScope)
+	if err != nil {
+		return err
+	}
+
+	var name string
+	if len(args) > 0 {
+		name = args[0]
+	}
+
+	var identity entity.Id
+	if apiTokenCreateIdentity != "" {
+		backend, err := cache.NewRepoCache(repo)
+		if err != nil {
+			return err
+		}
+		defer backend.Close()
+		interrupt.RegisterCleaner(backend.Close)
+
+		i, err := backend.ResolveIdentityPrefix(apiTokenCreateIdentity)
+		if err != nil {
+			return err
+		}
+		identity = i.Id()
+	}
+
+	secret, token, err := apitoken.Create(repo, name, scope, identity)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(os.Stderr, "API token %s created with the %s scope, it won't be displayed again:\n", token.Id, token.Scope)
+	fmt.Println(secret)
+
+	return nil
+}
+
+var apiTokenCreateCmd = &cobra.Command{
+	Use:   "create [<name>]",
+	Short: "Create a new API token.",
+	Example: `A token for a dashboard:
+git bug api-token create dashboard --scope read
+
+A token for a CI, writing as its own identity:
+git bug api-token create ci --scope write --identity 7e4a3bb
+`,
+	PreRunE: loadRepo,
+	RunE:    runAPITokenCreate,
+	Args:    cobra.MaximumNArgs(1),
+}
+
+func init() {
+	apiTokenCmd.AddCommand(apiTokenCreateCmd)
+
+	apiTokenCreateCmd.Flags().SortFlags = false
+
+	apiTokenCreateCmd.Flags().StringVarP(&apiTokenCreateScope, "scope", "s", "read",
+		"What the token allow. Valid values are [read,write]")
+	apiTokenCreateCmd.Flags().StringVarP(&apiTokenCreateIdentity, "identity", "i", "",
+		"Author the changes made with the token with the identity matching this id prefix")
+}
@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/graphql/apitoken"
+)
+
+func runAPITokenRm(cmd *cobra.Command, args []string) error {
+	token, err := apitoken.Remove(repo, args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("API token %s revoked\n", token.Id)
+
+	return nil
+}
+
+var apiTokenRmCmd = &cobra.Command{
+	Use:     "rm <id>",
+	Short:   "Revoke an API token.",
+	PreRunE: loadRepo,
+	RunE:    runAPITokenRm,
+	Args:    cobra.ExactArgs(1),
+}
+
+func init() {
+	apiTokenCmd.AddCommand(apiTokenRmCmd)
+}
@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	assignClear bool
+)
+
+func runAssign(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	return applyToBugs(backend, args, "Assign", func(b *cache.BugCache, args []string) error {
+		if len(args) > 1 {
+			return errors.New("only one user can be assigned")
+		}
+
+		snap := b.Snapshot()
+
+		// display the assignee
+		if len(args) == 0 && !assignClear {
+			if bulkQuery != "" {
+				return errors.New("you must provide a user or use --clear")
+			}
+			if snap.Assignee != "" {
+				i, err := backend.ResolveIdentityExcerpt(snap.Assignee)
+				if err != nil {
+					return err
+				}
+				fmt.Println(i.DisplayName())
+			}
+			return nil
+		}
+
+		if len(args) == 1 && assignClear {
+			return errors.New("a user can't be given along with --clear")
+		}
+
+		var assignee *cache.IdentityCache
+		if !assignClear {
+			assignee, err = backend.ResolveIdentityUser(args[0])
+			if err != nil {
+				return err
+			}
+		}
+
+		if assignee == nil && snap.Assignee == "" ||
+			assignee != nil && snap.Assignee == assignee.Id() {
+			// no change, only an error for a single bug
+			if bulkQuery != "" {
+				return nil
+			}
+			return errors.New("no change")
+		}
+
+		_, err = b.SetAssignee(assignee)
+		if err != nil {
+			return err
+		}
+
+		return b.Commit()
+	})
+}
+
+// resolveIdentity retrieve an identity from what the user typed, in the same
+// way as in the queries: "me" for the user identity, a login, or else an id
+// prefix.
+var assignCmd = &cobra.Command{
+	Use:   "assign [<id>] [<user>]",
+	Short: "Display or change the assignee of a bug.",
+	Long: `Display or change the assignee of a bug.
+
+The user is given as "me" for the user identity, as a login or as an identity id prefix.`,
+	Example: `Assign the selected bug to yourself:
+git bug assign me
+
+Unassign a bug:
+git bug assign 5f8a3b2 --clear
+
+Assign all the open bugs of a milestone without assignee:
+git bug assign --query "status:open milestone:v1.2 no:assignee" descartes
+`,
+	PreRunE: loadRepo,
+	RunE:    runAssign,
+}
+
+func init() {
+	RootCmd.AddCommand(assignCmd)
+
+	assignCmd.Flags().SortFlags = false
+
+	assignCmd.Flags().BoolVarP(&assignClear, "clear", "c", false,
+		"Remove the assignee")
+	addBulkFlags(assignCmd)
+}
@@ -0,0 +1,188 @@
+package commands
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/action"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+)
+
+// batchCommand is a single change to apply to a bug, either read from a line
+// of words or from a JSON line
+type batchCommand struct {
+	Id     string   `json:"id"`
+	Action string   `json:"action"`
+	Args   []string `json:"args"`
+}
+
+func runBatch(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	commands, err := readBatchCommands(bufio.NewScanner(os.Stdin))
+	if err != nil {
+		return err
+	}
+
+	// the bugs in the order they are first changed, to commit them in the
+	// same order
+	var touched []*cache.BugCache
+	bugs := make(map[string]*cache.BugCache)
+
+	discard := func() {
+		for _, b := range touched {
+			_ = b.DiscardPendingOps()
+		}
+	}
+
+	var changes int
+
+	for _, c := range commands {
+		b, ok := bugs[c.Id]
+		if !ok {
+			b, err = backend.ResolveBugPrefix(c.Id)
+			if err != nil {
+				discard()
+				return errors.Wrapf(err, "line %d", c.line)
+			}
+			bugs[c.Id] = b
+		}
+
+		changed, err := c.action().Apply(backend, b)
+		if err != nil {
+			discard()
+			return errors.Wrapf(err, "line %d", c.line)
+		}
+		if !changed {
+			continue
+		}
+
+		changes++
+		if !containsBug(touched, b) {
+			touched = append(touched, b)
+		}
+	}
+
+	if err := backend.CommitBugs(touched); err != nil {
+		return err
+	}
+
+	fmt.Printf("%d change(s) applied to %d bug(s)\n", changes, len(touched))
+
+	return nil
+}
+
+type numberedBatchCommand struct {
+	batchCommand
+	line int
+}
+
+// readBatchCommands parse all the commands first, so that nothing is applied
+// if one of them is invalid
+func readBatchCommands(scanner *bufio.Scanner) ([]numberedBatchCommand, error) {
+	var result []numberedBatchCommand
+
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var c batchCommand
+
+		if strings.HasPrefix(line, "{") {
+			if err := json.Unmarshal([]byte(line), &c); err != nil {
+				return nil, errors.Wrapf(err, "line %d", n)
+			}
+		} else {
+			words := action.SplitWords(line)
+			if len(words) < 2 {
+				return nil, fmt.Errorf("line %d: expected a bug id and an action", n)
+			}
+			c = batchCommand{Id: words[0], Action: words[1], Args: words[2:]}
+		}
+
+		if err := validateBatchCommand(c); err != nil {
+			return nil, errors.Wrapf(err, "line %d", n)
+		}
+
+		result = append(result, numberedBatchCommand{batchCommand: c, line: n})
+	}
+
+	return result, scanner.Err()
+}
+
+func validateBatchCommand(c batchCommand) error {
+	if c.Id == "" {
+		return fmt.Errorf("missing bug id")
+	}
+
+	return c.action().Validate()
+}
+
+func (c batchCommand) action() action.Action {
+	return action.Action{Name: c.Action, Args: c.Args}
+}
+
+func containsBug(bugs []*cache.BugCache, b *cache.BugCache) bool {
+	for _, other := range bugs {
+		if other == b {
+			return true
+		}
+	}
+	return false
+}
+
+var batchCmd = &cobra.Command{
+	Use:   "batch",
+	Short: "Apply a stream of changes read from the standard input.",
+	Long: `Apply a stream of changes read from the standard input, one per line.
+
+All the changes are checked before any is applied, then each changed bug is committed once at the end. If a change fails, none is committed.
+
+A change is given either as words, double quotes grouping words:
+  <id> open
+  <id> close
+  <id> comment <message>
+  <id> title <title>
+  <id> label add <label>...
+  <id> label rm <label>...
+  <id> assign <user>
+  <id> unassign
+  <id> milestone [<milestone>]
+
+or as a JSON object, for example to give a multi-line message:
+  {"id": "<id>", "action": "comment", "args": ["<message>"]}
+
+The user is given as "me", a login or an identity id prefix. Empty lines and lines starting with # are ignored. A change that would do nothing, like closing a closed bug, is skipped.`,
+	Example: `Triage bugs from a file:
+git bug batch < triage.txt
+
+Close all the bugs listed by a query, with a comment:
+git bug ls --porcelain label:wontfix | cut -f1 | while read id; do
+  echo "$id comment \"Closing as won't fix\""
+  echo "$id close"
+done | git bug batch
+`,
+	PreRunE: loadRepo,
+	RunE:    runBatch,
+	Args:    cobra.NoArgs,
+}
+
+func init() {
+	RootCmd.AddCommand(batchCmd)
+
+	batchCmd.Flags().SortFlags = false
+}
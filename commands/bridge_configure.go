@@ -122,7 +122,12 @@ var bridgeConfigureCmd = &cobra.Command{
 	Short: "Configure a new bridge.",
 	Long: `	Configure a new bridge by passing flags or/and using interactive terminal prompts. You can avoid all the terminal prompts by passing all the necessary flags to configure your bridge.
 	Repository configuration can be made by passing either the --url flag or the --project and --owner flags. If the three flags are provided git-bug will use --project and --owner flags.
-	Token configuration can be directly passed with the --token flag or in the terminal prompt. If you don't already have one you can use the interactive procedure to generate one.`,
+	Token configuration can be directly passed with the --token flag or in the terminal prompt. If you don't already have one you can use the interactive procedure to generate one.
+	Labels and statuses can be translated between the remote and git-bug with the git-bug.bridge.<name>.label-mapping (e.g. "kind/bug=bug,kind/feature=enhancement") and git-bug.bridge.<name>.status-mapping (e.g. "not_planned=closed+wontfix,In Progress=open+wip") git config keys. The remote statuses are open, closed, completed and not_planned for github; open, closed, closed via merge request and closed via commit for gitlab; and the statuses of launchpad, like "Fix Committed".
+
+With --local-project, the bridge is bound to a git-bug project (see "git bug project"): the imported bugs are moved into this project, and only its bugs are exported. This allows to track several remote repositories in a single git-bug repository. This is stored in the git-bug.bridge.<name>.local-project git config key.
+
+By default, an import abort at the first issue failing to import. Set the git-bug.bridge.<name>.error-policy git config key to "skip" to report the failing issues and continue with the next ones.`,
 	Example: `# Interactive example
 [1]: github
 [2]: launchpad-preview
@@ -187,5 +192,6 @@ func init() {
 	bridgeConfigureCmd.Flags().StringVarP(&bridgeParams.Token, "token", "T", "", "The authentication token for the API")
 	bridgeConfigureCmd.Flags().BoolVar(&bridgeParams.TokenStdin, "token-stdin", false, "Will read the token from stdin and ignore --token")
 	bridgeConfigureCmd.Flags().StringVarP(&bridgeParams.Project, "project", "p", "", "The name of the target repository")
+	bridgeConfigureCmd.Flags().StringVar(&bridgeParams.LocalProject, "local-project", "", "The git-bug project receiving the imported bugs, and holding the bugs to export")
 	bridgeConfigureCmd.Flags().SortFlags = false
 }
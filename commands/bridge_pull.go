@@ -3,11 +3,14 @@ package commands
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
 
 	"github.com/MichaelMure/git-bug/bridge"
 	"github.com/MichaelMure/git-bug/bridge/core"
@@ -15,6 +18,11 @@ import (
 	"github.com/MichaelMure/git-bug/util/interrupt"
 )
 
+var (
+	bridgePullDryRun     bool
+	bridgePullSkipErrors bool
+)
+
 func runBridgePull(cmd *cobra.Command, args []string) error {
 	backend, err := cache.NewRepoCache(repo)
 	if err != nil {
@@ -35,6 +43,13 @@ func runBridgePull(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if bridgePullSkipErrors {
+		err = b.SetErrorPolicy(core.ErrorPolicySkip)
+		if err != nil {
+			return err
+		}
+	}
+
 	parentCtx := context.Background()
 	ctx, cancel := context.WithCancel(parentCtx)
 	defer cancel()
@@ -65,34 +80,101 @@ func runBridgePull(cmd *cobra.Command, args []string) error {
 	})
 
 	// TODO: by default import only new events
-	events, err := b.ImportAll(ctx, time.Time{})
+	var events <-chan core.ImportResult
+	if bridgePullDryRun {
+		events, err = b.ImportAllDryRun(ctx, time.Time{})
+	} else {
+		events, err = b.ImportAll(ctx, time.Time{})
+	}
 	if err != nil {
 		return err
 	}
 
-	importedIssues := 0
-	importedIdentities := 0
+	// on a terminal, render a progress bar instead of printing every event
+	isTerminal := terminal.IsTerminal(int(os.Stdout.Fd()))
+	bar := &progressBar{}
+
+	counts := make(map[core.ImportEvent]int)
 	for result := range events {
-		if result.Event != core.ImportEventNothing {
-			fmt.Println(result.String())
-		}
+		counts[result.Event]++
 
-		switch result.Event {
-		case core.ImportEventBug:
-			importedIssues++
-		case core.ImportEventIdentity:
-			importedIdentities++
+		switch {
+		case result.Event == core.ImportEventProgress && isTerminal:
+			bar.render(os.Stdout, result.Current, result.Total)
+		case result.Event == core.ImportEventError || result.Event == core.ImportEventRateLimiting:
+			bar.clear(os.Stdout)
+			fmt.Println(result.String())
+		case result.Event != core.ImportEventNothing && !isTerminal:
+			fmt.Println(result.String())
 		}
 	}
 
+	bar.clear(os.Stdout)
+
 	// send done signal
 	close(done)
 
-	fmt.Printf("Successfully imported %d issues and %d identities with %s bridge\n", importedIssues, importedIdentities, b.Name)
+	if bridgePullDryRun {
+		fmt.Printf("Dry run: would import %d issues, %d comments, %d comment editions, %d status changes, %d title editions, %d label changes and %d identities with %s bridge\n",
+			counts[core.ImportEventBug],
+			counts[core.ImportEventComment],
+			counts[core.ImportEventCommentEdition],
+			counts[core.ImportEventStatusChange],
+			counts[core.ImportEventTitleEdition],
+			counts[core.ImportEventLabelChange],
+			counts[core.ImportEventIdentity],
+			b.Name,
+		)
+		return nil
+	}
+
+	fmt.Printf("Successfully imported %d issues and %d identities with %s bridge\n", counts[core.ImportEventBug], counts[core.ImportEventIdentity], b.Name)
+
+	if counts[core.ImportEventError] > 0 && bridgePullSkipErrors {
+		fmt.Printf("%d issues failed to import and were skipped\n", counts[core.ImportEventError])
+	}
 
 	return nil
 }
 
+// progressBar render an updatable progress bar on a single terminal line
+type progressBar struct {
+	lastLen int
+}
+
+const progressBarWidth = 40
+
+func (pb *progressBar) render(w io.Writer, current int, total int) {
+	var line string
+
+	if total > 0 {
+		if current > total {
+			total = current
+		}
+		filled := progressBarWidth * current / total
+		line = fmt.Sprintf("[%s%s] %d/%d issues",
+			strings.Repeat("=", filled),
+			strings.Repeat(" ", progressBarWidth-filled),
+			current, total,
+		)
+	} else {
+		line = fmt.Sprintf("%d issues processed", current)
+	}
+
+	pb.clear(w)
+	_, _ = fmt.Fprint(w, line)
+	pb.lastLen = len(line)
+}
+
+// clear erase the progress bar so that regular output can be printed
+func (pb *progressBar) clear(w io.Writer) {
+	if pb.lastLen == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "\r%s\r", strings.Repeat(" ", pb.lastLen))
+	pb.lastLen = 0
+}
+
 var bridgePullCmd = &cobra.Command{
 	Use:     "pull [<name>]",
 	Short:   "Pull updates.",
@@ -103,4 +185,9 @@ var bridgePullCmd = &cobra.Command{
 
 func init() {
 	bridgeCmd.AddCommand(bridgePullCmd)
+
+	bridgePullCmd.Flags().SortFlags = false
+
+	bridgePullCmd.Flags().BoolVar(&bridgePullDryRun, "dry-run", false, "Run the import against a throwaway copy of the repository and report what would be imported")
+	bridgePullCmd.Flags().BoolVar(&bridgePullSkipErrors, "skip-errors", false, "Report the issues failing to import and continue with the next ones instead of aborting")
 }
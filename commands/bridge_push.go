@@ -69,22 +69,29 @@ func runBridgePush(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	exportedIssues := 0
+	counts := make(map[core.ExportEvent]int)
 	for result := range events {
-		if result.Event != core.ExportEventNothing {
-			fmt.Println(result.String())
-		}
+		counts[result.Event]++
 
 		switch result.Event {
-		case core.ExportEventBug:
-			exportedIssues++
+		case core.ExportEventNothing, core.ExportEventProgress:
+		default:
+			fmt.Println(result.String())
 		}
 	}
 
 	// send done signal
 	close(done)
 
-	fmt.Printf("Successfully exported %d issues with %s bridge\n", exportedIssues, b.Name)
+	fmt.Printf("Successfully exported %d issues, %d comments, %d comment editions, %d status changes, %d title editions and %d label changes with %s bridge\n",
+		counts[core.ExportEventBug],
+		counts[core.ExportEventComment],
+		counts[core.ExportEventCommentEdition],
+		counts[core.ExportEventStatusChange],
+		counts[core.ExportEventTitleEdition],
+		counts[core.ExportEventLabelChange],
+		b.Name,
+	)
 	return nil
 }
 
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bridge"
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+)
+
+func runBridgeStatus(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	names := args
+	if len(names) == 0 {
+		names, err = bridge.ConfiguredBridges(backend)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(names) == 0 {
+		fmt.Println("No configured bridge.")
+		return nil
+	}
+
+	for i, name := range names {
+		b, err := bridge.LoadBridge(backend, name)
+		if err != nil {
+			return err
+		}
+
+		status, err := b.Status(context.Background())
+		if err != nil {
+			return err
+		}
+
+		if i > 0 {
+			fmt.Println()
+		}
+		printBridgeStatus(status)
+	}
+
+	return nil
+}
+
+func printBridgeStatus(status *core.Status) {
+	fmt.Printf("%s (%s)\n", colors.Cyan(status.Name), status.Target)
+
+	switch {
+	case status.RemoteErr != nil:
+		fmt.Printf("  remote:      %s\n", colors.Red(fmt.Sprintf("error: %v", status.RemoteErr)))
+	case status.Remote == nil:
+		fmt.Printf("  remote:      not checked\n")
+	default:
+		fmt.Printf("  remote:      %s, authenticated as %s\n", colors.Green("ok"), status.Remote.Login)
+		if status.Remote.QuotaLimit > 0 {
+			fmt.Printf("  quota:       %d/%d remaining, reset %s\n",
+				status.Remote.QuotaRemaining,
+				status.Remote.QuotaLimit,
+				humanize.Time(status.Remote.QuotaReset),
+			)
+		}
+	}
+
+	fmt.Printf("  last import: %s\n", formatSyncTime(status.LastImport))
+	fmt.Printf("  last export: %s\n", formatSyncTime(status.LastExport))
+	fmt.Printf("  bugs:        %d\n", status.BugCount)
+}
+
+func formatSyncTime(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return fmt.Sprintf("%s (%s)", t.Format(time.RFC1123), humanize.Time(t))
+}
+
+var bridgeStatusCmd = &cobra.Command{
+	Use:   "status [<name>...]",
+	Short: "Show the health of the configured bridges.",
+	Long: `Show the health of the configured bridges.
+
+For each bridge, the credentials are validated against the remote, and the remaining API quota, the time of the last successful import and export and the number of local bugs bound to the remote are reported.`,
+	PreRunE: loadRepo,
+	RunE:    runBridgeStatus,
+}
+
+func init() {
+	bridgeCmd.AddCommand(bridgeStatusCmd)
+}
@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/input"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	bulkQuery string
+	bulkYes   bool
+)
+
+// addBulkFlags add the flags to apply a command to all the bugs matching a
+// query instead of a single one
+func addBulkFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&bulkQuery, "query", "q", "",
+		"Apply to all the bugs matching the query instead of a single bug")
+	cmd.Flags().BoolVarP(&bulkYes, "yes", "y", false,
+		"Don't ask for confirmation before applying to the bugs matching the query")
+}
+
+// applyToBugs run a command on the bugs it applies to. With the --query flag,
+// those are all the bugs matching the query, once the user confirmed, and the
+// arguments are left untouched. Otherwise, this is the single bug given as the
+// first argument, or the selected bug, and the remaining arguments are given.
+func applyToBugs(backend *cache.RepoCache, args []string, action string, apply func(b *cache.BugCache, args []string) error) error {
+	if bulkQuery == "" {
+		b, args, err := _select.ResolveBug(backend, args)
+		if err != nil {
+			return err
+		}
+		return apply(b, args)
+	}
+
+	query, err := backend.ParseQuery(bulkQuery)
+	if err != nil {
+		return err
+	}
+
+	ids := backend.QueryBugs(query)
+	if len(ids) == 0 {
+		fmt.Println("No matching bug.")
+		return nil
+	}
+
+	if !bulkYes {
+		for _, id := range ids {
+			excerpt, err := backend.ResolveBugExcerpt(id)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s %s\n", colors.Cyan(id.Human()), excerpt.Title)
+		}
+
+		ok, err := input.PromptConfirm(fmt.Sprintf("%s these %d bug(s)?", action, len(ids)))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	for _, id := range ids {
+		b, err := backend.ResolveBug(id)
+		if err != nil {
+			return err
+		}
+
+		if err := apply(b, args); err != nil {
+			return errors.Wrapf(err, "bug %s", id.Human())
+		}
+	}
+
+	return nil
+}
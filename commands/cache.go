@@ -0,0 +1,21 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the git-bug cache.",
+	Long: `Manage the git-bug cache.
+
+git-bug maintain a cache of the bugs and identities, stored in .git/git-bug. This cache is kept up to date automatically, and only the bugs whose git references changed are read again when starting. These commands are an escape hatch in case something went wrong.
+
+To bound the memory usage of long running processes, at most 1000 bugs are kept loaded in memory. This limit can be changed with the git config key git-bug.cache.max-loaded-bugs, 0 meaning no limit.
+
+By default, all the creation metadata of the bugs are kept in the cache. To reduce its size, the git config key git-bug.cache.metadata-keys can hold a comma separated list of key prefixes to keep instead (ex: "github-,gitlab-,launchpad-"). The cache needs to be rebuilt after changing this setting.`,
+}
+
+func init() {
+	RootCmd.AddCommand(cacheCmd)
+}
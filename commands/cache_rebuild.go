@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+)
+
+func runCacheRebuild(cmd *cobra.Command, args []string) error {
+	backend, err := cache.RebuildRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	fmt.Printf("Cache rebuilt: %d bugs, %d identities\n",
+		len(backend.AllBugsIds()), len(backend.AllIdentityIds()))
+
+	return nil
+}
+
+var cacheRebuildCmd = &cobra.Command{
+	Use:     "rebuild",
+	Short:   "Discard the cache and build it again from scratch.",
+	PreRunE: loadRepo,
+	RunE:    runCacheRebuild,
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheRebuildCmd)
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/cache"
+)
+
+var (
+	cacheUnlockForce bool
+)
+
+func runCacheUnlock(cmd *cobra.Command, args []string) error {
+	err := cache.UnlockRepo(repo, cacheUnlockForce)
+	if err == cache.ErrNotLocked {
+		fmt.Println("The repository is not locked.")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Lock removed.")
+	return nil
+}
+
+var cacheUnlockCmd = &cobra.Command{
+	Use:   "unlock",
+	Short: "Remove a lock of the repository left behind by a crashed git-bug.",
+	Long: `Remove a lock of the repository left behind by a crashed git-bug.
+
+A stale lock is normally detected and removed automatically. With --force, the lock is removed even if the process holding it looks alive, which can corrupt the cache if that process is actually still running.`,
+	PreRunE: loadRepo,
+	RunE:    runCacheUnlock,
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheUnlockCmd)
+
+	cacheUnlockCmd.Flags().SortFlags = false
+
+	cacheUnlockCmd.Flags().BoolVarP(&cacheUnlockForce, "force", "f", false,
+		"Remove the lock even if the process holding it looks alive")
+}
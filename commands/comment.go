@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
@@ -45,9 +46,57 @@ func commentsTextOutput(comments []bug.Comment) {
 	}
 }
 
+// resolveComment find the bug and the comment designated by the arguments of a
+// comment command: an optional bug id, followed by either the index of the
+// comment as displayed by "git bug show", or a prefix of its id.
+func resolveComment(backend *cache.RepoCache, args []string) (*cache.BugCache, int, error) {
+	if len(args) == 0 {
+		return nil, 0, fmt.Errorf("you must provide a comment index or id")
+	}
+
+	var b *cache.BugCache
+	var err error
+
+	// with a single argument, it can only be the comment
+	if len(args) > 1 {
+		b, args, err = _select.ResolveBug(backend, args)
+	} else {
+		b, _, err = _select.ResolveBug(backend, nil)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(args) != 1 {
+		return nil, 0, fmt.Errorf("you must provide a single comment index or id")
+	}
+
+	comments := b.Snapshot().Comments
+
+	if index, err := strconv.Atoi(args[0]); err == nil && index >= 0 && index < len(comments) {
+		return b, index, nil
+	}
+
+	found := -1
+	for i, comment := range comments {
+		if comment.Id().HasPrefix(args[0]) {
+			if found >= 0 {
+				return nil, 0, fmt.Errorf("multiple comments match the id %s", args[0])
+			}
+			found = i
+		}
+	}
+
+	if found < 0 {
+		return nil, 0, fmt.Errorf("no comment matching %s", args[0])
+	}
+
+	return b, found, nil
+}
+
 var commentCmd = &cobra.Command{
 	Use:     "comment [<id>]",
-	Short:   "Display or add comments to a bug.",
+	Short:   "Display, add, edit or remove comments of a bug.",
 	PreRunE: loadRepo,
 	RunE:    runComment,
 }
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/input"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	commentEditMessageFile string
+	commentEditMessage     string
+)
+
+func runCommentEdit(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	b, index, err := resolveComment(backend, args)
+	if err != nil {
+		return err
+	}
+
+	comment := b.Snapshot().Comments[index]
+
+	if commentEditMessageFile != "" && commentEditMessage == "" {
+		commentEditMessage, err = input.BugCommentFileInput(commentEditMessageFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	if commentEditMessageFile == "" && commentEditMessage == "" {
+		commentEditMessage, err = input.BugCommentEditorInput(backend, comment.Message)
+		if err == input.ErrEmptyMessage {
+			fmt.Println("Empty message, aborting.")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if commentEditMessage == comment.Message {
+		fmt.Println("No change, aborting.")
+		return nil
+	}
+
+	_, err = b.EditComment(comment.Id(), commentEditMessage)
+	if err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
+
+var commentEditCmd = &cobra.Command{
+	Use:   "edit [<id>] <comment>",
+	Short: "Edit a comment of a bug.",
+	Long: `Edit a comment of a bug.
+
+The comment is designated either by its index as displayed by "git bug show", or by a prefix of its id as displayed by "git bug comment". Without message flag, the current message is opened in the editor.`,
+	Example: `git bug comment edit 5f8a3b2 1 -m "new message"`,
+	PreRunE: loadRepo,
+	RunE:    runCommentEdit,
+}
+
+func init() {
+	commentCmd.AddCommand(commentEditCmd)
+
+	commentEditCmd.Flags().SortFlags = false
+
+	commentEditCmd.Flags().StringVarP(&commentEditMessageFile, "file", "F", "",
+		"Take the message from the given file. Use - to read the message from the standard input",
+	)
+
+	commentEditCmd.Flags().StringVarP(&commentEditMessage, "message", "m", "",
+		"Provide the new message from the command line",
+	)
+}
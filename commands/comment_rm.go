@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runCommentRm(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	b, index, err := resolveComment(backend, args)
+	if err != nil {
+		return err
+	}
+
+	if index == 0 {
+		return errors.New("the first comment is the description of the bug and can't be removed, edit it instead")
+	}
+
+	comment := b.Snapshot().Comments[index]
+
+	_, err = b.DeleteComment(comment.Id())
+	if err != nil {
+		return err
+	}
+
+	return b.Commit()
+}
+
+var commentRmCmd = &cobra.Command{
+	Use:   "rm [<id>] <comment>",
+	Short: "Remove a comment from a bug.",
+	Long: `Remove a comment from a bug.
+
+The comment is designated either by its index as displayed by "git bug show", or by a prefix of its id as displayed by "git bug comment". The first comment, the description of the bug, can't be removed.`,
+	PreRunE: loadRepo,
+	RunE:    runCommentRm,
+}
+
+func init() {
+	commentCmd.AddCommand(commentRmCmd)
+}
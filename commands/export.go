@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFormat string
+	exportOutput string
+)
+
+func runExport(cmd *cobra.Command, args []string) error {
+	var textFormat cache.TextFormat
+	if exportFormat != "json" {
+		var err error
+		textFormat, err = cache.ParseTextFormat(exportFormat)
+		if err != nil {
+			return fmt.Errorf("unknown format %s", exportFormat)
+		}
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	rawQuery := strings.Join(args, " ")
+	if rawQuery == "" && textFormat != "" {
+		// the text formats are for triaging
+		rawQuery = "status:open"
+	}
+
+	query, err := backend.ParseQuery(rawQuery)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if exportOutput != "" && exportOutput != "-" {
+		f, err := os.Create(exportOutput)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if textFormat != "" {
+		return backend.ExportText(w, textFormat, backend.QueryBugs(query))
+	}
+
+	return backend.ExportJSON(w, backend.QueryBugs(query))
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export [<query>]",
+	Short: "Export the complete history of bugs, or a list to triage in a text editor.",
+	Long: `Export the complete history of bugs, with their metadata, the identities involved and the attached files.
+
+The export can be recreated in another repository with "git bug import", for backups or migration without a bridge. Without query, all the bugs are exported.
+
+With the org or todotxt format, the bugs are instead written as an org-mode file or a todo.txt file, to triage them in a text editor. Without query, only the open bugs are exported. Once edited, "git bug import" with the same format close the bugs marked as DONE or completed, and reopen the ones marked back as TODO or not completed. The other edits are ignored.`,
+	Example: `Export all the bugs:
+git bug export --format json > bugs.json
+
+Export only the open bugs:
+git bug export status:open -o open.json
+
+Triage the open bugs in an org-mode file:
+git bug export --format org -o bugs.org
+$EDITOR bugs.org
+git bug import --format org bugs.org
+`,
+	PreRunE: loadRepo,
+	RunE:    runExport,
+}
+
+func init() {
+	RootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().SortFlags = false
+
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "json",
+		"Select the export format. Valid values are [json,org,todotxt]")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "",
+		"Write the export to a file instead of the standard output")
+}
@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+)
+
+var (
+	fsckPullIdentities bool
+	fsckRemoveBroken   bool
+)
+
+func runFsck(cmd *cobra.Command, args []string) error {
+	// the cache is not loaded, as it can't be built with broken bugs
+	unlock, err := cache.LockRepo(repo)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	interrupt.RegisterCleaner(unlock)
+
+	results, err := bug.CheckLocalBugs(repo)
+	if err != nil {
+		return err
+	}
+
+	if fsckPullIdentities && hasMissingIdentities(results) {
+		err = pullAllIdentities()
+		if err != nil {
+			return err
+		}
+
+		results, err = bug.CheckLocalBugs(repo)
+		if err != nil {
+			return err
+		}
+	}
+
+	var failed int
+	for _, result := range results {
+		for _, problem := range result.Problems {
+			fmt.Printf("%s: %s\n", result.Id.Human(), problem)
+		}
+
+		if result.Broken && fsckRemoveBroken {
+			err = bug.RemoveLocalBug(repo, result.Id)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s: removed\n", result.Id.Human())
+			continue
+		}
+
+		if !result.Ok() {
+			failed++
+		}
+	}
+
+	fmt.Printf("%d bugs checked\n", len(results))
+
+	if failed > 0 {
+		return fmt.Errorf("%d bugs have problems", failed)
+	}
+
+	return nil
+}
+
+func hasMissingIdentities(results []bug.CheckResult) bool {
+	for _, result := range results {
+		if len(result.MissingIdentities) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// pullAllIdentities fetch and merge the identities of all the remotes
+func pullAllIdentities() error {
+	remotes, err := repo.GetRemotes()
+	if err != nil {
+		return err
+	}
+
+	for remote := range remotes {
+		fmt.Printf("Pulling the identities of %s ...\n", remote)
+
+		err = identity.Pull(repo, remote)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var fsckCmd = &cobra.Command{
+	Use:   "fsck",
+	Short: "Check the integrity of the bugs.",
+	Long: `Check the integrity of the local bugs, and optionally repair the common problems.
+
+For each bug, this verify that its operation packs can be read and are valid, that its id match the hash of its first commit, that its edit lamport times come after the one of its first pack, that the identities, the comments and the files it references exist, and that its operations are properly signed.
+
+A bug referencing identities that don't exist locally was usually fetched without them: --pull-identities retrieve the identities of all the remotes. A bug that can't be read can be removed with --remove-broken, and pulled again from a remote having a sound copy.
+
+The exit code is non-zero if some problems remain.`,
+	Example: `git bug fsck --pull-identities`,
+	PreRunE: loadRepo,
+	RunE:    runFsck,
+}
+
+func init() {
+	RootCmd.AddCommand(fsckCmd)
+
+	fsckCmd.Flags().SortFlags = false
+
+	fsckCmd.Flags().BoolVar(&fsckPullIdentities, "pull-identities", false,
+		"Pull the identities of all the remotes if some are missing")
+	fsckCmd.Flags().BoolVar(&fsckRemoveBroken, "remove-broken", false,
+		"Remove the bugs that can't be read or are invalid")
+}
@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/git"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+)
+
+var (
+	gcDryRun bool
+)
+
+func runGc(cmd *cobra.Command, args []string) error {
+	// the cache is not used, but it ensure that no other git-bug process
+	// write in the repository meanwhile
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	bugRefs, err := bug.ListOrphanRemoteRefs(repo)
+	if err != nil {
+		return err
+	}
+
+	identityRefs, err := identity.ListOrphanRemoteRefs(repo)
+	if err != nil {
+		return err
+	}
+
+	refs := append(bugRefs, identityRefs...)
+	for _, ref := range refs {
+		fmt.Printf("removing %s\n", ref)
+	}
+
+	if gcDryRun {
+		fmt.Printf("%d refs would be removed\n", len(refs))
+		return nil
+	}
+
+	before, err := repo.ObjectsSize()
+	if err != nil {
+		return err
+	}
+
+	err = removeRefs(repo, refs)
+	if err != nil {
+		return err
+	}
+
+	err = repo.CollectGarbage()
+	if err != nil {
+		return err
+	}
+
+	after, err := repo.ObjectsSize()
+	if err != nil {
+		return err
+	}
+
+	// git gc repack and prune the whole repository, so the size difference
+	// is not only due to the refs removed above
+	fmt.Printf("%d refs removed\n", len(refs))
+	fmt.Printf("git object store of the repository: %s before gc, %s after\n",
+		humanize.Bytes(before), humanize.Bytes(after))
+
+	return nil
+}
+
+func removeRefs(repo repository.Repo, refs []string) error {
+	update := make(map[string]git.Hash, len(refs))
+	for _, ref := range refs {
+		update[ref] = ""
+	}
+	return repo.UpdateRefs(update)
+}
+
+var gcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Remove the bug data that is not reachable anymore.",
+	Long: `Remove the bug data that is not reachable anymore, and report the size of the git object store before and after.
+
+The data of the removed bugs, of the failed imports or merges stays in the git object store as long as a reference keep it alive. This remove the remote-tracking references of the bugs and identities of the remotes that are not configured anymore, then run "git gc" to pack the objects and prune the ones not reachable from any reference.
+
+The remote-tracking references of the configured remotes are kept, including the ones of the bugs and identities fetched but not merged yet. "git bug rm" already remove the remote-tracking references of the bug it remove.
+
+As "git gc" is used, the unreachable objects are only pruned once older than the gc.pruneExpire git config (two weeks by default), so the space used by the data just unreferenced is reclaimed by a later gc. This also repack and prune ALL the objects of the repository, not only the ones of git-bug: for example the commits of deleted branches no longer in a reflog, as any "git gc" would. The reported sizes are the ones of the whole repository.`,
+	Example: `git bug gc --dry-run`,
+	PreRunE: loadRepo,
+	RunE:    runGc,
+}
+
+func init() {
+	RootCmd.AddCommand(gcCmd)
+
+	gcCmd.Flags().SortFlags = false
+
+	gcCmd.Flags().BoolVarP(&gcDryRun, "dry-run", "n", false,
+		"Only list the references that would be removed")
+}
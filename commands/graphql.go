@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var graphqlCmd = &cobra.Command{
+	Use:   "graphql",
+	Short: "Inspect and query the GraphQL API.",
+	Long: `Inspect and query the GraphQL API, the same as served by "git bug webui".
+
+This allow to generate the code of a client against the exact schema of this version, or to try a query without running the web UI.`,
+}
+
+func init() {
+	RootCmd.AddCommand(graphqlCmd)
+}
@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/graphql"
+)
+
+var (
+	graphqlQueryVariables string
+)
+
+func runGraphqlQuery(cmd *cobra.Command, args []string) error {
+	var query string
+	if len(args) > 0 {
+		query = args[0]
+	} else {
+		raw, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		query = string(raw)
+	}
+
+	request := map[string]interface{}{
+		"query": query,
+	}
+	if graphqlQueryVariables != "" {
+		var variables map[string]interface{}
+		if err := json.Unmarshal([]byte(graphqlQueryVariables), &variables); err != nil {
+			return fmt.Errorf("invalid variables, expected a JSON object: %v", err)
+		}
+		request["variables"] = variables
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	handler, err := graphql.NewHandler(repo)
+	if err != nil {
+		return err
+	}
+	defer handler.Close()
+
+	// the query is run in process, through the same handler as the web UI
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, rec.Body.Bytes(), "", "  "); err != nil {
+		return fmt.Errorf("invalid response (%d): %s", rec.Code, rec.Body.String())
+	}
+	fmt.Println(out.String())
+
+	var response struct {
+		Errors []json.RawMessage `json:"errors"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		return err
+	}
+	if len(response.Errors) > 0 || rec.Code != http.StatusOK {
+		return fmt.Errorf("the query failed")
+	}
+
+	return nil
+}
+
+var graphqlQueryCmd = &cobra.Command{
+	Use:   "query [<query>]",
+	Short: "Run a GraphQL query and print the JSON response.",
+	Long: `Run a GraphQL query and print the JSON response.
+
+The query is read from the standard input if not given. Mutations are authored by the user identity.`,
+	Example: `git bug graphql query '{ defaultRepository { allBugs { totalCount } } }'
+
+git bug graphql query --variables '{"prefix": "7e4a3bb"}' < bug.graphql`,
+	PreRunE: loadRepo,
+	RunE:    runGraphqlQuery,
+	Args:    cobra.MaximumNArgs(1),
+}
+
+func init() {
+	graphqlCmd.AddCommand(graphqlQueryCmd)
+
+	graphqlQueryCmd.Flags().StringVar(&graphqlQueryVariables, "variables", "",
+		"The variables of the query, as a JSON object")
+}
@@ -0,0 +1,26 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/graphql"
+)
+
+func runGraphqlSchema(cmd *cobra.Command, args []string) error {
+	fmt.Print(graphql.Schema())
+	return nil
+}
+
+var graphqlSchemaCmd = &cobra.Command{
+	Use:     "schema",
+	Short:   "Print the GraphQL schema, in the SDL format.",
+	Example: `git bug graphql schema > schema.graphql`,
+	RunE:    runGraphqlSchema,
+	Args:    cobra.NoArgs,
+}
+
+func init() {
+	graphqlCmd.AddCommand(graphqlSchemaCmd)
+}
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+// identify the hooks installed by git-bug, to not override the user's own
+const hookMarker = "# installed by git-bug"
+
+// the git hooks managed by git-bug, with the command they run
+var hookScripts = map[string]string{
+	"prepare-commit-msg": `#!/bin/sh
+` + hookMarker + `
+exec git bug hook prepare-commit-msg "$@"
+`,
+	"post-commit": `#!/bin/sh
+` + hookMarker + `
+exec git bug hook post-commit "$(git rev-parse HEAD)"
+`,
+}
+
+// hooksPath return the directory of the git hooks of the repository,
+// honoring core.hooksPath
+func hooksPath() string {
+	path, err := repo.ReadConfigString("core.hooksPath")
+	if err != nil || path == "" {
+		return filepath.Join(repo.GetPath(), "hooks")
+	}
+
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	// relative to the root of the working tree
+	return filepath.Join(filepath.Dir(repo.GetPath()), path)
+}
+
+var hookCmd = &cobra.Command{
+	Use:   "hook",
+	Short: "Manage the git hooks linking commits to bugs.",
+	Long: `Manage the git hooks linking commits to bugs.
+
+Once installed, the hooks read the trailers of the commit messages. A trailer "Closes: <id>" (or "Fixes:", "Resolves:") closes the bug with a comment referencing the commit, and a trailer "Refs: <id>" only adds the comment. When preparing a commit message, the selected bug is suggested.`,
+	Example: `git bug hook install
+
+git commit -m "Fix the crash on startup" -m "Closes: 5f8a3b2"
+`,
+}
+
+func init() {
+	RootCmd.AddCommand(hookCmd)
+}
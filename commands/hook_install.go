@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	hookInstallForce bool
+)
+
+func runHookInstall(cmd *cobra.Command, args []string) error {
+	dir := hooksPath()
+
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range hookNames() {
+		path := filepath.Join(dir, name)
+
+		existing, err := ioutil.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		if err == nil && !strings.Contains(string(existing), hookMarker) && !hookInstallForce {
+			return fmt.Errorf("a %s hook already exists, use --force to replace it", name)
+		}
+
+		err = ioutil.WriteFile(path, []byte(hookScripts[name]), 0755)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s hook installed\n", name)
+	}
+
+	return nil
+}
+
+func hookNames() []string {
+	names := make([]string, 0, len(hookScripts))
+	for name := range hookScripts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var hookInstallCmd = &cobra.Command{
+	Use:     "install",
+	Short:   "Install the git hooks in the repository.",
+	PreRunE: loadRepo,
+	RunE:    runHookInstall,
+	Args:    cobra.NoArgs,
+}
+
+func init() {
+	hookCmd.AddCommand(hookInstallCmd)
+
+	hookInstallCmd.Flags().BoolVarP(&hookInstallForce, "force", "f", false,
+		"Replace the existing hooks not installed by git-bug")
+}
@@ -0,0 +1,127 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/git"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+// the trailer keys closing a bug, lowercase
+var hookCloseKeys = map[string]bool{"closes": true, "fixes": true, "resolves": true}
+
+// the trailer keys only referencing a bug, lowercase
+var hookRefKeys = map[string]bool{"refs": true, "references": true}
+
+type commitBugRef struct {
+	prefix string
+	close  bool
+}
+
+// commitBugRefs extract the bugs referenced in the trailers of a commit message
+func commitBugRefs(message string) []commitBugRef {
+	var result []commitBugRef
+
+	for _, trailer := range git.ParseTrailers(message) {
+		key := strings.ToLower(trailer.Key)
+		if !hookCloseKeys[key] && !hookRefKeys[key] {
+			continue
+		}
+
+		fields := strings.FieldsFunc(trailer.Value, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		})
+		for _, field := range fields {
+			// as written by the prepare-commit-msg example hook
+			field = strings.TrimLeft(field, "#:")
+			if field != "" {
+				result = append(result, commitBugRef{prefix: field, close: hookCloseKeys[key]})
+			}
+		}
+	}
+
+	return result
+}
+
+func runHookPostCommit(cmd *cobra.Command, args []string) error {
+	commit := git.Hash(args[0])
+
+	message, err := repo.GetCommitMessage(commit)
+	if err != nil {
+		return err
+	}
+
+	refs := commitBugRefs(message)
+	if len(refs) == 0 {
+		return nil
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	subject := strings.SplitN(message, "\n", 2)[0]
+
+	for _, ref := range refs {
+		// a wrong reference must not prevent handling the others
+		err := hookApplyRef(backend, ref, commit, subject)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "git-bug: bug %s: %v\n", ref.prefix, err)
+		}
+	}
+
+	return nil
+}
+
+func hookApplyRef(backend *cache.RepoCache, ref commitBugRef, commit git.Hash, subject string) error {
+	b, err := backend.ResolveBugPrefix(ref.prefix)
+	if err != nil {
+		return err
+	}
+
+	action := "referenced"
+	if ref.close {
+		action = "closed"
+	}
+
+	_, err = b.AddComment(fmt.Sprintf("%s by commit %s: %s",
+		strings.Title(action), commit, subject))
+	if err != nil {
+		return err
+	}
+
+	if ref.close && b.Snapshot().Status != bug.ClosedStatus {
+		_, err = b.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	err = b.Commit()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("git-bug: bug %s %s\n", b.Id().Human(), action)
+	return nil
+}
+
+var hookPostCommitCmd = &cobra.Command{
+	Use:     "post-commit <commit>",
+	Short:   "Close or reference the bugs mentioned in the trailers of a commit, as the post-commit hook.",
+	PreRunE: loadRepo,
+	RunE:    runHookPostCommit,
+	Args:    cobra.ExactArgs(1),
+}
+
+func init() {
+	hookCmd.AddCommand(hookPostCommitCmd)
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runHookPrepareCommitMsg(cmd *cobra.Command, args []string) error {
+	// only for a message written from scratch, not for a merge, an amend, -m ...
+	if len(args) > 1 && args[1] != "" {
+		return nil
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	b, _, err := _select.ResolveBug(backend, nil)
+	if err == _select.ErrNoValidId {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	commentChar, err := repo.ReadConfigString("core.commentChar")
+	if err != nil || commentChar == "" {
+		commentChar = "#"
+	}
+
+	raw, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	hint := fmt.Sprintf("%s Uncomment to close the selected bug \"%s\":\n%s Closes: %s\n",
+		commentChar, b.Snapshot().Title, commentChar, b.Id().Human())
+
+	// insert the hint before the comments written by git, if any
+	lines := strings.SplitAfter(string(raw), "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, commentChar) {
+			lines = append(lines[:i], append([]string{hint}, lines[i:]...)...)
+			return ioutil.WriteFile(args[0], []byte(strings.Join(lines, "")), 0644)
+		}
+	}
+
+	return ioutil.WriteFile(args[0], []byte(string(raw)+hint), 0644)
+}
+
+var hookPrepareCommitMsgCmd = &cobra.Command{
+	Use:     "prepare-commit-msg <file> [<source> [<commit>]]",
+	Short:   "Suggest to close the selected bug in the commit message, as the prepare-commit-msg hook.",
+	PreRunE: loadRepo,
+	RunE:    runHookPrepareCommitMsg,
+	Args:    cobra.RangeArgs(1, 3),
+}
+
+func init() {
+	hookCmd.AddCommand(hookPrepareCommitMsgCmd)
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func runHookUninstall(cmd *cobra.Command, args []string) error {
+	dir := hooksPath()
+
+	for _, name := range hookNames() {
+		path := filepath.Join(dir, name)
+
+		existing, err := ioutil.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		// leave the user's own hooks alone
+		if !strings.Contains(string(existing), hookMarker) {
+			continue
+		}
+
+		err = os.Remove(path)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s hook removed\n", name)
+	}
+
+	return nil
+}
+
+var hookUninstallCmd = &cobra.Command{
+	Use:     "uninstall",
+	Short:   "Remove the git hooks installed by git-bug.",
+	PreRunE: loadRepo,
+	RunE:    runHookUninstall,
+	Args:    cobra.NoArgs,
+}
+
+func init() {
+	hookCmd.AddCommand(hookUninstallCmd)
+}
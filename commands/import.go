@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importFormat string
+)
+
+func runImport(cmd *cobra.Command, args []string) error {
+	var textFormat cache.TextFormat
+	if importFormat != "json" {
+		var err error
+		textFormat, err = cache.ParseTextFormat(importFormat)
+		if err != nil {
+			return fmt.Errorf("unknown format %s", importFormat)
+		}
+	}
+
+	var r io.Reader = os.Stdin
+	if len(args) == 1 && args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	if textFormat != "" {
+		result, err := backend.ImportText(r, textFormat)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%d bug(s) closed, %d bug(s) reopened\n", result.Closed, result.Reopened)
+		return nil
+	}
+
+	result, err := backend.ImportJSON(r)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%d bug(s) created, %d operation(s) added, %d identity(ies) created\n",
+		result.Bugs, result.Operations, result.Identities)
+	if result.Skipped > 0 {
+		fmt.Printf("%d bug(s) skipped as already present natively\n", result.Skipped)
+	}
+
+	return nil
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import [<file>]",
+	Short: "Import bugs from an export.",
+	Long: `Import bugs from an export made with "git bug export". Without file, or with "-", the export is read from the standard input.
+
+Importing the same export again only adds what changed since. Bugs that already exist natively in the repository are left untouched.
+
+With the org or todotxt format, a file made with "git bug export" in the same format and edited is read back: the bugs marked as DONE or completed are closed, the ones marked as TODO or not completed are reopened, as the user identity.`,
+	Example: `git bug import bugs.json
+git bug import --format todotxt todo.txt`,
+	PreRunE: loadRepo,
+	RunE:    runImport,
+	Args:    cobra.MaximumNArgs(1),
+}
+
+func init() {
+	RootCmd.AddCommand(importCmd)
+
+	importCmd.Flags().StringVarP(&importFormat, "format", "f", "json",
+		"Select the import format. Valid values are [json,org,todotxt]")
+}
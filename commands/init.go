@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+)
+
+var (
+	initRemotes []string
+)
+
+func runInit(cmd *cobra.Command, args []string) error {
+	// loading the repository already created the clocks, the cache is built
+	// here once for all
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	for _, remote := range initRemotes {
+		err = bug.ConfigureRemote(repo, remote)
+		if err != nil {
+			return err
+		}
+
+		err = identity.ConfigureRemote(repo, remote)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("remote %s now fetches the bugs and identities with git fetch\n", remote)
+	}
+
+	fmt.Printf("git-bug initialized: %d bugs, %d identities\n",
+		len(backend.AllBugsIds()), len(backend.AllIdentityIds()))
+
+	return nil
+}
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Initialize git-bug in the repository.",
+	Long: `Initialize git-bug in the repository, and optionally configure remotes to carry the bugs and identities.
+
+git-bug doesn't need to be initialized, but doing so creates its data and builds its cache ahead of the other commands.
+
+With --remote, the fetch refspecs of the bugs and identities are added to the configuration of the remote, so that a plain "git fetch" retrieves them. The fetched bugs and identities still need to be merged with "git bug pull". The push configuration of the remote is left alone: "git push" keeps pushing only the branches, and "git bug push" sends the bugs and identities. This can be run again on an initialized repository.`,
+	Example: `git bug init --remote origin`,
+	PreRunE: loadRepo,
+	RunE:    runInit,
+}
+
+func init() {
+	RootCmd.AddCommand(initCmd)
+
+	initCmd.Flags().SortFlags = false
+
+	initCmd.Flags().StringSliceVar(&initRemotes, "remote", nil,
+		"Configure the remote to fetch the bugs and identities, can be given several times")
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/util/lamport"
+)
+
+// JSONIdentity is the JSON representation of an identity
+type JSONIdentity struct {
+	Id      string `json:"id,omitempty"`
+	HumanId string `json:"human_id,omitempty"`
+	Name    string `json:"name"`
+	Login   string `json:"login,omitempty"`
+}
+
+// NewJSONIdentity return the JSON representation of an identity
+func NewJSONIdentity(i identity.Interface) JSONIdentity {
+	return JSONIdentity{
+		Id:      i.Id().String(),
+		HumanId: i.Id().Human(),
+		Name:    i.Name(),
+		Login:   i.Login(),
+	}
+}
+
+// NewJSONIdentityFromExcerpt return the JSON representation of a cached
+// identity
+func NewJSONIdentityFromExcerpt(excerpt *cache.IdentityExcerpt) JSONIdentity {
+	return JSONIdentity{
+		Id:      excerpt.Id.String(),
+		HumanId: excerpt.Id.Human(),
+		Name:    excerpt.Name,
+		Login:   excerpt.Login,
+	}
+}
+
+// NewJSONIdentityFromLegacyExcerpt return the JSON representation of a legacy
+// author, that has no id
+func NewJSONIdentityFromLegacyExcerpt(excerpt *cache.LegacyAuthorExcerpt) JSONIdentity {
+	return JSONIdentity{
+		Name:  excerpt.Name,
+		Login: excerpt.Login,
+	}
+}
+
+// newJSONIdentityFromId return the JSON representation of an identity known
+// by its id, falling back to the id alone if it's not in the cache
+func newJSONIdentityFromId(backend *cache.RepoCache, id entity.Id) JSONIdentity {
+	excerpt, err := backend.ResolveIdentityExcerpt(id)
+	if err != nil {
+		return JSONIdentity{Id: id.String(), HumanId: id.Human()}
+	}
+	return NewJSONIdentityFromExcerpt(excerpt)
+}
+
+// JSONTime is the JSON representation of a timestamp, along with its logical
+// clock value
+type JSONTime struct {
+	Timestamp int64        `json:"timestamp"`
+	Time      time.Time    `json:"time"`
+	Lamport   lamport.Time `json:"lamport,omitempty"`
+}
+
+// NewJSONTime return the JSON representation of a timestamp
+func NewJSONTime(t time.Time, l lamport.Time) JSONTime {
+	return JSONTime{
+		Timestamp: t.Unix(),
+		Time:      t,
+		Lamport:   l,
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/MichaelMure/git-bug/cache"
-	"github.com/MichaelMure/git-bug/commands/select"
 	"github.com/MichaelMure/git-bug/util/interrupt"
 	"github.com/spf13/cobra"
 )
@@ -17,31 +16,46 @@ func runLabelAdd(cmd *cobra.Command, args []string) error {
 	defer backend.Close()
 	interrupt.RegisterCleaner(backend.Close)
 
-	b, args, err := _select.ResolveBug(backend, args)
-	if err != nil {
-		return err
-	}
+	return applyToBugs(backend, args, "Label", func(b *cache.BugCache, args []string) error {
+		changes, op, err := b.ChangeLabels(args, nil)
 
-	changes, _, err := b.ChangeLabels(args, nil)
+		for _, change := range changes {
+			if bulkQuery != "" {
+				fmt.Printf("%s: %s\n", b.Id().Human(), change)
+			} else {
+				fmt.Println(change)
+			}
+		}
 
-	for _, change := range changes {
-		fmt.Println(change)
-	}
+		// when applied to many bugs, those without any change are skipped
+		if bulkQuery != "" && op == nil && changes != nil {
+			return nil
+		}
 
-	if err != nil {
-		return err
-	}
+		if err != nil {
+			return err
+		}
 
-	return b.Commit()
+		return b.Commit()
+	})
 }
 
 var labelAddCmd = &cobra.Command{
-	Use:     "add [<id>] <label>[...]",
-	Short:   "Add a label to a bug.",
+	Use:   "add [<id>] <label>[...]",
+	Short: "Add a label to a bug.",
+	Example: `Add labels to the selected bug:
+git bug label add bug critical
+
+Add a label to all the open bugs without label:
+git bug label add --query "status:open no:label" needs-triage
+`,
 	PreRunE: loadRepo,
 	RunE:    runLabelAdd,
 }
 
 func init() {
 	labelCmd.AddCommand(labelAddCmd)
+
+	labelAddCmd.Flags().SortFlags = false
+	addBulkFlags(labelAddCmd)
 }
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/MichaelMure/git-bug/util/text"
+	"github.com/spf13/cobra"
+)
+
+var (
+	labelLsPorcelain bool
+)
+
+func runLabelLs(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	for _, count := range backend.Labels() {
+		if labelLsPorcelain {
+			printPorcelain(string(count.Label), strconv.Itoa(count.Open), strconv.Itoa(count.Closed))
+			continue
+		}
+
+		fmt.Printf("%s\t%s\t%s\n",
+			text.LeftPadMaxLine(string(count.Label), 30, 0),
+			colors.Green(fmt.Sprintf("%d open", count.Open)),
+			colors.Red(fmt.Sprintf("%d closed", count.Closed)),
+		)
+	}
+
+	return nil
+}
+
+var labelLsCmd = &cobra.Command{
+	Use:     "ls",
+	Short:   "List the labels in use, with the number of open and closed bugs having them.",
+	PreRunE: loadRepo,
+	RunE:    runLabelLs,
+	Args:    cobra.NoArgs,
+}
+
+func init() {
+	labelCmd.AddCommand(labelLsCmd)
+
+	labelLsCmd.Flags().SortFlags = false
+
+	labelLsCmd.Flags().BoolVar(&labelLsPorcelain, "porcelain", false,
+		"Output a stable format for scripts, see doc/porcelain.md")
+}
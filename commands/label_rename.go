@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runLabelRename(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	count, err := backend.RenameLabel(args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("label \"%s\" renamed to \"%s\" on %d bug(s)\n", args[0], args[1], count)
+
+	return nil
+}
+
+var labelRenameCmd = &cobra.Command{
+	Use:   "rename <label> <new-label>",
+	Short: "Rename a label on all the bugs.",
+	Long: `Rename a label on all the bugs having it, open or closed.
+
+Each bug changed gets a label change operation, so the renaming is part of the history of the bug and is propagated by a push like any other edition.`,
+	Example: `Use a clearer name for a label:
+git bug label rename wip in-progress
+`,
+	PreRunE: loadRepo,
+	RunE:    runLabelRename,
+	Args:    cobra.ExactArgs(2),
+}
+
+func init() {
+	labelCmd.AddCommand(labelRenameCmd)
+
+	labelRenameCmd.Flags().SortFlags = false
+}
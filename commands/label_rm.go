@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/MichaelMure/git-bug/cache"
-	"github.com/MichaelMure/git-bug/commands/select"
 	"github.com/MichaelMure/git-bug/util/interrupt"
 	"github.com/spf13/cobra"
 )
@@ -17,31 +16,43 @@ func runLabelRm(cmd *cobra.Command, args []string) error {
 	defer backend.Close()
 	interrupt.RegisterCleaner(backend.Close)
 
-	b, args, err := _select.ResolveBug(backend, args)
-	if err != nil {
-		return err
-	}
+	return applyToBugs(backend, args, "Unlabel", func(b *cache.BugCache, args []string) error {
+		changes, op, err := b.ChangeLabels(nil, args)
 
-	changes, _, err := b.ChangeLabels(nil, args)
+		for _, change := range changes {
+			if bulkQuery != "" {
+				fmt.Printf("%s: %s\n", b.Id().Human(), change)
+			} else {
+				fmt.Println(change)
+			}
+		}
 
-	for _, change := range changes {
-		fmt.Println(change)
-	}
+		// when applied to many bugs, those without any change are skipped
+		if bulkQuery != "" && op == nil && changes != nil {
+			return nil
+		}
 
-	if err != nil {
-		return err
-	}
+		if err != nil {
+			return err
+		}
 
-	return b.Commit()
+		return b.Commit()
+	})
 }
 
 var labelRmCmd = &cobra.Command{
-	Use:     "rm [<id>] <label>[...]",
-	Short:   "Remove a label from a bug.",
+	Use:   "rm [<id>] <label>[...]",
+	Short: "Remove a label from a bug.",
+	Example: `Remove a label from all the closed bugs:
+git bug label rm --query "status:closed" needs-info
+`,
 	PreRunE: loadRepo,
 	RunE:    runLabelRm,
 }
 
 func init() {
 	labelCmd.AddCommand(labelRmCmd)
+
+	labelRmCmd.Flags().SortFlags = false
+	addBulkFlags(labelRmCmd)
 }
@@ -2,13 +2,19 @@ package commands
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
 	"github.com/MichaelMure/git-bug/util/interrupt"
 )
 
+var (
+	lsIdCompletion bool
+)
+
 func runLsID(cmd *cobra.Command, args []string) error {
 
 	backend, err := cache.NewRepoCache(repo)
@@ -24,14 +30,35 @@ func runLsID(cmd *cobra.Command, args []string) error {
 	}
 
 	for _, id := range backend.AllBugsIds() {
-		if prefix == "" || id.HasPrefix(prefix) {
-			fmt.Println(id)
+		if prefix != "" && !id.HasPrefix(prefix) {
+			continue
+		}
+
+		if lsIdCompletion {
+			if err := printBugCompletion(backend, id); err != nil {
+				return err
+			}
+			continue
 		}
+
+		fmt.Println(id)
 	}
 
 	return nil
 }
 
+// printBugCompletion output a candidate for the shell completion of a bug id:
+// the human id, with the title as a hint separated by two spaces.
+func printBugCompletion(backend *cache.RepoCache, id entity.Id) error {
+	excerpt, err := backend.ResolveBugExcerpt(id)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s  %s\n", id.Human(), strings.Replace(excerpt.Title, "\n", " ", -1))
+	return nil
+}
+
 var listBugIDCmd = &cobra.Command{
 	Use:     "ls-id [<prefix>]",
 	Short:   "List bug identifiers.",
@@ -41,4 +68,8 @@ var listBugIDCmd = &cobra.Command{
 
 func init() {
 	RootCmd.AddCommand(listBugIDCmd)
+
+	listBugIDCmd.Flags().BoolVar(&lsIdCompletion, "completion", false,
+		"Output candidates for the shell completion")
+	_ = listBugIDCmd.Flags().MarkHidden("completion")
 }
@@ -1,9 +1,16 @@
 package commands
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"os"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/util/colors"
 	"github.com/MichaelMure/git-bug/util/interrupt"
@@ -15,12 +22,21 @@ var (
 	lsStatusQuery      []string
 	lsAuthorQuery      []string
 	lsParticipantQuery []string
+	lsAssigneeQuery    []string
+	lsMilestoneQuery   []string
+	lsProjectQuery     []string
 	lsLabelQuery       []string
 	lsTitleQuery       []string
 	lsActorQuery       []string
 	lsNoQuery          []string
+	lsNoLabel          bool
+	lsBefore           string
+	lsAfter            string
+	lsSort             string
 	lsSortBy           string
 	lsSortDirection    string
+	lsOutputFormat     string
+	lsPorcelain        bool
 )
 
 func runLsBug(cmd *cobra.Command, args []string) error {
@@ -33,7 +49,7 @@ func runLsBug(cmd *cobra.Command, args []string) error {
 
 	var query *cache.Query
 	if len(args) >= 1 {
-		query, err = cache.ParseQuery(strings.Join(args, " "))
+		query, err = backend.ParseQuery(strings.Join(args, " "))
 
 		if err != nil {
 			return err
@@ -43,28 +59,120 @@ func runLsBug(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
+		err = backend.ScopeQuery(query)
+		if err != nil {
+			return err
+		}
 	}
 
 	allIds := backend.QueryBugs(query)
 
-	for _, id := range allIds {
+	bugExcerpts := make([]*cache.BugExcerpt, len(allIds))
+	for i, id := range allIds {
 		b, err := backend.ResolveBugExcerpt(id)
 		if err != nil {
 			return err
 		}
+		bugExcerpts[i] = b
+	}
+
+	// --porcelain is a shorthand for --format plain
+	if lsPorcelain {
+		lsOutputFormat = "plain"
+	}
+
+	switch lsOutputFormat {
+	case "default":
+		return lsDefaultFormatter(backend, bugExcerpts)
+	case "plain":
+		return lsPorcelainFormatter(backend, bugExcerpts)
+	case "json":
+		return lsJsonFormatter(backend, bugExcerpts)
+	case "csv":
+		return lsCsvFormatter(backend, bugExcerpts)
+	case "org":
+		return lsOrgFormatter(backend, bugExcerpts)
+	default:
+		return fmt.Errorf("unknown format %s", lsOutputFormat)
+	}
+}
+
+// JSONBugExcerpt is the JSON representation of a bug in the listing
+type JSONBugExcerpt struct {
+	Id         string   `json:"id"`
+	HumanId    string   `json:"human_id"`
+	CreateTime JSONTime `json:"create_time"`
+	EditTime   JSONTime `json:"edit_time"`
+
+	Status       string         `json:"status"`
+	Labels       []string       `json:"labels"`
+	Title        string         `json:"title"`
+	Author       JSONIdentity   `json:"author"`
+	Actors       []JSONIdentity `json:"actors"`
+	Participants []JSONIdentity `json:"participants"`
+	Assignee     *JSONIdentity  `json:"assignee,omitempty"`
+	Milestone    string         `json:"milestone,omitempty"`
+	Project      string         `json:"project,omitempty"`
+	Comments     int            `json:"comments"`
+}
+
+func lsJsonFormatter(backend *cache.RepoCache, bugExcerpts []*cache.BugExcerpt) error {
+	jsonBugs := make([]JSONBugExcerpt, len(bugExcerpts))
+
+	for i, b := range bugExcerpts {
+		jsonBug := JSONBugExcerpt{
+			Id:           b.Id.String(),
+			HumanId:      b.Id.Human(),
+			CreateTime:   NewJSONTime(time.Unix(b.CreateUnixTime, 0), b.CreateLamportTime),
+			EditTime:     NewJSONTime(time.Unix(b.EditUnixTime, 0), b.EditLamportTime),
+			Status:       b.Status.String(),
+			Labels:       make([]string, len(b.Labels)),
+			Title:        b.Title,
+			Actors:       make([]JSONIdentity, len(b.Actors)),
+			Participants: make([]JSONIdentity, len(b.Participants)),
+			Milestone:    b.Milestone,
+			Project:      b.Project,
+			Comments:     b.LenComments,
+		}
+
+		for j, l := range b.Labels {
+			jsonBug.Labels[j] = l.String()
+		}
 
-		var name string
 		if b.AuthorId != "" {
-			author, err := backend.ResolveIdentityExcerpt(b.AuthorId)
-			if err != nil {
-				name = "<missing author data>"
-			} else {
-				name = author.DisplayName()
-			}
+			jsonBug.Author = newJSONIdentityFromId(backend, b.AuthorId)
 		} else {
-			name = b.LegacyAuthor.DisplayName()
+			jsonBug.Author = NewJSONIdentityFromLegacyExcerpt(&b.LegacyAuthor)
+		}
+
+		for j, id := range b.Actors {
+			jsonBug.Actors[j] = newJSONIdentityFromId(backend, id)
+		}
+
+		for j, id := range b.Participants {
+			jsonBug.Participants[j] = newJSONIdentityFromId(backend, id)
+		}
+
+		if b.AssigneeId != "" {
+			assignee := newJSONIdentityFromId(backend, b.AssigneeId)
+			jsonBug.Assignee = &assignee
 		}
 
+		jsonBugs[i] = jsonBug
+	}
+
+	jsonObject, err := json.MarshalIndent(jsonBugs, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s\n", jsonObject)
+	return nil
+}
+
+func lsDefaultFormatter(backend *cache.RepoCache, bugExcerpts []*cache.BugExcerpt) error {
+	for _, b := range bugExcerpts {
+		name := lsAuthorName(backend, b)
+
 		// truncate + pad if needed
 		titleFmt := text.LeftPadMaxLine(b.Title, 50, 0)
 		authorFmt := text.LeftPadMaxLine(name, 15, 0)
@@ -82,6 +190,141 @@ func runLsBug(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// lsPorcelainFormatter output a porcelain record per bug, see doc/porcelain.md
+func lsPorcelainFormatter(backend *cache.RepoCache, bugExcerpts []*cache.BugExcerpt) error {
+	for _, b := range bugExcerpts {
+		labels := make([]string, len(b.Labels))
+		for i, l := range b.Labels {
+			labels[i] = l.String()
+		}
+
+		printPorcelain(
+			b.Id.String(),
+			b.Status.String(),
+			strconv.FormatInt(b.CreateUnixTime, 10),
+			strconv.FormatInt(b.EditUnixTime, 10),
+			lsAuthorName(backend, b),
+			strconv.Itoa(b.LenComments),
+			strings.Join(labels, ","),
+			b.Title,
+		)
+	}
+
+	return nil
+}
+
+// lsCsvFormatter output a CSV table with a header, for spreadsheets. The
+// times are given as RFC 3339 and the labels are separated by commas.
+func lsCsvFormatter(backend *cache.RepoCache, bugExcerpts []*cache.BugExcerpt) error {
+	w := csv.NewWriter(os.Stdout)
+
+	_ = w.Write([]string{
+		"id", "human_id", "status", "title", "author", "assignee",
+		"milestone", "project", "labels", "comments", "create_time", "edit_time",
+	})
+
+	for _, b := range bugExcerpts {
+		labels := make([]string, len(b.Labels))
+		for i, l := range b.Labels {
+			labels[i] = l.String()
+		}
+
+		_ = w.Write([]string{
+			b.Id.String(),
+			b.Id.Human(),
+			b.Status.String(),
+			b.Title,
+			lsAuthorName(backend, b),
+			lsAssigneeName(backend, b),
+			b.Milestone,
+			b.Project,
+			strings.Join(labels, ","),
+			strconv.Itoa(b.LenComments),
+			time.Unix(b.CreateUnixTime, 0).Format(time.RFC3339),
+			time.Unix(b.EditUnixTime, 0).Format(time.RFC3339),
+		})
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+// characters not allowed in an org-mode tag
+var orgTagReplacer = regexp.MustCompile(`[^\pL\pN_@#%]`)
+
+// lsOrgFormatter output an org-mode heading per bug, TODO or DONE depending
+// on the status, with the labels as tags and the details as properties.
+func lsOrgFormatter(backend *cache.RepoCache, bugExcerpts []*cache.BugExcerpt) error {
+	const orgTime = "[2006-01-02 Mon 15:04]"
+
+	for _, b := range bugExcerpts {
+		keyword := "TODO"
+		if b.Status == bug.ClosedStatus {
+			keyword = "DONE"
+		}
+
+		heading := fmt.Sprintf("* %s %s", keyword, orgField(b.Title))
+
+		if len(b.Labels) > 0 {
+			tags := make([]string, len(b.Labels))
+			for i, l := range b.Labels {
+				tags[i] = orgTagReplacer.ReplaceAllString(l.String(), "_")
+			}
+			heading += fmt.Sprintf(" :%s:", strings.Join(tags, ":"))
+		}
+
+		fmt.Println(heading)
+		fmt.Println("  :PROPERTIES:")
+		fmt.Printf("  :GIT_BUG_ID: %s\n", b.Id)
+		fmt.Printf("  :AUTHOR:     %s\n", orgField(lsAuthorName(backend, b)))
+		if b.AssigneeId != "" {
+			fmt.Printf("  :ASSIGNEE:   %s\n", orgField(lsAssigneeName(backend, b)))
+		}
+		if b.Milestone != "" {
+			fmt.Printf("  :MILESTONE:  %s\n", orgField(b.Milestone))
+		}
+		if b.Project != "" {
+			fmt.Printf("  :PROJECT:    %s\n", orgField(b.Project))
+		}
+		fmt.Printf("  :COMMENTS:   %d\n", b.LenComments)
+		fmt.Printf("  :CREATED:    %s\n", time.Unix(b.CreateUnixTime, 0).Format(orgTime))
+		fmt.Printf("  :EDITED:     %s\n", time.Unix(b.EditUnixTime, 0).Format(orgTime))
+		fmt.Println("  :END:")
+	}
+
+	return nil
+}
+
+// orgField remove the tabulations and line breaks that would break the
+// lines of the org format
+func orgField(s string) string {
+	return strings.NewReplacer("\t", " ", "\n", " ", "\r", " ").Replace(s)
+}
+
+func lsAuthorName(backend *cache.RepoCache, b *cache.BugExcerpt) string {
+	if b.AuthorId == "" {
+		return b.LegacyAuthor.DisplayName()
+	}
+
+	author, err := backend.ResolveIdentityExcerpt(b.AuthorId)
+	if err != nil {
+		return "<missing author data>"
+	}
+	return author.DisplayName()
+}
+
+func lsAssigneeName(backend *cache.RepoCache, b *cache.BugExcerpt) string {
+	if b.AssigneeId == "" {
+		return ""
+	}
+
+	assignee, err := backend.ResolveIdentityExcerpt(b.AssigneeId)
+	if err != nil {
+		return "<missing assignee data>"
+	}
+	return assignee.DisplayName()
+}
+
 // Transform the command flags into a query
 func lsQueryFromFlags() (*cache.Query, error) {
 	query := cache.NewQuery()
@@ -114,15 +357,62 @@ func lsQueryFromFlags() (*cache.Query, error) {
 		query.Participant = append(query.Participant, f)
 	}
 
+	for _, assignee := range lsAssigneeQuery {
+		f := cache.AssigneeFilter(assignee)
+		query.Assignee = append(query.Assignee, f)
+	}
+
+	for _, milestone := range lsMilestoneQuery {
+		f := cache.MilestoneFilter(milestone)
+		query.Milestone = append(query.Milestone, f)
+	}
+
+	for _, project := range lsProjectQuery {
+		query.ExplicitProject = true
+		// any project, or none
+		if project == "*" {
+			continue
+		}
+		f := cache.ProjectFilter(project)
+		query.Project = append(query.Project, f)
+	}
+
 	for _, label := range lsLabelQuery {
 		f := cache.LabelFilter(label)
 		query.Label = append(query.Label, f)
 	}
 
+	if lsNoLabel {
+		query.NoFilters = append(query.NoFilters, cache.NoLabelFilter())
+	}
+
+	if lsBefore != "" {
+		t, err := cache.ParseTime(lsBefore)
+		if err != nil {
+			return nil, err
+		}
+		query.Time = append(query.Time, cache.CreatedBeforeFilter(t))
+	}
+
+	if lsAfter != "" {
+		t, err := cache.ParseTime(lsAfter)
+		if err != nil {
+			return nil, err
+		}
+		query.Time = append(query.Time, cache.CreatedAfterFilter(t))
+	}
+
 	for _, no := range lsNoQuery {
 		switch no {
 		case "label":
 			query.NoFilters = append(query.NoFilters, cache.NoLabelFilter())
+		case "assignee":
+			query.NoFilters = append(query.NoFilters, cache.NoAssigneeFilter())
+		case "milestone":
+			query.NoFilters = append(query.NoFilters, cache.NoMilestoneFilter())
+		case "project":
+			query.ExplicitProject = true
+			query.NoFilters = append(query.NoFilters, cache.NoProjectFilter())
 		default:
 			return nil, fmt.Errorf("unknown \"no\" filter %s", no)
 		}
@@ -135,6 +425,10 @@ func lsQueryFromFlags() (*cache.Query, error) {
 		query.OrderBy = cache.OrderByCreation
 	case "edit":
 		query.OrderBy = cache.OrderByEdit
+	case "title":
+		query.OrderBy = cache.OrderByTitle
+	case "comments":
+		query.OrderBy = cache.OrderByComments
 	default:
 		return nil, fmt.Errorf("unknown sort flag %s", lsSortBy)
 	}
@@ -148,6 +442,14 @@ func lsQueryFromFlags() (*cache.Query, error) {
 		return nil, fmt.Errorf("unknown sort direction %s", lsSortDirection)
 	}
 
+	// --sort take precedence over --by and --direction
+	if lsSort != "" {
+		err := query.ParseSorting(lsSort)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return query, nil
 }
 
@@ -156,12 +458,32 @@ var lsCmd = &cobra.Command{
 	Short: "List bugs.",
 	Long: `Display a summary of each bugs.
 
-You can pass an additional query to filter and order the list. This query can be expressed either with a simple query language or with flags.`,
+You can pass an additional query to filter and order the list. This query can be expressed either with a simple query language or with flags.
+
+When the repository is switched to a project with "git bug project switch", only the bugs of this project are listed, unless the query filter on the project itself.`,
 	Example: `List open bugs sorted by last edition with a query:
 git bug ls status:open sort:edit-desc
 
+List bugs with a label and mentioning a text:
+git bug ls label:bug "panic in parser"
+
+List the open bugs of another project than the current one (see "git bug project"):
+git bug ls status:open project:backend
+
+List bugs with a saved query (see "git bug query"):
+git bug ls @mine
+
 List closed bugs sorted by creation with flags:
 git bug ls --status closed --by creation
+
+List open bugs without labels created in the last two weeks, last edited first:
+git bug ls --status open --no-label --after 2w --sort edit-desc
+
+List open bugs as JSON for scripting:
+git bug ls --format json status:open
+
+Export the open bugs to a spreadsheet:
+git bug ls --format csv status:open > bugs.csv
 `,
 	PreRunE: loadRepo,
 	RunE:    runLsBug,
@@ -180,14 +502,37 @@ func init() {
 		"Filter by participant")
 	lsCmd.Flags().StringSliceVarP(&lsActorQuery, "actor", "A", nil,
 		"Filter by actor")
+	lsCmd.Flags().StringSliceVar(&lsAssigneeQuery, "assignee", nil,
+		"Filter by assignee")
+	lsCmd.Flags().StringSliceVar(&lsMilestoneQuery, "milestone", nil,
+		"Filter by milestone")
+	lsCmd.Flags().StringSliceVar(&lsProjectQuery, "project", nil,
+		"Filter by project, or \"*\" for all the projects instead of the current one")
 	lsCmd.Flags().StringSliceVarP(&lsLabelQuery, "label", "l", nil,
 		"Filter by label")
 	lsCmd.Flags().StringSliceVarP(&lsTitleQuery, "title", "t", nil,
 		"Filter by title")
 	lsCmd.Flags().StringSliceVarP(&lsNoQuery, "no", "n", nil,
-		"Filter by absence of something. Valid values are [label]")
+		"Filter by absence of something. Valid values are [label,assignee,milestone,project]")
+	lsCmd.Flags().BoolVar(&lsNoLabel, "no-label", false,
+		"Only list the bugs without labels")
+	lsCmd.Flags().StringVar(&lsBefore, "before", "",
+		"Only list the bugs created before a date (YYYY-MM-DD) or a duration ago (ex: 90d)")
+	lsCmd.Flags().StringVar(&lsAfter, "after", "",
+		"Only list the bugs created after a date (YYYY-MM-DD) or a duration ago (ex: 2w)")
+	lsCmd.Flags().StringVar(&lsSort, "sort", "",
+		"Sort the results. Valid values are [id,id-asc,id-desc,creation,creation-asc,creation-desc,edit,edit-asc,edit-desc,title,title-asc,title-desc,comments,comments-asc,comments-desc]")
 	lsCmd.Flags().StringVarP(&lsSortBy, "by", "b", "creation",
-		"Sort the results by a characteristic. Valid values are [id,creation,edit]")
+		"Sort the results by a characteristic. Valid values are [id,creation,edit,title,comments]")
 	lsCmd.Flags().StringVarP(&lsSortDirection, "direction", "d", "asc",
 		"Select the sorting direction. Valid values are [asc,desc]")
+	lsCmd.Flags().StringVarP(&lsOutputFormat, "format", "f", "default",
+		"Select the output formatting style. Valid values are [default,plain,json,csv,org], plain being the stable format for scripts of doc/porcelain.md")
+	lsCmd.Flags().BoolVar(&lsPorcelain, "porcelain", false,
+		"Output the stable format for scripts, same as --format plain")
+
+	for _, flag := range []string{"author", "participant", "actor", "assignee"} {
+		_ = lsCmd.MarkFlagCustom(flag, "__git-bug_complete_identity")
+	}
+	_ = lsCmd.MarkFlagCustom("label", "__git-bug_complete_label")
 }
@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+)
+
+var (
+	migrateFormat uint
+)
+
+func runMigrate(cmd *cobra.Command, args []string) error {
+	// the bugs are rewritten below the cache, which notice the new heads the
+	// next time it's loaded
+	unlock, err := cache.LockRepo(repo)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	interrupt.RegisterCleaner(unlock)
+
+	err = bug.SetPackFormat(repo, migrateFormat)
+	if err != nil {
+		return err
+	}
+
+	ids, err := bug.ListLocalIds(repo)
+	if err != nil {
+		return err
+	}
+
+	var rewritten, shared int
+	for _, id := range ids {
+		changed, err := bug.MigratePacks(repo, id, migrateFormat)
+		if err == bug.ErrBugShared {
+			shared++
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %v", id.Human(), err)
+		}
+
+		if changed {
+			rewritten++
+			fmt.Printf("%s: rewritten\n", id.Human())
+		}
+	}
+
+	fmt.Printf("Operation packs are now written in format %d: %d bugs rewritten, %d shared bugs kept as is\n",
+		migrateFormat, rewritten, shared)
+
+	if rewritten > 0 {
+		fmt.Println("The previous packs are kept until garbage collected with \"git bug gc\".")
+	}
+
+	return nil
+}
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Change the format of the operation packs.",
+	Long: `Change the format in which the operation packs of the bugs are written, and rewrite the local bugs in this format.
+
+The format 1 is plain JSON, and the only one older versions of git-bug can read. The format 2 is compressed, to shrink the repositories carrying a lot of operations, like after importing from a bridge. All the formats can always be read.
+
+The first pack of a bug is never rewritten, as its hash is the id of the bug. The bugs shared with a remote are not rewritten either, as their new history would conflict with the other copies: only their next packs are written in the new format.`,
+	Example: `git bug migrate --format 2`,
+	PreRunE: loadRepo,
+	RunE:    runMigrate,
+}
+
+func init() {
+	RootCmd.AddCommand(migrateCmd)
+
+	migrateCmd.Flags().SortFlags = false
+
+	migrateCmd.Flags().UintVar(&migrateFormat, "format", bug.LatestPackFormat,
+		"The format version of the operation packs")
+}
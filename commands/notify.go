@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/notifier"
+	"github.com/MichaelMure/git-bug/util/colors"
+)
+
+func runNotify(cmd *cobra.Command, args []string) error {
+	subs, err := notifier.List(repo)
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range subs {
+		rules := make([]string, len(sub.Rules))
+		for i, rule := range sub.Rules {
+			rules[i] = string(rule)
+		}
+
+		identity := ""
+		if sub.Identity != "" {
+			identity = " for " + sub.Identity.Human()
+		}
+
+		fmt.Printf("%s %s\t%s%s\n",
+			colors.Cyan(sub.Name),
+			colors.Yellow(sub.Sink),
+			strings.Join(rules, ","),
+			identity,
+		)
+	}
+
+	return nil
+}
+
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "List, add and remove the notification subscriptions, and send the notifications.",
+	Long: `List, add and remove the notification subscriptions, and send the notifications.
+
+A subscription send the changes of the bugs selected by its rules to a sink: a desktop notification, an email, a webhook, a Slack incoming webhook or a Matrix room. The subscriptions are stored in the git config of the repository, which is not shared.
+
+The notifications are sent while "git bug notify run" is running.`,
+	PreRunE: loadRepo,
+	RunE:    runNotify,
+	Args:    cobra.NoArgs,
+}
+
+func init() {
+	RootCmd.AddCommand(notifyCmd)
+}
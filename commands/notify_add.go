@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/notifier"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+)
+
+var (
+	notifyAddSink     string
+	notifyAddRules    []string
+	notifyAddIdentity string
+	notifyAddParams   []string
+)
+
+func runNotifyAdd(cmd *cobra.Command, args []string) error {
+	rules, err := notifier.ParseRules(strings.Join(notifyAddRules, ","))
+	if err != nil {
+		return err
+	}
+
+	params := make(map[string]string)
+	for _, param := range notifyAddParams {
+		split := strings.SplitN(param, "=", 2)
+		if len(split) != 2 {
+			return fmt.Errorf("invalid parameter %q, expected key=value", param)
+		}
+		params[split[0]] = split[1]
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	var identity *cache.IdentityCache
+	if notifyAddIdentity != "" {
+		identity, err = backend.ResolveIdentityPrefix(notifyAddIdentity)
+	} else {
+		identity, err = backend.GetUserIdentity()
+	}
+	if err != nil {
+		return err
+	}
+
+	sub := &notifier.Subscription{
+		Name:     args[0],
+		Identity: identity.Id(),
+		Rules:    rules,
+		Sink:     notifier.SinkKind(notifyAddSink),
+		Params:   params,
+	}
+
+	err = notifier.Add(repo, sub)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Subscription %s added for %s\n", sub.Name, identity.DisplayName())
+
+	return nil
+}
+
+var notifyAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add a notification subscription.",
+	Long: `Add a notification subscription.
+
+The rules select the changes to notify, a change being notified if any rule match:
+- all: every change
+- new: the creation of a bug
+- author: the changes of the bugs created by the user
+- assigned: the changes of the bugs assigned to the user
+- participating: the changes of the bugs the user created, commented or is assigned to
+- label:<name>: the changes of the bugs having this label
+
+The changes made by the user of the subscription are never notified.
+
+The sinks take the following parameters:
+- desktop: none, it uses notify-send on Linux and osascript on macOS
+- email: smtp (host:port of the server), smtp-user, smtp-password, from and to (comma separated)
+- webhook: url, receiving a POST of a JSON description of the change
+- slack: url, the incoming webhook of the channel
+- matrix: url (of the homeserver), room (the room id) and token (the access token of the account posting)`,
+	Example: `Get a desktop notification for the bugs you participate in:
+git bug notify add desktop --sink desktop
+
+Send the new security bugs to the team by email:
+git bug notify add security --sink email --rule new,label:security \
+    --param smtp=smtp.example.com:587 --param smtp-user=bot --param smtp-password=secret \
+    --param from=git-bug@example.com --param to=security@example.com
+
+Post everything in a Slack channel:
+git bug notify add slack --sink slack --rule all --param url=https://hooks.slack.com/services/T000/B000/XXXX
+`,
+	PreRunE: loadRepo,
+	RunE:    runNotifyAdd,
+	Args:    cobra.ExactArgs(1),
+}
+
+func init() {
+	notifyCmd.AddCommand(notifyAddCmd)
+
+	notifyAddCmd.Flags().SortFlags = false
+
+	notifyAddCmd.Flags().StringVarP(&notifyAddSink, "sink", "s", "",
+		"Where to send the notifications. Valid values are [desktop,email,webhook,slack,matrix]")
+	notifyAddCmd.Flags().StringSliceVarP(&notifyAddRules, "rule", "r", []string{string(notifier.RuleParticipating)},
+		"Which changes to notify. Valid values are [all,new,author,assigned,participating,label:<name>]")
+	notifyAddCmd.Flags().StringVarP(&notifyAddIdentity, "identity", "i", "",
+		"The user of the subscription, matching this id prefix, instead of the user identity")
+	notifyAddCmd.Flags().StringArrayVarP(&notifyAddParams, "param", "p", nil,
+		"A key=value parameter of the sink")
+}
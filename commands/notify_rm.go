@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/notifier"
+)
+
+func runNotifyRm(cmd *cobra.Command, args []string) error {
+	err := notifier.Remove(repo, args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Subscription %s removed\n", args[0])
+
+	return nil
+}
+
+var notifyRmCmd = &cobra.Command{
+	Use:     "rm <name>",
+	Short:   "Remove a notification subscription.",
+	PreRunE: loadRepo,
+	RunE:    runNotifyRm,
+	Args:    cobra.ExactArgs(1),
+}
+
+func init() {
+	notifyCmd.AddCommand(notifyRmCmd)
+}
@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/notifier"
+)
+
+var (
+	notifyRunInterval time.Duration
+)
+
+func runNotifyRun(cmd *cobra.Command, args []string) error {
+	if notifyRunInterval <= 0 {
+		return fmt.Errorf("the interval must be positive")
+	}
+
+	subs, err := notifier.List(repo)
+	if err != nil {
+		return err
+	}
+	if len(subs) == 0 {
+		return fmt.Errorf("no subscription, add one with \"git bug notify add\"")
+	}
+
+	// like for watch, the cache is not used, so that the repository is not
+	// locked and other commands can still change the bugs
+	n, err := notifier.NewNotifier(notifier.RepoResolver(repo), subs)
+	if err != nil {
+		return err
+	}
+
+	watcher, err := cache.NewBugWatcher(repo)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan cache.BugChangeEvent)
+	pollErr := make(chan error, 1)
+
+	go func() {
+		defer close(events)
+
+		ticker := time.NewTicker(notifyRunInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			changes, err := watcher.Poll()
+			if err != nil {
+				pollErr <- err
+				return
+			}
+
+			for _, event := range changes {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	fmt.Printf("Notifying %d subscriptions...\n", len(subs))
+
+	n.Run(ctx, events, func(err error) {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+	})
+
+	select {
+	case err := <-pollErr:
+		return err
+	default:
+		return nil
+	}
+}
+
+var notifyRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Stay running and send the notifications of the subscriptions.",
+	Long: `Stay running and send the notifications of the subscriptions.
+
+The changes of the bugs are detected like with "git bug watch", be it by another git-bug command or by a git operation like a fetch. A sink failing to deliver a notification is reported, but doesn't stop the others.`,
+	PreRunE: loadRepo,
+	RunE:    runNotifyRun,
+	Args:    cobra.NoArgs,
+}
+
+func init() {
+	notifyCmd.AddCommand(notifyRunCmd)
+
+	notifyRunCmd.Flags().SortFlags = false
+
+	notifyRunCmd.Flags().DurationVarP(&notifyRunInterval, "interval", "i", 2*time.Second,
+		"Interval between two checks of the repository")
+}
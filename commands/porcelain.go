@@ -0,0 +1,26 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The porcelain output of the read commands is meant for scripts: it is
+// documented in doc/porcelain.md and must stay backward compatible. New fields
+// are only ever added at the end of a record.
+
+var porcelainEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"\t", `\t`,
+	"\n", `\n`,
+	"\r", `\r`,
+)
+
+// printPorcelain print a porcelain record: the fields separated by tabs, with
+// the tabulations, line breaks and backslashes of each field escaped
+func printPorcelain(fields ...string) {
+	for i, field := range fields {
+		fields[i] = porcelainEscaper.Replace(field)
+	}
+	fmt.Println(strings.Join(fields, "\t"))
+}
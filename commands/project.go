@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/MichaelMure/git-bug/util/text"
+)
+
+var (
+	projectPorcelain bool
+)
+
+func runProject(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	current, err := backend.CurrentProject()
+	if err != nil {
+		return err
+	}
+
+	counts := backend.Projects()
+
+	// the current project can be empty yet
+	found := current == ""
+	for _, count := range counts {
+		found = found || count.Project == current
+	}
+	if !found {
+		counts = append(counts, cache.ProjectCount{Project: current})
+	}
+
+	for _, count := range counts {
+		marker := " "
+		if count.Project == current {
+			marker = "*"
+		}
+
+		if projectPorcelain {
+			if marker == " " {
+				marker = ""
+			}
+			printPorcelain(count.Project, strconv.Itoa(count.Open), strconv.Itoa(count.Closed), marker)
+			continue
+		}
+
+		fmt.Printf("%s %s\t%s\t%s\n",
+			marker,
+			text.LeftPadMaxLine(count.Project, 30, 0),
+			colors.Green(fmt.Sprintf("%d open", count.Open)),
+			colors.Red(fmt.Sprintf("%d closed", count.Closed)),
+		)
+	}
+
+	return nil
+}
+
+var projectCmd = &cobra.Command{
+	Use:   "project",
+	Short: "List the projects, switch between them and move bugs into them.",
+	Long: `List the projects, switch between them and move bugs into them.
+
+Projects partition the bugs of a repository tracking several components, like a monorepo. Each bug belongs to one project at most. Without sub-command, the projects in use are listed with their number of open and closed bugs, the current one marked with a *.
+
+Once the repository is switched to a project with "git bug project switch", the queries and the selected bug are restricted to this project, and the new bugs are created in it.`,
+	Example: `git bug project
+git bug project switch frontend
+git bug project set 5f8a3b2 backend
+`,
+	PreRunE: loadRepo,
+	RunE:    runProject,
+	Args:    cobra.NoArgs,
+}
+
+func init() {
+	RootCmd.AddCommand(projectCmd)
+
+	projectCmd.Flags().SortFlags = false
+
+	projectCmd.Flags().BoolVar(&projectPorcelain, "porcelain", false,
+		"Output a stable format for scripts, see doc/porcelain.md")
+}
@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+)
+
+var (
+	projectSetClear bool
+)
+
+func runProjectSet(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	return applyToBugs(backend, args, "Move", func(b *cache.BugCache, args []string) error {
+		if len(args) > 1 {
+			return errors.New("a bug belong to a single project")
+		}
+
+		snap := b.Snapshot()
+
+		// display the project
+		if len(args) == 0 && !projectSetClear {
+			if bulkQuery != "" {
+				return errors.New("you must provide a project or use --clear")
+			}
+			if snap.Project != "" {
+				fmt.Println(snap.Project)
+			}
+			return nil
+		}
+
+		if len(args) == 1 && projectSetClear {
+			return errors.New("a project can't be given along with --clear")
+		}
+
+		project := ""
+		if !projectSetClear {
+			project = args[0]
+		}
+
+		if project == snap.Project {
+			// no change, only an error for a single bug
+			if bulkQuery != "" {
+				return nil
+			}
+			return errors.New("no change")
+		}
+
+		_, err = b.SetProject(project)
+		if err != nil {
+			return err
+		}
+
+		return b.Commit()
+	})
+}
+
+var projectSetCmd = &cobra.Command{
+	Use:   "set [<id>] [<project>]",
+	Short: "Display or change the project of a bug.",
+	Long: `Display or change the project of a bug.
+
+A project name is made of letters, digits, dots, dashes and underscores.`,
+	Example: `Move the selected bug to a project:
+git bug project set frontend
+
+Remove a bug from its project:
+git bug project set 5f8a3b2 --clear
+
+Move all the bugs having a label to a project:
+git bug project set --query "project:* label:ui" frontend
+`,
+	PreRunE: loadRepo,
+	RunE:    runProjectSet,
+}
+
+func init() {
+	projectCmd.AddCommand(projectSetCmd)
+
+	projectSetCmd.Flags().SortFlags = false
+
+	projectSetCmd.Flags().BoolVarP(&projectSetClear, "clear", "c", false,
+		"Remove the bug from its project")
+	addBulkFlags(projectSetCmd)
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+)
+
+var (
+	projectSwitchClear bool
+)
+
+func runProjectSwitch(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 && !projectSwitchClear {
+		return errors.New("you must provide a project or use --clear")
+	}
+	if len(args) == 1 && projectSwitchClear {
+		return errors.New("a project can't be given along with --clear")
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	if projectSwitchClear {
+		err = backend.SetCurrentProject("")
+		if err != nil {
+			return err
+		}
+
+		_, _ = fmt.Fprintln(os.Stderr, "Switched to the whole repository")
+		return nil
+	}
+
+	err = backend.SetCurrentProject(args[0])
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(os.Stderr, "Switched to the project %s\n", args[0])
+
+	return nil
+}
+
+var projectSwitchCmd = &cobra.Command{
+	Use:   "switch [<project>]",
+	Short: "Switch the repository to a project.",
+	Long: `Switch the repository to a project.
+
+Once switched, the queries only match the bugs of the project, unless they filter on the project themselves (project:<name>, project:* or no:project), the new bugs are created in the project, and each project keeps its own selected bug. With --clear, the repository is switched back to all the bugs.
+
+The project doesn't need to hold any bug yet. The current project is stored in the git config of the repository, as git-bug.project.`,
+	Example: `git bug project switch frontend
+git bug project switch --clear`,
+	PreRunE: loadRepo,
+	RunE:    runProjectSwitch,
+	Args:    cobra.MaximumNArgs(1),
+}
+
+func init() {
+	projectCmd.AddCommand(projectSwitchCmd)
+
+	projectSwitchCmd.Flags().SortFlags = false
+
+	projectSwitchCmd.Flags().BoolVarP(&projectSwitchClear, "clear", "c", false,
+		"Switch back to the whole repository")
+}
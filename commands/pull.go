@@ -1,26 +1,18 @@
 package commands
 
 import (
-	"errors"
 	"fmt"
 
 	"github.com/spf13/cobra"
 
+	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
 	"github.com/MichaelMure/git-bug/util/interrupt"
 )
 
 func runPull(cmd *cobra.Command, args []string) error {
-	if len(args) > 1 {
-		return errors.New("Only pulling from one remote at a time is supported")
-	}
-
-	remote := "origin"
-	if len(args) == 1 {
-		remote = args[0]
-	}
-
 	backend, err := cache.NewRepoCache(repo)
 	if err != nil {
 		return err
@@ -28,9 +20,19 @@ func runPull(cmd *cobra.Command, args []string) error {
 	defer backend.Close()
 	interrupt.RegisterCleaner(backend.Close)
 
+	remote, ids, err := resolveRemoteAndBugs(backend, args, true)
+	if err != nil {
+		return err
+	}
+
 	fmt.Println("Fetching remote ...")
 
-	stdout, err := backend.Fetch(remote)
+	var stdout string
+	if len(ids) > 0 {
+		stdout, err = backend.FetchBugs(remote, ids)
+	} else {
+		stdout, err = backend.Fetch(remote)
+	}
 	if err != nil {
 		return err
 	}
@@ -39,23 +41,51 @@ func runPull(cmd *cobra.Command, args []string) error {
 
 	fmt.Println("Merging data ...")
 
-	for result := range backend.MergeAll(remote) {
+	var results <-chan entity.MergeResult
+	if len(ids) > 0 {
+		results = backend.MergeBugs(remote, ids)
+	} else {
+		results = backend.MergeAll(remote)
+	}
+
+	for result := range results {
 		if result.Err != nil {
 			fmt.Println(result.Err)
 		}
 
 		if result.Status != entity.MergeStatusNothing {
-			fmt.Printf("%s: %s\n", result.Id.Human(), result)
+			fmt.Printf("%s%s: %s\n", mergedKind(result), result.Id.Human(), result)
 		}
 	}
 
 	return nil
 }
 
+// mergedKind name the kind of entity of a merge result, when known
+func mergedKind(result entity.MergeResult) string {
+	switch result.Entity.(type) {
+	case *bug.Bug:
+		return "bug "
+	case *identity.Identity:
+		return "identity "
+	default:
+		return ""
+	}
+}
+
 // showCmd defines the "push" subcommand.
 var pullCmd = &cobra.Command{
-	Use:     "pull [<remote>]",
-	Short:   "Pull bugs update from a git remote.",
+	Use:   "pull [<remote>] [<id>...]",
+	Short: "Pull bugs update from a git remote.",
+	Long: `Pull bugs update from a git remote, "origin" by default.
+
+By default, all the bugs are pulled. When bug ids are given, only those bugs are fetched and merged. A bug not pulled yet must be given with its full id. The identities are always pulled, as the bugs refer to them.`,
+	Example: `Pull all the bugs:
+git bug pull
+
+Pull a single bug from a fork:
+git bug pull fork 5f8a3b2c7d9e1f3a5b7c9d1e3f5a7b9c1d3e5f7a9b1c3d5e7f9a1b3c5d7e9f1a
+`,
 	PreRunE: loadRepo,
 	RunE:    runPull,
 }
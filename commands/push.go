@@ -1,24 +1,16 @@
 package commands
 
 import (
-	"errors"
 	"fmt"
 
+	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
 	"github.com/MichaelMure/git-bug/util/interrupt"
 	"github.com/spf13/cobra"
 )
 
 func runPush(cmd *cobra.Command, args []string) error {
-	if len(args) > 1 {
-		return errors.New("Only pushing to one remote at a time is supported")
-	}
-
-	remote := "origin"
-	if len(args) == 1 {
-		remote = args[0]
-	}
-
 	backend, err := cache.NewRepoCache(repo)
 	if err != nil {
 		return err
@@ -26,7 +18,17 @@ func runPush(cmd *cobra.Command, args []string) error {
 	defer backend.Close()
 	interrupt.RegisterCleaner(backend.Close)
 
-	stdout, err := backend.Push(remote)
+	remote, ids, err := resolveRemoteAndBugs(backend, args, false)
+	if err != nil {
+		return err
+	}
+
+	var stdout string
+	if len(ids) > 0 {
+		stdout, err = backend.PushBugs(remote, ids)
+	} else {
+		stdout, err = backend.Push(remote)
+	}
 	if err != nil {
 		return err
 	}
@@ -36,10 +38,62 @@ func runPush(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// resolveRemoteAndBugs split the arguments of push and pull: an optional remote,
+// "origin" by default, then optional bug ids. When pulling, the bugs not known
+// locally can be given with their full id.
+func resolveRemoteAndBugs(backend *cache.RepoCache, args []string, pulling bool) (string, []entity.Id, error) {
+	remote := "origin"
+
+	if len(args) > 0 {
+		remotes, err := backend.GetRemotes()
+		if err != nil {
+			return "", nil, err
+		}
+		if _, ok := remotes[args[0]]; ok {
+			remote = args[0]
+			args = args[1:]
+		}
+	}
+
+	ids := make([]entity.Id, 0, len(args))
+	for _, arg := range args {
+		b, err := backend.ResolveBugPrefix(arg)
+		if err == nil {
+			ids = append(ids, b.Id())
+			continue
+		}
+
+		if err != bug.ErrBugNotExist {
+			return "", nil, err
+		}
+
+		if pulling && entity.Id(arg).Validate() == nil {
+			ids = append(ids, entity.Id(arg))
+			continue
+		}
+
+		if pulling {
+			return "", nil, fmt.Errorf("%s is neither a remote nor a known bug, a bug not pulled yet must be given with its full id", arg)
+		}
+		return "", nil, fmt.Errorf("%s is neither a remote nor a bug", arg)
+	}
+
+	return remote, ids, nil
+}
+
 // showCmd defines the "push" subcommand.
 var pushCmd = &cobra.Command{
-	Use:     "push [<remote>]",
-	Short:   "Push bugs update to a git remote.",
+	Use:   "push [<remote>] [<id>...]",
+	Short: "Push bugs update to a git remote.",
+	Long: `Push bugs update to a git remote, "origin" by default.
+
+By default, all the bugs are pushed. When bug ids are given, only those bugs are pushed. The identities are always pushed, as the bugs refer to them.`,
+	Example: `Push all the bugs:
+git bug push
+
+Push a single bug to a fork:
+git bug push fork 5f8a3b2
+`,
 	PreRunE: loadRepo,
 	RunE:    runPush,
 }
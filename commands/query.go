@@ -0,0 +1,20 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var queryCmd = &cobra.Command{
+	Use:   "query",
+	Short: "List, save and remove named queries.",
+	Long: `List, save and remove named queries. Without a subcommand, the saved queries are listed as with "git bug query ls".
+
+A saved query can be used in any other query as @name, for example with "git bug ls @mine", in the terminal UI or through the GraphQL API.`,
+	PreRunE: loadRepo,
+	RunE:    runQueryLs,
+	Args:    cobra.NoArgs,
+}
+
+func init() {
+	RootCmd.AddCommand(queryCmd)
+}
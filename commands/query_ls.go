@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+)
+
+func runQueryLs(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	queries, err := backend.SavedQueries()
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(queries))
+	for name := range queries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Printf("%s\t%s\n", colors.Cyan("@"+name), queries[name])
+	}
+
+	return nil
+}
+
+var queryLsCmd = &cobra.Command{
+	Use:     "ls",
+	Short:   "List the saved queries.",
+	PreRunE: loadRepo,
+	RunE:    runQueryLs,
+	Args:    cobra.NoArgs,
+}
+
+func init() {
+	queryCmd.AddCommand(queryLsCmd)
+}
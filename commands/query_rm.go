@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+)
+
+func runQueryRm(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	return backend.RemoveSavedQuery(args[0])
+}
+
+var queryRmCmd = &cobra.Command{
+	Use:     "rm <name>",
+	Short:   "Remove a saved query.",
+	PreRunE: loadRepo,
+	RunE:    runQueryRm,
+	Args:    cobra.ExactArgs(1),
+}
+
+func init() {
+	queryCmd.AddCommand(queryRmCmd)
+}
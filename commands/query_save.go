@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+)
+
+func runQuerySave(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	return backend.SaveQuery(args[0], strings.Join(args[1:], " "))
+}
+
+var querySaveCmd = &cobra.Command{
+	Use:     "save <name> <query>",
+	Short:   "Save a query under a name.",
+	Example: `git bug query save mine "status:open participant:me"`,
+	PreRunE: loadRepo,
+	RunE:    runQuerySave,
+	Args:    cobra.MinimumNArgs(2),
+}
+
+func init() {
+	queryCmd.AddCommand(querySaveCmd)
+}
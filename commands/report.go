@@ -0,0 +1,197 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+// width of the longest bar of the chart
+const reportChartWidth = 40
+
+// grouping of the bugs without label or milestone
+const reportNoneGroup = "(none)"
+
+var (
+	reportSince string
+	reportUntil string
+	reportBy    string
+)
+
+func runReport(cmd *cobra.Command, args []string) error {
+	if reportBy != "" && reportBy != "label" && reportBy != "milestone" {
+		return fmt.Errorf("unknown grouping %s", reportBy)
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	query, err := backend.ParseQuery(strings.Join(args, " "))
+	if err != nil {
+		return err
+	}
+
+	// same as the stats, the end is computed first to not get an additional
+	// interval of a few nanoseconds
+	until := time.Now()
+	if reportUntil != "" {
+		until, err = cache.ParseTime(reportUntil)
+		if err != nil {
+			return err
+		}
+	}
+	until = until.Truncate(time.Second)
+
+	since, err := cache.ParseTime(reportSince)
+	if err != nil {
+		return err
+	}
+	// the intervals start at midnight, to match the displayed dates
+	since = time.Date(since.Year(), since.Month(), since.Day(), 0, 0, 0, 0, since.Location())
+
+	if !since.Before(until) {
+		return fmt.Errorf("the start of the period must be before its end")
+	}
+
+	// days for a sprint, weeks for a longer period
+	step, stepName := 24*time.Hour, "Day"
+	if until.Sub(since) > 31*24*time.Hour {
+		step, stepName = 7*24*time.Hour, "Week"
+	}
+
+	ids := backend.QueryBugs(query)
+
+	groups, names, err := reportGroups(backend, ids)
+	if err != nil {
+		return err
+	}
+
+	for i, name := range names {
+		points, err := backend.Burndown(groups[name], since, until, step)
+		if err != nil {
+			return err
+		}
+
+		if i > 0 {
+			fmt.Println()
+		}
+		if name != "" {
+			fmt.Printf("%s %s\n", strings.Title(reportBy), colors.Cyan(name))
+		}
+		reportPrint(points, stepName)
+	}
+
+	return nil
+}
+
+// reportGroups split the bugs according to their current label or milestone.
+// A bug having several labels is in several groups.
+func reportGroups(backend *cache.RepoCache, ids []entity.Id) (map[string][]entity.Id, []string, error) {
+	groups := make(map[string][]entity.Id)
+
+	if reportBy == "" {
+		groups[""] = ids
+		return groups, []string{""}, nil
+	}
+
+	for _, id := range ids {
+		excerpt, err := backend.ResolveBugExcerpt(id)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var names []string
+		switch reportBy {
+		case "label":
+			for _, l := range excerpt.Labels {
+				names = append(names, l.String())
+			}
+		case "milestone":
+			if excerpt.Milestone != "" {
+				names = append(names, excerpt.Milestone)
+			}
+		}
+		if len(names) == 0 {
+			names = []string{reportNoneGroup}
+		}
+
+		for _, name := range names {
+			groups[name] = append(groups[name], id)
+		}
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return groups, names, nil
+}
+
+func reportPrint(points []cache.BurndownPoint, stepName string) {
+	max := 0
+	for _, p := range points {
+		if p.Open > max {
+			max = p.Open
+		}
+	}
+
+	fmt.Printf("%-10s\t%6s\t%6s\t%6s\n", stepName, "Opened", "Closed", "Open")
+	for _, p := range points {
+		bar := 0
+		if max > 0 {
+			bar = (p.Open*reportChartWidth + max - 1) / max
+		}
+
+		fmt.Printf("%s\t%6d\t%6d\t%6d\t%s\n",
+			p.Start.Format("2006-01-02"),
+			p.Opened,
+			p.Closed,
+			p.Open,
+			colors.Green(strings.Repeat("#", bar)),
+		)
+	}
+}
+
+var reportCmd = &cobra.Command{
+	Use:   "report [<query>]",
+	Short: "Display a burndown of the bugs over a period.",
+	Long: `Display a burndown of the bugs over a period: for each day, or each week if the period is longer than a month, the number of bugs opened and closed and the number of bugs remaining open at its end, with a chart of the latter.
+
+A query can restrict the bugs considered. The bounds of the period are given either as a date (2006-01-02) or as a duration before now (30d, 12w, 1y).
+
+With --by, a burndown is displayed for each label or milestone the bugs currently have.`,
+	Example: `Burndown of the last sprint:
+git bug report --since 14d
+
+Burndown of each milestone over the last quarter:
+git bug report --since 12w --by milestone
+`,
+	PreRunE: loadRepo,
+	RunE:    runReport,
+}
+
+func init() {
+	RootCmd.AddCommand(reportCmd)
+
+	reportCmd.Flags().SortFlags = false
+
+	reportCmd.Flags().StringVar(&reportSince, "since", "30d",
+		"Start of the period, as a date or a duration before now")
+	reportCmd.Flags().StringVar(&reportUntil, "until", "",
+		"End of the period, as a date or a duration before now (default to now)")
+	reportCmd.Flags().StringVarP(&reportBy, "by", "b", "",
+		"Display a burndown for each group of bugs. Valid values are [label,milestone]")
+}
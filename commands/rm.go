@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/MichaelMure/git-bug/cache"
+	_select "github.com/MichaelMure/git-bug/commands/select"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runRm(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return errors.New("you must provide the id of the bug to remove")
+	}
+
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	// resolve everything first, to not remove only some of the bugs
+	bugs := make([]*cache.BugCache, len(args))
+	for i, prefix := range args {
+		bugs[i], err = backend.ResolveBugPrefix(prefix)
+		if err != nil {
+			return err
+		}
+	}
+
+	ids := make([]entity.Id, len(bugs))
+	for i, b := range bugs {
+		err = backend.RemoveBug(b.Id())
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("bug %s removed: %s\n", b.Id().Human(), b.Snapshot().Title)
+		ids[i] = b.Id()
+	}
+
+	// a removed bug can't stay selected
+	err = _select.Unselect(backend, ids...)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintln(os.Stderr, "The bugs are only removed locally: they will come back with the next pull if a remote has them.")
+
+	return nil
+}
+
+var rmCmd = &cobra.Command{
+	Use:   "rm <id>...",
+	Short: "Remove bugs from the local repository.",
+	Long: `Remove bugs from the local repository, along with their remote-tracking references.
+
+The bugs are not removed from the remotes: a bug that was pushed will come back with the next pull. This is meant for pruning bugs created by mistake or for testing, before sharing them.`,
+	Example: `git bug rm 5f8a3b2`,
+	PreRunE: loadRepo,
+	RunE:    runRm,
+}
+
+func init() {
+	RootCmd.AddCommand(rmCmd)
+}
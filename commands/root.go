@@ -6,16 +6,35 @@ import (
 	"os"
 
 	"github.com/MichaelMure/git-bug/bug"
+	_select "github.com/MichaelMure/git-bug/commands/select"
 	"github.com/MichaelMure/git-bug/identity"
 	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/termui"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
 )
 
 const rootCommandName = "git-bug"
 
+// environment variable giving the repository to use, overridden by --repo
+const repoPathEnv = "GIT_BUG_REPO"
+
+// environment variable giving the git backend to use, overridden by --backend
+const backendEnv = "GIT_BUG_BACKEND"
+
 // package scoped var to hold the repo after the PreRun execution
 var repo repository.ClockedRepo
 
+// path of the repository to use, the current directory if empty
+var repoPath string
+
+// git backend to use: "git" to run the git binary, or "go-git"
+var backend string
+
+// never ask the user to pick a bug interactively
+var noInteractive bool
+
 // RootCmd represents the base command when called without any subcommands
 var RootCmd = &cobra.Command{
 	Use:   rootCommandName,
@@ -40,28 +59,148 @@ the same git remote your are already using to collaborate with other peoples.
 	DisableAutoGenTag: true,
 
 	// Custom bash code to connect the git completion for "git bug" to the
-	// git-bug completion for "git-bug"
+	// git-bug completion for "git-bug", and to complete bug ids, identity
+	// queries and labels
 	BashCompletionFunction: `
 _git_bug() {
     __start_git-bug "$@"
 }
+
+__git-bug_complete_identity() {
+    local out
+    if out=$(git-bug user search --completion "${cur}" 2>/dev/null); then
+        COMPREPLY+=( ${out} )
+    fi
+}
+
+__git-bug_complete_label() {
+    local out
+    if out=$(git-bug ls-label 2>/dev/null); then
+        local IFS=$'\n'
+        COMPREPLY+=( $(compgen -W "${out}" -- "${cur}") )
+    fi
+}
+
+__git-bug_complete_bug() {
+    local out
+    if out=$(git-bug ls-id --completion "${cur}" 2>/dev/null); then
+        local IFS=$'\n'
+        COMPREPLY+=( ${out} )
+    fi
+}
+
+# the bugs are listed with their title as a hint, which must not be inserted
+# when it is the only candidate
+__git-bug_strip_hint() {
+    if [[ ${#COMPREPLY[@]} -eq 1 ]]; then
+        COMPREPLY=( "${COMPREPLY[0]%%  *}" )
+    fi
+}
+
+__git-bug_custom_func() {
+    case ${last_command} in
+        git-bug_show | git-bug_select | git-bug_comment | git-bug_comment_add | \
+        git-bug_comment_edit | git-bug_comment_rm | git-bug_label | git-bug_status | \
+        git-bug_status_open | git-bug_status_close | git-bug_title | git-bug_title_edit)
+            if [[ ${#nouns[@]} -eq 0 ]]; then
+                __git-bug_complete_bug
+            fi
+            ;;
+        git-bug_rm)
+            __git-bug_complete_bug
+            ;;
+        git-bug_label_add | git-bug_label_rm)
+            if [[ ${#nouns[@]} -eq 0 ]]; then
+                __git-bug_complete_bug
+            fi
+            __git-bug_complete_label
+            ;;
+        git-bug_label_rename)
+            if [[ ${#nouns[@]} -eq 0 ]]; then
+                __git-bug_complete_label
+            fi
+            ;;
+        git-bug_assign)
+            if [[ ${#nouns[@]} -eq 0 ]]; then
+                __git-bug_complete_bug
+            fi
+            __git-bug_complete_identity
+            ;;
+    esac
+    __git-bug_strip_hint
+}
 `,
 }
 
+// Exit codes of git-bug, part of the porcelain interface (see doc/porcelain.md)
+const (
+	exitError    = 1
+	exitNotFound = 2
+)
+
+func init() {
+	RootCmd.PersistentFlags().StringVarP(&repoPath, "repo", "C", "",
+		fmt.Sprintf("Run as if git-bug was started in this path instead of the current directory. Can also be set with %s", repoPathEnv))
+	RootCmd.PersistentFlags().StringVar(&backend, "backend", "",
+		fmt.Sprintf("Access the repository by running the git binary (git), or without it (go-git). Can also be set with %s", backendEnv))
+	RootCmd.PersistentFlags().BoolVar(&noInteractive, "no-interactive", false,
+		"Fail instead of asking to pick a bug when none is given or selected")
+}
+
 func Execute() {
 	if err := RootCmd.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(exitCode(err))
+	}
+}
+
+// exitCode distinguish the failures to find a bug or an identity from the
+// other errors
+func exitCode(err error) int {
+	switch errors.Cause(err) {
+	case bug.ErrBugNotExist, identity.ErrIdentityNotExist, _select.ErrNoValidId, _select.ErrSelectionExpired:
+		return exitNotFound
+	default:
+		return exitError
 	}
 }
 
 // loadRepo is a pre-run function that load the repository for use in a command
 func loadRepo(cmd *cobra.Command, args []string) error {
+	path := repoPath
+	if path == "" {
+		path = os.Getenv(repoPathEnv)
+	}
+
+	// like "git -C", so that the git commands and the relative paths behave
+	// the same as in the repository
+	if path != "" {
+		if err := os.Chdir(path); err != nil {
+			return fmt.Errorf("can't use the repository %s: %v", path, err)
+		}
+	}
+
 	cwd, err := os.Getwd()
 	if err != nil {
 		return fmt.Errorf("Unable to get the current working directory: %q\n", err)
 	}
 
-	repo, err = repository.NewGitRepo(cwd, bug.Witnesser)
+	name := backend
+	if name == "" {
+		name = os.Getenv(backendEnv)
+	}
+
+	switch name {
+	case "", "git":
+		repo, err = repository.NewGitRepo(cwd, bug.Witnesser)
+	case "go-git":
+		repo, err = repository.NewGoGitRepo(cwd, bug.Witnesser)
+	default:
+		return fmt.Errorf("unknown backend %s, expected git or go-git", name)
+	}
+
+	if err == repository.ErrNotARepo && path != "" {
+		return fmt.Errorf("%s is not a git repository", path)
+	}
 	if err == repository.ErrNotARepo {
 		return fmt.Errorf("%s must be run from within a git repo.\n", rootCommandName)
 	}
@@ -70,6 +209,11 @@ func loadRepo(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// only when a user is there to answer
+	if !noInteractive && terminal.IsTerminal(int(os.Stdin.Fd())) && terminal.IsTerminal(int(os.Stdout.Fd())) {
+		_select.Picker = termui.PickBug
+	}
+
 	return nil
 }
 
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/rules"
+)
+
+var (
+	rulesFile string
+)
+
+func runRules(cmd *cobra.Command, args []string) error {
+	loaded, err := loadRules()
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range loaded {
+		fmt.Println(rule)
+	}
+
+	return nil
+}
+
+func loadRules() ([]rules.Rule, error) {
+	if rulesFile == "" {
+		rulesFile = rules.DefaultPath(repo)
+	}
+	loaded, err := rules.Load(rulesFile)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no rules file %s", rulesFile)
+	}
+	return loaded, err
+}
+
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "List the automation rules, and apply them to the bugs.",
+	Long: `List the automation rules, and apply them to the bugs.
+
+A rule applies an action to the bugs matching a query, for example to label the bugs left untouched for a while, or to ping their assignee. The actions are regular operations, authored by the user identity, and are synchronized like any other change.
+
+The rules are read from the file .git/git-bug/rules of the repository, or from the file given with --file, one per line:
+  <name>: <query> => <action> [<args>...]
+
+The query uses the same language as "git bug ls", and is not restricted to the current project. The actions are the same as for "git bug batch":
+  open
+  close
+  comment <message>
+  title <title>
+  label add <label>...
+  label rm <label>...
+  assign <user>
+  unassign
+  milestone [<milestone>]
+
+In the arguments, {author} and {assignee} are replaced by the login, or else the name, of the author and the assignee of the bug. A bug without assignee is skipped by a rule using {assignee}. Empty lines and lines starting with # are ignored.`,
+	Example: `# label the open bugs untouched for 60 days
+stale: status:open edited-before:60d => label add stale
+# ping the assignee after 14 days
+ping: status:open edited-before:14d => comment "@{assignee} any news on this bug?"`,
+	PreRunE: loadRepo,
+	RunE:    runRules,
+	Args:    cobra.NoArgs,
+}
+
+func init() {
+	RootCmd.AddCommand(rulesCmd)
+
+	rulesCmd.PersistentFlags().StringVarP(&rulesFile, "file", "f", "",
+		"Read the rules from this file instead of .git/git-bug/rules")
+}
@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/rules"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+)
+
+var (
+	rulesRunDryRun bool
+	rulesRunEvery  time.Duration
+)
+
+func runRulesRun(cmd *cobra.Command, args []string) error {
+	if rulesRunEvery < 0 {
+		return fmt.Errorf("the interval must be positive")
+	}
+
+	loaded, err := loadRules()
+	if err != nil {
+		return err
+	}
+	if len(loaded) == 0 {
+		return fmt.Errorf("no rule in %s", rulesFile)
+	}
+
+	if rulesRunEvery == 0 {
+		return applyRules(loaded)
+	}
+
+	for {
+		if err := applyRules(loaded); err != nil {
+			// keep running, the next round might succeed
+			_, _ = fmt.Fprintln(os.Stderr, err)
+		}
+		time.Sleep(rulesRunEvery)
+	}
+}
+
+// applyRules open the cache only for one round, so that the repository is not
+// locked in between
+func applyRules(loaded []rules.Rule) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	changes, err := rules.Run(backend, loaded, rulesRunDryRun)
+
+	for _, change := range changes {
+		fmt.Printf("%s %s %s\t%s\n",
+			colors.Cyan(change.BugId.Human()),
+			colors.Yellow(change.Rule),
+			change.Action,
+			change.Title,
+		)
+	}
+
+	return err
+}
+
+var rulesRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Apply the automation rules to the bugs.",
+	Long: `Apply the automation rules to the bugs, in the order of the file.
+
+Each rule sees the changes of the previous ones. An action that would do nothing, like adding a label already set, is skipped, so running the rules again doesn't change anything until the bugs evolve. A rule adding a comment is however applied again once its query matches again, like a bug left untouched for another 14 days.
+
+With --every, the command stays running and applies the rules periodically, releasing the repository in between.`,
+	Example: `git bug rules run --dry-run
+git bug rules run --every 1h`,
+	PreRunE: loadRepo,
+	RunE:    runRulesRun,
+	Args:    cobra.NoArgs,
+}
+
+func init() {
+	rulesCmd.AddCommand(rulesRunCmd)
+
+	rulesRunCmd.Flags().SortFlags = false
+
+	rulesRunCmd.Flags().BoolVarP(&rulesRunDryRun, "dry-run", "n", false,
+		"Only show the changes, without applying them")
+	rulesRunCmd.Flags().DurationVarP(&rulesRunEvery, "every", "e", 0,
+		"Stay running and apply the rules at this interval")
+}
@@ -3,6 +3,7 @@ package commands
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/commands/select"
@@ -10,11 +11,24 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	selectShow bool
+	selectFor  time.Duration
+)
+
 func runSelect(cmd *cobra.Command, args []string) error {
+	if selectShow {
+		return runSelectShow()
+	}
+
 	if len(args) == 0 {
 		return errors.New("You must provide a bug id")
 	}
 
+	if selectFor < 0 {
+		return errors.New("the duration must be positive")
+	}
+
 	backend, err := cache.NewRepoCache(repo)
 	if err != nil {
 		return err
@@ -29,7 +43,7 @@ func runSelect(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	err = _select.Select(backend, b.Id())
+	err = _select.SelectFor(backend, b.Id(), selectFor)
 	if err != nil {
 		return err
 	}
@@ -39,12 +53,49 @@ func runSelect(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runSelectShow() error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	b, expiry, err := _select.Selected(backend)
+	if err == _select.ErrSelectionExpired {
+		fmt.Println("no bug selected, the selection has expired")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if b == nil {
+		fmt.Println("no bug selected")
+		return nil
+	}
+
+	fmt.Printf("selected bug %s: %s\n", b.Id().Human(), b.Snapshot().Title)
+
+	if !expiry.IsZero() {
+		fmt.Printf("expires in %s\n", time.Until(expiry).Round(time.Second))
+	}
+
+	return nil
+}
+
 var selectCmd = &cobra.Command{
-	Use:   "select <id>",
+	Use:   "select [<id>]",
 	Short: "Select a bug for implicit use in future commands.",
 	Example: `git bug select 2f15
 git bug comment
 git bug status
+
+Select a bug for the afternoon only:
+git bug select 2f15 --for 4h
+
+Check which bug is selected:
+git bug select --show
 `,
 	Long: `Select a bug for implicit use in future commands.
 
@@ -53,6 +104,8 @@ This command allows you to omit any bug <id> argument, for example:
 instead of
   git bug show 2f153ca
 
+The selection is shared by all the worktrees of the repository. With --for, it expires after the given duration, so that a forgotten selection doesn't silently target the wrong bug later.
+
 The complementary command is "git bug deselect" performing the opposite operation.
 `,
 	PreRunE: loadRepo,
@@ -62,4 +115,9 @@ The complementary command is "git bug deselect" performing the opposite operatio
 func init() {
 	RootCmd.AddCommand(selectCmd)
 	selectCmd.Flags().SortFlags = false
+
+	selectCmd.Flags().BoolVar(&selectShow, "show", false,
+		"Display the selected bug instead of selecting one")
+	selectCmd.Flags().DurationVar(&selectFor, "for", 0,
+		"Clear the selection after this duration (ex: 2h), instead of keeping it until deselected")
 }
@@ -6,18 +6,26 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/entity"
-	"github.com/MichaelMure/git-bug/repository"
 )
 
 const selectFile = "select"
 
 var ErrNoValidId = errors.New("you must provide a bug id or use the \"select\" command first")
+var ErrSelectionExpired = errors.New("the selection has expired, you must provide a bug id or select the bug again")
+
+// Picker, if set, let the user choose a bug interactively when none is given
+// or selected. It returns nil if the user didn't choose any.
+var Picker func(repo *cache.RepoCache) (*cache.BugCache, error)
 
 // ResolveBug first try to resolve a bug using the first argument of the command
 // line. If it fails, it fallback to the select mechanism.
@@ -43,7 +51,7 @@ func ResolveBug(repo *cache.RepoCache, args []string) (*cache.BugCache, []string
 
 	// first arg is not a valid bug prefix, we can safely use the preselected bug if any
 
-	b, err := selected(repo)
+	b, _, err := Selected(repo)
 
 	// selected bug is invalid
 	if err == bug.ErrBugNotExist {
@@ -56,29 +64,59 @@ func ResolveBug(repo *cache.RepoCache, args []string) (*cache.BugCache, []string
 	}
 
 	// another error when reading the bug
-	if err != nil {
+	if err != nil && err != ErrSelectionExpired {
 		return nil, nil, err
 	}
+	expired := err == ErrSelectionExpired
 
 	// bug is successfully retrieved
 	if b != nil {
 		return b, args, nil
 	}
 
-	// no selected bug and no valid first argument
+	// no selected bug and no valid first argument, the user can still choose
+	if Picker != nil {
+		b, err := Picker(repo)
+		if err != nil {
+			return nil, nil, err
+		}
+		if b != nil {
+			return b, args, nil
+		}
+	}
+
+	if expired {
+		return nil, nil, ErrSelectionExpired
+	}
+
 	return nil, nil, ErrNoValidId
 }
 
 // Select will select a bug for future use
 func Select(repo *cache.RepoCache, id entity.Id) error {
-	selectPath := selectFilePath(repo)
+	return SelectFor(repo, id, 0)
+}
+
+// SelectFor will select a bug for future use, until the given duration has
+// elapsed. A zero duration means no expiry.
+func SelectFor(repo *cache.RepoCache, id entity.Id, duration time.Duration) error {
+	selectPath, err := selectFilePath(repo)
+	if err != nil {
+		return err
+	}
 
 	f, err := os.OpenFile(selectPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
 	if err != nil {
 		return err
 	}
 
-	_, err = f.WriteString(id.String())
+	// the expiry, if any, is stored as a unix time on a second line
+	content := id.String()
+	if duration > 0 {
+		content += "\n" + strconv.FormatInt(time.Now().Add(duration).Unix(), 10)
+	}
+
+	_, err = f.WriteString(content)
 	if err != nil {
 		return err
 	}
@@ -88,54 +126,127 @@ func Select(repo *cache.RepoCache, id entity.Id) error {
 
 // Clear will clear the selected bug, if any
 func Clear(repo *cache.RepoCache) error {
-	selectPath := selectFilePath(repo)
+	selectPath, err := selectFilePath(repo)
+	if err != nil {
+		return err
+	}
 
 	return os.Remove(selectPath)
 }
 
-func selected(repo *cache.RepoCache) (*cache.BugCache, error) {
-	selectPath := selectFilePath(repo)
+// Unselect clear the selection of every project that has one of the given
+// bugs selected, so that a removed bug doesn't stay selected
+func Unselect(repo *cache.RepoCache, ids ...entity.Id) error {
+	paths, err := filepath.Glob(path.Join(repo.GetPath(), "git-bug", selectFile+"*"))
+	if err != nil {
+		return err
+	}
+
+	for _, selectPath := range paths {
+		buf, err := ioutil.ReadFile(selectPath)
+		if err != nil {
+			return err
+		}
+
+		selected := entity.Id(strings.SplitN(string(buf), "\n", 2)[0])
+
+		for _, id := range ids {
+			if id == selected {
+				err = os.Remove(selectPath)
+				if err != nil {
+					return err
+				}
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// Selected return the selected bug if any, and when the selection expires,
+// or a zero time if it doesn't. An expired selection is cleared and reported
+// with ErrSelectionExpired.
+func Selected(repo *cache.RepoCache) (*cache.BugCache, time.Time, error) {
+	selectPath, err := selectFilePath(repo)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
 
 	f, err := os.Open(selectPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, nil
+			return nil, time.Time{}, nil
 		} else {
-			return nil, err
+			return nil, time.Time{}, err
 		}
 	}
 
 	buf, err := ioutil.ReadAll(io.LimitReader(f, 100))
 	if err != nil {
-		return nil, err
+		return nil, time.Time{}, err
 	}
 	if len(buf) == 100 {
-		return nil, fmt.Errorf("the select file should be < 100 bytes")
+		return nil, time.Time{}, fmt.Errorf("the select file should be < 100 bytes")
 	}
 
-	id := entity.Id(buf)
-	if err := id.Validate(); err != nil {
+	err = f.Close()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	lines := strings.SplitN(string(buf), "\n", 2)
+
+	id := entity.Id(lines[0])
+	var expiry time.Time
+
+	err = id.Validate()
+	if err == nil && len(lines) > 1 {
+		var unix int64
+		unix, err = strconv.ParseInt(lines[1], 10, 64)
+		expiry = time.Unix(unix, 0)
+	}
+
+	if err != nil {
 		err = os.Remove(selectPath)
 		if err != nil {
-			return nil, errors.Wrap(err, "error while removing invalid select file")
+			return nil, time.Time{}, errors.Wrap(err, "error while removing invalid select file")
 		}
 
-		return nil, fmt.Errorf("select file in invalid, removing it")
+		return nil, time.Time{}, fmt.Errorf("select file in invalid, removing it")
+	}
+
+	if !expiry.IsZero() && !time.Now().Before(expiry) {
+		err = os.Remove(selectPath)
+		if err != nil {
+			return nil, time.Time{}, errors.Wrap(err, "error while removing expired select file")
+		}
+
+		return nil, time.Time{}, ErrSelectionExpired
 	}
 
 	b, err := repo.ResolveBug(id)
 	if err != nil {
-		return nil, err
+		return nil, time.Time{}, err
 	}
 
-	err = f.Close()
+	return b, expiry, nil
+}
+
+// selectFilePath return the path of the select file. As the repository path
+// is the common git directory, the worktrees share the selection. Each project
+// has its own selection, so that switching between projects doesn't carry a
+// bug of another project along.
+func selectFilePath(repo *cache.RepoCache) (string, error) {
+	project, err := repo.CurrentProject()
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	return b, nil
-}
+	name := selectFile
+	if project != "" {
+		name += "-" + project
+	}
 
-func selectFilePath(repo repository.RepoCommon) string {
-	return path.Join(repo.GetPath(), "git-bug", selectFile)
+	return path.Join(repo.GetPath(), "git-bug", name), nil
 }
@@ -1,6 +1,8 @@
 package _select
 
 import (
+	"fmt"
+	"io/ioutil"
 	"testing"
 	"time"
 
@@ -78,3 +80,133 @@ func TestSelect(t *testing.T) {
 	_, _, err = ResolveBug(repoCache, []string{})
 	require.Error(t, err)
 }
+
+func TestSelectExpiry(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	repoCache, err := cache.NewRepoCache(repo)
+	require.NoError(t, err)
+
+	rene, err := repoCache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+
+	b1, _, err := repoCache.NewBugRaw(rene, time.Now().Unix(), "title", "message", nil, nil)
+	require.NoError(t, err)
+
+	err = SelectFor(repoCache, b1.Id(), time.Hour)
+	require.NoError(t, err)
+
+	b2, expiry, err := Selected(repoCache)
+	require.NoError(t, err)
+	require.Equal(t, b1.Id(), b2.Id())
+	require.WithinDuration(t, time.Now().Add(time.Hour), expiry, time.Minute)
+
+	b3, _, err := ResolveBug(repoCache, []string{})
+	require.NoError(t, err)
+	require.Equal(t, b1.Id(), b3.Id())
+
+	// expire the selection
+	content := fmt.Sprintf("%s\n%d", b1.Id(), time.Now().Add(-time.Minute).Unix())
+	selectPath, err := selectFilePath(repoCache)
+	require.NoError(t, err)
+	err = ioutil.WriteFile(selectPath, []byte(content), 0666)
+	require.NoError(t, err)
+
+	_, _, err = ResolveBug(repoCache, []string{})
+	require.Equal(t, ErrSelectionExpired, err)
+
+	// the expired selection has been cleared
+	_, _, err = ResolveBug(repoCache, []string{})
+	require.Equal(t, ErrNoValidId, err)
+
+	// no expiry
+	err = Select(repoCache, b1.Id())
+	require.NoError(t, err)
+
+	_, expiry, err = Selected(repoCache)
+	require.NoError(t, err)
+	require.True(t, expiry.IsZero())
+}
+
+func TestSelectProject(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	repoCache, err := cache.NewRepoCache(repo)
+	require.NoError(t, err)
+
+	rene, err := repoCache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+
+	b1, _, err := repoCache.NewBugRaw(rene, time.Now().Unix(), "title", "message", nil, nil)
+	require.NoError(t, err)
+	b2, _, err := repoCache.NewBugRaw(rene, time.Now().Unix(), "title", "message", nil, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, Select(repoCache, b1.Id()))
+
+	// each project has its own selection
+	require.NoError(t, repoCache.SetCurrentProject("frontend"))
+	selected, _, err := Selected(repoCache)
+	require.NoError(t, err)
+	require.Nil(t, selected)
+
+	require.NoError(t, Select(repoCache, b2.Id()))
+
+	require.NoError(t, repoCache.SetCurrentProject(""))
+	selected, _, err = Selected(repoCache)
+	require.NoError(t, err)
+	require.Equal(t, b1.Id(), selected.Id())
+
+	require.NoError(t, repoCache.SetCurrentProject("frontend"))
+	selected, _, err = Selected(repoCache)
+	require.NoError(t, err)
+	require.Equal(t, b2.Id(), selected.Id())
+}
+
+func TestUnselect(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	repoCache, err := cache.NewRepoCache(repo)
+	require.NoError(t, err)
+
+	rene, err := repoCache.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+
+	b1, _, err := repoCache.NewBugRaw(rene, time.Now().Unix(), "title", "message", nil, nil)
+	require.NoError(t, err)
+	b2, _, err := repoCache.NewBugRaw(rene, time.Now().Unix(), "title", "message", nil, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, Select(repoCache, b1.Id()))
+	require.NoError(t, repoCache.SetCurrentProject("frontend"))
+	require.NoError(t, SelectFor(repoCache, b1.Id(), time.Hour))
+	require.NoError(t, repoCache.SetCurrentProject("backend"))
+	require.NoError(t, Select(repoCache, b2.Id()))
+
+	// another bug, nothing change
+	require.NoError(t, Unselect(repoCache, "unknown"))
+
+	selected, _, err := Selected(repoCache)
+	require.NoError(t, err)
+	require.Equal(t, b2.Id(), selected.Id())
+
+	// the selections of b1 are cleared in every project
+	require.NoError(t, Unselect(repoCache, b1.Id()))
+
+	selected, _, err = Selected(repoCache)
+	require.NoError(t, err)
+	require.Equal(t, b2.Id(), selected.Id())
+
+	require.NoError(t, repoCache.SetCurrentProject("frontend"))
+	selected, _, err = Selected(repoCache)
+	require.NoError(t, err)
+	require.Nil(t, selected)
+
+	require.NoError(t, repoCache.SetCurrentProject(""))
+	selected, _, err = Selected(repoCache)
+	require.NoError(t, err)
+	require.Nil(t, selected)
+}
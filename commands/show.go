@@ -1,19 +1,31 @@
 package commands
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"sort"
+	"strconv"
 	"strings"
+	"text/template"
 
+	"github.com/MichaelMure/git-bug/bridge/core"
+	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
 	_select "github.com/MichaelMure/git-bug/commands/select"
 	"github.com/MichaelMure/git-bug/util/colors"
 	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/MichaelMure/git-bug/util/text"
 	"github.com/spf13/cobra"
 )
 
 var (
-	showFieldsQuery string
+	showFieldsQuery  string
+	showOutputFormat string
+	showFormatString string
+	showPorcelain    bool
+	showHistory      bool
 )
 
 func runShowBug(cmd *cobra.Command, args []string) error {
@@ -37,6 +49,26 @@ func runShowBug(cmd *cobra.Command, args []string) error {
 
 	firstComment := snapshot.Comments[0]
 
+	if showPorcelain {
+		return showPorcelainFormatter(backend, snapshot)
+	}
+
+	if showHistory {
+		return showHistoryFormatter(backend, snapshot)
+	}
+
+	if showFormatString != "" {
+		return showTemplateFormatter(snapshot)
+	}
+
+	switch showOutputFormat {
+	case "default":
+	case "json":
+		return showJsonFormatter(snapshot)
+	default:
+		return fmt.Errorf("unknown format %s", showOutputFormat)
+	}
+
 	if showFieldsQuery != "" {
 		switch showFieldsQuery {
 		case "author":
@@ -45,6 +77,31 @@ func runShowBug(cmd *cobra.Command, args []string) error {
 			fmt.Printf("%s\n", firstComment.Author.Email())
 		case "createTime":
 			fmt.Printf("%s\n", firstComment.FormatTime())
+		case "editTime":
+			fmt.Printf("%s\n", snapshot.LastEditTime().Format("Mon Jan 2 15:04:05 2006 -0700"))
+		case "description":
+			fmt.Printf("%s\n", firstComment.Message)
+		case "comments":
+			fmt.Printf("%d\n", len(snapshot.Comments))
+		case "votes":
+			fmt.Printf("%d\n", snapshot.VoteCount())
+		case "assignee":
+			// nothing is printed if not assigned
+			if snapshot.Assignee != "" {
+				excerpt, err := backend.ResolveIdentityExcerpt(snapshot.Assignee)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("%s\n", excerpt.DisplayName())
+			}
+		case "milestone":
+			if snapshot.Milestone != "" {
+				fmt.Printf("%s\n", snapshot.Milestone)
+			}
+		case "project":
+			if snapshot.Project != "" {
+				fmt.Printf("%s\n", snapshot.Project)
+			}
 		case "humanId":
 			fmt.Printf("%s\n", snapshot.Id().Human())
 		case "id":
@@ -143,9 +200,359 @@ func runShowBug(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// JSONBugSnapshot is the JSON representation of the complete state of a bug
+type JSONBugSnapshot struct {
+	Id         string   `json:"id"`
+	HumanId    string   `json:"human_id"`
+	CreateTime JSONTime `json:"create_time"`
+	EditTime   JSONTime `json:"edit_time"`
+
+	Status       string          `json:"status"`
+	Labels       []string        `json:"labels"`
+	Title        string          `json:"title"`
+	Author       JSONIdentity    `json:"author"`
+	Actors       []JSONIdentity  `json:"actors"`
+	Participants []JSONIdentity  `json:"participants"`
+	Assignee     string          `json:"assignee,omitempty"`
+	Milestone    string          `json:"milestone,omitempty"`
+	Project      string          `json:"project,omitempty"`
+	Votes        int             `json:"votes"`
+	Comments     []JSONComment   `json:"comments"`
+	Operations   []JSONOperation `json:"operations"`
+}
+
+// JSONComment is the JSON representation of a comment of a bug
+type JSONComment struct {
+	Id      string       `json:"id"`
+	HumanId string       `json:"human_id"`
+	Author  JSONIdentity `json:"author"`
+	Message string       `json:"message"`
+	Files   []string     `json:"files,omitempty"`
+	Time    JSONTime     `json:"time"`
+}
+
+// JSONOperation is the JSON representation of an operation of a bug. Data hold
+// the fields specific to the type of operation.
+type JSONOperation struct {
+	Id       string                     `json:"id"`
+	Type     string                     `json:"type"`
+	Author   JSONIdentity               `json:"author"`
+	Time     JSONTime                   `json:"time"`
+	Metadata map[string]string          `json:"metadata,omitempty"`
+	Data     map[string]json.RawMessage `json:"data"`
+}
+
+var jsonOperationTypes = map[bug.OperationType]string{
+	bug.CreateOp:        "create",
+	bug.SetTitleOp:      "set_title",
+	bug.AddCommentOp:    "add_comment",
+	bug.SetStatusOp:     "set_status",
+	bug.LabelChangeOp:   "label_change",
+	bug.EditCommentOp:   "edit_comment",
+	bug.NoOpOp:          "noop",
+	bug.SetMetadataOp:   "set_metadata",
+	bug.SetAssigneeOp:   "set_assignee",
+	bug.SetMilestoneOp:  "set_milestone",
+	bug.VoteOp:          "vote",
+	bug.DeleteCommentOp: "delete_comment",
+	bug.SetProjectOp:    "set_project",
+}
+
+func newJSONOperation(op bug.Operation) (JSONOperation, error) {
+	raw, err := json.Marshal(op)
+	if err != nil {
+		return JSONOperation{}, err
+	}
+
+	var data map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return JSONOperation{}, err
+	}
+
+	var opType bug.OperationType
+	if err := json.Unmarshal(data["type"], &opType); err != nil {
+		return JSONOperation{}, err
+	}
+
+	// the common fields are exposed separately
+	for _, key := range []string{"type", "author", "timestamp", "metadata"} {
+		delete(data, key)
+	}
+
+	metadata := op.AllMetadata()
+	if len(metadata) == 0 {
+		metadata = nil
+	}
+
+	return JSONOperation{
+		Id:       op.Id().String(),
+		Type:     jsonOperationTypes[opType],
+		Author:   NewJSONIdentity(op.GetAuthor()),
+		Time:     NewJSONTime(op.Time(), 0),
+		Metadata: metadata,
+		Data:     data,
+	}, nil
+}
+
+func showJsonFormatter(snapshot *bug.Snapshot) error {
+	jsonBug := JSONBugSnapshot{
+		Id:           snapshot.Id().String(),
+		HumanId:      snapshot.Id().Human(),
+		CreateTime:   NewJSONTime(snapshot.CreatedAt, 0),
+		EditTime:     NewJSONTime(snapshot.LastEditTime(), 0),
+		Status:       snapshot.Status.String(),
+		Labels:       make([]string, len(snapshot.Labels)),
+		Title:        snapshot.Title,
+		Author:       NewJSONIdentity(snapshot.Author),
+		Actors:       make([]JSONIdentity, len(snapshot.Actors)),
+		Participants: make([]JSONIdentity, len(snapshot.Participants)),
+		Assignee:     snapshot.Assignee.String(),
+		Milestone:    snapshot.Milestone,
+		Project:      snapshot.Project,
+		Votes:        snapshot.VoteCount(),
+		Comments:     make([]JSONComment, len(snapshot.Comments)),
+		Operations:   make([]JSONOperation, len(snapshot.Operations)),
+	}
+
+	for i, l := range snapshot.Labels {
+		jsonBug.Labels[i] = l.String()
+	}
+
+	for i, a := range snapshot.Actors {
+		jsonBug.Actors[i] = NewJSONIdentity(a)
+	}
+
+	for i, p := range snapshot.Participants {
+		jsonBug.Participants[i] = NewJSONIdentity(p)
+	}
+
+	for i, c := range snapshot.Comments {
+		files := make([]string, len(c.Files))
+		for j, f := range c.Files {
+			files[j] = f.String()
+		}
+
+		jsonBug.Comments[i] = JSONComment{
+			Id:      c.Id().String(),
+			HumanId: c.Id().Human(),
+			Author:  NewJSONIdentity(c.Author),
+			Message: c.Message,
+			Files:   files,
+			Time:    NewJSONTime(c.UnixTime.Time(), 0),
+		}
+	}
+
+	for i, op := range snapshot.Operations {
+		jsonOp, err := newJSONOperation(op)
+		if err != nil {
+			return err
+		}
+		jsonBug.Operations[i] = jsonOp
+	}
+
+	jsonObject, err := json.MarshalIndent(jsonBug, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s\n", jsonObject)
+	return nil
+}
+
+// showPorcelainFormatter output the bug as porcelain records, the first field
+// naming the record, see doc/porcelain.md
+func showPorcelainFormatter(backend *cache.RepoCache, snapshot *bug.Snapshot) error {
+	printPorcelain("id", snapshot.Id().String())
+	printPorcelain("status", snapshot.Status.String())
+	printPorcelain("title", snapshot.Title)
+	printPorcelain("author", snapshot.Author.Id().String(), snapshot.Author.DisplayName())
+	printPorcelain("created", strconv.FormatInt(snapshot.CreatedAt.Unix(), 10))
+	printPorcelain("edited", strconv.FormatInt(snapshot.LastEditUnix(), 10))
+
+	if snapshot.Assignee != "" {
+		name := ""
+		if excerpt, err := backend.ResolveIdentityExcerpt(snapshot.Assignee); err == nil {
+			name = excerpt.DisplayName()
+		}
+		printPorcelain("assignee", snapshot.Assignee.String(), name)
+	}
+
+	if snapshot.Milestone != "" {
+		printPorcelain("milestone", snapshot.Milestone)
+	}
+
+	if snapshot.Project != "" {
+		printPorcelain("project", snapshot.Project)
+	}
+
+	for _, l := range snapshot.Labels {
+		printPorcelain("label", l.String())
+	}
+
+	for _, a := range snapshot.Actors {
+		printPorcelain("actor", a.Id().String(), a.DisplayName())
+	}
+
+	for _, p := range snapshot.Participants {
+		printPorcelain("participant", p.Id().String(), p.DisplayName())
+	}
+
+	for _, c := range snapshot.Comments {
+		printPorcelain("comment",
+			c.Id().String(),
+			strconv.FormatInt(int64(c.UnixTime), 10),
+			c.Author.Id().String(),
+			c.Author.DisplayName(),
+			c.Message,
+		)
+	}
+
+	return nil
+}
+
+// showHistoryFormatter output the operations of the bug as an audit log, the
+// most recent first, as git log does
+func showHistoryFormatter(backend *cache.RepoCache, snapshot *bug.Snapshot) error {
+	for i := len(snapshot.Operations) - 1; i >= 0; i-- {
+		op := snapshot.Operations[i]
+
+		jsonOp, err := newJSONOperation(op)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s %s\n", colors.Yellow("operation "+op.Id().Human()), jsonOp.Type)
+		fmt.Printf("Author: %s <%s> (%s)\n",
+			op.GetAuthor().DisplayName(),
+			op.GetAuthor().Email(),
+			op.GetAuthor().Id().Human(),
+		)
+		fmt.Printf("Date:   %s\n", op.Time().Format("Mon Jan 2 15:04:05 2006 -0700"))
+
+		// the operations imported by a bridge record where they come from
+		metadata := op.AllMetadata()
+		if origin, ok := metadata[core.KeyOrigin]; ok {
+			fmt.Printf("Origin: %s\n", origin)
+		}
+		keys := make([]string, 0, len(metadata))
+		for key := range metadata {
+			if key != core.KeyOrigin {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Printf("Meta:   %s=%s\n", key, metadata[key])
+		}
+
+		fmt.Printf("\n    %s\n", operationSummary(backend, op))
+
+		// the messages are displayed in full, as in the commits of git log
+		var message string
+		switch op := op.(type) {
+		case *bug.CreateOperation:
+			message = op.Message
+		case *bug.AddCommentOperation:
+			message = op.Message
+		case *bug.EditCommentOperation:
+			message = op.Message
+		}
+		if message != "" {
+			fmt.Printf("\n%s\n", text.LeftPad(message, 8))
+		}
+
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// operationSummary describe what an operation changed, in a single line
+func operationSummary(backend *cache.RepoCache, op bug.Operation) string {
+	switch op := op.(type) {
+	case *bug.CreateOperation:
+		return fmt.Sprintf("created the bug %q", op.Title)
+	case *bug.SetTitleOperation:
+		return fmt.Sprintf("changed the title from %q to %q", op.Was, op.Title)
+	case *bug.AddCommentOperation:
+		return "commented"
+	case *bug.EditCommentOperation:
+		return fmt.Sprintf("edited the comment %s", op.Target.Human())
+	case *bug.DeleteCommentOperation:
+		return fmt.Sprintf("deleted the comment %s", op.Target.Human())
+	case *bug.SetStatusOperation:
+		return fmt.Sprintf("%s the bug", op.Status.Action())
+	case *bug.LabelChangeOperation:
+		var changes []string
+		for _, l := range op.Added {
+			changes = append(changes, "+"+l.String())
+		}
+		for _, l := range op.Removed {
+			changes = append(changes, "-"+l.String())
+		}
+		return fmt.Sprintf("changed the labels: %s", strings.Join(changes, " "))
+	case *bug.SetAssigneeOperation:
+		if op.Assignee == "" {
+			return "unassigned the bug"
+		}
+		name := op.Assignee.Human()
+		if excerpt, err := backend.ResolveIdentityExcerpt(op.Assignee); err == nil {
+			name = excerpt.DisplayName()
+		}
+		return fmt.Sprintf("assigned the bug to %s", name)
+	case *bug.SetMilestoneOperation:
+		if op.Milestone == "" {
+			return "removed the bug from its milestone"
+		}
+		return fmt.Sprintf("set the milestone to %q", op.Milestone)
+	case *bug.SetProjectOperation:
+		if op.Project == "" {
+			return "removed the bug from its project"
+		}
+		return fmt.Sprintf("moved the bug to the project %q", op.Project)
+	case *bug.VoteOperation:
+		return fmt.Sprintf("voted %+d", op.Vote)
+	case *bug.SetMetadataOperation:
+		return fmt.Sprintf("set the metadata of the operation %s", op.Target.Human())
+	case *bug.NoOpOperation:
+		return "added metadata"
+	default:
+		return "unknown operation"
+	}
+}
+
+func showTemplateFormatter(snapshot *bug.Snapshot) error {
+	tmpl, err := template.New("show").Parse(showFormatString)
+	if err != nil {
+		return err
+	}
+
+	err = tmpl.Execute(os.Stdout, snapshot)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	return nil
+}
+
 var showCmd = &cobra.Command{
-	Use:     "show [<id>]",
-	Short:   "Display the details of a bug.",
+	Use:   "show [<id>]",
+	Short: "Display the details of a bug.",
+	Long: `Display the details of a bug.
+
+With --field, only the raw value of a field is displayed, without any decoration, which is convenient for scripts. The fields with several values, like the labels, are displayed one per line. Nothing is displayed for an unset assignee, milestone or project.`,
+	Example: `Display a bug as JSON:
+git bug show 5f8a3b2 --format json
+
+Display the status of a bug:
+git bug show 5f8a3b2 --field status
+
+Display who changed the bug, and from where:
+git bug show 5f8a3b2 --history
+
+Display a custom summary:
+git bug show 5f8a3b2 --format-string '{{.Id.Human}} [{{.Status}}] {{.Title}} ({{len .Comments}} comments)'
+`,
 	PreRunE: loadRepo,
 	RunE:    runShowBug,
 }
@@ -153,5 +560,13 @@ var showCmd = &cobra.Command{
 func init() {
 	RootCmd.AddCommand(showCmd)
 	showCmd.Flags().StringVarP(&showFieldsQuery, "field", "f", "",
-		"Select field to display. Valid values are [author,authorEmail,createTime,humanId,id,labels,shortId,status,title,actors,participants]")
+		"Select field to display. Valid values are [author,authorEmail,createTime,editTime,humanId,id,labels,shortId,status,title,description,comments,votes,assignee,milestone,project,actors,participants]")
+	showCmd.Flags().StringVar(&showOutputFormat, "format", "default",
+		"Select the output formatting style. Valid values are [default,json]")
+	showCmd.Flags().StringVar(&showFormatString, "format-string", "",
+		"Format the bug with a Go template, executed with the snapshot of the bug")
+	showCmd.Flags().BoolVar(&showPorcelain, "porcelain", false,
+		"Output a stable format for scripts, see doc/porcelain.md")
+	showCmd.Flags().BoolVar(&showHistory, "history", false,
+		"Display the operations of the bug as an audit log: their type, author, time and origin")
 }
@@ -0,0 +1,233 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/MichaelMure/git-bug/util/text"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsSince        string
+	statsUntil        string
+	statsTop          int
+	statsOutputFormat string
+)
+
+func runStats(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	query, err := backend.ParseQuery(strings.Join(args, " "))
+	if err != nil {
+		return err
+	}
+
+	// the end is computed first, so that a period given as a duration is not
+	// a few nanoseconds longer and split into an additional week
+	until := time.Now()
+	if statsUntil != "" {
+		until, err = cache.ParseTime(statsUntil)
+		if err != nil {
+			return err
+		}
+	}
+	until = until.Truncate(time.Second)
+
+	since, err := cache.ParseTime(statsSince)
+	if err != nil {
+		return err
+	}
+	since = since.Truncate(time.Second)
+
+	if !since.Before(until) {
+		return fmt.Errorf("the start of the period must be before its end")
+	}
+
+	stats, err := backend.Stats(backend.QueryBugs(query), since, until)
+	if err != nil {
+		return err
+	}
+
+	if statsTop >= 0 {
+		if len(stats.Labels) > statsTop {
+			stats.Labels = stats.Labels[:statsTop]
+		}
+		if len(stats.Authors) > statsTop {
+			stats.Authors = stats.Authors[:statsTop]
+		}
+	}
+
+	switch statsOutputFormat {
+	case "default":
+		return statsDefaultFormatter(stats)
+	case "json":
+		return statsJsonFormatter(stats)
+	default:
+		return fmt.Errorf("unknown format %s", statsOutputFormat)
+	}
+}
+
+func statsDefaultFormatter(stats *cache.BugStats) error {
+	fmt.Printf("Bugs: %s, %s\n",
+		colors.Green(fmt.Sprintf("%d open", stats.Open)),
+		colors.Red(fmt.Sprintf("%d closed", stats.Closed)),
+	)
+
+	fmt.Printf("\nFrom %s to %s:\n",
+		stats.Since.Format("2006-01-02"),
+		stats.Until.Format("2006-01-02"),
+	)
+
+	if stats.MeanTimeToClose > 0 {
+		fmt.Printf("Mean time to close: %s\n", formatDays(stats.MeanTimeToClose))
+	}
+
+	fmt.Printf("\n%s\t%s\t%s\n", "Week      ", "Opened", "Closed")
+	for _, w := range stats.Weeks {
+		fmt.Printf("%s\t%6d\t%6d\n", w.Start.Format("2006-01-02"), w.Opened, w.Closed)
+	}
+
+	if len(stats.Labels) > 0 {
+		fmt.Println("\nTop labels:")
+		for _, count := range stats.Labels {
+			fmt.Printf("%s\t%s\t%s\n",
+				text.LeftPadMaxLine(string(count.Label), 30, 0),
+				colors.Green(fmt.Sprintf("%d open", count.Open)),
+				colors.Red(fmt.Sprintf("%d closed", count.Closed)),
+			)
+		}
+	}
+
+	if len(stats.Authors) > 0 {
+		fmt.Println("\nMost active authors:")
+		for _, author := range stats.Authors {
+			fmt.Printf("%s\t%d operation(s)\n",
+				text.LeftPadMaxLine(author.Name, 30, 0),
+				author.Operations,
+			)
+		}
+	}
+
+	return nil
+}
+
+// formatDays format a duration as a number of days, more readable than hours
+// for the lifetime of a bug
+func formatDays(d time.Duration) string {
+	return fmt.Sprintf("%.1f days", d.Hours()/24)
+}
+
+// JSONBugStats is the JSON representation of the statistics
+type JSONBugStats struct {
+	Since time.Time `json:"since"`
+	Until time.Time `json:"until"`
+
+	Open   int `json:"open"`
+	Closed int `json:"closed"`
+
+	Weeks []JSONWeekStats `json:"weeks"`
+
+	// in seconds
+	MeanTimeToClose int64 `json:"mean_time_to_close"`
+
+	Labels  []JSONLabelCount  `json:"labels"`
+	Authors []JSONAuthorStats `json:"authors"`
+}
+
+type JSONWeekStats struct {
+	Start  time.Time `json:"start"`
+	Opened int       `json:"opened"`
+	Closed int       `json:"closed"`
+}
+
+type JSONLabelCount struct {
+	Label  string `json:"label"`
+	Open   int    `json:"open"`
+	Closed int    `json:"closed"`
+}
+
+type JSONAuthorStats struct {
+	Id         string `json:"id"`
+	HumanId    string `json:"human_id"`
+	Name       string `json:"name"`
+	Operations int    `json:"operations"`
+}
+
+func statsJsonFormatter(stats *cache.BugStats) error {
+	result := JSONBugStats{
+		Since:           stats.Since,
+		Until:           stats.Until,
+		Open:            stats.Open,
+		Closed:          stats.Closed,
+		Weeks:           make([]JSONWeekStats, len(stats.Weeks)),
+		MeanTimeToClose: int64(stats.MeanTimeToClose / time.Second),
+		Labels:          make([]JSONLabelCount, len(stats.Labels)),
+		Authors:         make([]JSONAuthorStats, len(stats.Authors)),
+	}
+
+	for i, w := range stats.Weeks {
+		result.Weeks[i] = JSONWeekStats{Start: w.Start, Opened: w.Opened, Closed: w.Closed}
+	}
+
+	for i, count := range stats.Labels {
+		result.Labels[i] = JSONLabelCount{Label: count.Label.String(), Open: count.Open, Closed: count.Closed}
+	}
+
+	for i, author := range stats.Authors {
+		result.Authors[i] = JSONAuthorStats{
+			Id:         author.Id.String(),
+			HumanId:    author.Id.Human(),
+			Name:       author.Name,
+			Operations: author.Operations,
+		}
+	}
+
+	jsonObject, err := json.MarshalIndent(result, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s\n", jsonObject)
+	return nil
+}
+
+var statsCmd = &cobra.Command{
+	Use:   "stats [<query>]",
+	Short: "Display statistics about the bugs.",
+	Long: `Display statistics about the bugs: the number of open and closed bugs, the bugs opened and closed each week of a period, the mean time to close them, the most used labels and the most active authors.
+
+A query can restrict the bugs considered. The bounds of the period are given either as a date (2006-01-02) or as a duration before now (90d, 12w, 1y).`,
+	Example: `Statistics over the last 3 months:
+git bug stats --since 90d
+
+Statistics of the bugs labeled "bug" for a dashboard:
+git bug stats label:bug --format json
+`,
+	PreRunE: loadRepo,
+	RunE:    runStats,
+}
+
+func init() {
+	RootCmd.AddCommand(statsCmd)
+
+	statsCmd.Flags().SortFlags = false
+
+	statsCmd.Flags().StringVar(&statsSince, "since", "12w",
+		"Start of the period, as a date or a duration before now")
+	statsCmd.Flags().StringVar(&statsUntil, "until", "",
+		"End of the period, as a date or a duration before now (default to now)")
+	statsCmd.Flags().IntVar(&statsTop, "top", 10,
+		"Number of labels and authors to display, -1 for all")
+	statsCmd.Flags().StringVarP(&statsOutputFormat, "format", "f", "default",
+		"Select the output formatting style. Valid values are [default,json]")
+}
@@ -1,8 +1,8 @@
 package commands
 
 import (
+	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
-	"github.com/MichaelMure/git-bug/commands/select"
 	"github.com/MichaelMure/git-bug/util/interrupt"
 	"github.com/spf13/cobra"
 )
@@ -15,26 +15,34 @@ func runStatusClose(cmd *cobra.Command, args []string) error {
 	defer backend.Close()
 	interrupt.RegisterCleaner(backend.Close)
 
-	b, args, err := _select.ResolveBug(backend, args)
-	if err != nil {
-		return err
-	}
+	return applyToBugs(backend, args, "Close", func(b *cache.BugCache, args []string) error {
+		// when applied to many bugs, those already in this status are left untouched
+		if bulkQuery != "" && b.Snapshot().Status == bug.ClosedStatus {
+			return nil
+		}
 
-	_, err = b.Close()
-	if err != nil {
-		return err
-	}
+		_, err := b.Close()
+		if err != nil {
+			return err
+		}
 
-	return b.Commit()
+		return b.Commit()
+	})
 }
 
 var closeCmd = &cobra.Command{
-	Use:     "close [<id>]",
-	Short:   "Mark a bug as closed.",
+	Use:   "close [<id>]",
+	Short: "Mark a bug as closed.",
+	Example: `Close all the open bugs waiting for information for more than a year:
+git bug status close --query "status:open label:needs-info edited-before:1y"
+`,
 	PreRunE: loadRepo,
 	RunE:    runStatusClose,
 }
 
 func init() {
 	statusCmd.AddCommand(closeCmd)
+
+	closeCmd.Flags().SortFlags = false
+	addBulkFlags(closeCmd)
 }
@@ -1,8 +1,8 @@
 package commands
 
 import (
+	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
-	"github.com/MichaelMure/git-bug/commands/select"
 	"github.com/MichaelMure/git-bug/util/interrupt"
 	"github.com/spf13/cobra"
 )
@@ -15,26 +15,34 @@ func runStatusOpen(cmd *cobra.Command, args []string) error {
 	defer backend.Close()
 	interrupt.RegisterCleaner(backend.Close)
 
-	b, args, err := _select.ResolveBug(backend, args)
-	if err != nil {
-		return err
-	}
+	return applyToBugs(backend, args, "Open", func(b *cache.BugCache, args []string) error {
+		// when applied to many bugs, those already in this status are left untouched
+		if bulkQuery != "" && b.Snapshot().Status == bug.OpenStatus {
+			return nil
+		}
 
-	_, err = b.Open()
-	if err != nil {
-		return err
-	}
+		_, err := b.Open()
+		if err != nil {
+			return err
+		}
 
-	return b.Commit()
+		return b.Commit()
+	})
 }
 
 var openCmd = &cobra.Command{
-	Use:     "open [<id>]",
-	Short:   "Mark a bug as open.",
+	Use:   "open [<id>]",
+	Short: "Mark a bug as open.",
+	Example: `Reopen all the bugs of a milestone, without confirmation:
+git bug status open --query "milestone:v1.2" --yes
+`,
 	PreRunE: loadRepo,
 	RunE:    runStatusOpen,
 }
 
 func init() {
 	statusCmd.AddCommand(openCmd)
+
+	openCmd.Flags().SortFlags = false
+	addBulkFlags(openCmd)
 }
@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+)
+
+var (
+	syncNoPush bool
+)
+
+func runSync(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	remote := "origin"
+	if len(args) > 0 {
+		remote = args[0]
+	}
+
+	fmt.Println("Fetching remote ...")
+
+	stdout, err := backend.Fetch(remote)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(stdout)
+
+	fmt.Println("Merging data ...")
+
+	// the cache is updated after each result is received, so the bugs are
+	// only read once the merge is complete
+	var bugResults []entity.MergeResult
+	var failed int
+
+	for result := range backend.MergeAll(remote) {
+		switch result.Status {
+		case entity.MergeStatusNothing:
+			continue
+		case entity.MergeStatusError, entity.MergeStatusInvalid:
+			failed++
+			fmt.Printf("%s: %s\n", result.Id.Human(), result)
+			continue
+		}
+
+		if _, ok := result.Entity.(*bug.Bug); ok {
+			bugResults = append(bugResults, result)
+		}
+	}
+
+	ahead, err := backend.BugsAhead(remote)
+	if err != nil {
+		return err
+	}
+
+	aheadSet := make(map[entity.Id]bool, len(ahead))
+	for _, id := range ahead {
+		aheadSet[id] = true
+	}
+
+	var created, updated, conflicting int
+
+	for _, result := range bugResults {
+		excerpt, err := backend.ResolveBugExcerpt(result.Id)
+		if err != nil {
+			return err
+		}
+
+		var status string
+		switch {
+		case result.Status == entity.MergeStatusNew:
+			created++
+			status = colors.Green("new")
+		case aheadSet[result.Id]:
+			// both sides changed: the local changes were rebased on top of
+			// the remote ones
+			conflicting++
+			status = colors.Yellow("conflicting")
+		default:
+			updated++
+			status = colors.Cyan("updated")
+		}
+
+		fmt.Printf("%s %s\t%s\n",
+			colors.Cyan(result.Id.Human()),
+			status,
+			excerpt.Title,
+		)
+	}
+
+	fmt.Printf("\n%d new, %d updated, %d conflicting\n", created, updated, conflicting)
+
+	if !syncNoPush && len(ahead) > 0 {
+		fmt.Printf("\nPushing %d bug(s) ...\n", len(ahead))
+
+		stdout, err = backend.Push(remote)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(stdout)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d bug(s) or identities could not be merged", failed)
+	}
+
+	return nil
+}
+
+var syncCmd = &cobra.Command{
+	Use:   "sync [<remote>]",
+	Short: "Pull then push bugs update with a git remote.",
+	Long: `Fetch the bugs update from a git remote, "origin" by default, merge them, then push the local changes back.
+
+Each bug changed by the merge is reported as:
+- new: the bug was created on the remote
+- updated: the bug was only changed on the remote
+- conflicting: the bug was changed on both sides, the local changes have been merged on top of the remote ones
+
+Bugs can't be left in a conflicted state: concurrent changes are always merged, but the result might deserve a look.`,
+	Example: `git bug sync
+git bug sync --no-push upstream
+`,
+	PreRunE: loadRepo,
+	RunE:    runSync,
+	Args:    cobra.MaximumNArgs(1),
+}
+
+func init() {
+	RootCmd.AddCommand(syncCmd)
+
+	syncCmd.Flags().SortFlags = false
+
+	syncCmd.Flags().BoolVarP(&syncNoPush, "no-push", "n", false,
+		"Only fetch and merge, don't push the local changes",
+	)
+}
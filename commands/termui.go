@@ -22,6 +22,19 @@ var termUICmd = &cobra.Command{
 	Use:     "termui",
 	Aliases: []string{"tui"},
 	Short:   "Launch the terminal UI.",
+	Long: `Launch the terminal UI.
+
+New bugs and comments are written in the terminal UI, with a preview of their markdown. The editor defined in the git config or in $EDITOR can still be opened from there.
+
+The bugs changed outside of the terminal UI, for example by a git fetch of the bug refs, are refreshed automatically.
+
+The terminal UI can be configured with the following git config keys, set in the repository or, for all the repositories, in the user config (--global):
+- git-bug.termui.color.<element>: the color of the instruction bars (instructions), of the selected bug (selection-fg and selection-bg) and of the errors (error). A color is either a name (black, red, green, yellow, blue, magenta, cyan, white or default) or a number of the 256 colors palette.
+- git-bug.termui.mouse: if true, a bug or a comment can be selected with a click, the lists scrolled with the wheel and the actions of the instruction bars clicked.
+- git-bug.termui.low-color: if true, only the 8 basic colors are used, for the terminals without 256 colors support. The numbered colors are then ignored.
+- git-bug.termui.key.<action>: the key of an action. The actions of the bug list are quit, filter, search, new, mark, unmark-all, bulk-action, pull, push, sort and reverse-sort. The actions of the bug view are back, open-close, labels, edit, history, raw, comment and title.`,
+	Example: `git config --global git-bug.termui.color.instructions 24
+git config git-bug.termui.key.new N`,
 	PreRunE: loadRepoEnsureUser,
 	RunE:    runTermUI,
 }
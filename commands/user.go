@@ -72,11 +72,14 @@ func runUser(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Last modification: %s (lamport %d)\n",
 		id.LastModification().Time().Format("Mon Jan 2 15:04:05 2006 +0200"),
 		id.LastModificationLamport())
+	if mergedInto := id.MergedInto(); mergedInto != "" {
+		fmt.Printf("Merged into: %s\n", mergedInto)
+	}
 	fmt.Println("Metadata:")
 	for key, value := range id.ImmutableMetadata() {
 		fmt.Printf("    %s --> %s\n", key, value)
 	}
-	// fmt.Printf("Protected: %v\n", id.IsProtected())
+	fmt.Printf("Protected: %v\n", id.IsProtected())
 
 	return nil
 }
@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/bridge"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runUserAttach(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	id, err := backend.GetUserIdentity()
+	if err != nil {
+		return err
+	}
+
+	metadata := make(map[string]string)
+	for _, target := range bridge.AttachableTargets() {
+		login, err := cmd.Flags().GetString(target)
+		if err != nil {
+			return err
+		}
+		if login == "" {
+			continue
+		}
+
+		key, err := bridge.LoginMetadataKey(target)
+		if err != nil {
+			return err
+		}
+		metadata[key] = login
+	}
+
+	if len(metadata) > 0 {
+		err = backend.AttachIdentity(id, metadata)
+		if err != nil {
+			return err
+		}
+	}
+
+	// display the attached accounts
+	immutable := id.ImmutableMetadata()
+	for _, target := range bridge.AttachableTargets() {
+		key, err := bridge.LoginMetadataKey(target)
+		if err != nil {
+			return err
+		}
+		if login, ok := immutable[key]; ok {
+			fmt.Printf("%s: %s\n", target, login)
+		}
+	}
+
+	return nil
+}
+
+var userAttachCmd = &cobra.Command{
+	Use:   "attach",
+	Short: "Attach your accounts of other trackers to your identity.",
+	Long: `Attach your accounts of other trackers to your identity, or display the attached accounts.
+
+When importing with a bridge, the activity of an attached account is authored by your identity instead of a new one. As the attachments are recorded in your identity, they are shared with the other repositories when pushing, and can't be changed later.`,
+	Example: `git bug user attach --github alice --gitlab alice2`,
+	PreRunE: loadRepo,
+	RunE:    runUserAttach,
+	Args:    cobra.NoArgs,
+}
+
+func init() {
+	userCmd.AddCommand(userAttachCmd)
+	userAttachCmd.Flags().SortFlags = false
+
+	for _, target := range bridge.AttachableTargets() {
+		userAttachCmd.Flags().String(target, "",
+			fmt.Sprintf("Attach your %s account with this login", target))
+	}
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runUserKey(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	var id *cache.IdentityCache
+	if len(args) == 1 {
+		id, err = backend.ResolveIdentityPrefix(args[0])
+	} else {
+		id, err = backend.GetUserIdentity()
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, key := range id.Keys() {
+		local, err := identity.HasPrivateKey(repo, key)
+		if err != nil {
+			return err
+		}
+
+		marker := ""
+		if local {
+			marker = " (available to sign)"
+		}
+
+		fmt.Printf("%s%s\n", key.Fingerprint, marker)
+	}
+
+	return nil
+}
+
+var userKeyCmd = &cobra.Command{
+	Use:   "key [<user-id>]",
+	Short: "Display, add or remove the keys protecting an identity.",
+	Long: `Display, add or remove the keys protecting an identity, the user identity by default.
+
+Once an identity declared a key, what it authors must be signed with one of its keys. The operations and the changes of the identity that are not signed are rejected when pulling, which prevent someone else to impersonate the identity in a shared repository.
+
+The private keys are kept in the git config of the repository, and never pushed. The keys available to sign are marked as such.`,
+	PreRunE: loadRepo,
+	RunE:    runUserKey,
+	Args:    cobra.MaximumNArgs(1),
+}
+
+func init() {
+	userCmd.AddCommand(userKeyCmd)
+	userKeyCmd.Flags().SortFlags = false
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runUserKeyAdd(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	id, err := backend.GetUserIdentity()
+	if err != nil {
+		return err
+	}
+
+	key, err := identity.GenerateKey(repo)
+	if err != nil {
+		return err
+	}
+
+	err = id.AddKey(key)
+	if err == nil {
+		err = id.Commit()
+	}
+	if err != nil {
+		// the key is of no use if it's not declared
+		_ = identity.RemovePrivateKey(repo, key)
+		return err
+	}
+
+	fmt.Println(key.Fingerprint)
+	_, _ = fmt.Fprintf(os.Stderr, "%s is now protected, push the identity to share the key\n", id.DisplayName())
+
+	return nil
+}
+
+var userKeyAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Generate a key in this repository and add it to your identity.",
+	Long: `Generate a key in this repository and add it to your identity.
+
+From then on, your operations are signed with this key. To work from several repositories or machines, add a key in each of them: once your identity is protected, only the repositories holding one of its keys can author with it.`,
+	PreRunE: loadRepo,
+	RunE:    runUserKeyAdd,
+	Args:    cobra.NoArgs,
+}
+
+func init() {
+	userKeyCmd.AddCommand(userKeyAddCmd)
+	userKeyAddCmd.Flags().SortFlags = false
+}
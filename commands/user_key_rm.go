@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/identity"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runUserKeyRm(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	id, err := backend.GetUserIdentity()
+	if err != nil {
+		return err
+	}
+
+	key, err := id.RemoveKey(args[0])
+	if err != nil {
+		return err
+	}
+
+	// the change is signed with one of the previous keys, possibly the
+	// removed one
+	err = id.Commit()
+	if err != nil {
+		return err
+	}
+
+	err = identity.RemovePrivateKey(repo, key)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("key %s removed\n", key.Fingerprint)
+
+	return nil
+}
+
+var userKeyRmCmd = &cobra.Command{
+	Use:   "rm <fingerprint>",
+	Short: "Revoke a key of your identity.",
+	Long: `Revoke a key of your identity, for example if it has been compromised or if the repository holding it is gone.
+
+The operations signed with the key before its revocation stay valid. Once all the keys are removed, your identity is not protected anymore.`,
+	PreRunE: loadRepo,
+	RunE:    runUserKeyRm,
+	Args:    cobra.ExactArgs(1),
+}
+
+func init() {
+	userKeyCmd.AddCommand(userKeyRmCmd)
+	userKeyRmCmd.Flags().SortFlags = false
+}
@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runUserLog(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	var id *cache.IdentityCache
+	if len(args) == 1 {
+		id, err = backend.ResolveIdentityPrefix(args[0])
+	} else {
+		id, err = backend.GetUserIdentity()
+	}
+	if err != nil {
+		return err
+	}
+
+	versions := id.Versions()
+
+	for i := len(versions) - 1; i >= 0; i-- {
+		v := versions[i]
+
+		fmt.Printf("%s\n", colors.Yellow("version "+v.CommitHash().String()))
+		fmt.Printf("Date:   %s (lamport %d)\n",
+			v.UnixTime().Time().Format("Mon Jan 2 15:04:05 2006 -0700"),
+			v.Time(),
+		)
+
+		fmt.Println()
+		printVersionField("Name", v.Name())
+		printVersionField("Email", v.Email())
+		printVersionField("Login", v.Login())
+		printVersionField("Avatar", v.AvatarUrl())
+
+		fingerprints := make([]string, len(v.Keys()))
+		for j, key := range v.Keys() {
+			fingerprints[j] = key.Fingerprint
+		}
+		printVersionField("Keys", strings.Join(fingerprints, ", "))
+
+		// the metadata, like the ones of the bridges, are what the identities
+		// are matched with
+		metadata := v.AllMetadata()
+		keys := make([]string, 0, len(metadata))
+		for key := range metadata {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Printf("    Meta:   %s=%s\n", key, metadata[key])
+		}
+
+		fmt.Println()
+	}
+
+	return nil
+}
+
+func printVersionField(name string, value string) {
+	if value == "" {
+		return
+	}
+	fmt.Printf("    %-7s %s\n", name+":", value)
+}
+
+var userLogCmd = &cobra.Command{
+	Use:   "log [<user-id>]",
+	Short: "Display the successive versions of an identity.",
+	Long: `Display the successive versions of an identity, the user identity by default, the most recent first.
+
+Each version records the name, email, login, avatar and keys of the identity at that time, as well as the metadata attached, for example by a bridge import to match the identity with the user of the other tracker. This helps to understand why an imported activity is attributed to one identity or another.`,
+	Example: `git bug user log
+git bug user log 5f8a3b2`,
+	PreRunE: loadRepo,
+	RunE:    runUserLog,
+	Args:    cobra.MaximumNArgs(1),
+}
+
+func init() {
+	userCmd.AddCommand(userLogCmd)
+	userLogCmd.Flags().SortFlags = false
+}
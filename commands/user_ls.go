@@ -9,6 +9,10 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	userLsPorcelain bool
+)
+
 func runUserLs(cmd *cobra.Command, args []string) error {
 	backend, err := cache.NewRepoCache(repo)
 	if err != nil {
@@ -23,6 +27,20 @@ func runUserLs(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
+		if userLsPorcelain {
+			printPorcelain(i.Id.String(), i.Name, i.Login, i.Email, i.MergedInto.String())
+			continue
+		}
+
+		if i.MergedInto != "" {
+			fmt.Printf("%s %s (merged into %s)\n",
+				colors.Cyan(i.Id.Human()),
+				i.DisplayName(),
+				colors.Cyan(i.MergedInto.Human()),
+			)
+			continue
+		}
+
 		fmt.Printf("%s %s\n",
 			colors.Cyan(i.Id.Human()),
 			i.DisplayName(),
@@ -42,4 +60,7 @@ var userLsCmd = &cobra.Command{
 func init() {
 	userCmd.AddCommand(userLsCmd)
 	userLsCmd.Flags().SortFlags = false
+
+	userLsCmd.Flags().BoolVar(&userLsPorcelain, "porcelain", false,
+		"Output a stable format for scripts, see doc/porcelain.md")
 }
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runUserMerge(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	from, err := backend.ResolveIdentityPrefix(args[0])
+	if err != nil {
+		return err
+	}
+
+	var into *cache.IdentityCache
+	if len(args) > 1 {
+		into, err = backend.ResolveIdentityPrefix(args[1])
+	} else {
+		into, err = backend.GetUserIdentity()
+	}
+	if err != nil {
+		return err
+	}
+
+	err = backend.MergeIdentity(from, into)
+	if err != nil {
+		return err
+	}
+
+	err = backend.SetUserIdentity(into)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s merged into %s\n", from.DisplayName(), into.DisplayName())
+	_, _ = fmt.Fprintf(os.Stderr, "Your identity is now: %s\n", into.DisplayName())
+
+	return nil
+}
+
+var userMergeCmd = &cobra.Command{
+	Use:   "merge <user-id> [<into-user-id>]",
+	Short: "Merge an identity into another one, your own by default.",
+	Long: `Merge an identity into another one, your own by default, and adopt the latter as your identity.
+
+This is useful when an identity has been created for you by a bridge import: once merged, the activity of both identities is yours when querying with "me", and the next imports use your identity.`,
+	Example: `Merge the identity imported from GitHub into your own:
+git bug user merge 5f8a3b2
+`,
+	PreRunE: loadRepo,
+	RunE:    runUserMerge,
+	Args:    cobra.RangeArgs(1, 2),
+}
+
+func init() {
+	userCmd.AddCommand(userMergeCmd)
+	userMergeCmd.Flags().SortFlags = false
+}
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	userSearchCompletion bool
+)
+
+func runUserSearch(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	term := strings.Join(args, " ")
+	identities := backend.SearchIdentity(term)
+
+	if userSearchCompletion {
+		return printIdentityCompletion(backend, term, identities)
+	}
+
+	for _, i := range identities {
+		fmt.Printf("%s %s\n",
+			colors.Cyan(i.Id.Human()),
+			i.DisplayName(),
+		)
+	}
+
+	return nil
+}
+
+// printIdentityCompletion output one candidate per line for the shell
+// completion of an identity query: the login if any, the human id otherwise.
+func printIdentityCompletion(backend *cache.RepoCache, term string, identities []*cache.IdentityExcerpt) error {
+	if strings.HasPrefix("me", strings.ToLower(term)) {
+		if _, err := backend.GetUserIdentity(); err == nil {
+			fmt.Println("me")
+		}
+	}
+
+	for _, i := range identities {
+		if i.Login != "" {
+			fmt.Println(i.Login)
+		} else {
+			fmt.Println(i.Id.Human())
+		}
+	}
+
+	return nil
+}
+
+var userSearchCmd = &cobra.Command{
+	Use:   "search [<term>]",
+	Short: "Search identities by name, login, email or id.",
+	Long: `Search identities by name, login, email or id.
+
+The term is matched exactly, as a prefix, as a substring or fuzzily, and the most relevant identities are listed first.`,
+	Example: `git bug user search descartes`,
+	PreRunE: loadRepo,
+	RunE:    runUserSearch,
+}
+
+func init() {
+	userCmd.AddCommand(userSearchCmd)
+	userSearchCmd.Flags().SortFlags = false
+
+	userSearchCmd.Flags().BoolVar(&userSearchCompletion, "completion", false,
+		"Output candidates for the shell completion")
+	_ = userSearchCmd.Flags().MarkHidden("completion")
+}
@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/spf13/cobra"
+)
+
+func runUserSwitch(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	own, err := backend.OwnIdentities()
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		return userSwitchList(backend, own)
+	}
+
+	i, err := backend.ResolveIdentityPrefix(args[0])
+	if err != nil {
+		return err
+	}
+
+	isOwn := false
+	for _, excerpt := range own {
+		isOwn = isOwn || excerpt.Id == i.Id()
+	}
+	if !isOwn {
+		return fmt.Errorf("%s is not one of your identities, use \"git bug user adopt\" to make it yours", i.Id().Human())
+	}
+
+	if into := i.MergedInto(); into != "" {
+		return fmt.Errorf("identity %s has been merged into %s", i.Id().Human(), into.Human())
+	}
+
+	err = backend.SetUserIdentity(i)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(os.Stderr, "Your identity in this repository is now: %s\n", i.DisplayName())
+
+	return nil
+}
+
+// userSwitchList list the identities of the user, the current one marked
+// with a *
+func userSwitchList(backend *cache.RepoCache, own []*cache.IdentityExcerpt) error {
+	var current entity.Id
+	if user, err := backend.GetUserIdentity(); err == nil {
+		current = user.Id()
+	}
+
+	for _, excerpt := range own {
+		marker := " "
+		if excerpt.Id == current {
+			marker = "*"
+		}
+
+		merged := ""
+		if excerpt.MergedInto != "" {
+			merged = fmt.Sprintf(" (merged into %s)", colors.Cyan(excerpt.MergedInto.Human()))
+		}
+
+		fmt.Printf("%s %s %s <%s>%s\n",
+			marker,
+			colors.Cyan(excerpt.Id.Human()),
+			excerpt.DisplayName(),
+			excerpt.Email,
+			merged,
+		)
+	}
+
+	return nil
+}
+
+var userSwitchCmd = &cobra.Command{
+	Use:   "switch [<user-id>]",
+	Short: "List your identities, or choose the one to use in this repository.",
+	Long: `List your identities, or choose the one to use in this repository.
+
+Your identities are the ones you created or adopted. You can have several, for example one with your work email and one with your personal email, and choose a different one in each repository. Without argument, your identities are listed, the current one marked with a *.`,
+	Example: `git bug user switch
+git bug user switch 5f8a3b2`,
+	PreRunE: loadRepo,
+	RunE:    runUserSwitch,
+	Args:    cobra.MaximumNArgs(1),
+}
+
+func init() {
+	userCmd.AddCommand(userSwitchCmd)
+	userSwitchCmd.Flags().SortFlags = false
+}
@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/util/colors"
+)
+
+var (
+	watchInterval time.Duration
+	watchExec     string
+)
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	if watchInterval <= 0 {
+		return fmt.Errorf("the interval must be positive")
+	}
+
+	// the cache is not used, so that the repository is not locked and other
+	// commands can still change the bugs
+	watcher, err := cache.NewBugWatcher(repo)
+	if err != nil {
+		return err
+	}
+
+	// the last known title of the bugs, to describe a removed bug
+	titles := make(map[entity.Id]string)
+
+	for range time.Tick(watchInterval) {
+		events, err := watcher.Poll()
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			title := titles[event.Id]
+			if event.Type != cache.BugRemoved {
+				b, err := bug.ReadLocalBug(repo, event.Id)
+				if err != nil {
+					return err
+				}
+				title = b.Compile().Title
+				titles[event.Id] = title
+			} else {
+				delete(titles, event.Id)
+			}
+
+			fmt.Printf("%s %s %s\t%s\n",
+				time.Now().Format("15:04:05"),
+				colors.Cyan(event.Id.Human()),
+				colors.Yellow(event.Type),
+				title,
+			)
+
+			if watchExec != "" {
+				watchRunHook(event, title)
+			}
+		}
+	}
+
+	return nil
+}
+
+// watchRunHook execute the user command for an event. A failure is only
+// reported, to keep watching.
+func watchRunHook(event cache.BugChangeEvent, title string) {
+	c := exec.Command("sh", "-c", watchExec)
+	c.Env = append(os.Environ(),
+		"GIT_BUG_EVENT="+event.Type.String(),
+		"GIT_BUG_ID="+event.Id.String(),
+		"GIT_BUG_TITLE="+title,
+	)
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+
+	if err := c.Run(); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "hook failed for %s: %v\n", event.Id.Human(), err)
+	}
+}
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Print the changes of the bugs as they happen.",
+	Long: `Stay running and print each bug created, updated or removed in the repository, be it by another git-bug command or by a git operation like a fetch.
+
+With --exec, a shell command is executed for each change, with the following environment variables:
+- GIT_BUG_EVENT: created, updated or removed
+- GIT_BUG_ID: the complete id of the bug
+- GIT_BUG_TITLE: the title of the bug, possibly empty for a removed bug`,
+	Example: `Print the changes:
+git bug watch
+
+Send a desktop notification for each change:
+git bug watch --exec 'notify-send "bug $GIT_BUG_EVENT" "$GIT_BUG_TITLE"'
+`,
+	PreRunE: loadRepo,
+	RunE:    runWatch,
+	Args:    cobra.NoArgs,
+}
+
+func init() {
+	RootCmd.AddCommand(watchCmd)
+
+	watchCmd.Flags().SortFlags = false
+
+	watchCmd.Flags().DurationVarP(&watchInterval, "interval", "i", 2*time.Second,
+		"Interval between two checks of the repository")
+	watchCmd.Flags().StringVarP(&watchExec, "exec", "e", "",
+		"Shell command to execute for each change")
+}
@@ -3,13 +3,19 @@ package commands
 import (
 	"bytes"
 	"context"
+	"crypto/subtle"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/99designs/gqlgen/handler"
@@ -18,22 +24,55 @@ import (
 	"github.com/skratchdot/open-golang/open"
 	"github.com/spf13/cobra"
 
+	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/graphql"
+	"github.com/MichaelMure/git-bug/graphql/apitoken"
 	"github.com/MichaelMure/git-bug/repository"
 	"github.com/MichaelMure/git-bug/util/git"
 	"github.com/MichaelMure/git-bug/webui"
+	"github.com/MichaelMure/git-bug/webui/site"
 )
 
 var (
-	webUIPort   int
-	webUIOpen   bool
-	webUINoOpen bool
+	webUIPort          int
+	webUIHost          string
+	webUIOpen          bool
+	webUINoOpen        bool
+	webUIReadOnly      bool
+	webUIIdentity      string
+	webUIBasicAuth     string
+	webUIToken         string
+	webUIUnixSocket    string
+	webUITLSCert       string
+	webUITLSKey        string
+	webUITLSSelfSigned bool
+	webUIAPIOnly       bool
+	webUIAllowedOrigin []string
 )
 
 const webUIOpenConfigKey = "git-bug.webui.open"
+const webUIBasicAuthConfigKey = "git-bug.webui.basic-auth"
+const webUITokenConfigKey = "git-bug.webui.token"
+
+// the cookie keeping the token given in the url of the web UI
+const webUITokenCookie = "git-bug-token"
+
+// 100MB, same as github
+const webUIMaxUploadSize = 100 * 1000 * 1000
+
+const webUIErrTooBig = "file too big (100MB max)"
+
+// the files displayed by the browser, any other file being downloaded
+var webUIInlineTypes = map[string]bool{
+	"image/gif":  true,
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/webp": true,
+	"image/bmp":  true,
+}
 
 func runWebUI(cmd *cobra.Command, args []string) error {
-	if webUIPort == 0 {
+	if webUIPort == 0 && webUIUnixSocket == "" {
 		var err error
 		webUIPort, err = freeport.GetFreePort()
 		if err != nil {
@@ -41,31 +80,86 @@ func runWebUI(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	addr := fmt.Sprintf("127.0.0.1:%d", webUIPort)
-	webUiAddr := fmt.Sprintf("http://%s", addr)
+	tlsConfig, err := webUITLSConfig()
+	if err != nil {
+		return err
+	}
 
-	router := mux.NewRouter()
+	scheme := "http"
+	if tlsConfig != nil {
+		scheme = "https"
+	}
+
+	addr := net.JoinHostPort(webUIHost, strconv.Itoa(webUIPort))
+	if webUIUnixSocket != "" {
+		addr = webUIUnixSocket
+	}
+	webUiAddr := fmt.Sprintf("%s://%s", scheme, addr)
 
-	graphqlHandler, err := graphql.NewHandler(repo)
+	auth, err := webUIAuth()
 	if err != nil {
 		return err
 	}
+	auth.secure = tlsConfig != nil
 
-	assetsHandler := &fileSystemWithDefault{
-		FileSystem:  webui.WebUIAssets,
-		defaultFile: "index.html",
+	origins, err := webUIAllowedOrigins()
+	if err != nil {
+		return err
+	}
+
+	if webUIUnixSocket == "" && !isLoopback(webUIHost) {
+		if !auth.enabled() {
+			fmt.Printf("Warning: the web UI is reachable from %s without authentication\n", webUIHost)
+		} else if tlsConfig == nil {
+			fmt.Printf("Warning: the credentials are sent in clear text to %s, consider using TLS\n", webUIHost)
+		}
+	}
+
+	listener, err := webUIListen(addr)
+	if err != nil {
+		return err
+	}
+	if tlsConfig != nil {
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+
+	router := mux.NewRouter()
+
+	graphqlHandler, err := graphql.NewHandlerWithOptions(repo, graphql.Options{
+		ReadOnly: webUIReadOnly,
+		Identity: webUIIdentity,
+	})
+	if err != nil {
+		return err
 	}
 
 	// Routes
 	router.Path("/playground").Handler(handler.Playground("git-bug", "/graphql"))
 	router.Path("/graphql").Handler(graphqlHandler)
 	router.Path("/gitfile/{hash}").Handler(newGitFileHandler(repo))
-	router.Path("/upload").Methods("POST").Handler(newGitUploadFileHandler(repo))
-	router.PathPrefix("/").Handler(http.FileServer(assetsHandler))
+	router.Path("/feed.atom").Handler(newFeedHandler(graphqlHandler, tlsConfig != nil))
+	router.Path("/bugs/{id}/feed.atom").Handler(newFeedHandler(graphqlHandler, tlsConfig != nil))
+	router.Path("/upload").Methods("POST").Handler(newUploadHandler(repo, webUIReadOnly))
+	if !webUIAPIOnly {
+		assetsHandler := &fileSystemWithDefault{
+			FileSystem:  webui.WebUIAssets,
+			defaultFile: "index.html",
+		}
+		router.PathPrefix("/").Handler(http.FileServer(assetsHandler))
+	}
+
+	// the API tokens are checked even without authentication, for their
+	// scope and identity to apply
+	auth.handler = router
+
+	var handler http.Handler = auth
+	if len(origins) > 0 {
+		handler = newCorsHandler(auth, origins)
+	}
 
 	srv := &http.Server{
 		Addr:    addr,
-		Handler: router,
+		Handler: handler,
 	}
 
 	done := make(chan bool)
@@ -95,9 +189,15 @@ func runWebUI(cmd *cobra.Command, args []string) error {
 		close(done)
 	}()
 
-	fmt.Printf("Web UI: %s\n", webUiAddr)
-	fmt.Printf("Graphql API: http://%s/graphql\n", addr)
-	fmt.Printf("Graphql Playground: http://%s/playground\n", addr)
+	if webUIUnixSocket != "" {
+		fmt.Printf("Web UI: listening on the unix socket %s\n", webUIUnixSocket)
+	} else {
+		if !webUIAPIOnly {
+			fmt.Printf("Web UI: %s\n", webUiAddr)
+		}
+		fmt.Printf("Graphql API: %s/graphql\n", webUiAddr)
+		fmt.Printf("Graphql Playground: %s/playground\n", webUiAddr)
+	}
 	fmt.Println("Press Ctrl+c to quit")
 
 	configOpen, err := repo.ReadConfigBool(webUIOpenConfigKey)
@@ -108,16 +208,22 @@ func runWebUI(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	shouldOpen := (configOpen && !webUINoOpen) || webUIOpen
+	// a browser can't open a unix socket, and there is nothing to open
+	// without the web UI
+	shouldOpen := ((configOpen && !webUINoOpen) || webUIOpen) && webUIUnixSocket == "" && !webUIAPIOnly
 
 	if shouldOpen {
-		err = open.Run(webUiAddr)
+		openAddr := webUiAddr
+		if auth.token != "" {
+			openAddr = fmt.Sprintf("%s/?token=%s", webUiAddr, url.QueryEscape(auth.token))
+		}
+		err = open.Run(openAddr)
 		if err != nil {
 			fmt.Println(err)
 		}
 	}
 
-	err = srv.ListenAndServe()
+	err = srv.Serve(listener)
 	if err != nil && err != http.ErrServerClosed {
 		return err
 	}
@@ -128,6 +234,151 @@ func runWebUI(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// webUIAuth read the credentials of the web UI from the flags, or else from
+// the git config
+func webUIAuth() (*authHandler, error) {
+	auth := &authHandler{}
+
+	basicAuth := webUIBasicAuth
+	if basicAuth == "" {
+		var err error
+		basicAuth, err = repo.ReadConfigString(webUIBasicAuthConfigKey)
+		if err != nil && err != repository.ErrNoConfigEntry {
+			return nil, err
+		}
+	}
+
+	if basicAuth != "" {
+		split := strings.SplitN(basicAuth, ":", 2)
+		if len(split) != 2 || split[0] == "" || split[1] == "" {
+			return nil, fmt.Errorf("invalid basic auth credentials, expected USER:PASSWORD")
+		}
+		auth.user, auth.password = split[0], split[1]
+	}
+
+	auth.token = webUIToken
+	if auth.token == "" {
+		var err error
+		auth.token, err = repo.ReadConfigString(webUITokenConfigKey)
+		if err != nil && err != repository.ErrNoConfigEntry {
+			return nil, err
+		}
+	}
+
+	return auth, nil
+}
+
+// webUIListen listen on the TCP address, or on the unix socket at this path
+func webUIListen(addr string) (net.Listener, error) {
+	if webUIUnixSocket == "" {
+		return net.Listen("tcp", addr)
+	}
+
+	// a socket left by a previous run that didn't stop cleanly is removed,
+	// unless something still listen on it
+	if _, err := os.Stat(addr); err == nil {
+		if conn, err := net.Dial("unix", addr); err == nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("the unix socket %s is already in use", addr)
+		}
+		if err := os.Remove(addr); err != nil {
+			return nil, err
+		}
+	}
+
+	return net.Listen("unix", addr)
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// implement a http.Handler that reject the requests without valid
+// credentials, either a basic auth or a token. The token is accepted as a
+// bearer token, or in the url of the web UI, in which case it's kept in a
+// cookie for the following requests. The API tokens are also accepted as
+// bearer tokens, with their scope.
+type authHandler struct {
+	handler  http.Handler
+	user     string
+	password string
+	token    string
+	// the web UI is served over TLS, the cookie is only sent back over TLS
+	secure bool
+}
+
+func (ah *authHandler) enabled() bool {
+	return ah.user != "" || ah.token != ""
+}
+
+func (ah *authHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	secret := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if apitoken.IsSecret(secret) {
+		token, err := apitoken.Check(repo, secret)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		ah.handler.ServeHTTP(rw, r.WithContext(apitoken.WithToken(r.Context(), token)))
+		return
+	}
+
+	if !ah.enabled() || ah.authorized(rw, r) {
+		ah.handler.ServeHTTP(rw, r)
+		return
+	}
+
+	if ah.user != "" {
+		rw.Header().Set("WWW-Authenticate", `Basic realm="git-bug"`)
+	}
+	http.Error(rw, "unauthorized", http.StatusUnauthorized)
+}
+
+func (ah *authHandler) authorized(rw http.ResponseWriter, r *http.Request) bool {
+	if ah.user != "" {
+		user, password, ok := r.BasicAuth()
+		if ok && secureCompare(user, ah.user) && secureCompare(password, ah.password) {
+			return true
+		}
+	}
+
+	if ah.token == "" {
+		return false
+	}
+
+	header := r.Header.Get("Authorization")
+	if strings.HasPrefix(header, "Bearer ") && secureCompare(strings.TrimPrefix(header, "Bearer "), ah.token) {
+		return true
+	}
+
+	if cookie, err := r.Cookie(webUITokenCookie); err == nil && secureCompare(cookie.Value, ah.token) {
+		return true
+	}
+
+	if secureCompare(r.URL.Query().Get("token"), ah.token) {
+		cookie := &http.Cookie{
+			Name:     webUITokenCookie,
+			Value:    ah.token,
+			Path:     "/",
+			HttpOnly: true,
+			Secure:   ah.secure,
+		}
+		// http.Cookie only support SameSite from go 1.11
+		rw.Header().Add("Set-Cookie", cookie.String()+"; SameSite=Strict")
+		return true
+	}
+
+	return false
+}
+
+func secureCompare(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
 // implement a http.FileSystem that will serve a default file when the looked up
 // file doesn't exist. Useful for Single-Page App that implement routing client
 // side, where the server has to return the root index.html file for every route.
@@ -144,6 +395,57 @@ func (fswd *fileSystemWithDefault) Open(name string) (http.File, error) {
 	return f, err
 }
 
+// implement a http.Handler serving the Atom feed of the recent changes of
+// the bugs, or of the changes of a single bug
+type feedHandler struct {
+	cache  *cache.MultiRepoCache
+	secure bool
+}
+
+func newFeedHandler(handler graphql.Handler, secure bool) http.Handler {
+	return &feedHandler{
+		cache:  &handler.MultiRepoCache,
+		secure: secure,
+	}
+}
+
+func (fh *feedHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	repo, err := fh.cache.DefaultRepo()
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// the links of the feed are absolute, to the web UI as reached by the
+	// client, possibly through a reverse proxy
+	scheme := "http"
+	if fh.secure || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	baseUrl := fmt.Sprintf("%s://%s", scheme, r.Host)
+
+	var feed *site.Feed
+	if prefix, ok := mux.Vars(r)["id"]; ok {
+		b, err := repo.ResolveBugPrefix(prefix)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusNotFound)
+			return
+		}
+		feed = site.BugFeed(repo, b.Snapshot(), baseUrl)
+	} else {
+		feed, err = site.RecentFeed(repo, baseUrl)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	rw.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	if err := feed.Encode(rw); err != nil {
+		log.Printf("can't write the feed: %v", err)
+	}
+}
+
 // implement a http.Handler that will read and server git blob.
 type gitFileHandler struct {
 	repo repository.Repo
@@ -163,6 +465,13 @@ func (gfh *gitFileHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// a file never change, as it's identified by the hash of its content
+	etag := fmt.Sprintf(`"%s"`, hash)
+	if r.Header.Get("If-None-Match") == etag {
+		rw.WriteHeader(http.StatusNotModified)
+		return
+	}
+
 	// TODO: this mean that the whole file will he buffered in memory
 	// This can be a problem for big files. There might be a way around
 	// that by implementing a io.ReadSeeker that would read and discard
@@ -173,7 +482,40 @@ func (gfh *gitFileHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	http.ServeContent(rw, r, "", time.Now(), bytes.NewReader(data))
+	// Only the images are displayed by the browser. Anything else, including
+	// html or svg that could run scripts in the web UI, is downloaded.
+	contentType := http.DetectContentType(data)
+	if !webUIInlineTypes[contentType] {
+		rw.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, hash))
+	}
+
+	rw.Header().Set("Content-Type", contentType)
+	rw.Header().Set("X-Content-Type-Options", "nosniff")
+	rw.Header().Set("Cache-Control", "private, max-age=31536000, immutable")
+	rw.Header().Set("ETag", etag)
+
+	http.ServeContent(rw, r, "", time.Time{}, bytes.NewReader(data))
+}
+
+// newUploadHandler return the handler of the file uploads, which change the
+// repository like the GraphQL mutations: they are refused for a read-only web
+// UI, and for an API token without the write scope.
+func newUploadHandler(repo repository.Repo, readOnly bool) http.Handler {
+	upload := newGitUploadFileHandler(repo)
+
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if readOnly {
+			http.Error(rw, graphql.ErrReadOnly.Error(), http.StatusForbidden)
+			return
+		}
+
+		if token, ok := apitoken.FromContext(r.Context()); ok && !token.Scope.CanWrite() {
+			http.Error(rw, graphql.ErrTokenReadOnly.Error(), http.StatusForbidden)
+			return
+		}
+
+		upload.ServeHTTP(rw, r)
+	})
 }
 
 // implement a http.Handler that will accept and store content into git blob.
@@ -188,30 +530,34 @@ func newGitUploadFileHandler(repo repository.Repo) http.Handler {
 }
 
 func (gufh *gitUploadFileHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
-	// 100MB (github limit)
-	var maxUploadSize int64 = 100 * 1000 * 1000
-	r.Body = http.MaxBytesReader(rw, r.Body, maxUploadSize)
-	if err := r.ParseMultipartForm(maxUploadSize); err != nil {
-		http.Error(rw, "file too big (100MB max)", http.StatusBadRequest)
+	// the multipart encoding add a bit of overhead around the file
+	r.Body = http.MaxBytesReader(rw, r.Body, webUIMaxUploadSize+1024*1024)
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		http.Error(rw, webUIErrTooBig, http.StatusRequestEntityTooLarge)
 		return
 	}
+	defer r.MultipartForm.RemoveAll()
 
-	file, _, err := r.FormFile("uploadfile")
+	file, header, err := r.FormFile("uploadfile")
 	if err != nil {
 		http.Error(rw, "invalid file", http.StatusBadRequest)
 		return
 	}
 	defer file.Close()
+
+	if header.Size > webUIMaxUploadSize {
+		http.Error(rw, webUIErrTooBig, http.StatusRequestEntityTooLarge)
+		return
+	}
+
 	fileBytes, err := ioutil.ReadAll(file)
 	if err != nil {
 		http.Error(rw, "invalid file", http.StatusBadRequest)
 		return
 	}
 
-	filetype := http.DetectContentType(fileBytes)
-	if filetype != "image/jpeg" && filetype != "image/jpg" &&
-		filetype != "image/gif" && filetype != "image/png" {
-		http.Error(rw, "invalid file type", http.StatusBadRequest)
+	if len(fileBytes) == 0 {
+		http.Error(rw, "empty file", http.StatusBadRequest)
 		return
 	}
 
@@ -222,10 +568,16 @@ func (gufh *gitUploadFileHandler) ServeHTTP(rw http.ResponseWriter, r *http.Requ
 	}
 
 	type response struct {
-		Hash string `json:"hash"`
+		Hash        string `json:"hash"`
+		ContentType string `json:"contentType"`
+		Size        int    `json:"size"`
 	}
 
-	resp := response{Hash: string(hash)}
+	resp := response{
+		Hash:        string(hash),
+		ContentType: http.DetectContentType(fileBytes),
+		Size:        len(fileBytes),
+	}
 
 	js, err := json.Marshal(resp)
 	if err != nil {
@@ -246,9 +598,28 @@ var webUICmd = &cobra.Command{
 	Short: "Launch the web UI.",
 	Long: `Launch the web UI.
 
+By default, the web UI only listen on the loopback interface. To expose it beyond localhost, use --host with an authentication, and possibly --read-only or --identity.
+
+The web UI can be served over TLS, either with a certificate given with --tls-cert and --tls-key, or with a self-signed certificate for the local network with --tls-self-signed. It can also listen on a unix socket, to be served behind a reverse proxy.
+
+API tokens created with "git bug api-token create" are accepted as bearer tokens, with their scope, for automation to use the GraphQL API.
+
+The Atom feed of the recent changes is served at /feed.atom, and the feed of a single bug at /bugs/<id>/feed.atom.
+
+To use the API from the pages of other sites, such as a dashboard, allow their origin with --allowed-origin. With --api-only, only the API is served, without the web UI.
+
 Available git config:
   git-bug.webui.open [bool]: control the automatic opening of the web UI in the default browser
+  git-bug.webui.basic-auth [string]: the USER:PASSWORD credentials of a basic authentication, as with --basic-auth
+  git-bug.webui.token [string]: the token to authenticate with, as with --token
+  git-bug.webui.allowed-origins [string]: the origins allowed to use the API from a browser, separated by spaces, as with --allowed-origin
 `,
+	Example: `git bug webui --host 0.0.0.0 --port 8080 --read-only --token s3cr3t
+git config git-bug.webui.basic-auth "rene:s3cr3t"
+git bug webui --host 0.0.0.0 --identity 7e4a3bb
+git bug webui --host 0.0.0.0 --tls-self-signed --basic-auth "rene:s3cr3t"
+git bug webui --unix-socket /run/git-bug/webui.sock --read-only
+git bug webui --api-only --allowed-origin https://dashboard.example.com --port 8080`,
 	PreRunE: loadRepo,
 	RunE:    runWebUI,
 }
@@ -261,5 +632,15 @@ func init() {
 	webUICmd.Flags().BoolVar(&webUIOpen, "open", false, "Automatically open the web UI in the default browser")
 	webUICmd.Flags().BoolVar(&webUINoOpen, "no-open", false, "Prevent the automatic opening of the web UI in the default browser")
 	webUICmd.Flags().IntVarP(&webUIPort, "port", "p", 0, "Port to listen to (default is random)")
-
+	webUICmd.Flags().StringVar(&webUIHost, "host", "127.0.0.1", "Host or address to listen to")
+	webUICmd.Flags().BoolVar(&webUIReadOnly, "read-only", false, "Reject every change to the repository")
+	webUICmd.Flags().StringVar(&webUIIdentity, "identity", "", "Author all the changes with the identity matching this id prefix, instead of the one selected by each client or the user identity")
+	webUICmd.Flags().StringVar(&webUIBasicAuth, "basic-auth", "", "Require a basic authentication with these USER:PASSWORD credentials")
+	webUICmd.Flags().StringVar(&webUIToken, "token", "", "Require this token, either as a bearer token or in the url of the web UI (?token=...)")
+	webUICmd.Flags().StringVar(&webUIUnixSocket, "unix-socket", "", "Listen on the unix socket at this path instead of a TCP port, for example behind a reverse proxy")
+	webUICmd.Flags().StringVar(&webUITLSCert, "tls-cert", "", "Serve over TLS with the certificate in this PEM file")
+	webUICmd.Flags().StringVar(&webUITLSKey, "tls-key", "", "Private key of the TLS certificate, in a PEM file")
+	webUICmd.Flags().BoolVar(&webUITLSSelfSigned, "tls-self-signed", false, "Serve over TLS with a self-signed certificate generated on start")
+	webUICmd.Flags().BoolVar(&webUIAPIOnly, "api-only", false, "Only serve the API, without the web UI")
+	webUICmd.Flags().StringSliceVar(&webUIAllowedOrigin, "allowed-origin", nil, "Allow the pages of this origin to use the API from a browser (CORS), or any origin with *, without the credentials of the browser. Can be repeated")
 }
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/graphql/session"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+const webUIAllowedOriginsConfigKey = "git-bug.webui.allowed-origins"
+
+// webUIAllowedOrigins read the origins allowed to use the API from a
+// browser from the flags, or else from the git config, where they are
+// separated by spaces or commas
+func webUIAllowedOrigins() ([]string, error) {
+	if len(webUIAllowedOrigin) > 0 {
+		return webUIAllowedOrigin, nil
+	}
+
+	raw, err := repo.ReadConfigString(webUIAllowedOriginsConfigKey)
+	if err == repository.ErrNoConfigEntry {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ' ' || r == ','
+	}), nil
+}
+
+// implement a http.Handler that allow the pages of other origins to use the
+// API from a browser (CORS). It answer the preflight requests itself, as the
+// browsers send them without the credentials.
+type corsHandler struct {
+	handler http.Handler
+	origins map[string]bool
+	// any origin is allowed
+	any bool
+}
+
+func newCorsHandler(handler http.Handler, origins []string) *corsHandler {
+	ch := &corsHandler{
+		handler: handler,
+		origins: make(map[string]bool),
+	}
+	for _, origin := range origins {
+		if origin == "*" {
+			ch.any = true
+			continue
+		}
+		ch.origins[strings.TrimSuffix(origin, "/")] = true
+	}
+	return ch
+}
+
+func (ch *corsHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("Origin")
+	rw.Header().Add("Vary", "Origin")
+
+	if origin == "" || !(ch.any || ch.origins[origin]) {
+		ch.handler.ServeHTTP(rw, r)
+		return
+	}
+
+	if ch.origins[origin] {
+		// the origin is given back instead of *, for the browser to send the
+		// credentials
+		rw.Header().Set("Access-Control-Allow-Origin", origin)
+		rw.Header().Set("Access-Control-Allow-Credentials", "true")
+	} else {
+		// any site is allowed, but without the credentials cached by the
+		// browser, or it could act on behalf of the user
+		rw.Header().Set("Access-Control-Allow-Origin", "*")
+	}
+
+	if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+		rw.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		rw.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, "+session.HeaderName)
+		rw.Header().Set("Access-Control-Max-Age", "600")
+		rw.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	ch.handler.ServeHTTP(rw, r)
+}
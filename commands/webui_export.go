@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/interrupt"
+	"github.com/MichaelMure/git-bug/webui/site"
+)
+
+var (
+	webUIExportOut string
+)
+
+func runWebUIExport(cmd *cobra.Command, args []string) error {
+	backend, err := cache.NewRepoCache(repo)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+	interrupt.RegisterCleaner(backend.Close)
+
+	query, err := backend.ParseQuery(strings.Join(args, " "))
+	if err != nil {
+		return err
+	}
+
+	ids := backend.QueryBugs(query)
+
+	if err := site.Export(backend, ids, webUIExportOut); err != nil {
+		return err
+	}
+
+	fmt.Printf("%d bugs exported in %s\n", len(ids), webUIExportOut)
+
+	return nil
+}
+
+var webUIExportCmd = &cobra.Command{
+	Use:   "export [<query>]",
+	Short: "Generate a static website of the bugs.",
+	Long: `Generate a static website of the bugs: an index.html listing them and a page for each bug with its timeline.
+
+The website is read-only and doesn't need git-bug to be served, for example to publish an archive of the bugs on GitHub Pages or on an internal server. The links are relative, so it can be served from any path. Without query, all the bugs are exported.
+
+For safety, the raw HTML of the messages is not rendered.`,
+	Example: `Generate the website in ./site:
+git bug webui export --out ./site
+
+Only the open bugs:
+git bug webui export --out ./public status:open
+`,
+	PreRunE: loadRepo,
+	RunE:    runWebUIExport,
+}
+
+func init() {
+	webUICmd.AddCommand(webUIExportCmd)
+
+	webUIExportCmd.Flags().SortFlags = false
+
+	webUIExportCmd.Flags().StringVarP(&webUIExportOut, "out", "o", "site",
+		"Directory to write the website to")
+}
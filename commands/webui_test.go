@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/graphql/apitoken"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestWebUIUploadScope(t *testing.T) {
+	testRepo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, testRepo)
+
+	// the auth handler check the tokens of the loaded repository
+	repo = testRepo
+	defer func() { repo = nil }()
+
+	readSecret, _, err := apitoken.Create(testRepo, "read", apitoken.ScopeRead, "")
+	require.NoError(t, err)
+	writeSecret, _, err := apitoken.Create(testRepo, "write", apitoken.ScopeWrite, "")
+	require.NoError(t, err)
+
+	upload := func(readOnly bool, secret string) int {
+		router := mux.NewRouter()
+		router.Path("/upload").Methods("POST").Handler(newUploadHandler(testRepo, readOnly))
+		auth := &authHandler{handler: router}
+
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		file, err := form.CreateFormFile("uploadfile", "file.txt")
+		require.NoError(t, err)
+		_, err = file.Write([]byte("content"))
+		require.NoError(t, err)
+		require.NoError(t, form.Close())
+
+		r := httptest.NewRequest("POST", "/upload", &body)
+		r.Header.Set("Content-Type", form.FormDataContentType())
+		r.Header.Set("Authorization", "Bearer "+secret)
+
+		rw := httptest.NewRecorder()
+		auth.ServeHTTP(rw, r)
+		return rw.Code
+	}
+
+	// as for the GraphQL mutations, a token needs the write scope
+	require.Equal(t, http.StatusForbidden, upload(false, readSecret))
+	require.Equal(t, http.StatusOK, upload(false, writeSecret))
+
+	require.Equal(t, http.StatusForbidden, upload(true, writeSecret))
+}
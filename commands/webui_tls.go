@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// webUITLSConfig return the TLS configuration of the web UI, nil to serve
+// plain http
+func webUITLSConfig() (*tls.Config, error) {
+	if (webUITLSCert == "") != (webUITLSKey == "") {
+		return nil, fmt.Errorf("--tls-cert and --tls-key must be given together")
+	}
+
+	var cert tls.Certificate
+	var err error
+
+	switch {
+	case webUITLSCert != "" && webUITLSSelfSigned:
+		return nil, fmt.Errorf("--tls-self-signed can't be used with a certificate")
+	case webUITLSCert != "":
+		cert, err = tls.LoadX509KeyPair(webUITLSCert, webUITLSKey)
+		if err != nil {
+			return nil, err
+		}
+	case webUITLSSelfSigned:
+		cert, err = selfSignedCertificate(webUIHost)
+		if err != nil {
+			return nil, err
+		}
+
+		// there is no authority to trust, the fingerprint allow to check
+		// the certificate accepted in the browser
+		sum := sha256.Sum256(cert.Certificate[0])
+		fmt.Printf("TLS certificate fingerprint (SHA-256): %s\n", fingerprint(sum[:]))
+	default:
+		return nil, nil
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// selfSignedCertificate generate a certificate valid for the host, the local
+// names and addresses of the machine. It's only kept in memory.
+func selfSignedCertificate(host string) (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	now := time.Now()
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"git-bug"}, CommonName: host},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.AddDate(1, 0, 0),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+
+	template.DNSNames, template.IPAddresses = certificateNames(host)
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+	}, nil
+}
+
+// certificateNames return the names and addresses the web UI can be reached
+// with: the listened host, or all the addresses of the machine if it listen
+// on all of them, as well as localhost and the hostname
+func certificateNames(host string) ([]string, []net.IP) {
+	names := []string{"localhost"}
+	ips := []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback}
+
+	if hostname, err := os.Hostname(); err == nil {
+		names = append(names, hostname)
+	}
+
+	ip := net.ParseIP(host)
+	switch {
+	case ip == nil:
+		if host != "" && host != "localhost" {
+			names = append(names, host)
+		}
+	case ip.IsUnspecified():
+		addrs, err := net.InterfaceAddrs()
+		if err != nil {
+			break
+		}
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok && !ipNet.IP.IsLoopback() {
+				ips = append(ips, ipNet.IP)
+			}
+		}
+	case !ip.IsLoopback():
+		ips = append(ips, ip)
+	}
+
+	return names, ips
+}
+
+func fingerprint(sum []byte) string {
+	hex := make([]string, len(sum))
+	for i, b := range sum {
+		hex[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(hex, ":")
+}
@@ -26,6 +26,10 @@ type MergeResult struct {
 	// Only set for invalid status
 	Reason string
 
+	// Only set for updated status, when known: the number of remote
+	// operations merged
+	Merged int
+
 	// Not set for invalid status
 	Entity Interface
 }
@@ -37,6 +41,9 @@ func (mr MergeResult) String() string {
 	case MergeStatusInvalid:
 		return fmt.Sprintf("invalid data: %s", mr.Reason)
 	case MergeStatusUpdated:
+		if mr.Merged > 0 {
+			return fmt.Sprintf("merged %d remote ops", mr.Merged)
+		}
 		return "updated"
 	case MergeStatusNothing:
 		return "nothing to do"
@@ -65,6 +72,15 @@ func NewMergeStatus(status MergeStatus, id Id, entity Interface) MergeResult {
 	}
 }
 
+func NewMergeUpdatedStatus(id Id, entity Interface, merged int) MergeResult {
+	return MergeResult{
+		Id:     id,
+		Status: MergeStatusUpdated,
+		Entity: entity,
+		Merged: merged,
+	}
+}
+
 func NewMergeInvalidStatus(id Id, reason string) MergeResult {
 	return MergeResult{
 		Id:     id,
@@ -0,0 +1,250 @@
+// Package apitoken manage the tokens giving access to the GraphQL API, for
+// automation. A token is limited to a scope, and can author the changes with
+// its own identity.
+package apitoken
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// the tokens are stored in the git config of the repository, which is not
+// shared, indexed by their id
+const configKeyPrefix = "git-bug.api-token."
+
+const (
+	configKeyName     = "name"
+	configKeyScope    = "scope"
+	configKeyIdentity = "identity"
+	configKeyCreated  = "created"
+	configKeyHash     = "hash"
+)
+
+// the secret of a token, prefixed to be easily recognized
+const secretPrefix = "gbt_"
+
+// the id of a token is the start of the hash of its secret
+const idLength = 10
+
+var ErrInvalidToken = errors.New("invalid API token")
+
+// Scope is what a token is allowed to do
+type Scope string
+
+const (
+	// ScopeRead only allow the queries
+	ScopeRead Scope = "read"
+	// ScopeWrite allow the queries and the mutations
+	ScopeWrite Scope = "write"
+)
+
+func ParseScope(s string) (Scope, error) {
+	switch Scope(s) {
+	case ScopeRead, ScopeWrite:
+		return Scope(s), nil
+	}
+	return "", fmt.Errorf("unknown scope %s, expected read or write", s)
+}
+
+// CanWrite tell if the scope allow to change the repository
+func (s Scope) CanWrite() bool {
+	return s == ScopeWrite
+}
+
+type Token struct {
+	Id        string
+	Name      string
+	Scope     Scope
+	CreatedAt time.Time
+
+	// Identity, if set, author the changes made with the token, instead of
+	// the identity of the server
+	Identity entity.Id
+
+	hash string
+}
+
+// Create generate a new token and store it. The secret is returned only
+// once, as only its hash is stored.
+func Create(repo repository.RepoCommon, name string, scope Scope, identity entity.Id) (string, *Token, error) {
+	random := make([]byte, 20)
+	if _, err := rand.Read(random); err != nil {
+		return "", nil, err
+	}
+
+	secret := secretPrefix + hex.EncodeToString(random)
+	hash := hashSecret(secret)
+
+	token := &Token{
+		Id:        hash[:idLength],
+		Name:      name,
+		Scope:     scope,
+		CreatedAt: time.Now(),
+		Identity:  identity,
+		hash:      hash,
+	}
+
+	values := map[string]string{
+		configKeyName:    token.Name,
+		configKeyScope:   string(token.Scope),
+		configKeyCreated: strconv.FormatInt(token.CreatedAt.Unix(), 10),
+		configKeyHash:    hash,
+	}
+	if identity != "" {
+		values[configKeyIdentity] = identity.String()
+	}
+
+	for key, value := range values {
+		if err := repo.StoreConfig(configKeyPrefix+token.Id+"."+key, value); err != nil {
+			return "", nil, errors.Wrap(err, "can't store the token")
+		}
+	}
+
+	return secret, token, nil
+}
+
+// List return all the tokens, the oldest first
+func List(repo repository.RepoCommon) ([]*Token, error) {
+	configs, err := repo.ReadConfigs(configKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make(map[string]*Token)
+
+	for key, value := range configs {
+		split := strings.Split(strings.TrimPrefix(key, configKeyPrefix), ".")
+		if len(split) != 2 {
+			continue
+		}
+
+		id, field := split[0], split[1]
+
+		token, ok := tokens[id]
+		if !ok {
+			token = &Token{Id: id}
+			tokens[id] = token
+		}
+
+		switch field {
+		case configKeyName:
+			token.Name = value
+		case configKeyScope:
+			token.Scope = Scope(value)
+		case configKeyIdentity:
+			token.Identity = entity.Id(value)
+		case configKeyHash:
+			token.hash = value
+		case configKeyCreated:
+			unix, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid creation time of the token %s", id)
+			}
+			token.CreatedAt = time.Unix(unix, 0)
+		}
+	}
+
+	result := make([]*Token, 0, len(tokens))
+	for _, token := range tokens {
+		result = append(result, token)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].Id < result[j].Id
+		}
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+// Remove revoke the token matching the id prefix
+func Remove(repo repository.RepoCommon, prefix string) (*Token, error) {
+	tokens, err := List(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var matching []*Token
+	for _, token := range tokens {
+		if strings.HasPrefix(token.Id, prefix) {
+			matching = append(matching, token)
+		}
+	}
+
+	if len(matching) == 0 {
+		return nil, fmt.Errorf("no API token matching %s", prefix)
+	}
+	if len(matching) > 1 {
+		return nil, fmt.Errorf("multiple API tokens matching %s", prefix)
+	}
+
+	return matching[0], repo.RmConfigs(configKeyPrefix + matching[0].Id)
+}
+
+// IsSecret tell if a string has the form of the secret of a token, to tell
+// it apart from other credentials
+func IsSecret(s string) bool {
+	return strings.HasPrefix(s, secretPrefix)
+}
+
+// Check return the token of a secret, or ErrInvalidToken
+func Check(repo repository.RepoCommon, secret string) (*Token, error) {
+	if !IsSecret(secret) {
+		return nil, ErrInvalidToken
+	}
+
+	hash := hashSecret(secret)
+
+	tokens, err := List(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, token := range tokens {
+		if token.Id != hash[:idLength] {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(token.hash), []byte(hash)) != 1 {
+			return nil, ErrInvalidToken
+		}
+		if _, err := ParseScope(string(token.Scope)); err != nil {
+			return nil, err
+		}
+		return token, nil
+	}
+
+	return nil, ErrInvalidToken
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+type tokenCtxKey struct{}
+
+// WithToken return a context holding the token a request is made with
+func WithToken(ctx context.Context, token *Token) context.Context {
+	return context.WithValue(ctx, tokenCtxKey{}, token)
+}
+
+// FromContext return the token a request is made with, if any
+func FromContext(ctx context.Context) (*Token, bool) {
+	token, ok := ctx.Value(tokenCtxKey{}).(*Token)
+	return token, ok
+}
@@ -0,0 +1,56 @@
+package apitoken
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestAPIToken(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+
+	secret, token, err := Create(repo, "ci", ScopeWrite, entity.Id("abcdef"))
+	require.NoError(t, err)
+	require.True(t, IsSecret(secret))
+
+	readSecret, _, err := Create(repo, "dashboard", ScopeRead, "")
+	require.NoError(t, err)
+
+	tokens, err := List(repo)
+	require.NoError(t, err)
+	require.Len(t, tokens, 2)
+
+	checked, err := Check(repo, secret)
+	require.NoError(t, err)
+	require.Equal(t, token.Id, checked.Id)
+	require.Equal(t, "ci", checked.Name)
+	require.True(t, checked.Scope.CanWrite())
+	require.Equal(t, entity.Id("abcdef"), checked.Identity)
+	require.Equal(t, token.CreatedAt.Unix(), checked.CreatedAt.Unix())
+
+	checked, err = Check(repo, readSecret)
+	require.NoError(t, err)
+	require.False(t, checked.Scope.CanWrite())
+
+	_, err = Check(repo, secret[:len(secret)-1]+"x")
+	require.Equal(t, ErrInvalidToken, err)
+	_, err = Check(repo, "not a token")
+	require.Equal(t, ErrInvalidToken, err)
+
+	removed, err := Remove(repo, token.Id[:4])
+	require.NoError(t, err)
+	require.Equal(t, token.Id, removed.Id)
+
+	_, err = Check(repo, secret)
+	require.Equal(t, ErrInvalidToken, err)
+
+	tokens, err = List(repo)
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+
+	_, err = ParseScope("admin")
+	require.Error(t, err)
+}
@@ -1,12 +1,17 @@
 package graphql
 
 import (
+	"net/http"
+	"net/http/cookiejar"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/vektah/gqlgen/client"
 
 	"github.com/MichaelMure/git-bug/graphql/models"
+	"github.com/MichaelMure/git-bug/graphql/session"
+	"github.com/MichaelMure/git-bug/identity"
 	"github.com/MichaelMure/git-bug/misc/random_bugs"
 	"github.com/MichaelMure/git-bug/repository"
 )
@@ -215,3 +220,602 @@ func TestQueries(t *testing.T) {
 
 	c.MustPost(query, &resp)
 }
+
+func TestMutations(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	handler, err := NewHandler(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backend, err := handler.DefaultRepo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rene, err := backend.NewIdentity("René Descartes", "rene@descartes.fr")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(handler)
+	c := client.New(srv.URL, newCookieClient(t))
+
+	var user struct {
+		DefaultRepository struct {
+			UserIdentity *struct {
+				Id string
+			}
+		}
+	}
+
+	c.MustPost(`query { defaultRepository { userIdentity { id } } }`, &user)
+
+	if user.DefaultRepository.UserIdentity != nil {
+		t.Fatalf("no user identity should be set: %+v", user)
+	}
+
+	var selected struct {
+		SetUserIdentity struct {
+			Identity struct {
+				Id string
+			}
+		}
+	}
+
+	c.MustPost(`mutation($prefix: String!) {
+		setUserIdentity(input: {prefix: $prefix}) {
+			identity { id }
+		}
+	}`, &selected, client.Var("prefix", rene.Id().Human()))
+
+	if selected.SetUserIdentity.Identity.Id != rene.Id().String() {
+		t.Fatalf("unexpected user identity: %+v", selected)
+	}
+
+	var created struct {
+		NewBug struct {
+			Bug struct {
+				Id string
+			}
+			Operation struct {
+				Id string
+			}
+		}
+	}
+
+	c.MustPost(`mutation {
+		newBug(input: {title: "title", message: "message"}) {
+			bug { id }
+			operation { id }
+		}
+	}`, &created)
+
+	bugId := created.NewBug.Bug.Id
+	commentId := created.NewBug.Operation.Id
+
+	var edited struct {
+		EditComment struct {
+			Operation struct {
+				Target  string
+				Message string
+			}
+		}
+	}
+
+	c.MustPost(`mutation($prefix: String!, $target: String!) {
+		editComment(input: {prefix: $prefix, target: $target, message: "edited"}) {
+			operation { target message }
+		}
+	}`, &edited, client.Var("prefix", bugId), client.Var("target", commentId))
+
+	if edited.EditComment.Operation.Target != commentId || edited.EditComment.Operation.Message != "edited" {
+		t.Fatalf("unexpected edit: %+v", edited)
+	}
+
+	var assigned struct {
+		SetAssignee struct {
+			Operation struct {
+				Assignee *string
+			}
+		}
+	}
+
+	c.MustPost(`mutation($prefix: String!, $assignee: String!) {
+		setAssignee(input: {prefix: $prefix, assignee: $assignee}) {
+			operation { assignee }
+		}
+	}`, &assigned, client.Var("prefix", bugId), client.Var("assignee", rene.Id().Human()))
+
+	if assigned.SetAssignee.Operation.Assignee == nil || *assigned.SetAssignee.Operation.Assignee != rene.Id().String() {
+		t.Fatalf("unexpected assignment: %+v", assigned)
+	}
+
+	c.MustPost(`mutation($prefix: String!) {
+		setAssignee(input: {prefix: $prefix}) {
+			operation { assignee }
+		}
+	}`, &assigned, client.Var("prefix", bugId))
+
+	if assigned.SetAssignee.Operation.Assignee != nil {
+		t.Fatalf("the assignment should be removed: %+v", assigned)
+	}
+
+	var milestone struct {
+		SetMilestone struct {
+			Bug struct {
+				Milestone string
+			}
+		}
+	}
+
+	c.MustPost(`mutation($prefix: String!) {
+		setMilestone(input: {prefix: $prefix, milestone: "v1.0"}) {
+			bug { milestone }
+		}
+	}`, &milestone, client.Var("prefix", bugId))
+
+	if milestone.SetMilestone.Bug.Milestone != "v1.0" {
+		t.Fatalf("unexpected milestone: %+v", milestone)
+	}
+}
+
+func TestSessionIdentity(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	if err := rene.Commit(repo); err != nil {
+		t.Fatal(err)
+	}
+	if err := identity.SetUserIdentity(repo, rene); err != nil {
+		t.Fatal(err)
+	}
+
+	blaise := identity.NewIdentity("Blaise Pascal", "blaise@pascal.fr")
+	if err := blaise.Commit(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	handler, err := NewHandler(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(handler)
+	browser := client.New(srv.URL, newCookieClient(t))
+	other := client.New(srv.URL)
+
+	var selected struct {
+		SetUserIdentity struct {
+			Identity struct {
+				Id string
+			}
+		}
+	}
+
+	browser.MustPost(`mutation($prefix: String!) {
+		setUserIdentity(input: {prefix: $prefix}) {
+			identity { id }
+		}
+	}`, &selected, client.Var("prefix", blaise.Id().Human()))
+
+	var created struct {
+		NewBug struct {
+			Bug struct {
+				Author struct {
+					Name string
+				}
+			}
+		}
+	}
+
+	newBug := `mutation {
+		newBug(input: {title: "title", message: "message"}) {
+			bug { author { name } }
+		}
+	}`
+
+	// the selection is kept for the next requests of the client only
+	browser.MustPost(newBug, &created)
+	if created.NewBug.Bug.Author.Name != "Blaise Pascal" {
+		t.Fatalf("unexpected author: %+v", created)
+	}
+
+	other.MustPost(newBug, &created)
+	if created.NewBug.Bug.Author.Name != "René Descartes" {
+		t.Fatalf("unexpected author: %+v", created)
+	}
+
+	// and the user identity of the repository is left as is
+	user, err := identity.GetUserIdentity(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Id() != rene.Id() {
+		t.Fatalf("the user identity of the repository shouldn't change")
+	}
+
+	// a client can give its identity with a header as well
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`{"query": "{ defaultRepository { userIdentity { name } } }"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(session.HeaderName, blaise.Id().String())
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if !strings.Contains(rec.Body.String(), "Blaise Pascal") {
+		t.Fatalf("unexpected user identity: %s", rec.Body.String())
+	}
+}
+
+// newCookieClient return a http client keeping the cookies, like a browser
+func newCookieClient(t *testing.T) *http.Client {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &http.Client{Jar: jar}
+}
+
+func TestBugPagination(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	random_bugs.FillRepoWithSeed(repo, 10, 42)
+
+	handler, err := NewHandler(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(handler)
+	c := client.New(srv.URL)
+
+	type page struct {
+		DefaultRepository struct {
+			AllBugs struct {
+				TotalCount int
+				PageInfo   models.PageInfo
+				Nodes      []struct {
+					Id string
+				}
+			}
+		}
+	}
+
+	query := `
+      query($first: Int, $last: Int, $after: String, $before: String) {
+        defaultRepository {
+          allBugs(first: $first, last: $last, after: $after, before: $before, orderBy: {field: ID, direction: DESC}) {
+            totalCount
+            pageInfo {
+              hasNextPage
+              hasPreviousPage
+              startCursor
+              endCursor
+            }
+            nodes {
+              id
+            }
+          }
+        }
+      }`
+
+	var all page
+	c.MustPost(query, &all)
+
+	var expected []string
+	for _, node := range all.DefaultRepository.AllBugs.Nodes {
+		expected = append(expected, node.Id)
+	}
+	if len(expected) != 10 || all.DefaultRepository.AllBugs.TotalCount != 10 {
+		t.Fatalf("unexpected bugs: %v", expected)
+	}
+	for i := 1; i < len(expected); i++ {
+		if expected[i-1] < expected[i] {
+			t.Fatalf("the bugs are not ordered by descending id: %v", expected)
+		}
+	}
+
+	var forward []string
+	var after *string
+	for {
+		var resp page
+		c.MustPost(query, &resp, client.Var("first", 3), client.Var("after", after))
+
+		for _, node := range resp.DefaultRepository.AllBugs.Nodes {
+			forward = append(forward, node.Id)
+		}
+
+		info := resp.DefaultRepository.AllBugs.PageInfo
+		if info.HasPreviousPage != (after != nil) {
+			t.Fatalf("unexpected hasPreviousPage: %+v", info)
+		}
+		if !info.HasNextPage {
+			break
+		}
+		after = &info.EndCursor
+	}
+
+	var backward []string
+	var before *string
+	for {
+		var resp page
+		c.MustPost(query, &resp, client.Var("last", 4), client.Var("before", before))
+
+		var ids []string
+		for _, node := range resp.DefaultRepository.AllBugs.Nodes {
+			ids = append(ids, node.Id)
+		}
+		backward = append(ids, backward...)
+
+		info := resp.DefaultRepository.AllBugs.PageInfo
+		if !info.HasPreviousPage {
+			break
+		}
+		before = &info.StartCursor
+	}
+
+	if len(forward) != len(expected) || len(backward) != len(expected) {
+		t.Fatalf("unexpected pagination:\nexpected %v\nforward  %v\nbackward %v", expected, forward, backward)
+	}
+	for i := range expected {
+		if forward[i] != expected[i] || backward[i] != expected[i] {
+			t.Fatalf("unexpected pagination:\nexpected %v\nforward  %v\nbackward %v", expected, forward, backward)
+		}
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	random_bugs.FillRepoWithSeed(repo, 2, 42)
+
+	handler, err := NewHandlerWithOptions(repo, Options{ReadOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(handler)
+	c := client.New(srv.URL)
+
+	var resp struct {
+		DefaultRepository struct {
+			AllBugs struct {
+				TotalCount int
+			}
+		}
+	}
+
+	c.MustPost(`query { defaultRepository { allBugs { totalCount } } }`, &resp)
+
+	if resp.DefaultRepository.AllBugs.TotalCount != 2 {
+		t.Fatalf("unexpected bugs: %+v", resp)
+	}
+
+	var created struct{}
+	err = c.Post(`mutation {
+		newBug(input: {title: "title", message: "message"}) {
+			bug { id }
+		}
+	}`, &created)
+
+	if err == nil {
+		t.Fatal("a mutation should be rejected")
+	}
+}
+
+func TestBoundIdentity(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	bound := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	if err := bound.Commit(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	other := identity.NewIdentity("Blaise Pascal", "blaise@pascal.fr")
+	if err := other.Commit(repo); err != nil {
+		t.Fatal(err)
+	}
+	if err := identity.SetUserIdentity(repo, other); err != nil {
+		t.Fatal(err)
+	}
+
+	handler, err := NewHandlerWithOptions(repo, Options{Identity: bound.Id().Human()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(handler)
+	c := client.New(srv.URL)
+
+	var user struct {
+		DefaultRepository struct {
+			UserIdentity struct {
+				Id string
+			}
+		}
+	}
+
+	c.MustPost(`query { defaultRepository { userIdentity { id } } }`, &user)
+
+	if user.DefaultRepository.UserIdentity.Id != bound.Id().String() {
+		t.Fatalf("unexpected user identity: %+v", user)
+	}
+
+	var created struct {
+		NewBug struct {
+			Bug struct {
+				Author struct {
+					Name string
+				}
+			}
+		}
+	}
+
+	c.MustPost(`mutation {
+		newBug(input: {title: "title", message: "message"}) {
+			bug { author { name } }
+		}
+	}`, &created)
+
+	if created.NewBug.Bug.Author.Name != "René Descartes" {
+		t.Fatalf("unexpected author: %+v", created)
+	}
+
+	var selected struct{}
+	err = c.Post(`mutation($prefix: String!) {
+		setUserIdentity(input: {prefix: $prefix}) {
+			identity { id }
+		}
+	}`, &selected, client.Var("prefix", other.Id().Human()))
+
+	if err == nil {
+		t.Fatal("the bound identity shouldn't be changed")
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	rene := identity.NewIdentity("René Descartes", "rene@descartes.fr")
+	if err := rene.Commit(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	admin := identity.NewIdentity("Blaise Pascal", "blaise@pascal.fr")
+	if err := admin.Commit(repo); err != nil {
+		t.Fatal(err)
+	}
+	if err := identity.SetUserIdentity(repo, admin); err != nil {
+		t.Fatal(err)
+	}
+
+	// only the admin can close, everyone can label
+	if err := repo.StoreConfig("git-bug.acl.close", admin.Id().String()); err != nil {
+		t.Fatal(err)
+	}
+
+	handler, err := NewHandlerWithOptions(repo, Options{Identity: rene.Id().Human()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(handler)
+	c := client.New(srv.URL)
+
+	var created struct {
+		NewBug struct {
+			Bug struct {
+				HumanId string
+			}
+		}
+	}
+
+	c.MustPost(`mutation {
+		newBug(input: {title: "title", message: "message"}) {
+			bug { humanId }
+		}
+	}`, &created)
+	bugId := created.NewBug.Bug.HumanId
+
+	var labeled struct {
+		ChangeLabels struct {
+			Bug struct {
+				Id string
+			}
+		}
+	}
+	c.MustPost(`mutation($prefix: String!) {
+		changeLabels(input: {prefix: $prefix, added: ["bug"]}) {
+			bug { id }
+		}
+	}`, &labeled, client.Var("prefix", bugId))
+
+	var closed struct {
+		CloseBug struct {
+			Bug struct {
+				Id string
+			}
+		}
+	}
+	closeBug := `mutation($prefix: String!) {
+		closeBug(input: {prefix: $prefix}) {
+			bug { id }
+		}
+	}`
+
+	err = c.Post(closeBug, &closed, client.Var("prefix", bugId))
+	if err == nil {
+		t.Fatal("closing should be denied by the policy")
+	}
+
+	if err := repo.StoreConfig("git-bug.acl.close", admin.Id().String()+","+rene.Id().String()); err != nil {
+		t.Fatal(err)
+	}
+
+	c.MustPost(closeBug, &closed, client.Var("prefix", bugId))
+}
+
+func TestPolicyUntrustedIdentity(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	admin := identity.NewIdentity("Blaise Pascal", "blaise@pascal.fr")
+	if err := admin.Commit(repo); err != nil {
+		t.Fatal(err)
+	}
+	if err := identity.SetUserIdentity(repo, admin); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repo.StoreConfig("git-bug.acl.close", admin.Id().String()); err != nil {
+		t.Fatal(err)
+	}
+
+	// without a trusted identity, a client can't pretend to be the admin,
+	// nor act as the user identity of the repository
+	handler, err := NewHandler(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(handler)
+	browser := client.New(srv.URL, newCookieClient(t))
+
+	var selected struct{}
+	err = browser.Post(`mutation($prefix: String!) {
+		setUserIdentity(input: {prefix: $prefix}) {
+			identity { id }
+		}
+	}`, &selected, client.Var("prefix", admin.Id().Human()))
+	if err == nil {
+		t.Fatal("the identity shouldn't be selected with a policy")
+	}
+
+	var created struct{}
+	err = browser.Post(`mutation {
+		newBug(input: {title: "title", message: "message"}) {
+			bug { id }
+		}
+	}`, &created)
+	if err == nil {
+		t.Fatal("a mutation should be denied without a trusted identity")
+	}
+
+	body := `{"query": "mutation { newBug(input: {title: \"title\", message: \"message\"}) { bug { id } } }"}`
+	req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(session.HeaderName, admin.Id().String())
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if !strings.Contains(rec.Body.String(), "access control policy") {
+		t.Fatalf("a mutation should be denied with a selected identity: %s", rec.Body.String())
+	}
+}
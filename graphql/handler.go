@@ -4,21 +4,49 @@
 package graphql
 
 import (
+	"context"
+	"errors"
 	"net/http"
 
+	gqlgraphql "github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/handler"
+	"github.com/MichaelMure/git-bug/graphql/apitoken"
 	"github.com/MichaelMure/git-bug/graphql/graph"
+	"github.com/MichaelMure/git-bug/graphql/models"
 	"github.com/MichaelMure/git-bug/graphql/resolvers"
+	"github.com/MichaelMure/git-bug/graphql/session"
 	"github.com/MichaelMure/git-bug/repository"
 )
 
+// ErrReadOnly is returned for the mutations of a read-only handler
+var ErrReadOnly = errors.New("the repository is read-only")
+
+// ErrTokenReadOnly is returned for the mutations made with a read-only API
+// token
+var ErrTokenReadOnly = errors.New("the API token doesn't allow changes")
+
 // Handler is the root GraphQL http handler
 type Handler struct {
 	http.HandlerFunc
 	*resolvers.RootResolver
 }
 
+// Options change the behavior of the GraphQL handler
+type Options struct {
+	// ReadOnly reject all the mutations
+	ReadOnly bool
+
+	// Identity, if set, is the prefix of the id of the identity authoring
+	// all the mutations, instead of the identity selected by each client or
+	// the user identity of the repository. It can't be changed then.
+	Identity string
+}
+
 func NewHandler(repo repository.ClockedRepo) (Handler, error) {
+	return NewHandlerWithOptions(repo, Options{})
+}
+
+func NewHandlerWithOptions(repo repository.ClockedRepo, opts Options) (Handler, error) {
 	h := Handler{
 		RootResolver: resolvers.NewRootResolver(),
 	}
@@ -28,11 +56,47 @@ func NewHandler(repo repository.ClockedRepo) (Handler, error) {
 		return Handler{}, err
 	}
 
+	if opts.Identity != "" {
+		err = h.RootResolver.BindIdentity(opts.Identity)
+		if err != nil {
+			return Handler{}, err
+		}
+	}
+
 	config := graph.Config{
 		Resolvers: h.RootResolver,
 	}
 
-	h.HandlerFunc = handler.GraphQL(graph.NewExecutableSchema(config))
+	options := []handler.Option{
+		// the bugs and identities are shared by the resolvers of a request
+		handler.RequestMiddleware(func(ctx context.Context, next func(ctx context.Context) []byte) []byte {
+			return next(models.WithLoader(ctx))
+		}),
+	}
+	options = append(options, handler.ResolverMiddleware(guardMutations(opts.ReadOnly)))
+
+	// each client select its own identity
+	h.HandlerFunc = session.Handler(handler.GraphQL(graph.NewExecutableSchema(config), options...))
 
 	return h, nil
 }
+
+// guardMutations reject the mutations of a read-only handler, and the ones
+// of the requests made with an API token not allowed to write
+func guardMutations(readOnly bool) gqlgraphql.FieldMiddleware {
+	return func(ctx context.Context, next gqlgraphql.Resolver) (interface{}, error) {
+		if gqlgraphql.GetResolverContext(ctx).Object != "Mutation" {
+			return next(ctx)
+		}
+
+		if readOnly {
+			return nil, ErrReadOnly
+		}
+
+		if token, ok := apitoken.FromContext(ctx); ok && !token.Scope.CanWrite() {
+			return nil, ErrTokenReadOnly
+		}
+
+		return next(ctx)
+	}
+}
@@ -0,0 +1,176 @@
+package models
+
+import (
+	"sync"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+// Bug is the model of a bug for the resolvers. A bug returned by a mutation
+// is already compiled, but a bug returned by a query is only known by its
+// excerpt: it's only loaded and compiled if a field requires more than the
+// excerpt, such as its comments or its timeline. Listing bugs then doesn't
+// read any of them from git.
+type Bug struct {
+	repo    *cache.RepoCache
+	loader  *Loader
+	excerpt *cache.BugExcerpt
+
+	// behind a pointer, as gqlgen pass the model by value
+	loaded *loadedBug
+}
+
+type loadedBug struct {
+	mu   sync.Mutex
+	snap *bug.Snapshot
+}
+
+// NewLoadedBug wrap a bug already compiled
+func NewLoadedBug(snap *bug.Snapshot) *Bug {
+	return &Bug{loaded: &loadedBug{snap: snap}}
+}
+
+func newBug(repo *cache.RepoCache, loader *Loader, excerpt *cache.BugExcerpt) *Bug {
+	return &Bug{
+		repo:    repo,
+		loader:  loader,
+		excerpt: excerpt,
+		loaded:  &loadedBug{},
+	}
+}
+
+func (b *Bug) load() (*bug.Snapshot, error) {
+	b.loaded.mu.Lock()
+	defer b.loaded.mu.Unlock()
+
+	if b.loaded.snap != nil {
+		return b.loaded.snap, nil
+	}
+
+	cached, err := b.repo.ResolveBug(b.excerpt.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	b.loaded.snap = cached.Snapshot()
+	return b.loaded.snap, nil
+}
+
+func (b *Bug) identities(ids []entity.Id) ([]identity.Interface, error) {
+	result := make([]identity.Interface, len(ids))
+	for i, id := range ids {
+		actor, err := b.loader.Identity(b.repo, id)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = actor
+	}
+	return result, nil
+}
+
+func (b *Bug) Id() entity.Id {
+	if b.excerpt == nil {
+		return b.loaded.snap.Id()
+	}
+	return b.excerpt.Id
+}
+
+func (b *Bug) Status() bug.Status {
+	if b.excerpt == nil {
+		return b.loaded.snap.Status
+	}
+	return b.excerpt.Status
+}
+
+func (b *Bug) Title() string {
+	if b.excerpt == nil {
+		return b.loaded.snap.Title
+	}
+	return b.excerpt.Title
+}
+
+func (b *Bug) Labels() []bug.Label {
+	if b.excerpt == nil {
+		return b.loaded.snap.Labels
+	}
+	return b.excerpt.Labels
+}
+
+func (b *Bug) Milestone() string {
+	if b.excerpt == nil {
+		return b.loaded.snap.Milestone
+	}
+	return b.excerpt.Milestone
+}
+
+func (b *Bug) Author() (identity.Interface, error) {
+	// the legacy authors are only fully available in the bug
+	if b.excerpt == nil || b.excerpt.AuthorId == "" {
+		snap, err := b.load()
+		if err != nil {
+			return nil, err
+		}
+		return snap.Author, nil
+	}
+
+	return b.loader.Identity(b.repo, b.excerpt.AuthorId)
+}
+
+func (b *Bug) CreatedAt() time.Time {
+	if b.excerpt == nil {
+		return b.loaded.snap.CreatedAt
+	}
+	return time.Unix(b.excerpt.CreateUnixTime, 0)
+}
+
+func (b *Bug) LastEdit() time.Time {
+	if b.excerpt == nil {
+		return b.loaded.snap.LastEditTime()
+	}
+	return time.Unix(b.excerpt.EditUnixTime, 0)
+}
+
+func (b *Bug) Actors() ([]identity.Interface, error) {
+	if b.excerpt == nil {
+		return b.loaded.snap.Actors, nil
+	}
+	return b.identities(b.excerpt.Actors)
+}
+
+func (b *Bug) Participants() ([]identity.Interface, error) {
+	if b.excerpt == nil {
+		return b.loaded.snap.Participants, nil
+	}
+	return b.identities(b.excerpt.Participants)
+}
+
+func (b *Bug) Comments() ([]bug.Comment, error) {
+	snap, err := b.load()
+	if err != nil {
+		return nil, err
+	}
+	return snap.Comments, nil
+}
+
+func (b *Bug) Timeline() ([]bug.TimelineItem, error) {
+	snap, err := b.load()
+	if err != nil {
+		return nil, err
+	}
+	return snap.Timeline, nil
+}
+
+func (b *Bug) Operations() ([]bug.Operation, error) {
+	snap, err := b.load()
+	if err != nil {
+		return nil, err
+	}
+	return snap.Operations, nil
+}
+
+// Sign post method for gqlgen
+func (b *Bug) IsAuthored() {}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestLazyBug(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	backend, err := cache.NewRepoCache(repo)
+	require.NoError(t, err)
+
+	rene, err := backend.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+
+	b, _, err := backend.NewBugRaw(rene, 1000, "title", "message", nil, nil)
+	require.NoError(t, err)
+	_, err = b.AddCommentRaw(rene, 1001, "comment", nil, nil)
+	require.NoError(t, err)
+	_, _, err = b.ChangeLabelsRaw(rene, 1002, []string{"bug"}, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, b.Commit())
+	require.NoError(t, backend.Close())
+
+	// a new cache only knows the excerpts
+	backend, err = cache.NewRepoCache(repo)
+	require.NoError(t, err)
+	defer backend.Close()
+
+	excerpt, err := backend.ResolveBugExcerpt(b.Id())
+	require.NoError(t, err)
+
+	loader := NewLoader()
+	lazy := loader.Bug(backend, excerpt)
+
+	// the same bug is shared within a request
+	require.True(t, lazy == loader.Bug(backend, excerpt))
+
+	require.Equal(t, b.Id(), lazy.Id())
+	require.Equal(t, "title", lazy.Title())
+	require.Equal(t, bug.OpenStatus, lazy.Status())
+	require.Equal(t, []bug.Label{"bug"}, lazy.Labels())
+	require.Equal(t, int64(1000), lazy.CreatedAt().Unix())
+	require.Equal(t, int64(1002), lazy.LastEdit().Unix())
+
+	author, err := lazy.Author()
+	require.NoError(t, err)
+	require.Equal(t, rene.Id(), author.Id())
+
+	participants, err := lazy.Participants()
+	require.NoError(t, err)
+	require.Len(t, participants, 1)
+
+	require.Nil(t, lazy.loaded.snap, "the excerpt should be enough")
+
+	comments, err := lazy.Comments()
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	require.NotNil(t, lazy.loaded.snap)
+
+	// a loaded bug answer the same
+	loaded := NewLoadedBug(lazy.loaded.snap)
+	require.Equal(t, lazy.Title(), loaded.Title())
+	require.Equal(t, lazy.CreatedAt().Unix(), loaded.CreatedAt().Unix())
+	require.Equal(t, lazy.LastEdit().Unix(), loaded.LastEdit().Unix())
+
+	loadedAuthor, err := loaded.Author()
+	require.NoError(t, err)
+	require.Equal(t, author.Id(), loadedAuthor.Id())
+}
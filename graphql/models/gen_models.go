@@ -34,7 +34,7 @@ type AddCommentPayload struct {
 	// A unique identifier for the client performing the mutation.
 	ClientMutationID *string `json:"clientMutationId"`
 	// The affected bug.
-	Bug *bug.Snapshot `json:"bug"`
+	Bug *Bug `json:"bug"`
 	// The resulting operation.
 	Operation *bug.AddCommentOperation `json:"operation"`
 }
@@ -42,8 +42,8 @@ type AddCommentPayload struct {
 // The connection type for Bug.
 type BugConnection struct {
 	// A list of edges.
-	Edges []*BugEdge      `json:"edges"`
-	Nodes []*bug.Snapshot `json:"nodes"`
+	Edges []*BugEdge `json:"edges"`
+	Nodes []*Bug     `json:"nodes"`
 	// Information to aid in pagination.
 	PageInfo *PageInfo `json:"pageInfo"`
 	// Identifies the total count of items in the connection.
@@ -55,7 +55,15 @@ type BugEdge struct {
 	// A cursor for use in pagination.
 	Cursor string `json:"cursor"`
 	// The item at the end of the edge.
-	Node *bug.Snapshot `json:"node"`
+	Node *Bug `json:"node"`
+}
+
+// An ordering of the bugs.
+type BugOrder struct {
+	// The field to order the bugs by.
+	Field BugOrderField `json:"field"`
+	// The direction of the ordering. If not set, the default direction of the field is used.
+	Direction *OrderDirection `json:"direction"`
 }
 
 type ChangeLabelInput struct {
@@ -75,7 +83,7 @@ type ChangeLabelPayload struct {
 	// A unique identifier for the client performing the mutation.
 	ClientMutationID *string `json:"clientMutationId"`
 	// The affected bug.
-	Bug *bug.Snapshot `json:"bug"`
+	Bug *Bug `json:"bug"`
 	// The resulting operation.
 	Operation *bug.LabelChangeOperation `json:"operation"`
 	// The effect each source label had.
@@ -95,7 +103,7 @@ type CloseBugPayload struct {
 	// A unique identifier for the client performing the mutation.
 	ClientMutationID *string `json:"clientMutationId"`
 	// The affected bug.
-	Bug *bug.Snapshot `json:"bug"`
+	Bug *Bug `json:"bug"`
 	// The resulting operation.
 	Operation *bug.SetStatusOperation `json:"operation"`
 }
@@ -125,7 +133,7 @@ type CommitAsNeededPayload struct {
 	// A unique identifier for the client performing the mutation.
 	ClientMutationID *string `json:"clientMutationId"`
 	// The affected bug.
-	Bug *bug.Snapshot `json:"bug"`
+	Bug *Bug `json:"bug"`
 }
 
 type CommitInput struct {
@@ -141,7 +149,29 @@ type CommitPayload struct {
 	// A unique identifier for the client performing the mutation.
 	ClientMutationID *string `json:"clientMutationId"`
 	// The affected bug.
-	Bug *bug.Snapshot `json:"bug"`
+	Bug *Bug `json:"bug"`
+}
+
+type EditCommentInput struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// "The name of the repository. If not set, the default repository is used.
+	RepoRef *string `json:"repoRef"`
+	// The bug ID's prefix.
+	Prefix string `json:"prefix"`
+	// The ID of the comment to be changed.
+	Target string `json:"target"`
+	// The new message of the comment.
+	Message string `json:"message"`
+}
+
+type EditCommentPayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// The affected bug.
+	Bug *Bug `json:"bug"`
+	// The resulting operation.
+	Operation *bug.EditCommentOperation `json:"operation"`
 }
 
 type IdentityConnection struct {
@@ -185,7 +215,7 @@ type NewBugPayload struct {
 	// A unique identifier for the client performing the mutation.
 	ClientMutationID *string `json:"clientMutationId"`
 	// The created bug.
-	Bug *bug.Snapshot `json:"bug"`
+	Bug *Bug `json:"bug"`
 	// The resulting operation.
 	Operation *bug.CreateOperation `json:"operation"`
 }
@@ -203,7 +233,7 @@ type OpenBugPayload struct {
 	// A unique identifier for the client performing the mutation.
 	ClientMutationID *string `json:"clientMutationId"`
 	// The affected bug.
-	Bug *bug.Snapshot `json:"bug"`
+	Bug *Bug `json:"bug"`
 	// The resulting operation.
 	Operation *bug.SetStatusOperation `json:"operation"`
 }
@@ -234,6 +264,54 @@ type PageInfo struct {
 	EndCursor string `json:"endCursor"`
 }
 
+// A named query saved in the repository configuration.
+type SavedQuery struct {
+	// The name of the query, usable as @name in another query.
+	Name string `json:"name"`
+	// The query itself.
+	Query string `json:"query"`
+}
+
+type SetAssigneeInput struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// "The name of the repository. If not set, the default repository is used.
+	RepoRef *string `json:"repoRef"`
+	// The bug ID's prefix.
+	Prefix string `json:"prefix"`
+	// The prefix of the ID of the identity to assign. If not set, the assignment is removed.
+	Assignee *string `json:"assignee"`
+}
+
+type SetAssigneePayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// The affected bug.
+	Bug *Bug `json:"bug"`
+	// The resulting operation.
+	Operation *bug.SetAssigneeOperation `json:"operation"`
+}
+
+type SetMilestoneInput struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// "The name of the repository. If not set, the default repository is used.
+	RepoRef *string `json:"repoRef"`
+	// The bug ID's prefix.
+	Prefix string `json:"prefix"`
+	// The new milestone. An empty milestone remove the bug from its milestone.
+	Milestone string `json:"milestone"`
+}
+
+type SetMilestonePayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// The affected bug.
+	Bug *Bug `json:"bug"`
+	// The resulting operation.
+	Operation *bug.SetMilestoneOperation `json:"operation"`
+}
+
 type SetTitleInput struct {
 	// A unique identifier for the client performing the mutation.
 	ClientMutationID *string `json:"clientMutationId"`
@@ -249,11 +327,27 @@ type SetTitlePayload struct {
 	// A unique identifier for the client performing the mutation.
 	ClientMutationID *string `json:"clientMutationId"`
 	// The affected bug.
-	Bug *bug.Snapshot `json:"bug"`
+	Bug *Bug `json:"bug"`
 	// The resulting operation
 	Operation *bug.SetTitleOperation `json:"operation"`
 }
 
+type SetUserIdentityInput struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// "The name of the repository. If not set, the default repository is used.
+	RepoRef *string `json:"repoRef"`
+	// The identity ID's prefix.
+	Prefix string `json:"prefix"`
+}
+
+type SetUserIdentityPayload struct {
+	// A unique identifier for the client performing the mutation.
+	ClientMutationID *string `json:"clientMutationId"`
+	// The identity now used as the user's own.
+	Identity identity.Interface `json:"identity"`
+}
+
 // The connection type for TimelineItem
 type TimelineItemConnection struct {
 	Edges      []*TimelineItemEdge `json:"edges"`
@@ -268,6 +362,59 @@ type TimelineItemEdge struct {
 	Node   bug.TimelineItem `json:"node"`
 }
 
+// The fields the bugs can be ordered by.
+type BugOrderField string
+
+const (
+	// The creation time of the bugs, newest first by default.
+	BugOrderFieldCreation BugOrderField = "CREATION"
+	// The last edition time of the bugs, most recent first by default.
+	BugOrderFieldEdit BugOrderField = "EDIT"
+	// The identifier of the bugs, ascending by default.
+	BugOrderFieldID BugOrderField = "ID"
+	// The title of the bugs, alphabetically by default.
+	BugOrderFieldTitle BugOrderField = "TITLE"
+	// The number of comments of the bugs, most commented first by default.
+	BugOrderFieldComments BugOrderField = "COMMENTS"
+)
+
+var AllBugOrderField = []BugOrderField{
+	BugOrderFieldCreation,
+	BugOrderFieldEdit,
+	BugOrderFieldID,
+	BugOrderFieldTitle,
+	BugOrderFieldComments,
+}
+
+func (e BugOrderField) IsValid() bool {
+	switch e {
+	case BugOrderFieldCreation, BugOrderFieldEdit, BugOrderFieldID, BugOrderFieldTitle, BugOrderFieldComments:
+		return true
+	}
+	return false
+}
+
+func (e BugOrderField) String() string {
+	return string(e)
+}
+
+func (e *BugOrderField) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = BugOrderField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid BugOrderField", str)
+	}
+	return nil
+}
+
+func (e BugOrderField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type LabelChangeStatus string
 
 const (
@@ -315,6 +462,48 @@ func (e LabelChangeStatus) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+// The direction of an ordering.
+type OrderDirection string
+
+const (
+	OrderDirectionAsc  OrderDirection = "ASC"
+	OrderDirectionDesc OrderDirection = "DESC"
+)
+
+var AllOrderDirection = []OrderDirection{
+	OrderDirectionAsc,
+	OrderDirectionDesc,
+}
+
+func (e OrderDirection) IsValid() bool {
+	switch e {
+	case OrderDirectionAsc, OrderDirectionDesc:
+		return true
+	}
+	return false
+}
+
+func (e OrderDirection) String() string {
+	return string(e)
+}
+
+func (e *OrderDirection) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrderDirection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrderDirection", str)
+	}
+	return nil
+}
+
+func (e OrderDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type Status string
 
 const (
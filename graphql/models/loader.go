@@ -0,0 +1,88 @@
+package models
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/identity"
+)
+
+type loaderCtxKey struct{}
+
+type loaderKey struct {
+	repo *cache.RepoCache
+	id   entity.Id
+}
+
+// Loader hold the bugs and identities resolved during a request. The same
+// bug, however many times it's part of the response, is only compiled once,
+// even if the cache evicted it in the meantime, and the identities are only
+// resolved once.
+type Loader struct {
+	mu         sync.Mutex
+	bugs       map[loaderKey]*Bug
+	identities map[loaderKey]identity.Interface
+}
+
+func NewLoader() *Loader {
+	return &Loader{
+		bugs:       make(map[loaderKey]*Bug),
+		identities: make(map[loaderKey]identity.Interface),
+	}
+}
+
+// WithLoader return a context holding a new Loader, for the duration of a
+// request
+func WithLoader(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loaderCtxKey{}, NewLoader())
+}
+
+// LoaderFor return the Loader of the request. Without one, a Loader is
+// created, which then only last for the caller.
+func LoaderFor(ctx context.Context) *Loader {
+	if loader, ok := ctx.Value(loaderCtxKey{}).(*Loader); ok {
+		return loader
+	}
+	return NewLoader()
+}
+
+// Bug return the bug of an excerpt, loaded only when and if needed
+func (l *Loader) Bug(repo *cache.RepoCache, excerpt *cache.BugExcerpt) *Bug {
+	key := loaderKey{repo: repo, id: excerpt.Id}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if b, ok := l.bugs[key]; ok {
+		return b
+	}
+
+	b := newBug(repo, l, excerpt)
+	l.bugs[key] = b
+	return b
+}
+
+// Identity resolve an identity
+func (l *Loader) Identity(repo *cache.RepoCache, id entity.Id) (identity.Interface, error) {
+	key := loaderKey{repo: repo, id: id}
+
+	l.mu.Lock()
+	i, ok := l.identities[key]
+	l.mu.Unlock()
+	if ok {
+		return i, nil
+	}
+
+	cached, err := repo.ResolveIdentity(id)
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	l.identities[key] = cached.Identity
+	l.mu.Unlock()
+
+	return cached.Identity, nil
+}
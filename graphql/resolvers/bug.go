@@ -2,7 +2,6 @@ package resolvers
 
 import (
 	"context"
-	"time"
 
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/graphql/connections"
@@ -15,19 +14,19 @@ var _ graph.BugResolver = &bugResolver{}
 
 type bugResolver struct{}
 
-func (bugResolver) ID(ctx context.Context, obj *bug.Snapshot) (string, error) {
+func (bugResolver) ID(ctx context.Context, obj *models.Bug) (string, error) {
 	return obj.Id().String(), nil
 }
 
-func (bugResolver) HumanID(ctx context.Context, obj *bug.Snapshot) (string, error) {
+func (bugResolver) HumanID(ctx context.Context, obj *models.Bug) (string, error) {
 	return obj.Id().Human(), nil
 }
 
-func (bugResolver) Status(ctx context.Context, obj *bug.Snapshot) (models.Status, error) {
-	return convertStatus(obj.Status)
+func (bugResolver) Status(ctx context.Context, obj *models.Bug) (models.Status, error) {
+	return convertStatus(obj.Status())
 }
 
-func (bugResolver) Comments(ctx context.Context, obj *bug.Snapshot, after *string, before *string, first *int, last *int) (*models.CommentConnection, error) {
+func (bugResolver) Comments(ctx context.Context, obj *models.Bug, after *string, before *string, first *int, last *int) (*models.CommentConnection, error) {
 	input := models.ConnectionInput{
 		Before: before,
 		After:  after,
@@ -55,10 +54,15 @@ func (bugResolver) Comments(ctx context.Context, obj *bug.Snapshot, after *strin
 		}, nil
 	}
 
-	return connections.CommentCon(obj.Comments, edger, conMaker, input)
+	source, err := obj.Comments()
+	if err != nil {
+		return nil, err
+	}
+
+	return connections.CommentCon(source, edger, conMaker, input)
 }
 
-func (bugResolver) Operations(ctx context.Context, obj *bug.Snapshot, after *string, before *string, first *int, last *int) (*models.OperationConnection, error) {
+func (bugResolver) Operations(ctx context.Context, obj *models.Bug, after *string, before *string, first *int, last *int) (*models.OperationConnection, error) {
 	input := models.ConnectionInput{
 		Before: before,
 		After:  after,
@@ -82,10 +86,15 @@ func (bugResolver) Operations(ctx context.Context, obj *bug.Snapshot, after *str
 		}, nil
 	}
 
-	return connections.OperationCon(obj.Operations, edger, conMaker, input)
+	source, err := obj.Operations()
+	if err != nil {
+		return nil, err
+	}
+
+	return connections.OperationCon(source, edger, conMaker, input)
 }
 
-func (bugResolver) Timeline(ctx context.Context, obj *bug.Snapshot, after *string, before *string, first *int, last *int) (*models.TimelineItemConnection, error) {
+func (bugResolver) Timeline(ctx context.Context, obj *models.Bug, after *string, before *string, first *int, last *int) (*models.TimelineItemConnection, error) {
 	input := models.ConnectionInput{
 		Before: before,
 		After:  after,
@@ -109,15 +118,15 @@ func (bugResolver) Timeline(ctx context.Context, obj *bug.Snapshot, after *strin
 		}, nil
 	}
 
-	return connections.TimelineItemCon(obj.Timeline, edger, conMaker, input)
-}
+	source, err := obj.Timeline()
+	if err != nil {
+		return nil, err
+	}
 
-func (bugResolver) LastEdit(ctx context.Context, obj *bug.Snapshot) (*time.Time, error) {
-	t := obj.LastEditTime()
-	return &t, nil
+	return connections.TimelineItemCon(source, edger, conMaker, input)
 }
 
-func (bugResolver) Actors(ctx context.Context, obj *bug.Snapshot, after *string, before *string, first *int, last *int) (*models.IdentityConnection, error) {
+func (bugResolver) Actors(ctx context.Context, obj *models.Bug, after *string, before *string, first *int, last *int) (*models.IdentityConnection, error) {
 	input := models.ConnectionInput{
 		Before: before,
 		After:  after,
@@ -141,10 +150,15 @@ func (bugResolver) Actors(ctx context.Context, obj *bug.Snapshot, after *string,
 		}, nil
 	}
 
-	return connections.IdentityCon(obj.Actors, edger, conMaker, input)
+	source, err := obj.Actors()
+	if err != nil {
+		return nil, err
+	}
+
+	return connections.IdentityCon(source, edger, conMaker, input)
 }
 
-func (bugResolver) Participants(ctx context.Context, obj *bug.Snapshot, after *string, before *string, first *int, last *int) (*models.IdentityConnection, error) {
+func (bugResolver) Participants(ctx context.Context, obj *models.Bug, after *string, before *string, first *int, last *int) (*models.IdentityConnection, error) {
 	input := models.ConnectionInput{
 		Before: before,
 		After:  after,
@@ -168,5 +182,10 @@ func (bugResolver) Participants(ctx context.Context, obj *bug.Snapshot, after *s
 		}, nil
 	}
 
-	return connections.IdentityCon(obj.Participants, edger, conMaker, input)
+	source, err := obj.Participants()
+	if err != nil {
+		return nil, err
+	}
+
+	return connections.IdentityCon(source, edger, conMaker, input)
 }
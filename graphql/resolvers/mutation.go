@@ -2,17 +2,25 @@ package resolvers
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/graphql/apitoken"
 	"github.com/MichaelMure/git-bug/graphql/graph"
 	"github.com/MichaelMure/git-bug/graphql/models"
+	"github.com/MichaelMure/git-bug/graphql/session"
 )
 
 var _ graph.MutationResolver = &mutationResolver{}
 
 type mutationResolver struct {
 	cache *cache.MultiRepoCache
+
+	// the identity bound to the mutations, if any
+	boundIdentity entity.Id
 }
 
 func (r mutationResolver) getRepo(ref *string) (*cache.RepoCache, error) {
@@ -23,20 +31,80 @@ func (r mutationResolver) getRepo(ref *string) (*cache.RepoCache, error) {
 	return r.cache.DefaultRepo()
 }
 
+// getAuthor return the identity authoring the mutations in a repository:
+// the identity of the API token of the request if any, or the one bound to
+// the server, or the one selected by the client, or the user identity.
+//
+// When the repository has an access control policy, only the identity of the
+// token or the bound one are trusted, as a client can select any identity.
+func (r mutationResolver) getAuthor(ctx context.Context, repo *cache.RepoCache) (*cache.IdentityCache, error) {
+	if token, ok := apitoken.FromContext(ctx); ok && token.Identity != "" {
+		return repo.ResolveIdentity(token.Identity)
+	}
+
+	if r.boundIdentity != "" {
+		return repo.ResolveIdentity(r.boundIdentity)
+	}
+
+	restricted, err := isRestricted(repo)
+	if err != nil {
+		return nil, err
+	}
+	if restricted {
+		return nil, errAuthorRequired
+	}
+
+	if s, ok := session.FromContext(ctx); ok && s.Identity() != "" {
+		return repo.ResolveIdentity(s.Identity())
+	}
+
+	return repo.GetUserIdentity()
+}
+
+// errAuthorRequired is returned when no trusted identity can author the
+// mutations of a repository with an access control policy
+var errAuthorRequired = fmt.Errorf("the repository has an access control policy, an API token with an identity or an identity bound by the server is required")
+
+// isRestricted tell if the repository has an access control policy
+func isRestricted(repo *cache.RepoCache) (bool, error) {
+	policy, err := repo.Policy()
+	if err != nil {
+		return false, err
+	}
+
+	return policy.Restricted(), nil
+}
+
+// checkPermission deny a mutation restricted by the access control policy of
+// the repository, see bug.Policy
+func checkPermission(repo *cache.RepoCache, perm bug.Permission, author *cache.IdentityCache) error {
+	policy, err := repo.Policy()
+	if err != nil {
+		return err
+	}
+
+	return policy.Check(perm, author.Id())
+}
+
 func (r mutationResolver) NewBug(ctx context.Context, input models.NewBugInput) (*models.NewBugPayload, error) {
 	repo, err := r.getRepo(input.RepoRef)
 	if err != nil {
 		return nil, err
 	}
 
-	b, op, err := repo.NewBugWithFiles(input.Title, input.Message, input.Files)
+	author, err := r.getAuthor(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	b, op, err := repo.NewBugRaw(author, time.Now().Unix(), input.Title, input.Message, input.Files, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	return &models.NewBugPayload{
 		ClientMutationID: input.ClientMutationID,
-		Bug:              b.Snapshot(),
+		Bug:              models.NewLoadedBug(b.Snapshot()),
 		Operation:        op,
 	}, nil
 }
@@ -52,14 +120,19 @@ func (r mutationResolver) AddComment(ctx context.Context, input models.AddCommen
 		return nil, err
 	}
 
-	op, err := b.AddCommentWithFiles(input.Message, input.Files)
+	author, err := r.getAuthor(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := b.AddCommentRaw(author, time.Now().Unix(), input.Message, input.Files, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	return &models.AddCommentPayload{
 		ClientMutationID: input.ClientMutationID,
-		Bug:              b.Snapshot(),
+		Bug:              models.NewLoadedBug(b.Snapshot()),
 		Operation:        op,
 	}, nil
 }
@@ -75,7 +148,17 @@ func (r mutationResolver) ChangeLabels(ctx context.Context, input *models.Change
 		return nil, err
 	}
 
-	results, op, err := b.ChangeLabels(input.Added, input.Removed)
+	author, err := r.getAuthor(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkPermission(repo, bug.PermissionLabel, author)
+	if err != nil {
+		return nil, err
+	}
+
+	results, op, err := b.ChangeLabelsRaw(author, time.Now().Unix(), input.Added, input.Removed, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -87,7 +170,7 @@ func (r mutationResolver) ChangeLabels(ctx context.Context, input *models.Change
 
 	return &models.ChangeLabelPayload{
 		ClientMutationID: input.ClientMutationID,
-		Bug:              b.Snapshot(),
+		Bug:              models.NewLoadedBug(b.Snapshot()),
 		Operation:        op,
 		Results:          resultsPtr,
 	}, nil
@@ -104,14 +187,24 @@ func (r mutationResolver) OpenBug(ctx context.Context, input models.OpenBugInput
 		return nil, err
 	}
 
-	op, err := b.Open()
+	author, err := r.getAuthor(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkPermission(repo, bug.PermissionClose, author)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := b.OpenRaw(author, time.Now().Unix(), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	return &models.OpenBugPayload{
 		ClientMutationID: input.ClientMutationID,
-		Bug:              b.Snapshot(),
+		Bug:              models.NewLoadedBug(b.Snapshot()),
 		Operation:        op,
 	}, nil
 }
@@ -127,14 +220,24 @@ func (r mutationResolver) CloseBug(ctx context.Context, input models.CloseBugInp
 		return nil, err
 	}
 
-	op, err := b.Close()
+	author, err := r.getAuthor(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkPermission(repo, bug.PermissionClose, author)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := b.CloseRaw(author, time.Now().Unix(), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	return &models.CloseBugPayload{
 		ClientMutationID: input.ClientMutationID,
-		Bug:              b.Snapshot(),
+		Bug:              models.NewLoadedBug(b.Snapshot()),
 		Operation:        op,
 	}, nil
 }
@@ -150,18 +253,166 @@ func (r mutationResolver) SetTitle(ctx context.Context, input models.SetTitleInp
 		return nil, err
 	}
 
-	op, err := b.SetTitle(input.Title)
+	author, err := r.getAuthor(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := b.SetTitleRaw(author, time.Now().Unix(), input.Title, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	return &models.SetTitlePayload{
 		ClientMutationID: input.ClientMutationID,
-		Bug:              b.Snapshot(),
+		Bug:              models.NewLoadedBug(b.Snapshot()),
 		Operation:        op,
 	}, nil
 }
 
+func (r mutationResolver) EditComment(ctx context.Context, input models.EditCommentInput) (*models.EditCommentPayload, error) {
+	repo, err := r.getRepo(input.RepoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := repo.ResolveBugPrefix(input.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := r.getAuthor(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := repo.Policy()
+	if err != nil {
+		return nil, err
+	}
+
+	err = policy.CheckCommentEdition(b.Snapshot(), entity.Id(input.Target), author.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := b.EditCommentRaw(author, time.Now().Unix(), entity.Id(input.Target), input.Message, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.EditCommentPayload{
+		ClientMutationID: input.ClientMutationID,
+		Bug:              models.NewLoadedBug(b.Snapshot()),
+		Operation:        op,
+	}, nil
+}
+
+func (r mutationResolver) SetAssignee(ctx context.Context, input models.SetAssigneeInput) (*models.SetAssigneePayload, error) {
+	repo, err := r.getRepo(input.RepoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := repo.ResolveBugPrefix(input.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var assignee *cache.IdentityCache
+	if input.Assignee != nil {
+		assignee, err = repo.ResolveIdentityPrefix(*input.Assignee)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	author, err := r.getAuthor(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := b.SetAssigneeRaw(author, time.Now().Unix(), assignee, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SetAssigneePayload{
+		ClientMutationID: input.ClientMutationID,
+		Bug:              models.NewLoadedBug(b.Snapshot()),
+		Operation:        op,
+	}, nil
+}
+
+func (r mutationResolver) SetMilestone(ctx context.Context, input models.SetMilestoneInput) (*models.SetMilestonePayload, error) {
+	repo, err := r.getRepo(input.RepoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := repo.ResolveBugPrefix(input.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	author, err := r.getAuthor(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := b.SetMilestoneRaw(author, time.Now().Unix(), input.Milestone, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SetMilestonePayload{
+		ClientMutationID: input.ClientMutationID,
+		Bug:              models.NewLoadedBug(b.Snapshot()),
+		Operation:        op,
+	}, nil
+}
+
+func (r mutationResolver) SetUserIdentity(ctx context.Context, input models.SetUserIdentityInput) (*models.SetUserIdentityPayload, error) {
+	if r.boundIdentity != "" {
+		return nil, fmt.Errorf("the identity is bound by the server and can't be changed")
+	}
+
+	if _, ok := apitoken.FromContext(ctx); ok {
+		return nil, fmt.Errorf("the identity can't be changed with an API token")
+	}
+
+	repo, err := r.getRepo(input.RepoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	restricted, err := isRestricted(repo)
+	if err != nil {
+		return nil, err
+	}
+	if restricted {
+		return nil, fmt.Errorf("the identity can't be selected in a repository with an access control policy")
+	}
+
+	s, ok := session.FromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("the identity can't be selected without a session")
+	}
+
+	i, err := repo.ResolveIdentityPrefix(input.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	// only for this client, the user identity of the repository is left as is
+	s.SetIdentity(i.Id())
+
+	return &models.SetUserIdentityPayload{
+		ClientMutationID: input.ClientMutationID,
+		Identity:         i.Identity,
+	}, nil
+}
+
 func (r mutationResolver) Commit(ctx context.Context, input models.CommitInput) (*models.CommitPayload, error) {
 	repo, err := r.getRepo(input.RepoRef)
 	if err != nil {
@@ -180,7 +431,7 @@ func (r mutationResolver) Commit(ctx context.Context, input models.CommitInput)
 
 	return &models.CommitPayload{
 		ClientMutationID: input.ClientMutationID,
-		Bug:              b.Snapshot(),
+		Bug:              models.NewLoadedBug(b.Snapshot()),
 	}, nil
 }
 
@@ -202,6 +453,6 @@ func (r mutationResolver) CommitAsNeeded(ctx context.Context, input models.Commi
 
 	return &models.CommitAsNeededPayload{
 		ClientMutationID: input.ClientMutationID,
-		Bug:              b.Snapshot(),
+		Bug:              models.NewLoadedBug(b.Snapshot()),
 	}, nil
 }
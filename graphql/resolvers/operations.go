@@ -96,6 +96,40 @@ func (setTitleOperationResolver) Date(ctx context.Context, obj *bug.SetTitleOper
 	return &t, nil
 }
 
+var _ graph.SetAssigneeOperationResolver = setAssigneeOperationResolver{}
+
+type setAssigneeOperationResolver struct{}
+
+func (setAssigneeOperationResolver) ID(ctx context.Context, obj *bug.SetAssigneeOperation) (string, error) {
+	return obj.Id().String(), nil
+}
+
+func (setAssigneeOperationResolver) Date(ctx context.Context, obj *bug.SetAssigneeOperation) (*time.Time, error) {
+	t := obj.Time()
+	return &t, nil
+}
+
+func (setAssigneeOperationResolver) Assignee(ctx context.Context, obj *bug.SetAssigneeOperation) (*string, error) {
+	if obj.Assignee == "" {
+		return nil, nil
+	}
+	assignee := obj.Assignee.String()
+	return &assignee, nil
+}
+
+var _ graph.SetMilestoneOperationResolver = setMilestoneOperationResolver{}
+
+type setMilestoneOperationResolver struct{}
+
+func (setMilestoneOperationResolver) ID(ctx context.Context, obj *bug.SetMilestoneOperation) (string, error) {
+	return obj.Id().String(), nil
+}
+
+func (setMilestoneOperationResolver) Date(ctx context.Context, obj *bug.SetMilestoneOperation) (*time.Time, error) {
+	t := obj.Time()
+	return &t, nil
+}
+
 func convertStatus(status bug.Status) (models.Status, error) {
 	switch status {
 	case bug.OpenStatus:
@@ -2,31 +2,32 @@ package resolvers
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/graphql/apitoken"
 	"github.com/MichaelMure/git-bug/graphql/connections"
 	"github.com/MichaelMure/git-bug/graphql/graph"
 	"github.com/MichaelMure/git-bug/graphql/models"
+	"github.com/MichaelMure/git-bug/graphql/session"
 	"github.com/MichaelMure/git-bug/identity"
 )
 
 var _ graph.RepositoryResolver = &repoResolver{}
 
-type repoResolver struct{}
-
-func (repoResolver) AllBugs(ctx context.Context, obj *models.Repository, after *string, before *string, first *int, last *int, queryStr *string) (*models.BugConnection, error) {
-	input := models.ConnectionInput{
-		Before: before,
-		After:  after,
-		First:  first,
-		Last:   last,
-	}
+type repoResolver struct {
+	// the identity bound to the mutations, if any
+	boundIdentity entity.Id
+}
 
+func (repoResolver) AllBugs(ctx context.Context, obj *models.Repository, after *string, before *string, first *int, last *int, queryStr *string, orderBy *models.BugOrder) (*models.BugConnection, error) {
 	var query *cache.Query
 	if queryStr != nil {
-		query2, err := cache.ParseQuery(*queryStr)
+		query2, err := obj.Repo.ParseQuery(*queryStr)
 		if err != nil {
 			return nil, err
 		}
@@ -35,57 +36,111 @@ func (repoResolver) AllBugs(ctx context.Context, obj *models.Repository, after *
 		query = cache.NewQuery()
 	}
 
-	// Simply pass a []string with the ids to the pagination algorithm
-	source := obj.Repo.QueryBugs(query)
+	if orderBy != nil {
+		sorting := strings.ToLower(orderBy.Field.String())
+		if orderBy.Direction != nil {
+			sorting += "-" + strings.ToLower(orderBy.Direction.String())
+		}
+		if err := query.ParseSorting(sorting); err != nil {
+			return nil, err
+		}
+	}
 
-	// The edger create a custom edge holding just the id
-	edger := func(id entity.Id, offset int) connections.Edge {
-		return connections.LazyBugEdge{
-			Id:     id,
-			Cursor: connections.OffsetToCursor(offset),
+	page, err := bugPagination(after, before, first, last)
+	if err != nil {
+		return nil, err
+	}
+
+	// the pagination is done by the cache, which avoid sorting all the bugs
+	result, err := obj.Repo.QueryBugsPage(query, page)
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]*models.BugEdge, len(result.Excerpts))
+	nodes := make([]*models.Bug, len(result.Excerpts))
+
+	// the bugs are only loaded if a field requires more than their excerpt
+	loader := models.LoaderFor(ctx)
+
+	for i, excerpt := range result.Excerpts {
+		b := loader.Bug(obj.Repo, excerpt)
+
+		edges[i] = &models.BugEdge{
+			Cursor: cache.BugCursor(excerpt.Id),
+			Node:   b,
 		}
+		nodes[i] = b
 	}
 
-	// The conMaker will finally load and compile bugs from git to replace the selected edges
-	conMaker := func(lazyBugEdges []*connections.LazyBugEdge, lazyNode []entity.Id, info *models.PageInfo, totalCount int) (*models.BugConnection, error) {
-		edges := make([]*models.BugEdge, len(lazyBugEdges))
-		nodes := make([]*bug.Snapshot, len(lazyBugEdges))
+	pageInfo := &models.PageInfo{
+		HasNextPage:     result.HasNextPage,
+		HasPreviousPage: result.HasPreviousPage,
+	}
 
-		for i, lazyBugEdge := range lazyBugEdges {
-			b, err := obj.Repo.ResolveBug(lazyBugEdge.Id)
+	if len(edges) > 0 {
+		pageInfo.StartCursor = edges[0].Cursor
+		pageInfo.EndCursor = edges[len(edges)-1].Cursor
+	}
 
-			if err != nil {
-				return nil, err
-			}
+	return &models.BugConnection{
+		Edges:      edges,
+		Nodes:      nodes,
+		PageInfo:   pageInfo,
+		TotalCount: result.TotalCount,
+	}, nil
+}
 
-			snap := b.Snapshot()
+// bugPagination convert the relay pagination arguments of a bug connection
+// into a cache pagination
+func bugPagination(after *string, before *string, first *int, last *int) (cache.Pagination, error) {
+	var page cache.Pagination
 
-			edges[i] = &models.BugEdge{
-				Cursor: lazyBugEdge.Cursor,
-				Node:   snap,
-			}
-			nodes[i] = snap
+	if first != nil && last != nil {
+		return page, fmt.Errorf("first and last can't be used together")
+	}
+
+	if after != nil {
+		id, err := cache.ParseBugCursor(*after)
+		if err != nil {
+			return page, err
 		}
+		page.After = id
+	}
 
-		return &models.BugConnection{
-			Edges:      edges,
-			Nodes:      nodes,
-			PageInfo:   info,
-			TotalCount: totalCount,
-		}, nil
+	if before != nil {
+		id, err := cache.ParseBugCursor(*before)
+		if err != nil {
+			return page, err
+		}
+		page.Before = id
+	}
+
+	switch {
+	case first != nil:
+		if *first < 0 {
+			return page, fmt.Errorf("first less than zero")
+		}
+		page.Limit = *first
+	case last != nil:
+		if *last < 0 {
+			return page, fmt.Errorf("last less than zero")
+		}
+		page.Limit = *last
+		page.Last = true
 	}
 
-	return connections.LazyBugCon(source, edger, conMaker, input)
+	return page, nil
 }
 
-func (repoResolver) Bug(ctx context.Context, obj *models.Repository, prefix string) (*bug.Snapshot, error) {
-	b, err := obj.Repo.ResolveBugPrefix(prefix)
+func (repoResolver) Bug(ctx context.Context, obj *models.Repository, prefix string) (*models.Bug, error) {
+	excerpt, err := obj.Repo.ResolveBugExcerptPrefix(prefix)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return b.Snapshot(), nil
+	return models.LoaderFor(ctx).Bug(obj.Repo, excerpt), nil
 }
 
 func (repoResolver) AllIdentities(ctx context.Context, obj *models.Repository, after *string, before *string, first *int, last *int) (*models.IdentityConnection, error) {
@@ -149,9 +204,51 @@ func (repoResolver) Identity(ctx context.Context, obj *models.Repository, prefix
 	return i.Identity, nil
 }
 
-func (repoResolver) UserIdentity(ctx context.Context, obj *models.Repository) (identity.Interface, error) {
+func (r repoResolver) UserIdentity(ctx context.Context, obj *models.Repository) (identity.Interface, error) {
+	if token, ok := apitoken.FromContext(ctx); ok && token.Identity != "" {
+		i, err := obj.Repo.ResolveIdentity(token.Identity)
+		if err != nil {
+			return nil, err
+		}
+		return i.Identity, nil
+	}
+
+	if r.boundIdentity != "" {
+		i, err := obj.Repo.ResolveIdentity(r.boundIdentity)
+		if err != nil {
+			return nil, err
+		}
+		return i.Identity, nil
+	}
+
+	// with an access control policy, the mutations are refused without a
+	// trusted identity
+	policy, err := obj.Repo.Policy()
+	if err != nil {
+		return nil, err
+	}
+	if policy.Restricted() {
+		return nil, nil
+	}
+
+	if s, ok := session.FromContext(ctx); ok && s.Identity() != "" {
+		i, err := obj.Repo.ResolveIdentity(s.Identity())
+		// the identity selected might be unknown in this repository
+		if err == identity.ErrIdentityNotExist {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return i.Identity, nil
+	}
+
 	i, err := obj.Repo.GetUserIdentity()
 
+	// the user has yet to choose an identity
+	if err == identity.ErrNoIdentitySet {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -185,3 +282,37 @@ func (resolver repoResolver) ValidLabels(ctx context.Context, obj *models.Reposi
 
 	return connections.LabelCon(obj.Repo.ValidLabels(), edger, conMaker, input)
 }
+
+func (repoResolver) SavedQueries(ctx context.Context, obj *models.Repository) ([]*models.SavedQuery, error) {
+	saved, err := obj.Repo.SavedQueries()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(saved))
+	for name := range saved {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]*models.SavedQuery, len(names))
+	for i, name := range names {
+		result[i] = &models.SavedQuery{
+			Name:  name,
+			Query: saved[name],
+		}
+	}
+
+	return result, nil
+}
+
+func (repoResolver) Labels(ctx context.Context, obj *models.Repository) ([]*cache.LabelCount, error) {
+	counts := obj.Repo.Labels()
+
+	result := make([]*cache.LabelCount, len(counts))
+	for i := range counts {
+		result[i] = &counts[i]
+	}
+
+	return result, nil
+}
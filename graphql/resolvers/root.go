@@ -3,6 +3,7 @@ package resolvers
 
 import (
 	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
 	"github.com/MichaelMure/git-bug/graphql/graph"
 )
 
@@ -10,6 +11,9 @@ var _ graph.ResolverRoot = &RootResolver{}
 
 type RootResolver struct {
 	cache.MultiRepoCache
+
+	// the identity bound to the mutations, if any
+	boundIdentity entity.Id
 }
 
 func NewRootResolver() *RootResolver {
@@ -18,6 +22,24 @@ func NewRootResolver() *RootResolver {
 	}
 }
 
+// BindIdentity make the identity matching the given prefix in the default
+// repository the author of all the mutations, instead of the user identity of
+// the repository
+func (r *RootResolver) BindIdentity(prefix string) error {
+	repo, err := r.DefaultRepo()
+	if err != nil {
+		return err
+	}
+
+	i, err := repo.ResolveIdentityPrefix(prefix)
+	if err != nil {
+		return err
+	}
+
+	r.boundIdentity = i.Id()
+	return nil
+}
+
 func (r RootResolver) Query() graph.QueryResolver {
 	return &rootQueryResolver{
 		cache: &r.MultiRepoCache,
@@ -26,12 +48,15 @@ func (r RootResolver) Query() graph.QueryResolver {
 
 func (r RootResolver) Mutation() graph.MutationResolver {
 	return &mutationResolver{
-		cache: &r.MultiRepoCache,
+		cache:         &r.MultiRepoCache,
+		boundIdentity: r.boundIdentity,
 	}
 }
 
-func (RootResolver) Repository() graph.RepositoryResolver {
-	return &repoResolver{}
+func (r RootResolver) Repository() graph.RepositoryResolver {
+	return &repoResolver{
+		boundIdentity: r.boundIdentity,
+	}
 }
 
 func (RootResolver) Bug() graph.BugResolver {
@@ -98,6 +123,14 @@ func (RootResolver) SetTitleOperation() graph.SetTitleOperationResolver {
 	return &setTitleOperationResolver{}
 }
 
+func (RootResolver) SetAssigneeOperation() graph.SetAssigneeOperationResolver {
+	return &setAssigneeOperationResolver{}
+}
+
+func (RootResolver) SetMilestoneOperation() graph.SetMilestoneOperationResolver {
+	return &setMilestoneOperationResolver{}
+}
+
 func (r RootResolver) LabelChangeResult() graph.LabelChangeResultResolver {
 	return &labelChangeResultResolver{}
 }
@@ -0,0 +1,52 @@
+package graphql
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/ast"
+
+	"github.com/MichaelMure/git-bug/graphql/graph"
+)
+
+// Schema return the SDL of the GraphQL schema, as built in the binary
+func Schema() string {
+	schema := graph.NewExecutableSchema(graph.Config{}).Schema()
+
+	// the schema only keep the parsed definitions, but they point to the
+	// files they come from
+	sources := make(map[string]*ast.Source)
+	add := func(pos *ast.Position) {
+		if pos != nil && pos.Src != nil && !pos.Src.BuiltIn {
+			sources[pos.Src.Name] = pos.Src
+		}
+	}
+	for _, def := range schema.Types {
+		add(def.Position)
+		for _, field := range def.Fields {
+			add(field.Position)
+		}
+	}
+	for _, directive := range schema.Directives {
+		add(directive.Position)
+	}
+
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb bytes.Buffer
+	for i, name := range names {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("# " + name + "\n")
+		sb.WriteString(strings.TrimSpace(sources[name].Input))
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
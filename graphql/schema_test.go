@@ -0,0 +1,22 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser"
+	"github.com/vektah/gqlparser/ast"
+)
+
+func TestSchema(t *testing.T) {
+	sdl := Schema()
+
+	// the printed schema is complete by itself
+	schema, err := gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: sdl})
+	require.Nil(t, err)
+
+	require.NotNil(t, schema.Query)
+	require.NotNil(t, schema.Mutation)
+	require.Contains(t, schema.Types, "Bug")
+	require.Contains(t, schema.Types, "TimelineItem")
+}
@@ -0,0 +1,87 @@
+// Package session keep the identity selected by a client of the GraphQL API,
+// so that each client author its changes with its own identity, without
+// changing the user identity of the repository shared with everyone.
+//
+// The identity is given by the client with a header, or kept in a cookie
+// for the browsers.
+package session
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+const (
+	// HeaderName is the header a client can give its identity with
+	HeaderName = "X-Git-Bug-Identity"
+
+	// CookieName is the cookie keeping the identity selected by a browser
+	CookieName = "git-bug-identity"
+)
+
+// Session is the identity selected by a client, if any
+type Session struct {
+	identity entity.Id
+
+	rw     http.ResponseWriter
+	secure bool
+}
+
+// Identity return the identity selected by the client, or an empty id
+func (s *Session) Identity() entity.Id {
+	return s.identity
+}
+
+// SetIdentity select the identity of the client, remembered in a cookie for
+// its next requests. It has to be called before the response is written.
+func (s *Session) SetIdentity(id entity.Id) {
+	s.identity = id
+
+	http.SetCookie(s.rw, &http.Cookie{
+		Name:     CookieName,
+		Value:    id.String(),
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   s.secure,
+	})
+}
+
+// Handler wrap a http.Handler to give the session of the requests in their
+// context
+func Handler(next http.Handler) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		s := &Session{
+			rw:     rw,
+			secure: r.TLS != nil,
+		}
+
+		var id entity.Id
+		if header := r.Header.Get(HeaderName); header != "" {
+			id = entity.Id(header)
+		} else if cookie, err := r.Cookie(CookieName); err == nil {
+			id = entity.Id(cookie.Value)
+		}
+
+		// a malformed id is ignored, as if nothing was selected
+		if id.Validate() == nil {
+			s.identity = id
+		}
+
+		next.ServeHTTP(rw, r.WithContext(WithSession(r.Context(), s)))
+	}
+}
+
+type sessionCtxKey struct{}
+
+// WithSession return a context holding the session of a request
+func WithSession(ctx context.Context, s *Session) context.Context {
+	return context.WithValue(ctx, sessionCtxKey{}, s)
+}
+
+// FromContext return the session of a request, if any
+func FromContext(ctx context.Context) (*Session, bool) {
+	s, ok := ctx.Value(sessionCtxKey{}).(*Session)
+	return s, ok
+}
@@ -129,6 +129,11 @@ func (i *Bare) ValidKeysAtTime(time lamport.Time) []Key {
 	return []Key{}
 }
 
+// AllKeys return all the keys ever declared, including the removed ones
+func (i *Bare) AllKeys() []Key {
+	return []Key{}
+}
+
 // DisplayName return a non-empty string to display, representing the
 // identity, based on the non-empty values.
 func (i *Bare) DisplayName() string {
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -22,6 +24,14 @@ const identityRemoteRefPattern = "refs/remotes/%s/identities/"
 const versionEntryName = "version"
 const identityConfigKey = "git-bug.identity"
 
+// the identities created or adopted by the user, among which the user
+// identity is chosen, as git-bug.own-identity.<id>.adopted=<unix time>
+const ownIdentityConfigPrefix = "git-bug.own-identity."
+const ownIdentityConfigKey = "adopted"
+
+// metadata key recording the identity an identity has been merged into
+const mergedIntoMetadataKey = "git-bug-merged-into"
+
 var ErrNonFastForwardMerge = errors.New("non fast-forward identity merge")
 var ErrNoIdentitySet = errors.New("to interact with bugs, an identity first needs to be created using \"git bug user create\" or \"git bug user adopt\"")
 var ErrMultipleIdentitiesSet = errors.New("multiple user identities set")
@@ -120,13 +130,24 @@ func read(repo repository.Repo, ref string) (*Identity, error) {
 			return nil, errors.Wrap(err, "can't list git tree entries")
 		}
 
-		if len(entries) != 1 {
+		var entry repository.TreeEntry
+		found := false
+		signatures := make(Signatures)
+
+		for _, e := range entries {
+			if e.Name == versionEntryName {
+				entry = e
+				found = true
+				continue
+			}
+			if fingerprint, ok := IsSignatureEntry(e); ok {
+				signatures[fingerprint] = e.Hash
+				continue
+			}
 			return nil, fmt.Errorf("invalid identity data at hash %s", hash)
 		}
 
-		entry := entries[0]
-
-		if entry.Name != versionEntryName {
+		if !found {
 			return nil, fmt.Errorf("invalid identity data at hash %s", hash)
 		}
 
@@ -144,6 +165,8 @@ func read(repo repository.Repo, ref string) (*Identity, error) {
 
 		// tag the version with the commit hash
 		version.commitHash = hash
+		version.blobHash = entry.Hash
+		version.signatures = signatures
 		i.lastCommit = hash
 
 		i.versions = append(i.versions, &version)
@@ -196,6 +219,36 @@ func readAllIdentities(repo repository.ClockedRepo, refPrefix string) <-chan Str
 	return out
 }
 
+// ListOrphanRemoteRefs list the remote-tracking refs of the identities of the
+// remotes that are not configured anymore. These refs keep the versions of the
+// identities alive in the git object store, while no pull can merge them
+// anymore. The refs of the configured remotes are never listed, as they hold
+// the identities fetched but not merged yet.
+func ListOrphanRemoteRefs(repo repository.Repo) ([]string, error) {
+	remotes, err := repo.GetRemotes()
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := repo.ListRefs("refs/remotes/")
+	if err != nil {
+		return nil, err
+	}
+
+	var orphans []string
+	for _, ref := range refs {
+		i := strings.LastIndex(ref, "/identities/")
+		if i < 0 {
+			continue
+		}
+		if _, ok := remotes[strings.TrimPrefix(ref[:i], "refs/remotes/")]; !ok {
+			orphans = append(orphans, ref)
+		}
+	}
+
+	return orphans, nil
+}
+
 // NewFromGitUser will query the repository for user detail and
 // build the corresponding Identity
 func NewFromGitUser(repo repository.Repo) (*Identity, error) {
@@ -232,11 +285,56 @@ func IsUserIdentitySet(repo repository.RepoCommon) (bool, error) {
 	return len(configs) == 1, nil
 }
 
-// SetUserIdentity store the user identity's id in the git config
+// SetUserIdentity store the user identity's id in the git config, and record
+// it as one of the user's own identities
 func SetUserIdentity(repo repository.RepoCommon, identity *Identity) error {
+	key := ownIdentityConfigPrefix + identity.Id().String() + "." + ownIdentityConfigKey
+	err := repo.StoreConfig(key, strconv.FormatInt(time.Now().Unix(), 10))
+	if err != nil {
+		return err
+	}
 	return repo.StoreConfig(identityConfigKey, identity.Id().String())
 }
 
+// OwnIdentities return the ids of the identities the user has created or
+// adopted in this repository, including the user identity
+func OwnIdentities(repo repository.RepoCommon) ([]entity.Id, error) {
+	configs, err := repo.ReadConfigs(ownIdentityConfigPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[entity.Id]bool)
+	var result []entity.Id
+
+	for key := range configs {
+		id := entity.Id(strings.TrimSuffix(strings.TrimPrefix(key, ownIdentityConfigPrefix), "."+ownIdentityConfigKey))
+		if id.Validate() == nil && !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+
+	// the user identity set before the own identities were recorded
+	current, err := repo.ReadConfigs(identityConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	for _, val := range current {
+		id := entity.Id(val)
+		if id.Validate() == nil && !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i] < result[j]
+	})
+
+	return result, nil
+}
+
 // GetUserIdentity read the current user identity, set with a git config entry
 func GetUserIdentity(repo repository.Repo) (*Identity, error) {
 	configs, err := repo.ReadConfigs(identityConfigKey)
@@ -269,6 +367,15 @@ func GetUserIdentity(repo repository.Repo) (*Identity, error) {
 		}
 		return nil, err
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	// the operations are only authored by the identity the user chose, not
+	// by the one it has been merged into, possibly by someone else
+	if into := i.MergedInto(); into != "" {
+		return nil, fmt.Errorf("your identity %s has been merged into %s, choose the identity to use with \"git bug user switch\"", id.Human(), into.Human())
+	}
 
 	return i, nil
 }
@@ -290,7 +397,7 @@ func (i *Identity) Commit(repo repository.ClockedRepo) error {
 		return errors.Wrap(err, "can't commit an identity with invalid data")
 	}
 
-	for _, v := range i.versions {
+	for j, v := range i.versions {
 		if v.commitHash != "" {
 			i.lastCommit = v.commitHash
 			// ignore already commit versions
@@ -311,6 +418,21 @@ func (i *Identity) Commit(repo repository.ClockedRepo) error {
 			{ObjectType: repository.Blob, Hash: blobHash, Name: versionEntryName},
 		}
 
+		// once protected, a new version must be signed with one of the
+		// keys of the previous version
+		v.blobHash = blobHash
+		v.signatures = make(Signatures)
+		if j > 0 && len(i.versions[j-1].keys) > 0 {
+			signature, err := signVersion(repo, i.versions[j-1].keys, blobHash)
+			if err != nil {
+				return err
+			}
+			tree = append(tree, signature)
+
+			fingerprint, _ := IsSignatureEntry(signature)
+			v.signatures[fingerprint] = signature.Hash
+		}
+
 		treeHash, err := repo.StoreTree(tree)
 		if err != nil {
 			return err
@@ -460,6 +582,12 @@ func (i *Identity) lastVersion() *Version {
 	return i.versions[len(i.versions)-1]
 }
 
+// Versions return all the successive versions of the identity, the oldest
+// first
+func (i *Identity) Versions() []*Version {
+	return i.versions
+}
+
 // Id return the Identity identifier
 func (i *Identity) Id() entity.Id {
 	if i.id == "" {
@@ -510,6 +638,24 @@ func (i *Identity) ValidKeysAtTime(time lamport.Time) []Key {
 	return result
 }
 
+// AllKeys return all the keys ever declared, including the removed ones
+func (i *Identity) AllKeys() []Key {
+	var result []Key
+	seen := make(map[string]struct{})
+
+	for _, v := range i.versions {
+		for _, key := range v.keys {
+			if _, ok := seen[key.Fingerprint]; ok {
+				continue
+			}
+			seen[key.Fingerprint] = struct{}{}
+			result = append(result, key)
+		}
+	}
+
+	return result
+}
+
 // DisplayName return a non-empty string to display, representing the
 // identity, based on the non-empty values.
 func (i *Identity) DisplayName() string {
@@ -528,8 +674,120 @@ func (i *Identity) DisplayName() string {
 // IsProtected return true if the chain of git commits started to be signed.
 // If that's the case, only signed commit with a valid key for this identity can be added.
 func (i *Identity) IsProtected() bool {
-	// Todo
-	return false
+	return len(i.Keys()) > 0
+}
+
+// AddKey declare a new key of the identity, in a new version to be committed.
+// From then on, what the identity authors must be signed with one of its keys.
+func (i *Identity) AddKey(key Key) error {
+	if err := key.Validate(); err != nil {
+		return err
+	}
+
+	keys := i.Keys()
+	for _, k := range keys {
+		if k.Fingerprint == key.Fingerprint {
+			return fmt.Errorf("key %s is already declared", key.Fingerprint)
+		}
+	}
+
+	i.nextVersion(append(keys[:len(keys):len(keys)], key))
+
+	return nil
+}
+
+// RemoveKey revoke a key of the identity, in a new version to be committed
+func (i *Identity) RemoveKey(fingerprint string) (Key, error) {
+	keys := i.Keys()
+	for j, k := range keys {
+		if k.Fingerprint != fingerprint {
+			continue
+		}
+
+		remaining := make([]Key, 0, len(keys)-1)
+		remaining = append(remaining, keys[:j]...)
+		remaining = append(remaining, keys[j+1:]...)
+		i.nextVersion(remaining)
+
+		return k, nil
+	}
+
+	return Key{}, fmt.Errorf("no key %s in the identity %s", fingerprint, i.Id().Human())
+}
+
+// Attach record that the identity is the one of some accounts of other
+// trackers, as immutable metadata (e.g. "github-login" --> "alice"), in a new
+// version to be committed
+func (i *Identity) Attach(metadata map[string]string) error {
+	immutable := i.ImmutableMetadata()
+
+	attached := make(map[string]string)
+	for key, value := range metadata {
+		current, ok := immutable[key]
+		switch {
+		case ok && current != value:
+			return fmt.Errorf("the identity %s is already attached to %s %s", i.Id().Human(), key, current)
+		case !ok:
+			attached[key] = value
+		}
+	}
+
+	if len(attached) == 0 {
+		return nil
+	}
+
+	version := i.nextVersion(i.Keys())
+	for key, value := range attached {
+		version.SetMetadata(key, value)
+	}
+
+	return nil
+}
+
+// nextVersion add a new version, copy of the last one with the given keys
+func (i *Identity) nextVersion(keys []Key) *Version {
+	last := i.lastVersion()
+	version := &Version{
+		name:      last.name,
+		email:     last.email,
+		login:     last.login,
+		avatarURL: last.avatarURL,
+		keys:      keys,
+	}
+	i.versions = append(i.versions, version)
+	return version
+}
+
+// verifySignatures check that the versions following a version declaring
+// keys are signed with one of them
+func (i *Identity) verifySignatures(repo repository.Repo) error {
+	for j := 1; j < len(i.versions); j++ {
+		keys := i.versions[j-1].keys
+		if len(keys) == 0 {
+			continue
+		}
+
+		v := i.versions[j]
+		if err := v.signatures.Verify(repo, keys, v.blobHash); err != nil {
+			return errors.Wrapf(err, "version %s", v.commitHash)
+		}
+	}
+
+	return nil
+}
+
+func signVersion(repo repository.Repo, keys []Key, blobHash git.Hash) (repository.TreeEntry, error) {
+	data, err := repo.ReadData(blobHash)
+	if err != nil {
+		return repository.TreeEntry{}, err
+	}
+
+	entry, err := SignEntry(repo, keys, data)
+	if err == ErrNoPrivateKey {
+		return repository.TreeEntry{}, fmt.Errorf("the identity is protected, but none of its keys is available in this repository to sign the change")
+	}
+
+	return entry, err
 }
 
 // LastModificationLamportTime return the Lamport time at which the last version of the identity became valid.
@@ -564,6 +822,33 @@ func (i *Identity) ImmutableMetadata() map[string]string {
 	return metadata
 }
 
+// MergeInto record that the identity represent the same person as another
+// identity, like an identity created by a bridge import and the user's own.
+// A new version is added, to be committed.
+func (i *Identity) MergeInto(other entity.Id) error {
+	if other == i.Id() {
+		return fmt.Errorf("can't merge an identity into itself")
+	}
+
+	last := i.lastVersion()
+	i.versions = append(i.versions, &Version{
+		name:      last.name,
+		email:     last.email,
+		login:     last.login,
+		avatarURL: last.avatarURL,
+		keys:      last.keys,
+		metadata:  map[string]string{mergedIntoMetadataKey: other.String()},
+	})
+
+	return nil
+}
+
+// MergedInto return the id of the identity this identity has been merged into,
+// or an empty id if it hasn't been merged
+func (i *Identity) MergedInto() entity.Id {
+	return entity.Id(i.MutableMetadata()[mergedIntoMetadataKey])
+}
+
 // MutableMetadata return all metadata for this Identity, accumulated from each Version.
 // If multiple value are found, the last defined takes precedence.
 func (i *Identity) MutableMetadata() map[string]string {
@@ -23,6 +23,16 @@ func Push(repo repository.Repo, remote string) (string, error) {
 	return repo.PushRefs(remote, identityRefPattern+"*")
 }
 
+// ConfigureRemote install the fetch refspec of the identities in the
+// configuration of a remote, so that a plain git fetch carry them as well. As
+// with Fetch, the fetched identities are not merged.
+func ConfigureRemote(repo repository.Repo, remote string) error {
+	remoteRefSpec := fmt.Sprintf(identityRemoteRefPattern, remote)
+	fetchRefSpec := fmt.Sprintf("%s*:%s*", identityRefPattern, remoteRefSpec)
+
+	return repo.AddRemoteFetchRefSpecs(remote, fetchRefSpec)
+}
+
 // Pull will do a Fetch + MergeAll
 // This function will return an error if a merge fail
 func Pull(repo repository.ClockedRepo, remote string) error {
@@ -80,6 +90,11 @@ func MergeAll(repo repository.ClockedRepo, remote string) <-chan entity.MergeRes
 				continue
 			}
 
+			if err := remoteIdentity.verifySignatures(repo); err != nil {
+				out <- entity.NewMergeInvalidStatus(id, errors.Wrap(err, "remote identity is not properly signed").Error())
+				continue
+			}
+
 			localRef := identityRefPattern + remoteIdentity.Id().String()
 			localExist, err := repo.RefExist(localRef)
 
@@ -72,6 +72,10 @@ func (IdentityStub) ValidKeysAtTime(time lamport.Time) []Key {
 	panic("identities needs to be properly loaded with identity.ReadLocal()")
 }
 
+func (IdentityStub) AllKeys() []Key {
+	panic("identities needs to be properly loaded with identity.ReadLocal()")
+}
+
 func (IdentityStub) DisplayName() string {
 	panic("identities needs to be properly loaded with identity.ReadLocal()")
 }
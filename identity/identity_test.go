@@ -13,6 +13,13 @@ import (
 func TestIdentityCommitLoad(t *testing.T) {
 	mockRepo := repository.NewMockRepoForTest()
 
+	// the versions following one with keys are signed
+	keyA := generateKey(t, mockRepo)
+	keyB := generateKey(t, mockRepo)
+	keyC := generateKey(t, mockRepo)
+	keyD := generateKey(t, mockRepo)
+	keyE := generateKey(t, mockRepo)
+
 	// single version
 
 	identity := &Identity{
@@ -45,7 +52,7 @@ func TestIdentityCommitLoad(t *testing.T) {
 				name:  "René Descartes",
 				email: "rene.descartes@example.com",
 				keys: []Key{
-					{PubKey: "pubkeyA"},
+					keyA,
 				},
 			},
 			{
@@ -53,7 +60,7 @@ func TestIdentityCommitLoad(t *testing.T) {
 				name:  "René Descartes",
 				email: "rene.descartes@example.com",
 				keys: []Key{
-					{PubKey: "pubkeyB"},
+					keyB,
 				},
 			},
 			{
@@ -61,7 +68,7 @@ func TestIdentityCommitLoad(t *testing.T) {
 				name:  "René Descartes",
 				email: "rene.descartes@example.com",
 				keys: []Key{
-					{PubKey: "pubkeyC"},
+					keyC,
 				},
 			},
 		},
@@ -84,7 +91,7 @@ func TestIdentityCommitLoad(t *testing.T) {
 		name:  "René Descartes",
 		email: "rene.descartes@example.com",
 		keys: []Key{
-			{PubKey: "pubkeyD"},
+			keyD,
 		},
 	})
 
@@ -93,7 +100,7 @@ func TestIdentityCommitLoad(t *testing.T) {
 		name:  "René Descartes",
 		email: "rene.descartes@example.com",
 		keys: []Key{
-			{PubKey: "pubkeyE"},
+			keyE,
 		},
 	})
 
@@ -29,6 +29,9 @@ type Interface interface {
 	// ValidKeysAtTime return the set of keys valid at a given lamport time
 	ValidKeysAtTime(time lamport.Time) []Key
 
+	// AllKeys return all the keys ever declared, including the removed ones
+	AllKeys() []Key
+
 	// DisplayName return a non-empty string to display, representing the
 	// identity, based on the non-empty values.
 	DisplayName() string
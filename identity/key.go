@@ -1,13 +1,138 @@
 package identity
 
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// the private keys are stored in the git config of the repository, which is
+// not shared, as git-bug.signing-key.<fingerprint>.private=<base64 seed>
+const signingKeyConfigPrefix = "git-bug.signing-key."
+const signingKeyConfigKey = "private"
+
+// the fingerprint is the start of the hash of the public key
+const fingerprintLength = 16
+
+var ErrNoPrivateKey = errors.New("none of the keys is available in this repository")
+
+// Key is an ed25519 public key of an identity. Once an identity declare keys,
+// what it authors must be signed with one of them.
 type Key struct {
-	// The GPG fingerprint of the key
+	// The fingerprint of the key
 	Fingerprint string `json:"fingerprint"`
-	PubKey      string `json:"pub_key"`
+	// The public key, base64 encoded
+	PubKey string `json:"pub_key"`
+}
+
+// GenerateKey create a new key, and store its private part in the repository
+func GenerateKey(repo repository.RepoCommon) (Key, error) {
+	pub, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return Key{}, err
+	}
+
+	key := Key{
+		Fingerprint: fingerprint(pub),
+		PubKey:      base64.StdEncoding.EncodeToString(pub),
+	}
+
+	seed := base64.StdEncoding.EncodeToString(private.Seed())
+	err = repo.StoreConfig(privateKeyConfigKey(key.Fingerprint), seed)
+	if err != nil {
+		return Key{}, errors.Wrap(err, "can't store the private key")
+	}
+
+	return key, nil
+}
+
+// HasPrivateKey tell if the private part of the key is available in the
+// repository, that is if it can be used to sign
+func HasPrivateKey(repo repository.RepoCommon, key Key) (bool, error) {
+	_, err := repo.ReadConfigString(privateKeyConfigKey(key.Fingerprint))
+	if err == repository.ErrNoConfigEntry {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RemovePrivateKey delete the private part of the key from the repository,
+// if present
+func RemovePrivateKey(repo repository.RepoCommon, key Key) error {
+	has, err := HasPrivateKey(repo, key)
+	if err != nil || !has {
+		return err
+	}
+	return repo.RmConfigs(signingKeyConfigPrefix + key.Fingerprint)
 }
 
 func (k *Key) Validate() error {
-	// Todo
+	pub, err := k.publicKey()
+	if err != nil {
+		return err
+	}
+
+	if k.Fingerprint != fingerprint(pub) {
+		return fmt.Errorf("fingerprint %s doesn't match the public key", k.Fingerprint)
+	}
 
 	return nil
 }
+
+// Sign return the signature of the data, made with the private part of the
+// key stored in the repository
+func (k *Key) Sign(repo repository.RepoCommon, data []byte) ([]byte, error) {
+	seed, err := repo.ReadConfigString(privateKeyConfigKey(k.Fingerprint))
+	if err == repository.ErrNoConfigEntry {
+		return nil, ErrNoPrivateKey
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(seed)
+	if err != nil || len(raw) != ed25519.SeedSize {
+		return nil, fmt.Errorf("invalid private key %s", k.Fingerprint)
+	}
+
+	return ed25519.Sign(ed25519.NewKeyFromSeed(raw), data), nil
+}
+
+// Verify tell if the signature of the data has been made with this key
+func (k *Key) Verify(data []byte, signature []byte) bool {
+	pub, err := k.publicKey()
+	if err != nil {
+		return false
+	}
+	return ed25519.Verify(pub, data, signature)
+}
+
+func (k *Key) publicKey() (ed25519.PublicKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(k.PubKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid public key")
+	}
+	if len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key size %d", len(raw))
+	}
+	return raw, nil
+}
+
+func fingerprint(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
+	return hex.EncodeToString(sum[:])[:fingerprintLength]
+}
+
+func privateKeyConfigKey(fingerprint string) string {
+	return signingKeyConfigPrefix + fingerprint + "." + signingKeyConfigKey
+}
@@ -0,0 +1,81 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func generateKey(t *testing.T, repo repository.RepoCommon) Key {
+	key, err := GenerateKey(repo)
+	require.NoError(t, err)
+	return key
+}
+
+func TestKey(t *testing.T) {
+	mockRepo := repository.NewMockRepoForTest()
+
+	key := generateKey(t, mockRepo)
+	require.NoError(t, key.Validate())
+
+	has, err := HasPrivateKey(mockRepo, key)
+	require.NoError(t, err)
+	require.True(t, has)
+
+	signature, err := key.Sign(mockRepo, []byte("data"))
+	require.NoError(t, err)
+	require.True(t, key.Verify([]byte("data"), signature))
+	require.False(t, key.Verify([]byte("other data"), signature))
+
+	other := generateKey(t, mockRepo)
+	require.False(t, other.Verify([]byte("data"), signature))
+
+	forged := Key{Fingerprint: other.Fingerprint, PubKey: key.PubKey}
+	require.Error(t, forged.Validate())
+
+	require.NoError(t, RemovePrivateKey(mockRepo, key))
+
+	has, err = HasPrivateKey(mockRepo, key)
+	require.NoError(t, err)
+	require.False(t, has)
+
+	_, err = key.Sign(mockRepo, []byte("data"))
+	require.Equal(t, ErrNoPrivateKey, err)
+}
+
+// Test that the changes of a protected identity are signed
+func TestIdentityProtected(t *testing.T) {
+	mockRepo := repository.NewMockRepoForTest()
+
+	identity := NewIdentity("René Descartes", "rene.descartes@example.com")
+	require.NoError(t, identity.Commit(mockRepo))
+	require.False(t, identity.IsProtected())
+
+	key := generateKey(t, mockRepo)
+	require.NoError(t, identity.AddKey(key))
+	require.Error(t, identity.AddKey(key))
+	require.NoError(t, identity.Commit(mockRepo))
+	require.True(t, identity.IsProtected())
+
+	// signed with the key
+	require.NoError(t, identity.MergeInto(entity.Id("0123456789abcdef")))
+	require.NoError(t, identity.Commit(mockRepo))
+
+	loaded, err := ReadLocal(mockRepo, identity.Id())
+	require.NoError(t, err)
+	require.Equal(t, identity, loaded)
+	require.NoError(t, loaded.verifySignatures(mockRepo))
+
+	// an unsigned change is rejected
+	loaded.versions[2].signatures = make(Signatures)
+	require.Error(t, loaded.verifySignatures(mockRepo))
+
+	// without the private key, the identity can't be changed anymore
+	require.NoError(t, RemovePrivateKey(mockRepo, key))
+	_, err = identity.RemoveKey(key.Fingerprint)
+	require.NoError(t, err)
+	require.Error(t, identity.Commit(mockRepo))
+}
@@ -0,0 +1,97 @@
+package identity
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/repository"
+	"github.com/MichaelMure/git-bug/util/git"
+)
+
+// the signatures of a git blob are stored in the git tree referencing it, as
+// signature-<fingerprint> entries
+const signatureEntryPrefix = "signature-"
+
+// Signatures are the signatures of a git blob, by key fingerprint
+type Signatures map[string]git.Hash
+
+// SignEntry sign the data with the first of the keys available in the
+// repository, and return the tree entry to store along the data
+func SignEntry(repo repository.Repo, keys []Key, data []byte) (repository.TreeEntry, error) {
+	for _, key := range keys {
+		signature, err := key.Sign(repo, data)
+		if err == ErrNoPrivateKey {
+			continue
+		}
+		if err != nil {
+			return repository.TreeEntry{}, err
+		}
+
+		hash, err := repo.StoreData(signature)
+		if err != nil {
+			return repository.TreeEntry{}, err
+		}
+
+		return repository.TreeEntry{
+			ObjectType: repository.Blob,
+			Hash:       hash,
+			Name:       signatureEntryPrefix + key.Fingerprint,
+		}, nil
+	}
+
+	return repository.TreeEntry{}, ErrNoPrivateKey
+}
+
+// IsSignatureEntry tell if a tree entry is a signature, and of which key
+func IsSignatureEntry(entry repository.TreeEntry) (string, bool) {
+	if !strings.HasPrefix(entry.Name, signatureEntryPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(entry.Name, signatureEntryPrefix), true
+}
+
+// Verify check that the blob is signed with one of the keys
+func (s Signatures) Verify(repo repository.Repo, keys []Key, blob git.Hash) error {
+	data, err := repo.ReadData(blob)
+	if err != nil {
+		return errors.Wrap(err, "failed to read git blob data")
+	}
+
+	return s.VerifyData(repo, keys, data)
+}
+
+// VerifyData check that the data is signed with one of the keys
+func (s Signatures) VerifyData(repo repository.Repo, keys []Key, data []byte) error {
+	for _, key := range keys {
+		hash, ok := s[key.Fingerprint]
+		if !ok {
+			continue
+		}
+
+		signature, err := repo.ReadData(hash)
+		if err != nil {
+			return errors.Wrap(err, "failed to read the signature")
+		}
+
+		if !key.Verify(data, signature) {
+			return fmt.Errorf("invalid signature of key %s", key.Fingerprint)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("not signed with one of the keys")
+}
+
+// ReadSignatures return the signatures among the entries of a git tree
+func ReadSignatures(entries []repository.TreeEntry) Signatures {
+	signatures := make(Signatures)
+	for _, entry := range entries {
+		if fingerprint, ok := IsSignatureEntry(entry); ok {
+			signatures[fingerprint] = entry.Hash
+		}
+	}
+	return signatures
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/MichaelMure/git-bug/util/git"
 	"github.com/MichaelMure/git-bug/util/lamport"
 	"github.com/MichaelMure/git-bug/util/text"
+	"github.com/MichaelMure/git-bug/util/timestamp"
 	"github.com/pkg/errors"
 )
 
@@ -43,6 +44,11 @@ type Version struct {
 
 	// Not serialized
 	commitHash git.Hash
+	blobHash   git.Hash
+
+	// the signatures of the version, required once the previous version
+	// declared keys
+	signatures Signatures
 }
 
 type VersionJSON struct {
@@ -186,6 +192,46 @@ func makeNonce(len int) []byte {
 	return result
 }
 
+// Name return the name of the version
+func (v *Version) Name() string {
+	return v.name
+}
+
+// Email return the email of the version
+func (v *Version) Email() string {
+	return v.email
+}
+
+// Login return the login of the version
+func (v *Version) Login() string {
+	return v.login
+}
+
+// AvatarUrl return the avatar URL of the version
+func (v *Version) AvatarUrl() string {
+	return v.avatarURL
+}
+
+// Keys return the keys valid from this version onward
+func (v *Version) Keys() []Key {
+	return v.keys
+}
+
+// Time return the lamport time at which the version became effective
+func (v *Version) Time() lamport.Time {
+	return v.time
+}
+
+// UnixTime return the timestamp at which the version became effective
+func (v *Version) UnixTime() timestamp.Timestamp {
+	return timestamp.Timestamp(v.unixTime)
+}
+
+// CommitHash return the hash of the git commit storing the version
+func (v *Version) CommitHash() git.Hash {
+	return v.commitHash
+}
+
 // SetMetadata store arbitrary metadata about a version or an Identity in general
 // If the Version has been commit to git already, it won't be overwritten.
 func (v *Version) SetMetadata(key string, value string) {
@@ -11,6 +11,7 @@ import (
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"sort"
 	"strings"
 
 	"github.com/MichaelMure/git-bug/repository"
@@ -193,15 +194,23 @@ const queryTemplate = `%s
 #
 # - status:open, status:closed
 # - author:<query>
+# - assignee:<query>
+# - milestone:<milestone>
+# - project:<project>, project:*
+# - created-before:<time>, created-after:<time>
+# - edited-before:<time>, edited-after:<time>
 # - title:<title>
 # - label:<label>
-# - no:label
+# - no:label, no:assignee, no:milestone, no:project
+# - @<name> to use a saved query
 #
 # Sorting
 #
 # - sort:id, sort:id-desc, sort:id-asc
 # - sort:creation, sort:creation-desc, sort:creation-asc
 # - sort:edit, sort:edit-desc, sort:edit-asc
+# - sort:title, sort:title-desc, sort:title-asc
+# - sort:comments, sort:comments-desc, sort:comments-asc
 #
 # Notes
 # 
@@ -212,8 +221,23 @@ const queryTemplate = `%s
 
 // QueryEditorInput will open the default editor in the terminal with a
 // template for the user to fill. The file is then processed to extract a query.
-func QueryEditorInput(repo repository.RepoCommon, preQuery string) (string, error) {
+// The saved queries, if any, are listed in the template.
+func QueryEditorInput(repo repository.RepoCommon, preQuery string, savedQueries map[string]string) (string, error) {
 	template := fmt.Sprintf(queryTemplate, preQuery)
+
+	if len(savedQueries) > 0 {
+		names := make([]string, 0, len(savedQueries))
+		for name := range savedQueries {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		template += "#\n# Saved queries\n#\n"
+		for _, name := range names {
+			template += fmt.Sprintf("# - @%s: %s\n", name, savedQueries[name])
+		}
+	}
+
 	raw, err := launchEditorWithTemplate(repo, messageFilename, template)
 
 	if err != nil {
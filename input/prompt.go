@@ -3,6 +3,7 @@ package input
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 )
@@ -42,3 +43,24 @@ func promptValue(name string, preValue string, required bool) (string, error) {
 		return line, nil
 	}
 }
+
+// PromptConfirm ask a yes/no question, the answer being no by default
+func PromptConfirm(question string) (bool, error) {
+	_, _ = fmt.Fprintf(os.Stderr, "%s [y/N]: ", question)
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err == io.EOF {
+		// not interactive
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
@@ -0,0 +1,92 @@
+package notifier
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+// describeAll describe the operations not authored by the given user
+func describeAll(ops []bug.Operation, user entity.Id) []string {
+	var result []string
+	for _, op := range ops {
+		if user != "" && op.GetAuthor().Id() == user {
+			continue
+		}
+		if description := describe(op); description != "" {
+			result = append(result, description)
+		}
+	}
+	return result
+}
+
+// describe an operation in a human readable way, or return an empty string
+// for the operations not worth notifying, like the metadata changes
+func describe(op bug.Operation) string {
+	author := op.GetAuthor().DisplayName()
+
+	switch op := op.(type) {
+	case *bug.CreateOperation:
+		return fmt.Sprintf("%s created the bug %q:\n%s", author, op.Title, indent(op.Message))
+	case *bug.AddCommentOperation:
+		return fmt.Sprintf("%s commented:\n%s", author, indent(op.Message))
+	case *bug.EditCommentOperation:
+		return fmt.Sprintf("%s edited a comment:\n%s", author, indent(op.Message))
+	case *bug.DeleteCommentOperation:
+		return fmt.Sprintf("%s deleted a comment", author)
+	case *bug.SetTitleOperation:
+		return fmt.Sprintf("%s changed the title from %q to %q", author, op.Was, op.Title)
+	case *bug.SetStatusOperation:
+		return fmt.Sprintf("%s %s the bug", author, op.Status.Action())
+	case *bug.LabelChangeOperation:
+		return fmt.Sprintf("%s %s", author, labelChange(op.Added, op.Removed))
+	case *bug.SetAssigneeOperation:
+		if op.Assignee == "" {
+			return fmt.Sprintf("%s removed the assignee", author)
+		}
+		return fmt.Sprintf("%s assigned the bug to %s", author, op.Assignee.Human())
+	case *bug.SetMilestoneOperation:
+		if op.Milestone == "" {
+			return fmt.Sprintf("%s removed the milestone", author)
+		}
+		return fmt.Sprintf("%s set the milestone to %q", author, op.Milestone)
+	case *bug.SetProjectOperation:
+		if op.Project == "" {
+			return fmt.Sprintf("%s removed the bug from its project", author)
+		}
+		return fmt.Sprintf("%s moved the bug to the project %q", author, op.Project)
+	case *bug.VoteOperation:
+		return fmt.Sprintf("%s voted %+d", author, op.Vote)
+	}
+
+	return ""
+}
+
+func labelChange(added []bug.Label, removed []bug.Label) string {
+	var parts []string
+	if len(added) > 0 {
+		parts = append(parts, "added "+quoteLabels(added))
+	}
+	if len(removed) > 0 {
+		parts = append(parts, "removed "+quoteLabels(removed))
+	}
+
+	if len(added)+len(removed) > 1 {
+		return strings.Join(parts, " and ") + " labels"
+	}
+	return strings.Join(parts, " and ") + " label"
+}
+
+func quoteLabels(labels []bug.Label) string {
+	quoted := make([]string, len(labels))
+	for i, l := range labels {
+		quoted[i] = fmt.Sprintf("%q", l.String())
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func indent(message string) string {
+	return "    " + strings.Replace(strings.TrimSpace(message), "\n", "\n    ", -1)
+}
@@ -0,0 +1,72 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// emailSink send the notifications by email through a SMTP server. The
+// connection is upgraded with STARTTLS when the server supports it.
+type emailSink struct {
+	server string
+	auth   smtp.Auth
+	from   string
+	to     []string
+
+	// sendMail is smtp.SendMail, replaced in the tests
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func newEmailSink(server string, user string, password string, from string, to []string) (*emailSink, error) {
+	host, _, err := net.SplitHostPort(server)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMTP server %s, expected host:port", server)
+	}
+
+	recipients := make([]string, 0, len(to))
+	for _, addr := range to {
+		addr = strings.TrimSpace(addr)
+		if addr != "" {
+			recipients = append(recipients, addr)
+		}
+	}
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("no recipient for the emails")
+	}
+
+	sink := &emailSink{
+		server:   server,
+		from:     from,
+		to:       recipients,
+		sendMail: smtp.SendMail,
+	}
+	if user != "" {
+		sink.auth = smtp.PlainAuth("", user, password, host)
+	}
+
+	return sink, nil
+}
+
+func (s *emailSink) Send(ctx context.Context, n *Notification) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var msg bytes.Buffer
+	_, _ = fmt.Fprintf(&msg, "From: %s\r\n", s.from)
+	_, _ = fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(s.to, ", "))
+	_, _ = fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", n.Subject()))
+	_, _ = fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	_, _ = fmt.Fprintf(&msg, "MIME-Version: 1.0\r\n")
+	_, _ = fmt.Fprintf(&msg, "Content-Type: text/plain; charset=utf-8\r\n")
+	_, _ = fmt.Fprintf(&msg, "\r\n")
+	_, _ = fmt.Fprintf(&msg, "%s\r\n", strings.Replace(n.Text(), "\n", "\r\n", -1))
+
+	return s.sendMail(s.server, s.auth, s.from, s.to, msg.Bytes())
+}
@@ -0,0 +1,225 @@
+// Package notifier dispatch the changes of the bugs to the users, through
+// sinks like a desktop notification, an email, a webhook or a chat message,
+// according to the rules of their subscriptions.
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// Notification describe the change of a bug
+type Notification struct {
+	Type  cache.BugChangeType
+	BugId entity.Id
+	Title string
+
+	// Changes describe the new operations of the bug, like "René Descartes
+	// closed the bug". Empty for a removed bug.
+	Changes []string
+
+	Time time.Time
+}
+
+// Subject is a single line summary of the notification
+func (n *Notification) Subject() string {
+	return fmt.Sprintf("[git-bug] %s %s: %s", n.BugId.Human(), n.Type, n.Title)
+}
+
+// Text is the complete description of the changes
+func (n *Notification) Text() string {
+	if len(n.Changes) == 0 {
+		return fmt.Sprintf("The bug %s was %s.", n.BugId.Human(), n.Type)
+	}
+	return strings.Join(n.Changes, "\n")
+}
+
+// Resolver return the current state of a bug
+type Resolver func(id entity.Id) (*bug.Snapshot, error)
+
+// RepoResolver read the bugs directly in the repository, which doesn't need
+// to lock it like a cache.
+func RepoResolver(repo repository.ClockedRepo) Resolver {
+	return func(id entity.Id) (*bug.Snapshot, error) {
+		b, err := bug.ReadLocalBug(repo, id)
+		if err != nil {
+			return nil, err
+		}
+		snap := b.Compile()
+		return &snap, nil
+	}
+}
+
+// CacheResolver read the bugs through a cache
+func CacheResolver(repo *cache.RepoCache) Resolver {
+	return func(id entity.Id) (*bug.Snapshot, error) {
+		b, err := repo.ResolveBug(id)
+		if err != nil {
+			return nil, err
+		}
+		return b.Snapshot(), nil
+	}
+}
+
+// Notifier turn the change events of the bugs, as produced by
+// cache.RepoCache.Subscribe or cache.BugWatcher, into notifications sent to
+// the subscriptions whose rules match.
+//
+// The notifier remember the last state of each bug it saw, to describe only
+// the new operations. When an already existing bug is updated for the first
+// time, only its last operation is described.
+type Notifier struct {
+	resolve Resolver
+	targets []target
+
+	// the last known state of the bugs, and the ids of their operations
+	// already described, as a snapshot can be updated in place by the cache
+	last map[entity.Id]*bug.Snapshot
+	seen map[entity.Id]map[entity.Id]bool
+}
+
+type target struct {
+	sub  *Subscription
+	sink Sink
+}
+
+// NewNotifier create a Notifier for the given subscriptions
+func NewNotifier(resolve Resolver, subs []*Subscription) (*Notifier, error) {
+	n := &Notifier{
+		resolve: resolve,
+		last:    make(map[entity.Id]*bug.Snapshot),
+		seen:    make(map[entity.Id]map[entity.Id]bool),
+	}
+
+	for _, sub := range subs {
+		sink, err := newSink(sub)
+		if err != nil {
+			return nil, fmt.Errorf("subscription %s: %v", sub.Name, err)
+		}
+		n.targets = append(n.targets, target{sub: sub, sink: sink})
+	}
+
+	return n, nil
+}
+
+// Run notify the events received until the channel is closed or the context
+// is done. The errors are passed to onError, and don't stop the notifier.
+func (n *Notifier) Run(ctx context.Context, events <-chan cache.BugChangeEvent, onError func(error)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := n.Notify(ctx, event); err != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// Notify send the notifications of a single event. A failing sink doesn't
+// prevent the others to be notified.
+func (n *Notifier) Notify(ctx context.Context, event cache.BugChangeEvent) error {
+	var snap *bug.Snapshot
+	var ops []bug.Operation
+
+	if event.Type == cache.BugRemoved {
+		snap = n.last[event.Id]
+		delete(n.last, event.Id)
+		delete(n.seen, event.Id)
+	} else {
+		var err error
+		snap, err = n.resolve(event.Id)
+		if err == bug.ErrBugNotExist {
+			// removed since, a removal event follow
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		ops = n.newOperations(event, snap)
+		n.last[event.Id] = snap
+	}
+
+	notification := Notification{
+		Type:  event.Type,
+		BugId: event.Id,
+		Time:  time.Now(),
+	}
+	if snap != nil {
+		notification.Title = snap.Title
+	}
+
+	var errs []string
+
+	for _, target := range n.targets {
+		changes := describeAll(ops, target.sub.Identity)
+
+		// nothing the user doesn't know already
+		if event.Type != cache.BugRemoved && len(changes) == 0 {
+			continue
+		}
+
+		if !target.sub.match(event.Type, snap) {
+			continue
+		}
+
+		// every sink get its own copy
+		sent := notification
+		sent.Changes = changes
+		if err := target.sink.Send(ctx, &sent); err != nil {
+			errs = append(errs, fmt.Sprintf("subscription %s: %v", target.sub.Name, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("notifying %s: %s", event.Id.Human(), strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// match tell if any rule of the subscription select a change
+func (s *Subscription) match(event cache.BugChangeType, snap *bug.Snapshot) bool {
+	for _, rule := range s.Rules {
+		if rule.match(s.Identity, event, snap) {
+			return true
+		}
+	}
+	return false
+}
+
+// newOperations return the operations of a bug not described yet, and mark
+// them as described
+func (n *Notifier) newOperations(event cache.BugChangeEvent, snap *bug.Snapshot) []bug.Operation {
+	seen, known := n.seen[event.Id]
+	if !known {
+		seen = make(map[entity.Id]bool, len(snap.Operations))
+		n.seen[event.Id] = seen
+	}
+
+	var result []bug.Operation
+	for _, op := range snap.Operations {
+		if !seen[op.Id()] {
+			seen[op.Id()] = true
+			result = append(result, op)
+		}
+	}
+
+	// the previous operations of an existing bug are unknown
+	if !known && event.Type != cache.BugCreated && len(result) > 0 {
+		return result[len(result)-1:]
+	}
+
+	return result
+}
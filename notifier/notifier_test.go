@@ -0,0 +1,170 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+type recordSink struct {
+	sent []Notification
+}
+
+func (s *recordSink) Send(ctx context.Context, n *Notification) error {
+	s.sent = append(s.sent, *n)
+	return nil
+}
+
+// next return the single notification received since the last call, if any
+func (s *recordSink) next(t *testing.T) *Notification {
+	defer func() { s.sent = nil }()
+
+	require.True(t, len(s.sent) <= 1, "more than one notification")
+	if len(s.sent) == 0 {
+		return nil
+	}
+	return &s.sent[0]
+}
+
+func TestNotifier(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	backend, err := cache.NewRepoCache(repo)
+	require.NoError(t, err)
+	defer backend.Close()
+
+	rene, err := backend.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	require.NoError(t, backend.SetUserIdentity(rene))
+	ada, err := backend.NewIdentity("Ada Lovelace", "ada@lovelace.org")
+	require.NoError(t, err)
+
+	n, err := NewNotifier(CacheResolver(backend), nil)
+	require.NoError(t, err)
+
+	sink := func(identity entity.Id, rules ...Rule) *recordSink {
+		s := &recordSink{}
+		n.targets = append(n.targets, target{
+			sub:  &Subscription{Name: "test", Identity: identity, Rules: rules},
+			sink: s,
+		})
+		return s
+	}
+
+	reneSink := sink(rene.Id(), RuleParticipating)
+	adaSink := sink(ada.Id(), RuleParticipating)
+	adaLabelSink := sink(ada.Id(), Rule("label:security"))
+	allSink := sink("", RuleAll)
+
+	events := backend.Subscribe()
+	notifyAll := func() {
+		for {
+			select {
+			case event := <-events:
+				require.NoError(t, n.Notify(context.Background(), event))
+			default:
+				return
+			}
+		}
+	}
+
+	// creation
+	b, _, err := backend.NewBug("title", "message")
+	require.NoError(t, err)
+	notifyAll()
+
+	require.Nil(t, reneSink.next(t), "own changes are not notified")
+	require.Nil(t, adaSink.next(t))
+	require.Nil(t, adaLabelSink.next(t))
+	created := allSink.next(t)
+	require.NotNil(t, created)
+	require.Equal(t, cache.BugCreated, created.Type)
+	require.Equal(t, "title", created.Title)
+	require.Equal(t, []string{"René Descartes created the bug \"title\":\n    message"}, created.Changes)
+
+	// a comment by someone else
+	_, err = b.AddCommentRaw(ada, time.Now().Unix(), "a comment", nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, b.Commit())
+	notifyAll()
+
+	commented := reneSink.next(t)
+	require.NotNil(t, commented)
+	require.Equal(t, cache.BugUpdated, commented.Type)
+	require.Equal(t, []string{"Ada Lovelace commented:\n    a comment"}, commented.Changes)
+	require.Nil(t, adaSink.next(t))
+	require.NotNil(t, allSink.next(t), "the commit doesn't notify twice")
+
+	// ada now participate
+	_, _, err = b.ChangeLabels([]string{"security"}, nil)
+	require.NoError(t, err)
+	notifyAll()
+
+	require.Nil(t, reneSink.next(t))
+	labeled := adaSink.next(t)
+	require.NotNil(t, labeled)
+	require.Equal(t, []string{"René Descartes added \"security\" label"}, labeled.Changes)
+	require.Equal(t, labeled.Changes, adaLabelSink.next(t).Changes)
+	require.NotNil(t, allSink.next(t))
+
+	// removal, matched with the last known state
+	require.NoError(t, b.Commit())
+	require.NoError(t, backend.RemoveBug(b.Id()))
+	notifyAll()
+
+	removed := reneSink.next(t)
+	require.NotNil(t, removed)
+	require.Equal(t, cache.BugRemoved, removed.Type)
+	require.Equal(t, "title", removed.Title)
+	require.Empty(t, removed.Changes)
+	require.NotNil(t, adaSink.next(t))
+	require.NotNil(t, allSink.next(t))
+}
+
+func TestNotifierExistingBug(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	backend, err := cache.NewRepoCache(repo)
+	require.NoError(t, err)
+	defer backend.Close()
+
+	rene, err := backend.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	require.NoError(t, backend.SetUserIdentity(rene))
+
+	b, _, err := backend.NewBug("title", "message")
+	require.NoError(t, err)
+	_, err = b.AddComment("first")
+	require.NoError(t, err)
+	require.NoError(t, b.Commit())
+
+	s := &recordSink{}
+	n, err := NewNotifier(CacheResolver(backend), nil)
+	require.NoError(t, err)
+	n.targets = []target{{sub: &Subscription{Name: "test", Rules: []Rule{RuleAll}}, sink: s}}
+
+	events := make(chan cache.BugChangeEvent, 1)
+
+	// the history of a bug existing before the notifier is not described
+	_, err = b.AddComment("second")
+	require.NoError(t, err)
+	events <- cache.BugChangeEvent{Type: cache.BugUpdated, Id: b.Id()}
+	close(events)
+
+	n.Run(context.Background(), events, func(err error) {
+		t.Fatal(err)
+	})
+
+	updated := s.next(t)
+	require.NotNil(t, updated)
+	require.Equal(t, []string{"René Descartes commented:\n    second"}, updated.Changes)
+	require.Equal(t, "[git-bug] "+b.Id().Human()+" updated: title", updated.Subject())
+}
@@ -0,0 +1,92 @@
+package notifier
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+// Rule select the bug changes notified to the user of a subscription
+type Rule string
+
+const (
+	// RuleAll match every change
+	RuleAll Rule = "all"
+	// RuleNew match the creation of a bug
+	RuleNew Rule = "new"
+	// RuleAuthor match the changes of the bugs created by the user
+	RuleAuthor Rule = "author"
+	// RuleAssigned match the changes of the bugs assigned to the user
+	RuleAssigned Rule = "assigned"
+	// RuleParticipating match the changes of the bugs the user created,
+	// commented or is assigned to
+	RuleParticipating Rule = "participating"
+
+	// rules of the form label:<name> match the changes of the bugs having this
+	// label
+	ruleLabelPrefix = "label:"
+)
+
+// ParseRule parse a single rule
+func ParseRule(s string) (Rule, error) {
+	switch Rule(s) {
+	case RuleAll, RuleNew, RuleAuthor, RuleAssigned, RuleParticipating:
+		return Rule(s), nil
+	}
+	if strings.HasPrefix(s, ruleLabelPrefix) && len(s) > len(ruleLabelPrefix) {
+		return Rule(s), nil
+	}
+	return "", fmt.Errorf("unknown rule %s, expected all, new, author, assigned, participating or label:<name>", s)
+}
+
+// ParseRules parse a comma separated list of rules
+func ParseRules(s string) ([]Rule, error) {
+	var rules []Rule
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		rule, err := ParseRule(field)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// match tell if the rule select a change of a bug for a user. The snapshot is
+// the current state of the bug, or its last known state if it was removed,
+// and can be nil if the bug was never seen.
+func (r Rule) match(user entity.Id, event cache.BugChangeType, snap *bug.Snapshot) bool {
+	if r == RuleAll {
+		return true
+	}
+	if r == RuleNew {
+		return event == cache.BugCreated
+	}
+	if snap == nil {
+		return false
+	}
+
+	switch r {
+	case RuleAuthor:
+		return snap.Author.Id() == user
+	case RuleAssigned:
+		return snap.Assignee == user
+	case RuleParticipating:
+		return snap.Author.Id() == user || snap.Assignee == user || snap.HasParticipant(user)
+	}
+
+	label := bug.Label(strings.TrimPrefix(string(r), ruleLabelPrefix))
+	for _, l := range snap.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// SinkKind is the kind of destination of the notifications
+type SinkKind string
+
+const (
+	// SinkDesktop show a notification on the desktop of the machine running
+	// the notifier
+	SinkDesktop SinkKind = "desktop"
+	// SinkEmail send an email through a SMTP server
+	SinkEmail SinkKind = "email"
+	// SinkWebhook POST the notification as JSON to an URL
+	SinkWebhook SinkKind = "webhook"
+	// SinkSlack post a message through a Slack incoming webhook
+	SinkSlack SinkKind = "slack"
+	// SinkMatrix post a message in a Matrix room
+	SinkMatrix SinkKind = "matrix"
+)
+
+// SinkKinds return all the known kinds of sink
+func SinkKinds() []SinkKind {
+	return []SinkKind{SinkDesktop, SinkEmail, SinkWebhook, SinkSlack, SinkMatrix}
+}
+
+// Sink deliver the notifications somewhere
+type Sink interface {
+	Send(ctx context.Context, n *Notification) error
+}
+
+// newSink create the sink of a subscription, checking its parameters
+func newSink(sub *Subscription) (Sink, error) {
+	param := func(key string) (string, error) {
+		value := sub.Params[key]
+		if value == "" {
+			return "", fmt.Errorf("the %s sink needs a %s parameter", sub.Sink, key)
+		}
+		return value, nil
+	}
+
+	switch sub.Sink {
+	case SinkDesktop:
+		return newDesktopSink()
+
+	case SinkEmail:
+		server, err := param(ParamSMTP)
+		if err != nil {
+			return nil, err
+		}
+		from, err := param(ParamFrom)
+		if err != nil {
+			return nil, err
+		}
+		to, err := param(ParamTo)
+		if err != nil {
+			return nil, err
+		}
+		return newEmailSink(server, sub.Params[ParamSMTPUser], sub.Params[ParamSMTPPassword], from, strings.Split(to, ","))
+
+	case SinkWebhook, SinkSlack:
+		url, err := param(ParamURL)
+		if err != nil {
+			return nil, err
+		}
+		if sub.Sink == SinkSlack {
+			return &slackSink{url: url}, nil
+		}
+		return &webhookSink{url: url}, nil
+
+	case SinkMatrix:
+		url, err := param(ParamURL)
+		if err != nil {
+			return nil, err
+		}
+		room, err := param(ParamRoom)
+		if err != nil {
+			return nil, err
+		}
+		token, err := param(ParamToken)
+		if err != nil {
+			return nil, err
+		}
+		return &matrixSink{url: strings.TrimSuffix(url, "/"), room: room, token: token}, nil
+	}
+
+	return nil, fmt.Errorf("unknown sink %q, expected one of %v", sub.Sink, SinkKinds())
+}
+
+// desktopSink show the notifications with the native tool of the platform
+type desktopSink struct {
+	command func(n *Notification) []string
+}
+
+func newDesktopSink() (*desktopSink, error) {
+	switch runtime.GOOS {
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return &desktopSink{command: func(n *Notification) []string {
+			// the text starts with a remote author name, which must not be
+			// parsed as an option
+			return []string{"notify-send", "--app-name=git-bug", "--", n.Subject(), n.Text()}
+		}}, nil
+
+	case "darwin":
+		return &desktopSink{command: func(n *Notification) []string {
+			script := fmt.Sprintf("display notification %s with title %s",
+				strconv.Quote(n.Text()), strconv.Quote(n.Subject()))
+			return []string{"osascript", "-e", script}
+		}}, nil
+	}
+
+	return nil, fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+}
+
+func (s *desktopSink) Send(ctx context.Context, n *Notification) error {
+	args := s.command(n)
+
+	out, err := exec.CommandContext(ctx, args[0], args[1:]...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s failed: %v %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
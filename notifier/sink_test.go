@@ -0,0 +1,135 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+func testNotification() *Notification {
+	return &Notification{
+		Type:    cache.BugUpdated,
+		BugId:   entity.Id("8d2d3f2e1c0b0a09080706050403020100ffeeddccbbaa99887766554433221100"),
+		Title:   "title",
+		Changes: []string{"René Descartes closed the bug"},
+	}
+}
+
+func TestHTTPSinks(t *testing.T) {
+	var method, path, auth string
+	var body map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path, auth = r.Method, r.URL.EscapedPath(), r.Header.Get("Authorization")
+		data, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		body = nil
+		require.NoError(t, json.Unmarshal(data, &body))
+
+		if r.URL.Path == "/fail" {
+			http.Error(w, "nope", http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	send := func(sub *Subscription) error {
+		sink, err := newSink(sub)
+		require.NoError(t, err)
+		return sink.Send(context.Background(), testNotification())
+	}
+
+	err := send(&Subscription{Sink: SinkWebhook, Params: map[string]string{ParamURL: server.URL + "/hook"}})
+	require.NoError(t, err)
+	require.Equal(t, "POST", method)
+	require.Equal(t, "/hook", path)
+	require.Equal(t, "updated", body["type"])
+	require.Equal(t, "title", body["title"])
+	require.Equal(t, []interface{}{"René Descartes closed the bug"}, body["changes"])
+
+	err = send(&Subscription{Sink: SinkSlack, Params: map[string]string{ParamURL: server.URL + "/slack"}})
+	require.NoError(t, err)
+	require.Equal(t, "*[git-bug] 8d2d3f2 updated: title*\nRené Descartes closed the bug", body["text"])
+
+	err = send(&Subscription{Sink: SinkMatrix, Params: map[string]string{
+		ParamURL:   server.URL + "/",
+		ParamRoom:  "!room:example.org",
+		ParamToken: "secret",
+	}})
+	require.NoError(t, err)
+	require.Equal(t, "PUT", method)
+	require.Regexp(t, `^/_matrix/client/r0/rooms/%21room:example.org/send/m.room.message/\d+$`, path)
+	require.Equal(t, "Bearer secret", auth)
+	require.Equal(t, "m.text", body["msgtype"])
+
+	err = send(&Subscription{Sink: SinkWebhook, Params: map[string]string{ParamURL: server.URL + "/fail"}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "403")
+}
+
+func TestEmailSink(t *testing.T) {
+	sink, err := newSink(&Subscription{Sink: SinkEmail, Params: map[string]string{
+		ParamSMTP:         "smtp.example.com:587",
+		ParamSMTPUser:     "bot",
+		ParamSMTPPassword: "secret",
+		ParamFrom:         "git-bug@example.com",
+		ParamTo:           "a@example.com, b@example.com",
+	}})
+	require.NoError(t, err)
+
+	email := sink.(*emailSink)
+
+	var sentTo []string
+	var sentMsg string
+	email.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		require.Equal(t, "smtp.example.com:587", addr)
+		require.NotNil(t, a)
+		require.Equal(t, "git-bug@example.com", from)
+		sentTo, sentMsg = to, string(msg)
+		return nil
+	}
+
+	require.NoError(t, sink.Send(context.Background(), testNotification()))
+	require.Equal(t, []string{"a@example.com", "b@example.com"}, sentTo)
+	require.Contains(t, sentMsg, "To: a@example.com, b@example.com\r\n")
+	require.Contains(t, sentMsg, "Subject: [git-bug] 8d2d3f2 updated: title\r\n")
+	require.Contains(t, sentMsg, "\r\n\r\nRené Descartes closed the bug\r\n")
+}
+
+func TestNewSinkValidation(t *testing.T) {
+	for _, sub := range []*Subscription{
+		{Sink: "pigeon"},
+		{Sink: SinkWebhook},
+		{Sink: SinkMatrix, Params: map[string]string{ParamURL: "https://matrix.org", ParamRoom: "!room"}},
+		{Sink: SinkEmail, Params: map[string]string{ParamSMTP: "no-port", ParamFrom: "a@b.c", ParamTo: "d@e.f"}},
+		{Sink: SinkEmail, Params: map[string]string{ParamSMTP: "host:25", ParamFrom: "a@b.c", ParamTo: " , "}},
+	} {
+		_, err := newSink(sub)
+		require.Error(t, err, "sink %s %v", sub.Sink, sub.Params)
+	}
+}
+
+func TestDesktopSinkOptionInjection(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("notify-send is only used on linux and BSDs")
+	}
+
+	sink, err := newDesktopSink()
+	require.NoError(t, err)
+
+	n := testNotification()
+	n.Changes = []string{"--icon=/tmp/evil closed the bug"}
+
+	args := sink.command(n)
+	require.Equal(t, "--", args[len(args)-3])
+	require.Equal(t, "--icon=/tmp/evil closed the bug", args[len(args)-1])
+}
@@ -0,0 +1,178 @@
+package notifier
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+// the subscriptions are stored in the git config of the repository, which is
+// not shared, indexed by their name
+const configKeyPrefix = "git-bug.notify."
+
+const (
+	configKeySink     = "sink"
+	configKeyRules    = "rules"
+	configKeyIdentity = "identity"
+)
+
+// the parameters of the sinks
+const (
+	// ParamURL is the URL of a webhook, a Slack incoming webhook or a Matrix
+	// homeserver
+	ParamURL = "url"
+	// ParamRoom is the Matrix room to post in
+	ParamRoom = "room"
+	// ParamToken is the access token of the Matrix account posting
+	ParamToken = "token"
+	// ParamSMTP is the host:port of the SMTP server sending the emails
+	ParamSMTP = "smtp"
+	// ParamSMTPUser and ParamSMTPPassword are the SMTP credentials, if needed
+	ParamSMTPUser     = "smtp-user"
+	ParamSMTPPassword = "smtp-password"
+	// ParamFrom is the sender of the emails
+	ParamFrom = "from"
+	// ParamTo is the comma separated recipients of the emails
+	ParamTo = "to"
+)
+
+// Subscription define which changes are notified to a user, and how
+type Subscription struct {
+	Name string
+
+	// Identity is the user subscribing: the rules are evaluated against it,
+	// and its own changes are not notified
+	Identity entity.Id
+
+	// Rules select the changes to notify: a change is notified if any rule
+	// match
+	Rules []Rule
+
+	// Sink is where the notifications are sent, configured with Params
+	Sink   SinkKind
+	Params map[string]string
+}
+
+// Validate check that the subscription can be used
+func (s *Subscription) Validate() error {
+	if s.Name == "" || strings.ContainsAny(s.Name, ". ") {
+		return fmt.Errorf("invalid subscription name %q", s.Name)
+	}
+	if len(s.Rules) == 0 {
+		return fmt.Errorf("a subscription needs at least one rule")
+	}
+	_, err := newSink(s)
+	return err
+}
+
+// Add store a new subscription
+func Add(repo repository.RepoCommon, sub *Subscription) error {
+	if err := sub.Validate(); err != nil {
+		return err
+	}
+
+	existing, err := List(repo)
+	if err != nil {
+		return err
+	}
+	for _, other := range existing {
+		if other.Name == sub.Name {
+			return fmt.Errorf("subscription %s already exist", sub.Name)
+		}
+	}
+
+	rules := make([]string, len(sub.Rules))
+	for i, rule := range sub.Rules {
+		rules[i] = string(rule)
+	}
+
+	values := map[string]string{
+		configKeySink:  string(sub.Sink),
+		configKeyRules: strings.Join(rules, ","),
+	}
+	if sub.Identity != "" {
+		values[configKeyIdentity] = sub.Identity.String()
+	}
+	for key, value := range sub.Params {
+		values[key] = value
+	}
+
+	for key, value := range values {
+		if err := repo.StoreConfig(configKeyPrefix+sub.Name+"."+key, value); err != nil {
+			return errors.Wrap(err, "can't store the subscription")
+		}
+	}
+
+	return nil
+}
+
+// List return all the subscriptions, ordered by name
+func List(repo repository.RepoCommon) ([]*Subscription, error) {
+	configs, err := repo.ReadConfigs(configKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	subs := make(map[string]*Subscription)
+
+	for key, value := range configs {
+		split := strings.SplitN(strings.TrimPrefix(key, configKeyPrefix), ".", 2)
+		if len(split) != 2 {
+			continue
+		}
+
+		name, field := split[0], split[1]
+
+		sub, ok := subs[name]
+		if !ok {
+			sub = &Subscription{Name: name, Params: make(map[string]string)}
+			subs[name] = sub
+		}
+
+		switch field {
+		case configKeySink:
+			sub.Sink = SinkKind(value)
+		case configKeyIdentity:
+			sub.Identity = entity.Id(value)
+		case configKeyRules:
+			sub.Rules, err = ParseRules(value)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid rules of the subscription %s", name)
+			}
+		default:
+			sub.Params[field] = value
+		}
+	}
+
+	result := make([]*Subscription, 0, len(subs))
+	for _, sub := range subs {
+		result = append(result, sub)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, nil
+}
+
+// Remove delete the subscription with the given name
+func Remove(repo repository.RepoCommon, name string) error {
+	subs, err := List(repo)
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range subs {
+		if sub.Name == name {
+			return repo.RmConfigs(configKeyPrefix + name)
+		}
+	}
+
+	return fmt.Errorf("no subscription named %s", name)
+}
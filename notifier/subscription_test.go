@@ -0,0 +1,58 @@
+package notifier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestSubscriptions(t *testing.T) {
+	repo := repository.NewMockRepoForTest()
+
+	slack := &Subscription{
+		Name:     "slack",
+		Identity: "1234",
+		Rules:    []Rule{RuleNew, Rule("label:security")},
+		Sink:     SinkSlack,
+		Params:   map[string]string{ParamURL: "https://hooks.slack.com/services/T000/B000/XXXX"},
+	}
+	require.NoError(t, Add(repo, slack))
+
+	hook := &Subscription{
+		Name:   "hook",
+		Rules:  []Rule{RuleAll},
+		Sink:   SinkWebhook,
+		Params: map[string]string{ParamURL: "https://example.com/hook"},
+	}
+	require.NoError(t, Add(repo, hook))
+
+	// invalid ones
+	require.Error(t, Add(repo, hook), "duplicated name")
+	require.Error(t, Add(repo, &Subscription{Name: "a.b", Rules: []Rule{RuleAll}, Sink: SinkWebhook, Params: hook.Params}))
+	require.Error(t, Add(repo, &Subscription{Name: "norule", Sink: SinkWebhook, Params: hook.Params}))
+	require.Error(t, Add(repo, &Subscription{Name: "nourl", Rules: []Rule{RuleAll}, Sink: SinkWebhook}))
+
+	subs, err := List(repo)
+	require.NoError(t, err)
+	require.Equal(t, []*Subscription{hook, slack}, subs)
+
+	require.NoError(t, Remove(repo, "hook"))
+	require.Error(t, Remove(repo, "hook"))
+
+	subs, err = List(repo)
+	require.NoError(t, err)
+	require.Equal(t, []*Subscription{slack}, subs)
+}
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules("participating, label:bug,new")
+	require.NoError(t, err)
+	require.Equal(t, []Rule{RuleParticipating, Rule("label:bug"), RuleNew}, rules)
+
+	_, err = ParseRules("label:")
+	require.Error(t, err)
+	_, err = ParseRules("everything")
+	require.Error(t, err)
+}
@@ -0,0 +1,112 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const defaultTimeout = 30 * time.Second
+
+var httpClient = &http.Client{Timeout: defaultTimeout}
+
+// webhookSink POST the notifications as JSON to an URL
+type webhookSink struct {
+	url string
+}
+
+// webhookPayload is the JSON body sent by the webhook sink
+type webhookPayload struct {
+	Type    string    `json:"type"`
+	Bug     string    `json:"bug"`
+	Title   string    `json:"title"`
+	Subject string    `json:"subject"`
+	Changes []string  `json:"changes"`
+	Time    time.Time `json:"time"`
+}
+
+func (s *webhookSink) Send(ctx context.Context, n *Notification) error {
+	changes := n.Changes
+	if changes == nil {
+		changes = []string{}
+	}
+
+	return sendJSON(ctx, http.MethodPost, s.url, "", webhookPayload{
+		Type:    n.Type.String(),
+		Bug:     n.BugId.String(),
+		Title:   n.Title,
+		Subject: n.Subject(),
+		Changes: changes,
+		Time:    n.Time,
+	})
+}
+
+// slackSink post the notifications through a Slack incoming webhook, or any
+// chat accepting the same payload
+type slackSink struct {
+	url string
+}
+
+func (s *slackSink) Send(ctx context.Context, n *Notification) error {
+	return sendJSON(ctx, http.MethodPost, s.url, "", map[string]string{
+		"text": fmt.Sprintf("*%s*\n%s", n.Subject(), n.Text()),
+	})
+}
+
+// matrixSink post the notifications in a Matrix room, with the client-server
+// API of the homeserver
+type matrixSink struct {
+	url   string
+	room  string
+	token string
+}
+
+func (s *matrixSink) Send(ctx context.Context, n *Notification) error {
+	// the transaction id make the request idempotent for the homeserver
+	txn := strconv.FormatInt(time.Now().UnixNano(), 10)
+	endpoint := fmt.Sprintf("%s/_matrix/client/r0/rooms/%s/send/m.room.message/%s",
+		s.url, url.PathEscape(s.room), txn)
+
+	return sendJSON(ctx, http.MethodPut, endpoint, s.token, map[string]string{
+		"msgtype": "m.text",
+		"body":    fmt.Sprintf("%s\n%s", n.Subject(), n.Text()),
+	})
+}
+
+// sendJSON send a JSON payload, authenticated with a bearer token if not empty
+func sendJSON(ctx context.Context, method string, url string, token string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s %s: %s %s", method, req.URL.Host, resp.Status, bytes.TrimSpace(msg))
+	}
+
+	return nil
+}
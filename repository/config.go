@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"strconv"
+	"time"
+)
+
+// Config is the configuration of a single scope, the repository or the user
+type Config interface {
+	ConfigRead
+	ConfigWrite
+}
+
+// ConfigRead give read access to a configuration
+type ConfigRead interface {
+	// ReadAll read all key/value pair matching the key prefix
+	ReadAll(keyPrefix string) (map[string]string, error)
+
+	// ReadString read a single string value
+	// Return ErrNoConfigEntry or ErrMultipleConfigEntry if there is zero or more than one entry
+	// for this key
+	ReadString(key string) (string, error)
+
+	// ReadBool read a single boolean value
+	// Return ErrNoConfigEntry or ErrMultipleConfigEntry if there is zero or more than one entry
+	// for this key
+	ReadBool(key string) (bool, error)
+
+	// ReadDuration read a single duration value, written as "1h30m"
+	// Return ErrNoConfigEntry or ErrMultipleConfigEntry if there is zero or more than one entry
+	// for this key
+	ReadDuration(key string) (time.Duration, error)
+
+	// ReadList read all the values of a multi-valued key, in order
+	// Return ErrNoConfigEntry if there is no entry for this key
+	ReadList(key string) ([]string, error)
+}
+
+// ConfigWrite give write access to a configuration
+type ConfigWrite interface {
+	// StoreString store a single key/value pair, replacing the previous values
+	StoreString(key string, value string) error
+
+	// StoreBool store a single boolean value, replacing the previous values
+	StoreBool(key string, value bool) error
+
+	// StoreDuration store a single duration value, replacing the previous values
+	StoreDuration(key string, value time.Duration) error
+
+	// StoreList store the values of a multi-valued key, replacing the previous
+	// values
+	StoreList(key string, values []string) error
+
+	// RemoveAll remove all key/value pair matching the key prefix
+	RemoveAll(keyPrefix string) error
+}
+
+func parseBool(value string, err error) (bool, error) {
+	if err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(value)
+}
+
+func parseDuration(value string, err error) (time.Duration, error) {
+	if err != nil {
+		return 0, err
+	}
+	return time.ParseDuration(value)
+}
+
+var _ ConfigRead = mergedConfig{}
+
+// mergedConfig read a key from the most specific configuration having it, as
+// git does with the repository, user and system configurations
+type mergedConfig []ConfigRead
+
+// newMergedConfig create a mergedConfig from the configurations given from
+// the most specific to the least specific
+func newMergedConfig(configs ...ConfigRead) mergedConfig {
+	return configs
+}
+
+func (m mergedConfig) ReadAll(keyPrefix string) (map[string]string, error) {
+	result := make(map[string]string)
+
+	// the most specific values are written last, to take precedence
+	for i := len(m) - 1; i >= 0; i-- {
+		values, err := m[i].ReadAll(keyPrefix)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range values {
+			result[key] = value
+		}
+	}
+
+	return result, nil
+}
+
+func (m mergedConfig) ReadString(key string) (string, error) {
+	for _, config := range m {
+		value, err := config.ReadString(key)
+		if err != ErrNoConfigEntry {
+			return value, err
+		}
+	}
+	return "", ErrNoConfigEntry
+}
+
+func (m mergedConfig) ReadBool(key string) (bool, error) {
+	return parseBool(m.ReadString(key))
+}
+
+func (m mergedConfig) ReadDuration(key string) (time.Duration, error) {
+	return parseDuration(m.ReadString(key))
+}
+
+func (m mergedConfig) ReadList(key string) ([]string, error) {
+	for _, config := range m {
+		values, err := config.ReadList(key)
+		if err != ErrNoConfigEntry {
+			return values, err
+		}
+	}
+	return nil, ErrNoConfigEntry
+}
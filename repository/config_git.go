@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var _ Config = &gitConfig{}
+
+// gitConfig is the git configuration of a single scope, as given by the
+// scope flag of git config: --local, --global or --system
+type gitConfig struct {
+	repo  *GitRepo
+	scope string
+}
+
+func newGitConfig(repo *GitRepo, scope string) *gitConfig {
+	return &gitConfig{
+		repo:  repo,
+		scope: scope,
+	}
+}
+
+func (gc *gitConfig) runConfigCommand(args ...string) (string, error) {
+	return gc.repo.runGitCommand(append([]string{"config", gc.scope}, args...)...)
+}
+
+// ReadAll read all key/value pair matching the key prefix
+func (gc *gitConfig) ReadAll(keyPrefix string) (map[string]string, error) {
+	stdout, err := gc.runConfigCommand("--get-regexp", keyPrefix)
+
+	//   / \
+	//  / ! \
+	// -------
+	//
+	// There can be a legitimate error here, but I see no portable way to
+	// distinguish them from the git error that say "no matching value exist"
+	if err != nil {
+		return nil, nil
+	}
+
+	lines := strings.Split(stdout, "\n")
+
+	result := make(map[string]string, len(lines))
+
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		// the value might contain spaces, only split on the first one
+		parts := strings.SplitN(line, " ", 2)
+
+		// an empty value leave the key alone
+		if len(parts) == 1 {
+			result[parts[0]] = ""
+			continue
+		}
+
+		result[parts[0]] = parts[1]
+	}
+
+	return result, nil
+}
+
+func (gc *gitConfig) ReadString(key string) (string, error) {
+	lines, err := gc.ReadList(key)
+	if err != nil {
+		return "", err
+	}
+
+	if len(lines) > 1 {
+		return "", ErrMultipleConfigEntry
+	}
+
+	return lines[0], nil
+}
+
+func (gc *gitConfig) ReadBool(key string) (bool, error) {
+	return parseBool(gc.ReadString(key))
+}
+
+func (gc *gitConfig) ReadDuration(key string) (time.Duration, error) {
+	return parseDuration(gc.ReadString(key))
+}
+
+func (gc *gitConfig) ReadList(key string) ([]string, error) {
+	stdout, err := gc.runConfigCommand("--get-all", key)
+
+	//   / \
+	//  / ! \
+	// -------
+	//
+	// There can be a legitimate error here, but I see no portable way to
+	// distinguish them from the git error that say "no matching value exist"
+	if err != nil {
+		return nil, ErrNoConfigEntry
+	}
+
+	return strings.Split(stdout, "\n"), nil
+}
+
+// StoreString store a single key/value pair, replacing the previous values
+func (gc *gitConfig) StoreString(key string, value string) error {
+	_, err := gc.runConfigCommand("--replace-all", key, value)
+
+	return err
+}
+
+func (gc *gitConfig) StoreBool(key string, value bool) error {
+	return gc.StoreString(key, strconv.FormatBool(value))
+}
+
+func (gc *gitConfig) StoreDuration(key string, value time.Duration) error {
+	return gc.StoreString(key, value.String())
+}
+
+// StoreList store the values of a multi-valued key, replacing the previous
+// values
+func (gc *gitConfig) StoreList(key string, values []string) error {
+	// fail if the key doesn't exist yet, which is fine
+	_ = gc.unsetAll(key)
+
+	for _, value := range values {
+		_, err := gc.runConfigCommand("--add", key, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (gc *gitConfig) rmSection(keyPrefix string) error {
+	_, err := gc.runConfigCommand("--remove-section", keyPrefix)
+	return err
+}
+
+func (gc *gitConfig) unsetAll(keyPrefix string) error {
+	_, err := gc.runConfigCommand("--unset-all", keyPrefix)
+	return err
+}
+
+// return keyPrefix section
+// example: sectionFromKey(a.b.c.d) return a.b.c
+func sectionFromKey(keyPrefix string) string {
+	s := strings.Split(keyPrefix, ".")
+	if len(s) == 1 {
+		return keyPrefix
+	}
+
+	return strings.Join(s[:len(s)-1], ".")
+}
+
+// rmConfigs with git version lesser than 2.18
+func (gc *gitConfig) rmConfigsGitVersionLT218(keyPrefix string) error {
+	// try to remove key/value pair by key
+	err := gc.unsetAll(keyPrefix)
+	if err != nil {
+		return gc.rmSection(keyPrefix)
+	}
+
+	m, err := gc.ReadAll(sectionFromKey(keyPrefix))
+	if err != nil {
+		return err
+	}
+
+	// if section doesn't have any left key/value remove the section
+	if len(m) == 0 {
+		return gc.rmSection(sectionFromKey(keyPrefix))
+	}
+
+	return nil
+}
+
+// RemoveAll remove all key/value pair matching the key prefix
+func (gc *gitConfig) RemoveAll(keyPrefix string) error {
+	// starting from git 2.18.0 sections are automatically deleted when the last existing
+	// key/value is removed. Before 2.18.0 we should remove the section
+	// see https://github.com/git/git/blob/master/Documentation/RelNotes/2.18.0.txt#L379
+	lt218, err := gc.repo.gitVersionLT218()
+	if err != nil {
+		return errors.Wrap(err, "getting git version")
+	}
+
+	if lt218 {
+		return gc.rmConfigsGitVersionLT218(keyPrefix)
+	}
+
+	err = gc.unsetAll(keyPrefix)
+	if err != nil {
+		return gc.rmSection(keyPrefix)
+	}
+
+	return nil
+}
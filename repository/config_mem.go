@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+var _ Config = memConfig{}
+
+// memConfig is an in-memory configuration, for testing
+type memConfig map[string][]string
+
+func newMemConfig() memConfig {
+	return make(memConfig)
+}
+
+func (mc memConfig) ReadAll(keyPrefix string) (map[string]string, error) {
+	result := make(map[string]string)
+	for key, values := range mc {
+		if strings.HasPrefix(key, keyPrefix) {
+			result[key] = values[len(values)-1]
+		}
+	}
+	return result, nil
+}
+
+func (mc memConfig) ReadString(key string) (string, error) {
+	values, err := mc.ReadList(key)
+	if err != nil {
+		return "", err
+	}
+	if len(values) > 1 {
+		return "", ErrMultipleConfigEntry
+	}
+	return values[0], nil
+}
+
+func (mc memConfig) ReadBool(key string) (bool, error) {
+	return parseBool(mc.ReadString(key))
+}
+
+func (mc memConfig) ReadDuration(key string) (time.Duration, error) {
+	return parseDuration(mc.ReadString(key))
+}
+
+func (mc memConfig) ReadList(key string) ([]string, error) {
+	values, ok := mc[key]
+	if !ok {
+		return nil, ErrNoConfigEntry
+	}
+	return values, nil
+}
+
+func (mc memConfig) StoreString(key string, value string) error {
+	mc[key] = []string{value}
+	return nil
+}
+
+func (mc memConfig) StoreBool(key string, value bool) error {
+	return mc.StoreString(key, strconv.FormatBool(value))
+}
+
+func (mc memConfig) StoreDuration(key string, value time.Duration) error {
+	return mc.StoreString(key, value.String())
+}
+
+func (mc memConfig) StoreList(key string, values []string) error {
+	if len(values) == 0 {
+		delete(mc, key)
+		return nil
+	}
+	mc[key] = append([]string(nil), values...)
+	return nil
+}
+
+func (mc memConfig) RemoveAll(keyPrefix string) error {
+	for key := range mc {
+		if strings.HasPrefix(key, keyPrefix) {
+			delete(mc, key)
+		}
+	}
+	return nil
+}
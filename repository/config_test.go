@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig(t *testing.T, config Config) {
+	err := config.StoreString("section.key", "value")
+	require.NoError(t, err)
+
+	val, err := config.ReadString("section.key")
+	require.NoError(t, err)
+	require.Equal(t, "value", val)
+
+	err = config.StoreBool("section.bool", true)
+	require.NoError(t, err)
+
+	b, err := config.ReadBool("section.bool")
+	require.NoError(t, err)
+	require.True(t, b)
+
+	err = config.StoreDuration("section.duration", 90*time.Minute)
+	require.NoError(t, err)
+
+	d, err := config.ReadDuration("section.duration")
+	require.NoError(t, err)
+	require.Equal(t, 90*time.Minute, d)
+
+	err = config.StoreList("section.list", []string{"first", "second"})
+	require.NoError(t, err)
+
+	list, err := config.ReadList("section.list")
+	require.NoError(t, err)
+	require.Equal(t, []string{"first", "second"}, list)
+
+	_, err = config.ReadString("section.list")
+	require.Equal(t, ErrMultipleConfigEntry, err)
+
+	// the previous values are replaced
+	err = config.StoreList("section.list", []string{"third"})
+	require.NoError(t, err)
+
+	list, err = config.ReadList("section.list")
+	require.NoError(t, err)
+	require.Equal(t, []string{"third"}, list)
+
+	all, err := config.ReadAll("section.")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"section.key":      "value",
+		"section.bool":     "true",
+		"section.duration": "1h30m0s",
+		"section.list":     "third",
+	}, all)
+
+	err = config.RemoveAll("section.list")
+	require.NoError(t, err)
+
+	_, err = config.ReadList("section.list")
+	require.Equal(t, ErrNoConfigEntry, err)
+
+	err = config.StoreString("section.empty", "")
+	require.NoError(t, err)
+
+	all, err = config.ReadAll("section.empty")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"section.empty": ""}, all)
+
+	_, err = config.ReadDuration("section.missing")
+	require.Equal(t, ErrNoConfigEntry, err)
+}
+
+func TestGitConfig(t *testing.T) {
+	repo := CreateTestRepo(false)
+	defer CleanupTestRepos(t, repo)
+
+	testConfig(t, repo.LocalConfig())
+}
+
+func TestMemConfig(t *testing.T) {
+	testConfig(t, newMemConfig())
+}
+
+func TestConfigScopes(t *testing.T) {
+	repo := CreateTestRepo(false)
+	defer CleanupTestRepos(t, repo)
+
+	// don't touch the configuration of the user running the tests
+	home, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(home)
+
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	err = os.Setenv("HOME", home)
+	require.NoError(t, err)
+
+	err = repo.GlobalConfig().StoreString("section.shared", "global")
+	require.NoError(t, err)
+	err = repo.GlobalConfig().StoreString("section.overridden", "global")
+	require.NoError(t, err)
+	err = repo.LocalConfig().StoreString("section.overridden", "local")
+	require.NoError(t, err)
+
+	_, err = repo.LocalConfig().ReadString("section.shared")
+	require.Equal(t, ErrNoConfigEntry, err)
+
+	val, err := repo.AnyConfig().ReadString("section.shared")
+	require.NoError(t, err)
+	require.Equal(t, "global", val)
+
+	// the repository configuration take precedence
+	val, err = repo.AnyConfig().ReadString("section.overridden")
+	require.NoError(t, err)
+	require.Equal(t, "local", val)
+
+	val, err = repo.ReadConfigString("section.overridden")
+	require.NoError(t, err)
+	require.Equal(t, "local", val)
+
+	all, err := repo.AnyConfig().ReadAll("section.")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"section.shared":     "global",
+		"section.overridden": "local",
+	}, all)
+}
@@ -5,9 +5,13 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
 	"os/exec"
 	"path"
+	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -21,6 +25,9 @@ import (
 const createClockFile = "/git-bug/create-clock"
 const editClockFile = "/git-bug/edit-clock"
 
+// nullHash is the hash git use for a reference that doesn't exist
+const nullHash = "0000000000000000000000000000000000000000"
+
 // ErrNotARepo is the error returned when the git repo root wan't be found
 var ErrNotARepo = errors.New("not a git repository")
 
@@ -85,16 +92,23 @@ func (repo *GitRepo) runGitCommand(args ...string) (string, error) {
 func NewGitRepo(path string, witnesser Witnesser) (*GitRepo, error) {
 	repo := &GitRepo{Path: path}
 
-	// Check the repo and retrieve the root path
-	stdout, err := repo.runGitCommand("rev-parse", "--git-dir")
+	// Check the repo and retrieve the root path. In a worktree, the git
+	// directory is specific to the worktree, so the common one is used to
+	// share the bugs, the cache and the selection with the main checkout.
+	stdout, err := repo.runGitCommand("rev-parse", "--git-common-dir")
 
-	// Now dir is fetched with "git rev-parse --git-dir". May be it can
+	// Now dir is fetched with "git rev-parse --git-common-dir". May be it can
 	// still return nothing in some cases. Then empty stdout check is
 	// kept.
 	if err != nil || stdout == "" {
 		return nil, ErrNotARepo
 	}
 
+	// the common dir can be given relative to the working directory
+	if stdout != ".git" && !filepath.IsAbs(stdout) {
+		stdout = filepath.Join(path, stdout)
+	}
+
 	// Fix the path to be sure we are at the root
 	repo.Path = stdout
 
@@ -186,6 +200,11 @@ func (repo *GitRepo) GetRemotes() (map[string]string, error) {
 	remotes := make(map[string]string, len(lines))
 
 	for _, line := range lines {
+		// no remote at all
+		if line == "" {
+			continue
+		}
+
 		elements := strings.Fields(line)
 		if len(elements) != 3 {
 			return nil, fmt.Errorf("unexpected output format: %s", line)
@@ -197,143 +216,44 @@ func (repo *GitRepo) GetRemotes() (map[string]string, error) {
 	return remotes, nil
 }
 
-// StoreConfig store a single key/value pair in the config of the repo
-func (repo *GitRepo) StoreConfig(key string, value string) error {
-	_, err := repo.runGitCommand("config", "--replace-all", key, value)
-
-	return err
-}
-
-// ReadConfigs read all key/value pair matching the key prefix
-func (repo *GitRepo) ReadConfigs(keyPrefix string) (map[string]string, error) {
-	stdout, err := repo.runGitCommand("config", "--get-regexp", keyPrefix)
-
-	//   / \
-	//  / ! \
-	// -------
-	//
-	// There can be a legitimate error here, but I see no portable way to
-	// distinguish them from the git error that say "no matching value exist"
-	if err != nil {
-		return nil, nil
-	}
-
-	lines := strings.Split(stdout, "\n")
-
-	result := make(map[string]string, len(lines))
-
-	for _, line := range lines {
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
-
-		parts := strings.Fields(line)
-		if len(parts) != 2 {
-			return nil, fmt.Errorf("bad git config: %s", line)
-		}
-
-		result[parts[0]] = parts[1]
-	}
-
-	return result, nil
+// LocalConfig give access to the configuration of the repository
+func (repo *GitRepo) LocalConfig() Config {
+	return newGitConfig(repo, "--local")
 }
 
-func (repo *GitRepo) ReadConfigBool(key string) (bool, error) {
-	val, err := repo.ReadConfigString(key)
-	if err != nil {
-		return false, err
-	}
-
-	return strconv.ParseBool(val)
+// GlobalConfig give access to the configuration of the user, shared by all
+// the repositories
+func (repo *GitRepo) GlobalConfig() Config {
+	return newGitConfig(repo, "--global")
 }
 
-func (repo *GitRepo) ReadConfigString(key string) (string, error) {
-	stdout, err := repo.runGitCommand("config", "--get-all", key)
-
-	//   / \
-	//  / ! \
-	// -------
-	//
-	// There can be a legitimate error here, but I see no portable way to
-	// distinguish them from the git error that say "no matching value exist"
-	if err != nil {
-		return "", ErrNoConfigEntry
-	}
-
-	lines := strings.Split(stdout, "\n")
-
-	if len(lines) == 0 {
-		return "", ErrNoConfigEntry
-	}
-	if len(lines) > 1 {
-		return "", ErrMultipleConfigEntry
-	}
-
-	return lines[0], nil
+// AnyConfig give access to the configuration as git read it: a value from the
+// repository configuration, or else the user's, or else the system's
+func (repo *GitRepo) AnyConfig() ConfigRead {
+	return newMergedConfig(repo.LocalConfig(), repo.GlobalConfig(), newGitConfig(repo, "--system"))
 }
 
-func (repo *GitRepo) rmSection(keyPrefix string) error {
-	_, err := repo.runGitCommand("config", "--remove-section", keyPrefix)
-	return err
+// StoreConfig store a single key/value pair in the config of the repo
+func (repo *GitRepo) StoreConfig(key string, value string) error {
+	return repo.LocalConfig().StoreString(key, value)
 }
 
-func (repo *GitRepo) unsetAll(keyPrefix string) error {
-	_, err := repo.runGitCommand("config", "--unset-all", keyPrefix)
-	return err
+// ReadConfigs read all key/value pair matching the key prefix
+func (repo *GitRepo) ReadConfigs(keyPrefix string) (map[string]string, error) {
+	return repo.AnyConfig().ReadAll(keyPrefix)
 }
 
-// return keyPrefix section
-// example: sectionFromKey(a.b.c.d) return a.b.c
-func sectionFromKey(keyPrefix string) string {
-	s := strings.Split(keyPrefix, ".")
-	if len(s) == 1 {
-		return keyPrefix
-	}
-
-	return strings.Join(s[:len(s)-1], ".")
+func (repo *GitRepo) ReadConfigBool(key string) (bool, error) {
+	return repo.AnyConfig().ReadBool(key)
 }
 
-// rmConfigs with git version lesser than 2.18
-func (repo *GitRepo) rmConfigsGitVersionLT218(keyPrefix string) error {
-	// try to remove key/value pair by key
-	err := repo.unsetAll(keyPrefix)
-	if err != nil {
-		return repo.rmSection(keyPrefix)
-	}
-
-	m, err := repo.ReadConfigs(sectionFromKey(keyPrefix))
-	if err != nil {
-		return err
-	}
-
-	// if section doesn't have any left key/value remove the section
-	if len(m) == 0 {
-		return repo.rmSection(sectionFromKey(keyPrefix))
-	}
-
-	return nil
+func (repo *GitRepo) ReadConfigString(key string) (string, error) {
+	return repo.AnyConfig().ReadString(key)
 }
 
 // RmConfigs remove all key/value pair matching the key prefix
 func (repo *GitRepo) RmConfigs(keyPrefix string) error {
-	// starting from git 2.18.0 sections are automatically deleted when the last existing
-	// key/value is removed. Before 2.18.0 we should remove the section
-	// see https://github.com/git/git/blob/master/Documentation/RelNotes/2.18.0.txt#L379
-	lt218, err := repo.gitVersionLT218()
-	if err != nil {
-		return errors.Wrap(err, "getting git version")
-	}
-
-	if lt218 {
-		return repo.rmConfigsGitVersionLT218(keyPrefix)
-	}
-
-	err = repo.unsetAll(keyPrefix)
-	if err != nil {
-		return repo.rmSection(keyPrefix)
-	}
-
-	return nil
+	return repo.LocalConfig().RemoveAll(keyPrefix)
 }
 
 func (repo *GitRepo) gitVersionLT218() (bool, error) {
@@ -366,8 +286,9 @@ func (repo *GitRepo) gitVersionLT218() (bool, error) {
 }
 
 // FetchRefs fetch git refs from a remote
-func (repo *GitRepo) FetchRefs(remote, refSpec string) (string, error) {
-	stdout, err := repo.runGitCommand("fetch", remote, refSpec)
+func (repo *GitRepo) FetchRefs(remote string, refSpecs ...string) (string, error) {
+	args := append([]string{"fetch", remote}, refSpecs...)
+	stdout, err := repo.runGitCommand(args...)
 
 	if err != nil {
 		return stdout, fmt.Errorf("failed to fetch from the remote '%s': %v", remote, err)
@@ -377,8 +298,9 @@ func (repo *GitRepo) FetchRefs(remote, refSpec string) (string, error) {
 }
 
 // PushRefs push git refs to a remote
-func (repo *GitRepo) PushRefs(remote string, refSpec string) (string, error) {
-	stdout, stderr, err := repo.runGitCommandRaw(nil, "push", remote, refSpec)
+func (repo *GitRepo) PushRefs(remote string, refSpecs ...string) (string, error) {
+	args := append([]string{"push", remote}, refSpecs...)
+	stdout, stderr, err := repo.runGitCommandRaw(nil, args...)
 
 	if err != nil {
 		return stdout + stderr, fmt.Errorf("failed to push to the remote '%s': %v", remote, stderr)
@@ -386,6 +308,55 @@ func (repo *GitRepo) PushRefs(remote string, refSpec string) (string, error) {
 	return stdout + stderr, nil
 }
 
+// AddRemoteFetchRefSpecs add fetch refspecs to the configuration of a remote,
+// if not present already, so that a plain git fetch carry these refs as well
+func (repo *GitRepo) AddRemoteFetchRefSpecs(remote string, refSpecs ...string) error {
+	remotes, err := repo.GetRemotes()
+	if err != nil {
+		return err
+	}
+	if _, ok := remotes[remote]; !ok {
+		return fmt.Errorf("unknown remote %s", remote)
+	}
+
+	return repo.addConfigValues(fmt.Sprintf("remote.%s.fetch", remote), refSpecs)
+}
+
+// configValues return all the values of a multi-valued config key
+func (repo *GitRepo) configValues(key string) []string {
+	stdout, err := repo.runGitCommand("config", "--get-all", key)
+
+	// as in ReadConfigString, a missing key can't be told apart from the
+	// other errors
+	if err != nil || stdout == "" {
+		return nil
+	}
+
+	return strings.Split(stdout, "\n")
+}
+
+// addConfigValues add the values missing from a multi-valued config key
+func (repo *GitRepo) addConfigValues(key string, values []string) error {
+	existing := make(map[string]bool)
+	for _, value := range repo.configValues(key) {
+		existing[value] = true
+	}
+
+	for _, value := range values {
+		if existing[value] {
+			continue
+		}
+
+		_, err := repo.runGitCommand("config", "--add", key, value)
+		if err != nil {
+			return err
+		}
+		existing[value] = true
+	}
+
+	return nil
+}
+
 // StoreData will store arbitrary data and return the corresponding hash
 func (repo *GitRepo) StoreData(data []byte) (git.Hash, error) {
 	var stdin = bytes.NewReader(data)
@@ -395,6 +366,53 @@ func (repo *GitRepo) StoreData(data []byte) (git.Hash, error) {
 	return git.Hash(stdout), err
 }
 
+// StoreDataBatch will store several arbitrary data at once, with a single git
+// process, and return the corresponding hashes
+func (repo *GitRepo) StoreDataBatch(data [][]byte) ([]git.Hash, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	// git read the blobs from files, given one per line
+	dir, err := ioutil.TempDir("", "git-bug-blobs")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	var paths bytes.Buffer
+	for i, d := range data {
+		p := filepath.Join(dir, strconv.Itoa(i))
+		if err := ioutil.WriteFile(p, d, 0600); err != nil {
+			return nil, err
+		}
+		paths.WriteString(p)
+		paths.WriteString("\n")
+	}
+
+	stdout, err := repo.runGitCommandWithStdin(&paths, "hash-object", "-w", "--no-filters", "--stdin-paths")
+	if err != nil {
+		return nil, err
+	}
+
+	return splitHashes(stdout, len(data))
+}
+
+// splitHashes parse the hashes output by a batched git command, one per line
+func splitHashes(stdout string, expected int) ([]git.Hash, error) {
+	lines := strings.Split(stdout, "\n")
+	if len(lines) != expected {
+		return nil, fmt.Errorf("expected %d hashes from git, got %d", expected, len(lines))
+	}
+
+	hashes := make([]git.Hash, len(lines))
+	for i, line := range lines {
+		hashes[i] = git.Hash(line)
+	}
+
+	return hashes, nil
+}
+
 // ReadData will attempt to read arbitrary data from the given hash
 func (repo *GitRepo) ReadData(hash git.Hash) ([]byte, error) {
 	var stdout bytes.Buffer
@@ -422,6 +440,29 @@ func (repo *GitRepo) StoreTree(entries []TreeEntry) (git.Hash, error) {
 	return git.Hash(stdout), nil
 }
 
+// StoreTreeBatch will store several Git trees at once, with a single git
+// process, and return their hashes
+func (repo *GitRepo) StoreTreeBatch(trees [][]TreeEntry) ([]git.Hash, error) {
+	if len(trees) == 0 {
+		return nil, nil
+	}
+
+	// the trees are separated by an empty line
+	var buffer bytes.Buffer
+	for _, entries := range trees {
+		tree := prepareTreeEntries(entries)
+		buffer.Write(tree.Bytes())
+		buffer.WriteString("\n")
+	}
+
+	stdout, err := repo.runGitCommandWithStdin(&buffer, "mktree", "--batch")
+	if err != nil {
+		return nil, err
+	}
+
+	return splitHashes(stdout, len(trees))
+}
+
 // StoreCommit will store a Git commit with the given Git tree
 func (repo *GitRepo) StoreCommit(treeHash git.Hash) (git.Hash, error) {
 	stdout, err := repo.runGitCommand("commit-tree", string(treeHash))
@@ -452,6 +493,82 @@ func (repo *GitRepo) UpdateRef(ref string, hash git.Hash) error {
 	return err
 }
 
+// UpdateRefs will create, update or remove (for an empty hash) several Git
+// references at once, in a single transaction
+func (repo *GitRepo) UpdateRefs(refs map[string]git.Hash) error {
+	if len(refs) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(refs))
+	for ref := range refs {
+		names = append(names, ref)
+	}
+	sort.Strings(names)
+
+	var stdin bytes.Buffer
+	for _, ref := range names {
+		if refs[ref] == "" {
+			_, _ = fmt.Fprintf(&stdin, "delete %s\n", ref)
+		} else {
+			_, _ = fmt.Fprintf(&stdin, "update %s %s\n", ref, refs[ref])
+		}
+	}
+
+	_, err := repo.runGitCommandWithStdin(&stdin, "update-ref", "--stdin")
+
+	return err
+}
+
+// UpdateRefsIf will create, update or remove (for an empty hash) several Git
+// references at once like UpdateRefs, but only if each of them still point to
+// its hash in old, an empty or missing one meaning that the reference doesn't
+// exist. Otherwise nothing is changed and ErrRefChanged is returned.
+func (repo *GitRepo) UpdateRefsIf(refs map[string]git.Hash, old map[string]git.Hash) error {
+	if len(refs) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(refs))
+	for ref := range refs {
+		names = append(names, ref)
+	}
+	sort.Strings(names)
+
+	var stdin bytes.Buffer
+	for _, ref := range names {
+		// the null hash require the reference to not exist
+		expected := old[ref]
+		if expected == "" {
+			expected = nullHash
+		}
+
+		if refs[ref] == "" {
+			_, _ = fmt.Fprintf(&stdin, "delete %s %s\n", ref, expected)
+		} else {
+			_, _ = fmt.Fprintf(&stdin, "update %s %s %s\n", ref, refs[ref], expected)
+		}
+	}
+
+	_, err := repo.runGitCommandWithStdin(&stdin, "update-ref", "--stdin")
+	if err == nil {
+		return nil
+	}
+
+	// tell a reference changed apart from the other failures
+	for _, ref := range names {
+		current, errList := repo.ListRefHashes(ref)
+		if errList != nil {
+			return err
+		}
+		if current[ref] != old[ref] {
+			return ErrRefChanged
+		}
+	}
+
+	return err
+}
+
 // ListRefs will return a list of Git ref matching the given refspec
 func (repo *GitRepo) ListRefs(refspec string) ([]string, error) {
 	stdout, err := repo.runGitCommand("for-each-ref", "--format=%(refname)", refspec)
@@ -469,6 +586,39 @@ func (repo *GitRepo) ListRefs(refspec string) ([]string, error) {
 	return split, nil
 }
 
+// ListRefHashes will return the hash pointed by each Git ref matching
+// the given refspec
+func (repo *GitRepo) ListRefHashes(refspec string) (map[string]git.Hash, error) {
+	stdout, err := repo.runGitCommand("for-each-ref", "--format=%(objectname) %(refname)", refspec)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]git.Hash)
+
+	if stdout == "" {
+		return result, nil
+	}
+
+	for _, line := range strings.Split(stdout, "\n") {
+		split := strings.SplitN(line, " ", 2)
+		if len(split) != 2 {
+			return nil, fmt.Errorf("unexpected for-each-ref output: %s", line)
+		}
+		result[split[1]] = git.Hash(split[0])
+	}
+
+	return result, nil
+}
+
+// RemoveRef will remove a Git reference
+func (repo *GitRepo) RemoveRef(ref string) error {
+	_, err := repo.runGitCommand("update-ref", "-d", ref)
+
+	return err
+}
+
 // RefExist will check if a reference exist in Git
 func (repo *GitRepo) RefExist(ref string) (bool, error) {
 	stdout, err := repo.runGitCommand("for-each-ref", ref)
@@ -539,6 +689,50 @@ func (repo *GitRepo) GetTreeHash(commit git.Hash) (git.Hash, error) {
 	return git.Hash(stdout), nil
 }
 
+// GetCommitMessage return the message of a commit
+func (repo *GitRepo) GetCommitMessage(commit git.Hash) (string, error) {
+	return repo.runGitCommand("log", "-1", "--format=%B", string(commit))
+}
+
+// CollectGarbage remove from the git object store the objects not reachable
+// from any reference anymore, and pack the others. As for a plain git gc, the
+// unreachable objects are only pruned once older than gc.pruneExpire (two
+// weeks by default), which keep safe the other git processes running.
+func (repo *GitRepo) CollectGarbage() error {
+	_, err := repo.runGitCommand("gc", "--quiet")
+
+	return err
+}
+
+// ObjectsSize return the disk space used by the git object store, loose and
+// packed, in bytes
+func (repo *GitRepo) ObjectsSize() (uint64, error) {
+	stdout, err := repo.runGitCommand("count-objects", "-v")
+	if err != nil {
+		return 0, err
+	}
+
+	var total uint64
+	for _, line := range strings.Split(stdout, "\n") {
+		split := strings.SplitN(line, ": ", 2)
+		if len(split) != 2 {
+			return 0, fmt.Errorf("unexpected count-objects output: %s", line)
+		}
+
+		switch split[0] {
+		case "size", "size-pack", "size-garbage":
+			// reported in KiB
+			size, err := strconv.ParseUint(split[1], 10, 64)
+			if err != nil {
+				return 0, err
+			}
+			total += size * 1024
+		}
+	}
+
+	return total, nil
+}
+
 // AddRemote add a new remote to the repository
 // Not in the interface because it's only used for testing
 func (repo *GitRepo) AddRemote(name string, url string) error {
@@ -2,9 +2,15 @@
 package repository
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/util/git"
 )
 
 func TestConfig(t *testing.T) {
@@ -64,3 +70,195 @@ func TestConfig(t *testing.T) {
 	assert.Error(t, err)
 
 }
+
+func TestWorktree(t *testing.T) {
+	repo := CreateTestRepo(false)
+	defer CleanupTestRepos(t, repo)
+
+	_, err := repo.runGitCommand("-C", filepath.Dir(repo.GetPath()), "commit", "--allow-empty", "-m", "init")
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = repo.runGitCommand("-C", filepath.Dir(repo.GetPath()), "worktree", "add", filepath.Join(dir, "worktree"))
+	require.NoError(t, err)
+
+	// the worktree share the git directory of the main checkout
+	worktree, err := NewGitRepo(filepath.Join(dir, "worktree"), func(repo ClockedRepo) error {
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, repo.GetPath(), worktree.GetPath())
+}
+
+func TestBatch(t *testing.T) {
+	repo := CreateTestRepo(false)
+	defer CleanupTestRepos(t, repo)
+
+	testBatch(t, repo)
+}
+
+func testBatch(t *testing.T, repo Repo) {
+	blobs, err := repo.StoreDataBatch([][]byte{[]byte("first"), []byte("second"), {}})
+	require.NoError(t, err)
+	require.Len(t, blobs, 3)
+
+	single, err := repo.StoreData([]byte("second"))
+	require.NoError(t, err)
+	require.Equal(t, single, blobs[1])
+
+	data, err := repo.ReadData(blobs[0])
+	require.NoError(t, err)
+	require.Equal(t, []byte("first"), data)
+
+	// the second tree reference the first one
+	trees, err := repo.StoreTreeBatch([][]TreeEntry{
+		{{ObjectType: Blob, Hash: blobs[0], Name: "first"}},
+		{{ObjectType: Blob, Hash: blobs[1], Name: "second"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, trees, 2)
+
+	nested, err := repo.StoreTree([]TreeEntry{{ObjectType: Tree, Hash: trees[0], Name: "dir"}})
+	require.NoError(t, err)
+
+	entries, err := repo.ListEntries(nested)
+	require.NoError(t, err)
+	require.Equal(t, []TreeEntry{{ObjectType: Tree, Hash: trees[0], Name: "dir"}}, entries)
+
+	first, err := repo.StoreCommit(trees[0])
+	require.NoError(t, err)
+	second, err := repo.StoreCommit(trees[1])
+	require.NoError(t, err)
+
+	err = repo.UpdateRefs(map[string]git.Hash{
+		"refs/bugs/first":  first,
+		"refs/bugs/second": second,
+	})
+	require.NoError(t, err)
+
+	refs, err := repo.ListRefHashes("refs/bugs/")
+	require.NoError(t, err)
+	require.Equal(t, map[string]git.Hash{
+		"refs/bugs/first":  first,
+		"refs/bugs/second": second,
+	}, refs)
+
+	// an empty hash remove the ref
+	err = repo.UpdateRefs(map[string]git.Hash{"refs/bugs/first": ""})
+	require.NoError(t, err)
+
+	refs, err = repo.ListRefHashes("refs/bugs/")
+	require.NoError(t, err)
+	require.Equal(t, map[string]git.Hash{"refs/bugs/second": second}, refs)
+}
+
+func TestUpdateRefsIf(t *testing.T) {
+	repo := CreateTestRepo(false)
+	defer CleanupTestRepos(t, repo)
+
+	testUpdateRefsIf(t, repo)
+}
+
+func testUpdateRefsIf(t *testing.T, repo Repo) {
+	blob, err := repo.StoreData([]byte("data"))
+	require.NoError(t, err)
+	trees, err := repo.StoreTreeBatch([][]TreeEntry{
+		{{ObjectType: Blob, Hash: blob, Name: "first"}},
+		{{ObjectType: Blob, Hash: blob, Name: "second"}},
+	})
+	require.NoError(t, err)
+	first, err := repo.StoreCommit(trees[0])
+	require.NoError(t, err)
+	second, err := repo.StoreCommitWithParent(trees[1], first)
+	require.NoError(t, err)
+
+	// an empty old hash require the ref to not exist
+	err = repo.UpdateRefsIf(map[string]git.Hash{"refs/bugs/a": first}, nil)
+	require.NoError(t, err)
+	err = repo.UpdateRefsIf(map[string]git.Hash{"refs/bugs/a": first}, nil)
+	require.Equal(t, ErrRefChanged, err)
+
+	err = repo.UpdateRefsIf(map[string]git.Hash{"refs/bugs/a": second}, map[string]git.Hash{"refs/bugs/a": first})
+	require.NoError(t, err)
+
+	// nothing is changed if one of the refs moved
+	err = repo.UpdateRefsIf(
+		map[string]git.Hash{"refs/bugs/a": first, "refs/bugs/b": first},
+		map[string]git.Hash{"refs/bugs/a": first},
+	)
+	require.Equal(t, ErrRefChanged, err)
+
+	refs, err := repo.ListRefHashes("refs/bugs/")
+	require.NoError(t, err)
+	require.Equal(t, map[string]git.Hash{"refs/bugs/a": second}, refs)
+}
+
+func TestCollectGarbage(t *testing.T) {
+	repo := CreateTestRepo(false)
+	defer CleanupTestRepos(t, repo)
+
+	testCollectGarbage(t, repo)
+}
+
+func testCollectGarbage(t *testing.T, repo Repo) {
+	kept, err := repo.StoreData([]byte("kept"))
+	require.NoError(t, err)
+	tree, err := repo.StoreTree([]TreeEntry{{ObjectType: Blob, Hash: kept, Name: "kept"}})
+	require.NoError(t, err)
+	commit, err := repo.StoreCommit(tree)
+	require.NoError(t, err)
+	err = repo.UpdateRef("refs/bugs/kept", commit)
+	require.NoError(t, err)
+
+	pruned, err := repo.StoreData([]byte("pruned"))
+	require.NoError(t, err)
+
+	size, err := repo.ObjectsSize()
+	require.NoError(t, err)
+	require.NotZero(t, size)
+
+	// the unreachable objects are kept during git's grace period
+	err = repo.CollectGarbage()
+	require.NoError(t, err)
+
+	_, err = repo.ReadData(pruned)
+	require.NoError(t, err)
+
+	err = repo.StoreConfig("gc.pruneExpire", "now")
+	require.NoError(t, err)
+
+	err = repo.CollectGarbage()
+	require.NoError(t, err)
+
+	_, err = repo.ReadData(kept)
+	require.NoError(t, err)
+	_, err = repo.ReadData(pruned)
+	require.Error(t, err)
+}
+
+func TestAddRemoteFetchRefSpecs(t *testing.T) {
+	repo := CreateTestRepo(false)
+	defer CleanupTestRepos(t, repo)
+
+	err := repo.AddRemoteFetchRefSpecs("origin", "refs/bugs/*:refs/remotes/origin/bugs/*")
+	require.Error(t, err)
+
+	err = repo.AddRemote("origin", "https://example.com/repo.git")
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		err = repo.AddRemoteFetchRefSpecs("origin", "refs/bugs/*:refs/remotes/origin/bugs/*")
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, []string{
+		"+refs/heads/*:refs/remotes/origin/*",
+		"refs/bugs/*:refs/remotes/origin/bugs/*",
+	}, repo.configValues("remote.origin.fetch"))
+
+	// git push is left alone
+	require.Empty(t, repo.configValues("remote.origin.push"))
+}
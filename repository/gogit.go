@@ -0,0 +1,1137 @@
+package repository
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/src-d/go-billy.v4/osfs"
+	gogit "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/config"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/cache"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/storer"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport/client"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport/server"
+	"gopkg.in/src-d/go-git.v4/storage/filesystem"
+
+	"github.com/MichaelMure/git-bug/util/git"
+	"github.com/MichaelMure/git-bug/util/lamport"
+)
+
+func init() {
+	// go-git run git-upload-pack and git-receive-pack for the local remotes,
+	// serve them in process instead to not need the git binary at all
+	client.InstallProtocol("file", server.DefaultServer)
+}
+
+var _ ClockedRepo = &GoGitRepo{}
+
+// GoGitRepo is a git repository accessed with go-git instead of running the
+// git binary. It works on the same on-disk repository as GitRepo, so both can
+// be used on the same repository.
+type GoGitRepo struct {
+	path string
+	repo *gogit.Repository
+
+	// serialize the reference updates of this process, which go-git doesn't
+	// do as a transaction
+	refsMu sync.Mutex
+
+	createClock *lamport.Persisted
+	editClock   *lamport.Persisted
+}
+
+// NewGoGitRepo determines if the given working directory is inside of a git
+// repository, and returns the corresponding GoGitRepo instance if it is.
+func NewGoGitRepo(path string, witnesser Witnesser) (*GoGitRepo, error) {
+	gitPath, err := detectGitPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := openGoGitRepo(gitPath)
+	if err != nil {
+		return nil, err
+	}
+
+	err = repo.LoadClocks()
+
+	if err != nil {
+		// No clock yet, trying to initialize them
+		err = repo.createClocks()
+		if err != nil {
+			return nil, err
+		}
+
+		err = witnesser(repo)
+		if err != nil {
+			return nil, err
+		}
+
+		err = repo.WriteClocks()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return repo, nil
+}
+
+// InitGoGitRepo create a new empty git repo at the given path
+func InitGoGitRepo(path string) (*GoGitRepo, error) {
+	_, err := gogit.PlainInit(path, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return initGoGitClocks(filepath.Join(path, ".git"))
+}
+
+// InitBareGoGitRepo create a new --bare empty git repo at the given path
+func InitBareGoGitRepo(path string) (*GoGitRepo, error) {
+	_, err := gogit.PlainInit(path, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return initGoGitClocks(path)
+}
+
+func initGoGitClocks(gitPath string) (*GoGitRepo, error) {
+	repo, err := openGoGitRepo(gitPath)
+	if err != nil {
+		return nil, err
+	}
+
+	err = repo.createClocks()
+	if err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+func openGoGitRepo(gitPath string) (*GoGitRepo, error) {
+	storage := filesystem.NewStorage(osfs.New(gitPath), cache.NewObjectLRUDefault())
+
+	repo, err := gogit.Open(storage, nil)
+	if err == gogit.ErrRepositoryNotExists {
+		return nil, ErrNotARepo
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &GoGitRepo{
+		path: gitPath,
+		repo: repo,
+	}, nil
+}
+
+// detectGitPath return the git directory of the repository containing the
+// given path. As for GitRepo, a worktree resolve to the common git directory
+// of the main checkout, to share the bugs, the cache and the selection.
+func detectGitPath(path string) (string, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	var gitPath string
+
+	for {
+		fi, err := os.Stat(filepath.Join(path, ".git"))
+		if err == nil && fi.IsDir() {
+			gitPath = filepath.Join(path, ".git")
+			break
+		}
+		if err == nil {
+			// a worktree or a submodule has a .git file pointing to its
+			// git directory
+			gitPath, err = readGitPathFile(filepath.Join(path, ".git"), "gitdir: ")
+			if err != nil {
+				return "", err
+			}
+			break
+		}
+
+		// a bare repository
+		if isGitPath(path) {
+			gitPath = path
+			break
+		}
+
+		parent := filepath.Dir(path)
+		if parent == path {
+			return "", ErrNotARepo
+		}
+		path = parent
+	}
+
+	commonPath, err := readGitPathFile(filepath.Join(gitPath, "commondir"), "")
+	if os.IsNotExist(errors.Cause(err)) {
+		return gitPath, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return commonPath, nil
+}
+
+// readGitPathFile read a path from a file of git, relative to the directory
+// of the file
+func readGitPathFile(file string, prefix string) (string, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+
+	line := strings.TrimSpace(string(data))
+	if !strings.HasPrefix(line, prefix) {
+		return "", ErrNotARepo
+	}
+
+	p := strings.TrimPrefix(line, prefix)
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(filepath.Dir(file), p)
+	}
+
+	return filepath.Clean(p), nil
+}
+
+func isGitPath(path string) bool {
+	for _, name := range []string{"HEAD", "objects", "refs"} {
+		if _, err := os.Stat(filepath.Join(path, name)); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// GetPath returns the path to the repo.
+func (repo *GoGitRepo) GetPath() string {
+	return repo.path
+}
+
+// GetUserName returns the name the the user has used to configure git
+func (repo *GoGitRepo) GetUserName() (string, error) {
+	return repo.AnyConfig().ReadString("user.name")
+}
+
+// GetUserEmail returns the email address that the user has used to configure git.
+func (repo *GoGitRepo) GetUserEmail() (string, error) {
+	return repo.AnyConfig().ReadString("user.email")
+}
+
+// GetCoreEditor returns the name of the editor that the user has used to configure git.
+func (repo *GoGitRepo) GetCoreEditor() (string, error) {
+	// the same order as git var GIT_EDITOR
+	if editor := os.Getenv("GIT_EDITOR"); editor != "" {
+		return editor, nil
+	}
+
+	editor, err := repo.AnyConfig().ReadString("core.editor")
+	if err == nil && editor != "" {
+		return editor, nil
+	}
+	if err != nil && err != ErrNoConfigEntry {
+		return "", err
+	}
+
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if editor := os.Getenv(env); editor != "" {
+			return editor, nil
+		}
+	}
+
+	return "vi", nil
+}
+
+// GetRemotes returns the configured remotes repositories.
+func (repo *GoGitRepo) GetRemotes() (map[string]string, error) {
+	cfg, err := repo.repo.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	remotes := make(map[string]string, len(cfg.Remotes))
+	for name, remote := range cfg.Remotes {
+		if len(remote.URLs) > 0 {
+			remotes[name] = remote.URLs[0]
+		}
+	}
+
+	return remotes, nil
+}
+
+// LocalConfig give access to the configuration of the repository
+func (repo *GoGitRepo) LocalConfig() Config {
+	return newGoGitConfig(filepath.Join(repo.path, "config"))
+}
+
+// GlobalConfig give access to the configuration of the user, shared by all
+// the repositories
+func (repo *GoGitRepo) GlobalConfig() Config {
+	home := os.Getenv("HOME")
+	if home == "" {
+		home = os.Getenv("USERPROFILE")
+	}
+
+	return newGoGitConfig(filepath.Join(home, ".gitconfig"))
+}
+
+// AnyConfig give access to the configuration as git read it: a value from the
+// repository configuration, or else the user's, or else the system's
+func (repo *GoGitRepo) AnyConfig() ConfigRead {
+	system := "/etc/gitconfig"
+	if prefix := os.Getenv("PREFIX"); prefix != "" {
+		system = filepath.Join(prefix, "etc", "gitconfig")
+	}
+
+	return newMergedConfig(repo.LocalConfig(), repo.GlobalConfig(), newGoGitConfig(system))
+}
+
+// StoreConfig store a single key/value pair in the config of the repo
+func (repo *GoGitRepo) StoreConfig(key string, value string) error {
+	return repo.LocalConfig().StoreString(key, value)
+}
+
+// ReadConfigs read all key/value pair matching the key prefix
+func (repo *GoGitRepo) ReadConfigs(keyPrefix string) (map[string]string, error) {
+	return repo.AnyConfig().ReadAll(keyPrefix)
+}
+
+func (repo *GoGitRepo) ReadConfigBool(key string) (bool, error) {
+	return repo.AnyConfig().ReadBool(key)
+}
+
+func (repo *GoGitRepo) ReadConfigString(key string) (string, error) {
+	return repo.AnyConfig().ReadString(key)
+}
+
+// RmConfigs remove all key/value pair matching the key prefix
+func (repo *GoGitRepo) RmConfigs(keyPrefix string) error {
+	return repo.LocalConfig().RemoveAll(keyPrefix)
+}
+
+// withRemote call f with the name of the configured remote, or of a temporary
+// one when given an url or a path, as git accept both
+func (repo *GoGitRepo) withRemote(remote string, f func(name string) error) error {
+	remotes, err := repo.GetRemotes()
+	if err != nil {
+		return err
+	}
+	if _, ok := remotes[remote]; ok {
+		return f(remote)
+	}
+
+	name := fmt.Sprintf("git-bug-%d", time.Now().UnixNano())
+	key := fmt.Sprintf("remote.%s.url", name)
+
+	err = repo.StoreConfig(key, remote)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = repo.RmConfigs("remote." + name) }()
+
+	return f(name)
+}
+
+// parseRefSpecs parse refspecs as git does, a single ref meaning the same one
+// on both sides
+func parseRefSpecs(refSpecs []string) ([]config.RefSpec, error) {
+	result := make([]config.RefSpec, len(refSpecs))
+	for i, refSpec := range refSpecs {
+		if !strings.Contains(refSpec, ":") {
+			refSpec = fmt.Sprintf("%s:%s", refSpec, strings.TrimPrefix(refSpec, "+"))
+		}
+
+		result[i] = config.RefSpec(refSpec)
+		if err := result[i].Validate(); err != nil {
+			return nil, errors.Wrapf(err, "invalid refspec %s", refSpec)
+		}
+	}
+	return result, nil
+}
+
+// FetchRefs fetch git refs from a remote
+func (repo *GoGitRepo) FetchRefs(remote string, refSpecs ...string) (string, error) {
+	specs, err := parseRefSpecs(refSpecs)
+	if err != nil {
+		return "", err
+	}
+
+	var progress bytes.Buffer
+
+	err = repo.withRemote(remote, func(name string) error {
+		return repo.repo.Fetch(&gogit.FetchOptions{
+			RemoteName: name,
+			RefSpecs:   specs,
+			Progress:   &progress,
+		})
+	})
+
+	if err != nil && err != gogit.NoErrAlreadyUpToDate {
+		return progress.String(), fmt.Errorf("failed to fetch from the remote '%s': %v", remote, err)
+	}
+
+	return progress.String(), nil
+}
+
+// PushRefs push git refs to a remote
+func (repo *GoGitRepo) PushRefs(remote string, refSpecs ...string) (string, error) {
+	specs, err := parseRefSpecs(refSpecs)
+	if err != nil {
+		return "", err
+	}
+
+	var progress bytes.Buffer
+
+	err = repo.withRemote(remote, func(name string) error {
+		return repo.repo.Push(&gogit.PushOptions{
+			RemoteName: name,
+			RefSpecs:   specs,
+			Progress:   &progress,
+		})
+	})
+
+	if err != nil && err != gogit.NoErrAlreadyUpToDate {
+		return progress.String(), fmt.Errorf("failed to push to the remote '%s': %v", remote, err)
+	}
+
+	return progress.String(), nil
+}
+
+// AddRemoteFetchRefSpecs add fetch refspecs to the configuration of a remote,
+// if not present already, so that a plain git fetch carry these refs as well
+func (repo *GoGitRepo) AddRemoteFetchRefSpecs(remote string, refSpecs ...string) error {
+	remotes, err := repo.GetRemotes()
+	if err != nil {
+		return err
+	}
+	if _, ok := remotes[remote]; !ok {
+		return fmt.Errorf("unknown remote %s", remote)
+	}
+
+	return addConfigValues(repo.LocalConfig(), fmt.Sprintf("remote.%s.fetch", remote), refSpecs)
+}
+
+// addConfigValues add the values missing from a multi-valued config key
+func addConfigValues(config Config, key string, values []string) error {
+	existing, err := config.ReadList(key)
+	if err != nil && err != ErrNoConfigEntry {
+		return err
+	}
+
+	all := append([]string(nil), existing...)
+	for _, value := range values {
+		found := false
+		for _, e := range all {
+			found = found || e == value
+		}
+		if !found {
+			all = append(all, value)
+		}
+	}
+
+	if len(all) == len(existing) {
+		return nil
+	}
+
+	return config.StoreList(key, all)
+}
+
+// StoreData will store arbitrary data and return the corresponding hash
+func (repo *GoGitRepo) StoreData(data []byte) (git.Hash, error) {
+	obj := repo.repo.Storer.NewEncodedObject()
+	obj.SetType(plumbing.BlobObject)
+
+	w, err := obj.Writer()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = w.Write(data)
+	if err != nil {
+		return "", err
+	}
+
+	err = w.Close()
+	if err != nil {
+		return "", err
+	}
+
+	h, err := repo.repo.Storer.SetEncodedObject(obj)
+	if err != nil {
+		return "", err
+	}
+
+	return git.Hash(h.String()), nil
+}
+
+// StoreDataBatch will store several arbitrary data at once and return the
+// corresponding hashes
+func (repo *GoGitRepo) StoreDataBatch(data [][]byte) ([]git.Hash, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	hashes := make([]git.Hash, len(data))
+	for i, d := range data {
+		hash, err := repo.StoreData(d)
+		if err != nil {
+			return nil, err
+		}
+		hashes[i] = hash
+	}
+
+	return hashes, nil
+}
+
+// ReadData will attempt to read arbitrary data from the given hash
+func (repo *GoGitRepo) ReadData(hash git.Hash) ([]byte, error) {
+	obj, err := repo.repo.Storer.EncodedObject(plumbing.AnyObject, plumbing.NewHash(string(hash)))
+	if err != nil {
+		return []byte{}, err
+	}
+
+	r, err := obj.Reader()
+	if err != nil {
+		return []byte{}, err
+	}
+	defer r.Close()
+
+	return ioutil.ReadAll(r)
+}
+
+// StoreTree will store a mapping key-->Hash as a Git tree
+func (repo *GoGitRepo) StoreTree(entries []TreeEntry) (git.Hash, error) {
+	tree := object.Tree{
+		Entries: make([]object.TreeEntry, len(entries)),
+	}
+
+	for i, entry := range entries {
+		mode := filemode.Regular
+		if entry.ObjectType == Tree {
+			mode = filemode.Dir
+		}
+
+		tree.Entries[i] = object.TreeEntry{
+			Name: entry.Name,
+			Mode: mode,
+			Hash: plumbing.NewHash(string(entry.Hash)),
+		}
+	}
+
+	// as git mktree, sort the entries the way git compare them: a tree as if
+	// its name ended with a slash
+	sortName := func(entry object.TreeEntry) string {
+		if entry.Mode == filemode.Dir {
+			return entry.Name + "/"
+		}
+		return entry.Name
+	}
+	sort.Slice(tree.Entries, func(i, j int) bool {
+		return sortName(tree.Entries[i]) < sortName(tree.Entries[j])
+	})
+
+	obj := repo.repo.Storer.NewEncodedObject()
+	err := tree.Encode(obj)
+	if err != nil {
+		return "", err
+	}
+
+	h, err := repo.repo.Storer.SetEncodedObject(obj)
+	if err != nil {
+		return "", err
+	}
+
+	return git.Hash(h.String()), nil
+}
+
+// StoreTreeBatch will store several Git trees at once and return their hashes
+func (repo *GoGitRepo) StoreTreeBatch(trees [][]TreeEntry) ([]git.Hash, error) {
+	if len(trees) == 0 {
+		return nil, nil
+	}
+
+	hashes := make([]git.Hash, len(trees))
+	for i, entries := range trees {
+		hash, err := repo.StoreTree(entries)
+		if err != nil {
+			return nil, err
+		}
+		hashes[i] = hash
+	}
+
+	return hashes, nil
+}
+
+// StoreCommit will store a Git commit with the given Git tree
+func (repo *GoGitRepo) StoreCommit(treeHash git.Hash) (git.Hash, error) {
+	return repo.storeCommit(treeHash)
+}
+
+// StoreCommitWithParent will store a Git commit with the given Git tree
+func (repo *GoGitRepo) StoreCommitWithParent(treeHash git.Hash, parent git.Hash) (git.Hash, error) {
+	return repo.storeCommit(treeHash, parent)
+}
+
+func (repo *GoGitRepo) storeCommit(treeHash git.Hash, parents ...git.Hash) (git.Hash, error) {
+	// as git commit-tree, the commit is authored by the configured user
+	name, err := repo.GetUserName()
+	if err != nil {
+		return "", errors.Wrap(err, "can't read user.name to author the commit")
+	}
+	email, err := repo.GetUserEmail()
+	if err != nil {
+		return "", errors.Wrap(err, "can't read user.email to author the commit")
+	}
+
+	signature := object.Signature{
+		Name:  name,
+		Email: email,
+		When:  time.Now(),
+	}
+
+	commit := object.Commit{
+		Author:    signature,
+		Committer: signature,
+		TreeHash:  plumbing.NewHash(string(treeHash)),
+	}
+
+	for _, parent := range parents {
+		commit.ParentHashes = append(commit.ParentHashes, plumbing.NewHash(string(parent)))
+	}
+
+	obj := repo.repo.Storer.NewEncodedObject()
+	err = commit.Encode(obj)
+	if err != nil {
+		return "", err
+	}
+
+	h, err := repo.repo.Storer.SetEncodedObject(obj)
+	if err != nil {
+		return "", err
+	}
+
+	return git.Hash(h.String()), nil
+}
+
+// UpdateRef will create or update a Git reference
+func (repo *GoGitRepo) UpdateRef(ref string, hash git.Hash) error {
+	repo.refsMu.Lock()
+	defer repo.refsMu.Unlock()
+
+	return repo.setRef(ref, hash)
+}
+
+func (repo *GoGitRepo) setRef(ref string, hash git.Hash) error {
+	name := plumbing.ReferenceName(ref)
+
+	if hash == "" {
+		return repo.repo.Storer.RemoveReference(name)
+	}
+
+	return repo.repo.Storer.SetReference(plumbing.NewHashReference(name, plumbing.NewHash(string(hash))))
+}
+
+// UpdateRefs will create, update or remove (for an empty hash) several Git
+// references at once. Contrary to GitRepo, the references are updated one
+// after the other, so that another process can see only some of them updated.
+func (repo *GoGitRepo) UpdateRefs(refs map[string]git.Hash) error {
+	repo.refsMu.Lock()
+	defer repo.refsMu.Unlock()
+
+	for _, ref := range sortedRefs(refs) {
+		err := repo.setRef(ref, refs[ref])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UpdateRefsIf will create, update or remove (for an empty hash) several Git
+// references at once like UpdateRefs, but only if each of them still point to
+// its hash in old, an empty or missing one meaning that the reference doesn't
+// exist. Otherwise nothing is changed and ErrRefChanged is returned.
+// As for UpdateRefs, the update is not a transaction: the references are
+// checked and updated under a lock of this process only.
+func (repo *GoGitRepo) UpdateRefsIf(refs map[string]git.Hash, old map[string]git.Hash) error {
+	repo.refsMu.Lock()
+	defer repo.refsMu.Unlock()
+
+	names := sortedRefs(refs)
+
+	for _, ref := range names {
+		current, err := repo.refHash(ref)
+		if err != nil {
+			return err
+		}
+		if current != old[ref] {
+			return ErrRefChanged
+		}
+	}
+
+	for _, ref := range names {
+		err := repo.setRef(ref, refs[ref])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sortedRefs(refs map[string]git.Hash) []string {
+	names := make([]string, 0, len(refs))
+	for ref := range refs {
+		names = append(names, ref)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// refHash return the hash a reference point to, or an empty one if it
+// doesn't exist
+func (repo *GoGitRepo) refHash(ref string) (git.Hash, error) {
+	r, err := repo.repo.Storer.Reference(plumbing.ReferenceName(ref))
+	if err == plumbing.ErrReferenceNotFound {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return git.Hash(r.Hash().String()), nil
+}
+
+// ListRefs will return a list of Git ref matching the given refspec
+func (repo *GoGitRepo) ListRefs(refspec string) ([]string, error) {
+	refs, err := repo.ListRefHashes(refspec)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(refs))
+	for ref := range refs {
+		result = append(result, ref)
+	}
+	sort.Strings(result)
+
+	return result, nil
+}
+
+// ListRefHashes will return the hash pointed by each Git ref matching
+// the given refspec
+func (repo *GoGitRepo) ListRefHashes(refspec string) (map[string]git.Hash, error) {
+	iter, err := repo.repo.Storer.IterReferences()
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	result := make(map[string]git.Hash)
+
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference {
+			return nil
+		}
+
+		name := ref.Name().String()
+		if strings.HasPrefix(name, "refs/") && matchRefPattern(refspec, name) {
+			result[name] = git.Hash(ref.Hash().String())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// matchRefPattern match a reference as git for-each-ref does: a pattern
+// match either a reference, the references under it or, with wildcards, the
+// references matching it
+func matchRefPattern(pattern string, ref string) bool {
+	if strings.ContainsAny(pattern, "*?[") {
+		match, _ := path.Match(pattern, ref)
+		return match
+	}
+
+	return ref == pattern || strings.HasPrefix(ref, strings.TrimSuffix(pattern, "/")+"/")
+}
+
+// RemoveRef will remove a Git reference
+func (repo *GoGitRepo) RemoveRef(ref string) error {
+	repo.refsMu.Lock()
+	defer repo.refsMu.Unlock()
+
+	return repo.repo.Storer.RemoveReference(plumbing.ReferenceName(ref))
+}
+
+// RefExist will check if a reference exist in Git
+func (repo *GoGitRepo) RefExist(ref string) (bool, error) {
+	hash, err := repo.refHash(ref)
+	if err != nil {
+		return false, err
+	}
+
+	return hash != "", nil
+}
+
+// CopyRef will create a new reference with the same value as another one
+func (repo *GoGitRepo) CopyRef(source string, dest string) error {
+	hash, err := repo.resolve(source)
+	if err != nil {
+		return err
+	}
+
+	return repo.UpdateRef(dest, git.Hash(hash.String()))
+}
+
+var hashRegexp = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// resolve return the hash of a reference or a hash, as git rev-parse does
+func (repo *GoGitRepo) resolve(rev string) (plumbing.Hash, error) {
+	ref, err := repo.repo.Storer.Reference(plumbing.ReferenceName(rev))
+	if err == nil {
+		return ref.Hash(), nil
+	}
+	if err != plumbing.ErrReferenceNotFound {
+		return plumbing.ZeroHash, err
+	}
+
+	if hashRegexp.MatchString(rev) {
+		return plumbing.NewHash(rev), nil
+	}
+
+	return plumbing.ZeroHash, fmt.Errorf("unknown revision %s", rev)
+}
+
+// ListCommits will return the list of commit hashes of a ref, in chronological order
+func (repo *GoGitRepo) ListCommits(ref string) ([]git.Hash, error) {
+	hash, err := repo.resolve(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := repo.repo.CommitObject(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	// as git rev-list --first-parent
+	hashes := []git.Hash{git.Hash(commit.Hash.String())}
+	for commit.NumParents() > 0 {
+		commit, err = commit.Parent(0)
+		if err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, git.Hash(commit.Hash.String()))
+	}
+
+	for i, j := 0, len(hashes)-1; i < j; i, j = i+1, j-1 {
+		hashes[i], hashes[j] = hashes[j], hashes[i]
+	}
+
+	return hashes, nil
+}
+
+// ListEntries will return the list of entries in a Git tree, or in the tree of
+// a commit as git ls-tree does
+func (repo *GoGitRepo) ListEntries(hash git.Hash) ([]TreeEntry, error) {
+	obj, err := repo.repo.Object(plumbing.AnyObject, plumbing.NewHash(string(hash)))
+	if err != nil {
+		return nil, err
+	}
+
+	var tree *object.Tree
+	switch obj := obj.(type) {
+	case *object.Tree:
+		tree = obj
+	case *object.Commit:
+		tree, err = obj.Tree()
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%s is not a tree", hash)
+	}
+
+	entries := make([]TreeEntry, len(tree.Entries))
+	for i, entry := range tree.Entries {
+		objType := Blob
+		if entry.Mode == filemode.Dir {
+			objType = Tree
+		}
+
+		entries[i] = TreeEntry{
+			ObjectType: objType,
+			Hash:       git.Hash(entry.Hash.String()),
+			Name:       entry.Name,
+		}
+	}
+
+	return entries, nil
+}
+
+// FindCommonAncestor will return the last common ancestor of two chain of commit
+func (repo *GoGitRepo) FindCommonAncestor(hash1 git.Hash, hash2 git.Hash) (git.Hash, error) {
+	commit1, err := repo.repo.CommitObject(plumbing.NewHash(string(hash1)))
+	if err != nil {
+		return "", err
+	}
+	commit2, err := repo.repo.CommitObject(plumbing.NewHash(string(hash2)))
+	if err != nil {
+		return "", err
+	}
+
+	ancestors := make(map[plumbing.Hash]bool)
+	err = object.NewCommitPreorderIter(commit1, nil, nil).ForEach(func(c *object.Commit) error {
+		ancestors[c.Hash] = true
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// the most recent commit of the second chain also in the first one
+	var ancestor plumbing.Hash
+	err = object.NewCommitIterCTime(commit2, nil, nil).ForEach(func(c *object.Commit) error {
+		if ancestors[c.Hash] {
+			ancestor = c.Hash
+			return storer.ErrStop
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if ancestor.IsZero() {
+		return "", fmt.Errorf("no common ancestor between %s and %s", hash1, hash2)
+	}
+
+	return git.Hash(ancestor.String()), nil
+}
+
+// GetTreeHash return the git tree hash referenced in a commit
+func (repo *GoGitRepo) GetTreeHash(commit git.Hash) (git.Hash, error) {
+	c, err := repo.repo.CommitObject(plumbing.NewHash(string(commit)))
+	if err != nil {
+		return "", err
+	}
+
+	return git.Hash(c.TreeHash.String()), nil
+}
+
+// GetCommitMessage return the message of a commit
+func (repo *GoGitRepo) GetCommitMessage(commit git.Hash) (string, error) {
+	c, err := repo.repo.CommitObject(plumbing.NewHash(string(commit)))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(c.Message), nil
+}
+
+// CollectGarbage remove from the git object store the loose objects not
+// reachable from any reference or from the index anymore, once older than
+// gc.pruneExpire (two weeks by default) as git does. Contrary to git gc, the
+// objects are not packed.
+func (repo *GoGitRepo) CollectGarbage() error {
+	expiry, prune, err := repo.pruneExpiry()
+	if err != nil || !prune {
+		return err
+	}
+
+	// the objects staged in the index are not reachable from a reference yet
+	index, err := repo.repo.Storer.Index()
+	if err != nil {
+		return err
+	}
+	staged := make(map[plumbing.Hash]bool, len(index.Entries))
+	for _, entry := range index.Entries {
+		staged[entry.Hash] = true
+	}
+
+	return repo.repo.Prune(gogit.PruneOptions{
+		OnlyObjectsOlderThan: expiry,
+		Handler: func(hash plumbing.Hash) error {
+			if staged[hash] {
+				return nil
+			}
+			return repo.repo.DeleteObject(hash)
+		},
+	})
+}
+
+var pruneExpireRegexp = regexp.MustCompile(`^(\d+)[. ](second|minute|hour|day|week|month|year)s?[. ]ago$`)
+
+// pruneExpiry return the time before which the unreachable objects can be
+// pruned, read from gc.pruneExpire in the forms "now", "never" or
+// "2.weeks.ago"
+func (repo *GoGitRepo) pruneExpiry() (time.Time, bool, error) {
+	value, err := repo.AnyConfig().ReadString("gc.pruneExpire")
+	if err == ErrNoConfigEntry {
+		value = "2.weeks.ago"
+	} else if err != nil {
+		return time.Time{}, false, err
+	}
+
+	switch value {
+	case "now":
+		return time.Now(), true, nil
+	case "never":
+		return time.Time{}, false, nil
+	}
+
+	match := pruneExpireRegexp.FindStringSubmatch(value)
+	if match == nil {
+		return time.Time{}, false, fmt.Errorf("unsupported gc.pruneExpire %s", value)
+	}
+
+	count, err := strconv.Atoi(match[1])
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	units := map[string]time.Duration{
+		"second": time.Second,
+		"minute": time.Minute,
+		"hour":   time.Hour,
+		"day":    24 * time.Hour,
+		"week":   7 * 24 * time.Hour,
+		"month":  30 * 24 * time.Hour,
+		"year":   365 * 24 * time.Hour,
+	}
+
+	return time.Now().Add(-time.Duration(count) * units[match[2]]), true, nil
+}
+
+// ObjectsSize return the disk space used by the git object store, loose and
+// packed, in bytes
+func (repo *GoGitRepo) ObjectsSize() (uint64, error) {
+	var total uint64
+
+	err := filepath.Walk(filepath.Join(repo.path, "objects"), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			total += uint64(info.Size())
+		}
+		return nil
+	})
+
+	return total, err
+}
+
+// AddRemote add a new remote to the repository
+// Not in the interface because it's only used for testing
+func (repo *GoGitRepo) AddRemote(name string, url string) error {
+	// as git remote add
+	err := repo.StoreConfig(fmt.Sprintf("remote.%s.url", name), url)
+	if err != nil {
+		return err
+	}
+
+	return repo.StoreConfig(fmt.Sprintf("remote.%s.fetch", name),
+		fmt.Sprintf("+refs/heads/*:refs/remotes/%s/*", name))
+}
+
+func (repo *GoGitRepo) createClocks() error {
+	createPath := path.Join(repo.path, createClockFile)
+	createClock, err := lamport.NewPersisted(createPath)
+	if err != nil {
+		return err
+	}
+
+	editPath := path.Join(repo.path, editClockFile)
+	editClock, err := lamport.NewPersisted(editPath)
+	if err != nil {
+		return err
+	}
+
+	repo.createClock = createClock
+	repo.editClock = editClock
+
+	return nil
+}
+
+// LoadClocks read the clocks values from the on-disk repo
+func (repo *GoGitRepo) LoadClocks() error {
+	createClock, err := lamport.LoadPersisted(repo.path + createClockFile)
+	if err != nil {
+		return err
+	}
+
+	editClock, err := lamport.LoadPersisted(repo.path + editClockFile)
+	if err != nil {
+		return err
+	}
+
+	repo.createClock = createClock
+	repo.editClock = editClock
+	return nil
+}
+
+// WriteClocks write the clocks values into the repo
+func (repo *GoGitRepo) WriteClocks() error {
+	err := repo.createClock.Write()
+	if err != nil {
+		return err
+	}
+
+	return repo.editClock.Write()
+}
+
+// CreateTime return the current value of the creation clock
+func (repo *GoGitRepo) CreateTime() lamport.Time {
+	return repo.createClock.Time()
+}
+
+// CreateTimeIncrement increment the creation clock and return the new value.
+func (repo *GoGitRepo) CreateTimeIncrement() (lamport.Time, error) {
+	return repo.createClock.Increment()
+}
+
+// EditTime return the current value of the edit clock
+func (repo *GoGitRepo) EditTime() lamport.Time {
+	return repo.editClock.Time()
+}
+
+// EditTimeIncrement increment the edit clock and return the new value.
+func (repo *GoGitRepo) EditTimeIncrement() (lamport.Time, error) {
+	return repo.editClock.Increment()
+}
+
+// CreateWitness witness another create time and increment the corresponding clock
+// if needed.
+func (repo *GoGitRepo) CreateWitness(time lamport.Time) error {
+	return repo.createClock.Witness(time)
+}
+
+// EditWitness witness another edition time and increment the corresponding clock
+// if needed.
+func (repo *GoGitRepo) EditWitness(time lamport.Time) error {
+	return repo.editClock.Witness(time)
+}
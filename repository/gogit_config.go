@@ -0,0 +1,386 @@
+package repository
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	format "gopkg.in/src-d/go-git.v4/plumbing/format/config"
+)
+
+var _ Config = &goGitConfig{}
+
+// goGitConfig is the configuration stored in a single git config file, read
+// and written without the git binary. As git does, the file is locked with a
+// .lock file while written. The comments of the file are not kept.
+type goGitConfig struct {
+	path string
+}
+
+func newGoGitConfig(path string) *goGitConfig {
+	return &goGitConfig{path: path}
+}
+
+// read decode the configuration file, a missing one being empty
+func (gc *goGitConfig) read() (*format.Config, error) {
+	cfg := format.New()
+
+	f, err := os.Open(gc.path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	err = format.NewDecoder(f).Decode(cfg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't read the git config %s", gc.path)
+	}
+
+	return cfg, nil
+}
+
+// update lock the configuration file, apply the given change and write it
+func (gc *goGitConfig) update(change func(cfg *format.Config) error) error {
+	lockPath := gc.path + ".lock"
+
+	lock, err := os.OpenFile(lockPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "can't lock the git config %s", gc.path)
+	}
+
+	written := false
+	defer func() {
+		if !written {
+			_ = lock.Close()
+			_ = os.Remove(lockPath)
+		}
+	}()
+
+	cfg, err := gc.read()
+	if err != nil {
+		return err
+	}
+
+	err = change(cfg)
+	if err != nil {
+		return err
+	}
+
+	err = encodeConfig(lock, cfg)
+	if err != nil {
+		return err
+	}
+
+	err = lock.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(lockPath, gc.path)
+	if err != nil {
+		return err
+	}
+
+	written = true
+	return nil
+}
+
+// ReadAll read all key/value pair matching the key prefix
+func (gc *goGitConfig) ReadAll(keyPrefix string) (map[string]string, error) {
+	// as git config --get-regexp, the prefix is a regular expression
+	r, err := regexp.Compile(keyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := gc.read()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string)
+
+	add := func(prefix string, options format.Options) {
+		for _, option := range options {
+			key := prefix + strings.ToLower(option.Key)
+			if r.MatchString(key) {
+				result[key] = option.Value
+			}
+		}
+	}
+
+	for _, section := range cfg.Sections {
+		name := strings.ToLower(section.Name)
+		add(name+".", section.Options)
+		for _, subsection := range section.Subsections {
+			add(name+"."+subsection.Name+".", subsection.Options)
+		}
+	}
+
+	return result, nil
+}
+
+func (gc *goGitConfig) ReadString(key string) (string, error) {
+	values, err := gc.ReadList(key)
+	if err != nil {
+		return "", err
+	}
+
+	if len(values) > 1 {
+		return "", ErrMultipleConfigEntry
+	}
+
+	return values[0], nil
+}
+
+func (gc *goGitConfig) ReadBool(key string) (bool, error) {
+	return parseBool(gc.ReadString(key))
+}
+
+func (gc *goGitConfig) ReadDuration(key string) (time.Duration, error) {
+	return parseDuration(gc.ReadString(key))
+}
+
+func (gc *goGitConfig) ReadList(key string) ([]string, error) {
+	section, subsection, name, err := splitConfigKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := gc.read()
+	if err != nil {
+		return nil, err
+	}
+
+	values := findConfigOptions(cfg, section, subsection).GetAll(name)
+	if len(values) == 0 {
+		return nil, ErrNoConfigEntry
+	}
+
+	return values, nil
+}
+
+// StoreString store a single key/value pair, replacing the previous values
+func (gc *goGitConfig) StoreString(key string, value string) error {
+	return gc.StoreList(key, []string{value})
+}
+
+func (gc *goGitConfig) StoreBool(key string, value bool) error {
+	return gc.StoreString(key, strconv.FormatBool(value))
+}
+
+func (gc *goGitConfig) StoreDuration(key string, value time.Duration) error {
+	return gc.StoreString(key, value.String())
+}
+
+// StoreList store the values of a multi-valued key, replacing the previous
+// values
+func (gc *goGitConfig) StoreList(key string, values []string) error {
+	section, subsection, name, err := splitConfigKey(key)
+	if err != nil {
+		return err
+	}
+
+	return gc.update(func(cfg *format.Config) error {
+		s := cfg.Section(section)
+		if subsection == "" {
+			s.RemoveOption(name)
+			for _, value := range values {
+				s.AddOption(name, value)
+			}
+		} else {
+			ss := s.Subsection(subsection)
+			ss.RemoveOption(name)
+			for _, value := range values {
+				ss.AddOption(name, value)
+			}
+		}
+
+		removeEmptyConfigSections(cfg)
+		return nil
+	})
+}
+
+// RemoveAll remove all key/value pair matching the key prefix
+func (gc *goGitConfig) RemoveAll(keyPrefix string) error {
+	return gc.update(func(cfg *format.Config) error {
+		// as for the git binary, the prefix is either a key or a section
+		if removeConfigKey(cfg, keyPrefix) || removeConfigSection(cfg, keyPrefix) {
+			removeEmptyConfigSections(cfg)
+			return nil
+		}
+
+		return fmt.Errorf("no config key or section %s", keyPrefix)
+	})
+}
+
+// splitConfigKey split a key in its section, subsection and name, the
+// subsection being everything between the first and the last dot, as git does
+func splitConfigKey(key string) (section string, subsection string, name string, err error) {
+	first := strings.Index(key, ".")
+	last := strings.LastIndex(key, ".")
+
+	if first <= 0 || last == len(key)-1 {
+		return "", "", "", fmt.Errorf("invalid config key %s", key)
+	}
+
+	if first != last {
+		subsection = key[first+1 : last]
+	}
+
+	return key[:first], subsection, key[last+1:], nil
+}
+
+// findConfigOptions return the options of a section or subsection, or nil if
+// it doesn't exist
+func findConfigOptions(cfg *format.Config, section string, subsection string) format.Options {
+	for _, s := range cfg.Sections {
+		if !s.IsName(section) {
+			continue
+		}
+		if subsection == "" {
+			return s.Options
+		}
+		for _, ss := range s.Subsections {
+			if ss.IsName(subsection) {
+				return ss.Options
+			}
+		}
+	}
+
+	return nil
+}
+
+func removeConfigKey(cfg *format.Config, key string) bool {
+	section, subsection, name, err := splitConfigKey(key)
+	if err != nil {
+		return false
+	}
+
+	if len(findConfigOptions(cfg, section, subsection).GetAll(name)) == 0 {
+		return false
+	}
+
+	if subsection == "" {
+		cfg.Section(section).RemoveOption(name)
+	} else {
+		cfg.Section(section).Subsection(subsection).RemoveOption(name)
+	}
+
+	return true
+}
+
+func removeConfigSection(cfg *format.Config, name string) bool {
+	split := strings.SplitN(name, ".", 2)
+
+	for _, s := range cfg.Sections {
+		if !s.IsName(split[0]) {
+			continue
+		}
+
+		if len(split) == 1 {
+			cfg.RemoveSection(s.Name)
+			return true
+		}
+
+		if s.HasSubsection(split[1]) {
+			cfg.RemoveSubsection(s.Name, split[1])
+			return true
+		}
+	}
+
+	return false
+}
+
+// removeEmptyConfigSections remove the sections left without any value, as
+// git does since 2.18
+func removeEmptyConfigSections(cfg *format.Config) {
+	sections := cfg.Sections[:0]
+
+	for _, s := range cfg.Sections {
+		subsections := s.Subsections[:0]
+		for _, ss := range s.Subsections {
+			if len(ss.Options) > 0 {
+				subsections = append(subsections, ss)
+			}
+		}
+		s.Subsections = subsections
+
+		if len(s.Options) > 0 || len(s.Subsections) > 0 {
+			sections = append(sections, s)
+		}
+	}
+
+	cfg.Sections = sections
+}
+
+// encodeConfig write the configuration in the format of git, quoting the
+// values as needed to read them back unchanged
+func encodeConfig(w io.Writer, cfg *format.Config) error {
+	var buf bytes.Buffer
+
+	writeOptions := func(options format.Options) {
+		for _, option := range options {
+			_, _ = fmt.Fprintf(&buf, "\t%s = %s\n", option.Key, quoteConfigValue(option.Value))
+		}
+	}
+
+	for _, s := range cfg.Sections {
+		if len(s.Options) > 0 {
+			_, _ = fmt.Fprintf(&buf, "[%s]\n", s.Name)
+			writeOptions(s.Options)
+		}
+
+		for _, ss := range s.Subsections {
+			_, _ = fmt.Fprintf(&buf, "[%s %s]\n", s.Name, quoteConfigString(ss.Name))
+			writeOptions(ss.Options)
+		}
+	}
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// quoteConfigValue escape a value, and quote it if it would be changed or cut
+// by git otherwise
+func quoteConfigValue(value string) string {
+	quoted := quoteConfigString(value)
+
+	if value != strings.TrimSpace(value) || strings.ContainsAny(value, "#;") {
+		return quoted
+	}
+
+	return quoted[1 : len(quoted)-1]
+}
+
+func quoteConfigString(value string) string {
+	var buf bytes.Buffer
+
+	buf.WriteByte('"')
+	for _, r := range value {
+		switch r {
+		case '\\':
+			buf.WriteString(`\\`)
+		case '"':
+			buf.WriteString(`\"`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	buf.WriteByte('"')
+
+	return buf.String()
+}
@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/util/git"
+)
+
+func TestGoGitConfig(t *testing.T) {
+	repo := CreateGoGitTestRepo(false)
+	defer CleanupTestRepos(t, repo)
+
+	testConfig(t, repo.LocalConfig())
+}
+
+func TestGoGitConfigGitInterop(t *testing.T) {
+	repo := CreateGoGitTestRepo(false)
+	defer CleanupTestRepos(t, repo)
+
+	gitRepo, err := NewGitRepo(repo.GetPath(), func(repo ClockedRepo) error {
+		return nil
+	})
+	require.NoError(t, err)
+
+	// the values are quoted as needed for git to read them back unchanged
+	values := map[string]string{
+		"section.plain":         "value",
+		"section.quote":         `a "quoted" value`,
+		"section.comment":       "before # after ; end",
+		"section.backslash":     `C:\path\to`,
+		"section.sub.with.dots": "subsection",
+	}
+
+	for key, value := range values {
+		err := repo.LocalConfig().StoreString(key, value)
+		require.NoError(t, err)
+	}
+
+	for key, value := range values {
+		read, err := gitRepo.LocalConfig().ReadString(key)
+		require.NoError(t, err)
+		require.Equal(t, value, read, key)
+	}
+
+	// git would read it as well, but GitRepo trim the output of git
+	err = repo.LocalConfig().StoreString("section.spaces", "  padded  ")
+	require.NoError(t, err)
+
+	padded, err := repo.LocalConfig().ReadString("section.spaces")
+	require.NoError(t, err)
+	require.Equal(t, "  padded  ", padded)
+
+	// and the values written by git are read as well
+	err = gitRepo.LocalConfig().StoreList("section.list", []string{"first", "second"})
+	require.NoError(t, err)
+
+	list, err := repo.LocalConfig().ReadList("section.list")
+	require.NoError(t, err)
+	require.Equal(t, []string{"first", "second"}, list)
+
+	name, err := repo.GetUserName()
+	require.NoError(t, err)
+	require.Equal(t, "testuser", name)
+}
+
+func TestGoGitWorktree(t *testing.T) {
+	repo := CreateTestRepo(false)
+	defer CleanupTestRepos(t, repo)
+
+	_, err := repo.runGitCommand("-C", filepath.Dir(repo.GetPath()), "commit", "--allow-empty", "-m", "init")
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = repo.runGitCommand("-C", filepath.Dir(repo.GetPath()), "worktree", "add", filepath.Join(dir, "worktree"))
+	require.NoError(t, err)
+
+	// the worktree share the git directory of the main checkout
+	worktree, err := NewGoGitRepo(filepath.Join(dir, "worktree"), func(repo ClockedRepo) error {
+		return nil
+	})
+	require.NoError(t, err)
+
+	expected, err := filepath.EvalSymlinks(repo.GetPath())
+	require.NoError(t, err)
+	actual, err := filepath.EvalSymlinks(worktree.GetPath())
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+}
+
+func TestGoGitBatch(t *testing.T) {
+	repo := CreateGoGitTestRepo(false)
+	defer CleanupTestRepos(t, repo)
+
+	testBatch(t, repo)
+}
+
+func TestGoGitUpdateRefsIf(t *testing.T) {
+	repo := CreateGoGitTestRepo(false)
+	defer CleanupTestRepos(t, repo)
+
+	testUpdateRefsIf(t, repo)
+}
+
+func TestGoGitCollectGarbage(t *testing.T) {
+	repo := CreateGoGitTestRepo(false)
+	defer CleanupTestRepos(t, repo)
+
+	testCollectGarbage(t, repo)
+}
+
+func TestGoGitHistory(t *testing.T) {
+	repo := CreateGoGitTestRepo(false)
+	defer CleanupTestRepos(t, repo)
+
+	blob, err := repo.StoreData([]byte("data"))
+	require.NoError(t, err)
+	trees, err := repo.StoreTreeBatch([][]TreeEntry{
+		{{ObjectType: Blob, Hash: blob, Name: "first"}},
+		{{ObjectType: Blob, Hash: blob, Name: "second"}},
+		{{ObjectType: Blob, Hash: blob, Name: "third"}},
+	})
+	require.NoError(t, err)
+
+	root, err := repo.StoreCommit(trees[0])
+	require.NoError(t, err)
+	left, err := repo.StoreCommitWithParent(trees[1], root)
+	require.NoError(t, err)
+	right, err := repo.StoreCommitWithParent(trees[2], root)
+	require.NoError(t, err)
+
+	err = repo.UpdateRef("refs/bugs/left", left)
+	require.NoError(t, err)
+
+	commits, err := repo.ListCommits("refs/bugs/left")
+	require.NoError(t, err)
+	require.Equal(t, []git.Hash{root, left}, commits)
+
+	ancestor, err := repo.FindCommonAncestor(left, right)
+	require.NoError(t, err)
+	require.Equal(t, root, ancestor)
+
+	tree, err := repo.GetTreeHash(right)
+	require.NoError(t, err)
+	require.Equal(t, trees[2], tree)
+
+	err = repo.CopyRef("refs/bugs/left", "refs/bugs/copy")
+	require.NoError(t, err)
+
+	exist, err := repo.RefExist("refs/bugs/copy")
+	require.NoError(t, err)
+	require.True(t, exist)
+
+	// the objects are the same as with the git binary
+	gitRepo, err := NewGitRepo(repo.GetPath(), func(repo ClockedRepo) error {
+		return nil
+	})
+	require.NoError(t, err)
+
+	gitCommits, err := gitRepo.ListCommits("refs/bugs/copy")
+	require.NoError(t, err)
+	require.Equal(t, commits, gitCommits)
+
+	gitTree, err := gitRepo.StoreTree([]TreeEntry{
+		{ObjectType: Tree, Hash: trees[0], Name: "b"},
+		{ObjectType: Blob, Hash: blob, Name: "a"},
+	})
+	require.NoError(t, err)
+	goGitTree, err := repo.StoreTree([]TreeEntry{
+		{ObjectType: Tree, Hash: trees[0], Name: "b"},
+		{ObjectType: Blob, Hash: blob, Name: "a"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, gitTree, goGitTree)
+
+	err = repo.RemoveRef("refs/bugs/copy")
+	require.NoError(t, err)
+
+	refs, err := gitRepo.ListRefs("refs/bugs/")
+	require.NoError(t, err)
+	require.Equal(t, []string{"refs/bugs/left"}, refs)
+}
+
+func TestGoGitPushFetch(t *testing.T) {
+	repoA, repoB, remote := SetupGoGitReposAndRemote(t)
+	defer CleanupTestRepos(t, repoA, repoB, remote)
+
+	blob, err := repoA.StoreData([]byte("data"))
+	require.NoError(t, err)
+	tree, err := repoA.StoreTree([]TreeEntry{{ObjectType: Blob, Hash: blob, Name: "data"}})
+	require.NoError(t, err)
+	commit, err := repoA.StoreCommit(tree)
+	require.NoError(t, err)
+	err = repoA.UpdateRef("refs/bugs/a", commit)
+	require.NoError(t, err)
+
+	_, err = repoA.PushRefs("origin", "refs/bugs/*")
+	require.NoError(t, err)
+
+	_, err = repoB.FetchRefs("origin", "refs/bugs/*:refs/remotes/origin/bugs/*")
+	require.NoError(t, err)
+
+	refs, err := repoB.ListRefHashes("refs/remotes/origin/bugs/")
+	require.NoError(t, err)
+	require.Equal(t, map[string]git.Hash{"refs/remotes/origin/bugs/a": commit}, refs)
+
+	data, err := repoB.ReadData(blob)
+	require.NoError(t, err)
+	require.Equal(t, []byte("data"), data)
+
+	// as with git, a path is accepted instead of a remote
+	_, err = repoB.FetchRefs(repoA.GetPath(), "refs/bugs/*:refs/bugs/*")
+	require.NoError(t, err)
+
+	exist, err := repoB.RefExist("refs/bugs/a")
+	require.NoError(t, err)
+	require.True(t, exist)
+
+	remotes, err := repoB.GetRemotes()
+	require.NoError(t, err)
+	require.Len(t, remotes, 1)
+}
@@ -3,7 +3,6 @@ package repository
 import (
 	"crypto/sha1"
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/MichaelMure/git-bug/util/git"
@@ -14,13 +13,14 @@ var _ ClockedRepo = &mockRepoForTest{}
 
 // mockRepoForTest defines an instance of Repo that can be used for testing.
 type mockRepoForTest struct {
-	config      map[string]string
-	blobs       map[git.Hash][]byte
-	trees       map[git.Hash]string
-	commits     map[git.Hash]commit
-	refs        map[string]git.Hash
-	createClock lamport.Clock
-	editClock   lamport.Clock
+	config       memConfig
+	globalConfig memConfig
+	blobs        map[git.Hash][]byte
+	trees        map[git.Hash]string
+	commits      map[git.Hash]commit
+	refs         map[string]git.Hash
+	createClock  lamport.Clock
+	editClock    lamport.Clock
 }
 
 type commit struct {
@@ -30,13 +30,14 @@ type commit struct {
 
 func NewMockRepoForTest() *mockRepoForTest {
 	return &mockRepoForTest{
-		config:      make(map[string]string),
-		blobs:       make(map[git.Hash][]byte),
-		trees:       make(map[git.Hash]string),
-		commits:     make(map[git.Hash]commit),
-		refs:        make(map[string]git.Hash),
-		createClock: lamport.NewClock(),
-		editClock:   lamport.NewClock(),
+		config:       newMemConfig(),
+		globalConfig: newMemConfig(),
+		blobs:        make(map[git.Hash][]byte),
+		trees:        make(map[git.Hash]string),
+		commits:      make(map[git.Hash]commit),
+		refs:         make(map[string]git.Hash),
+		createClock:  lamport.NewClock(),
+		editClock:    lamport.NewClock(),
 	}
 }
 
@@ -66,59 +67,49 @@ func (r *mockRepoForTest) GetRemotes() (map[string]string, error) {
 	}, nil
 }
 
-func (r *mockRepoForTest) StoreConfig(key string, value string) error {
-	r.config[key] = value
-	return nil
+func (r *mockRepoForTest) LocalConfig() Config {
+	return r.config
 }
 
-func (r *mockRepoForTest) ReadConfigs(keyPrefix string) (map[string]string, error) {
-	result := make(map[string]string)
+func (r *mockRepoForTest) GlobalConfig() Config {
+	return r.globalConfig
+}
 
-	for key, val := range r.config {
-		if strings.HasPrefix(key, keyPrefix) {
-			result[key] = val
-		}
-	}
+func (r *mockRepoForTest) AnyConfig() ConfigRead {
+	return newMergedConfig(r.config, r.globalConfig)
+}
 
-	return result, nil
+func (r *mockRepoForTest) StoreConfig(key string, value string) error {
+	return r.config.StoreString(key, value)
 }
 
-func (r *mockRepoForTest) ReadConfigBool(key string) (bool, error) {
-	// unlike git, the mock can only store one value for the same key
-	val, ok := r.config[key]
-	if !ok {
-		return false, ErrNoConfigEntry
-	}
+func (r *mockRepoForTest) ReadConfigs(keyPrefix string) (map[string]string, error) {
+	return r.AnyConfig().ReadAll(keyPrefix)
+}
 
-	return strconv.ParseBool(val)
+func (r *mockRepoForTest) ReadConfigBool(key string) (bool, error) {
+	return r.AnyConfig().ReadBool(key)
 }
 
 func (r *mockRepoForTest) ReadConfigString(key string) (string, error) {
-	// unlike git, the mock can only store one value for the same key
-	val, ok := r.config[key]
-	if !ok {
-		return "", ErrNoConfigEntry
-	}
-
-	return val, nil
+	return r.AnyConfig().ReadString(key)
 }
 
 // RmConfigs remove all key/value pair matching the key prefix
 func (r *mockRepoForTest) RmConfigs(keyPrefix string) error {
-	for key := range r.config {
-		if strings.HasPrefix(key, keyPrefix) {
-			delete(r.config, key)
-		}
-	}
-	return nil
+	return r.config.RemoveAll(keyPrefix)
 }
 
 // PushRefs push git refs to a remote
-func (r *mockRepoForTest) PushRefs(remote string, refSpec string) (string, error) {
+func (r *mockRepoForTest) PushRefs(remote string, refSpecs ...string) (string, error) {
 	return "", nil
 }
 
-func (r *mockRepoForTest) FetchRefs(remote string, refSpec string) (string, error) {
+func (r *mockRepoForTest) AddRemoteFetchRefSpecs(remote string, refSpecs ...string) error {
+	return nil
+}
+
+func (r *mockRepoForTest) FetchRefs(remote string, refSpecs ...string) (string, error) {
 	return "", nil
 }
 
@@ -129,6 +120,18 @@ func (r *mockRepoForTest) StoreData(data []byte) (git.Hash, error) {
 	return hash, nil
 }
 
+func (r *mockRepoForTest) StoreDataBatch(data [][]byte) ([]git.Hash, error) {
+	hashes := make([]git.Hash, len(data))
+	for i, d := range data {
+		hash, err := r.StoreData(d)
+		if err != nil {
+			return nil, err
+		}
+		hashes[i] = hash
+	}
+	return hashes, nil
+}
+
 func (r *mockRepoForTest) ReadData(hash git.Hash) ([]byte, error) {
 	data, ok := r.blobs[hash]
 
@@ -148,6 +151,18 @@ func (r *mockRepoForTest) StoreTree(entries []TreeEntry) (git.Hash, error) {
 	return hash, nil
 }
 
+func (r *mockRepoForTest) StoreTreeBatch(trees [][]TreeEntry) ([]git.Hash, error) {
+	hashes := make([]git.Hash, len(trees))
+	for i, entries := range trees {
+		hash, err := r.StoreTree(entries)
+		if err != nil {
+			return nil, err
+		}
+		hashes[i] = hash
+	}
+	return hashes, nil
+}
+
 func (r *mockRepoForTest) StoreCommit(treeHash git.Hash) (git.Hash, error) {
 	rawHash := sha1.Sum([]byte(treeHash))
 	hash := git.Hash(fmt.Sprintf("%x", rawHash))
@@ -172,6 +187,31 @@ func (r *mockRepoForTest) UpdateRef(ref string, hash git.Hash) error {
 	return nil
 }
 
+func (r *mockRepoForTest) UpdateRefs(refs map[string]git.Hash) error {
+	for ref, hash := range refs {
+		if hash == "" {
+			delete(r.refs, ref)
+		} else {
+			r.refs[ref] = hash
+		}
+	}
+	return nil
+}
+
+func (r *mockRepoForTest) UpdateRefsIf(refs map[string]git.Hash, old map[string]git.Hash) error {
+	for ref := range refs {
+		if r.refs[ref] != old[ref] {
+			return ErrRefChanged
+		}
+	}
+	return r.UpdateRefs(refs)
+}
+
+func (r *mockRepoForTest) RemoveRef(ref string) error {
+	delete(r.refs, ref)
+	return nil
+}
+
 func (r *mockRepoForTest) RefExist(ref string) (bool, error) {
 	_, exist := r.refs[ref]
 	return exist, nil
@@ -200,6 +240,18 @@ func (r *mockRepoForTest) ListRefs(refspec string) ([]string, error) {
 	return keys, nil
 }
 
+func (r *mockRepoForTest) ListRefHashes(refspec string) (map[string]git.Hash, error) {
+	result := make(map[string]git.Hash)
+
+	for k, hash := range r.refs {
+		if strings.HasPrefix(k, refspec) {
+			result[k] = hash
+		}
+	}
+
+	return result, nil
+}
+
 func (r *mockRepoForTest) ListCommits(ref string) ([]git.Hash, error) {
 	var hashes []git.Hash
 
@@ -250,6 +302,22 @@ func (r *mockRepoForTest) GetTreeHash(commit git.Hash) (git.Hash, error) {
 	panic("implement me")
 }
 
+func (r *mockRepoForTest) GetCommitMessage(commit git.Hash) (string, error) {
+	panic("implement me")
+}
+
+func (r *mockRepoForTest) CollectGarbage() error {
+	return nil
+}
+
+func (r *mockRepoForTest) ObjectsSize() (uint64, error) {
+	var total uint64
+	for _, data := range r.blobs {
+		total += uint64(len(data))
+	}
+	return total, nil
+}
+
 func (r *mockRepoForTest) LoadClocks() error {
 	return nil
 }
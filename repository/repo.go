@@ -13,6 +13,10 @@ import (
 var ErrNoConfigEntry = errors.New("no config entry for the given key")
 var ErrMultipleConfigEntry = errors.New("multiple config entry for the given key")
 
+// ErrRefChanged is returned when a Git reference to update doesn't point to
+// the expected hash anymore
+var ErrRefChanged = errors.New("the reference has been changed in the meantime")
+
 // RepoCommon represent the common function the we want all the repo to implement
 type RepoCommon interface {
 	// GetPath returns the path to the repo.
@@ -30,23 +34,39 @@ type RepoCommon interface {
 	// GetRemotes returns the configured remotes repositories.
 	GetRemotes() (map[string]string, error)
 
+	// LocalConfig give access to the configuration of the repository
+	LocalConfig() Config
+
+	// GlobalConfig give access to the configuration of the user, shared by
+	// all the repositories
+	GlobalConfig() Config
+
+	// AnyConfig give access to the configuration as git read it: a value
+	// from the repository configuration, or else the user's, or else the
+	// system's
+	AnyConfig() ConfigRead
+
 	// StoreConfig store a single key/value pair in the config of the repo
 	StoreConfig(key string, value string) error
 
-	// ReadConfigs read all key/value pair matching the key prefix
+	// ReadConfigs read all key/value pair matching the key prefix, as
+	// AnyConfig does
 	ReadConfigs(keyPrefix string) (map[string]string, error)
 
-	// ReadConfigBool read a single boolean value from the config
+	// ReadConfigBool read a single boolean value from the config, as
+	// AnyConfig does
 	// Return ErrNoConfigEntry or ErrMultipleConfigEntry if there is zero or more than one entry
 	// for this key
 	ReadConfigBool(key string) (bool, error)
 
-	// ReadConfigBool read a single string value from the config
+	// ReadConfigBool read a single string value from the config, as
+	// AnyConfig does
 	// Return ErrNoConfigEntry or ErrMultipleConfigEntry if there is zero or more than one entry
 	// for this key
 	ReadConfigString(key string) (string, error)
 
-	// RmConfigs remove all key/value pair matching the key prefix
+	// RmConfigs remove all key/value pair matching the key prefix from the
+	// config of the repo
 	RmConfigs(keyPrefix string) error
 }
 
@@ -55,20 +75,33 @@ type Repo interface {
 	RepoCommon
 
 	// FetchRefs fetch git refs from a remote
-	FetchRefs(remote string, refSpec string) (string, error)
+	FetchRefs(remote string, refSpecs ...string) (string, error)
 
 	// PushRefs push git refs to a remote
-	PushRefs(remote string, refSpec string) (string, error)
+	PushRefs(remote string, refSpecs ...string) (string, error)
+
+	// AddRemoteFetchRefSpecs add fetch refspecs to the configuration of a
+	// remote, if not present already, so that a plain git fetch carry these
+	// refs as well
+	AddRemoteFetchRefSpecs(remote string, refSpecs ...string) error
 
 	// StoreData will store arbitrary data and return the corresponding hash
 	StoreData(data []byte) (git.Hash, error)
 
+	// StoreDataBatch will store several arbitrary data at once and return the
+	// corresponding hashes, in the same order
+	StoreDataBatch(data [][]byte) ([]git.Hash, error)
+
 	// ReadData will attempt to read arbitrary data from the given hash
 	ReadData(hash git.Hash) ([]byte, error)
 
 	// StoreTree will store a mapping key-->Hash as a Git tree
 	StoreTree(mapping []TreeEntry) (git.Hash, error)
 
+	// StoreTreeBatch will store several Git trees at once and return their
+	// hashes, in the same order. A tree can reference a previous one.
+	StoreTreeBatch(trees [][]TreeEntry) ([]git.Hash, error)
+
 	// StoreCommit will store a Git commit with the given Git tree
 	StoreCommit(treeHash git.Hash) (git.Hash, error)
 
@@ -78,9 +111,27 @@ type Repo interface {
 	// UpdateRef will create or update a Git reference
 	UpdateRef(ref string, hash git.Hash) error
 
+	// UpdateRefs will create, update or remove (for an empty hash) several
+	// Git references at once, in a single transaction
+	UpdateRefs(refs map[string]git.Hash) error
+
+	// UpdateRefsIf will create, update or remove (for an empty hash) several
+	// Git references at once like UpdateRefs, but only if each of them still
+	// point to its hash in old, an empty or missing one meaning that the
+	// reference doesn't exist. Otherwise nothing is changed and
+	// ErrRefChanged is returned.
+	UpdateRefsIf(refs map[string]git.Hash, old map[string]git.Hash) error
+
 	// ListRefs will return a list of Git ref matching the given refspec
 	ListRefs(refspec string) ([]string, error)
 
+	// ListRefHashes will return the hash pointed by each Git ref matching
+	// the given refspec
+	ListRefHashes(refspec string) (map[string]git.Hash, error)
+
+	// RemoveRef will remove a Git reference
+	RemoveRef(ref string) error
+
 	// RefExist will check if a reference exist in Git
 	RefExist(ref string) (bool, error)
 
@@ -98,6 +149,17 @@ type Repo interface {
 
 	// GetTreeHash return the git tree hash referenced in a commit
 	GetTreeHash(commit git.Hash) (git.Hash, error)
+
+	// GetCommitMessage return the message of a commit
+	GetCommitMessage(commit git.Hash) (string, error)
+
+	// CollectGarbage remove from the git object store the objects not
+	// reachable from any reference anymore, once older than git's prune
+	// expiry
+	CollectGarbage() error
+
+	// ObjectsSize return the disk space used by the git object store, in bytes
+	ObjectsSize() (uint64, error)
 }
 
 // ClockedRepo is a Repo that also has Lamport clocks
@@ -0,0 +1,139 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/action"
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+// Change describe an action applied to a bug by a rule
+type Change struct {
+	Rule  string
+	BugId entity.Id
+	Title string
+	// Action is the action with its arguments, the placeholders replaced
+	Action string
+}
+
+// Run apply the rules in order, each one seeing the changes of the previous
+// ones, and return the changes made. An action that would do nothing, like
+// adding a label already set, is skipped, so that running the rules again
+// doesn't change anything until the bugs evolve.
+//
+// The queries are not restricted to the current project of the repository.
+// With dryRun, the changes are only reported.
+func Run(backend *cache.RepoCache, rules []Rule, dryRun bool) ([]Change, error) {
+	var changes []Change
+
+	// the bugs changed, committed together at the end
+	var touched []*cache.BugCache
+
+	for _, rule := range rules {
+		q, err := cache.ParseQuery(rule.Query)
+		if err != nil {
+			return changes, finish(backend, touched, dryRun, errors.Wrapf(err, "rule %s", rule.Name))
+		}
+
+		for _, id := range backend.QueryBugs(q) {
+			b, err := backend.ResolveBug(id)
+			if err != nil {
+				return changes, finish(backend, touched, dryRun, err)
+			}
+
+			snap := b.Snapshot()
+
+			args, ok := expand(backend, snap, rule.Action.Args)
+			if !ok {
+				continue
+			}
+
+			expanded := action.Action{Name: rule.Action.Name, Args: args}
+
+			changed, err := expanded.Apply(backend, b)
+			if err != nil {
+				err = errors.Wrapf(err, "rule %s, bug %s", rule.Name, id.Human())
+				return changes, finish(backend, touched, dryRun, err)
+			}
+			if !changed {
+				continue
+			}
+
+			if !containsBug(touched, b) {
+				touched = append(touched, b)
+			}
+
+			changes = append(changes, Change{
+				Rule:   rule.Name,
+				BugId:  id,
+				Title:  snap.Title,
+				Action: expanded.String(),
+			})
+		}
+	}
+
+	return changes, finish(backend, touched, dryRun, nil)
+}
+
+// finish commit the changed bugs, or discard their changes with dryRun, and
+// return err if any
+func finish(backend *cache.RepoCache, touched []*cache.BugCache, dryRun bool, err error) error {
+	if dryRun {
+		for _, b := range touched {
+			_ = b.DiscardPendingOps()
+		}
+		return err
+	}
+
+	if errCommit := backend.CommitBugs(touched); errCommit != nil && err == nil {
+		return errCommit
+	}
+
+	return err
+}
+
+func containsBug(bugs []*cache.BugCache, b *cache.BugCache) bool {
+	for _, other := range bugs {
+		if other == b {
+			return true
+		}
+	}
+	return false
+}
+
+// expand replace the placeholders {author} and {assignee} in the arguments of
+// an action by the login, or else the name, of these identities. It returns
+// false if the bug has no assignee to fill in.
+func expand(backend *cache.RepoCache, snap *bug.Snapshot, args []string) ([]string, bool) {
+	result := make([]string, len(args))
+
+	for i, arg := range args {
+		if strings.Contains(arg, "{assignee}") {
+			if snap.Assignee == "" {
+				return nil, false
+			}
+			// the assignee might not be known locally
+			assignee, err := backend.ResolveIdentityExcerpt(snap.Assignee)
+			if err != nil {
+				return nil, false
+			}
+			arg = strings.Replace(arg, "{assignee}", mention(assignee.Login, assignee.DisplayName()), -1)
+		}
+
+		author := mention(snap.Author.Login(), snap.Author.DisplayName())
+		result[i] = strings.Replace(arg, "{author}", author, -1)
+	}
+
+	return result, true
+}
+
+func mention(login string, name string) string {
+	if login != "" {
+		return login
+	}
+	return name
+}
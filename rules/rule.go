@@ -0,0 +1,133 @@
+// Package rules automate the triage of the bugs, like labeling the stale ones
+// or pinging the assignees, with rules applying an action to the bugs matching
+// a query. The actions are regular operations, authored by the user identity.
+package rules
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/action"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+const rulesFile = "rules"
+
+var nameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+// Rule apply an action to the bugs matching a query
+type Rule struct {
+	Name   string
+	Query  string
+	Action action.Action
+}
+
+// String format the rule as in a rules file
+func (r Rule) String() string {
+	return fmt.Sprintf("%s: %s => %s", r.Name, r.Query, r.Action)
+}
+
+// Validate check that the rule is well formed
+func (r Rule) Validate() error {
+	if !nameRegexp.MatchString(r.Name) {
+		return fmt.Errorf("invalid rule name \"%s\"", r.Name)
+	}
+
+	if strings.TrimSpace(r.Query) == "" {
+		return fmt.Errorf("rule %s: missing query", r.Name)
+	}
+	if _, err := cache.ParseQuery(r.Query); err != nil {
+		return errors.Wrapf(err, "rule %s", r.Name)
+	}
+
+	if err := r.Action.Validate(); err != nil {
+		return errors.Wrapf(err, "rule %s", r.Name)
+	}
+
+	return nil
+}
+
+// DefaultPath return the path of the rules file of a repository
+func DefaultPath(repo repository.RepoCommon) string {
+	return path.Join(repo.GetPath(), "git-bug", rulesFile)
+}
+
+// Load read the rules of a file
+func Load(filePath string) ([]Rule, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rules, err := Parse(f)
+	if err != nil {
+		return nil, errors.Wrap(err, filePath)
+	}
+
+	return rules, nil
+}
+
+// Parse read the rules, one per line, as:
+//
+//	<name>: <query> => <action> [<args>...]
+//
+// The action and its arguments are words, double quotes grouping words.
+// Empty lines and lines starting with # are ignored.
+func Parse(r io.Reader) ([]Rule, error) {
+	var result []Rule
+	names := make(map[string]bool)
+
+	scanner := bufio.NewScanner(r)
+
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule, err := parseLine(line)
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", n)
+		}
+
+		if names[rule.Name] {
+			return nil, fmt.Errorf("line %d: duplicate rule %s", n, rule.Name)
+		}
+		names[rule.Name] = true
+
+		result = append(result, rule)
+	}
+
+	return result, scanner.Err()
+}
+
+func parseLine(line string) (Rule, error) {
+	// the name is followed by a space, unlike a qualifier of the query
+	colon := strings.Index(line, ":")
+	arrow := strings.Index(line, "=>")
+	if colon < 0 || arrow < colon || !unicode.IsSpace(rune(line[colon+1])) {
+		return Rule{}, fmt.Errorf("expected \"<name>: <query> => <action> [<args>...]\"")
+	}
+
+	words := action.SplitWords(line[arrow+2:])
+
+	rule := Rule{
+		Name:  strings.TrimSpace(line[:colon]),
+		Query: strings.TrimSpace(line[colon+1 : arrow]),
+	}
+	if len(words) > 0 {
+		rule.Action = action.Action{Name: words[0], Args: words[1:]}
+	}
+
+	return rule, rule.Validate()
+}
@@ -0,0 +1,113 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/action"
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestParse(t *testing.T) {
+	rules, err := Parse(strings.NewReader(`
+# label the forgotten bugs
+stale: status:open edited-before:60d => label add stale
+ping:  status:open author:"René Descartes" => comment "@{assignee} any news?"
+`))
+	require.NoError(t, err)
+	require.Equal(t, []Rule{
+		{Name: "stale", Query: "status:open edited-before:60d", Action: action.Action{Name: "label", Args: []string{"add", "stale"}}},
+		{Name: "ping", Query: `status:open author:"René Descartes"`, Action: action.Action{Name: "comment", Args: []string{"@{assignee} any news?"}}},
+	}, rules)
+	require.Equal(t, `ping: status:open author:"René Descartes" => comment "@{assignee} any news?"`, rules[1].String())
+
+	invalid := []string{
+		"status:open => close",
+		"stale status:open => close",
+		"stale: status:open",
+		"stale: => close",
+		"stale: status:open =>",
+		"stale: status:open => delete",
+		"stale: status:open => close now",
+		"stale: status:open => label stale",
+		"stale: status:foo => close",
+		"stale: status:open => close\nstale: status:closed => open",
+	}
+	for _, s := range invalid {
+		_, err := Parse(strings.NewReader(s))
+		require.Error(t, err, s)
+	}
+}
+
+func TestRun(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	backend, err := cache.NewRepoCache(repo)
+	require.NoError(t, err)
+	defer backend.Close()
+
+	rene, err := backend.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+	require.NoError(t, backend.SetUserIdentity(rene))
+	isaac, err := backend.NewIdentityRaw("Isaac Newton", "isaac@newton.uk", "isaac", "", nil)
+	require.NoError(t, err)
+
+	assigned, _, err := backend.NewBug("assigned", "message")
+	require.NoError(t, err)
+	_, err = assigned.SetAssignee(isaac)
+	require.NoError(t, err)
+	require.NoError(t, assigned.Commit())
+
+	unassigned, _, err := backend.NewBug("unassigned", "message")
+	require.NoError(t, err)
+
+	closed, _, err := backend.NewBug("closed", "message")
+	require.NoError(t, err)
+	_, err = closed.Close()
+	require.NoError(t, err)
+	require.NoError(t, closed.Commit())
+
+	rules, err := Parse(strings.NewReader(`
+recent: status:open edited-before:60d => label add stale
+stale: status:open edited-before:2100-01-01 => label add stale
+ping: status:open label:stale => comment "@{assignee} any news?"
+`))
+	require.NoError(t, err)
+
+	// a dry run report the changes of a real run, the rules seeing the
+	// changes of the previous ones
+	changes, err := Run(backend, rules, true)
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+	require.Len(t, assigned.Snapshot().Labels, 0)
+	require.Len(t, assigned.Snapshot().Comments, 1)
+
+	changes, err = Run(backend, rules, false)
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+	require.Equal(t, "stale", changes[0].Rule)
+	require.Equal(t, "label add stale", changes[0].Action)
+	require.Equal(t, Change{
+		Rule:   "ping",
+		BugId:  assigned.Id(),
+		Title:  "assigned",
+		Action: `comment "@isaac any news?"`,
+	}, changes[2])
+
+	require.Equal(t, []bug.Label{"stale"}, assigned.Snapshot().Labels)
+	require.Equal(t, []bug.Label{"stale"}, unassigned.Snapshot().Labels)
+	require.Len(t, closed.Snapshot().Labels, 0)
+	require.Len(t, assigned.Snapshot().Comments, 2)
+	// no assignee to ping
+	require.Len(t, unassigned.Snapshot().Comments, 1)
+
+	// the labels are already set, only the ping is repeated
+	changes, err = Run(backend, rules[:2], false)
+	require.NoError(t, err)
+	require.Len(t, changes, 0)
+}
@@ -0,0 +1,248 @@
+package termui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/MichaelMure/gocui"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/text"
+)
+
+const bugPickerInputView = "bugPickerInputView"
+const bugPickerListView = "bugPickerListView"
+
+// bugPicker is a standalone fuzzy finder over the open bugs, to choose a bug
+// without starting the complete terminal UI
+type bugPicker struct {
+	excerpts []*cache.BugExcerpt
+	matching []*cache.BugExcerpt
+	selected int
+	picked   entity.Id
+}
+
+// PickBug let the user choose an open bug, filtering them by typing part of
+// their id or title. It returns nil if the user aborted or if there is no open
+// bug.
+func PickBug(repo *cache.RepoCache) (*cache.BugCache, error) {
+	query, err := repo.ParseQuery("status:open sort:edit-desc")
+	if err != nil {
+		return nil, err
+	}
+
+	ids := repo.QueryBugs(query)
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	bp := &bugPicker{}
+	for _, id := range ids {
+		excerpt, err := repo.ResolveBugExcerpt(id)
+		if err != nil {
+			return nil, err
+		}
+		bp.excerpts = append(bp.excerpts, excerpt)
+	}
+	bp.matching = bp.excerpts
+
+	g, err := gocui.NewGui(gocui.OutputNormal)
+	if err != nil {
+		return nil, err
+	}
+
+	g.InputEsc = true
+	g.Cursor = true
+	g.SetManagerFunc(bp.layout)
+
+	if err := bp.keybindings(g); err != nil {
+		g.Close()
+		return nil, err
+	}
+
+	err = g.MainLoop()
+	g.Close()
+
+	if err != nil && err != gocui.ErrQuit {
+		return nil, err
+	}
+
+	if bp.picked == "" {
+		return nil, nil
+	}
+
+	return repo.ResolveBug(bp.picked)
+}
+
+func (bp *bugPicker) keybindings(g *gocui.Gui) error {
+	// Abort
+	if err := g.SetKeybinding("", gocui.KeyCtrlC, gocui.ModNone, bp.abort); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(bugPickerInputView, gocui.KeyEsc, gocui.ModNone, bp.abort); err != nil {
+		return err
+	}
+
+	// Pick
+	if err := g.SetKeybinding(bugPickerInputView, gocui.KeyEnter, gocui.ModNone, bp.pick); err != nil {
+		return err
+	}
+
+	// Up
+	if err := g.SetKeybinding(bugPickerInputView, gocui.KeyArrowUp, gocui.ModNone, bp.selectPrevious); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(bugPickerInputView, gocui.KeyCtrlP, gocui.ModNone, bp.selectPrevious); err != nil {
+		return err
+	}
+
+	// Down
+	if err := g.SetKeybinding(bugPickerInputView, gocui.KeyArrowDown, gocui.ModNone, bp.selectNext); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(bugPickerInputView, gocui.KeyCtrlN, gocui.ModNone, bp.selectNext); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (bp *bugPicker) layout(g *gocui.Gui) error {
+	maxX, maxY := g.Size()
+
+	v, err := g.SetView(bugPickerInputView, 0, 0, maxX-1, 2)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Title = "Pick a bug [enter] Pick [esc] Abort [↓↑] Nav"
+		v.Editable = true
+		v.Editor = gocui.EditorFunc(func(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) {
+			gocui.DefaultEditor.Edit(v, key, ch, mod)
+			bp.filter(v.Buffer())
+		})
+	}
+
+	if _, err := g.SetCurrentView(bugPickerInputView); err != nil {
+		return err
+	}
+
+	v, err = g.SetView(bugPickerListView, 0, 2, maxX-1, maxY-1)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = false
+	}
+
+	v.Clear()
+
+	width, height := v.Size()
+	start := 0
+	if bp.selected >= height {
+		start = bp.selected - height + 1
+	}
+
+	for i := start; i < len(bp.matching) && i < start+height; i++ {
+		excerpt := bp.matching[i]
+
+		prefix := "  "
+		if i == bp.selected {
+			prefix = "> "
+		}
+
+		title := text.LeftPadMaxLine(excerpt.Title, maxInt(width-12, 0), 0)
+		_, _ = fmt.Fprintf(v, "%s%s %s\n", prefix, colors.Cyan(excerpt.Id.Human()), title)
+	}
+
+	return nil
+}
+
+// filter keep the bugs matching the query, best matches first
+func (bp *bugPicker) filter(query string) {
+	query = strings.ToLower(strings.Join(strings.Fields(query), ""))
+
+	type scored struct {
+		excerpt *cache.BugExcerpt
+		score   int
+	}
+
+	var matching []scored
+	for _, excerpt := range bp.excerpts {
+		candidate := strings.ToLower(excerpt.Id.Human() + " " + excerpt.Title)
+		if score, ok := fuzzyMatch(query, candidate); ok {
+			matching = append(matching, scored{excerpt, score})
+		}
+	}
+
+	// stable, to keep the most recently edited first for equal scores
+	sort.SliceStable(matching, func(i, j int) bool {
+		return matching[i].score < matching[j].score
+	})
+
+	bp.matching = make([]*cache.BugExcerpt, len(matching))
+	for i, m := range matching {
+		bp.matching[i] = m.excerpt
+	}
+	bp.selected = 0
+}
+
+// fuzzyMatch tell if the characters of the query appear in order in the
+// candidate. The score is lower for better matches: early and contiguous.
+func fuzzyMatch(query string, candidate string) (int, bool) {
+	runes := []rune(candidate)
+	score := 0
+	pos := 0
+	last := -1
+
+	for _, q := range query {
+		for pos < len(runes) && runes[pos] != q {
+			pos++
+		}
+		if pos == len(runes) {
+			return 0, false
+		}
+
+		if last == -1 {
+			score += pos
+		} else {
+			score += pos - last - 1
+		}
+
+		last = pos
+		pos++
+	}
+
+	return score, true
+}
+
+func (bp *bugPicker) selectPrevious(g *gocui.Gui, v *gocui.View) error {
+	if bp.selected > 0 {
+		bp.selected--
+	}
+	return nil
+}
+
+func (bp *bugPicker) selectNext(g *gocui.Gui, v *gocui.View) error {
+	if bp.selected < len(bp.matching)-1 {
+		bp.selected++
+	}
+	return nil
+}
+
+func (bp *bugPicker) pick(g *gocui.Gui, v *gocui.View) error {
+	if len(bp.matching) == 0 {
+		return nil
+	}
+	bp.picked = bp.matching[bp.selected].Id
+	return gocui.ErrQuit
+}
+
+func (bp *bugPicker) abort(g *gocui.Gui, v *gocui.View) error {
+	return gocui.ErrQuit
+}
@@ -3,8 +3,11 @@ package termui
 import (
 	"bytes"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
+	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/entity"
 	"github.com/MichaelMure/git-bug/util/colors"
@@ -17,10 +20,19 @@ const bugTableView = "bugTableView"
 const bugTableHeaderView = "bugTableHeaderView"
 const bugTableFooterView = "bugTableFooterView"
 const bugTableInstructionView = "bugTableInstructionView"
+const bugTableSearchLabelView = "bugTableSearchLabelView"
+const bugTableSearchView = "bugTableSearchView"
 
 const defaultRemote = "origin"
 const defaultQuery = "status:open"
 
+// the sortings cycled through, each with its default direction
+var bugTableSortings = []string{"creation-desc", "edit-desc", "id-asc", "title-asc", "comments-desc"}
+
+var sortQualifierRegexp = regexp.MustCompile(`(?i)(^|\s)sort:\S+`)
+
+const bulkActionsHelp = "close, open, label <label>..., unlabel <label>..., assign <user>, unassign"
+
 type bugTable struct {
 	repo         *cache.RepoCache
 	queryStr     string
@@ -29,6 +41,20 @@ type bugTable struct {
 	excerpts     []*cache.BugExcerpt
 	pageCursor   int
 	selectCursor int
+
+	// bugs marked for a bulk action, kept when changing page or query
+	marked map[entity.Id]bool
+
+	// live filtering with the search box, the query before opening it being
+	// restored if the search is aborted
+	searching       bool
+	searchInvalid   bool
+	searchPrevStr   string
+	searchPrevQuery *cache.Query
+
+	// the last time bugs were changed outside of the termui
+	updatedAt    time.Time
+	updatedCount int
 }
 
 func newBugTable(c *cache.RepoCache) *bugTable {
@@ -43,6 +69,7 @@ func newBugTable(c *cache.RepoCache) *bugTable {
 		queryStr:     defaultQuery,
 		pageCursor:   0,
 		selectCursor: 0,
+		marked:       make(map[entity.Id]bool),
 	}
 }
 
@@ -76,8 +103,8 @@ func (bt *bugTable) layout(g *gocui.Gui) error {
 
 		v.Frame = false
 		v.Highlight = true
-		v.SelBgColor = gocui.ColorWhite
-		v.SelFgColor = gocui.ColorBlack
+		v.SelBgColor = ui.config.theme.selectionBg
+		v.SelFgColor = ui.config.theme.selectionFg
 
 		// restore the cursor
 		// window is too small to set the cursor properly, ignoring the error
@@ -119,18 +146,69 @@ func (bt *bugTable) layout(g *gocui.Gui) error {
 		}
 
 		v.Frame = false
-		v.BgColor = gocui.ColorBlue
+		v.BgColor = ui.config.theme.instructions
 
-		_, _ = fmt.Fprintf(v, "[q] Quit [s] Search [←↓↑→,hjkl] Navigation [↵] Open bug [n] New bug [i] Pull [o] Push")
+		renderButtons(v, bt.buttons())
+	}
+
+	if bt.searching {
+		return bt.layoutSearch(g, maxX, maxY)
 	}
 
 	_, err = g.SetCurrentView(bugTableView)
 	return err
 }
 
+// layoutSearch display the search box over the instructions
+func (bt *bugTable) layoutSearch(g *gocui.Gui, maxX int, maxY int) error {
+	const label = "Filter: "
+
+	v, err := g.SetView(bugTableSearchLabelView, -1, maxY-2, len(label), maxY)
+
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = false
+		v.BgColor = ui.config.theme.instructions
+
+		_, _ = fmt.Fprint(v, label)
+	}
+
+	v, err = g.SetView(bugTableSearchView, len(label)-1, maxY-2, maxX, maxY)
+
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = false
+		v.Editable = true
+		v.Editor = gocui.EditorFunc(func(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) {
+			gocui.DefaultEditor.Edit(v, key, ch, mod)
+			bt.search(g, v.Buffer())
+		})
+
+		_, _ = fmt.Fprint(v, bt.queryStr)
+		_ = v.SetCursor(len([]rune(bt.queryStr)), 0)
+	}
+
+	// an invalid query is shown in red, the last valid one still applying
+	v.FgColor = gocui.ColorDefault
+	if bt.searchInvalid {
+		v.FgColor = ui.config.theme.error
+	}
+
+	g.Cursor = true
+
+	_, err = g.SetCurrentView(bugTableSearchView)
+	return err
+}
+
 func (bt *bugTable) keybindings(g *gocui.Gui) error {
 	// Quit
-	if err := g.SetKeybinding(bugTableView, 'q', gocui.ModNone, quit); err != nil {
+	if err := g.SetKeybinding(bugTableView, ui.config.keys["quit"], gocui.ModNone, quit); err != nil {
 		return err
 	}
 
@@ -181,7 +259,7 @@ func (bt *bugTable) keybindings(g *gocui.Gui) error {
 	}
 
 	// New bug
-	if err := g.SetKeybinding(bugTableView, 'n', gocui.ModNone,
+	if err := g.SetKeybinding(bugTableView, ui.config.keys["new"], gocui.ModNone,
 		bt.newBug); err != nil {
 		return err
 	}
@@ -193,26 +271,102 @@ func (bt *bugTable) keybindings(g *gocui.Gui) error {
 	}
 
 	// Pull
-	if err := g.SetKeybinding(bugTableView, 'i', gocui.ModNone,
+	if err := g.SetKeybinding(bugTableView, ui.config.keys["pull"], gocui.ModNone,
 		bt.pull); err != nil {
 		return err
 	}
 
 	// Push
-	if err := g.SetKeybinding(bugTableView, 'o', gocui.ModNone,
+	if err := g.SetKeybinding(bugTableView, ui.config.keys["push"], gocui.ModNone,
 		bt.push); err != nil {
 		return err
 	}
 
 	// Query
-	if err := g.SetKeybinding(bugTableView, 's', gocui.ModNone,
+	if err := g.SetKeybinding(bugTableView, ui.config.keys["search"], gocui.ModNone,
 		bt.changeQuery); err != nil {
 		return err
 	}
 
+	// Marks and bulk action
+	if err := g.SetKeybinding(bugTableView, ui.config.keys["mark"], gocui.ModNone,
+		bt.toggleMark); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(bugTableView, ui.config.keys["unmark-all"], gocui.ModNone,
+		bt.clearMarks); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(bugTableView, ui.config.keys["bulk-action"], gocui.ModNone,
+		bt.bulkAction); err != nil {
+		return err
+	}
+
+	// Sorting
+	if err := g.SetKeybinding(bugTableView, ui.config.keys["sort"], gocui.ModNone,
+		bt.cycleSort); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(bugTableView, ui.config.keys["reverse-sort"], gocui.ModNone,
+		bt.reverseSort); err != nil {
+		return err
+	}
+
+	// Filter
+	if err := g.SetKeybinding(bugTableView, ui.config.keys["filter"], gocui.ModNone,
+		bt.openSearch); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(bugTableSearchView, gocui.KeyEnter, gocui.ModNone,
+		bt.validateSearch); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(bugTableSearchView, gocui.KeyEsc, gocui.ModNone,
+		bt.abortSearch); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// buttons return the actions of the instruction bar
+func (bt *bugTable) buttons() []button {
+	conf := ui.config
+	return []button{
+		{conf.keyHelp("quit", "Quit"), quit},
+		{conf.keyHelp("filter", "Filter"), bt.openSearch},
+		{conf.keyHelp("search", "Search"), bt.changeQuery},
+		{conf.keyHelp("sort", "Sort"), bt.cycleSort},
+		{"[←↓↑→,hjkl] Nav", nil},
+		{"[↵] Open bug", bt.openBug},
+		{conf.keyHelp("new", "New bug"), bt.newBug},
+		{conf.keyHelp("mark", "Mark"), bt.toggleMark},
+		{conf.keyHelp("unmark-all", "Unmark all"), bt.clearMarks},
+		{conf.keyHelp("pull", "Pull"), bt.pull},
+		{conf.keyHelp("push", "Push"), bt.push},
+	}
+}
+
+// click select the clicked bug, or run the clicked action. The cursor of the
+// table is moved by gocui.
+func (bt *bugTable) click(g *gocui.Gui, v *gocui.View) error {
+	if v.Name() == bugTableInstructionView {
+		return clickButton(g, v, bugTableView, bt.buttons())
+	}
 	return nil
 }
 
+func (bt *bugTable) wheel(g *gocui.Gui, v *gocui.View, down bool) error {
+	v, err := g.View(bugTableView)
+	if err != nil {
+		return err
+	}
+	if down {
+		return bt.cursorDown(g, v)
+	}
+	return bt.cursorUp(g, v)
+}
+
 func (bt *bugTable) disable(g *gocui.Gui) error {
 	if err := g.DeleteView(bugTableView); err != nil && err != gocui.ErrUnknownView {
 		return err
@@ -226,7 +380,7 @@ func (bt *bugTable) disable(g *gocui.Gui) error {
 	if err := g.DeleteView(bugTableInstructionView); err != nil && err != gocui.ErrUnknownView {
 		return err
 	}
-	return nil
+	return bt.closeSearch(g)
 }
 
 func (bt *bugTable) paginate(max int) error {
@@ -273,7 +427,8 @@ func (bt *bugTable) getColumnWidths(maxX int) map[string]int {
 	m["id"] = 9
 	m["status"] = 7
 
-	left := maxX - 5 - m["id"] - m["status"]
+	m["mark"] = 1
+	left := maxX - 5 - m["mark"] - m["id"] - m["status"]
 
 	m["summary"] = 10
 	left -= m["summary"]
@@ -315,7 +470,13 @@ func (bt *bugTable) render(v *gocui.View, maxX int) {
 		summary := text.LeftPadMaxLine(summaryTxt, columnWidths["summary"], 1)
 		lastEdit := text.LeftPadMaxLine(humanize.Time(lastEditTime), columnWidths["lastEdit"], 1)
 
-		_, _ = fmt.Fprintf(v, "%s %s %s %s %s %s\n",
+		mark := " "
+		if bt.marked[excerpt.Id] {
+			mark = colors.Green("*")
+		}
+
+		_, _ = fmt.Fprintf(v, "%s%s %s %s %s %s %s\n",
+			mark,
 			colors.Cyan(id),
 			colors.Yellow(status),
 			title,
@@ -336,13 +497,25 @@ func (bt *bugTable) renderHeader(v *gocui.View, maxX int) {
 	summary := text.LeftPadMaxLine("SUMMARY", columnWidths["summary"], 1)
 	lastEdit := text.LeftPadMaxLine("LAST EDIT", columnWidths["lastEdit"], 1)
 
-	_, _ = fmt.Fprintf(v, "\n")
-	_, _ = fmt.Fprintf(v, "%s %s %s %s %s %s\n", id, status, title, author, summary, lastEdit)
+	_, _ = fmt.Fprintf(v, " Sort: %s\n", bt.sortingHelp())
+	_, _ = fmt.Fprintf(v, " %s %s %s %s %s %s\n", id, status, title, author, summary, lastEdit)
 
 }
 
 func (bt *bugTable) renderFooter(v *gocui.View, maxX int) {
-	_, _ = fmt.Fprintf(v, " \nShowing %d of %d bugs", len(bt.excerpts), len(bt.allIds))
+	if len(bt.marked) > 0 {
+		_, _ = fmt.Fprintf(v, "%s %s: %s",
+			colors.Green(fmt.Sprintf("%d marked", len(bt.marked))),
+			ui.config.keyHelp("bulk-action", "Action"), bulkActionsHelp)
+	}
+
+	_, _ = fmt.Fprintf(v, " \nShowing %d of %d bugs, query: %s",
+		len(bt.excerpts), len(bt.allIds), colors.Cyan(bt.queryStr))
+
+	if bt.updatedCount > 0 {
+		_, _ = fmt.Fprint(v, colors.Green(fmt.Sprintf(" (%d updated at %s)",
+			bt.updatedCount, bt.updatedAt.Format("15:04"))))
+	}
 }
 
 func (bt *bugTable) cursorDown(g *gocui.Gui, v *gocui.View) error {
@@ -435,7 +608,8 @@ func (bt *bugTable) previousPage(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (bt *bugTable) newBug(g *gocui.Gui, v *gocui.View) error {
-	return newBugWithEditor(bt.repo)
+	ui.composer.NewBug()
+	return ui.activateWindow(ui.composer)
 }
 
 func (bt *bugTable) openBug(g *gocui.Gui, v *gocui.View) error {
@@ -532,3 +706,281 @@ func (bt *bugTable) push(g *gocui.Gui, v *gocui.View) error {
 func (bt *bugTable) changeQuery(g *gocui.Gui, v *gocui.View) error {
 	return editQueryWithEditor(bt)
 }
+
+func (bt *bugTable) openSearch(g *gocui.Gui, v *gocui.View) error {
+	bt.searching = true
+	bt.searchInvalid = false
+	bt.searchPrevStr = bt.queryStr
+	bt.searchPrevQuery = bt.query
+	return nil
+}
+
+// search apply the query typed in the search box, if valid
+func (bt *bugTable) search(g *gocui.Gui, queryStr string) {
+	queryStr = strings.TrimSpace(queryStr)
+
+	query, err := bt.repo.ParseQuery(queryStr)
+	if err != nil {
+		bt.searchInvalid = true
+		return
+	}
+
+	bt.searchInvalid = false
+	bt.queryStr = queryStr
+	bt.query = query
+
+	// back to the top of the results
+	bt.pageCursor = 0
+	bt.selectCursor = 0
+	if v, err := g.View(bugTableView); err == nil {
+		_ = v.SetCursor(0, 0)
+	}
+}
+
+func (bt *bugTable) validateSearch(g *gocui.Gui, v *gocui.View) error {
+	bt.searching = false
+	return bt.closeSearch(g)
+}
+
+func (bt *bugTable) abortSearch(g *gocui.Gui, v *gocui.View) error {
+	bt.searching = false
+	bt.queryStr = bt.searchPrevStr
+	bt.query = bt.searchPrevQuery
+	return bt.closeSearch(g)
+}
+
+func (bt *bugTable) closeSearch(g *gocui.Gui) error {
+	if err := g.DeleteView(bugTableSearchLabelView); err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	if err := g.DeleteView(bugTableSearchView); err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	return nil
+}
+
+// sortingHelp describe the current sorting of the table
+func (bt *bugTable) sortingHelp() string {
+	var by string
+	switch bt.query.OrderBy {
+	case cache.OrderById:
+		by = "id"
+	case cache.OrderByCreation:
+		by = "creation"
+	case cache.OrderByEdit:
+		by = "last edit"
+	case cache.OrderByTitle:
+		by = "title"
+	case cache.OrderByComments:
+		by = "comments"
+	}
+
+	if bt.query.OrderDirection == cache.OrderDescending {
+		return by + " ▼"
+	}
+	return by + " ▲"
+}
+
+func (bt *bugTable) cycleSort(g *gocui.Gui, v *gocui.View) error {
+	by := strings.Split(bt.query.Sorting(), "-")[0]
+
+	next := bugTableSortings[0]
+	for i, sorting := range bugTableSortings {
+		if strings.HasPrefix(sorting, by+"-") {
+			next = bugTableSortings[(i+1)%len(bugTableSortings)]
+		}
+	}
+
+	return bt.setSorting(g, next)
+}
+
+func (bt *bugTable) reverseSort(g *gocui.Gui, v *gocui.View) error {
+	sorting := bt.query.Sorting()
+	if strings.HasSuffix(sorting, "-desc") {
+		sorting = strings.TrimSuffix(sorting, "-desc") + "-asc"
+	} else {
+		sorting = strings.TrimSuffix(sorting, "-asc") + "-desc"
+	}
+
+	return bt.setSorting(g, sorting)
+}
+
+// setSorting change the sorting of the query, replacing the sort qualifier of
+// the query string
+func (bt *bugTable) setSorting(g *gocui.Gui, sorting string) error {
+	queryStr := sortQualifierRegexp.ReplaceAllString(bt.queryStr, "")
+	queryStr = strings.TrimSpace(queryStr + " sort:" + sorting)
+
+	query, err := bt.repo.ParseQuery(queryStr)
+	if err != nil {
+		// a saved query can carry its own sorting
+		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+		return nil
+	}
+
+	bt.queryStr = queryStr
+	bt.query = query
+
+	// back to the top of the results
+	bt.pageCursor = 0
+	bt.selectCursor = 0
+	if v, err := g.View(bugTableView); err == nil {
+		_ = v.SetCursor(0, 0)
+	}
+
+	return nil
+}
+
+func (bt *bugTable) toggleMark(g *gocui.Gui, v *gocui.View) error {
+	_, y := v.Cursor()
+	if y >= len(bt.excerpts) {
+		return nil
+	}
+
+	id := bt.excerpts[y].Id
+	if bt.marked[id] {
+		delete(bt.marked, id)
+	} else {
+		bt.marked[id] = true
+	}
+
+	// ready to mark the next one
+	return bt.cursorDown(g, v)
+}
+
+func (bt *bugTable) clearMarks(g *gocui.Gui, v *gocui.View) error {
+	bt.marked = make(map[entity.Id]bool)
+	return nil
+}
+
+func (bt *bugTable) bulkAction(g *gocui.Gui, v *gocui.View) error {
+	if len(bt.marked) == 0 {
+		ui.msgPopup.Activate(msgPopupErrorTitle, "No bug marked, use [m] to mark bugs first.")
+		return nil
+	}
+
+	c := ui.inputPopup.Activate(fmt.Sprintf("Action on %d bug(s)", len(bt.marked)))
+
+	go func() {
+		input := <-c
+
+		g.Update(func(g *gocui.Gui) error {
+			msg, err := bt.applyBulkAction(strings.TrimSpace(input))
+			if err != nil {
+				ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+				return nil
+			}
+
+			bt.marked = make(map[entity.Id]bool)
+			ui.msgPopup.Activate("Done", msg)
+			return nil
+		})
+	}()
+
+	return nil
+}
+
+// applyBulkAction apply an action to all the marked bugs, each changed bug
+// being committed. A bug that wouldn't change is skipped.
+func (bt *bugTable) applyBulkAction(input string) (string, error) {
+	fields := strings.Fields(input)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("no action given, valid actions are: %s", bulkActionsHelp)
+	}
+
+	action, args := fields[0], fields[1:]
+
+	var apply func(b *cache.BugCache) (bool, error)
+
+	switch action {
+	case "open", "close":
+		status := bug.OpenStatus
+		if action == "close" {
+			status = bug.ClosedStatus
+		}
+		apply = func(b *cache.BugCache) (bool, error) {
+			if b.Snapshot().Status == status {
+				return false, nil
+			}
+			var err error
+			if status == bug.OpenStatus {
+				_, err = b.Open()
+			} else {
+				_, err = b.Close()
+			}
+			return err == nil, err
+		}
+
+	case "label", "unlabel":
+		if len(args) == 0 {
+			return "", fmt.Errorf("%s needs at least one label", action)
+		}
+		for _, l := range args {
+			if err := bug.Label(l).Validate(); err != nil {
+				return "", fmt.Errorf("invalid label \"%s\": %s", l, err)
+			}
+		}
+		apply = func(b *cache.BugCache) (bool, error) {
+			var added, removed []string
+			if action == "label" {
+				added = args
+			} else {
+				removed = args
+			}
+			results, op, err := b.ChangeLabels(added, removed)
+			// only already set or missing labels
+			if err != nil && results != nil && op == nil {
+				return false, nil
+			}
+			return err == nil, err
+		}
+
+	case "assign", "unassign":
+		var assignee *cache.IdentityCache
+		if action == "assign" {
+			if len(args) != 1 {
+				return "", fmt.Errorf("assign needs a single user")
+			}
+			var err error
+			assignee, err = bt.repo.ResolveIdentityUser(args[0])
+			if err != nil {
+				return "", err
+			}
+		}
+		apply = func(b *cache.BugCache) (bool, error) {
+			current := b.Snapshot().Assignee
+			if assignee == nil && current == "" || assignee != nil && current == assignee.Id() {
+				return false, nil
+			}
+			_, err := b.SetAssignee(assignee)
+			return err == nil, err
+		}
+
+	default:
+		return "", fmt.Errorf("unknown action \"%s\", valid actions are: %s", action, bulkActionsHelp)
+	}
+
+	changed := 0
+	for id := range bt.marked {
+		b, err := bt.repo.ResolveBug(id)
+		if err != nil {
+			return "", err
+		}
+
+		ok, err := apply(b)
+		if err != nil {
+			_ = b.DiscardPendingOps()
+			return "", fmt.Errorf("bug %s: %s", id.Human(), err)
+		}
+		if !ok {
+			continue
+		}
+
+		if err := b.Commit(); err != nil {
+			return "", fmt.Errorf("bug %s: %s", id.Human(), err)
+		}
+		changed++
+	}
+
+	return fmt.Sprintf("%d of %d bug(s) changed", changed, len(bt.marked)), nil
+}
@@ -0,0 +1,232 @@
+package termui
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/MichaelMure/gocui"
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/text"
+)
+
+const commentHistoryView = "commentHistoryView"
+const commentHistoryInstructionView = "commentHistoryInstructionView"
+
+// the revisions can be made the same day, the time is needed
+const historyTimeLayout = "Jan 2 2006 15:04"
+
+// commentHistory display the successive revisions of an edited comment, each
+// one as a diff with the previous one
+type commentHistory struct {
+	comment *bug.CommentTimelineItem
+	scroll  int
+}
+
+func newCommentHistory() *commentHistory {
+	return &commentHistory{}
+}
+
+func (ch *commentHistory) SetComment(comment *bug.CommentTimelineItem) {
+	ch.comment = comment
+	ch.scroll = 0
+}
+
+func (ch *commentHistory) keybindings(g *gocui.Gui) error {
+	// Return
+	if err := g.SetKeybinding(commentHistoryView, 'q', gocui.ModNone, ch.back); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(commentHistoryView, gocui.KeyEsc, gocui.ModNone, ch.back); err != nil {
+		return err
+	}
+
+	// Down
+	if err := g.SetKeybinding(commentHistoryView, 'j', gocui.ModNone, ch.scrollDown); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(commentHistoryView, gocui.KeyArrowDown, gocui.ModNone, ch.scrollDown); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(commentHistoryView, gocui.KeyPgdn, gocui.ModNone, ch.pageDown); err != nil {
+		return err
+	}
+
+	// Up
+	if err := g.SetKeybinding(commentHistoryView, 'k', gocui.ModNone, ch.scrollUp); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(commentHistoryView, gocui.KeyArrowUp, gocui.ModNone, ch.scrollUp); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(commentHistoryView, gocui.KeyPgup, gocui.ModNone, ch.pageUp); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ch *commentHistory) layout(g *gocui.Gui) error {
+	maxX, maxY := g.Size()
+
+	v, err := g.SetView(commentHistoryView, 0, 0, maxX-1, maxY-2)
+
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = false
+	}
+
+	width, height := v.Size()
+
+	var buffer bytes.Buffer
+	ch.render(&buffer)
+	content, lines := text.Wrap(buffer.String(), width)
+
+	v.Clear()
+	_, _ = fmt.Fprint(v, content)
+
+	// don't scroll past the end
+	ch.scroll = minInt(ch.scroll, maxInt(lines-height, 0))
+	if err := v.SetOrigin(0, ch.scroll); err != nil {
+		return err
+	}
+
+	v, err = g.SetView(commentHistoryInstructionView, -1, maxY-2, maxX, maxY)
+
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = false
+		v.BgColor = ui.config.theme.instructions
+
+		renderButtons(v, ch.buttons())
+	}
+
+	_, err = g.SetCurrentView(commentHistoryView)
+	return err
+}
+
+// buttons return the actions of the instruction bar
+func (ch *commentHistory) buttons() []button {
+	return []button{
+		{"[q] Return", ch.back},
+		{"[↓↑,jk] Scroll", nil},
+	}
+}
+
+func (ch *commentHistory) disable(g *gocui.Gui) error {
+	if err := g.DeleteView(commentHistoryView); err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	if err := g.DeleteView(commentHistoryInstructionView); err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	return nil
+}
+
+func (ch *commentHistory) render(w io.Writer) {
+	history := ch.comment.History
+
+	var previous []string
+
+	for i, step := range history {
+		// the first revision is made by the author of the comment
+		author := step.Author
+		if author == nil {
+			author = ch.comment.Author
+		}
+
+		_, _ = fmt.Fprintf(w, "%s by %s on %s\n\n",
+			colors.Bold(fmt.Sprintf("Revision %d of %d", i+1, len(history))),
+			colors.Magenta(author.DisplayName()),
+			step.UnixTime.Time().Format(historyTimeLayout),
+		)
+
+		current := strings.Split(step.Message, "\n")
+
+		if i == 0 {
+			for _, line := range current {
+				_, _ = fmt.Fprintf(w, "    %s\n", line)
+			}
+		} else {
+			renderLineDiff(w, previous, current)
+		}
+
+		_, _ = fmt.Fprintln(w)
+		previous = current
+	}
+}
+
+// renderLineDiff display the lines removed from a revision in red and the
+// lines added in green
+func renderLineDiff(w io.Writer, from []string, to []string) {
+	matcher := difflib.NewMatcher(from, to)
+
+	for _, op := range matcher.GetOpCodes() {
+		if op.Tag == 'e' {
+			for _, line := range from[op.I1:op.I2] {
+				_, _ = fmt.Fprintf(w, "    %s\n", line)
+			}
+			continue
+		}
+
+		// replaced lines are removed then added
+		for _, line := range from[op.I1:op.I2] {
+			_, _ = fmt.Fprintf(w, "  %s\n", colors.Red("- "+line))
+		}
+		for _, line := range to[op.J1:op.J2] {
+			_, _ = fmt.Fprintf(w, "  %s\n", colors.Green("+ "+line))
+		}
+	}
+}
+
+func (ch *commentHistory) scrollDown(g *gocui.Gui, v *gocui.View) error {
+	ch.scroll++
+	return nil
+}
+
+func (ch *commentHistory) scrollUp(g *gocui.Gui, v *gocui.View) error {
+	ch.scroll = maxInt(ch.scroll-1, 0)
+	return nil
+}
+
+func (ch *commentHistory) pageDown(g *gocui.Gui, v *gocui.View) error {
+	_, height := v.Size()
+	ch.scroll += height / 2
+	return nil
+}
+
+func (ch *commentHistory) pageUp(g *gocui.Gui, v *gocui.View) error {
+	_, height := v.Size()
+	ch.scroll = maxInt(ch.scroll-height/2, 0)
+	return nil
+}
+
+func (ch *commentHistory) click(g *gocui.Gui, v *gocui.View) error {
+	if v.Name() == commentHistoryInstructionView {
+		return clickButton(g, v, commentHistoryView, ch.buttons())
+	}
+	return nil
+}
+
+func (ch *commentHistory) wheel(g *gocui.Gui, v *gocui.View, down bool) error {
+	if down {
+		ch.scroll += mouseWheelLines
+	} else {
+		ch.scroll = maxInt(ch.scroll-mouseWheelLines, 0)
+	}
+	return nil
+}
+
+func (ch *commentHistory) back(g *gocui.Gui, v *gocui.View) error {
+	return ui.activateWindow(ui.showBug)
+}
@@ -0,0 +1,396 @@
+package termui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/gocui"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/util/colors"
+	"github.com/MichaelMure/git-bug/util/text"
+)
+
+const composerTitleView = "composerTitleView"
+const composerLabelsView = "composerLabelsView"
+const composerMessageView = "composerMessageView"
+const composerPreviewView = "composerPreviewView"
+const composerInstructionView = "composerInstructionView"
+
+// composer write a new bug or a new comment without leaving the termui: a
+// title field for a new bug, a message field with a live preview of its
+// markdown, and the labels of the new bug. The external editor is only used
+// on request.
+type composer struct {
+	repo *cache.RepoCache
+
+	// the bug commented, nil for a new bug
+	bug *cache.BugCache
+
+	title   string
+	message string
+	labels  []bug.Label
+
+	// the field being edited
+	focus string
+}
+
+func newComposer(repo *cache.RepoCache) *composer {
+	return &composer{
+		repo: repo,
+	}
+}
+
+// NewBug prepare the composer to write a new bug
+func (c *composer) NewBug() {
+	c.bug = nil
+	c.title = ""
+	c.message = ""
+	c.labels = nil
+	c.focus = composerTitleView
+}
+
+// NewComment prepare the composer to write a new comment on a bug
+func (c *composer) NewComment(b *cache.BugCache) {
+	c.bug = b
+	c.title = ""
+	c.message = ""
+	c.labels = nil
+	c.focus = composerMessageView
+}
+
+func (c *composer) keybindings(g *gocui.Gui) error {
+	for _, view := range []string{composerTitleView, composerMessageView} {
+		// Cancel
+		if err := g.SetKeybinding(view, gocui.KeyEsc, gocui.ModNone, c.cancel); err != nil {
+			return err
+		}
+
+		// Submit
+		if err := g.SetKeybinding(view, gocui.KeyCtrlS, gocui.ModNone, c.submit); err != nil {
+			return err
+		}
+
+		// Next field
+		if err := g.SetKeybinding(view, gocui.KeyTab, gocui.ModNone, c.nextField); err != nil {
+			return err
+		}
+
+		// Labels
+		if err := g.SetKeybinding(view, gocui.KeyCtrlL, gocui.ModNone, c.editLabels); err != nil {
+			return err
+		}
+
+		// Open in the editor
+		if err := g.SetKeybinding(view, gocui.KeyCtrlE, gocui.ModNone, c.openEditor); err != nil {
+			return err
+		}
+	}
+
+	// the title is a single line
+	if err := g.SetKeybinding(composerTitleView, gocui.KeyEnter, gocui.ModNone, c.nextField); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *composer) layout(g *gocui.Gui) error {
+	maxX, maxY := g.Size()
+
+	top := 0
+	if c.bug == nil {
+		if err := c.layoutHeader(g, maxX); err != nil {
+			return err
+		}
+		top = 3
+	}
+
+	split := maxX / 2
+
+	v, err := g.SetView(composerMessageView, 0, top, split-1, maxY-2)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = true
+		v.Editable = true
+		v.Wrap = true
+
+		if c.bug == nil {
+			v.Title = "Message"
+		} else {
+			snap := c.bug.Snapshot()
+			v.Title = fmt.Sprintf("Comment on [%s] %s", snap.Id().Human(), snap.Title)
+		}
+
+		writeEditable(v, c.message)
+	}
+
+	// the preview follow the content of the message field
+	message := v.Buffer()
+
+	v, err = g.SetView(composerPreviewView, split, top, maxX-1, maxY-2)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = true
+		v.Title = "Preview"
+	}
+
+	width, _ := v.Size()
+	content, _ := text.Wrap(renderMarkdown(strings.TrimSpace(message)), width)
+
+	v.Clear()
+	_, _ = fmt.Fprint(v, content)
+
+	v, err = g.SetView(composerInstructionView, -1, maxY-2, maxX, maxY)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = false
+		v.BgColor = ui.config.theme.instructions
+
+		renderButtons(v, c.buttons())
+	}
+
+	g.Cursor = true
+
+	_, err = g.SetCurrentView(c.focus)
+	return err
+}
+
+// layoutHeader layout the title and the labels of a new bug
+func (c *composer) layoutHeader(g *gocui.Gui, maxX int) error {
+	split := maxX * 2 / 3
+
+	v, err := g.SetView(composerTitleView, 0, 0, split-1, 2)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = true
+		v.Title = "Title"
+		v.Editable = true
+
+		writeEditable(v, c.title)
+	}
+
+	v, err = g.SetView(composerLabelsView, split, 0, maxX-1, 2)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+
+		v.Frame = true
+		v.Title = "Labels"
+	}
+
+	labels := make([]string, len(c.labels))
+	for i, l := range c.labels {
+		labels[i] = l.String()
+	}
+
+	v.Clear()
+	if len(labels) == 0 {
+		_, _ = fmt.Fprint(v, colors.Black("none"))
+	} else {
+		_, _ = fmt.Fprint(v, strings.Join(labels, " "))
+	}
+
+	return nil
+}
+
+// writeEditable fill an editable view and put the cursor at the end
+func writeEditable(v *gocui.View, content string) {
+	_, _ = fmt.Fprint(v, content)
+
+	lines := strings.Split(content, "\n")
+	last := lines[len(lines)-1]
+	_ = v.SetCursor(len([]rune(last)), len(lines)-1)
+}
+
+// buttons return the actions of the instruction bar
+func (c *composer) buttons() []button {
+	buttons := []button{
+		{"[Ctrl+S] Submit", c.submit},
+		{"[Esc] Cancel", c.cancel},
+	}
+	if c.bug == nil {
+		buttons = append(buttons,
+			button{"[Tab] Next field", c.nextField},
+			button{"[Ctrl+L] Labels", c.editLabels},
+		)
+	}
+	return append(buttons, button{"[Ctrl+E] Open in $EDITOR", c.openEditor})
+}
+
+func (c *composer) disable(g *gocui.Gui) error {
+	views := []string{
+		composerTitleView,
+		composerLabelsView,
+		composerMessageView,
+		composerPreviewView,
+		composerInstructionView,
+	}
+	for _, view := range views {
+		if err := g.DeleteView(view); err != nil && err != gocui.ErrUnknownView {
+			return err
+		}
+	}
+	return nil
+}
+
+// save keep the content of the fields, as the views don't survive a restart
+// of the gui
+func (c *composer) save(g *gocui.Gui) {
+	if v, err := g.View(composerTitleView); err == nil {
+		c.title = strings.TrimSpace(v.Buffer())
+	}
+	if v, err := g.View(composerMessageView); err == nil {
+		c.message = strings.TrimSpace(v.Buffer())
+	}
+}
+
+func (c *composer) nextField(g *gocui.Gui, v *gocui.View) error {
+	if c.bug != nil {
+		return nil
+	}
+
+	if c.focus == composerTitleView {
+		c.focus = composerMessageView
+	} else {
+		c.focus = composerTitleView
+	}
+
+	return nil
+}
+
+func (c *composer) editLabels(g *gocui.Gui, v *gocui.View) error {
+	if c.bug != nil {
+		return nil
+	}
+
+	current := make([]string, len(c.labels))
+	for i, l := range c.labels {
+		current[i] = l.String()
+	}
+
+	ch := ui.inputPopup.ActivateWithContent("Labels, space separated", strings.Join(current, " "))
+
+	go func() {
+		input := <-ch
+
+		var labels []bug.Label
+		for _, field := range strings.Fields(input) {
+			label := bug.Label(field)
+			if err := label.Validate(); err != nil {
+				ui.update(func(gui *gocui.Gui) error {
+					ui.msgPopup.Activate(msgPopupErrorTitle, fmt.Sprintf("Invalid label: %s", err))
+					return nil
+				})
+				return
+			}
+			labels = append(labels, label)
+		}
+
+		ui.update(func(gui *gocui.Gui) error {
+			c.labels = labels
+			return nil
+		})
+	}()
+
+	return nil
+}
+
+func (c *composer) submit(g *gocui.Gui, v *gocui.View) error {
+	c.save(g)
+
+	if c.bug != nil {
+		if c.message == "" {
+			ui.msgPopup.Activate(msgPopupErrorTitle, "Empty message.")
+			return nil
+		}
+
+		if _, err := c.bug.AddComment(c.message); err != nil {
+			return err
+		}
+
+		return ui.activateWindow(ui.showBug)
+	}
+
+	if c.title == "" {
+		ui.msgPopup.Activate(msgPopupErrorTitle, "Empty title.")
+		return nil
+	}
+
+	b, err := createBug(c.repo, c.title, c.message, c.labels)
+	if err != nil {
+		return err
+	}
+
+	ui.showBug.SetBug(b)
+	return ui.activateWindow(ui.showBug)
+}
+
+func (c *composer) cancel(g *gocui.Gui, v *gocui.View) error {
+	if c.bug != nil {
+		return ui.activateWindow(ui.showBug)
+	}
+	return ui.activateWindow(ui.bugTable)
+}
+
+func (c *composer) openEditor(g *gocui.Gui, v *gocui.View) error {
+	c.save(g)
+
+	if c.bug != nil {
+		return addCommentWithEditor(c.bug, c.message)
+	}
+	return newBugWithEditor(c.repo, c.title, c.message, c.labels)
+}
+
+func (c *composer) click(g *gocui.Gui, v *gocui.View) error {
+	switch v.Name() {
+	case composerInstructionView:
+		return clickButton(g, v, c.focus, c.buttons())
+	case composerTitleView, composerMessageView:
+		c.focus = v.Name()
+	case composerLabelsView:
+		return c.editLabels(g, v)
+	}
+	return nil
+}
+
+func (c *composer) wheel(g *gocui.Gui, v *gocui.View, down bool) error {
+	return nil
+}
+
+// createBug create a new bug, with its labels if any
+func createBug(repo *cache.RepoCache, title string, message string, labels []bug.Label) (*cache.BugCache, error) {
+	b, _, err := repo.NewBug(title, message)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(labels) == 0 {
+		return b, nil
+	}
+
+	added := make([]string, len(labels))
+	for i, l := range labels {
+		added[i] = l.String()
+	}
+
+	if _, _, err := b.ChangeLabels(added, nil); err != nil {
+		return nil, err
+	}
+
+	return b, b.Commit()
+}
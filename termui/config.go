@@ -0,0 +1,229 @@
+package termui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/MichaelMure/gocui"
+	"github.com/pkg/errors"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+const configLowColor = "git-bug.termui.low-color"
+const configMouse = "git-bug.termui.mouse"
+const configColorPrefix = "git-bug.termui.color."
+const configKeyPrefix = "git-bug.termui.key."
+
+var colorNames = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// theme hold the colors of the termui
+type theme struct {
+	instructions gocui.Attribute
+	selectionFg  gocui.Attribute
+	selectionBg  gocui.Attribute
+	error        gocui.Attribute
+}
+
+func defaultTheme() theme {
+	return theme{
+		instructions: gocui.ColorBlue,
+		selectionFg:  gocui.ColorBlack,
+		selectionBg:  gocui.ColorWhite,
+		error:        gocui.ColorRed,
+	}
+}
+
+// the actions whose key can be changed, grouped by window, with their
+// default key. Within a window, the keys must be distinct, including
+// from the fixed navigation keys.
+var (
+	bugTableKeys = []keyAction{
+		{"quit", 'q'},
+		{"filter", '/'},
+		{"search", 's'},
+		{"new", 'n'},
+		{"mark", 'm'},
+		{"unmark-all", 'M'},
+		{"bulk-action", 'a'},
+		{"pull", 'i'},
+		{"push", 'o'},
+		{"sort", 'S'},
+		{"reverse-sort", 'R'},
+	}
+	showBugKeys = []keyAction{
+		{"back", 'q'},
+		{"open-close", 'o'},
+		{"labels", 'L'},
+		{"edit", 'e'},
+		{"history", 'H'},
+		{"raw", 'r'},
+		{"comment", 'c'},
+		{"title", 't'},
+	}
+)
+
+const navigationKeys = "hjkl"
+
+type keyAction struct {
+	name string
+	key  rune
+}
+
+// config is the user configuration of the termui
+type config struct {
+	lowColor bool
+	mouse    bool
+	theme    theme
+	keys     map[string]rune
+}
+
+// readConfig read the configuration of the termui from the git config: the
+// low-color mode, the mouse support, the colors of the theme and the keys of
+// the actions
+func readConfig(repo *cache.RepoCache) (*config, error) {
+	conf := &config{
+		theme: defaultTheme(),
+		keys:  make(map[string]rune),
+	}
+
+	// the settings can be shared by all the repositories of the user
+	gitConfig := repo.AnyConfig()
+
+	lowColor, err := gitConfig.ReadBool(configLowColor)
+	switch err {
+	case nil:
+		conf.lowColor = lowColor
+	case repository.ErrNoConfigEntry:
+	default:
+		return nil, errors.Wrap(err, "can't read the termui configuration")
+	}
+
+	mouse, err := gitConfig.ReadBool(configMouse)
+	switch err {
+	case nil:
+		conf.mouse = mouse
+	case repository.ErrNoConfigEntry:
+	default:
+		return nil, errors.Wrap(err, "can't read the termui configuration")
+	}
+
+	colors, err := gitConfig.ReadAll(configColorPrefix)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't read the termui configuration")
+	}
+
+	for key, value := range colors {
+		var target *gocui.Attribute
+		switch strings.TrimPrefix(key, configColorPrefix) {
+		case "instructions":
+			target = &conf.theme.instructions
+		case "selection-fg":
+			target = &conf.theme.selectionFg
+		case "selection-bg":
+			target = &conf.theme.selectionBg
+		case "error":
+			target = &conf.theme.error
+		default:
+			return nil, fmt.Errorf("unknown termui color %s", key)
+		}
+
+		color, err := parseColor(value)
+		if err != nil {
+			return nil, errors.Wrap(err, key)
+		}
+
+		// keep the default color if the terminal can't display this one
+		if conf.lowColor && color > gocui.ColorWhite {
+			continue
+		}
+
+		*target = color
+	}
+
+	for _, actions := range [][]keyAction{bugTableKeys, showBugKeys} {
+		if err := conf.readKeys(gitConfig, actions); err != nil {
+			return nil, err
+		}
+	}
+
+	return conf, nil
+}
+
+func (conf *config) readKeys(gitConfig repository.ConfigRead, actions []keyAction) error {
+	used := make(map[rune]string)
+	for _, r := range navigationKeys {
+		used[r] = "navigation"
+	}
+
+	for _, action := range actions {
+		key := action.key
+
+		value, err := gitConfig.ReadString(configKeyPrefix + action.name)
+		switch err {
+		case nil:
+			if utf8.RuneCountInString(value) != 1 {
+				return fmt.Errorf("invalid %s%s: a single character is expected", configKeyPrefix, action.name)
+			}
+			key, _ = utf8.DecodeRuneInString(value)
+		case repository.ErrNoConfigEntry:
+		default:
+			return errors.Wrap(err, "can't read the termui configuration")
+		}
+
+		if other, ok := used[key]; ok {
+			return fmt.Errorf("the key %c of the termui action %s is already used by %s", key, action.name, other)
+		}
+		used[key] = action.name
+
+		conf.keys[action.name] = key
+	}
+
+	return nil
+}
+
+// outputMode return the terminal mode able to display the configured colors
+func (conf *config) outputMode() gocui.OutputMode {
+	if conf.lowColor {
+		return gocui.OutputNormal
+	}
+
+	t := conf.theme
+	for _, color := range []gocui.Attribute{t.instructions, t.selectionFg, t.selectionBg, t.error} {
+		if color > gocui.ColorWhite {
+			return gocui.Output256
+		}
+	}
+
+	return gocui.OutputNormal
+}
+
+// keyHelp format the help of an action for the instruction bars
+func (conf *config) keyHelp(action string, help string) string {
+	return fmt.Sprintf("[%c] %s", conf.keys[action], help)
+}
+
+func parseColor(value string) (gocui.Attribute, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	if value == "default" {
+		return gocui.ColorDefault, nil
+	}
+
+	for i, name := range colorNames {
+		if value == name {
+			return gocui.Attribute(i + 1), nil
+		}
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 || n > 255 {
+		return 0, fmt.Errorf("invalid color %s", value)
+	}
+
+	// the colors are shifted by one, 0 being the default color
+	return gocui.Attribute(n + 1), nil
+}
@@ -70,6 +70,7 @@ func (ip *inputPopup) layout(g *gocui.Gui) error {
 
 func (ip *inputPopup) close(g *gocui.Gui, v *gocui.View) error {
 	ip.title = ""
+	ip.preload = ""
 	ip.active = false
 	return g.DeleteView(inputPopupView)
 }
@@ -83,6 +84,7 @@ func (ip *inputPopup) validate(g *gocui.Gui, v *gocui.View) error {
 	}
 
 	ip.title = ""
+	ip.preload = ""
 	ip.active = false
 	err = g.DeleteView(inputPopupView)
 	if err != nil {
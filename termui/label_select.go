@@ -138,7 +138,7 @@ func (ls *labelSelect) layout(g *gocui.Gui) error {
 			return err
 		}
 		v.Frame = false
-		v.BgColor = gocui.ColorBlue
+		v.BgColor = ui.config.theme.instructions
 	}
 	v.Clear()
 	fmt.Fprint(v, "[q] Save and close [↓↑,jk] Nav [a] Add item")
@@ -223,9 +223,17 @@ func (ls *labelSelect) addItem(g *gocui.Gui, v *gocui.View) error {
 		input := <-c
 
 		// Standardize label format
-		input = strings.TrimSuffix(input, "\n")
+		input = strings.TrimSpace(input)
 		input = strings.Replace(input, " ", "-", -1)
 
+		if err := bug.Label(input).Validate(); err != nil {
+			g.Update(func(gui *gocui.Gui) error {
+				ui.msgPopup.Activate(msgPopupErrorTitle, fmt.Sprintf("Invalid label: %s", err))
+				return nil
+			})
+			return
+		}
+
 		// Check if label already exists
 		for i, label := range ls.labels {
 			if input == label.String() {
@@ -296,6 +304,10 @@ func (ls *labelSelect) saveAndReturn(g *gocui.Gui, v *gocui.View) error {
 		}
 	}
 
+	if len(newLabels) == 0 && len(rmLabels) == 0 {
+		return ui.activateWindow(ui.showBug)
+	}
+
 	if _, _, err := ls.bug.ChangeLabels(newLabels, rmLabels); err != nil {
 		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
 	}
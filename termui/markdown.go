@@ -0,0 +1,94 @@
+package termui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/MichaelMure/git-bug/util/colors"
+)
+
+var (
+	mdHeaderRegexp    = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	mdListRegexp      = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	mdBoldRegexp      = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	mdStarEmphRegexp  = regexp.MustCompile(`\*([^*\s][^*]*)\*`)
+	mdUnderEmphRegexp = regexp.MustCompile(`(^|\W)_([^_\s][^_]*)_(\W|$)`)
+)
+
+// renderMarkdown style the basic markdown constructs of a message for the
+// terminal: headers, emphasis, inline code, code blocks, quotes and lists.
+// Anything else is kept as is.
+func renderMarkdown(message string) string {
+	lines := strings.Split(message, "\n")
+	result := make([]string, 0, len(lines))
+	inCode := false
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inCode = !inCode
+			continue
+		}
+
+		if inCode {
+			result = append(result, "    "+colors.Cyan(line))
+			continue
+		}
+
+		if m := mdHeaderRegexp.FindStringSubmatch(trimmed); m != nil {
+			if len(m[1]) <= 2 {
+				result = append(result, colors.YellowBold(m[2]))
+			} else {
+				result = append(result, colors.Bold(m[2]))
+			}
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, ">") {
+			quote := strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+			result = append(result, colors.Green("│ ")+renderMarkdownInline(quote))
+			continue
+		}
+
+		if m := mdListRegexp.FindStringSubmatch(line); m != nil {
+			result = append(result, m[1]+"• "+renderMarkdownInline(m[2]))
+			continue
+		}
+
+		result = append(result, renderMarkdownInline(line))
+	}
+
+	return strings.Join(result, "\n")
+}
+
+// renderMarkdownInline style the emphasis and the inline code of a line
+func renderMarkdownInline(line string) string {
+	// the odd parts are inline code, not to be styled further
+	parts := strings.Split(line, "`")
+
+	// an unbalanced backtick is kept as is
+	if len(parts)%2 == 0 {
+		parts[len(parts)-2] += "`" + parts[len(parts)-1]
+		parts = parts[:len(parts)-1]
+	}
+
+	for i, part := range parts {
+		if i%2 == 1 {
+			parts[i] = colors.Cyan(part)
+			continue
+		}
+
+		part = mdBoldRegexp.ReplaceAllStringFunc(part, func(s string) string {
+			return colors.Bold(s[2 : len(s)-2])
+		})
+		part = mdStarEmphRegexp.ReplaceAllStringFunc(part, func(s string) string {
+			return colors.Underline(s[1 : len(s)-1])
+		})
+		part = mdUnderEmphRegexp.ReplaceAllString(part, "$1"+colors.Underline("$2")+"$3")
+
+		parts[i] = part
+	}
+
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,94 @@
+package termui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MichaelMure/gocui"
+	"github.com/mattn/go-runewidth"
+)
+
+// number of lines scrolled by a step of the mouse wheel
+const mouseWheelLines = 3
+
+// mouseWindow is a window reacting to the mouse, if enabled in the config
+type mouseWindow interface {
+	// click is called with the view under the mouse, its cursor already moved
+	// to the clicked position
+	click(g *gocui.Gui, v *gocui.View) error
+	wheel(g *gocui.Gui, v *gocui.View, down bool) error
+}
+
+func mouseKeybindings(g *gocui.Gui) error {
+	if err := g.SetKeybinding("", gocui.MouseLeft, gocui.ModNone, mouseClick); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", gocui.MouseWheelDown, gocui.ModNone, mouseWheel(true)); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding("", gocui.MouseWheelUp, gocui.ModNone, mouseWheel(false)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// the popups are modal, the window below doesn't get the mouse events
+func popupActive() bool {
+	return ui.msgPopup.active || ui.inputPopup.active
+}
+
+func mouseClick(g *gocui.Gui, v *gocui.View) error {
+	if w, ok := ui.activeWindow.(mouseWindow); ok && !popupActive() {
+		return w.click(g, v)
+	}
+	return nil
+}
+
+func mouseWheel(down bool) func(g *gocui.Gui, v *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		if w, ok := ui.activeWindow.(mouseWindow); ok && !popupActive() {
+			return w.wheel(g, v, down)
+		}
+		return nil
+	}
+}
+
+// button is an action of an instruction bar, that can be clicked. The
+// handler is called with the view the action applies to.
+type button struct {
+	help    string
+	handler func(g *gocui.Gui, v *gocui.View) error
+}
+
+func renderButtons(v *gocui.View, buttons []button) {
+	helps := make([]string, len(buttons))
+	for i, b := range buttons {
+		helps[i] = b.help
+	}
+	_, _ = fmt.Fprint(v, strings.Join(helps, " "))
+}
+
+// clickButton run the action of the button under the cursor of an
+// instruction bar, on the target view
+func clickButton(g *gocui.Gui, bar *gocui.View, target string, buttons []button) error {
+	x, _ := bar.Cursor()
+
+	pos := 0
+	for _, b := range buttons {
+		width := runewidth.StringWidth(b.help)
+		if x >= pos && x < pos+width {
+			if b.handler == nil {
+				return nil
+			}
+
+			v, err := g.View(target)
+			if err != nil {
+				return err
+			}
+			return b.handler(g, v)
+		}
+		pos += width + 1
+	}
+
+	return nil
+}
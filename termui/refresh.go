@@ -0,0 +1,96 @@
+package termui
+
+import (
+	"time"
+
+	"github.com/MichaelMure/gocui"
+
+	"github.com/MichaelMure/git-bug/cache"
+)
+
+// interval between two checks of the bug refs, for the changes made outside
+// of the termui
+const refreshInterval = 5 * time.Second
+
+// watchChanges redraw the termui each time a bug change, and regularly look
+// for the bugs changed outside of the termui, for example by a git fetch. It
+// stops when done is closed.
+func watchChanges(repo *cache.RepoCache, done <-chan struct{}) {
+	events := repo.Subscribe()
+	defer repo.Unsubscribe(events)
+
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			ui.update(func(g *gocui.Gui) error {
+				return bugChanged(event)
+			})
+
+		case <-ticker.C:
+			// the changes are notified through the subscription as well
+			changes, err := repo.Refresh()
+			if err != nil {
+				ui.update(func(g *gocui.Gui) error {
+					ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
+					return nil
+				})
+				continue
+			}
+			if len(changes) == 0 {
+				continue
+			}
+			ui.update(func(g *gocui.Gui) error {
+				ui.bugTable.updatedAt = time.Now()
+				ui.bugTable.updatedCount = len(changes)
+				return nil
+			})
+		}
+	}
+}
+
+// bugChanged keep the displayed bug up to date. The bug table query the
+// cache at each redraw and doesn't need anything.
+func bugChanged(event cache.BugChangeEvent) error {
+	sb := ui.showBug
+	if sb.bug == nil || sb.bug.Id() != event.Id {
+		return nil
+	}
+
+	// the composer write a comment on the displayed bug, or a new bug
+	commenting := ui.composer.bug != nil
+
+	if event.Type == cache.BugRemoved {
+		switch ui.activeWindow {
+		case sb, ui.commentHistory, ui.labelSelect:
+		case ui.composer:
+			if !commenting {
+				return nil
+			}
+		default:
+			return nil
+		}
+		ui.msgPopup.Activate(msgPopupErrorTitle, "This bug has been removed.")
+		return ui.activateWindow(ui.bugTable)
+	}
+
+	// an outdated copy is dropped by the cache, a new one is read
+	b, err := ui.cache.ResolveBug(event.Id)
+	if err != nil {
+		return err
+	}
+	sb.bug = b
+	if commenting {
+		ui.composer.bug = b
+	}
+
+	return nil
+}
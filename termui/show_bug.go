@@ -29,6 +29,28 @@ type showBug struct {
 	selected           string
 	isOnSide           bool
 	scroll             int
+	// display the markdown source of the messages instead of rendering it
+	rawMarkdown bool
+
+	// the blocks of the timeline laid out so far, and the views of the ones
+	// currently rendered
+	blocks        []timelineBlock
+	layoutKey     timelineLayoutKey
+	renderedViews map[string]bool
+}
+
+// timelineBlock is the position in the timeline of a rendered bug header or
+// timeline item, independent of the scrolling
+type timelineBlock struct {
+	name  string
+	top   int
+	lines int
+}
+
+type timelineLayoutKey struct {
+	width       int
+	rawMarkdown bool
+	ops         int
 }
 
 func newShowBug(cache *cache.RepoCache) *showBug {
@@ -39,6 +61,8 @@ func newShowBug(cache *cache.RepoCache) *showBug {
 
 func (sb *showBug) SetBug(bug *cache.BugCache) {
 	sb.bug = bug
+	sb.blocks = nil
+	sb.layoutKey = timelineLayoutKey{}
 	sb.scroll = 0
 	sb.selected = ""
 	sb.isOnSide = false
@@ -91,11 +115,11 @@ func (sb *showBug) layout(g *gocui.Gui) error {
 
 		sb.childViews = append(sb.childViews, showBugInstructionView)
 		v.Frame = false
-		v.BgColor = gocui.ColorBlue
+		v.BgColor = ui.config.theme.instructions
 	}
 
 	v.Clear()
-	_, _ = fmt.Fprintf(v, "[q] Save and return [←↓↑→,hjkl] Navigation [o] Toggle open/close [e] Edit [c] Comment [t] Change title")
+	renderButtons(v, sb.buttons())
 
 	_, err = g.SetViewOnTop(showBugInstructionView)
 	if err != nil {
@@ -108,7 +132,7 @@ func (sb *showBug) layout(g *gocui.Gui) error {
 
 func (sb *showBug) keybindings(g *gocui.Gui) error {
 	// Return
-	if err := g.SetKeybinding(showBugView, 'q', gocui.ModNone, sb.saveAndBack); err != nil {
+	if err := g.SetKeybinding(showBugView, ui.config.keys["back"], gocui.ModNone, sb.saveAndBack); err != nil {
 		return err
 	}
 
@@ -161,25 +185,43 @@ func (sb *showBug) keybindings(g *gocui.Gui) error {
 	}
 
 	// Comment
-	if err := g.SetKeybinding(showBugView, 'c', gocui.ModNone,
+	if err := g.SetKeybinding(showBugView, ui.config.keys["comment"], gocui.ModNone,
 		sb.comment); err != nil {
 		return err
 	}
 
 	// Open/close
-	if err := g.SetKeybinding(showBugView, 'o', gocui.ModNone,
+	if err := g.SetKeybinding(showBugView, ui.config.keys["open-close"], gocui.ModNone,
 		sb.toggleOpenClose); err != nil {
 		return err
 	}
 
 	// Title
-	if err := g.SetKeybinding(showBugView, 't', gocui.ModNone,
+	if err := g.SetKeybinding(showBugView, ui.config.keys["title"], gocui.ModNone,
 		sb.setTitle); err != nil {
 		return err
 	}
 
+	// Labels
+	if err := g.SetKeybinding(showBugView, ui.config.keys["labels"], gocui.ModNone,
+		sb.labels); err != nil {
+		return err
+	}
+
+	// History
+	if err := g.SetKeybinding(showBugView, ui.config.keys["history"], gocui.ModNone,
+		sb.history); err != nil {
+		return err
+	}
+
+	// Markdown source
+	if err := g.SetKeybinding(showBugView, ui.config.keys["raw"], gocui.ModNone,
+		sb.toggleRawMarkdown); err != nil {
+		return err
+	}
+
 	// Edit
-	if err := g.SetKeybinding(showBugView, 'e', gocui.ModNone,
+	if err := g.SetKeybinding(showBugView, ui.config.keys["edit"], gocui.ModNone,
 		sb.edit); err != nil {
 		return err
 	}
@@ -187,6 +229,56 @@ func (sb *showBug) keybindings(g *gocui.Gui) error {
 	return nil
 }
 
+// buttons return the actions of the instruction bar
+func (sb *showBug) buttons() []button {
+	conf := ui.config
+	return []button{
+		{conf.keyHelp("back", "Save and return"), sb.saveAndBack},
+		{"[←↓↑→,hjkl] Navigation", nil},
+		{conf.keyHelp("open-close", "Open/close"), sb.toggleOpenClose},
+		{conf.keyHelp("labels", "Labels"), sb.labels},
+		{conf.keyHelp("edit", "Edit"), sb.edit},
+		{conf.keyHelp("history", "History"), sb.history},
+		{conf.keyHelp("raw", "Raw"), sb.toggleRawMarkdown},
+		{conf.keyHelp("comment", "Comment"), sb.comment},
+		{conf.keyHelp("title", "Title"), sb.setTitle},
+	}
+}
+
+// click select the clicked block, or run the clicked action
+func (sb *showBug) click(g *gocui.Gui, v *gocui.View) error {
+	if v.Name() == showBugInstructionView {
+		return clickButton(g, v, showBugView, sb.buttons())
+	}
+
+	for _, name := range sb.mainSelectableView {
+		if name == v.Name() {
+			sb.isOnSide = false
+			sb.selected = name
+			return sb.focusView(g)
+		}
+	}
+
+	for _, name := range sb.sideSelectableView {
+		if name == v.Name() {
+			sb.isOnSide = true
+			sb.selected = name
+			return sb.focusView(g)
+		}
+	}
+
+	return nil
+}
+
+func (sb *showBug) wheel(g *gocui.Gui, v *gocui.View, down bool) error {
+	if down {
+		sb.scrollBy(g, mouseWheelLines)
+	} else {
+		sb.scrollBy(g, -mouseWheelLines)
+	}
+	return nil
+}
+
 func (sb *showBug) disable(g *gocui.Gui) error {
 	for _, view := range sb.childViews {
 		if err := g.DeleteView(view); err != nil && err != gocui.ErrUnknownView {
@@ -197,183 +289,200 @@ func (sb *showBug) disable(g *gocui.Gui) error {
 }
 
 func (sb *showBug) renderMain(g *gocui.Gui, mainView *gocui.View) error {
-	maxX, _ := mainView.Size()
+	maxX, maxY := mainView.Size()
 	x0, y0, _, _, _ := g.ViewPosition(mainView.Name())
 
-	y0 -= sb.scroll
-
 	snap := sb.bug.Snapshot()
 
-	sb.mainSelectableView = nil
-
-	createTimelineItem := snap.Timeline[0].(*bug.CreateTimelineItem)
-
-	edited := ""
-	if createTimelineItem.Edited() {
-		edited = " (edited)"
+	// the blocks already laid out are only valid as long as their content
+	// and the width don't change
+	key := timelineLayoutKey{width: maxX, rawMarkdown: sb.rawMarkdown, ops: len(snap.Operations)}
+	if key != sb.layoutKey {
+		sb.layoutKey = key
+		sb.blocks = nil
 	}
 
-	bugHeader := fmt.Sprintf("[%s] %s\n\n[%s] %s opened this bug on %s%s",
-		colors.Cyan(snap.Id().Human()),
-		colors.Bold(snap.Title),
-		colors.Yellow(snap.Status),
-		colors.Magenta(snap.Author.DisplayName()),
-		snap.CreatedAt.Format(timeLayout),
-		edited,
-	)
-	bugHeader, lines := text.Wrap(bugHeader, maxX)
-
-	v, err := sb.createOpView(g, showBugHeaderView, x0, y0, maxX+1, lines, false)
-	if err != nil {
-		return err
-	}
-
-	_, _ = fmt.Fprint(v, bugHeader)
-	y0 += lines + 1
-
-	for _, op := range snap.Timeline {
-		viewName := op.Id().String()
+	sb.mainSelectableView = nil
+	rendered := make(map[string]bool)
+
+	// Only the blocks of the timeline intersecting the view are rendered, and
+	// the blocks below are only laid out as the user scroll down, so that a
+	// bug with hundreds of comments still open instantly.
+	top := 0
+	laidOutBelow := false
+
+	for i := 0; i <= len(snap.Timeline); i++ {
+		below := top-sb.scroll >= maxY
+
+		var content string
+		if i >= len(sb.blocks) {
+			// the first block below the view is laid out, to be able to select it
+			if below && laidOutBelow {
+				break
+			}
 
-		// TODO: me might skip the rendering of blocks that are outside of the view
-		// but to do that we need to rework how sb.mainSelectableView is maintained
+			name := showBugHeaderView
+			if i > 0 {
+				name = snap.Timeline[i-1].Id().String()
+			}
 
-		switch op.(type) {
+			var lines int
+			content, lines = sb.renderBlock(snap, i, maxX)
+			sb.blocks = append(sb.blocks, timelineBlock{name: name, top: top, lines: lines})
+		}
 
-		case *bug.CreateTimelineItem:
-			create := op.(*bug.CreateTimelineItem)
+		block := sb.blocks[i]
+		laidOutBelow = laidOutBelow || below
 
-			var content string
-			var lines int
+		// the header is not selectable
+		if i > 0 {
+			sb.mainSelectableView = append(sb.mainSelectableView, block.name)
+		}
 
-			if create.MessageIsEmpty() {
-				content, lines = text.WrapLeftPadded(emptyMessagePlaceholder(), maxX-1, 4)
-			} else {
-				content, lines = text.WrapLeftPadded(create.Message, maxX-1, 4)
+		if !below && top+block.lines+1 >= sb.scroll {
+			if content == "" {
+				content, _ = sb.renderBlock(snap, i, maxX)
 			}
 
-			v, err := sb.createOpView(g, viewName, x0, y0, maxX+1, lines, true)
+			v, err := sb.createOpView(g, block.name, x0, y0+top-sb.scroll, maxX+1, block.lines)
 			if err != nil {
 				return err
 			}
 			_, _ = fmt.Fprint(v, content)
-			y0 += lines + 2
-
-		case *bug.AddCommentTimelineItem:
-			comment := op.(*bug.AddCommentTimelineItem)
-
-			edited := ""
-			if comment.Edited() {
-				edited = " (edited)"
-			}
+			rendered[block.name] = true
+		}
 
-			var message string
-			if comment.MessageIsEmpty() {
-				message, _ = text.WrapLeftPadded(emptyMessagePlaceholder(), maxX-1, 4)
-			} else {
-				message, _ = text.WrapLeftPadded(comment.Message, maxX-1, 4)
-			}
+		if i == 0 {
+			top += block.lines + 1
+		} else {
+			top += block.lines + 2
+		}
+	}
 
-			content := fmt.Sprintf("%s commented on %s%s\n\n%s",
-				colors.Magenta(comment.Author.DisplayName()),
-				comment.CreatedAt.Time().Format(timeLayout),
-				edited,
-				message,
-			)
-			content, lines = text.Wrap(content, maxX)
+	// remove the blocks scrolled out of the view
+	for name := range sb.renderedViews {
+		if rendered[name] {
+			continue
+		}
+		if err := g.DeleteView(name); err != nil && err != gocui.ErrUnknownView {
+			return err
+		}
+	}
+	sb.renderedViews = rendered
 
-			v, err := sb.createOpView(g, viewName, x0, y0, maxX+1, lines, true)
-			if err != nil {
-				return err
-			}
-			_, _ = fmt.Fprint(v, content)
-			y0 += lines + 2
+	return nil
+}
 
-		case *bug.SetTitleTimelineItem:
-			setTitle := op.(*bug.SetTitleTimelineItem)
+// renderBlock render the i-th block of the timeline, the header of the bug
+// being the first one
+func (sb *showBug) renderBlock(snap *bug.Snapshot, i int, maxX int) (string, int) {
+	if i == 0 {
+		createTimelineItem := snap.Timeline[0].(*bug.CreateTimelineItem)
 
-			content := fmt.Sprintf("%s changed the title to %s on %s",
-				colors.Magenta(setTitle.Author.DisplayName()),
-				colors.Bold(setTitle.Title),
-				setTitle.UnixTime.Time().Format(timeLayout),
-			)
-			content, lines := text.Wrap(content, maxX)
+		edited := ""
+		if createTimelineItem.Edited() {
+			edited = " (edited)"
+		}
 
-			v, err := sb.createOpView(g, viewName, x0, y0, maxX+1, lines, true)
-			if err != nil {
-				return err
-			}
-			_, _ = fmt.Fprint(v, content)
-			y0 += lines + 2
+		bugHeader := fmt.Sprintf("[%s] %s\n\n[%s] %s opened this bug on %s%s",
+			colors.Cyan(snap.Id().Human()),
+			colors.Bold(snap.Title),
+			colors.Yellow(snap.Status),
+			colors.Magenta(snap.Author.DisplayName()),
+			snap.CreatedAt.Format(timeLayout),
+			edited,
+		)
+		return text.Wrap(bugHeader, maxX)
+	}
 
-		case *bug.SetStatusTimelineItem:
-			setStatus := op.(*bug.SetStatusTimelineItem)
+	switch op := snap.Timeline[i-1].(type) {
 
-			content := fmt.Sprintf("%s %s the bug on %s",
-				colors.Magenta(setStatus.Author.DisplayName()),
-				colors.Bold(setStatus.Status.Action()),
-				setStatus.UnixTime.Time().Format(timeLayout),
-			)
-			content, lines := text.Wrap(content, maxX)
+	case *bug.CreateTimelineItem:
+		if op.MessageIsEmpty() {
+			return text.WrapLeftPadded(emptyMessagePlaceholder(), maxX-1, 4)
+		}
+		return text.WrapLeftPadded(sb.formatMessage(op.Message), maxX-1, 4)
 
-			v, err := sb.createOpView(g, viewName, x0, y0, maxX+1, lines, true)
-			if err != nil {
-				return err
-			}
-			_, _ = fmt.Fprint(v, content)
-			y0 += lines + 2
+	case *bug.AddCommentTimelineItem:
+		edited := ""
+		if op.Edited() {
+			edited = " (edited)"
+		}
 
-		case *bug.LabelChangeTimelineItem:
-			labelChange := op.(*bug.LabelChangeTimelineItem)
+		var message string
+		if op.MessageIsEmpty() {
+			message, _ = text.WrapLeftPadded(emptyMessagePlaceholder(), maxX-1, 4)
+		} else {
+			message, _ = text.WrapLeftPadded(sb.formatMessage(op.Message), maxX-1, 4)
+		}
 
-			var added []string
-			for _, label := range labelChange.Added {
-				added = append(added, colors.Bold("\""+label+"\""))
-			}
+		content := fmt.Sprintf("%s commented on %s%s\n\n%s",
+			colors.Magenta(op.Author.DisplayName()),
+			op.CreatedAt.Time().Format(timeLayout),
+			edited,
+			message,
+		)
+		return text.Wrap(content, maxX)
+
+	case *bug.SetTitleTimelineItem:
+		content := fmt.Sprintf("%s changed the title to %s on %s",
+			colors.Magenta(op.Author.DisplayName()),
+			colors.Bold(op.Title),
+			op.UnixTime.Time().Format(timeLayout),
+		)
+		return text.Wrap(content, maxX)
+
+	case *bug.SetStatusTimelineItem:
+		content := fmt.Sprintf("%s %s the bug on %s",
+			colors.Magenta(op.Author.DisplayName()),
+			colors.Bold(op.Status.Action()),
+			op.UnixTime.Time().Format(timeLayout),
+		)
+		return text.Wrap(content, maxX)
 
-			var removed []string
-			for _, label := range labelChange.Removed {
-				removed = append(removed, colors.Bold("\""+label+"\""))
-			}
+	case *bug.LabelChangeTimelineItem:
+		var added []string
+		for _, label := range op.Added {
+			added = append(added, colors.Bold("\""+label+"\""))
+		}
 
-			var action bytes.Buffer
+		var removed []string
+		for _, label := range op.Removed {
+			removed = append(removed, colors.Bold("\""+label+"\""))
+		}
 
-			if len(added) > 0 {
-				action.WriteString("added ")
-				action.WriteString(strings.Join(added, ", "))
+		var action bytes.Buffer
 
-				if len(removed) > 0 {
-					action.WriteString(" and ")
-				}
-			}
+		if len(added) > 0 {
+			action.WriteString("added ")
+			action.WriteString(strings.Join(added, ", "))
 
 			if len(removed) > 0 {
-				action.WriteString("removed ")
-				action.WriteString(strings.Join(removed, ", "))
-			}
-
-			if len(added)+len(removed) > 1 {
-				action.WriteString(" labels")
-			} else {
-				action.WriteString(" label")
+				action.WriteString(" and ")
 			}
+		}
 
-			content := fmt.Sprintf("%s %s on %s",
-				colors.Magenta(labelChange.Author.DisplayName()),
-				action.String(),
-				labelChange.UnixTime.Time().Format(timeLayout),
-			)
-			content, lines := text.Wrap(content, maxX)
+		if len(removed) > 0 {
+			action.WriteString("removed ")
+			action.WriteString(strings.Join(removed, ", "))
+		}
 
-			v, err := sb.createOpView(g, viewName, x0, y0, maxX+1, lines, true)
-			if err != nil {
-				return err
-			}
-			_, _ = fmt.Fprint(v, content)
-			y0 += lines + 2
+		if len(added)+len(removed) > 1 {
+			action.WriteString(" labels")
+		} else {
+			action.WriteString(" label")
 		}
+
+		content := fmt.Sprintf("%s %s on %s",
+			colors.Magenta(op.Author.DisplayName()),
+			action.String(),
+			op.UnixTime.Time().Format(timeLayout),
+		)
+		return text.Wrap(content, maxX)
 	}
 
-	return nil
+	// an unknown item still takes a line, to keep the layout consistent
+	return "", 1
 }
 
 // emptyMessagePlaceholder return a formatted placeholder for an empty message
@@ -381,7 +490,7 @@ func emptyMessagePlaceholder() string {
 	return colors.GreyBold("No description provided.")
 }
 
-func (sb *showBug) createOpView(g *gocui.Gui, name string, x0 int, y0 int, maxX int, height int, selectable bool) (*gocui.View, error) {
+func (sb *showBug) createOpView(g *gocui.Gui, name string, x0 int, y0 int, maxX int, height int) (*gocui.View, error) {
 	v, err := g.SetView(name, x0, y0, maxX, y0+height+1)
 
 	if err != nil && err != gocui.ErrUnknownView {
@@ -390,10 +499,6 @@ func (sb *showBug) createOpView(g *gocui.Gui, name string, x0 int, y0 int, maxX
 
 	sb.childViews = append(sb.childViews, name)
 
-	if selectable {
-		sb.mainSelectableView = append(sb.mainSelectableView, name)
-	}
-
 	v.Frame = sb.selected == name
 
 	v.Clear()
@@ -460,44 +565,37 @@ func (sb *showBug) saveAndBack(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (sb *showBug) scrollUp(g *gocui.Gui, v *gocui.View) error {
-	mainView, err := g.View(showBugView)
-	if err != nil {
-		return err
-	}
-
-	_, maxY := mainView.Size()
-
-	sb.scroll -= maxY / 2
-
-	sb.scroll = maxInt(sb.scroll, 0)
-
+	_, maxY := v.Size()
+	sb.scrollBy(g, -maxY/2)
 	return nil
 }
 
 func (sb *showBug) scrollDown(g *gocui.Gui, v *gocui.View) error {
 	_, maxY := v.Size()
+	sb.scrollBy(g, maxY/2)
+	return nil
+}
 
-	lastViewName := sb.mainSelectableView[len(sb.mainSelectableView)-1]
-
-	lastView, err := g.View(lastViewName)
-	if err != nil {
-		return err
-	}
-
-	_, vMaxY := lastView.Size()
-
-	_, vy0, _, _, err := g.ViewPosition(lastViewName)
+// scrollBy scroll the timeline by a number of lines, without going past its
+// beginning or its end
+func (sb *showBug) scrollBy(g *gocui.Gui, lines int) {
+	mainView, err := g.View(showBugView)
 	if err != nil {
-		return err
+		return
 	}
 
-	maxScroll := vy0 + sb.scroll + vMaxY - maxY
+	_, maxY := mainView.Size()
 
-	sb.scroll += maxY / 2
+	sb.scroll += lines
 
-	sb.scroll = minInt(sb.scroll, maxScroll)
+	// the end of the timeline is only known once it has been laid out
+	if len(sb.blocks) == len(sb.bug.Snapshot().Timeline)+1 {
+		last := sb.blocks[len(sb.blocks)-1]
+		maxScroll := last.top + last.lines - maxY
+		sb.scroll = minInt(sb.scroll, maxScroll)
+	}
 
-	return nil
+	sb.scroll = maxInt(sb.scroll, 0)
 }
 
 func (sb *showBug) selectPrevious(g *gocui.Gui, v *gocui.View) error {
@@ -585,18 +683,11 @@ func (sb *showBug) focusView(g *gocui.Gui) error {
 
 	_, maxY := mainView.Size()
 
-	_, vy0, _, _, err := g.ViewPosition(sb.selected)
+	vy0, vMaxY, err := sb.selectedPosition(g)
 	if err != nil {
 		return err
 	}
 
-	v, err := g.View(sb.selected)
-	if err != nil {
-		return err
-	}
-
-	_, vMaxY := v.Size()
-
 	vy1 := vy0 + vMaxY
 
 	if vy0 < 0 {
@@ -611,8 +702,35 @@ func (sb *showBug) focusView(g *gocui.Gui) error {
 	return nil
 }
 
+// selectedPosition return the position and height of the selected block. A
+// block of the timeline might be out of the view, and not rendered.
+func (sb *showBug) selectedPosition(g *gocui.Gui) (int, int, error) {
+	if !sb.isOnSide {
+		for _, block := range sb.blocks {
+			if block.name == sb.selected {
+				return block.top - sb.scroll, block.lines, nil
+			}
+		}
+	}
+
+	_, vy0, _, _, err := g.ViewPosition(sb.selected)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	v, err := g.View(sb.selected)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	_, vMaxY := v.Size()
+
+	return vy0, vMaxY, nil
+}
+
 func (sb *showBug) comment(g *gocui.Gui, v *gocui.View) error {
-	return addCommentWithEditor(sb.bug)
+	ui.composer.NewComment(sb.bug)
+	return ui.activateWindow(ui.composer)
 }
 
 func (sb *showBug) setTitle(g *gocui.Gui, v *gocui.View) error {
@@ -632,6 +750,53 @@ func (sb *showBug) toggleOpenClose(g *gocui.Gui, v *gocui.View) error {
 	}
 }
 
+func (sb *showBug) labels(g *gocui.Gui, v *gocui.View) error {
+	return sb.editLabels(g, sb.bug.Snapshot())
+}
+
+func (sb *showBug) formatMessage(message string) string {
+	if sb.rawMarkdown {
+		return message
+	}
+	return renderMarkdown(message)
+}
+
+func (sb *showBug) toggleRawMarkdown(g *gocui.Gui, v *gocui.View) error {
+	sb.rawMarkdown = !sb.rawMarkdown
+	return nil
+}
+
+// history show the revisions of the selected comment, if edited
+func (sb *showBug) history(g *gocui.Gui, v *gocui.View) error {
+	if sb.isOnSide || sb.selected == "" {
+		return nil
+	}
+
+	op, err := sb.bug.Snapshot().SearchTimelineItem(entity.Id(sb.selected))
+	if err != nil {
+		return err
+	}
+
+	var comment *bug.CommentTimelineItem
+	switch op := op.(type) {
+	case *bug.CreateTimelineItem:
+		comment = &op.CommentTimelineItem
+	case *bug.AddCommentTimelineItem:
+		comment = &op.CommentTimelineItem
+	default:
+		ui.msgPopup.Activate(msgPopupErrorTitle, "Selected item is not a comment.")
+		return nil
+	}
+
+	if !comment.Edited() {
+		ui.msgPopup.Activate(msgPopupErrorTitle, "This comment has not been edited.")
+		return nil
+	}
+
+	ui.commentHistory.SetComment(comment)
+	return ui.activateWindow(ui.commentHistory)
+}
+
 func (sb *showBug) edit(g *gocui.Gui, v *gocui.View) error {
 	snap := sb.bug.Snapshot()
 
@@ -2,9 +2,12 @@
 package termui
 
 import (
+	"sync"
+
 	"github.com/MichaelMure/gocui"
 	"github.com/pkg/errors"
 
+	"github.com/MichaelMure/git-bug/bug"
 	"github.com/MichaelMure/git-bug/cache"
 	"github.com/MichaelMure/git-bug/entity"
 	"github.com/MichaelMure/git-bug/input"
@@ -16,14 +19,20 @@ type termUI struct {
 	g      *gocui.Gui
 	gError chan error
 	cache  *cache.RepoCache
+	config *config
+
+	// protect g, for the goroutine watching the bug changes
+	muG sync.Mutex
 
 	activeWindow window
 
-	bugTable    *bugTable
-	showBug     *showBug
-	labelSelect *labelSelect
-	msgPopup    *msgPopup
-	inputPopup  *inputPopup
+	bugTable       *bugTable
+	showBug        *showBug
+	labelSelect    *labelSelect
+	commentHistory *commentHistory
+	composer       *composer
+	msgPopup       *msgPopup
+	inputPopup     *inputPopup
 }
 
 func (tui *termUI) activateWindow(window window) error {
@@ -36,6 +45,23 @@ func (tui *termUI) activateWindow(window window) error {
 	return nil
 }
 
+// setGui change the running gui, or clear it while an editor is running
+func (tui *termUI) setGui(g *gocui.Gui) {
+	tui.muG.Lock()
+	tui.g = g
+	tui.muG.Unlock()
+}
+
+// update run a function in the gui goroutine, if the gui is running
+func (tui *termUI) update(f func(g *gocui.Gui) error) {
+	tui.muG.Lock()
+	defer tui.muG.Unlock()
+
+	if tui.g != nil {
+		tui.g.Update(f)
+	}
+}
+
 var ui *termUI
 
 type window interface {
@@ -46,21 +72,33 @@ type window interface {
 
 // Run will launch the termUI in the terminal
 func Run(cache *cache.RepoCache) error {
+	conf, err := readConfig(cache)
+	if err != nil {
+		return err
+	}
+
 	ui = &termUI{
-		gError:      make(chan error, 1),
-		cache:       cache,
-		bugTable:    newBugTable(cache),
-		showBug:     newShowBug(cache),
-		labelSelect: newLabelSelect(),
-		msgPopup:    newMsgPopup(),
-		inputPopup:  newInputPopup(),
+		gError:         make(chan error, 1),
+		cache:          cache,
+		config:         conf,
+		bugTable:       newBugTable(cache),
+		showBug:        newShowBug(cache),
+		labelSelect:    newLabelSelect(),
+		commentHistory: newCommentHistory(),
+		composer:       newComposer(cache),
+		msgPopup:       newMsgPopup(),
+		inputPopup:     newInputPopup(),
 	}
 
 	ui.activeWindow = ui.bugTable
 
+	done := make(chan struct{})
+	defer close(done)
+	go watchChanges(cache, done)
+
 	initGui(nil)
 
-	err := <-ui.gError
+	err = <-ui.gError
 
 	if err != nil && err != gocui.ErrQuit {
 		return err
@@ -70,24 +108,25 @@ func Run(cache *cache.RepoCache) error {
 }
 
 func initGui(action func(ui *termUI) error) {
-	g, err := gocui.NewGui(gocui.OutputNormal)
+	g, err := gocui.NewGui(ui.config.outputMode())
 
 	if err != nil {
 		ui.gError <- err
 		return
 	}
 
-	ui.g = g
+	ui.setGui(g)
 
 	ui.g.SetManagerFunc(layout)
 
 	ui.g.InputEsc = true
+	ui.g.Mouse = ui.config.mouse
 
 	err = keybindings(ui.g)
 
 	if err != nil {
 		ui.g.Close()
-		ui.g = nil
+		ui.setGui(nil)
 		ui.gError <- err
 		return
 	}
@@ -96,7 +135,7 @@ func initGui(action func(ui *termUI) error) {
 		err = action(ui)
 		if err != nil {
 			ui.g.Close()
-			ui.g = nil
+			ui.setGui(nil)
 			ui.gError <- err
 			return
 		}
@@ -138,6 +177,12 @@ func keybindings(g *gocui.Gui) error {
 		return err
 	}
 
+	if ui.config.mouse {
+		if err := mouseKeybindings(g); err != nil {
+			return err
+		}
+	}
+
 	if err := ui.bugTable.keybindings(g); err != nil {
 		return err
 	}
@@ -150,6 +195,14 @@ func keybindings(g *gocui.Gui) error {
 		return err
 	}
 
+	if err := ui.commentHistory.keybindings(g); err != nil {
+		return err
+	}
+
+	if err := ui.composer.keybindings(g); err != nil {
+		return err
+	}
+
 	if err := ui.msgPopup.keybindings(g); err != nil {
 		return err
 	}
@@ -165,7 +218,7 @@ func quit(g *gocui.Gui, v *gocui.View) error {
 	return gocui.ErrQuit
 }
 
-func newBugWithEditor(repo *cache.RepoCache) error {
+func newBugWithEditor(repo *cache.RepoCache, preTitle string, preMessage string, labels []bug.Label) error {
 	// This is somewhat hacky.
 	// As there is no way to pause gocui, run the editor and restart gocui,
 	// we have to stop it entirely and start a new one later.
@@ -176,9 +229,9 @@ func newBugWithEditor(repo *cache.RepoCache) error {
 	//		instance's mainLoop. This error is then filtered.
 
 	ui.g.Close()
-	ui.g = nil
+	ui.setGui(nil)
 
-	title, message, err := input.BugCreateEditorInput(ui.cache, "", "")
+	title, message, err := input.BugCreateEditorInput(ui.cache, preTitle, preMessage)
 
 	if err != nil && err != input.ErrEmptyTitle {
 		return err
@@ -191,7 +244,7 @@ func newBugWithEditor(repo *cache.RepoCache) error {
 
 		return errTerminateMainloop
 	} else {
-		b, _, err = repo.NewBug(title, message)
+		b, err = createBug(repo, title, message, labels)
 		if err != nil {
 			return err
 		}
@@ -205,7 +258,7 @@ func newBugWithEditor(repo *cache.RepoCache) error {
 	}
 }
 
-func addCommentWithEditor(bug *cache.BugCache) error {
+func addCommentWithEditor(bug *cache.BugCache, preMessage string) error {
 	// This is somewhat hacky.
 	// As there is no way to pause gocui, run the editor and restart gocui,
 	// we have to stop it entirely and start a new one later.
@@ -216,9 +269,9 @@ func addCommentWithEditor(bug *cache.BugCache) error {
 	//		instance's mainLoop. This error is then filtered.
 
 	ui.g.Close()
-	ui.g = nil
+	ui.setGui(nil)
 
-	message, err := input.BugCommentEditorInput(ui.cache, "")
+	message, err := input.BugCommentEditorInput(ui.cache, preMessage)
 
 	if err != nil && err != input.ErrEmptyMessage {
 		return err
@@ -226,14 +279,19 @@ func addCommentWithEditor(bug *cache.BugCache) error {
 
 	if err == input.ErrEmptyMessage {
 		ui.msgPopup.Activate(msgPopupErrorTitle, "Empty message, aborting.")
-	} else {
-		_, err := bug.AddComment(message)
-		if err != nil {
-			return err
-		}
+		initGui(nil)
+
+		return errTerminateMainloop
 	}
 
-	initGui(nil)
+	_, err = bug.AddComment(message)
+	if err != nil {
+		return err
+	}
+
+	initGui(func(ui *termUI) error {
+		return ui.activateWindow(ui.showBug)
+	})
 
 	return errTerminateMainloop
 }
@@ -249,7 +307,7 @@ func editCommentWithEditor(bug *cache.BugCache, target entity.Id, preMessage str
 	//		instance's mainLoop. This error is then filtered.
 
 	ui.g.Close()
-	ui.g = nil
+	ui.setGui(nil)
 
 	message, err := input.BugCommentEditorInput(ui.cache, preMessage)
 	if err != nil && err != input.ErrEmptyMessage {
@@ -284,7 +342,7 @@ func setTitleWithEditor(bug *cache.BugCache) error {
 	//		instance's mainLoop. This error is then filtered.
 
 	ui.g.Close()
-	ui.g = nil
+	ui.setGui(nil)
 
 	snap := bug.Snapshot()
 
@@ -321,9 +379,14 @@ func editQueryWithEditor(bt *bugTable) error {
 	//		instance's mainLoop. This error is then filtered.
 
 	ui.g.Close()
-	ui.g = nil
+	ui.setGui(nil)
+
+	savedQueries, err := bt.repo.SavedQueries()
+	if err != nil {
+		return err
+	}
 
-	queryStr, err := input.QueryEditorInput(bt.repo, bt.queryStr)
+	queryStr, err := input.QueryEditorInput(bt.repo, bt.queryStr, savedQueries)
 
 	if err != nil {
 		return err
@@ -331,7 +394,7 @@ func editQueryWithEditor(bt *bugTable) error {
 
 	bt.queryStr = queryStr
 
-	query, err := cache.ParseQuery(queryStr)
+	query, err := bt.repo.ParseQuery(queryStr)
 
 	if err != nil {
 		ui.msgPopup.Activate(msgPopupErrorTitle, err.Error())
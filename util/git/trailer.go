@@ -0,0 +1,47 @@
+package git
+
+import (
+	"regexp"
+	"strings"
+)
+
+var trailerRegexp = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9-]*):\s*(.*)$`)
+
+// Trailer is a "Key: value" line at the end of a commit message
+type Trailer struct {
+	Key   string
+	Value string
+}
+
+// ParseTrailers return the trailers of a commit message, that is the lines of
+// its last paragraph if they are all trailers, as git does. A trailer value
+// can continue on the next lines if they are indented. The subject alone
+// doesn't hold trailers.
+func ParseTrailers(message string) []Trailer {
+	paragraphs := strings.Split(strings.TrimSpace(strings.Replace(message, "\r\n", "\n", -1)), "\n\n")
+	if len(paragraphs) < 2 {
+		return nil
+	}
+
+	var result []Trailer
+
+	last := strings.Trim(paragraphs[len(paragraphs)-1], "\n")
+	for _, line := range strings.Split(last, "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(result) > 0 {
+			result[len(result)-1].Value += " " + strings.TrimSpace(line)
+			continue
+		}
+
+		matches := trailerRegexp.FindStringSubmatch(line)
+		if matches == nil {
+			return nil
+		}
+
+		result = append(result, Trailer{
+			Key:   matches[1],
+			Value: strings.TrimSpace(matches[2]),
+		})
+	}
+
+	return result
+}
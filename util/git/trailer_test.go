@@ -0,0 +1,29 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTrailers(t *testing.T) {
+	var tests = []struct {
+		message  string
+		trailers []Trailer
+	}{
+		{"subject", nil},
+		{"Closes: 1234", nil},
+		{"subject\n\nbody", nil},
+		{"subject\n\nCloses: 1234", []Trailer{{"Closes", "1234"}}},
+		{"subject\n\nbody\n\nCloses: 1234\nSigned-off-by: René <rene@descartes.fr>\n",
+			[]Trailer{{"Closes", "1234"}, {"Signed-off-by", "René <rene@descartes.fr>"}}},
+		{"subject\n\nCloses: 1234,\n  5678", []Trailer{{"Closes", "1234, 5678"}}},
+		{"subject\r\n\r\nRefs: 1234\r\n", []Trailer{{"Refs", "1234"}}},
+		// not only trailers in the last paragraph
+		{"subject\n\nCloses: 1234\nand some text", nil},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.trailers, ParseTrailers(test.message), test.message)
+	}
+}
@@ -5,11 +5,15 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
 )
 
 type Persisted struct {
 	Clock
 	filePath string
+
+	// serialize the writes of the file
+	mu sync.Mutex
 }
 
 // NewPersisted create a new persisted Lamport clock
@@ -79,6 +83,9 @@ func (c *Persisted) read() error {
 }
 
 func (c *Persisted) Write() error {
-	data := []byte(fmt.Sprintf("%d", c.counter))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data := []byte(fmt.Sprintf("%d", c.Clock.Time()))
 	return ioutil.WriteFile(c.filePath, data, 0644)
 }
@@ -17,7 +17,10 @@ func IsRunning(pid int) bool {
 	// Signal 0 doesn't do anything but allow testing the process
 	err = process.Signal(syscall.Signal(0))
 
-	// Todo: distinguish "you don't have access" and "process doesn't exist"
+	// the process exist but belong to another user
+	if err == syscall.EPERM {
+		return true
+	}
 
 	return err == nil
 }
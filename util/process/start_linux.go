@@ -0,0 +1,96 @@
+package process
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// clockTicks is the USER_HZ of the kernel, used in /proc/<pid>/stat. It's
+// 100 on all the common architectures.
+const clockTicks = 100
+
+// StartTime return the time a process started, if it can be determined
+func StartTime(pid int) (time.Time, bool) {
+	stat, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	// the second field is the command name, in parenthesis, that can
+	// contain spaces
+	end := strings.LastIndexByte(string(stat), ')')
+	if end < 0 {
+		return time.Time{}, false
+	}
+
+	// the start time is the 22nd field, the 20th after the command name
+	fields := strings.Fields(string(stat[end+1:]))
+	if len(fields) < 20 {
+		return time.Time{}, false
+	}
+
+	ticks, err := strconv.ParseUint(fields[19], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	boot, ok := bootTime()
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return boot.Add(time.Duration(ticks) * time.Second / clockTicks), true
+}
+
+func bootTime() (time.Time, bool) {
+	stat, err := ioutil.ReadFile("/proc/stat")
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	for _, line := range strings.Split(string(stat), "\n") {
+		if !strings.HasPrefix(line, "btime ") {
+			continue
+		}
+
+		sec, err := strconv.ParseInt(strings.TrimSpace(line[len("btime "):]), 10, 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+
+		return time.Unix(sec, 0), true
+	}
+
+	return time.Time{}, false
+}
+
+// IsGitBug tell if a process runs git-bug, that is the same executable as the
+// current process or one named git-bug
+func IsGitBug(pid int) bool {
+	exe, err := os.Readlink(fmt.Sprintf("/proc/%d/exe", pid))
+	if err != nil {
+		return false
+	}
+	// an executable replaced by an upgrade since the process started
+	exe = strings.TrimSuffix(exe, " (deleted)")
+
+	if strings.HasPrefix(filepath.Base(exe), "git-bug") {
+		return true
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return false
+	}
+	self, err = filepath.EvalSymlinks(self)
+	if err != nil {
+		return false
+	}
+
+	return exe == self
+}
@@ -0,0 +1,16 @@
+// +build !linux
+
+package process
+
+import "time"
+
+// StartTime return the time a process started, if it can be determined
+func StartTime(pid int) (time.Time, bool) {
+	return time.Time{}, false
+}
+
+// IsGitBug tell if a process runs git-bug, that is the same executable as the
+// current process or one named git-bug
+func IsGitBug(pid int) bool {
+	return false
+}
@@ -0,0 +1,176 @@
+package site
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+)
+
+// the number of entries of a feed, the most recent ones
+const feedLength = 50
+
+// the global feed is made of the changes of the most recently edited bugs
+const feedRecentBugs = 20
+
+// Feed is an Atom feed of the changes of bugs
+type Feed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Id      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []feedLink  `xml:"link"`
+	Entries []feedEntry `xml:"entry"`
+}
+
+type feedLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type feedEntry struct {
+	Id      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Author  feedAuthor  `xml:"author"`
+	Link    feedLink    `xml:"link"`
+	Content feedContent `xml:"content"`
+
+	time time.Time
+}
+
+type feedAuthor struct {
+	Name string `xml:"name"`
+}
+
+type feedContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+// BugFeed return the feed of the changes of a bug. The links point to the
+// pages of the web UI served at baseUrl.
+func BugFeed(repo *cache.RepoCache, snap *bug.Snapshot, baseUrl string) *Feed {
+	entries := bugEntries(repo, snap, baseUrl)
+
+	return newFeed(
+		fmt.Sprintf("urn:git-bug:bug:%s", snap.Id()),
+		fmt.Sprintf("%s: %s", snap.Id().Human(), snap.Title),
+		fmt.Sprintf("%s/bugs/%s/feed.atom", baseUrl, snap.Id()),
+		bugUrl(baseUrl, snap),
+		entries,
+	)
+}
+
+// RecentFeed return the feed of the recent changes of the bugs of the
+// repository. The links point to the pages of the web UI served at baseUrl.
+func RecentFeed(repo *cache.RepoCache, baseUrl string) (*Feed, error) {
+	query := &cache.Query{
+		OrderBy:        cache.OrderByEdit,
+		OrderDirection: cache.OrderDescending,
+	}
+
+	ids := repo.QueryBugs(query)
+	if len(ids) > feedRecentBugs {
+		ids = ids[:feedRecentBugs]
+	}
+
+	var entries []feedEntry
+	for _, id := range ids {
+		b, err := repo.ResolveBug(id)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, bugEntries(repo, b.Snapshot(), baseUrl)...)
+	}
+
+	return newFeed(
+		"urn:git-bug:recent",
+		"Recent changes",
+		fmt.Sprintf("%s/feed.atom", baseUrl),
+		baseUrl+"/",
+		entries,
+	), nil
+}
+
+// Encode write the feed as XML
+func (f *Feed) Encode(w io.Writer) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	return encoder.Encode(f)
+}
+
+func newFeed(id string, title string, self string, alternate string, entries []feedEntry) *Feed {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].time.After(entries[j].time)
+	})
+	if len(entries) > feedLength {
+		entries = entries[:feedLength]
+	}
+
+	// an empty feed is still valid, as of when it's generated
+	updated := time.Now()
+	if len(entries) > 0 {
+		updated = entries[0].time
+	}
+
+	return &Feed{
+		Id:      id,
+		Title:   title,
+		Updated: updated.UTC().Format(time.RFC3339),
+		Links: []feedLink{
+			{Href: self, Rel: "self"},
+			{Href: alternate, Rel: "alternate"},
+		},
+		Entries: entries,
+	}
+}
+
+func bugUrl(baseUrl string, snap *bug.Snapshot) string {
+	return fmt.Sprintf("%s/bug/%s", baseUrl, snap.Id())
+}
+
+// bugEntries return an entry for each comment and event of the bug, as
+// displayed in its page
+func bugEntries(repo *cache.RepoCache, snap *bug.Snapshot, baseUrl string) []feedEntry {
+	page := newBugPage(repo, snap)
+	link := feedLink{Href: bugUrl(baseUrl, snap), Rel: "alternate"}
+
+	entries := make([]feedEntry, len(page.Timeline))
+	for i, item := range page.Timeline {
+		entry := feedEntry{
+			Id:      fmt.Sprintf("urn:git-bug:bug:%s:%s", snap.Id(), item.Id),
+			Updated: item.time.UTC().Format(time.RFC3339),
+			Author:  feedAuthor{Name: item.Author},
+			Link:    link,
+			time:    item.time,
+		}
+
+		action := item.Event
+		switch {
+		case i == 0:
+			action = "opened the bug"
+		case item.Event == "":
+			action = "commented"
+		}
+		entry.Title = fmt.Sprintf("%s: %s %s", snap.Title, item.Author, action)
+
+		if item.Event == "" {
+			entry.Content = feedContent{Type: "html", Body: string(item.Message)}
+		} else {
+			entry.Content = feedContent{Type: "text", Body: item.Event}
+		}
+
+		entries[i] = entry
+	}
+
+	return entries
+}
@@ -0,0 +1,270 @@
+// Package site render the bugs as a static website, to publish a read-only
+// archive of the issues without running the web UI.
+package site
+
+import (
+	"fmt"
+	"html/template"
+	"image/color"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/russross/blackfriday"
+
+	"github.com/MichaelMure/git-bug/bug"
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/entity"
+)
+
+const timeLayout = "Jan 2 2006 15:04"
+
+// the directory of the bug pages, relative to the root of the site
+const bugDir = "bug"
+
+// the markdown of the messages is rendered without the raw HTML, as the site
+// is published and the messages are written by anyone able to push bugs
+const markdownHtmlFlags = blackfriday.HTML_SKIP_HTML |
+	blackfriday.HTML_SKIP_STYLE |
+	blackfriday.HTML_SAFELINK |
+	blackfriday.HTML_NOFOLLOW_LINKS |
+	blackfriday.HTML_USE_XHTML
+
+const markdownExtensions = blackfriday.EXTENSION_NO_INTRA_EMPHASIS |
+	blackfriday.EXTENSION_TABLES |
+	blackfriday.EXTENSION_FENCED_CODE |
+	blackfriday.EXTENSION_AUTOLINK |
+	blackfriday.EXTENSION_STRIKETHROUGH |
+	blackfriday.EXTENSION_SPACE_HEADERS |
+	blackfriday.EXTENSION_HARD_LINE_BREAK
+
+// Export write a static rendering of the given bugs in the out directory: an
+// index.html listing them and a page for each bug in bug/<id>.html. The links
+// are relative, so the site can be served from any path.
+func Export(repo *cache.RepoCache, ids []entity.Id, out string) error {
+	if err := os.MkdirAll(filepath.Join(out, bugDir), 0755); err != nil {
+		return err
+	}
+
+	index := indexPage{
+		Generated: time.Now().Format(timeLayout),
+	}
+
+	for _, id := range ids {
+		b, err := repo.ResolveBug(id)
+		if err != nil {
+			return err
+		}
+
+		snap := b.Snapshot()
+
+		page := newBugPage(repo, snap)
+		path := filepath.Join(out, bugDir, fmt.Sprintf("%s.html", id))
+		if err := render(path, "bug", page); err != nil {
+			return err
+		}
+
+		index.Bugs = append(index.Bugs, page.bugRow)
+		if snap.Status == bug.OpenStatus {
+			index.Open++
+		} else {
+			index.Closed++
+		}
+	}
+
+	return render(filepath.Join(out, "index.html"), "index", index)
+}
+
+func render(path string, name string, data interface{}) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := templates.ExecuteTemplate(f, name, data); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+type indexPage struct {
+	Generated string
+	Open      int
+	Closed    int
+	Bugs      []bugRow
+}
+
+// bugRow is a bug in the listing
+type bugRow struct {
+	Id          entity.Id
+	Title       string
+	Status      bug.Status
+	Labels      []label
+	Author      string
+	CreatedAt   string
+	LenComments int
+}
+
+// Link return the path of the page of the bug, relative to the root of the
+// site
+func (r bugRow) Link() string {
+	return fmt.Sprintf("%s/%s.html", bugDir, r.Id)
+}
+
+type bugPage struct {
+	bugRow
+
+	Assignee  string
+	Milestone string
+	Timeline  []timelineItem
+}
+
+// timelineItem is either a comment, with a message, or an event
+type timelineItem struct {
+	Id      entity.Id
+	Author  string
+	Date    string
+	Edited  bool
+	Message template.HTML
+	Event   string
+
+	time time.Time
+}
+
+type label struct {
+	Name       string
+	Background string
+	Foreground string
+}
+
+func newLabel(l bug.Label) label {
+	rgba := l.RGBA()
+	return label{
+		Name:       l.String(),
+		Background: cssColor(rgba),
+		Foreground: cssColor(contrastColor(rgba)),
+	}
+}
+
+func cssColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// contrastColor return the color of a text readable on the given background
+func contrastColor(c color.RGBA) color.RGBA {
+	luminance := (299*int(c.R) + 587*int(c.G) + 114*int(c.B)) / 1000
+	if luminance > 150 {
+		return color.RGBA{A: 0xff}
+	}
+	return color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+}
+
+func newBugPage(repo *cache.RepoCache, snap *bug.Snapshot) bugPage {
+	labels := make([]label, len(snap.Labels))
+	for i, l := range snap.Labels {
+		labels[i] = newLabel(l)
+	}
+
+	page := bugPage{
+		bugRow: bugRow{
+			Id:          snap.Id(),
+			Title:       snap.Title,
+			Status:      snap.Status,
+			Labels:      labels,
+			Author:      snap.Author.DisplayName(),
+			CreatedAt:   snap.CreatedAt.Format(timeLayout),
+			LenComments: len(snap.Comments) - 1,
+		},
+		Milestone: snap.Milestone,
+	}
+
+	if snap.Assignee != "" {
+		page.Assignee = snap.Assignee.Human()
+		if excerpt, err := repo.ResolveIdentityExcerpt(snap.Assignee); err == nil {
+			page.Assignee = excerpt.DisplayName()
+		}
+	}
+
+	for _, item := range snap.Timeline {
+		switch item := item.(type) {
+		case *bug.CreateTimelineItem:
+			page.Timeline = append(page.Timeline, newCommentItem(&item.CommentTimelineItem))
+		case *bug.AddCommentTimelineItem:
+			page.Timeline = append(page.Timeline, newCommentItem(&item.CommentTimelineItem))
+		case *bug.SetTitleTimelineItem:
+			page.Timeline = append(page.Timeline, timelineItem{
+				Id:     item.Id(),
+				Author: item.Author.DisplayName(),
+				Date:   item.UnixTime.Time().Format(timeLayout),
+				time:   item.UnixTime.Time(),
+				Event:  fmt.Sprintf("changed the title from %q to %q", item.Was, item.Title),
+			})
+		case *bug.SetStatusTimelineItem:
+			page.Timeline = append(page.Timeline, timelineItem{
+				Id:     item.Id(),
+				Author: item.Author.DisplayName(),
+				Date:   item.UnixTime.Time().Format(timeLayout),
+				time:   item.UnixTime.Time(),
+				Event:  fmt.Sprintf("%s the bug", item.Status.Action()),
+			})
+		case *bug.LabelChangeTimelineItem:
+			page.Timeline = append(page.Timeline, timelineItem{
+				Id:     item.Id(),
+				Author: item.Author.DisplayName(),
+				Date:   item.UnixTime.Time().Format(timeLayout),
+				time:   item.UnixTime.Time(),
+				Event:  labelChangeEvent(item.Added, item.Removed),
+			})
+		}
+	}
+
+	return page
+}
+
+func newCommentItem(c *bug.CommentTimelineItem) timelineItem {
+	return timelineItem{
+		Id:      c.Id(),
+		Author:  c.Author.DisplayName(),
+		Date:    c.CreatedAt.Time().Format(timeLayout),
+		Edited:  c.Edited(),
+		Message: renderMarkdown(c.Message),
+		time:    c.CreatedAt.Time(),
+	}
+}
+
+func labelChangeEvent(added []bug.Label, removed []bug.Label) string {
+	event := ""
+	if len(added) > 0 {
+		event = fmt.Sprintf("added %s", quoteLabels(added))
+		if len(removed) > 0 {
+			event += " and "
+		}
+	}
+	if len(removed) > 0 {
+		event += fmt.Sprintf("removed %s", quoteLabels(removed))
+	}
+
+	if len(added)+len(removed) > 1 {
+		return event + " labels"
+	}
+	return event + " label"
+}
+
+func quoteLabels(labels []bug.Label) string {
+	result := ""
+	for i, l := range labels {
+		if i > 0 {
+			result += ", "
+		}
+		result += fmt.Sprintf("%q", l.String())
+	}
+	return result
+}
+
+func renderMarkdown(message string) template.HTML {
+	renderer := blackfriday.HtmlRenderer(markdownHtmlFlags, "", "")
+	html := blackfriday.Markdown([]byte(message), renderer, markdownExtensions)
+	return template.HTML(html)
+}
@@ -0,0 +1,105 @@
+package site
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MichaelMure/git-bug/cache"
+	"github.com/MichaelMure/git-bug/repository"
+)
+
+func TestExport(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	backend, err := cache.NewRepoCache(repo)
+	require.NoError(t, err)
+
+	rene, err := backend.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+
+	bug1, _, err := backend.NewBugRaw(rene, 1000, "first bug", "**important** <script>alert(1)</script>", nil, nil)
+	require.NoError(t, err)
+	_, err = bug1.AddCommentRaw(rene, 1001, "[link](javascript:alert(1))", nil, nil)
+	require.NoError(t, err)
+	_, _, err = bug1.ChangeLabelsRaw(rene, 1002, []string{"bug", "core"}, nil, nil)
+	require.NoError(t, err)
+	_, err = bug1.CloseRaw(rene, 1003, nil)
+	require.NoError(t, err)
+	require.NoError(t, bug1.Commit())
+
+	bug2, _, err := backend.NewBugRaw(rene, 1004, "second bug", "", nil, nil)
+	require.NoError(t, err)
+
+	out, err := ioutil.TempDir("", "git-bug-site")
+	require.NoError(t, err)
+	defer os.RemoveAll(out)
+
+	err = Export(backend, backend.AllBugsIds(), out)
+	require.NoError(t, err)
+
+	index, err := ioutil.ReadFile(filepath.Join(out, "index.html"))
+	require.NoError(t, err)
+	require.Contains(t, string(index), "1 open")
+	require.Contains(t, string(index), "1 closed")
+	require.Contains(t, string(index), `href="bug/`+bug1.Id().String()+`.html"`)
+	require.Contains(t, string(index), "second bug")
+
+	page, err := ioutil.ReadFile(filepath.Join(out, "bug", bug1.Id().String()+".html"))
+	require.NoError(t, err)
+	require.Contains(t, string(page), "<strong>important</strong>")
+	require.NotContains(t, string(page), "<script>")
+	require.NotContains(t, string(page), "javascript:")
+	require.Contains(t, string(page), `added &#34;bug&#34;, &#34;core&#34; labels`)
+	require.Contains(t, string(page), "closed the bug")
+
+	page, err = ioutil.ReadFile(filepath.Join(out, "bug", bug2.Id().String()+".html"))
+	require.NoError(t, err)
+	require.Contains(t, string(page), "No description provided.")
+}
+
+func TestFeed(t *testing.T) {
+	repo := repository.CreateTestRepo(false)
+	defer repository.CleanupTestRepos(t, repo)
+
+	backend, err := cache.NewRepoCache(repo)
+	require.NoError(t, err)
+
+	rene, err := backend.NewIdentity("René Descartes", "rene@descartes.fr")
+	require.NoError(t, err)
+
+	bug1, _, err := backend.NewBugRaw(rene, 1000, "first bug", "**important**", nil, nil)
+	require.NoError(t, err)
+	_, err = bug1.AddCommentRaw(rene, 1002, "a comment", nil, nil)
+	require.NoError(t, err)
+	_, err = bug1.CloseRaw(rene, 1003, nil)
+	require.NoError(t, err)
+	require.NoError(t, bug1.Commit())
+
+	_, _, err = backend.NewBugRaw(rene, 1001, "second bug", "", nil, nil)
+	require.NoError(t, err)
+
+	feed := BugFeed(backend, bug1.Snapshot(), "http://localhost:8080")
+	require.Len(t, feed.Entries, 3)
+	require.Equal(t, "first bug: René Descartes closed the bug", feed.Entries[0].Title)
+	require.Equal(t, "first bug: René Descartes opened the bug", feed.Entries[2].Title)
+	require.Equal(t, "http://localhost:8080/bug/"+bug1.Id().String(), feed.Entries[0].Link.Href)
+
+	var buf bytes.Buffer
+	require.NoError(t, feed.Encode(&buf))
+	require.Contains(t, buf.String(), `<feed xmlns="http://www.w3.org/2005/Atom">`)
+	require.Contains(t, buf.String(), "&lt;strong&gt;important&lt;/strong&gt;")
+
+	// the changes of all the bugs, the most recent first
+	feed, err = RecentFeed(backend, "http://localhost:8080")
+	require.NoError(t, err)
+	require.Len(t, feed.Entries, 4)
+	require.Equal(t, "first bug: René Descartes closed the bug", feed.Entries[0].Title)
+	require.Equal(t, "second bug: René Descartes opened the bug", feed.Entries[2].Title)
+	require.Equal(t, "1970-01-01T00:16:43Z", feed.Updated)
+}
@@ -0,0 +1,96 @@
+package site
+
+import "html/template"
+
+var templates = template.Must(template.New("site").Parse(`
+{{define "style"}}
+<style>
+  body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, Helvetica, Arial, sans-serif;
+         color: #24292e; margin: 0; }
+  header { background: #3f51b5; color: #fff; padding: 12px 24px; }
+  header a { color: #fff; text-decoration: none; font-size: 1.25em; }
+  main { max-width: 1000px; margin: 24px auto; padding: 0 16px; }
+  footer { max-width: 1000px; margin: 24px auto; padding: 0 16px; color: #6a737d; font-size: 0.85em; }
+  a { color: #0366d6; }
+  table { width: 100%; border-collapse: collapse; }
+  td { padding: 8px; border-top: 1px solid #e1e4e8; vertical-align: top; }
+  .status { font-weight: bold; }
+  .open { color: #28a745; }
+  .closed { color: #cb2431; }
+  .details { color: #6a737d; font-size: 0.85em; }
+  .label { display: inline-block; padding: 0 6px; margin-left: 4px; border-radius: 3px; font-size: 0.85em; }
+  .comment { border: 1px solid #e1e4e8; border-radius: 3px; margin: 16px 0; }
+  .comment-header { background: #f6f8fa; border-bottom: 1px solid #e1e4e8; padding: 8px 16px; }
+  .comment-body { padding: 0 16px; }
+  .event { margin: 16px; color: #586069; }
+  .sidebar { margin-bottom: 16px; }
+  pre { background: #f6f8fa; padding: 8px; overflow: auto; }
+</style>
+{{end}}
+
+{{define "labels"}}{{range .}}<span class="label" style="background: {{.Background}}; color: {{.Foreground}}">{{.Name}}</span>{{end}}{{end}}
+
+{{define "index"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Bugs</title>
+  {{template "style"}}
+</head>
+<body>
+<header><a href="index.html">git-bug</a></header>
+<main>
+  <p><span class="open">{{.Open}} open</span> · <span class="closed">{{.Closed}} closed</span></p>
+  <table>
+  {{range .Bugs}}
+    <tr>
+      <td class="status {{.Status}}">{{.Status}}</td>
+      <td>
+        <a href="{{.Link}}">{{.Title}}</a>{{template "labels" .Labels}}
+        <div class="details">{{.Id.Human}} opened {{.CreatedAt}} by {{.Author}}</div>
+      </td>
+      <td class="details">{{if .LenComments}}{{.LenComments}} comments{{end}}</td>
+    </tr>
+  {{else}}
+    <tr><td>No bugs.</td></tr>
+  {{end}}
+  </table>
+</main>
+<footer>Generated with git-bug on {{.Generated}}</footer>
+</body>
+</html>
+{{end}}
+
+{{define "bug"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>{{.Title}}</title>
+  {{template "style"}}
+</head>
+<body>
+<header><a href="../index.html">git-bug</a></header>
+<main>
+  <h1>{{.Title}} <span class="details">{{.Id.Human}}</span></h1>
+  <p class="sidebar">
+    <span class="status {{.Status}}">{{.Status}}</span>
+    · {{.Author}} opened this bug on {{.CreatedAt}}
+    {{if .Assignee}}· assigned to {{.Assignee}}{{end}}
+    {{if .Milestone}}· milestone {{.Milestone}}{{end}}
+    {{template "labels" .Labels}}
+  </p>
+  {{range .Timeline}}
+    {{if .Event}}
+    <div class="event" id="{{.Id}}"><b>{{.Author}}</b> {{.Event}} on {{.Date}}</div>
+    {{else}}
+    <div class="comment" id="{{.Id}}">
+      <div class="comment-header"><b>{{.Author}}</b> commented on {{.Date}}{{if .Edited}} (edited){{end}}</div>
+      <div class="comment-body">{{if .Message}}{{.Message}}{{else}}<p><i>No description provided.</i></p>{{end}}</div>
+    </div>
+    {{end}}
+  {{end}}
+</main>
+</body>
+</html>
+{{end}}
+`))
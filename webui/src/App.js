@@ -6,7 +6,11 @@ import React from 'react';
 import { Route, Switch } from 'react-router';
 import { Link } from 'react-router-dom';
 
+import { Avatar } from './Author';
 import BugQuery from './bug/BugQuery';
+import NewBug from './bug/NewBug';
+import CurrentIdentity from './identity/CurrentIdentity';
+import SelectIdentity from './identity/SelectIdentity';
 import ListQuery from './list/ListQuery';
 
 const useStyles = makeStyles(theme => ({
@@ -14,6 +18,17 @@ const useStyles = makeStyles(theme => ({
     ...theme.typography.h6,
     color: 'white',
     textDecoration: 'none',
+    flex: 1,
+  },
+  identity: {
+    ...theme.typography.body1,
+    color: 'white',
+    textDecoration: 'none',
+    display: 'flex',
+    alignItems: 'center',
+    '& > *:first-child': {
+      marginRight: theme.spacing(1),
+    },
   },
 }));
 
@@ -28,11 +43,21 @@ export default function App() {
           <Link to="/" className={classes.appTitle}>
             git-bug webui
           </Link>
+          <CurrentIdentity>
+            {identity => (
+              <Link to="/identity" className={classes.identity}>
+                {identity && <Avatar author={identity} />}
+                {identity ? identity.displayName : 'Select an identity'}
+              </Link>
+            )}
+          </CurrentIdentity>
         </Toolbar>
       </AppBar>
       <Switch>
         <Route path="/" exact component={ListQuery} />
+        <Route path="/new" exact component={NewBug} />
         <Route path="/bug/:id" exact component={BugQuery} />
+        <Route path="/identity" exact component={SelectIdentity} />
       </Switch>
     </>
   );
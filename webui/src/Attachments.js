@@ -0,0 +1,73 @@
+import { makeStyles } from '@material-ui/styles';
+import React from 'react';
+
+const useStyles = makeStyles(theme => ({
+  list: {
+    display: 'flex',
+    flexWrap: 'wrap',
+    alignItems: 'flex-end',
+    padding: '0.5rem 0',
+    '& > *': {
+      marginRight: theme.spacing(1),
+    },
+  },
+  image: {
+    maxWidth: 200,
+    maxHeight: 150,
+  },
+}));
+
+export const fileUrl = hash => `/gitfile/${hash}`;
+
+// uploadFile store a file in the repository and resolve with its hash and its
+// content type, the same type deciding if the file is displayed inline
+export async function uploadFile(file) {
+  const body = new FormData();
+  body.append('uploadfile', file);
+
+  const response = await fetch('/upload', { method: 'POST', body });
+  if (!response.ok) {
+    throw new Error((await response.text()).trim());
+  }
+  return response.json();
+}
+
+// markdownFor return the markdown referencing an uploaded file in a message
+export function markdownFor(name, { hash, contentType }) {
+  const link = `[${name}](${fileUrl(hash)})`;
+  return contentType.startsWith('image/') ? `!${link}` : link;
+}
+
+// Attachments display the files of a comment that its message doesn't
+// already reference, as the files attached outside of the web UI
+function Attachments({ files, message }) {
+  const classes = useStyles();
+  const remaining = files.filter(hash => !message.includes(fileUrl(hash)));
+
+  if (remaining.length === 0) {
+    return null;
+  }
+
+  // the type of the file is unknown here, the browser only display the images
+  // and the other files are shown as a link
+  return (
+    <div className={classes.list}>
+      {remaining.map(hash => (
+        <a
+          key={hash}
+          href={fileUrl(hash)}
+          target="_blank"
+          rel="noopener noreferrer"
+        >
+          <img
+            className={classes.image}
+            src={fileUrl(hash)}
+            alt={`attachment ${hash.slice(0, 7)}`}
+          />
+        </a>
+      ))}
+    </div>
+  );
+}
+
+export default Attachments;
@@ -1,13 +1,22 @@
+import React from 'react';
 import unified from 'unified';
 import parse from 'remark-parse';
 import html from 'remark-html';
 import remark2react from 'remark-react';
 
+// The images, including the attached ones, are scaled down to fit in the
+// comment and open in full size in a new tab
+const Image = ({ alt, ...props }) => (
+  <a href={props.src} target="_blank" rel="noopener noreferrer">
+    <img alt={alt} style={{ maxWidth: '100%' }} {...props} />
+  </a>
+);
+
 const Content = ({ markdown }) => {
   const processor = unified()
     .use(parse)
     .use(html)
-    .use(remark2react);
+    .use(remark2react, { remarkReactComponents: { img: Image } });
 
   return processor.processSync(markdown).contents;
 };
@@ -4,6 +4,10 @@ import gql from 'graphql-tag';
 import React from 'react';
 import Author from '../Author';
 import Date from '../Date';
+import RequireIdentity from '../identity/RequireIdentity';
+import CommentForm from './CommentForm';
+import EditTitle from './EditTitle';
+import LabelPicker from './LabelPicker';
 import TimelineQuery from './TimelineQuery';
 import Label from '../Label';
 
@@ -16,13 +20,6 @@ const useStyles = makeStyles(theme => ({
   header: {
     marginLeft: theme.spacing(1) + 40,
   },
-  title: {
-    ...theme.typography.h5,
-  },
-  id: {
-    ...theme.typography.subtitle1,
-    marginLeft: theme.spacing(1),
-  },
   container: {
     display: 'flex',
     marginBottom: theme.spacing(1),
@@ -36,17 +33,8 @@ const useStyles = makeStyles(theme => ({
     marginTop: theme.spacing(2),
     flex: '0 0 200px',
   },
-  labelList: {
-    listStyle: 'none',
-    padding: 0,
-    margin: 0,
-  },
-  label: {
-    marginTop: theme.spacing(1),
-    marginBottom: theme.spacing(1),
-    '& > *': {
-      display: 'block',
-    },
+  commentForm: {
+    marginTop: theme.spacing(2),
   },
 }));
 
@@ -55,8 +43,7 @@ function Bug({ bug }) {
   return (
     <main className={classes.main}>
       <div className={classes.header}>
-        <span className={classes.title}>{bug.title}</span>
-        <span className={classes.id}>{bug.humanId}</span>
+        <EditTitle bug={bug} />
 
         <Typography color={'textSecondary'}>
           <Author author={bug.author} />
@@ -68,16 +55,14 @@ function Bug({ bug }) {
       <div className={classes.container}>
         <div className={classes.timeline}>
           <TimelineQuery id={bug.id} />
+          <div className={classes.commentForm}>
+            <RequireIdentity>
+              <CommentForm bug={bug} />
+            </RequireIdentity>
+          </div>
         </div>
         <div className={classes.sidebar}>
-          <Typography variant={'subtitle1'}>Labels</Typography>
-          <ul className={classes.labelList}>
-            {bug.labels.map(l => (
-              <li className={classes.label} key={l.name}>
-                <Label label={l} key={l.name} />
-              </li>
-            ))}
-          </ul>
+          <LabelPicker bug={bug} />
         </div>
       </div>
     </main>
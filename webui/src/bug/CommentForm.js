@@ -0,0 +1,200 @@
+import Button from '@material-ui/core/Button';
+import Paper from '@material-ui/core/Paper';
+import Tab from '@material-ui/core/Tab';
+import Tabs from '@material-ui/core/Tabs';
+import TextField from '@material-ui/core/TextField';
+import { makeStyles } from '@material-ui/styles';
+import gql from 'graphql-tag';
+import React, { useState } from 'react';
+import { Mutation } from 'react-apollo';
+
+import Content from '../Content';
+import { markdownFor, uploadFile } from '../Attachments';
+
+const useStyles = makeStyles(theme => ({
+  main: {
+    marginLeft: theme.spacing(1) + 40,
+  },
+  body: {
+    padding: '0 1rem',
+    minHeight: 150,
+  },
+  preview: {
+    ...theme.typography.body2,
+  },
+  upload: {
+    marginRight: 'auto',
+  },
+  error: {
+    ...theme.typography.body2,
+    color: theme.palette.error.main,
+    alignSelf: 'center',
+  },
+  actions: {
+    display: 'flex',
+    justifyContent: 'flex-end',
+    padding: theme.spacing(1),
+    '& > *': {
+      marginLeft: theme.spacing(1),
+    },
+  },
+}));
+
+// The comments and the status changes are committed right away, the pending
+// operations being written in the same request
+const ADD_COMMENT = gql`
+  mutation AddComment($prefix: String!, $message: String!, $files: [Hash!]) {
+    addComment(input: { prefix: $prefix, message: $message, files: $files }) {
+      operation {
+        id
+      }
+    }
+    commitAsNeeded(input: { prefix: $prefix }) {
+      bug {
+        id
+      }
+    }
+  }
+`;
+
+const OPEN_BUG = gql`
+  mutation OpenBug($prefix: String!) {
+    openBug(input: { prefix: $prefix }) {
+      operation {
+        id
+      }
+    }
+    commitAsNeeded(input: { prefix: $prefix }) {
+      bug {
+        id
+      }
+    }
+  }
+`;
+
+const CLOSE_BUG = gql`
+  mutation CloseBug($prefix: String!) {
+    closeBug(input: { prefix: $prefix }) {
+      operation {
+        id
+      }
+    }
+    commitAsNeeded(input: { prefix: $prefix }) {
+      bug {
+        id
+      }
+    }
+  }
+`;
+
+const refetchQueries = ['GetBug', 'GetTimeline'];
+
+function CommentForm({ bug }) {
+  const classes = useStyles();
+  const [message, setMessage] = useState('');
+  const [tab, setTab] = useState(0);
+  const [files, setFiles] = useState([]);
+  const [uploading, setUploading] = useState(false);
+  const [uploadError, setUploadError] = useState(null);
+
+  // The files are referenced in the message where they are displayed, and
+  // given with the comment for them to be pushed along with the bug
+  const attach = async e => {
+    const selected = Array.from(e.target.files);
+    e.target.value = '';
+
+    setUploading(true);
+    setUploadError(null);
+    try {
+      for (const file of selected) {
+        const uploaded = await uploadFile(file);
+        setFiles(files => [...files, uploaded.hash]);
+        setMessage(message => {
+          const separator =
+            message === '' || message.endsWith('\n') ? '' : '\n';
+          return message + separator + markdownFor(file.name, uploaded) + '\n';
+        });
+      }
+    } catch (err) {
+      setUploadError(err.message);
+    }
+    setUploading(false);
+  };
+
+  const isOpen = bug.status === 'OPEN';
+
+  return (
+    <Paper elevation={1} className={classes.main}>
+      <Tabs value={tab} onChange={(e, value) => setTab(value)}>
+        <Tab label="Write" />
+        <Tab label="Preview" />
+      </Tabs>
+      <div className={classes.body}>
+        {tab === 0 ? (
+          <TextField
+            multiline
+            fullWidth
+            rows={6}
+            margin="normal"
+            placeholder="Leave a comment"
+            value={message}
+            onChange={e => setMessage(e.target.value)}
+          />
+        ) : (
+          <div className={classes.preview}>
+            <Content markdown={message || 'Nothing to preview'} />
+          </div>
+        )}
+      </div>
+      <div className={classes.actions}>
+        <Button
+          component="label"
+          className={classes.upload}
+          disabled={uploading}
+        >
+          {uploading ? 'Uploading...' : 'Attach files'}
+          <input type="file" multiple hidden onChange={attach} />
+        </Button>
+        {uploadError && <div className={classes.error}>{uploadError}</div>}
+        <Mutation
+          mutation={isOpen ? CLOSE_BUG : OPEN_BUG}
+          variables={{ prefix: bug.id }}
+          refetchQueries={refetchQueries}
+        >
+          {(setStatus, { loading }) => (
+            <Button
+              variant="outlined"
+              disabled={loading}
+              onClick={() => setStatus()}
+            >
+              {isOpen ? 'Close bug' : 'Reopen bug'}
+            </Button>
+          )}
+        </Mutation>
+        <Mutation
+          mutation={ADD_COMMENT}
+          variables={{ prefix: bug.id, message, files }}
+          refetchQueries={refetchQueries}
+          onCompleted={() => {
+            setMessage('');
+            setFiles([]);
+            setTab(0);
+          }}
+        >
+          {(addComment, { loading }) => (
+            <Button
+              variant="contained"
+              color="primary"
+              disabled={loading || uploading || message.trim() === ''}
+              onClick={() => addComment()}
+            >
+              Comment
+            </Button>
+          )}
+        </Mutation>
+      </div>
+    </Paper>
+  );
+}
+
+export default CommentForm;
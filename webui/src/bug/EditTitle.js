@@ -0,0 +1,112 @@
+import Button from '@material-ui/core/Button';
+import TextField from '@material-ui/core/TextField';
+import { makeStyles } from '@material-ui/styles';
+import gql from 'graphql-tag';
+import React, { useState } from 'react';
+import { Mutation } from 'react-apollo';
+
+import CurrentIdentity from '../identity/CurrentIdentity';
+
+const useStyles = makeStyles(theme => ({
+  main: {
+    display: 'flex',
+    alignItems: 'center',
+  },
+  title: {
+    ...theme.typography.h5,
+  },
+  id: {
+    ...theme.typography.subtitle1,
+    marginLeft: theme.spacing(1),
+    flex: 1,
+  },
+  input: {
+    flex: 1,
+    marginRight: theme.spacing(1),
+  },
+  button: {
+    marginLeft: theme.spacing(1),
+  },
+}));
+
+const SET_TITLE = gql`
+  mutation SetTitle($prefix: String!, $title: String!) {
+    setTitle(input: { prefix: $prefix, title: $title }) {
+      operation {
+        id
+      }
+    }
+    commitAsNeeded(input: { prefix: $prefix }) {
+      bug {
+        id
+      }
+    }
+  }
+`;
+
+function EditTitle({ bug }) {
+  const classes = useStyles();
+  const [editing, setEditing] = useState(false);
+  const [title, setTitle] = useState(bug.title);
+
+  if (!editing) {
+    return (
+      <div className={classes.main}>
+        <span className={classes.title}>{bug.title}</span>
+        <span className={classes.id}>{bug.humanId}</span>
+        <CurrentIdentity>
+          {identity =>
+            identity && (
+              <Button
+                size="small"
+                onClick={() => {
+                  setTitle(bug.title);
+                  setEditing(true);
+                }}
+              >
+                Edit
+              </Button>
+            )
+          }
+        </CurrentIdentity>
+      </div>
+    );
+  }
+
+  return (
+    <Mutation
+      mutation={SET_TITLE}
+      variables={{ prefix: bug.id, title }}
+      refetchQueries={['GetBug', 'GetTimeline']}
+      onCompleted={() => setEditing(false)}
+    >
+      {(setBugTitle, { loading }) => (
+        <div className={classes.main}>
+          <TextField
+            className={classes.input}
+            value={title}
+            autoFocus
+            onChange={e => setTitle(e.target.value)}
+          />
+          <Button
+            variant="contained"
+            color="primary"
+            className={classes.button}
+            disabled={loading || title.trim() === '' || title === bug.title}
+            onClick={() => setBugTitle()}
+          >
+            Save
+          </Button>
+          <Button
+            className={classes.button}
+            onClick={() => setEditing(false)}
+          >
+            Cancel
+          </Button>
+        </div>
+      )}
+    </Mutation>
+  );
+}
+
+export default EditTitle;
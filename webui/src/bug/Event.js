@@ -0,0 +1,60 @@
+import { makeStyles } from '@material-ui/styles';
+import React from 'react';
+import Author from '../Author';
+import Date from '../Date';
+
+const useStyles = makeStyles(theme => ({
+  main: {
+    ...theme.typography.body1,
+    display: 'flex',
+    alignItems: 'center',
+  },
+  // the icon is centered under the avatars of the comments
+  badge: {
+    flex: '0 0 40px',
+    display: 'flex',
+    justifyContent: 'center',
+    marginRight: theme.spacing(1),
+  },
+  icon: {
+    width: 32,
+    height: 32,
+    borderRadius: '50%',
+    display: 'flex',
+    alignItems: 'center',
+    justifyContent: 'center',
+    color: '#444',
+    backgroundColor: '#e6ebf1',
+    '& > svg': {
+      fontSize: 18,
+    },
+  },
+  author: {
+    fontWeight: 'bold',
+  },
+}));
+
+// A change of the bug other than a comment, displayed as a single line of
+// the timeline, between the comments
+function Event({ icon: Icon, color, author, date, children }) {
+  const classes = useStyles();
+  return (
+    <div className={classes.main}>
+      <div className={classes.badge}>
+        <span
+          className={classes.icon}
+          style={color && { color: 'white', backgroundColor: color }}
+        >
+          <Icon />
+        </span>
+      </div>
+      <div>
+        <Author author={author} className={classes.author} />
+        {children}
+        <Date date={date} />
+      </div>
+    </div>
+  );
+}
+
+export default Event;
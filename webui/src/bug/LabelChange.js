@@ -1,26 +1,14 @@
-import { makeStyles } from '@material-ui/styles';
+import LabelIcon from '@material-ui/icons/LabelOutlined';
 import gql from 'graphql-tag';
 import React from 'react';
 import Author from '../Author';
-import Date from '../Date';
 import Label from '../Label';
-
-const useStyles = makeStyles(theme => ({
-  main: {
-    ...theme.typography.body1,
-    marginLeft: theme.spacing(1) + 40,
-  },
-  author: {
-    fontWeight: 'bold',
-  },
-}));
+import Event from './Event';
 
 function LabelChange({ op }) {
   const { added, removed } = op;
-  const classes = useStyles();
   return (
-    <div className={classes.main}>
-      <Author author={op.author} className={classes.author} />
+    <Event icon={LabelIcon} author={op.author} date={op.date}>
       {added.length > 0 && <span> added the </span>}
       {added.map((label, index) => (
         <Label key={index} label={label} />
@@ -33,10 +21,9 @@ function LabelChange({ op }) {
       <span>
         {' '}
         label
-        {added.length + removed.length > 1 && 's'}{' '}
+        {added.length + removed.length > 1 && 's'}
       </span>
-      <Date date={op.date} />
-    </div>
+    </Event>
   );
 }
 
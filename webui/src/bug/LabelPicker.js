@@ -0,0 +1,146 @@
+import Checkbox from '@material-ui/core/Checkbox';
+import IconButton from '@material-ui/core/IconButton';
+import Menu from '@material-ui/core/Menu';
+import MenuItem from '@material-ui/core/MenuItem';
+import Typography from '@material-ui/core/Typography';
+import SettingsIcon from '@material-ui/icons/Settings';
+import { makeStyles } from '@material-ui/styles';
+import gql from 'graphql-tag';
+import React, { useState } from 'react';
+import { Mutation, Query } from 'react-apollo';
+import { Link } from 'react-router-dom';
+
+import CurrentIdentity from '../identity/CurrentIdentity';
+import Label from '../Label';
+import { labelUrl } from '../list/SavedViews';
+
+const useStyles = makeStyles(theme => ({
+  header: {
+    display: 'flex',
+    alignItems: 'center',
+    justifyContent: 'space-between',
+  },
+  labelList: {
+    listStyle: 'none',
+    padding: 0,
+    margin: 0,
+  },
+  label: {
+    marginTop: theme.spacing(1),
+    marginBottom: theme.spacing(1),
+    '& > *': {
+      display: 'block',
+    },
+  },
+}));
+
+export const VALID_LABELS = gql`
+  query ValidLabels {
+    defaultRepository {
+      validLabels(first: 100) {
+        nodes {
+          ...Label
+        }
+      }
+    }
+  }
+
+  ${Label.fragment}
+`;
+
+const CHANGE_LABELS = gql`
+  mutation ChangeLabels(
+    $prefix: String!
+    $added: [String!]
+    $removed: [String!]
+  ) {
+    changeLabels(input: { prefix: $prefix, added: $added, Removed: $removed }) {
+      operation {
+        id
+      }
+    }
+    commitAsNeeded(input: { prefix: $prefix }) {
+      bug {
+        id
+      }
+    }
+  }
+`;
+
+// The labels of a bug, with a menu to add the valid labels of the repository
+// or to remove them
+function LabelPicker({ bug }) {
+  const classes = useStyles();
+  const [anchor, setAnchor] = useState(null);
+
+  const has = name => bug.labels.some(l => l.name === name);
+
+  return (
+    <>
+      <div className={classes.header}>
+        <Typography variant={'subtitle1'}>Labels</Typography>
+        <CurrentIdentity>
+          {identity =>
+            identity && (
+              <IconButton size="small" onClick={e => setAnchor(e.target)}>
+                <SettingsIcon fontSize="small" />
+              </IconButton>
+            )
+          }
+        </CurrentIdentity>
+      </div>
+      <ul className={classes.labelList}>
+        {bug.labels.map(l => (
+          <li className={classes.label} key={l.name}>
+            <Link to={labelUrl(l.name)}>
+              <Label label={l} />
+            </Link>
+          </li>
+        ))}
+      </ul>
+      {anchor && (
+        <Query query={VALID_LABELS}>
+          {({ loading, error, data }) => {
+            if (loading || error) return null;
+
+            // the labels of the bug are not necessarily valid ones
+            const labels = [...data.defaultRepository.validLabels.nodes];
+            bug.labels
+              .filter(l => !labels.some(v => v.name === l.name))
+              .forEach(l => labels.push(l));
+
+            return (
+              <Mutation
+                mutation={CHANGE_LABELS}
+                refetchQueries={['GetBug', 'GetTimeline']}
+              >
+                {(changeLabels, { loading }) => (
+                  <Menu open anchorEl={anchor} onClose={() => setAnchor(null)}>
+                    {labels.map(l => (
+                      <MenuItem
+                        key={l.name}
+                        disabled={loading}
+                        onClick={() =>
+                          changeLabels({
+                            variables: has(l.name)
+                              ? { prefix: bug.id, removed: [l.name] }
+                              : { prefix: bug.id, added: [l.name] },
+                          })
+                        }
+                      >
+                        <Checkbox checked={has(l.name)} />
+                        <Label label={l} />
+                      </MenuItem>
+                    ))}
+                  </Menu>
+                )}
+              </Mutation>
+            );
+          }}
+        </Query>
+      )}
+    </>
+  );
+}
+
+export default LabelPicker;
@@ -6,6 +6,7 @@ import Author from '../Author';
 import { Avatar } from '../Author';
 import Date from '../Date';
 import Content from '../Content';
+import Attachments from '../Attachments';
 
 const useStyles = makeStyles(theme => ({
   author: {
@@ -62,6 +63,7 @@ function Message({ op }) {
         </header>
         <section className={classes.body}>
           <Content markdown={op.message} />
+          <Attachments files={op.files} message={op.message} />
         </section>
       </Paper>
     </article>
@@ -75,6 +77,7 @@ Message.createFragment = gql`
       ...authored
       edited
       message
+      files
     }
   }
 
@@ -88,6 +91,7 @@ Message.commentFragment = gql`
       ...authored
       edited
       message
+      files
     }
   }
 
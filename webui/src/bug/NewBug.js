@@ -0,0 +1,245 @@
+import Button from '@material-ui/core/Button';
+import Checkbox from '@material-ui/core/Checkbox';
+import IconButton from '@material-ui/core/IconButton';
+import Menu from '@material-ui/core/Menu';
+import MenuItem from '@material-ui/core/MenuItem';
+import Paper from '@material-ui/core/Paper';
+import TextField from '@material-ui/core/TextField';
+import Typography from '@material-ui/core/Typography';
+import SettingsIcon from '@material-ui/icons/Settings';
+import { makeStyles } from '@material-ui/styles';
+import gql from 'graphql-tag';
+import React, { useState } from 'react';
+import { ApolloConsumer, Query } from 'react-apollo';
+
+import Content from '../Content';
+import RequireIdentity from '../identity/RequireIdentity';
+import Label from '../Label';
+import { VALID_LABELS } from './LabelPicker';
+
+const useStyles = makeStyles(theme => ({
+  main: {
+    maxWidth: 800,
+    margin: 'auto',
+    marginTop: theme.spacing(4),
+  },
+  container: {
+    display: 'flex',
+  },
+  form: {
+    flex: 1,
+    marginRight: theme.spacing(2),
+  },
+  body: {
+    padding: '0 1rem',
+  },
+  preview: {
+    ...theme.typography.body2,
+    minHeight: 50,
+    borderTop: `1px solid ${theme.palette.divider}`,
+  },
+  sidebar: {
+    flex: '0 0 200px',
+  },
+  header: {
+    display: 'flex',
+    alignItems: 'center',
+    justifyContent: 'space-between',
+  },
+  labelList: {
+    listStyle: 'none',
+    padding: 0,
+    margin: 0,
+  },
+  label: {
+    marginTop: theme.spacing(1),
+    marginBottom: theme.spacing(1),
+    '& > *': {
+      display: 'block',
+    },
+  },
+  error: {
+    ...theme.typography.body2,
+    color: theme.palette.error.main,
+    alignSelf: 'center',
+  },
+  actions: {
+    display: 'flex',
+    justifyContent: 'flex-end',
+    padding: theme.spacing(1),
+    '& > *': {
+      marginLeft: theme.spacing(1),
+    },
+  },
+}));
+
+const NEW_BUG = gql`
+  mutation NewBug($title: String!, $message: String!) {
+    newBug(input: { title: $title, message: $message }) {
+      bug {
+        id
+      }
+    }
+  }
+`;
+
+// The labels are only added once the bug exists, and are committed with it
+const LABEL_BUG = gql`
+  mutation LabelNewBug($prefix: String!, $added: [String!]) {
+    changeLabels(input: { prefix: $prefix, added: $added }) {
+      operation {
+        id
+      }
+    }
+    commitAsNeeded(input: { prefix: $prefix }) {
+      bug {
+        id
+      }
+    }
+  }
+`;
+
+const COMMIT_BUG = gql`
+  mutation CommitNewBug($prefix: String!) {
+    commitAsNeeded(input: { prefix: $prefix }) {
+      bug {
+        id
+      }
+    }
+  }
+`;
+
+// A form to file a new bug, with a preview of its message and the labels to
+// apply to it
+function NewBug({ history }) {
+  const classes = useStyles();
+  const [title, setTitle] = useState('');
+  const [message, setMessage] = useState('');
+  const [labels, setLabels] = useState([]);
+  const [anchor, setAnchor] = useState(null);
+  const [submitting, setSubmitting] = useState(false);
+  const [error, setError] = useState(null);
+
+  const toggle = label =>
+    setLabels(labels =>
+      labels.some(l => l.name === label.name)
+        ? labels.filter(l => l.name !== label.name)
+        : [...labels, label]
+    );
+
+  const submit = async client => {
+    setSubmitting(true);
+    setError(null);
+    try {
+      const { data } = await client.mutate({
+        mutation: NEW_BUG,
+        variables: { title: title.trim(), message },
+      });
+      const id = data.newBug.bug.id;
+
+      await client.mutate(
+        labels.length > 0
+          ? {
+              mutation: LABEL_BUG,
+              variables: { prefix: id, added: labels.map(l => l.name) },
+            }
+          : { mutation: COMMIT_BUG, variables: { prefix: id } }
+      );
+
+      // the list of bugs is out of date
+      await client.resetStore();
+      history.push(`/bug/${id}`);
+    } catch (err) {
+      setError(err.message);
+      setSubmitting(false);
+    }
+  };
+
+  return (
+    <main className={classes.main}>
+      <RequireIdentity action="file a bug">
+        <div className={classes.container}>
+          <Paper elevation={1} className={classes.form}>
+            <div className={classes.body}>
+              <TextField
+                fullWidth
+                autoFocus
+                margin="normal"
+                label="Title"
+                value={title}
+                onChange={e => setTitle(e.target.value)}
+              />
+              <TextField
+                multiline
+                fullWidth
+                rows={8}
+                margin="normal"
+                placeholder="Describe the bug, in markdown"
+                value={message}
+                onChange={e => setMessage(e.target.value)}
+              />
+              <div className={classes.preview}>
+                <Content markdown={message || 'Nothing to preview'} />
+              </div>
+            </div>
+            <div className={classes.actions}>
+              {error && <div className={classes.error}>{error}</div>}
+              <ApolloConsumer>
+                {client => (
+                  <Button
+                    variant="contained"
+                    color="primary"
+                    disabled={submitting || title.trim() === ''}
+                    onClick={() => submit(client)}
+                  >
+                    Submit new bug
+                  </Button>
+                )}
+              </ApolloConsumer>
+            </div>
+          </Paper>
+          <div className={classes.sidebar}>
+            <div className={classes.header}>
+              <Typography variant={'subtitle1'}>Labels</Typography>
+              <IconButton size="small" onClick={e => setAnchor(e.target)}>
+                <SettingsIcon fontSize="small" />
+              </IconButton>
+            </div>
+            <ul className={classes.labelList}>
+              {labels.map(l => (
+                <li className={classes.label} key={l.name}>
+                  <Label label={l} />
+                </li>
+              ))}
+            </ul>
+            {anchor && (
+              <Query query={VALID_LABELS}>
+                {({ loading, error, data }) => {
+                  if (loading || error) return null;
+                  return (
+                    <Menu
+                      open
+                      anchorEl={anchor}
+                      onClose={() => setAnchor(null)}
+                    >
+                      {data.defaultRepository.validLabels.nodes.map(l => (
+                        <MenuItem key={l.name} onClick={() => toggle(l)}>
+                          <Checkbox
+                            checked={labels.some(s => s.name === l.name)}
+                          />
+                          <Label label={l} />
+                        </MenuItem>
+                      ))}
+                    </Menu>
+                  );
+                }}
+              </Query>
+            )}
+          </div>
+        </div>
+      </RequireIdentity>
+    </main>
+  );
+}
+
+export default NewBug;
@@ -1,24 +1,22 @@
-import { makeStyles } from '@material-ui/styles';
+import CheckCircleIcon from '@material-ui/icons/CheckCircleOutline';
+import ErrorIcon from '@material-ui/icons/ErrorOutline';
 import gql from 'graphql-tag';
 import React from 'react';
 import Author from '../Author';
-import Date from '../Date';
+import Event from './Event';
 
-const useStyles = makeStyles(theme => ({
-  main: {
-    ...theme.typography.body1,
-    marginLeft: theme.spacing(1) + 40,
-  },
-}));
+// the same colors as the status of the bugs in the list
+const icons = {
+  OPEN: { icon: ErrorIcon, color: '#28a745' },
+  CLOSED: { icon: CheckCircleIcon, color: '#cb2431' },
+};
 
 function SetStatus({ op }) {
-  const classes = useStyles();
+  const { icon, color } = icons[op.status];
   return (
-    <div className={classes.main}>
-      <Author author={op.author} bold />
-      <span> {op.status.toLowerCase()} this</span>
-      <Date date={op.date} />
-    </div>
+    <Event icon={icon} color={color} author={op.author} date={op.date}>
+      <span> {op.status === 'OPEN' ? 'reopened' : 'closed'} this</span>
+    </Event>
   );
 }
 
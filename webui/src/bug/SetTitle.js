@@ -1,30 +1,28 @@
+import EditIcon from '@material-ui/icons/Edit';
 import { makeStyles } from '@material-ui/styles';
 import gql from 'graphql-tag';
 import React from 'react';
 import Author from '../Author';
-import Date from '../Date';
+import Event from './Event';
 
-const useStyles = makeStyles(theme => ({
-  main: {
-    ...theme.typography.body1,
-    marginLeft: theme.spacing(1) + 40,
+const useStyles = makeStyles({
+  was: {
+    textDecoration: 'line-through',
   },
   bold: {
     fontWeight: 'bold',
   },
-}));
+});
 
 function SetTitle({ op }) {
   const classes = useStyles();
   return (
-    <div className={classes.main}>
-      <Author author={op.author} className={classes.bold} />
+    <Event icon={EditIcon} author={op.author} date={op.date}>
       <span> changed the title from </span>
-      <span className={classes.bold}>{op.was}</span>
+      <span className={classes.was}>{op.was}</span>
       <span> to </span>
       <span className={classes.bold}>{op.title}</span>
-      <Date date={op.date} />
-    </div>
+    </Event>
   );
 }
 
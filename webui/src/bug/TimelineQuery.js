@@ -9,7 +9,7 @@ import Timeline from './Timeline';
 import Message from './Message';
 
 const QUERY = gql`
-  query($id: String!, $first: Int = 10, $after: String) {
+  query GetTimeline($id: String!, $first: Int = 10, $after: String) {
     defaultRepository {
       bug(prefix: $id) {
         timeline(first: $first, after: $after) {
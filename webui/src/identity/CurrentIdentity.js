@@ -0,0 +1,33 @@
+import gql from 'graphql-tag';
+import React from 'react';
+import { Query } from 'react-apollo';
+
+const QUERY = gql`
+  query CurrentIdentity {
+    defaultRepository {
+      userIdentity {
+        id
+        humanId
+        name
+        email
+        displayName
+        avatarUrl
+      }
+    }
+  }
+`;
+
+// Render the children with the identity the user act as, or null if none has
+// been selected yet
+const CurrentIdentity = ({ children }) => (
+  <Query query={QUERY}>
+    {({ loading, error, data }) => {
+      if (loading || error) return null;
+      return children(data.defaultRepository.userIdentity);
+    }}
+  </Query>
+);
+
+CurrentIdentity.query = QUERY;
+
+export default CurrentIdentity;
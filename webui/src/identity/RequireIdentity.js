@@ -0,0 +1,23 @@
+import Typography from '@material-ui/core/Typography';
+import React from 'react';
+import { Link } from 'react-router-dom';
+
+import CurrentIdentity from './CurrentIdentity';
+
+// Render the children only once the user has selected an identity, as every
+// change to a bug is authored
+const RequireIdentity = ({ children, action = 'edit this bug' }) => (
+  <CurrentIdentity>
+    {identity => {
+      if (identity) return children;
+      return (
+        <Typography color={'textSecondary'}>
+          <Link to="/identity">Select an identity</Link>
+          {` to ${action}.`}
+        </Typography>
+      );
+    }}
+  </CurrentIdentity>
+);
+
+export default RequireIdentity;
@@ -0,0 +1,104 @@
+import CircularProgress from '@material-ui/core/CircularProgress';
+import List from '@material-ui/core/List';
+import ListItem from '@material-ui/core/ListItem';
+import ListItemAvatar from '@material-ui/core/ListItemAvatar';
+import ListItemText from '@material-ui/core/ListItemText';
+import Typography from '@material-ui/core/Typography';
+import { makeStyles } from '@material-ui/styles';
+import gql from 'graphql-tag';
+import React from 'react';
+import { Mutation, Query } from 'react-apollo';
+
+import { Avatar } from '../Author';
+import CurrentIdentity from './CurrentIdentity';
+
+const useStyles = makeStyles(theme => ({
+  main: {
+    maxWidth: 600,
+    margin: 'auto',
+    marginTop: theme.spacing(4),
+  },
+}));
+
+const QUERY = gql`
+  query AllIdentities {
+    defaultRepository {
+      allIdentities(first: 100) {
+        nodes {
+          id
+          humanId
+          email
+          displayName
+          avatarUrl
+        }
+      }
+    }
+  }
+`;
+
+const SET_USER_IDENTITY = gql`
+  mutation SetUserIdentity($prefix: String!) {
+    setUserIdentity(input: { prefix: $prefix }) {
+      identity {
+        id
+      }
+    }
+  }
+`;
+
+function SelectIdentity({ history }) {
+  const classes = useStyles();
+
+  return (
+    <main className={classes.main}>
+      <Typography variant={'h5'}>Select your identity</Typography>
+      <Typography color={'textSecondary'}>
+        Your changes to the bugs are authored by this identity. A new one can
+        be created with "git bug user create".
+      </Typography>
+      <CurrentIdentity>
+        {current => (
+          <Query query={QUERY}>
+            {({ loading, error, data }) => {
+              if (loading) return <CircularProgress />;
+              if (error) return <p>Error: {error}</p>;
+              return (
+                <Mutation
+                  mutation={SET_USER_IDENTITY}
+                  refetchQueries={[{ query: CurrentIdentity.query }]}
+                  awaitRefetchQueries={true}
+                  onCompleted={() => history.goBack()}
+                >
+                  {setUserIdentity => (
+                    <List>
+                      {data.defaultRepository.allIdentities.nodes.map(i => (
+                        <ListItem
+                          button
+                          key={i.id}
+                          selected={current !== null && current.id === i.id}
+                          onClick={() =>
+                            setUserIdentity({ variables: { prefix: i.id } })
+                          }
+                        >
+                          <ListItemAvatar>
+                            <Avatar author={i} />
+                          </ListItemAvatar>
+                          <ListItemText
+                            primary={i.displayName}
+                            secondary={i.email || i.humanId}
+                          />
+                        </ListItem>
+                      ))}
+                    </List>
+                  )}
+                </Mutation>
+              );
+            }}
+          </Query>
+        )}
+      </CurrentIdentity>
+    </main>
+  );
+}
+
+export default SelectIdentity;
@@ -10,6 +10,7 @@ import { Link } from 'react-router-dom';
 import Date from '../Date';
 import Label from '../Label';
 import Author from '../Author';
+import { labelUrl } from './SavedViews';
 
 const Open = ({ className }) => (
   <Tooltip title="Open">
@@ -76,18 +77,20 @@ function BugRow({ bug }) {
       <TableCell className={classes.cell}>
         <Status status={bug.status} className={classes.status} />
         <div className={classes.expand}>
-          <Link to={'bug/' + bug.humanId}>
-            <div className={classes.expand}>
+          <div className={classes.expand}>
+            <Link to={'bug/' + bug.humanId}>
               <span className={classes.title}>{bug.title}</span>
-              {bug.labels.length > 0 && (
-                <span className={classes.labels}>
-                  {bug.labels.map(l => (
-                    <Label key={l.name} label={l} />
-                  ))}
-                </span>
-              )}
-            </div>
-          </Link>
+            </Link>
+            {bug.labels.length > 0 && (
+              <span className={classes.labels}>
+                {bug.labels.map(l => (
+                  <Link key={l.name} to={labelUrl(l.name)}>
+                    <Label label={l} />
+                  </Link>
+                ))}
+              </span>
+            )}
+          </div>
           <div className={classes.details}>
             {bug.humanId} opened
             <Date date={bug.createdAt} />
@@ -8,11 +8,6 @@ import React from 'react';
 import BugRow from './BugRow';
 
 const useStyles = makeStyles(theme => ({
-  main: {
-    maxWidth: 600,
-    margin: 'auto',
-    marginTop: theme.spacing(4),
-  },
   pagination: {
     ...theme.typography.overline,
     display: 'flex',
@@ -25,7 +20,7 @@ function List({ bugs, nextPage, prevPage }) {
   const classes = useStyles();
   const { hasNextPage, hasPreviousPage } = bugs.pageInfo;
   return (
-    <main className={classes.main}>
+    <>
       <Table className={classes.table}>
         <TableBody>
           {bugs.edges.map(({ cursor, node }) => (
@@ -43,7 +38,7 @@ function List({ bugs, nextPage, prevPage }) {
           <KeyboardArrowRight />
         </IconButton>
       </div>
-    </main>
+    </>
   );
 }
 
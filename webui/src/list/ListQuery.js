@@ -1,19 +1,59 @@
 // @flow
+import Button from '@material-ui/core/Button';
 import CircularProgress from '@material-ui/core/CircularProgress';
+import TextField from '@material-ui/core/TextField';
+import { makeStyles } from '@material-ui/styles';
 import gql from 'graphql-tag';
 import React, { useState } from 'react';
 import { Query } from 'react-apollo';
+import { Link } from 'react-router-dom';
 import BugRow from './BugRow';
 import List from './List';
+import SavedViews, { listUrl } from './SavedViews';
+
+const useStyles = makeStyles(theme => ({
+  main: {
+    maxWidth: 800,
+    margin: 'auto',
+    marginTop: theme.spacing(4),
+    display: 'flex',
+  },
+  sidebar: {
+    flex: '0 0 180px',
+    marginRight: theme.spacing(2),
+  },
+  content: {
+    flex: 1,
+  },
+  header: {
+    display: 'flex',
+    alignItems: 'center',
+    '& > *:first-child': {
+      flex: 1,
+      marginRight: theme.spacing(2),
+    },
+  },
+  error: {
+    ...theme.typography.body2,
+    color: theme.palette.error.main,
+  },
+}));
 
 const QUERY = gql`
-  query($first: Int, $last: Int, $after: String, $before: String) {
+  query(
+    $first: Int
+    $last: Int
+    $after: String
+    $before: String
+    $query: String
+  ) {
     defaultRepository {
       bugs: allBugs(
         first: $first
         last: $last
         after: $after
         before: $before
+        query: $query
       ) {
         totalCount
         edges {
@@ -35,7 +75,27 @@ const QUERY = gql`
   ${BugRow.fragment}
 `;
 
-function ListQuery() {
+function Search({ query, onSearch }) {
+  const [value, setValue] = useState(query);
+  return (
+    <form
+      onSubmit={e => {
+        e.preventDefault();
+        onSearch(value.trim());
+      }}
+    >
+      <TextField
+        fullWidth
+        placeholder="status:open label:bug sort:edit"
+        value={value}
+        onChange={e => setValue(e.target.value)}
+      />
+    </form>
+  );
+}
+
+function BugList({ query }) {
+  const classes = useStyles();
   const [page, setPage] = useState({ first: 10, after: null });
 
   const perPage = page.first || page.last;
@@ -45,10 +105,10 @@ function ListQuery() {
     setPage({ last: perPage, before: pageInfo.startCursor });
 
   return (
-    <Query query={QUERY} variables={page}>
+    <Query query={QUERY} variables={{ ...page, query: query || null }}>
       {({ loading, error, data }) => {
         if (loading) return <CircularProgress />;
-        if (error) return <p>Error: {error}</p>;
+        if (error) return <p className={classes.error}>{error.message}</p>;
         const bugs = data.defaultRepository.bugs;
         return (
           <List
@@ -62,4 +122,37 @@ function ListQuery() {
   );
 }
 
+// The list of bugs matching the query in the url, as selected in the saved
+// views or typed in the search field
+function ListQuery({ location, history }) {
+  const classes = useStyles();
+  const query = new URLSearchParams(location.search).get('q') || '';
+
+  return (
+    <main className={classes.main}>
+      <aside className={classes.sidebar}>
+        <SavedViews current={query} />
+      </aside>
+      <div className={classes.content}>
+        <div className={classes.header}>
+          <Search
+            key={query}
+            query={query}
+            onSearch={q => history.push(listUrl(q))}
+          />
+          <Button
+            variant="contained"
+            color="primary"
+            component={Link}
+            to="/new"
+          >
+            New bug
+          </Button>
+        </div>
+        <BugList key={query} query={query} />
+      </div>
+    </main>
+  );
+}
+
 export default ListQuery;
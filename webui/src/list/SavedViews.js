@@ -0,0 +1,65 @@
+import List from '@material-ui/core/List';
+import ListItem from '@material-ui/core/ListItem';
+import ListItemText from '@material-ui/core/ListItemText';
+import ListSubheader from '@material-ui/core/ListSubheader';
+import gql from 'graphql-tag';
+import React from 'react';
+import { Query } from 'react-apollo';
+import { Link } from 'react-router-dom';
+
+const QUERY = gql`
+  query SavedQueries {
+    defaultRepository {
+      savedQueries {
+        name
+        query
+      }
+    }
+  }
+`;
+
+export const listUrl = query =>
+  query ? '/?q=' + encodeURIComponent(query) : '/';
+
+// the labels with spaces are quoted to be a single term of the query
+export const labelUrl = name =>
+  listUrl(/\s/.test(name) ? `label:"${name}"` : `label:${name}`);
+
+// The queries saved in the repository, with "git bug query save", as views of
+// the list of bugs
+function SavedViews({ current }) {
+  return (
+    <Query query={QUERY}>
+      {({ loading, error, data }) => {
+        if (loading || error) return null;
+        const saved = data.defaultRepository.savedQueries;
+        return (
+          <List dense subheader={<ListSubheader>Views</ListSubheader>}>
+            <ListItem
+              button
+              component={Link}
+              to={listUrl('')}
+              selected={current === ''}
+            >
+              <ListItemText primary="All bugs" />
+            </ListItem>
+            {saved.map(q => (
+              <ListItem
+                button
+                key={q.name}
+                component={Link}
+                to={listUrl('@' + q.name)}
+                selected={current === '@' + q.name}
+                title={q.query}
+              >
+                <ListItemText primary={q.name} />
+              </ListItem>
+            ))}
+          </List>
+        );
+      }}
+    </Query>
+  );
+}
+
+export default SavedViews;